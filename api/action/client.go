@@ -4,6 +4,10 @@
 package action
 
 import (
+	"fmt"
+	"io"
+	"net/url"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
@@ -48,6 +52,56 @@ func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return results, err
 }
 
+// EnqueueOperation queues up an action to be run on a number of units
+// as a single operation, returning the operation and the actions it
+// has started so far.
+func (c *Client) EnqueueOperation(arg params.RunOperationArgs) (params.OperationResult, error) {
+	result := params.OperationResult{}
+	if err := c.facade.FacadeCall("EnqueueOperation", arg, &result); err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.OperationResult{}, result.Error
+	}
+	return result, nil
+}
+
+// Operations takes a list of operation ids, and returns each operation
+// along with the actions it has started so far.
+func (c *Client) Operations(arg params.OperationIDs) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	err := c.facade.FacadeCall("Operations", arg, &results)
+	return results, err
+}
+
+// AddActionSchedules adds schedules on which the controller runs
+// actions, returning an error result for each schedule.
+func (c *Client) AddActionSchedules(arg params.ActionScheduleArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("AddActionSchedules", arg, &results)
+	return results, err
+}
+
+// ActionSchedules returns the action schedules in the model.
+func (c *Client) ActionSchedules() ([]params.ActionSchedule, error) {
+	result := params.ActionSchedulesResult{}
+	if err := c.facade.FacadeCall("ActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Schedules, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// names, returning an error result for each schedule.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("RemoveActionSchedules", arg, &results)
+	return results, err
+}
+
 // FindActionsByNames takes a list of action names and returns actions for
 // every name.
 func (c *Client) FindActionsByNames(arg params.FindActionsByNames) (params.ActionsByNames, error) {
@@ -118,3 +172,47 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// ActionOutputStream streams the messages and output of a running
+// action from the API's action stream endpoint.
+type ActionOutputStream interface {
+	io.Closer
+
+	// Next returns the next batch of messages logged by the action.
+	// The last batch has Done set, along with the action's final
+	// status; calling Next after that returns an error.
+	Next() (params.ActionStreamRecords, error)
+}
+
+// StreamActionOutput opens a websocket to the API's action stream
+// endpoint for the action with the given id. The stream ends once the
+// action has finished. It requires Action facade v5 or later.
+func (c *Client) StreamActionOutput(id string) (ActionOutputStream, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("StreamActionOutput() (need V5+)")
+	}
+	path := fmt.Sprintf("/actions/%s/stream", url.PathEscape(id))
+	stream, err := c.facade.RawAPICaller().ConnectStream(path, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot stream output of action %q", id)
+	}
+	return &actionOutputStream{stream: stream}, nil
+}
+
+type actionOutputStream struct {
+	stream base.Stream
+}
+
+// Next is part of the ActionOutputStream interface.
+func (s *actionOutputStream) Next() (params.ActionStreamRecords, error) {
+	var result params.ActionStreamRecords
+	if err := s.stream.ReadJSON(&result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Close is part of the ActionOutputStream interface.
+func (s *actionOutputStream) Close() error {
+	return s.stream.Close()
+}
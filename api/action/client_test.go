@@ -12,6 +12,8 @@ import (
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type actionSuite struct {
@@ -127,3 +129,138 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
+	args := params.RunOperationArgs{
+		Receivers: []string{"application-foo"},
+		Name:      "backup",
+		Parallel:  2,
+	}
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "EnqueueOperation")
+			c.Assert(paramsIn, jc.DeepEquals, args)
+			result := resp.(*params.OperationResult)
+			result.OperationID = "1"
+			result.Status = "running"
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.EnqueueOperation(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OperationResult{OperationID: "1", Status: "running"})
+}
+
+func (s *actionSuite) TestEnqueueOperationError(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			result := resp.(*params.OperationResult)
+			result.Error = &params.Error{Message: "boom"}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.EnqueueOperation(params.RunOperationArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSuite) TestAddActionSchedules(c *gc.C) {
+	args := params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Name:       "nightly",
+			Receiver:   "application-foo",
+			ActionName: "backup",
+			Cron:       "@daily",
+		}},
+	}
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "AddActionSchedules")
+			c.Assert(paramsIn, jc.DeepEquals, args)
+			result := resp.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.AddActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Combine(), jc.ErrorIsNil)
+}
+
+func (s *actionSuite) TestActionSchedules(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "ActionSchedules")
+			result := resp.(*params.ActionSchedulesResult)
+			result.Schedules = []params.ActionSchedule{{Name: "nightly"}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	schedules, err := s.client.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []params.ActionSchedule{{Name: "nightly"}})
+}
+
+func (s *actionSuite) TestRemoveActionSchedules(c *gc.C) {
+	args := params.ActionScheduleNames{Names: []string{"nightly"}}
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "RemoveActionSchedules")
+			c.Assert(paramsIn, jc.DeepEquals, args)
+			result := resp.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.RemoveActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Combine(), gc.ErrorMatches, "boom")
+}
+
+func (s *actionSuite) TestStreamActionOutput(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := m.EnqueueAction(unit.UnitTag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Log("starting"), jc.ErrorIsNil)
+	c.Assert(a.LogOutput(state.ActionStdout, []string{"hello"}), jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stream, err := s.client.StreamActionOutput(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	defer stream.Close()
+
+	var messages []string
+	for {
+		records, err := stream.Next()
+		c.Assert(err, jc.ErrorIsNil)
+		for _, m := range records.Messages {
+			messages = append(messages, m.Stream+":"+m.Message)
+		}
+		if records.Done {
+			c.Assert(records.Status, gc.Equals, "completed")
+			break
+		}
+	}
+	c.Assert(messages, jc.DeepEquals, []string{":starting", "stdout:hello"})
+}
+
+func (s *actionSuite) TestStreamActionOutputNotFound(c *gc.C) {
+	_, err := s.client.StreamActionOutput("42")
+	c.Assert(err, gc.ErrorMatches, `cannot stream output of action "42": .*not found`)
+}
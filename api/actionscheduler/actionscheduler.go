@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// API provides access to the ActionScheduler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionScheduler facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, actionSchedulerFacade)
+	return &API{facade: facadeCaller}
+}
+
+// WatchActionSchedules returns a watcher that notifies
+// of changes to the model's action schedules.
+func (api *API) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := api.facade.FacadeCall("WatchActionSchedules", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// RunDueActionSchedules enqueues the actions of every schedule that is
+// due, and returns the time at which the next schedule is due. A zero
+// time indicates that the model has no action schedules.
+func (api *API) RunDueActionSchedules() (time.Time, error) {
+	var result params.ActionScheduleRunResult
+	err := api.facade.FacadeCall("RunDueActionSchedules", nil, &result)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return time.Time{}, errors.Trace(result.Error)
+	}
+	return result.NextRun, nil
+}
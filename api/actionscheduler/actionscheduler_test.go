@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type actionSchedulerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) TestWatchActionSchedulesError(c *gc.C) {
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		c.Check(request, gc.Equals, "WatchActionSchedules")
+		return errors.New("blam pow")
+	})
+	api := actionscheduler.NewAPI(caller)
+
+	w, err := api.WatchActionSchedules()
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "blam pow")
+}
+
+func (s *actionSchedulerSuite) TestWatchActionSchedulesResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "no watching"},
+		}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller)
+
+	w, err := api.WatchActionSchedules()
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "no watching")
+}
+
+func (s *actionSchedulerSuite) TestRunDueActionSchedules(c *gc.C) {
+	next := time.Date(2020, 4, 1, 2, 0, 0, 0, time.UTC)
+	caller := apiCaller(c, func(request string, _, result interface{}) error {
+		c.Check(request, gc.Equals, "RunDueActionSchedules")
+		*(result.(*params.ActionScheduleRunResult)) = params.ActionScheduleRunResult{NextRun: next}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller)
+
+	result, err := api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, next)
+}
+
+func (s *actionSchedulerSuite) TestRunDueActionSchedulesResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*(result.(*params.ActionScheduleRunResult)) = params.ActionScheduleRunResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller)
+
+	_, err := api.RunDueActionSchedules()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ActionScheduler")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
@@ -588,6 +588,13 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 	// some tests call dialAPI directly.
 	if opts.DialWebsocket == nil {
 		opts.DialWebsocket = gorillaDialWebsocket
+		if info.ProxyURL != "" {
+			proxyURL, err := url.Parse(info.ProxyURL)
+			if err != nil {
+				return nil, errors.Annotate(err, "invalid proxy URL")
+			}
+			opts.DialWebsocket = proxiedDialWebsocket(proxyURL)
+		}
 	}
 	if opts.IPAddrResolver == nil {
 		opts.IPAddrResolver = net.DefaultResolver
@@ -631,6 +638,23 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 // is used only for TLS verification when tlsConfig.ServerName
 // is empty.
 func gorillaDialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+	return dialWebsocketWithProxy(ctx, urlStr, tlsConfig, ipAddr, proxy.DefaultConfig.GetProxy)
+}
+
+// proxiedDialWebsocket returns a websocket dial function which always
+// connects through the HTTP proxy at proxyURL, regardless of the proxy
+// settings of the process. The proxy sees only the CONNECT request;
+// TLS and the API login happen end to end with the API server.
+func proxiedDialWebsocket(proxyURL *url.URL) func(context.Context, string, *tls.Config, string) (jsoncodec.JSONConn, error) {
+	return func(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+		return dialWebsocketWithProxy(ctx, urlStr, tlsConfig, ipAddr, http.ProxyURL(proxyURL))
+	}
+}
+
+func dialWebsocketWithProxy(
+	ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string,
+	getProxy func(*http.Request) (*url.URL, error),
+) (jsoncodec.JSONConn, error) {
 	url, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -648,7 +672,7 @@ func gorillaDialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Con
 			}
 			return netDialer.DialContext(ctx, netw, addr)
 		},
-		Proxy:            proxy.DefaultConfig.GetProxy,
+		Proxy:            getProxy,
 		HandshakeTimeout: 45 * time.Second,
 		TLSClientConfig:  tlsConfig,
 		// In order to deal with the remote side not handling message
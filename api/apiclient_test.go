@@ -128,6 +128,42 @@ func (s *apiclientSuite) TestDialAPIWithProxy(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "unable to connect to API: I'm a teapot")
 }
 
+func (s *apiclientSuite) TestDialAPIWithProxyURL(c *gc.C) {
+	info := s.APIInfo(c)
+	opts := api.DialOpts{IPAddrResolver: apitesting.IPAddrResolverMap{
+		"testing.invalid": {"0.1.1.1"},
+	}}
+	fakeAddr := "testing.invalid:1234"
+
+	// The proxy in the API info is used in place of the
+	// process proxy configuration.
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "CONNECT" {
+			http.Error(w, fmt.Sprintf("invalid method %s", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Host != fakeAddr {
+			http.Error(w, fmt.Sprintf("unexpected host %s", r.URL.Host), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "🍵", http.StatusTeapot)
+	}
+	proxyServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer proxyServer.Close()
+
+	info.Addrs = []string{fakeAddr}
+	info.ProxyURL = proxyServer.URL
+	_, _, err := api.DialAPI(info, opts)
+	c.Assert(err, gc.ErrorMatches, "unable to connect to API: I'm a teapot")
+}
+
+func (s *apiclientSuite) TestOpenInvalidProxyURL(c *gc.C) {
+	info := s.APIInfo(c)
+	info.ProxyURL = "socks5://relay.example.com:1080"
+	_, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, gc.ErrorMatches, `validating info for opening an API connection: proxy URL "socks5://relay.example.com:1080" not valid`)
+}
+
 func (s *apiclientSuite) TestDialAPIMultipleError(c *gc.C) {
 	var addrs []string
 
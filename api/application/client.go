@@ -779,6 +779,35 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", args, nil)
 }
 
+// ExposeEndpoints exposes the given endpoints of the application,
+// restricting access to each endpoint to the specified spaces and
+// CIDRs. The settings are merged with any existing endpoint expose
+// settings for the application.
+func (c *Client) ExposeEndpoints(application string, exposed map[string]params.ExposedEndpoint) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("ExposeEndpoints for Application facade v%v", apiVersion)
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposed,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
+// UnexposeEndpoints removes the expose settings of the given endpoints
+// of the application. If no endpoint expose settings remain, the
+// application is unexposed.
+func (c *Client) UnexposeEndpoints(application string, endpoints []string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("UnexposeEndpoints for Application facade v%v", apiVersion)
+	}
+	args := params.ApplicationUnexpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Unexpose", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(branchName, application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
@@ -880,6 +909,7 @@ func (c *Client) Consume(arg crossmodel.ConsumeApplicationArgs) (string, error)
 			Alias:         arg.ControllerInfo.Alias,
 			Addrs:         arg.ControllerInfo.Addrs,
 			CACert:        arg.ControllerInfo.CACert,
+			RelayProxy:    arg.ControllerInfo.RelayProxy,
 		}
 	}
 	err := c.facade.FacadeCall("Consume", args, &consumeRes)
@@ -1000,3 +1030,93 @@ func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params
 	}
 	return out.Results, nil
 }
+
+// UnitsInfo retrieves units information, including the state stored
+// for each unit by its charm.
+func (c *Client) UnitsInfo(units []names.UnitTag) ([]params.UnitInfoResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 12 {
+		return nil, errors.NotSupportedf("UnitsInfo for Application facade v%v", apiVersion)
+	}
+	all := make([]params.Entity, len(units))
+	for i, one := range units {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.UnitInfoResults
+	err := c.facade.FacadeCall("UnitsInfo", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), resultsLen)
+	}
+	return out.Results, nil
+}
+
+// PauseUnits stops the agents of the given units from running hooks
+// until the units are resumed, recording the given reason.
+func (c *Client) PauseUnits(units []string, reason string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return errors.NotSupportedf("PauseUnits for Application facade v%v", apiVersion)
+	}
+	entities, err := unitEntities(units)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args := params.PauseUnitsArgs{
+		Entities: entities,
+		Reason:   reason,
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("PauseUnits", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.Combine())
+}
+
+// ResumeUnits lets the agents of the given paused units run hooks
+// again.
+func (c *Client) ResumeUnits(units []string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return errors.NotSupportedf("ResumeUnits for Application facade v%v", apiVersion)
+	}
+	entities, err := unitEntities(units)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResumeUnits", params.Entities{Entities: entities}, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.Combine())
+}
+
+func unitEntities(units []string) ([]params.Entity, error) {
+	entities := make([]params.Entity, len(units))
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return nil, errors.NotValidf("unit name %q", unit)
+		}
+		entities[i].Tag = names.NewUnitTag(unit).String()
+	}
+	return entities, nil
+}
+
+// MergeBindings merges the supplied endpoint bindings with the existing
+// bindings of the specified application.
+func (c *Client) MergeBindings(application string, bindings map[string]string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 11 {
+		return errors.NotSupportedf("MergeBindings for Application facade v%v", apiVersion)
+	}
+	args := params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Bindings:       bindings,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("MergeBindings", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -1559,3 +1559,217 @@ func (s *applicationSuite) TestApplicationsInfoResultMismatch(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestMergeBindings(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "MergeBindings")
+				c.Assert(a, jc.DeepEquals, params.ApplicationMergeBindingsArgs{
+					Args: []params.ApplicationMergeBindings{{
+						ApplicationTag: "application-foo",
+						Bindings:       map[string]string{"db": "alpha"},
+					}},
+				})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 11,
+	})
+	err := client.MergeBindings("foo", map[string]string{"db": "alpha"})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestMergeBindingsPriorV11(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %q", request)
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+	err := client.MergeBindings("foo", map[string]string{"db": "alpha"})
+	c.Assert(err, gc.ErrorMatches, "MergeBindings for Application facade v10 not supported")
+}
+
+func (s *applicationSuite) TestUnitsInfoPriorV12(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.UnitsInfo(nil)
+	c.Assert(err, gc.ErrorMatches, "UnitsInfo for Application facade v11 not supported")
+}
+
+func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(request, gc.Equals, "UnitsInfo")
+			c.Check(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-foo-0"}}})
+			result, ok := response.(*params.UnitInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.UnitInfoResult{{
+				Result: &params.UnitInfo{Tag: "unit-foo-0", State: map[string]string{"seen": "yes"}},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.UnitsInfo([]names.UnitTag{names.NewUnitTag("foo/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.UnitInfoResult{{
+		Result: &params.UnitInfo{Tag: "unit-foo-0", State: map[string]string{"seen": "yes"}},
+	}})
+}
+
+func (s *applicationSuite) TestPauseUnitsPriorV13(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %q", request)
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+	err := client.PauseUnits([]string{"foo/0"}, "")
+	c.Assert(err, gc.ErrorMatches, "PauseUnits for Application facade v12 not supported")
+}
+
+func (s *applicationSuite) TestPauseUnits(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "PauseUnits")
+				c.Check(a, jc.DeepEquals, params.PauseUnitsArgs{
+					Entities: []params.Entity{{Tag: "unit-foo-0"}, {Tag: "unit-foo-1"}},
+					Reason:   "kernel upgrade",
+				})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.PauseUnits([]string{"foo/0", "foo/1"}, "kernel upgrade")
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestPauseUnitsInvalidName(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %q", request)
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.PauseUnits([]string{"foo"}, "")
+	c.Assert(err, gc.ErrorMatches, `unit name "foo" not valid`)
+}
+
+func (s *applicationSuite) TestResumeUnits(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(request, gc.Equals, "ResumeUnits")
+				c.Check(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-foo-0"}}})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.ResumeUnits([]string{"foo/0"})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestExposeEndpointsPriorV14(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %q", request)
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{"db": {}})
+	c.Assert(err, gc.ErrorMatches, "ExposeEndpoints for Application facade v13 not supported")
+	err = client.UnexposeEndpoints("foo", []string{"db"})
+	c.Assert(err, gc.ErrorMatches, "UnexposeEndpoints for Application facade v13 not supported")
+}
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "Expose")
+				c.Check(a, jc.DeepEquals, params.ApplicationExpose{
+					ApplicationName: "foo",
+					ExposedEndpoints: map[string]params.ExposedEndpoint{
+						"db": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+					},
+				})
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{
+		"db": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "Unexpose")
+				c.Check(a, jc.DeepEquals, params.ApplicationUnexpose{
+					ApplicationName:  "foo",
+					ExposedEndpoints: []string{"db"},
+				})
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.UnexposeEndpoints("foo", []string{"db"})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -32,6 +32,26 @@ func NewClient(st base.APICallCloser) *Client {
 
 // Offer prepares application's endpoints for consumption.
 func (c *Client) Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error) {
+	return c.offer(modelUUID, application, endpoints, offerName, desc, 0)
+}
+
+// OfferWithMaxConnections prepares application's endpoints for consumption,
+// limiting the number of consumers which may be connected to the offer at
+// any one time. A limit of 0 means there is no limit.
+func (c *Client) OfferWithMaxConnections(
+	modelUUID, application string, endpoints []string, offerName string, desc string, maxConnections int,
+) ([]params.ErrorResult, error) {
+	if maxConnections != 0 {
+		if bestVer := c.BestAPIVersion(); bestVer < 3 {
+			return nil, errors.NotImplementedf("Offer() with max connections (need v3+, have v%d)", bestVer)
+		}
+	}
+	return c.offer(modelUUID, application, endpoints, offerName, desc, maxConnections)
+}
+
+func (c *Client) offer(
+	modelUUID, application string, endpoints []string, offerName string, desc string, maxConnections int,
+) ([]params.ErrorResult, error) {
 	// TODO(wallyworld) - support endpoint aliases
 	ep := make(map[string]string)
 	for _, name := range endpoints {
@@ -44,6 +64,7 @@ func (c *Client) Offer(modelUUID, application string, endpoints []string, offerN
 			ApplicationDescription: desc,
 			Endpoints:              ep,
 			OfferName:              offerName,
+			MaxConnections:         maxConnections,
 		},
 	}
 	out := params.ErrorResults{}
@@ -116,6 +137,7 @@ func offerParamsToDetails(offer params.ApplicationOfferAdminDetails) (*crossmode
 		CharmURL:               offer.CharmURL,
 		OfferURL:               offer.OfferURL,
 		Endpoints:              eps,
+		MaxConnections:         offer.MaxConnections,
 	}
 	for _, oc := range offer.Connections {
 		modelTag, err := names.ParseModelTag(oc.SourceModelTag)
@@ -317,3 +339,42 @@ func (c *Client) DestroyOffers(force bool, offerURLs ...string) error {
 	}
 	return result.Combine()
 }
+
+// RevokeOfferConnection removes the relation in the offering model which
+// backs the specified connection to an offer. The consuming model removes
+// its side of the relation in turn.
+func (c *Client) RevokeOfferConnection(offerURL string, relationId int) error {
+	return c.revokeOfferConnection(params.RevokeOfferConnectionArg{
+		OfferURL:   offerURL,
+		RelationId: relationId,
+	})
+}
+
+// SuspendOfferConnection suspends the relation in the offering model which
+// backs the specified connection to an offer.
+func (c *Client) SuspendOfferConnection(offerURL string, relationId int, reason string) error {
+	return c.revokeOfferConnection(params.RevokeOfferConnectionArg{
+		OfferURL:   offerURL,
+		RelationId: relationId,
+		Suspend:    true,
+		Reason:     reason,
+	})
+}
+
+func (c *Client) revokeOfferConnection(arg params.RevokeOfferConnectionArg) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("RevokeOfferConnections() (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(arg.OfferURL); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.RevokeOfferConnectionsArgs{
+		Args: []params.RevokeOfferConnectionArg{arg},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("RevokeOfferConnections", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -701,3 +701,137 @@ func (s *crossmodelMockSuite) TestDestroyOffersForce(c *gc.C) {
 
 	c.Assert(err, gc.ErrorMatches, "DestroyOffers\\(\\).* not implemented")
 }
+
+func (s *crossmodelMockSuite) TestOfferWithMaxConnections(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "Offer")
+				args, ok := a.(params.AddApplicationOffers)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Offers, gc.HasLen, 1)
+				c.Assert(args.Offers[0].MaxConnections, gc.Equals, 3)
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	results, err := client.OfferWithMaxConnections("uuid", "mysql", []string{"db"}, "hosted-mysql", "", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestOfferWithMaxConnectionsPriorV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.OfferWithMaxConnections("uuid", "mysql", []string{"db"}, "hosted-mysql", "", 3)
+	c.Assert(err, gc.ErrorMatches, "Offer\\(\\) with max connections.* not implemented")
+}
+
+func (s *crossmodelMockSuite) TestRevokeOfferConnection(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "RevokeOfferConnections")
+				c.Assert(a, jc.DeepEquals, params.RevokeOfferConnectionsArgs{
+					Args: []params.RevokeOfferConnectionArg{{
+						OfferURL:   "me/prod.app",
+						RelationId: 2,
+					}},
+				})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{
+						Error: &params.Error{Message: "fail"},
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.RevokeOfferConnection("me/prod.app", 2)
+	c.Assert(err, gc.ErrorMatches, "fail")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestSuspendOfferConnection(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "RevokeOfferConnections")
+				c.Assert(a, jc.DeepEquals, params.RevokeOfferConnectionsArgs{
+					Args: []params.RevokeOfferConnectionArg{{
+						OfferURL:   "me/prod.app",
+						RelationId: 2,
+						Suspend:    true,
+						Reason:     "too noisy",
+					}},
+				})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.SuspendOfferConnection("me/prod.app", 2, "too noisy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestRevokeOfferConnectionPriorV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.RevokeOfferConnection("me/prod.app", 2)
+	c.Assert(err, gc.ErrorMatches, "RevokeOfferConnections\\(\\).* not implemented")
+}
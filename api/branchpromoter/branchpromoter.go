@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
+)
+
+const branchPromoterFacade = "BranchPromoter"
+
+// Unit describes the state of a unit tracking an in-flight branch.
+type Unit struct {
+	// Name is the name of the unit.
+	Name string
+
+	// WorkloadStatus is the current workload status of the unit,
+	// and WorkloadSince is the time at which it was last set.
+	WorkloadStatus string
+	WorkloadSince  time.Time
+
+	// AgentStatus is the current status of the unit agent.
+	AgentStatus string
+
+	// ActionStatus is the status of the latest run of the policy's action
+	// on the unit since the branch was created. It is empty if no such
+	// action has been enqueued.
+	ActionStatus string
+}
+
+// Branch describes an in-flight branch that has a promotion policy,
+// along with the units tracking it.
+type Branch struct {
+	Name   string
+	Policy model.PromotionPolicy
+	Units  []Unit
+
+	// Created is when the branch was created, and PolicySet
+	// is when its promotion policy was set.
+	Created   time.Time
+	PolicySet time.Time
+}
+
+// Decision is a determination made by applying
+// a branch's promotion policy.
+type Decision struct {
+	// Branch is the name of the branch to which the decision applies.
+	Branch string
+
+	// Decision is one of "commit", "abort" or "untrack".
+	Decision string
+
+	// Units are the units that should stop tracking the branch.
+	Units []string
+
+	// Reason is a human-readable explanation for the decision.
+	Reason string
+}
+
+// API provides access to the BranchPromoter API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side BranchPromoter facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, branchPromoterFacade)
+	return &API{facade: facadeCaller}
+}
+
+// WatchBranchPromotion returns a watcher that notifies of changes to
+// branches with promotion policies, or to the units tracking them.
+func (api *API) WatchBranchPromotion() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := api.facade.FacadeCall("WatchBranchPromotion", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// BranchPromotionInfo returns details of all in-flight branches
+// that have a promotion policy.
+func (api *API) BranchPromotionInfo() ([]Branch, error) {
+	var result params.BranchPromotionInfoResults
+	err := api.facade.FacadeCall("BranchPromotionInfo", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+
+	branches := make([]Branch, len(result.Results))
+	for i, res := range result.Results {
+		units := make([]Unit, len(res.Units))
+		for j, u := range res.Units {
+			units[j] = Unit{
+				Name:           u.UnitName,
+				WorkloadStatus: u.WorkloadStatus,
+				AgentStatus:    u.AgentStatus,
+				ActionStatus:   u.ActionStatus,
+			}
+			if u.WorkloadSince != nil {
+				units[j].WorkloadSince = *u.WorkloadSince
+			}
+		}
+		branches[i] = Branch{
+			Name: res.BranchName,
+			Policy: model.PromotionPolicy{
+				HealthyFor: res.Policy.HealthyFor,
+				ActionName: res.Policy.ActionName,
+				OnError:    model.BranchErrorPolicy(res.Policy.OnError),
+			},
+			Units:     units,
+			Created:   res.Created,
+			PolicySet: res.PolicySet,
+		}
+	}
+	return branches, nil
+}
+
+// PromoteBranches applies the input decisions to their branches.
+// The first error encountered is returned.
+func (api *API) PromoteBranches(decisions []Decision) error {
+	args := params.BranchPromotionDecisions{
+		Decisions: make([]params.BranchPromotionDecision, len(decisions)),
+	}
+	for i, d := range decisions {
+		args.Decisions[i] = params.BranchPromotionDecision{
+			BranchName: d.Branch,
+			Decision:   d.Decision,
+			Units:      d.Units,
+			Reason:     d.Reason,
+		}
+	}
+
+	var results params.ErrorResults
+	err := api.facade.FacadeCall("PromoteBranches", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.Combine())
+}
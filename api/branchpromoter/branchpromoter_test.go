@@ -0,0 +1,165 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/branchpromoter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+)
+
+type branchPromoterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&branchPromoterSuite{})
+
+func (s *branchPromoterSuite) TestWatchBranchPromotionError(c *gc.C) {
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		c.Check(request, gc.Equals, "WatchBranchPromotion")
+		return errors.New("blam pow")
+	})
+	api := branchpromoter.NewAPI(caller)
+
+	w, err := api.WatchBranchPromotion()
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "blam pow")
+}
+
+func (s *branchPromoterSuite) TestWatchBranchPromotionResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "no watching"},
+		}
+		return nil
+	})
+	api := branchpromoter.NewAPI(caller)
+
+	w, err := api.WatchBranchPromotion()
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "no watching")
+}
+
+func (s *branchPromoterSuite) TestBranchPromotionInfo(c *gc.C) {
+	since := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	caller := apiCaller(c, func(request string, _, result interface{}) error {
+		c.Check(request, gc.Equals, "BranchPromotionInfo")
+		*(result.(*params.BranchPromotionInfoResults)) = params.BranchPromotionInfoResults{
+			Results: []params.BranchPromotionInfo{{
+				BranchName: "new-branch",
+				Policy: params.BranchPromotionPolicy{
+					HealthyFor: time.Minute,
+					ActionName: "smoke-test",
+					OnError:    "abort",
+				},
+				Units: []params.BranchPromotionUnit{{
+					UnitName:       "redis/0",
+					WorkloadStatus: "active",
+					WorkloadSince:  &since,
+					AgentStatus:    "idle",
+					ActionStatus:   "completed",
+				}, {
+					UnitName:       "redis/1",
+					WorkloadStatus: "maintenance",
+					AgentStatus:    "executing",
+				}},
+				Created:   since.Add(-time.Hour),
+				PolicySet: since.Add(-time.Minute),
+			}},
+		}
+		return nil
+	})
+	api := branchpromoter.NewAPI(caller)
+
+	branches, err := api.BranchPromotionInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(branches, jc.DeepEquals, []branchpromoter.Branch{{
+		Name: "new-branch",
+		Policy: model.PromotionPolicy{
+			HealthyFor: time.Minute,
+			ActionName: "smoke-test",
+			OnError:    model.BranchErrorAbort,
+		},
+		Units: []branchpromoter.Unit{{
+			Name:           "redis/0",
+			WorkloadStatus: "active",
+			WorkloadSince:  since,
+			AgentStatus:    "idle",
+			ActionStatus:   "completed",
+		}, {
+			Name:           "redis/1",
+			WorkloadStatus: "maintenance",
+			AgentStatus:    "executing",
+		}},
+		Created:   since.Add(-time.Hour),
+		PolicySet: since.Add(-time.Minute),
+	}})
+}
+
+func (s *branchPromoterSuite) TestBranchPromotionInfoError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*(result.(*params.BranchPromotionInfoResults)) = params.BranchPromotionInfoResults{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	api := branchpromoter.NewAPI(caller)
+
+	_, err := api.BranchPromotionInfo()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *branchPromoterSuite) TestPromoteBranches(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "PromoteBranches")
+		c.Check(arg, jc.DeepEquals, params.BranchPromotionDecisions{
+			Decisions: []params.BranchPromotionDecision{{
+				BranchName: "new-branch",
+				Decision:   "untrack",
+				Units:      []string{"redis/1"},
+				Reason:     "unit in error",
+			}, {
+				BranchName: "other-branch",
+				Decision:   "commit",
+				Reason:     "all units healthy",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "commit failed"}},
+		}}
+		return nil
+	})
+	api := branchpromoter.NewAPI(caller)
+
+	err := api.PromoteBranches([]branchpromoter.Decision{{
+		Branch:   "new-branch",
+		Decision: "untrack",
+		Units:    []string{"redis/1"},
+		Reason:   "unit in error",
+	}, {
+		Branch:   "other-branch",
+		Decision: "commit",
+		Reason:   "all units healthy",
+	}})
+	c.Check(err, gc.ErrorMatches, "commit failed")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "BranchPromoter")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
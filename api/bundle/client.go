@@ -61,14 +61,24 @@ func (c *Client) GetChangesMapArgs(bundleURL, bundleDataYAML string) (params.Bun
 	return result, nil
 }
 
-// ExportBundle exports the current model configuration.
-func (c *Client) ExportBundle() (string, error) {
+// ExportBundle exports the current model configuration. If splitOverlay
+// is true, environment specific values such as machines and placement
+// are exported in the overlay document rather than in the base bundle.
+func (c *Client) ExportBundle(splitOverlay bool) (string, error) {
 	var result params.StringResult
-	if bestVer := c.BestAPIVersion(); bestVer < 2 {
+	bestVer := c.BestAPIVersion()
+	if bestVer < 2 {
 		return "", errors.Errorf("this controller version does not support bundle export feature.")
 	}
 
-	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+	var arg interface{}
+	if bestVer >= 5 {
+		arg = params.ExportBundleParams{SplitOverlay: splitOverlay}
+	} else if splitOverlay {
+		return "", errors.NotSupportedf("splitting the overlay with this controller version")
+	}
+
+	if err := c.facade.FacadeCall("ExportBundle", arg, &result); err != nil {
 		return "", errors.Trace(err)
 	}
 
@@ -286,7 +286,7 @@ func (s *bundleMockSuite) TestFailExportBundlev1(c *gc.C) {
 			return nil
 		}, 1,
 	)
-	result, err := client.ExportBundle()
+	result, err := client.ExportBundle(false)
 	c.Assert(err, gc.NotNil)
 	c.Assert(err.Error(), gc.Equals, "this controller version does not support bundle export feature.")
 	c.Assert(result, jc.DeepEquals, "")
@@ -317,7 +317,7 @@ func (s *bundleMockSuite) TestExportBundlev2(c *gc.C) {
 			return nil
 		}, 2,
 	)
-	result, err := client.ExportBundle()
+	result, err := client.ExportBundle(false)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, bundle)
 }
@@ -337,7 +337,7 @@ func (s *bundleMockSuite) TestExportBundleNotNilParamsErrorv2(c *gc.C) {
 			return result.Error
 		}, 2,
 	)
-	result, err := client.ExportBundle()
+	result, err := client.ExportBundle(false)
 	c.Assert(err, gc.NotNil)
 	c.Assert(result, jc.DeepEquals, "")
 	c.Check(err.Error(), gc.Matches, "export failed nothing to export as there are no applications")
@@ -356,8 +356,44 @@ func (s *bundleMockSuite) TestExportBundleFailNoParamsErrorv2(c *gc.C) {
 			return errors.New("foo")
 		}, 2,
 	)
-	result, err := client.ExportBundle()
+	result, err := client.ExportBundle(false)
 	c.Assert(err, gc.NotNil)
 	c.Assert(result, jc.DeepEquals, "")
 	c.Check(err.Error(), gc.Matches, "foo")
 }
+
+func (s *bundleMockSuite) TestExportBundleSplitOverlayv5(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Assert(args, jc.DeepEquals, params.ExportBundleParams{SplitOverlay: true})
+			result := response.(*params.StringResult)
+			result.Result = "applications: {}\n"
+			return nil
+		}, 5,
+	)
+	result, err := client.ExportBundle(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "applications: {}\n")
+}
+
+func (s *bundleMockSuite) TestExportBundleSplitOverlayNotSupported(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}, 4,
+	)
+	_, err := client.ExportBundle(true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -33,8 +33,9 @@ func NewClient(caller base.APICallCloser) *Client {
 // ControllerInfo contains the information about the controller that will be
 // returned by the ControllerInfo method.
 type ControllerInfo struct {
-	Addrs  []string
-	CACert string
+	Addrs      []string
+	CACert     string
+	RelayProxy string
 }
 
 // ControllerInfo returns the remote controller's API information.
@@ -51,8 +52,9 @@ func (c *Client) ControllerInfo() (*ControllerInfo, error) {
 	}
 	info := results.Results[0]
 	return &ControllerInfo{
-		Addrs:  info.Addresses,
-		CACert: info.CACert,
+		Addrs:      info.Addresses,
+		CACert:     info.CACert,
+		RelayProxy: info.RelayProxy,
 	}, nil
 }
 
@@ -37,8 +37,9 @@ func (s *CrossControllerSuite) TestControllerInfo(c *gc.C) {
 		c.Assert(result, gc.FitsTypeOf, &params.ControllerAPIInfoResults{})
 		*(result.(*params.ControllerAPIInfoResults)) = params.ControllerAPIInfoResults{
 			[]params.ControllerAPIInfoResult{{
-				Addresses: []string{"foo"},
-				CACert:    "bar",
+				Addresses:  []string{"foo"},
+				CACert:     "bar",
+				RelayProxy: "http://relay:3128",
 			}},
 		}
 		return nil
@@ -47,8 +48,9 @@ func (s *CrossControllerSuite) TestControllerInfo(c *gc.C) {
 	info, err := client.ControllerInfo()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(info, jc.DeepEquals, &crosscontroller.ControllerInfo{
-		Addrs:  []string{"foo"},
-		CACert: "bar",
+		Addrs:      []string{"foo"},
+		CACert:     "bar",
+		RelayProxy: "http://relay:3128",
 	})
 }
 
@@ -71,6 +71,7 @@ func (c *Client) ExternalControllerInfo(controllerUUID string) (*crossmodel.Cont
 		Alias:         result.Result.Alias,
 		Addrs:         result.Result.Addrs,
 		CACert:        result.Result.CACert,
+		RelayProxy:    result.Result.RelayProxy,
 	}, nil
 }
 
@@ -84,6 +85,7 @@ func (c *Client) SetExternalControllerInfo(info crossmodel.ControllerInfo) error
 				Alias:         info.Alias,
 				Addrs:         info.Addrs,
 				CACert:        info.CACert,
+				RelayProxy:    info.RelayProxy,
 			},
 		}},
 	}
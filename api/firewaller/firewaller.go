@@ -125,6 +125,24 @@ func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnetChanges returns a NotifyWatcher that notifies of changes
+// to the subnets of the current model, including changes to the space
+// a subnet belongs to.
+func (c *Client) WatchSubnetChanges() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("WatchSubnetChanges for Firewaller facade v%d", c.BestAPIVersion())
+	}
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchSubnetChanges", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // Relation provides access to methods of a state.Relation through the
 // facade.
 func (c *Client) Relation(tag names.RelationTag) (*Relation, error) {
@@ -201,6 +219,7 @@ func (c *Client) ControllerAPIInfoForModel(modelUUID string) (*api.Info, error)
 	return &api.Info{
 		Addrs:    result.Addresses,
 		CACert:   result.CACert,
+		ProxyURL: result.RelayProxy,
 		ModelTag: modelTag,
 	}, nil
 }
@@ -111,6 +111,29 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	portRanges, err := m.OpenedPortRanges(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	endResult := make(map[network.PortRange]names.UnitTag)
+	for portRange, opened := range portRanges {
+		endResult[portRange] = opened.UnitTag
+	}
+	return endResult, nil
+}
+
+// OpenedPortRange holds the tag of the unit that opened a port range,
+// and the application endpoint it was opened for. An empty endpoint
+// means the port range was opened for all endpoints.
+type OpenedPortRange struct {
+	UnitTag  names.UnitTag
+	Endpoint string
+}
+
+// OpenedPortRanges returns a map of network.PortRange to the unit that
+// opened it, and the endpoint it was opened for, for all opened port
+// ranges on the machine for the subnet matching given subnetTag.
+func (m *Machine) OpenedPortRanges(subnetTag names.SubnetTag) (map[network.PortRange]OpenedPortRange, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +156,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]OpenedPortRange)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = OpenedPortRange{
+			UnitTag:  unitTag,
+			Endpoint: ports.Endpoint,
+		}
 	}
 	return endResult, nil
 }
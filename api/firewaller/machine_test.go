@@ -135,6 +135,21 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	})
 }
 
+func (s *machineSuite) TestOpenedPortRanges(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenPort("tcp", 1234)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.apiMachine.OpenedPortRanges(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.OpenedPortRange{
+		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {UnitTag: unitTag},
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"}:     {UnitTag: unitTag, Endpoint: "url"},
+	})
+}
+
 func (s *machineSuite) TestIsManual(c *gc.C) {
 	answer, err := s.machines[0].IsManual()
 	c.Assert(err, jc.ErrorIsNil)
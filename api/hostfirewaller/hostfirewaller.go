@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
+)
+
+const hostFirewallerFacade = "HostFirewaller"
+
+// Rules holds the host firewall rules for a machine.
+type Rules struct {
+	// Enabled indicates whether the host firewall is enabled.
+	Enabled bool
+
+	// InternalCIDRs holds the sources that may access any port.
+	InternalCIDRs []string
+
+	// IngressRules holds the ingress rules for the ports opened
+	// by the machine's units.
+	IngressRules []network.IngressRule
+}
+
+// State provides access to a hostfirewaller worker's view of the state.
+type State struct {
+	facade base.FacadeCaller
+	tag    names.MachineTag
+}
+
+// NewState creates a new client-side HostFirewaller facade.
+func NewState(caller base.APICaller, authTag names.MachineTag) *State {
+	return &State{
+		base.NewFacadeCaller(caller, hostFirewallerFacade),
+		authTag,
+	}
+}
+
+// WatchHostFirewallRules returns a NotifyWatcher that notifies when the
+// host firewall rules for the machine identified by the authenticated
+// machine tag may have changed.
+func (st *State) WatchHostFirewallRules() (watcher.NotifyWatcher, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.tag.String()}},
+	}
+	var results params.NotifyWatchResults
+	err := st.facade.FacadeCall("WatchHostFirewallRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// HostFirewallRules returns the host firewall rules for the machine
+// identified by the authenticated machine tag.
+func (st *State) HostFirewallRules() (Rules, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.tag.String()}},
+	}
+	var results params.HostFirewallRulesResults
+	err := st.facade.FacadeCall("GetHostFirewallRules", args, &results)
+	if err != nil {
+		return Rules{}, err
+	}
+	if len(results.Results) != 1 {
+		return Rules{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return Rules{}, result.Error
+	}
+	rules := Rules{
+		Enabled:       result.Enabled,
+		InternalCIDRs: result.InternalCIDRs,
+	}
+	for _, rule := range result.Rules {
+		rules.IngressRules = append(rules.IngressRules, network.IngressRule{
+			PortRange:   rule.PortRange.NetworkPortRange(),
+			SourceCIDRs: rule.SourceCIDRs,
+		})
+	}
+	return rules, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&HostFirewallerSuite{})
+
+type HostFirewallerSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *HostFirewallerSuite) TestHostFirewallRules(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HostFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "GetHostFirewallRules")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-123"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.HostFirewallRulesResults{})
+		*(result.(*params.HostFirewallRulesResults)) = params.HostFirewallRulesResults{
+			Results: []params.HostFirewallRulesResult{{
+				Enabled:       true,
+				InternalCIDRs: []string{"10.0.0.1/32"},
+				Rules: []params.IngressRule{{
+					PortRange:   params.PortRange{FromPort: 5432, ToPort: 5432, Protocol: "tcp"},
+					SourceCIDRs: []string{"10.0.0.0/8"},
+				}},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st := hostfirewaller.NewState(apiCaller, names.NewMachineTag("123"))
+	rules, err := st.HostFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callCount, gc.Equals, 1)
+	c.Assert(rules, jc.DeepEquals, hostfirewaller.Rules{
+		Enabled:       true,
+		InternalCIDRs: []string{"10.0.0.1/32"},
+		IngressRules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+		},
+	})
+}
+
+func (s *HostFirewallerSuite) TestHostFirewallRulesResultError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.HostFirewallRulesResults)) = params.HostFirewallRulesResults{
+			Results: []params.HostFirewallRulesResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+
+	st := hostfirewaller.NewState(apiCaller, names.NewMachineTag("123"))
+	_, err := st.HostFirewallRules()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *HostFirewallerSuite) TestWatchHostFirewallRules(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HostFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchHostFirewallRules")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-123"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st := hostfirewaller.NewState(apiCaller, names.NewMachineTag("123"))
+	_, err := st.WatchHostFirewallRules()
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+	c.Assert(callCount, gc.Equals, 1)
+}
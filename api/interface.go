@@ -58,6 +58,13 @@ type Info struct {
 	// login will be made.
 	ModelTag names.ModelTag
 
+	// ProxyURL optionally holds the URL of an HTTP proxy through
+	// which to connect to the addresses in Addrs, in place of any
+	// proxy configured for the process. It is used to reach
+	// controllers which are not directly routable, such as an
+	// offering controller behind NAT in a cross model relation.
+	ProxyURL string `yaml:",omitempty"`
+
 	// ...but this block of fields is all about the authentication mechanism
 	// to use after connecting -- if any -- and should probably be extracted.
 
@@ -104,6 +111,11 @@ func (info *Info) Validate() error {
 	if _, err := network.ParseHostPorts(info.Addrs...); err != nil {
 		return errors.NotValidf("host addresses: %v", err)
 	}
+	if info.ProxyURL != "" {
+		if u, err := url.Parse(info.ProxyURL); err != nil || u.Scheme != "http" || u.Host == "" {
+			return errors.NotValidf("proxy URL %q", info.ProxyURL)
+		}
+	}
 	if info.SkipLogin {
 		if info.Tag != nil {
 			return errors.NotValidf("specifying Tag and SkipLogin")
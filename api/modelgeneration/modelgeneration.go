@@ -102,6 +102,30 @@ func (c *Client) TrackBranch(branchName string, entities []string) error {
 	return nil
 }
 
+// SetBranchPromotionPolicy sets the policy used to automatically commit or
+// abort the branch with the input name. Supplying a nil policy removes any
+// policy currently set for the branch.
+func (c *Client) SetBranchPromotionPolicy(branchName string, policy *model.PromotionPolicy) error {
+	arg := params.BranchPromotionPolicyArg{BranchName: branchName}
+	if policy != nil {
+		arg.Policy = &params.BranchPromotionPolicy{
+			HealthyFor: policy.HealthyFor,
+			ActionName: policy.ActionName,
+			OnError:    string(policy.OnError),
+		}
+	}
+
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("SetBranchPromotionPolicy", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
 // HasActiveBranch returns true if the model has an
 // "in-flight" branch with the input name.
 func (c *Client) HasActiveBranch(branchName string) (bool, error) {
@@ -165,11 +189,19 @@ func generationInfoFromResult(
 			}
 			appDeltas[i] = bApp
 		}
-		summaries[res.BranchName] = model.Generation{
+		gen := model.Generation{
 			Created:      formatTime(time.Unix(res.Created, 0)),
 			CreatedBy:    res.CreatedBy,
 			Applications: appDeltas,
 		}
+		if p := res.PromotionPolicy; p != nil {
+			gen.PromotionPolicy = &model.PromotionPolicy{
+				HealthyFor: p.HealthyFor,
+				ActionName: p.ActionName,
+				OnError:    model.BranchErrorPolicy(p.OnError),
+			}
+		}
+		summaries[res.BranchName] = gen
 	}
 	return summaries
 }
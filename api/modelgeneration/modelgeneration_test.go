@@ -68,6 +68,39 @@ func (s *modelGenerationSuite) TestAbortBranch(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 }
 
+func (s *modelGenerationSuite) TestSetBranchPromotionPolicy(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{}
+	arg := params.BranchPromotionPolicyArg{
+		BranchName: s.branchName,
+		Policy: &params.BranchPromotionPolicy{
+			HealthyFor: 5 * time.Minute,
+			OnError:    "untrack",
+		},
+	}
+	s.fCaller.EXPECT().FacadeCall("SetBranchPromotionPolicy", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetBranchPromotionPolicy(s.branchName, &model.PromotionPolicy{
+		HealthyFor: 5 * time.Minute,
+		OnError:    model.BranchErrorUntrack,
+	})
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestClearBranchPromotionPolicy(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{}
+	arg := params.BranchPromotionPolicyArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().FacadeCall("SetBranchPromotionPolicy", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetBranchPromotionPolicy(s.branchName, nil)
+	c.Assert(err, gc.IsNil)
+}
+
 func (s *modelGenerationSuite) TestTrackBranchSuccess(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -83,6 +83,12 @@ type ProxyConfiguration struct {
 	SnapStoreProxyId         string
 	SnapStoreProxyAssertions string
 	SnapStoreProxyURL        string
+
+	AptMirror string
+
+	// SpaceAptMirror is the apt mirror for the spaces of the agent's
+	// machine, if it differs from the model's apt mirror.
+	SpaceAptMirror string
 }
 
 // ProxyConfig returns the proxy settings for the current model.
@@ -111,6 +117,9 @@ func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 		return empty, errors.NotFoundf("ProxyConfig for %q", api.tag)
 	}
 	result := results.Results[0]
+	if result.Error != nil {
+		return empty, result.Error
+	}
 	return ProxyConfiguration{
 		LegacyProxy: proxySettingsParamToProxySettings(result.LegacyProxySettings),
 		JujuProxy:   proxySettingsParamToProxySettings(result.JujuProxySettings),
@@ -120,6 +129,9 @@ func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 		SnapStoreProxyId:         result.SnapStoreProxyId,
 		SnapStoreProxyAssertions: result.SnapStoreProxyAssertions,
 		SnapStoreProxyURL:        result.SnapStoreProxyURL,
+
+		AptMirror:      result.AptMirror,
+		SpaceAptMirror: result.SpaceAptMirror,
 	}, nil
 }
 
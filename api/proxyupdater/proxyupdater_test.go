@@ -104,6 +104,8 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 			HTTP:  "http-snap",
 			HTTPS: "https-snap",
 		},
+		AptMirror:      "http://mirror/ubuntu",
+		SpaceAptMirror: "http://mirror/ubuntu",
 	}
 
 	called, api := newAPI(c, 2, apitesting.APICall{
@@ -138,6 +140,24 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 		Http:  "http-snap",
 		Https: "https-snap",
 	})
+	c.Check(config.AptMirror, gc.Equals, "http://mirror/ubuntu")
+	c.Check(config.SpaceAptMirror, gc.Equals, "http://mirror/ubuntu")
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigError(c *gc.C) {
+	called, api := newAPI(c, 2, apitesting.APICall{
+		Facade: "ProxyUpdater",
+		Method: "ProxyConfig",
+		Results: params.ProxyConfigResults{
+			Results: []params.ProxyConfigResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		},
+	})
+
+	_, err := api.ProxyConfig()
+	c.Assert(*called, gc.Equals, 1)
+	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
 func (s *ProxyUpdaterSuite) TestProxyConfigV1(c *gc.C) {
@@ -279,6 +279,7 @@ func (c *Client) ControllerAPIInfoForModel(modelUUID string) (*api.Info, error)
 	return &api.Info{
 		Addrs:    result.Addresses,
 		CACert:   result.CACert,
+		ProxyURL: result.RelayProxy,
 		ModelTag: modelTag,
 	}, nil
 }
@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/remoterelations"
 	apitesting "github.com/juju/juju/api/testing"
@@ -410,6 +411,28 @@ func (s *remoteRelationsSuite) TestControllerAPIInfoForModel(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *remoteRelationsSuite) TestControllerAPIInfoForModelRelayProxy(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ControllerAPIInfoResults)) = params.ControllerAPIInfoResults{
+			Results: []params.ControllerAPIInfoResult{{
+				Addresses:  []string{"10.0.0.1:17070"},
+				CACert:     coretesting.CACert,
+				RelayProxy: "http://relay.example.com:3128",
+			}},
+		}
+		return nil
+	})
+	client := remoterelations.NewClient(apiCaller)
+	info, err := client.ControllerAPIInfoForModel(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &api.Info{
+		Addrs:    []string{"10.0.0.1:17070"},
+		CACert:   coretesting.CACert,
+		ProxyURL: "http://relay.example.com:3128",
+		ModelTag: coretesting.ModelTag,
+	})
+}
+
 func (s *remoteRelationsSuite) TestSaveMacaroon(c *gc.C) {
 	rel := names.NewRelationTag("mysql:db wordpress:db")
 	mac, err := apitesting.NewMacaroon("id")
@@ -355,3 +355,127 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// CreateSnapshots creates a snapshot of the volume backing each of
+// the specified storage instances.
+func (c *Client) CreateSnapshots(storageIds []string, description string) ([]params.StorageSnapshotResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("creating storage snapshots on this version of Juju")
+	}
+	args := params.CreateStorageSnapshotsArgs{
+		Storage: make([]params.CreateStorageSnapshotArg, len(storageIds)),
+	}
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		args.Storage[i] = params.CreateStorageSnapshotArg{
+			StorageTag:  names.NewStorageTag(id).String(),
+			Description: description,
+		}
+	}
+	var results params.StorageSnapshotResults
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists the snapshots of the volume backing each of
+// the specified storage instances.
+func (c *Client) ListSnapshots(storageIds []string) ([]params.StorageSnapshotsResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("listing storage snapshots on this version of Juju")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(storageIds)),
+	}
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		args.Entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	var results params.StorageSnapshotsResults
+	if err := c.facade.FacadeCall("ListSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// Resize requests that the storage instance with the specified ID be
+// grown to the specified size, in MiB. The resize is carried out
+// asynchronously by the storage provisioner.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("resizing storage on this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// MoveStorage requests that the storage instance with the specified
+// ID be moved to the named storage pool. The storage contents are
+// copied to a new volume by the unit that the storage is attached to.
+func (c *Client) MoveStorage(storageId, pool string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("moving storage on this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.MoveStorageArgs{
+		Storage: []params.MoveStorageArg{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Pool:       pool,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("MoveStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CancelStorageMove cancels the in-progress move of the storage
+// instance with the specified ID.
+func (c *Client) CancelStorageMove(storageId string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("moving storage on this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewStorageTag(storageId).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CancelStorageMoves", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -1077,3 +1077,191 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.CreateStorageSnapshotsArgs{
+					Storage: []params.CreateStorageSnapshotArg{
+						{StorageTag: "storage-foo-0", Description: "nightly"},
+						{StorageTag: "storage-bar-1", Description: "nightly"},
+					},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotResults{})
+				results := result.(*params.StorageSnapshotResults)
+				results.Results = []params.StorageSnapshotResult{
+					{Result: &params.StorageSnapshot{SnapshotId: "snap-0", StorageTag: "storage-foo-0"}},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"foo/0", "bar/1"}, "nightly")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StorageSnapshotResult{
+		{Result: &params.StorageSnapshot{SnapshotId: "snap-0", StorageTag: "storage-foo-0"}},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 6}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/0"}, "")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "storage-foo-0"}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotsResults{})
+				results := result.(*params.StorageSnapshotsResults)
+				results.Results = []params.StorageSnapshotsResult{{
+					Result: []params.StorageSnapshot{{SnapshotId: "snap-0", StorageTag: "storage-foo-0"}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ListSnapshots([]string{"foo/0"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{SnapshotId: "snap-0", StorageTag: "storage-foo-0"}},
+	}})
+}
+
+func (s *storageMockSuite) TestListSnapshotsInvalidStorageId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 7}
+	client := storage.NewClient(apiCaller)
+	_, err := client.ListSnapshots([]string{"foo/bar"})
+	c.Check(err, gc.ErrorMatches, `storage ID "foo/bar" not valid`)
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Resize")
+				c.Check(a, jc.DeepEquals, params.ResizeStorageArgs{
+					Storage: []params.ResizeStorageArg{
+						{StorageTag: "storage-foo-0", Size: 2048},
+					},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 7}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestMoveStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "MoveStorage")
+				c.Check(a, jc.DeepEquals, params.MoveStorageArgs{
+					Storage: []params.MoveStorageArg{
+						{StorageTag: "storage-foo-0", Pool: "fast"},
+					},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.MoveStorage("foo/0", "fast")
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestCancelStorageMove(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CancelStorageMoves")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "storage-foo-0"}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.CancelStorageMove("foo/0")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestMoveStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 8}
+	client := storage.NewClient(apiCaller)
+	err := client.MoveStorage("foo/0", "fast")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.CancelStorageMove("foo/0")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -114,6 +114,22 @@ func (st *State) watchStorageEntities(method string, scope names.Tag) (watcher.S
 	return w, nil
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the specified tag, including requests to resize them.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	if err := st.checkResizeSupported(); err != nil {
+		return nil, err
+	}
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
+func (st *State) checkResizeSupported() error {
+	if st.facade.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("resizing storage on this version of Juju")
+	}
+	return nil
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments
 // scoped to the entity with the specified tag.
 func (st *State) WatchVolumeAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error) {
@@ -247,6 +263,25 @@ func (st *State) VolumeBlockDevices(ids []params.MachineStorageId) ([]params.Blo
 	return results.Results, nil
 }
 
+// VolumeEncryptionKeys returns the keys with which the volumes of the
+// specified attachments are encrypted at rest. The key for a volume
+// that is not encrypted is empty.
+func (st *State) VolumeEncryptionKeys(ids []params.MachineStorageId) ([]params.StringResult, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("volume encryption on this version of Juju")
+	}
+	args := params.MachineStorageIds{ids}
+	var results params.StringResults
+	err := st.facade.FacadeCall("VolumeEncryptionKeys", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemAttachments returns details of filesystem attachments with the specified IDs.
 func (st *State) FilesystemAttachments(ids []params.MachineStorageId) ([]params.FilesystemAttachmentResult, error) {
 	args := params.MachineStorageIds{ids}
@@ -301,6 +336,29 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if err := st.checkResizeSupported(); err != nil {
+		return nil, err
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -385,6 +443,32 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// SetVolumeSizes records the sizes of resized volumes.
+func (st *State) SetVolumeSizes(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	return st.setStorageSizes("SetVolumeSizes", sizes)
+}
+
+// SetFilesystemSizes records the sizes of resized filesystems.
+func (st *State) SetFilesystemSizes(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	return st.setStorageSizes("SetFilesystemSizes", sizes)
+}
+
+func (st *State) setStorageSizes(method string, sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	if err := st.checkResizeSupported(); err != nil {
+		return nil, err
+	}
+	args := params.StorageSizes{Sizes: sizes}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall(method, args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(sizes) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(sizes), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
@@ -4,8 +4,7 @@
 package storageprovisioner_test
 
 import (
-	"errors"
-
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -409,6 +408,46 @@ func (s *provisionerSuite) TestVolumeBlockDevices(c *gc.C) {
 	c.Assert(volumes, jc.DeepEquals, blockDeviceResults)
 }
 
+func (s *provisionerSuite) TestVolumeEncryptionKeys(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 6)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeEncryptionKeys")
+		c.Check(arg, gc.DeepEquals, params.MachineStorageIds{
+			Ids: []params.MachineStorageId{{
+				MachineTag: "machine-100", AttachmentTag: "volume-100",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "sekrit"}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{apiCaller, 6})
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err := st.VolumeEncryptionKeys([]params.MachineStorageId{{
+		MachineTag: "machine-100", AttachmentTag: "volume-100",
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []params.StringResult{{Result: "sekrit"}})
+}
+
+func (s *provisionerSuite) TestVolumeEncryptionKeysNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{apiCaller, 5})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.VolumeEncryptionKeys([]params.MachineStorageId{{
+		MachineTag: "machine-100", AttachmentTag: "volume-100",
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *provisionerSuite) TestFilesystemAttachments(c *gc.C) {
 	filesystemAttachmentResults := []params.FilesystemAttachmentResult{{
 		Result: params.FilesystemAttachment{
@@ -516,6 +555,75 @@ func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 5)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+		*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+			Results: []params.VolumeResizeParamsResult{{
+				Result: params.VolumeResizeParams{
+					VolumeTag: "volume-100",
+					VolumeId:  "bar",
+					Provider:  "foo",
+					Size:      2048,
+				},
+			}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{apiCaller, 5})
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100",
+			VolumeId:  "bar",
+			Provider:  "foo",
+			Size:      2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSizes(c *gc.C) {
+	sizes := []params.StorageSize{{Tag: "volume-100", Size: 2048}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 5)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSizes")
+		c.Check(arg, gc.DeepEquals, params.StorageSizes{Sizes: sizes})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{apiCaller, 5})
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSizes(sizes)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizesNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{apiCaller, 4})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -65,6 +65,13 @@ func NewStateV4(
 	return newStateForVersion(caller, authTag, 4)
 }
 
+func NewStateV16(
+	caller base.APICaller,
+	authTag names.UnitTag,
+) *State {
+	return newStateForVersion(caller, authTag, 16)
+}
+
 func newStateForVersion(
 	caller base.APICaller,
 	authTag names.UnitTag,
@@ -187,3 +187,63 @@ func (sa *StorageAccessor) RemoveStorageAttachment(storageTag names.StorageTag,
 	}
 	return nil
 }
+
+// CompleteStorageMove completes the move of the storage attachment with
+// the specified unit and storage tags to another storage pool, once the
+// unit has copied the storage contents to the given target location.
+func (sa *StorageAccessor) CompleteStorageMove(storageTag names.StorageTag, unitTag names.UnitTag, target string) error {
+	if sa.facade.BestAPIVersion() < 16 {
+		return errors.NotImplementedf("CompleteStorageMove() (need V16+)")
+	}
+	var results params.ErrorResults
+	args := params.StorageMoveCompletions{
+		Completions: []params.StorageMoveCompletion{{
+			StorageTag: storageTag.String(),
+			UnitTag:    unitTag.String(),
+			Target:     target,
+		}},
+	}
+	err := sa.facade.FacadeCall("CompleteStorageMoves", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// SetStorageMoveProgress records the number of bytes of the storage
+// attachment with the specified unit and storage tags that have been
+// copied to the given target location, so that the copy may be resumed
+// if it is interrupted.
+func (sa *StorageAccessor) SetStorageMoveProgress(storageTag names.StorageTag, unitTag names.UnitTag, target string, copied uint64) error {
+	if sa.facade.BestAPIVersion() < 16 {
+		return errors.NotImplementedf("SetStorageMoveProgress() (need V16+)")
+	}
+	var results params.ErrorResults
+	args := params.StorageMoveProgresses{
+		Progress: []params.StorageMoveProgress{{
+			StorageTag: storageTag.String(),
+			UnitTag:    unitTag.String(),
+			Target:     target,
+			Copied:     copied,
+		}},
+	}
+	err := sa.facade.FacadeCall("SetStorageMoveProgress", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -246,3 +246,80 @@ func (s *storageSuite) TestRemoveStorageAttachment(c *gc.C) {
 	err := st.RemoveStorageAttachment(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"))
 	c.Check(err, gc.ErrorMatches, "yoink")
 }
+
+func (s *storageSuite) TestCompleteStorageMove(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(version, gc.Equals, 16)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CompleteStorageMoves")
+		c.Check(arg, gc.DeepEquals, params.StorageMoveCompletions{
+			Completions: []params.StorageMoveCompletion{{
+				StorageTag: "storage-data-0",
+				UnitTag:    "unit-mysql-0",
+				Target:     "/dev/sdb",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "yoink"},
+			}},
+		}
+		return nil
+	})
+
+	st := uniter.NewStateV16(apiCaller, names.NewUnitTag("mysql/0"))
+	err := st.CompleteStorageMove(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"), "/dev/sdb")
+	c.Check(err, gc.ErrorMatches, "yoink")
+}
+
+func (s *storageSuite) TestCompleteStorageMoveNotImplemented(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+
+	st := uniter.NewStateV2(apiCaller, names.NewUnitTag("mysql/0"))
+	err := st.CompleteStorageMove(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"), "/dev/sdb")
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *storageSuite) TestSetStorageMoveProgress(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(version, gc.Equals, 16)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetStorageMoveProgress")
+		c.Check(arg, gc.DeepEquals, params.StorageMoveProgresses{
+			Progress: []params.StorageMoveProgress{{
+				StorageTag: "storage-data-0",
+				UnitTag:    "unit-mysql-0",
+				Target:     "/dev/sdb",
+				Copied:     4096,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "yoink"},
+			}},
+		}
+		return nil
+	})
+
+	st := uniter.NewStateV16(apiCaller, names.NewUnitTag("mysql/0"))
+	err := st.SetStorageMoveProgress(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"), "/dev/sdb", 4096)
+	c.Check(err, gc.ErrorMatches, "yoink")
+}
+
+func (s *storageSuite) TestSetStorageMoveProgressNotImplemented(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+
+	st := uniter.NewStateV2(apiCaller, names.NewUnitTag("mysql/0"))
+	err := st.SetStorageMoveProgress(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"), "/dev/sdb", 4096)
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
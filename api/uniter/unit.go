@@ -23,6 +23,7 @@ type Unit struct {
 	life         params.Life
 	resolvedMode params.ResolvedMode
 	providerID   string
+	paused       bool
 }
 
 // Tag returns the unit's tag.
@@ -55,6 +56,11 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// Paused returns whether the unit's hooks are paused.
+func (u *Unit) Paused() bool {
+	return u.paused
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -78,6 +84,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.paused = result.Paused
 	return nil
 }
 
@@ -458,6 +465,16 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
+	return u.OpenPortsForEndpoint("", protocol, fromPort, toPort)
+}
+
+// OpenPortsForEndpoint sets the policy of the port range with protocol
+// to be opened for the given application endpoint, or for all endpoints
+// if the endpoint is empty.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint != "" && u.st.facade.BestAPIVersion() < 16 {
+		return errors.NotImplementedf("OpenPortsForEndpoint() (need V16+)")
+	}
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -465,6 +482,7 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
@@ -653,6 +671,24 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// LogActionOutput records lines written by the specified action to one
+// of its output streams, "stdout" or "stderr".
+func (u *Unit) LogActionOutput(tag names.ActionTag, stream string, lines []string) error {
+	if u.st.facade.BestAPIVersion() < 14 {
+		return errors.NotImplementedf("LogActionOutput() (need V14+)")
+	}
+
+	var result params.ErrorResults
+	args := params.ActionOutputParams{
+		Output: []params.ActionOutputLines{{Tag: tag.String(), Stream: stream, Lines: lines}},
+	}
+	err := u.st.facade.FacadeCall("LogActionsOutput", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
@@ -687,6 +723,62 @@ func (u *Unit) RequestReboot() error {
 	return result.OneError()
 }
 
+// State returns the charm state stored for the unit.
+func (u *Unit) State() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 13 {
+		return nil, errors.NotImplementedf("State() (need V13+)")
+	}
+	var results params.UnitStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("State", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.State, nil
+}
+
+// SetState replaces the charm state stored for the unit.
+func (u *Unit) SetState(state map[string]string) error {
+	if u.st.facade.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("SetState() (need V13+)")
+	}
+	var result params.ErrorResults
+	args := params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{{Tag: u.tag.String(), State: state}},
+	}
+	err := u.st.facade.FacadeCall("SetState", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// RecordHookExecutions records the timing and outcome of hooks run by
+// the unit.
+func (u *Unit) RecordHookExecutions(executions []params.HookExecution) error {
+	if u.st.facade.BestAPIVersion() < 15 {
+		return errors.NotImplementedf("RecordHookExecutions() (need V15+)")
+	}
+	var result params.ErrorResults
+	args := params.RecordHookExecutionsArgs{
+		Args: []params.RecordHookExecutionsArg{{Tag: u.tag.String(), Executions: executions}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookExecutions", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // RelationStatus holds information about a relation's scope and status.
 type RelationStatus struct {
 	// Tag is the relation tag.
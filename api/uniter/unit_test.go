@@ -164,6 +164,22 @@ func (s *unitSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Timestamp, gc.NotNil)
 }
 
+func (s *unitSuite) TestLogActionOutput(c *gc.C) {
+	anAction, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.LogActionOutput(anAction.ActionTag(), "stderr", []string{"oops"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := anAction.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "oops")
+	c.Assert(messages[0].Stream, gc.Equals, "stderr")
+}
+
 func (s *unitSuite) TestEnsureDead(c *gc.C) {
 	c.Assert(s.wordpressUnit.Life(), gc.Equals, state.Alive)
 
@@ -257,6 +273,22 @@ func (s *unitSuite) TestRefreshResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestRefreshPaused(c *gc.C) {
+	c.Assert(s.apiUnit.Paused(), jc.IsFalse)
+
+	err := s.wordpressUnit.Pause("admin", "maintenance")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.Paused(), jc.IsTrue)
+
+	err = s.wordpressUnit.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.Paused(), jc.IsFalse)
+}
+
 func (s *unitSuite) TestRefreshUpdateProviderID(c *gc.C) {
 	c.Assert(s.apiUnit.ProviderID(), gc.Equals, "")
 
@@ -480,6 +512,17 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.wordpressUnit.OpenedEndpointPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.DeepEquals, map[string][]corenetwork.PortRange{
+		"url": {{Protocol: "tcp", FromPort: 80, ToPort: 80}},
+	})
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -538,6 +581,37 @@ func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	c.Assert(called, gc.Equals, 2)
 }
 
+func (s *unitSuite) TestState(c *gc.C) {
+	state, err := s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.HasLen, 0)
+
+	err = s.apiUnit.SetState(map[string]string{"seen": "yes"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	state, err = s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.DeepEquals, map[string]string{"seen": "yes"})
+}
+
+func (s *unitSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.RecordHookExecutions([]params.HookExecution{{
+		Hook:     "install",
+		Outcome:  "succeeded",
+		Queued:   started,
+		Started:  started,
+		Finished: started.Add(time.Minute),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 1)
+	c.Assert(executions[0].Hook, gc.Equals, "install")
+	c.Assert(executions[0].Outcome, gc.Equals, "succeeded")
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
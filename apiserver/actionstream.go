@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// actionStreamStatusInterval is how often the status of a streamed
+// action is checked when no new messages arrive, so the stream can be
+// closed once the action finishes.
+const actionStreamStatusInterval = 2 * time.Second
+
+// actionStreamSource provides the actions and action message watchers
+// needed to stream an action's messages.
+type actionStreamSource interface {
+	Action(id string) (state.Action, error)
+	WatchActionLogs(id string) state.StringsWatcher
+}
+
+// actionStreamHandler takes requests to stream the progress messages
+// and output of a running action.
+type actionStreamHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP will serve up connections as a websocket streaming the
+// messages of the action given in the URL.
+//
+// The first message sent is a JSON formatted error, which is nil if
+// the stream was started. Each following message is a JSON encoded
+// params.ActionStreamRecords. The last of these has Done set, along
+// with the action's final status, and the connection is then closed.
+func (h *actionStreamHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+
+		st, err := h.stateForRequest(req)
+		if err != nil {
+			h.sendError(conn, err)
+			return
+		}
+		defer st.Release()
+
+		m, err := st.Model()
+		if err != nil {
+			h.sendError(conn, err)
+			return
+		}
+		id := req.URL.Query().Get(":action")
+		source := &actionStreamState{State: st.State, model: m}
+		if _, err := source.Action(id); err != nil {
+			h.sendError(conn, err)
+			return
+		}
+		h.sendError(conn, nil)
+
+		err = streamActionMessages(source, id, conn, h.ctxt.srv.clock, h.ctxt.stop())
+		if err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("action stream handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("action stream handler error: %v", err)
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// stateForRequest returns the state for the request's model, as long
+// as the authenticated user can read it.
+func (h *actionStreamHandler) stateForRequest(req *http.Request) (_ *state.PooledState, err error) {
+	st, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			st.Release()
+		}
+	}()
+	ok, err := common.HasPermission(
+		st.UserPermission,
+		entity.Tag(),
+		permission.ReadAccess,
+		names.NewModelTag(st.ModelUUID()),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	return st, nil
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *actionStreamHandler) sendError(conn *websocket.Conn, err error) {
+	if sendErr := conn.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		conn.Close()
+	}
+}
+
+// streamActionMessages writes the action's messages to the connection
+// as they are logged, until the action finishes or stop is closed.
+func streamActionMessages(source actionStreamSource, id string, conn messageWriter, clock clock.Clock, stop <-chan struct{}) error {
+	w := source.WatchActionLogs(id)
+	defer watcher.Stop(w, nil)
+
+	sent := 0
+	for {
+		select {
+		case <-stop:
+			return nil
+		case changes, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+			records, err := actionRecordsFromJSON(changes)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(records.Messages) > 0 {
+				if err := conn.WriteJSON(records); err != nil {
+					return errors.Trace(err)
+				}
+				sent += len(records.Messages)
+			}
+		case <-clock.After(actionStreamStatusInterval):
+		}
+
+		action, err := source.Action(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		switch action.Status() {
+		case state.ActionPending, state.ActionRunning:
+			continue
+		}
+		// The action has finished, so send any messages the watcher
+		// has not reported yet along with the final status.
+		final := params.ActionStreamRecords{
+			Status: string(action.Status()),
+			Done:   true,
+		}
+		if messages := action.Messages(); len(messages) > sent {
+			final.Messages = actionMessagesToParams(messages[sent:])
+		}
+		return errors.Trace(conn.WriteJSON(final))
+	}
+}
+
+func actionRecordsFromJSON(changes []string) (params.ActionStreamRecords, error) {
+	var records params.ActionStreamRecords
+	messages := make([]state.ActionMessage, len(changes))
+	for i, change := range changes {
+		if err := json.Unmarshal([]byte(change), &messages[i]); err != nil {
+			return records, errors.Annotate(err, "decoding action message")
+		}
+	}
+	records.Messages = actionMessagesToParams(messages)
+	return records, nil
+}
+
+func actionMessagesToParams(messages []state.ActionMessage) []params.ActionMessage {
+	result := make([]params.ActionMessage, len(messages))
+	for i, m := range messages {
+		result[i] = params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+			Stream:    m.Stream,
+		}
+	}
+	return result
+}
+
+// actionStreamState is an implementation of actionStreamSource.
+type actionStreamState struct {
+	*state.State
+	model *state.Model
+}
+
+// Action implements actionStreamSource.
+func (st *actionStreamState) Action(id string) (state.Action, error) {
+	return st.model.Action(id)
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type actionStreamIntSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&actionStreamIntSuite{})
+
+func (s *actionStreamIntSuite) TestStreamUntilFinished(c *gc.C) {
+	now := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	messages := []state.ActionMessage{
+		{Timestamp: now, Message: "starting"},
+		{Timestamp: now, Message: "hello", Stream: state.ActionStdout},
+	}
+	// The action finishes before its last message is reported by the
+	// watcher; the message is still sent, along with the final status.
+	source := newFakeActionStreamSource()
+	source.action.status = state.ActionCompleted
+	source.action.messages = messages
+	source.changes <- actionMessagesJSON(c, messages[:1])
+
+	writer := &fakeActionStreamWriter{}
+	err := streamActionMessages(source, "2", writer, testclock.NewClock(now), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(writer.records, jc.DeepEquals, []params.ActionStreamRecords{{
+		Messages: []params.ActionMessage{{Timestamp: now, Message: "starting"}},
+	}, {
+		Messages: []params.ActionMessage{{Timestamp: now, Message: "hello", Stream: "stdout"}},
+		Status:   "completed",
+		Done:     true,
+	}})
+	c.Assert(source.watcher.stopped, jc.IsTrue)
+}
+
+func (s *actionStreamIntSuite) TestStreamChecksStatus(c *gc.C) {
+	source := newFakeActionStreamSource()
+	source.action.status = state.ActionFailed
+	writer := &fakeActionStreamWriter{}
+	clock := testclock.NewClock(time.Now())
+	done := make(chan error, 1)
+	go func() {
+		done <- streamActionMessages(source, "2", writer, clock, nil)
+	}()
+
+	// With no messages logged, the status is checked periodically.
+	err := clock.WaitAdvance(actionStreamStatusInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for stream to finish")
+	}
+	c.Assert(writer.records, jc.DeepEquals, []params.ActionStreamRecords{{
+		Status: "failed",
+		Done:   true,
+	}})
+}
+
+func (s *actionStreamIntSuite) TestStreamStops(c *gc.C) {
+	source := newFakeActionStreamSource()
+	source.action.status = state.ActionRunning
+	stop := make(chan struct{})
+	close(stop)
+	writer := &fakeActionStreamWriter{}
+	err := streamActionMessages(source, "2", writer, testclock.NewClock(time.Now()), stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(writer.records, gc.HasLen, 0)
+}
+
+func actionMessagesJSON(c *gc.C, messages []state.ActionMessage) []string {
+	var changes []string
+	for _, m := range messages {
+		data, err := json.Marshal(m)
+		c.Assert(err, jc.ErrorIsNil)
+		changes = append(changes, string(data))
+	}
+	return changes
+}
+
+type fakeActionStreamSource struct {
+	action  *fakeStreamedAction
+	watcher *fakeActionLogsWatcher
+	changes chan []string
+}
+
+func newFakeActionStreamSource() *fakeActionStreamSource {
+	changes := make(chan []string, 1)
+	w := &fakeActionLogsWatcher{changes: changes}
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		return nil
+	})
+	return &fakeActionStreamSource{
+		action:  &fakeStreamedAction{},
+		watcher: w,
+		changes: changes,
+	}
+}
+
+func (s *fakeActionStreamSource) Action(id string) (state.Action, error) {
+	return s.action, nil
+}
+
+func (s *fakeActionStreamSource) WatchActionLogs(id string) state.StringsWatcher {
+	return s.watcher
+}
+
+type fakeStreamedAction struct {
+	state.Action
+	status   state.ActionStatus
+	messages []state.ActionMessage
+}
+
+func (a *fakeStreamedAction) Status() state.ActionStatus {
+	return a.status
+}
+
+func (a *fakeStreamedAction) Messages() []state.ActionMessage {
+	return a.messages
+}
+
+type fakeActionLogsWatcher struct {
+	tomb    tomb.Tomb
+	changes chan []string
+	stopped bool
+}
+
+func (w *fakeActionLogsWatcher) Changes() <-chan []string {
+	return w.changes
+}
+
+func (w *fakeActionLogsWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *fakeActionLogsWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *fakeActionLogsWatcher) Stop() error {
+	w.stopped = true
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *fakeActionLogsWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+type fakeActionStreamWriter struct {
+	records []params.ActionStreamRecords
+}
+
+func (w *fakeActionStreamWriter) WriteJSON(v interface{}) error {
+	w.records = append(w.records, v.(params.ActionStreamRecords))
+	return nil
+}
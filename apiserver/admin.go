@@ -152,6 +152,16 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
+	if auditRecorder != nil {
+		// Facades can record the details of changes which aren't
+		// evident from the request arguments alone.
+		if err := a.root.resources.RegisterNamed(
+			"auditRecorder",
+			common.ValueResource{Value: auditRecorder},
+		); err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
 
 	recorderFactory := observer.NewRecorderFactory(
 		a.apiObserver, auditRecorder, auditConfig.CaptureAPIArgs,
@@ -484,6 +484,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	httpCtxt := httpContext{srv: srv}
 	mainAPIHandler := http.HandlerFunc(srv.apiHandler)
 	logStreamHandler := newLogStreamEndpointHandler(httpCtxt)
+	actionStreamHandler := &actionStreamHandler{ctxt: httpCtxt}
 	debugLogHandler := newDebugLogDBHandler(
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.ControllerAgentTagKind, names.UserTagKind, names.ApplicationTagKind})
@@ -621,6 +622,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		pattern: modelRoutePrefix + "/logstream",
 		handler: logStreamHandler,
 		tracked: true,
+	}, {
+		pattern:    modelRoutePrefix + "/actions/:action/stream",
+		handler:    actionStreamHandler,
+		tracked:    true,
+		authorizer: tagKindAuthorizer{names.UserTagKind},
 	}, {
 		pattern: modelRoutePrefix + "/log",
 		handler: debugLogHandler,
@@ -20,6 +20,13 @@ type AddressAndCertGetter interface {
 	WatchAPIHostPortsForAgents() state.NotifyWatcher
 }
 
+// AddressFamilyGetter is implemented by address getters that know the
+// model's preferred address family. API addresses are prioritised for
+// IPv4 when a getter does not implement it.
+type AddressFamilyGetter interface {
+	PreferredAddressFamily() (network.AddressFamilyPreference, error)
+}
+
 // APIAddresser implements the APIAddresses method.
 // Note that the getter backing for this implies that it is suitable for use by
 // agents, which are bound by the configured controller management space.
@@ -76,9 +83,15 @@ func apiAddresses(getter APIHostPortsForAgentsGetter) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	family := network.PreferIPv4
+	if familyGetter, ok := getter.(AddressFamilyGetter); ok {
+		if family, err = familyGetter.PreferredAddressFamily(); err != nil {
+			return nil, err
+		}
+	}
 	var addrs = make([]string, 0, len(apiHostPorts))
 	for _, hostPorts := range apiHostPorts {
-		ordered := network.PrioritizeInternalHostPorts(hostPorts, false)
+		ordered := network.PrioritizeInternalHostPortsForFamily(hostPorts, false, family)
 		for _, addr := range ordered {
 			if addr != "" {
 				addrs = append(addrs, addr)
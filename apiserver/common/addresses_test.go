@@ -35,8 +35,10 @@ func (s *stateAddresserSuite) SetUpTest(c *gc.C) {
 	})
 }
 
-// Verify that AddressAndCertGetter is satisfied by *state.State.
+// Verify that AddressAndCertGetter and AddressFamilyGetter are satisfied
+// by *state.State.
 var _ common.AddressAndCertGetter = (*state.State)(nil)
+var _ common.AddressFamilyGetter = (*state.State)(nil)
 
 func (s *stateAddresserSuite) TestStateAddresses(c *gc.C) {
 	result, err := s.addresser.StateAddresses()
@@ -90,6 +92,23 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAPIAddressesPreferredAddressFamily(c *gc.C) {
+	ctlr, err := network.ParseHostPorts("10.0.2.1:17070", "[fc00::1]:17070")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.hostPorts = [][]network.HostPort{ctlr}
+	addresser := common.NewAPIAddresser(fakeFamilyAddresses{
+		fakeAddresses: s.fake,
+		family:        network.PreferIPv6,
+	}, common.NewResources())
+
+	result, err := addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Result, gc.DeepEquals, []string{
+		"[fc00::1]:17070",
+		"10.0.2.1:17070",
+	})
+}
+
 func (s *apiAddresserSuite) TestModelUUID(c *gc.C) {
 	result := s.addresser.ModelUUID()
 	c.Assert(result.Result, gc.Equals, "the model uuid")
@@ -120,3 +139,12 @@ func (f fakeAddresses) APIHostPortsForAgents() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPortsForAgents() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+type fakeFamilyAddresses struct {
+	*fakeAddresses
+	family network.AddressFamilyPreference
+}
+
+func (f fakeFamilyAddresses) PreferredAddressFamily() (network.AddressFamilyPreference, error) {
+	return f.family, nil
+}
@@ -55,8 +55,14 @@ func (s *ControllerConfigAPI) ControllerAPIInfoForModels(args params.Entities) (
 			result.Results[i].Error = ServerError(err)
 			continue
 		}
+		relayProxy, err := s.st.ControllerRelayProxy(modelTag.Id())
+		if err != nil {
+			result.Results[i].Error = ServerError(err)
+			continue
+		}
 		result.Results[i].Addresses = addrs
 		result.Results[i].CACert = caCert
+		result.Results[i].RelayProxy = relayProxy
 	}
 	return result, nil
 }
@@ -85,6 +91,25 @@ func (s *controllerStateShim) ControllerInfo(modelUUID string) (addrs []string,
 	return info.ControllerInfo().Addrs, info.ControllerInfo().CACert, nil
 }
 
+// ControllerRelayProxy returns the proxy through which to connect to the
+// controller hosting the specified model. Models hosted by this controller
+// are always connected to directly.
+func (s *controllerStateShim) ControllerRelayProxy(modelUUID string) (string, error) {
+	modelExists, err := s.State.ModelExists(modelUUID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if modelExists {
+		return "", nil
+	}
+	ec := state.NewExternalControllers(s.State)
+	info, err := ec.ControllerForModel(modelUUID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.ControllerInfo().RelayProxy, nil
+}
+
 // StateControllerInfo returns the local controller details for the given State.
 func StateControllerInfo(st *state.State) (addrs []string, caCert string, _ error) {
 	addr, err := apiAddresses(st)
@@ -54,6 +54,13 @@ func (f *fakeControllerAccessor) ControllerInfo(modelUUID string) ([]string, str
 	return []string{"192.168.1.1:17070"}, testing.CACert, nil
 }
 
+func (f *fakeControllerAccessor) ControllerRelayProxy(modelUUID string) (string, error) {
+	if modelUUID != testing.ModelTag.Id() {
+		return "", errors.New("wrong model")
+	}
+	return "", nil
+}
+
 func (s *controllerConfigSuite) TearDownTest(c *gc.C) {
 	dummy.Reset(c)
 	s.BaseSuite.TearDownTest(c)
@@ -126,6 +133,7 @@ func (s *controllerInfoSuite) TestControllerInfoLocalModel(c *gc.C) {
 	c.Assert(results.Results[0].Addresses, gc.HasLen, 1)
 	c.Assert(results.Results[0].Addresses[0], gc.Equals, apiAddr[0][0].String())
 	c.Assert(results.Results[0].CACert, gc.Equals, testing.CACert)
+	c.Assert(results.Results[0].RelayProxy, gc.Equals, "")
 }
 
 func (s *controllerInfoSuite) TestControllerInfoExternalModel(c *gc.C) {
@@ -146,3 +154,23 @@ func (s *controllerInfoSuite) TestControllerInfoExternalModel(c *gc.C) {
 	c.Assert(results.Results[0].Addresses, gc.DeepEquals, info.Addrs)
 	c.Assert(results.Results[0].CACert, gc.Equals, info.CACert)
 }
+
+func (s *controllerInfoSuite) TestControllerInfoExternalModelRelayProxy(c *gc.C) {
+	ec := state.NewExternalControllers(s.State)
+	modelUUID := utils.MustNewUUID().String()
+	info := crossmodel.ControllerInfo{
+		ControllerTag: testing.ControllerTag,
+		Addrs:         []string{"10.0.0.1:17070"},
+		CACert:        testing.CACert,
+		RelayProxy:    "http://relay.example.com:3128",
+	}
+	_, err := ec.Save(info, modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	cc := common.NewStateControllerConfig(s.State)
+	results, err := cc.ControllerAPIInfoForModels(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Addresses, gc.DeepEquals, info.Addrs)
+	c.Assert(results.Results[0].RelayProxy, gc.Equals, "http://relay.example.com:3128")
+}
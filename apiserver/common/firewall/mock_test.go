@@ -83,6 +83,14 @@ func (st *mockState) ControllerInfo(modelUUID string) ([]string, string, error)
 	}
 }
 
+func (st *mockState) ControllerRelayProxy(modelUUID string) (string, error) {
+	if info, ok := st.controllerInfo[modelUUID]; !ok {
+		return "", errors.NotFoundf("controller info for %v", modelUUID)
+	} else {
+		return info.ControllerInfo().RelayProxy, nil
+	}
+}
+
 func (st *mockState) GetMacaroon(model names.ModelTag, entity names.Tag) (*macaroon.Macaroon, error) {
 	st.MethodCall(st, "GetMacaroon", model, entity)
 	if err := st.NextErr(); err != nil {
@@ -30,6 +30,8 @@ func BlockDeviceFromState(in state.BlockDeviceInfo) storage.BlockDevice {
 		InUse:          in.InUse,
 		MountPoint:     in.MountPoint,
 		SerialId:       in.SerialId,
+		UsedSize:       in.UsedSize,
+		FreeSize:       in.FreeSize,
 	}
 }
 
@@ -25,11 +25,12 @@ func FilesystemParams(
 	registry storage.ProviderRegistry,
 ) (params.FilesystemParams, error) {
 
-	var pool string
+	var pool, snapshotId string
 	var size uint64
 	if stateFilesystemParams, ok := f.Params(); ok {
 		pool = stateFilesystemParams.Pool
 		size = stateFilesystemParams.Size
+		snapshotId = stateFilesystemParams.SnapshotId
 	} else {
 		filesystemInfo, err := f.Info()
 		if err != nil {
@@ -56,6 +57,7 @@ func FilesystemParams(
 		cfg.Attrs(),
 		filesystemTags,
 		nil, // attachment params set by the caller
+		snapshotId,
 	}
 
 	volumeTag, err := f.Volume()
@@ -19,6 +19,7 @@ type fakeStorage struct {
 	storagecommon.StorageAccess
 	storagecommon.FilesystemAccess
 	storageInstance           func(names.StorageTag) (state.StorageInstance, error)
+	volume                    func(names.VolumeTag) (state.Volume, error)
 	storageInstanceVolume     func(names.StorageTag) (state.Volume, error)
 	storageInstanceFilesystem func(names.StorageTag) (state.Filesystem, error)
 	volumeAttachment          func(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
@@ -32,6 +33,11 @@ func (s *fakeStorage) StorageInstance(tag names.StorageTag) (state.StorageInstan
 	return s.storageInstance(tag)
 }
 
+func (s *fakeStorage) Volume(tag names.VolumeTag) (state.Volume, error) {
+	s.MethodCall(s, "Volume", tag)
+	return s.volume(tag)
+}
+
 func (s *fakeStorage) StorageInstanceVolume(tag names.StorageTag) (state.Volume, error) {
 	s.MethodCall(s, "StorageInstanceVolume", tag)
 	return s.storageInstanceVolume(tag)
@@ -67,6 +73,7 @@ type fakeStorageInstance struct {
 	tag   names.StorageTag
 	owner names.Tag
 	kind  state.StorageKind
+	move  *state.StorageMove
 }
 
 func (i *fakeStorageInstance) StorageTag() names.StorageTag {
@@ -85,6 +92,13 @@ func (i *fakeStorageInstance) Kind() state.StorageKind {
 	return i.kind
 }
 
+func (i *fakeStorageInstance) Move() (state.StorageMove, bool) {
+	if i.move == nil {
+		return state.StorageMove{}, false
+	}
+	return *i.move, true
+}
+
 type fakeStorageAttachment struct {
 	state.StorageAttachment
 	storageTag names.StorageTag
@@ -96,9 +110,10 @@ func (a *fakeStorageAttachment) StorageInstance() names.StorageTag {
 
 type fakeVolume struct {
 	state.Volume
-	tag    names.VolumeTag
-	params *state.VolumeParams
-	info   *state.VolumeInfo
+	tag       names.VolumeTag
+	params    *state.VolumeParams
+	info      *state.VolumeInfo
+	encrypted bool
 }
 
 func (v *fakeVolume) VolumeTag() names.VolumeTag {
@@ -109,6 +124,10 @@ func (v *fakeVolume) Tag() names.Tag {
 	return v.tag
 }
 
+func (v *fakeVolume) Encrypted() bool {
+	return v.encrypted
+}
+
 func (v *fakeVolume) Params() (state.VolumeParams, bool) {
 	if v.params == nil {
 		return state.VolumeParams{}, false
@@ -6,6 +6,8 @@
 package storagecommon
 
 import (
+	"path"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
@@ -30,6 +32,9 @@ type StorageAccess interface {
 // VolumeAccess is an interface for obtaining information about
 // block storage instances and related entities.
 type VolumeAccess interface {
+	// Volume returns the state.Volume with the specified tag.
+	Volume(names.VolumeTag) (state.Volume, error)
+
 	// StorageInstanceVolume returns the state.Volume assigned to the
 	// storage instance with the specified storage tag.
 	StorageInstanceVolume(names.StorageTag) (state.Volume, error)
@@ -95,6 +100,68 @@ func StorageAttachmentInfo(
 	return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 }
 
+// StorageAttachmentUsage returns the used and free capacity, in MiB, of
+// the specified StorageAttachment, as last reported by the agent of the
+// machine it is attached to. Usage is only known for storage that is
+// mounted on the machine.
+//
+// StorageAttachmentUsage returns an error satisfying errors.IsNotFound
+// if no usage has been reported for the storage attachment.
+func StorageAttachmentUsage(
+	st StorageAccess,
+	stVolume VolumeAccess,
+	stFile FilesystemAccess,
+	att state.StorageAttachment,
+	machineTag names.MachineTag,
+) (used, free uint64, _ error) {
+	if stVolume == nil {
+		return 0, 0, errors.NotImplementedf("storage usage without block devices")
+	}
+	storageInstance, err := st.StorageInstance(att.StorageInstance())
+	if err != nil {
+		return 0, 0, errors.Annotate(err, "getting storage instance")
+	}
+	storageTag := storageInstance.StorageTag()
+	var blockDevice *state.BlockDeviceInfo
+	switch storageInstance.Kind() {
+	case state.StorageKindBlock:
+		volume, err := stVolume.StorageInstanceVolume(storageTag)
+		if err != nil {
+			return 0, 0, errors.Annotate(err, "getting volume")
+		}
+		blockDevice, _, _, err = volumeBlockDevice(stVolume, volume, machineTag)
+		if errors.IsNotProvisioned(err) {
+			return 0, 0, errors.NotFoundf("usage of %s", names.ReadableString(storageTag))
+		} else if err != nil {
+			return 0, 0, errors.Trace(err)
+		}
+	case state.StorageKindFilesystem:
+		if stFile == nil {
+			return 0, 0, errors.NotImplementedf("FilesystemStorage instance")
+		}
+		info, err := filesystemStorageAttachmentInfo(stFile, storageInstance, machineTag)
+		if err != nil {
+			return 0, 0, errors.Trace(err)
+		}
+		blockDevices, err := stVolume.BlockDevices(machineTag)
+		if err != nil {
+			return 0, 0, errors.Annotate(err, "getting block devices")
+		}
+		for i, dev := range blockDevices {
+			if dev.MountPoint != "" && dev.MountPoint == info.Location {
+				blockDevice = &blockDevices[i]
+				break
+			}
+		}
+	default:
+		return 0, 0, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
+	}
+	if blockDevice == nil || blockDevice.UsedSize+blockDevice.FreeSize == 0 {
+		return 0, 0, errors.NotFoundf("usage of %s", names.ReadableString(storageTag))
+	}
+	return blockDevice.UsedSize, blockDevice.FreeSize, nil
+}
+
 func volumeStorageAttachmentInfo(
 	st VolumeAccess,
 	storageInstance state.StorageInstance,
@@ -111,41 +178,110 @@ func volumeStorageAttachmentInfo(
 	} else if err != nil {
 		return nil, errors.Annotate(err, "getting volume")
 	}
-	volumeInfo, err := volume.Info()
+	// TODO(caas) - we currently only support block devices on machines.
+	if hostTag.Kind() != names.MachineTagKind {
+		return nil, errors.NotProvisionedf("%v", names.ReadableString(storageTag))
+	}
+	devicePath, size, err := volumeBlockDevicePath(st, volume, hostTag.(names.MachineTag))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: devicePath,
+		Size:     size,
+	}
+	if move, ok := storageInstance.Move(); ok {
+		// The storage is being moved to a volume in another
+		// pool. Once that volume's block device has shown up
+		// on the machine, the unit may copy the storage
+		// contents to it.
+		target, err := st.Volume(move.Volume)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting move target volume")
+		}
+		targetPath, _, err := volumeBlockDevicePath(st, target, hostTag.(names.MachineTag))
+		if err == nil {
+			info.MoveTarget = targetPath
+			info.MoveCopied = move.Copied
+		} else if !errors.IsNotProvisioned(err) && !errors.IsNotFound(err) {
+			return nil, errors.Annotate(err, "getting move target device path")
+		}
+	}
+	return info, nil
+}
+
+// volumeBlockDevicePath returns the absolute device path and size of
+// the block device for the given volume on the specified machine.
+// volumeBlockDevicePath returns an error satisfying errors.IsNotProvisioned
+// if the volume's block device has not yet shown up on the machine.
+func volumeBlockDevicePath(
+	st VolumeAccess,
+	volume state.Volume,
+	machineTag names.MachineTag,
+) (string, uint64, error) {
+	blockDevice, volumeInfo, volumeAttachmentInfo, err := volumeBlockDevice(st, volume, machineTag)
+	if err != nil {
+		return "", 0, errors.Trace(err)
+	}
+	devicePath, err := volumeAttachmentDevicePath(
+		volumeInfo,
+		volumeAttachmentInfo,
+		*blockDevice,
+	)
+	if err != nil {
+		return "", 0, errors.Trace(err)
+	}
+	if volume.Encrypted() {
+		// The charm must only ever see the decrypted device,
+		// which the machine agent opens on top of the
+		// attached block device.
+		devicePath = path.Join("/dev", storage.EncryptedDeviceName(volume.VolumeTag()))
+	}
+	return devicePath, blockDevice.Size, nil
+}
+
+// volumeBlockDevice returns the block device published for the given
+// volume on the specified machine, along with the volume and volume
+// attachment info used to match it. volumeBlockDevice returns an error
+// satisfying errors.IsNotProvisioned if the volume's block device has
+// not yet shown up on the machine.
+func volumeBlockDevice(
+	st VolumeAccess,
+	volume state.Volume,
+	machineTag names.MachineTag,
+) (_ *state.BlockDeviceInfo, volumeInfo state.VolumeInfo, volumeAttachmentInfo state.VolumeAttachmentInfo, err error) {
+	volumeInfo, err = volume.Info()
 	if err != nil {
-		return nil, errors.Annotate(err, "getting volume info")
+		return nil, volumeInfo, volumeAttachmentInfo, errors.Annotate(err, "getting volume info")
 	}
-	volumeAttachment, err := st.VolumeAttachment(hostTag, volume.VolumeTag())
+	volumeAttachment, err := st.VolumeAttachment(machineTag, volume.VolumeTag())
 	if err != nil {
-		return nil, errors.Annotate(err, "getting volume attachment")
+		return nil, volumeInfo, volumeAttachmentInfo, errors.Annotate(err, "getting volume attachment")
 	}
-	volumeAttachmentInfo, err := volumeAttachment.Info()
+	volumeAttachmentInfo, err = volumeAttachment.Info()
 	if err != nil {
-		return nil, errors.Annotate(err, "getting volume attachment info")
+		return nil, volumeInfo, volumeAttachmentInfo, errors.Annotate(err, "getting volume attachment info")
 	}
 
 	blockDeviceInfo := state.BlockDeviceInfo{}
-	volumeAttachmentPlan, err := st.VolumeAttachmentPlan(hostTag, volume.VolumeTag())
+	volumeAttachmentPlan, err := st.VolumeAttachmentPlan(machineTag, volume.VolumeTag())
 	if err != nil {
 		if !errors.IsNotFound(err) {
-			return nil, errors.Annotate(err, "getting attachment plans")
+			return nil, volumeInfo, volumeAttachmentInfo, errors.Annotate(err, "getting attachment plans")
 		}
 	} else {
 		blockDeviceInfo, err = volumeAttachmentPlan.BlockDeviceInfo()
 		if err != nil {
 			if !errors.IsNotFound(err) {
-				return nil, errors.Annotate(err, "getting block device info")
+				return nil, volumeInfo, volumeAttachmentInfo, errors.Annotate(err, "getting block device info")
 			}
 		}
 	}
 
-	// TODO(caas) - we currently only support block devices on machines.
-	if hostTag.Kind() != names.MachineTagKind {
-		return nil, errors.NotProvisionedf("%v", names.ReadableString(storageTag))
-	}
-	blockDevices, err := st.BlockDevices(hostTag.(names.MachineTag))
+	blockDevices, err := st.BlockDevices(machineTag)
 	if err != nil {
-		return nil, errors.Annotate(err, "getting block devices")
+		return nil, volumeInfo, volumeAttachmentInfo, errors.Annotate(err, "getting block devices")
 	}
 	blockDevice, ok := MatchingBlockDevice(
 		blockDevices,
@@ -158,20 +294,9 @@ func volumeStorageAttachmentInfo(
 		// provisioned until its block device has shown up on the
 		// machine, otherwise the charm may attempt to use it and
 		// fail.
-		return nil, errors.NotProvisionedf("%v", names.ReadableString(storageTag))
+		return nil, volumeInfo, volumeAttachmentInfo, errors.NotProvisionedf("%v", names.ReadableString(volume.VolumeTag()))
 	}
-	devicePath, err := volumeAttachmentDevicePath(
-		volumeInfo,
-		volumeAttachmentInfo,
-		*blockDevice,
-	)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return &storage.StorageAttachmentInfo{
-		storage.StorageKindBlock,
-		devicePath,
-	}, nil
+	return blockDevice, volumeInfo, volumeAttachmentInfo, nil
 }
 
 func filesystemStorageAttachmentInfo(
@@ -198,10 +323,33 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
-	return &storage.StorageAttachmentInfo{
-		storage.StorageKindFilesystem,
-		filesystemAttachmentInfo.MountPoint,
-	}, nil
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
+	info := &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindFilesystem,
+		Location: filesystemAttachmentInfo.MountPoint,
+		Size:     filesystemInfo.Size,
+	}
+	if move, ok := storageInstance.Move(); ok {
+		// The storage is being moved to a filesystem in another
+		// pool. Once that filesystem has been mounted on the
+		// host, the unit may copy the storage contents to it.
+		targetAttachment, err := st.FilesystemAttachment(hostTag, move.Filesystem)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotate(err, "getting move target filesystem attachment")
+		} else if err == nil {
+			targetInfo, err := targetAttachment.Info()
+			if err == nil {
+				info.MoveTarget = targetInfo.MountPoint
+				info.MoveCopied = move.Copied
+			} else if !errors.IsNotProvisioned(err) {
+				return nil, errors.Annotate(err, "getting move target filesystem attachment info")
+			}
+		}
+	}
+	return info, nil
 }
 
 // volumeAttachmentDevicePath returns the absolute device path for
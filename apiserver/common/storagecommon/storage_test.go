@@ -113,6 +113,61 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDe
 	})
 }
 
+func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoEncrypted(c *gc.C) {
+	s.volume.encrypted = true
+	s.volumeAttachment.info.DeviceName = "sda"
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceVolume", "VolumeAttachment", "VolumeAttachmentPlan", "BlockDevices")
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: "/dev/mapper/juju-0",
+	})
+}
+
+func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoMoveTarget(c *gc.C) {
+	targetTag := names.NewVolumeTag("1")
+	s.storageInstance.move = &state.StorageMove{Pool: "fast", Volume: targetTag, Copied: 4096}
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.st.volume = func(tag names.VolumeTag) (state.Volume, error) {
+		return &fakeVolume{tag: tag, info: &state.VolumeInfo{VolumeId: "vol-fast"}}, nil
+	}
+	s.st.volumeAttachment = func(m names.Tag, v names.VolumeTag) (state.VolumeAttachment, error) {
+		if v == targetTag {
+			return &fakeVolumeAttachment{
+				info: &state.VolumeAttachmentInfo{DeviceName: "sdb"},
+			}, nil
+		}
+		return s.volumeAttachment, nil
+	}
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCallNames(c,
+		"StorageInstance", "StorageInstanceVolume", "VolumeAttachment", "VolumeAttachmentPlan", "BlockDevices",
+		"Volume", "VolumeAttachment", "VolumeAttachmentPlan", "BlockDevices",
+	)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:       storage.StorageKindBlock,
+		Location:   "/dev/sda",
+		MoveTarget: "/dev/sdb",
+		MoveCopied: 4096,
+	})
+}
+
+func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoMoveTargetNotAttached(c *gc.C) {
+	s.storageInstance.move = &state.StorageMove{Pool: "fast", Volume: names.NewVolumeTag("1")}
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.st.volume = func(tag names.VolumeTag) (state.Volume, error) {
+		return &fakeVolume{tag: tag}, nil
+	}
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: "/dev/sda",
+	})
+}
+
 func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoMissingBlockDevice(c *gc.C) {
 	// If the block device has not shown up yet,
 	// then we should get a NotProvisioned error.
@@ -209,6 +264,23 @@ func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentInfoVolumeNotFou
 	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceVolume")
 }
 
+func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentUsage(c *gc.C) {
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.blockDevices[0].MountPoint = "/srv"
+	s.blockDevices[0].UsedSize = 100
+	s.blockDevices[0].FreeSize = 900
+	used, free, err := storagecommon.StorageAttachmentUsage(s.st, s.st, s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(used, gc.Equals, uint64(100))
+	c.Assert(free, gc.Equals, uint64(900))
+}
+
+func (s *VolumeStorageAttachmentInfoSuite) TestStorageAttachmentUsageNotReported(c *gc.C) {
+	s.volumeAttachment.info.DeviceName = "sda"
+	_, _, err := storagecommon.StorageAttachmentUsage(s.st, s.st, s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type FilesystemStorageAttachmentInfoSuite struct {
 	hostTag              names.Tag
 	filsystemTag         names.FilesystemTag
@@ -266,6 +338,49 @@ func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfo(c *gc.C
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindFilesystem,
 		Location: "/path/to/here",
+		Size:     1024,
+	})
+}
+
+func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfoMoveTarget(c *gc.C) {
+	targetTag := names.NewFilesystemTag("1")
+	s.storageInstance.move = &state.StorageMove{Pool: "fast", Filesystem: targetTag}
+	s.filesystemAttachment.info.MountPoint = "/path/to/here"
+	s.st.filesystemAttachment = func(m names.Tag, fs names.FilesystemTag) (state.FilesystemAttachment, error) {
+		if fs == targetTag {
+			return &fakeFilesystemAttachment{
+				info: &state.FilesystemAttachmentInfo{MountPoint: "/path/to/there"},
+			}, nil
+		}
+		return s.filesystemAttachment, nil
+	}
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.hostTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceFilesystem", "FilesystemAttachment", "FilesystemAttachment")
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:       storage.StorageKindFilesystem,
+		Location:   "/path/to/here",
+		Size:       1024,
+		MoveTarget: "/path/to/there",
+	})
+}
+
+func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfoMoveTargetNotMounted(c *gc.C) {
+	targetTag := names.NewFilesystemTag("1")
+	s.storageInstance.move = &state.StorageMove{Pool: "fast", Filesystem: targetTag}
+	s.filesystemAttachment.info.MountPoint = "/path/to/here"
+	s.st.filesystemAttachment = func(m names.Tag, fs names.FilesystemTag) (state.FilesystemAttachment, error) {
+		if fs == targetTag {
+			return &fakeFilesystemAttachment{}, nil
+		}
+		return s.filesystemAttachment, nil
+	}
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.st, s.st, s.storageAttachment, s.hostTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindFilesystem,
+		Location: "/path/to/here",
+		Size:     1024,
 	})
 }
 
@@ -277,3 +392,24 @@ func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentInfoFilesyst
 	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
 	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceFilesystem")
 }
+
+func (s *FilesystemStorageAttachmentInfoSuite) TestStorageAttachmentUsage(c *gc.C) {
+	s.filesystemAttachment.info.MountPoint = "/path/to/here"
+	s.st.blockDevices = func(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
+		return []state.BlockDeviceInfo{{
+			DeviceName: "sda",
+			MountPoint: "/",
+			UsedSize:   1000,
+			FreeSize:   1000,
+		}, {
+			DeviceName: "sdb",
+			MountPoint: "/path/to/here",
+			UsedSize:   300,
+			FreeSize:   700,
+		}}, nil
+	}
+	used, free, err := storagecommon.StorageAttachmentUsage(s.st, s.st, s.st, s.storageAttachment, names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(used, gc.Equals, uint64(300))
+	c.Assert(free, gc.Equals, uint64(700))
+}
@@ -4,6 +4,12 @@
 package diskmanager
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
@@ -13,6 +19,8 @@ import (
 	"github.com/juju/juju/storage"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.diskmanager")
+
 // DiskManagerAPI provides access to the DiskManager API facade.
 type DiskManagerAPI struct {
 	st          stateInterface
@@ -80,12 +88,95 @@ func (d *DiskManagerAPI) SetMachineBlockDevices(args params.SetMachineBlockDevic
 			// volumes.
 			err = d.st.SetMachineBlockDevices(tag.Id(), stateBlockDeviceInfo(arg.BlockDevices))
 			// TODO(axw) set volume/filesystem attachment info.
+			if err == nil {
+				// The block devices have been recorded, so failing
+				// to update the storage usage warnings is not fatal.
+				if err := d.updateStorageUsageWarnings(tag.Id()); err != nil {
+					logger.Warningf("updating storage usage warnings for machine %q: %v", tag.Id(), err)
+				}
+			}
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
 }
 
+// updateStorageUsageWarnings sets a warning on the status of each
+// storage instance attached to the machine whose usage exceeds the
+// model's storage usage warning threshold, and sets the workload status
+// of the units that the storage is attached to to warning. The warnings
+// are cleared from storage and units whose usage does not exceed it.
+func (d *DiskManagerAPI) updateStorageUsageWarnings(machineId string) error {
+	threshold, err := d.st.StorageUsageWarningThreshold()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitUsage, err := d.st.MachineStorageUsage(machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitNames := make([]string, 0, len(unitUsage))
+	for unitName := range unitUsage {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	// Shared storage may be attached to several of the units.
+	warned := set.NewStrings()
+	for _, unitName := range unitNames {
+		var worst *storageUsage
+		var worstPercent int
+		for i, u := range unitUsage[unitName] {
+			percent, ok := storageUsagePercent(u, threshold)
+			if ok && (worst == nil || percent > worstPercent) {
+				worst, worstPercent = &unitUsage[unitName][i], percent
+			}
+			if warned.Contains(u.StorageTag.Id()) {
+				continue
+			}
+			warned.Add(u.StorageTag.Id())
+			var message string
+			if ok {
+				message = storageUsageWarning(percent, threshold)
+			}
+			if err := d.st.SetStorageUsageWarning(u.StorageTag, message); err != nil {
+				return errors.Annotatef(err, "setting storage usage warning for storage %q", u.StorageTag.Id())
+			}
+		}
+		var message string
+		if worst != nil {
+			message = fmt.Sprintf(
+				"storage %s is %s", worst.StorageTag.Id(),
+				storageUsageWarning(worstPercent, threshold),
+			)
+		}
+		if err := d.st.SetUnitStorageUsageWarning(unitName, message); err != nil {
+			return errors.Annotatef(err, "setting storage usage warning for unit %q", unitName)
+		}
+	}
+	return nil
+}
+
+// storageUsagePercent returns the percentage of the storage instance's
+// capacity that is used, and whether that exceeds the threshold.
+// A threshold of zero disables warnings.
+func storageUsagePercent(usage storageUsage, threshold int) (int, bool) {
+	if threshold <= 0 {
+		return 0, false
+	}
+	total := usage.Used + usage.Free
+	if total == 0 {
+		return 0, false
+	}
+	percent := int(usage.Used * 100 / total)
+	return percent, percent >= threshold
+}
+
+// storageUsageWarning returns the warning message for storage whose
+// usage, as a percentage of its capacity, exceeds the threshold.
+func storageUsageWarning(percent, threshold int) string {
+	return fmt.Sprintf("%d%% full (warning threshold %d%%)", percent, threshold)
+}
+
 func stateBlockDeviceInfo(devices []storage.BlockDevice) []state.BlockDeviceInfo {
 	result := make([]state.BlockDeviceInfo, len(devices))
 	for i, dev := range devices {
@@ -102,6 +193,8 @@ func stateBlockDeviceInfo(devices []storage.BlockDevice) []state.BlockDeviceInfo
 			InUse:          dev.InUse,
 			MountPoint:     dev.MountPoint,
 			SerialId:       dev.SerialId,
+			UsedSize:       dev.UsedSize,
+			FreeSize:       dev.FreeSize,
 		}
 	}
 	return result
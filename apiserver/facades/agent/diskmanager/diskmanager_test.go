@@ -56,6 +56,67 @@ func (s *DiskManagerSuite) TestSetMachineBlockDevices(c *gc.C) {
 	})
 }
 
+func (s *DiskManagerSuite) TestSetMachineBlockDevicesStorageUsageWarnings(c *gc.C) {
+	s.st.threshold = 90
+	s.st.usage = map[string][]diskmanager.StorageUsage{
+		"mysql/0": {{
+			StorageTag: names.NewStorageTag("data/0"),
+			Used:       910,
+			Free:       90,
+		}, {
+			StorageTag: names.NewStorageTag("logs/0"),
+			Used:       950,
+			Free:       50,
+		}},
+		"mysql/1": {{
+			StorageTag: names.NewStorageTag("data/1"),
+			Used:       100,
+			Free:       900,
+		}, {
+			// Shared with mysql/0.
+			StorageTag: names.NewStorageTag("logs/0"),
+			Used:       950,
+			Free:       50,
+		}},
+		"logging/0": nil,
+	}
+	results, err := s.api.SetMachineBlockDevices(params.SetMachineBlockDevices{
+		MachineBlockDevices: []params.MachineBlockDevices{{
+			Machine: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.st.warnings, jc.DeepEquals, map[string]string{
+		"data/0": "91% full (warning threshold 90%)",
+		"logs/0": "95% full (warning threshold 90%)",
+		"data/1": "",
+	})
+	c.Assert(s.st.unitWarnings, jc.DeepEquals, map[string]string{
+		"mysql/0":   "storage logs/0 is 95% full (warning threshold 90%)",
+		"mysql/1":   "storage logs/0 is 95% full (warning threshold 90%)",
+		"logging/0": "",
+	})
+}
+
+func (s *DiskManagerSuite) TestSetMachineBlockDevicesStorageUsageWarningsDisabled(c *gc.C) {
+	s.st.usage = map[string][]diskmanager.StorageUsage{
+		"mysql/0": {{
+			StorageTag: names.NewStorageTag("data/0"),
+			Used:       1000,
+		}},
+	}
+	results, err := s.api.SetMachineBlockDevices(params.SetMachineBlockDevices{
+		MachineBlockDevices: []params.MachineBlockDevices{{
+			Machine: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.st.warnings, jc.DeepEquals, map[string]string{"data/0": ""})
+	c.Assert(s.st.unitWarnings, jc.DeepEquals, map[string]string{"mysql/0": ""})
+}
+
 func (s *DiskManagerSuite) TestSetMachineBlockDevicesEmptyArgs(c *gc.C) {
 	results, err := s.api.SetMachineBlockDevices(params.SetMachineBlockDevices{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -108,9 +169,13 @@ func (s *DiskManagerSuite) TestSetMachineBlockDevicesStateError(c *gc.C) {
 }
 
 type mockState struct {
-	calls   int
-	devices map[string][]state.BlockDeviceInfo
-	err     error
+	calls        int
+	devices      map[string][]state.BlockDeviceInfo
+	err          error
+	threshold    int
+	usage        map[string][]diskmanager.StorageUsage
+	warnings     map[string]string
+	unitWarnings map[string]string
 }
 
 func (st *mockState) SetMachineBlockDevices(machineId string, devices []state.BlockDeviceInfo) error {
@@ -121,3 +186,27 @@ func (st *mockState) SetMachineBlockDevices(machineId string, devices []state.Bl
 	st.devices[machineId] = devices
 	return st.err
 }
+
+func (st *mockState) StorageUsageWarningThreshold() (int, error) {
+	return st.threshold, nil
+}
+
+func (st *mockState) MachineStorageUsage(machineId string) (map[string][]diskmanager.StorageUsage, error) {
+	return st.usage, nil
+}
+
+func (st *mockState) SetStorageUsageWarning(tag names.StorageTag, message string) error {
+	if st.warnings == nil {
+		st.warnings = make(map[string]string)
+	}
+	st.warnings[tag.Id()] = message
+	return nil
+}
+
+func (st *mockState) SetUnitStorageUsageWarning(unitName, message string) error {
+	if st.unitWarnings == nil {
+		st.unitWarnings = make(map[string]string)
+	}
+	st.unitWarnings[unitName] = message
+	return nil
+}
@@ -7,6 +7,8 @@ import "github.com/juju/juju/state"
 
 type StateInterface stateInterface
 
+type StorageUsage = storageUsage
+
 type Patcher interface {
 	PatchValue(ptr, value interface{})
 }
@@ -3,10 +3,40 @@
 
 package diskmanager
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/state"
+)
 
 type stateInterface interface {
 	SetMachineBlockDevices(machineId string, devices []state.BlockDeviceInfo) error
+
+	// StorageUsageWarningThreshold returns the percentage of a storage
+	// instance's capacity that may be used before its units are warned.
+	StorageUsageWarningThreshold() (int, error)
+
+	// MachineStorageUsage returns the usage of the storage attached
+	// to each unit on the specified machine, keyed by unit name.
+	MachineStorageUsage(machineId string) (map[string][]storageUsage, error)
+
+	// SetStorageUsageWarning sets, or clears if the message is
+	// empty, the usage warning on the specified storage instance.
+	SetStorageUsageWarning(tag names.StorageTag, message string) error
+
+	// SetUnitStorageUsageWarning sets, or clears if the message is
+	// empty, the storage usage warning on the specified unit.
+	SetUnitStorageUsageWarning(unitName, message string) error
+}
+
+// storageUsage records the used and free capacity, in MiB,
+// of a storage instance.
+type storageUsage struct {
+	StorageTag names.StorageTag
+	Used       uint64
+	Free       uint64
 }
 
 type stateShim struct {
@@ -20,3 +50,69 @@ func (s stateShim) SetMachineBlockDevices(machineId string, devices []state.Bloc
 	}
 	return m.SetMachineBlockDevices(devices...)
 }
+
+func (s stateShim) StorageUsageWarningThreshold() (int, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.StorageUsageWarningThreshold(), nil
+}
+
+func (s stateShim) MachineStorageUsage(machineId string) (map[string][]storageUsage, error) {
+	m, err := s.State.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]storageUsage)
+	for _, u := range units {
+		attachments, err := sb.UnitStorageAttachments(u.UnitTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		usage := []storageUsage{}
+		for _, a := range attachments {
+			used, free, err := storagecommon.StorageAttachmentUsage(sb, sb, sb, a, m.MachineTag())
+			if errors.IsNotFound(err) || errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			usage = append(usage, storageUsage{
+				StorageTag: a.StorageInstance(),
+				Used:       used,
+				Free:       free,
+			})
+		}
+		result[u.Name()] = usage
+	}
+	return result, nil
+}
+
+func (s stateShim) SetStorageUsageWarning(tag names.StorageTag, message string) error {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return sb.SetStorageUsageWarning(tag, message)
+}
+
+func (s stateShim) SetUnitStorageUsageWarning(unitName, message string) error {
+	u, err := s.State.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return u.SetStorageUsageWarning(message)
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/collections/set"
+
+	"github.com/juju/juju/state"
+)
+
+type StateInterface stateInterface
+
+type Patcher interface {
+	PatchValue(ptr, value interface{})
+}
+
+func PatchState(p Patcher, st StateInterface) {
+	p.PatchValue(&getState, func(*state.State) stateInterface {
+		return st
+	})
+}
+
+// ExposedPortCIDRs calls exposedPortCIDRs with the given CIDRs for each
+// exposed endpoint; endpoints with no CIDRs allow access from everywhere.
+func ExposedPortCIDRs(endpointCIDRs map[string][]string, endpoint string) ([]string, bool) {
+	sources := make(map[string]exposedSources)
+	for exposedEndpoint, cidrs := range endpointCIDRs {
+		if len(cidrs) == 0 {
+			sources[exposedEndpoint] = exposedSources{allowAll: true}
+			continue
+		}
+		sources[exposedEndpoint] = exposedSources{cidrs: set.NewStrings(cidrs...)}
+	}
+	return exposedPortCIDRs(sources, endpoint)
+}
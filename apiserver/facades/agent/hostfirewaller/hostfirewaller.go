@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// HostFirewallerAPI provides access to the HostFirewaller API facade.
+type HostFirewallerAPI struct {
+	st          stateInterface
+	resources   facade.Resources
+	getAuthFunc common.GetAuthFunc
+}
+
+var getState = func(st *state.State) stateInterface {
+	return stateShim{st}
+}
+
+// NewHostFirewallerAPI creates a new server-side HostFirewaller API facade.
+func NewHostFirewallerAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*HostFirewallerAPI, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+
+	authEntityTag := authorizer.GetAuthTag()
+	getAuthFunc := func() (common.AuthFunc, error) {
+		return func(tag names.Tag) bool {
+			// A machine agent can always access its own machine.
+			return tag == authEntityTag
+		}, nil
+	}
+
+	return &HostFirewallerAPI{
+		st:          getState(st),
+		resources:   resources,
+		getAuthFunc: getAuthFunc,
+	}, nil
+}
+
+// WatchHostFirewallRules returns a NotifyWatcher for each of the given
+// machines, which notifies when the machine's host firewall rules may
+// have changed.
+func (h *HostFirewallerAPI) WatchHostFirewallRules(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := h.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		id, err := h.watchOne()
+		result.Results[i].NotifyWatcherId = id
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (h *HostFirewallerAPI) watchOne() (string, error) {
+	w, err := h.st.WatchHostFirewallChanges()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); ok {
+		return h.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// GetHostFirewallRules returns the host firewall rules for each of the
+// given machines: whether the host firewall is enabled for the model,
+// the sources that may access any port on the machine, and the ingress
+// rules for the ports opened by the machine's units.
+func (h *HostFirewallerAPI) GetHostFirewallRules(args params.Entities) (params.HostFirewallRulesResults, error) {
+	result := params.HostFirewallRulesResults{
+		Results: make([]params.HostFirewallRulesResult, len(args.Entities)),
+	}
+	canAccess, err := h.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	enabled, err := h.st.HostFirewallEnabled()
+	if err != nil {
+		return result, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !enabled {
+			continue
+		}
+		rules, err := h.machineRules(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i] = rules
+	}
+	return result, nil
+}
+
+func (h *HostFirewallerAPI) machineRules(machineId string) (params.HostFirewallRulesResult, error) {
+	internalCIDRs, err := h.st.InternalCIDRs()
+	if err != nil {
+		return params.HostFirewallRulesResult{}, err
+	}
+	rules, err := h.st.MachineIngressRules(machineId)
+	if err != nil {
+		return params.HostFirewallRulesResult{}, err
+	}
+	result := params.HostFirewallRulesResult{
+		Enabled:       true,
+		InternalCIDRs: internalCIDRs,
+	}
+	for _, rule := range rules {
+		result.Rules = append(result.Rules, params.IngressRule{
+			PortRange:   params.FromNetworkPortRange(rule.PortRange),
+			SourceCIDRs: rule.SourceCIDRs,
+		})
+	}
+	return result, nil
+}
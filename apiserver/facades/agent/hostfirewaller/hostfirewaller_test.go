@@ -0,0 +1,200 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&HostFirewallerSuite{})
+
+type HostFirewallerSuite struct {
+	coretesting.BaseSuite
+	authorizer *apiservertesting.FakeAuthorizer
+	resources  *common.Resources
+	st         *mockState
+	api        *hostfirewaller.HostFirewallerAPI
+}
+
+func (s *HostFirewallerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.st = &mockState{
+		enabled:       true,
+		internalCIDRs: []string{"10.0.0.1/32", "10.0.0.2/32"},
+		changes:       make(chan struct{}, 1),
+		rules: map[string][]network.IngressRule{
+			"0": {
+				network.MustNewIngressRule("tcp", 80, 80),
+				network.MustNewIngressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+			},
+		},
+	}
+	hostfirewaller.PatchState(s, s.st)
+
+	var err error
+	s.api, err = hostfirewaller.NewHostFirewallerAPI(nil, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HostFirewallerSuite) TestNewHostFirewallerAPINonMachine(c *gc.C) {
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")}
+	_, err := hostfirewaller.NewHostFirewallerAPI(nil, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *HostFirewallerSuite) TestWatchHostFirewallRules(c *gc.C) {
+	results, err := s.api.WatchHostFirewallRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{
+			NotifyWatcherId: "1",
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call).
+	wc := statetesting.NewNotifyWatcherC(c, nopSyncStarter{}, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.st.changes <- struct{}{}
+	wc.AssertOneChange()
+}
+
+func (s *HostFirewallerSuite) TestGetHostFirewallRules(c *gc.C) {
+	results, err := s.api.GetHostFirewallRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HostFirewallRulesResults{
+		Results: []params.HostFirewallRulesResult{{
+			Enabled:       true,
+			InternalCIDRs: []string{"10.0.0.1/32", "10.0.0.2/32"},
+			Rules: []params.IngressRule{{
+				PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			}, {
+				PortRange:   params.PortRange{FromPort: 5432, ToPort: 5432, Protocol: "tcp"},
+				SourceCIDRs: []string{"10.0.0.0/8"},
+			}},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *HostFirewallerSuite) TestGetHostFirewallRulesDisabled(c *gc.C) {
+	s.st.enabled = false
+	results, err := s.api.GetHostFirewallRules(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HostFirewallRulesResults{
+		Results: []params.HostFirewallRulesResult{{Enabled: false}},
+	})
+}
+
+func (s *HostFirewallerSuite) TestGetHostFirewallRulesStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.GetHostFirewallRules(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HostFirewallRulesResults{
+		Results: []params.HostFirewallRulesResult{{
+			Error: &params.Error{Message: "boom", Code: ""},
+		}},
+	})
+}
+
+type mockState struct {
+	enabled       bool
+	internalCIDRs []string
+	rules         map[string][]network.IngressRule
+	changes       chan struct{}
+	err           error
+}
+
+func (st *mockState) HostFirewallEnabled() (bool, error) {
+	return st.enabled, nil
+}
+
+func (st *mockState) InternalCIDRs() ([]string, error) {
+	return st.internalCIDRs, nil
+}
+
+func (st *mockState) MachineIngressRules(machineId string) ([]network.IngressRule, error) {
+	return st.rules[machineId], st.err
+}
+
+func (st *mockState) WatchHostFirewallChanges() (state.NotifyWatcher, error) {
+	st.changes <- struct{}{}
+	return statetesting.NewMockNotifyWatcher(st.changes), nil
+}
+
+type nopSyncStarter struct{}
+
+func (nopSyncStarter) StartSync() {}
+
+func (s *HostFirewallerSuite) TestExposedPortCIDRs(c *gc.C) {
+	endpointCIDRs := map[string][]string{
+		"":    {"192.168.0.0/16"},
+		"db":  {"10.0.0.0/8"},
+		"url": nil,
+	}
+	for i, test := range []struct {
+		endpoint string
+		cidrs    []string
+		allowAll bool
+	}{
+		{endpoint: "db", cidrs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+		{endpoint: "cache", cidrs: []string{"192.168.0.0/16"}},
+		{endpoint: "url", allowAll: true},
+		{endpoint: "", allowAll: true},
+	} {
+		c.Logf("test %d: endpoint %q", i, test.endpoint)
+		cidrs, allowAll := hostfirewaller.ExposedPortCIDRs(endpointCIDRs, test.endpoint)
+		c.Check(allowAll, gc.Equals, test.allowAll)
+		if !test.allowAll {
+			c.Check(cidrs, jc.DeepEquals, test.cidrs)
+		}
+	}
+
+	delete(endpointCIDRs, "url")
+	cidrs, allowAll := hostfirewaller.ExposedPortCIDRs(endpointCIDRs, "")
+	c.Check(allowAll, jc.IsFalse)
+	c.Check(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+}
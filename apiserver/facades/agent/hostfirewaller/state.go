@@ -0,0 +1,272 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type stateInterface interface {
+	// HostFirewallEnabled returns whether the host firewall is
+	// enabled for the model.
+	HostFirewallEnabled() (bool, error)
+
+	// InternalCIDRs returns the sources that may access any port on
+	// the model's machines: the addresses of all of the machines.
+	InternalCIDRs() ([]string, error)
+
+	// MachineIngressRules returns the ingress rules for the ports
+	// opened by the units on the specified machine.
+	MachineIngressRules(machineId string) ([]network.IngressRule, error)
+
+	// WatchHostFirewallChanges returns a NotifyWatcher that notifies
+	// when the host firewall rules of the model's machines may have
+	// changed.
+	WatchHostFirewallChanges() (state.NotifyWatcher, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) HostFirewallEnabled() (bool, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.HostFirewall(), nil
+}
+
+func (s stateShim) WatchHostFirewallChanges() (state.NotifyWatcher, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		m.WatchForModelConfigChanges(),
+		s.State.WatchHostFirewallChanges(),
+	), nil
+}
+
+func (s stateShim) InternalCIDRs() ([]string, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := set.NewStrings()
+	for _, m := range machines {
+		for _, addr := range m.Addresses() {
+			switch addr.Type {
+			case corenetwork.IPv4Address:
+				cidrs.Add(addr.Value + "/32")
+			case corenetwork.IPv6Address:
+				cidrs.Add(addr.Value + "/128")
+			}
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
+func (s stateShim) MachineIngressRules(machineId string) ([]network.IngressRule, error) {
+	m, err := s.State.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.IngressRule
+	for _, u := range units {
+		app, err := u.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.IsExposed() {
+			exposedRules, err := s.exposedIngressRules(app, u)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			rules = append(rules, exposedRules...)
+			continue
+		}
+		// Ports opened by the units of unexposed applications
+		// are only accessible from within the model, and from
+		// the consumers of any cross model relations.
+		cidrs, err := s.relationIngressCIDRs(app)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(cidrs) == 0 {
+			continue
+		}
+		portRanges, err := u.OpenedPorts()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, portRange := range portRanges {
+			rules = append(rules, network.IngressRule{
+				PortRange:   portRange,
+				SourceCIDRs: cidrs,
+			})
+		}
+	}
+	if m.IsManager() {
+		controllerRules, err := s.controllerIngressRules()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, controllerRules...)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// exposedIngressRules returns the ingress rules for the ports opened by
+// the given unit of an exposed application. Each port range may be
+// accessed from the sources of the endpoint it was opened for.
+func (s stateShim) exposedIngressRules(app *state.Application, u *state.Unit) ([]network.IngressRule, error) {
+	sources, err := s.exposedCIDRs(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpointPorts, err := u.OpenedEndpointPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.IngressRule
+	for endpoint, portRanges := range endpointPorts {
+		cidrs, allowAll := exposedPortCIDRs(sources, endpoint)
+		if !allowAll && len(cidrs) == 0 {
+			continue
+		}
+		for _, portRange := range portRanges {
+			rules = append(rules, network.IngressRule{
+				PortRange:   portRange,
+				SourceCIDRs: cidrs,
+			})
+		}
+	}
+	return rules, nil
+}
+
+// exposedSources holds the sources that may access an exposed endpoint.
+type exposedSources struct {
+	allowAll bool
+	cidrs    set.Strings
+}
+
+// exposedCIDRs returns the sources that may access each endpoint of the
+// given exposed application, keyed by endpoint name; the "" key applies
+// to all endpoints. Access to all endpoints is allowed from everywhere
+// if there are no endpoint expose settings, and access to an endpoint
+// is allowed from everywhere if its settings have no sources.
+func (s stateShim) exposedCIDRs(app *state.Application) (map[string]exposedSources, error) {
+	exposedEndpoints := app.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return map[string]exposedSources{"": {allowAll: true}}, nil
+	}
+	result := make(map[string]exposedSources)
+	for endpoint, exposed := range exposedEndpoints {
+		if exposed.AllowsAll() {
+			result[endpoint] = exposedSources{allowAll: true}
+			continue
+		}
+		cidrs := set.NewStrings(exposed.ExposeToCIDRs...)
+		for _, spaceName := range exposed.ExposeToSpaces {
+			space, err := s.State.Space(spaceName)
+			if err != nil {
+				return nil, errors.Annotatef(err, "resolving space %q for endpoint %q", spaceName, endpoint)
+			}
+			subnets, err := space.Subnets()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, subnet := range subnets {
+				cidrs.Add(subnet.CIDR())
+			}
+		}
+		result[endpoint] = exposedSources{cidrs: cidrs}
+	}
+	return result, nil
+}
+
+// exposedPortCIDRs returns the sources that may access a port range
+// opened for the given endpoint. A port range opened for an endpoint may
+// be accessed from the sources of that endpoint and of all endpoints; a
+// port range opened for all endpoints may be accessed from the sources
+// of any endpoint.
+func exposedPortCIDRs(sources map[string]exposedSources, endpoint string) (_ []string, allowAll bool) {
+	cidrs := set.NewStrings()
+	for exposedEndpoint, exposed := range sources {
+		if endpoint != "" && exposedEndpoint != "" && exposedEndpoint != endpoint {
+			continue
+		}
+		if exposed.allowAll {
+			return nil, true
+		}
+		cidrs = cidrs.Union(exposed.cidrs)
+	}
+	return cidrs.SortedValues(), false
+}
+
+// relationIngressCIDRs returns the sources that may access the ports
+// opened by the units of the given unexposed application: the ingress
+// networks of its live, unsuspended cross model relations.
+func (s stateShim) relationIngressCIDRs(app *state.Application) ([]string, error) {
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ingress := state.NewRelationIngressNetworks(s.State)
+	cidrs := set.NewStrings()
+	for _, rel := range relations {
+		if rel.Life() != state.Alive || rel.Suspended() {
+			continue
+		}
+		_, isRemote, err := rel.RemoteApplication()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !isRemote {
+			continue
+		}
+		networks, err := ingress.Networks(rel.Tag().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = cidrs.Union(set.NewStrings(networks.CIDRS()...))
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// controllerIngressRules returns the ingress rules for the ports that
+// clients connect to on controller machines.
+func (s stateShim) controllerIngressRules() ([]network.IngressRule, error) {
+	cfg, err := s.State.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ports := []int{cfg.APIPort()}
+	if port := cfg.ControllerAPIPort(); port != 0 {
+		ports = append(ports, port)
+	}
+	rules := make([]network.IngressRule, len(ports))
+	for i, port := range ports {
+		rules[i] = network.MustNewIngressRule("tcp", port, port)
+	}
+	return rules, nil
+}
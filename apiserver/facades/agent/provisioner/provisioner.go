@@ -444,6 +444,7 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.SnapStoreProxyURL = config.SnapStoreProxyURL()
 	result.CloudInitUserData = config.CloudInitUserData()
 	result.ContainerInheritProperties = config.ContainerInheritProperties()
+	result.PreferredAddressFamily = string(config.PreferredAddressFamily())
 	return result, nil
 }
 
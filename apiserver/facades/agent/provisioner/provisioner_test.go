@@ -1455,6 +1455,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"snap-store-proxy":             "b4dc0ffee",
 		"cloudinit-userdata":           validCloudInitUserData,
 		"container-inherit-properties": "ca-certs,apt-primary",
+		"preferred-address-family":     "dual",
 	}
 	err := s.Model.UpdateModelConfig(attrs, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1492,6 +1493,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"postruncmd":      []interface{}{"mkdir /tmp/postruncmd", "mkdir /tmp/postruncmd2"},
 		"package_upgrade": false})
 	c.Check(results.ContainerInheritProperties, gc.DeepEquals, "ca-certs,apt-primary")
+	c.Check(results.PreferredAddressFamily, gc.Equals, "dual")
 }
 
 func (s *withoutControllerSuite) TestContainerConfigLegacy(c *gc.C) {
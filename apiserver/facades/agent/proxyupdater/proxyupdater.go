@@ -89,6 +89,15 @@ type Backend interface {
 	APIHostPortsForAgents() ([][]network.HostPort, error)
 	WatchAPIHostPortsForAgents() state.NotifyWatcher
 	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// SpacesForEntity returns the names of the spaces the machine
+	// hosting the given agent is connected to.
+	SpacesForEntity(tag names.Tag) ([]string, error)
+
+	// WatchSpacesForEntity returns a watcher that notifies when the
+	// spaces of the machine hosting the given agent may have changed,
+	// or nil if the agent is not hosted on a machine.
+	WatchSpacesForEntity(tag names.Tag) (state.NotifyWatcher, error)
 }
 
 // NewAPIBase creates a new server-side API facade with the given Backing.
@@ -103,12 +112,24 @@ func NewAPIBase(backend Backend, resources facade.Resources, authorizer facade.A
 	}, nil
 }
 
-func (api *APIBase) oneWatch() params.NotifyWatchResult {
+func (api *APIBase) oneWatch(tag names.Tag) params.NotifyWatchResult {
 	var result params.NotifyWatchResult
 
-	watch := common.NewMultiNotifyWatcher(
+	// Space specific settings change with the spaces of the agent's
+	// machine, as well as with the model config.
+	spacesWatcher, err := api.backend.WatchSpacesForEntity(tag)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	watchers := []state.NotifyWatcher{
 		api.backend.WatchForModelConfigChanges(),
-		api.backend.WatchAPIHostPortsForAgents())
+		api.backend.WatchAPIHostPortsForAgents(),
+	}
+	if spacesWatcher != nil {
+		watchers = append(watchers, spacesWatcher)
+	}
+	watch := common.NewMultiNotifyWatcher(watchers...)
 
 	if _, ok := <-watch.Changes(); ok {
 		result = params.NotifyWatchResult{
@@ -127,9 +148,11 @@ func (api *APIBase) WatchForProxyConfigAndAPIHostPortChanges(args params.Entitie
 	}
 	errors, _ := api.authEntities(args)
 
-	for i := range args.Entities {
+	for i, entity := range args.Entities {
 		if errors.Results[i].Error == nil {
-			results.Results[i] = api.oneWatch()
+			// The tag has already been validated by authEntities.
+			tag, _ := names.ParseTag(entity.Tag)
+			results.Results[i] = api.oneWatch(tag)
 		} else {
 			results.Results[i].Error = errors.Results[i].Error
 		}
@@ -170,7 +193,7 @@ func (api *APIBase) authEntities(args params.Entities) (params.ErrorResults, boo
 	return result, ok
 }
 
-func (api *APIBase) proxyConfig() params.ProxyConfigResult {
+func (api *APIBase) proxyConfig(tag names.Tag) params.ProxyConfigResult {
 	var result params.ProxyConfigResult
 	config, err := api.backend.ModelConfig()
 	if err != nil {
@@ -178,6 +201,19 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 		return result
 	}
 
+	modelAptMirror := config.AptMirror()
+	if len(config.SpaceProxySettings()) > 0 {
+		spaces, err := api.backend.SpacesForEntity(tag)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			return result
+		}
+		if config, err = config.ForSpaces(spaces); err != nil {
+			result.Error = common.ServerError(err)
+			return result
+		}
+	}
+
 	apiHostPorts, err := api.backend.APIHostPortsForAgents()
 	if err != nil {
 		result.Error = common.ServerError(err)
@@ -196,6 +232,10 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 	result.LegacyProxySettings = toParams(legacyProxySettings)
 
 	result.APTProxySettings = toParams(config.AptProxySettings())
+	result.AptMirror = config.AptMirror()
+	if result.AptMirror != modelAptMirror {
+		result.SpaceAptMirror = result.AptMirror
+	}
 
 	result.SnapProxySettings = toParams(config.SnapProxySettings())
 	result.SnapStoreProxyId = config.SnapStoreProxy()
@@ -205,23 +245,22 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 	return result
 }
 
-// ProxyConfig returns the proxy settings for the current model.
+// ProxyConfig returns the proxy settings for the current model, with
+// the settings for the spaces of each entity's machine applied.
 func (api *APIBase) ProxyConfig(args params.Entities) params.ProxyConfigResults {
-	var result params.ProxyConfigResult
-	errors, ok := api.authEntities(args)
-
-	if ok {
-		result = api.proxyConfig()
-	}
+	errors, _ := api.authEntities(args)
 
 	results := params.ProxyConfigResults{
 		Results: make([]params.ProxyConfigResult, len(args.Entities)),
 	}
-	for i := range args.Entities {
-		if errors.Results[i].Error == nil {
-			results.Results[i] = result
+	for i, entity := range args.Entities {
+		if errors.Results[i].Error != nil {
+			results.Results[i].Error = errors.Results[i].Error
+			continue
 		}
-		results.Results[i].Error = errors.Results[i].Error
+		// The tag has already been validated by authEntities.
+		tag, _ := names.ParseTag(entity.Tag)
+		results.Results[i] = api.proxyConfig(tag)
 	}
 
 	return results
@@ -229,25 +268,23 @@ func (api *APIBase) ProxyConfig(args params.Entities) params.ProxyConfigResults
 
 // ProxyConfig returns the proxy settings for the current model.
 func (api *APIv1) ProxyConfig(args params.Entities) params.ProxyConfigResultsV1 {
-	var result params.ProxyConfigResultV1
-	errors, ok := api.authEntities(args)
-
-	if ok {
-		v2 := api.proxyConfig()
-		result = params.ProxyConfigResultV1{
-			ProxySettings:    v2.LegacyProxySettings,
-			APTProxySettings: v2.APTProxySettings,
-		}
-	}
+	errors, _ := api.authEntities(args)
 
 	results := params.ProxyConfigResultsV1{
 		Results: make([]params.ProxyConfigResultV1, len(args.Entities)),
 	}
-	for i := range args.Entities {
-		if errors.Results[i].Error == nil {
-			results.Results[i] = result
+	for i, entity := range args.Entities {
+		if errors.Results[i].Error != nil {
+			results.Results[i].Error = errors.Results[i].Error
+			continue
+		}
+		tag, _ := names.ParseTag(entity.Tag)
+		v2 := api.proxyConfig(tag)
+		results.Results[i] = params.ProxyConfigResultV1{
+			ProxySettings:    v2.LegacyProxySettings,
+			APTProxySettings: v2.APTProxySettings,
+			Error:            v2.Error,
 		}
-		results.Results[i].Error = errors.Results[i].Error
 	}
 
 	return results
@@ -6,6 +6,7 @@ package proxyupdater_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -67,10 +68,11 @@ func (s *ProxyUpdaterSuite) TestWatchForProxyConfigAndAPIHostPortChanges(c *gc.C
 	c.Assert(result.Results, gc.HasLen, 1)
 	c.Assert(result.Results[0].Error, gc.IsNil)
 
-	s.state.Stub.CheckCallNames(c,
-		"WatchForModelConfigChanges",
-		"WatchAPIHostPortsForAgents",
-	)
+	s.state.Stub.CheckCalls(c, []testing.StubCall{
+		{"WatchSpacesForEntity", []interface{}{s.tag}},
+		{"WatchForModelConfigChanges", nil},
+		{"WatchAPIHostPortsForAgents", nil},
+	})
 
 	// Verify the watcher resource was registered.
 	c.Assert(s.resources.Count(), gc.Equals, 1)
@@ -86,6 +88,31 @@ func (s *ProxyUpdaterSuite) TestWatchForProxyConfigAndAPIHostPortChanges(c *gc.C
 	}
 }
 
+func (s *ProxyUpdaterSuite) TestWatchForProxyConfigAndSpaceChanges(c *gc.C) {
+	result := s.facade.WatchForProxyConfigAndAPIHostPortChanges(s.oneEntity())
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	resource := s.resources.Get(result.Results[0].NotifyWatcherId)
+	watcher, ok := resource.(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+
+	// A change to the machine's spaces is reported.
+	s.state.spacesWatcher.Ping()
+	select {
+	case <-watcher.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for space change")
+	}
+}
+
+func (s *ProxyUpdaterSuite) TestWatchForProxyConfigAndSpaceChangesError(c *gc.C) {
+	s.state.SetErrors(errors.New("boom"))
+	result := s.facade.WatchForProxyConfigAndAPIHostPortChanges(s.oneEntity())
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
 func (s *ProxyUpdaterSuite) oneEntity() params.Entities {
 	entities := params.Entities{
 		make([]params.Entity, 1),
@@ -246,6 +273,82 @@ func (s *ProxyUpdaterSuite) TestSnapProxyConfig(c *gc.C) {
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestProxyConfigForSpaces(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"juju-http-proxy": "http proxy",
+		"apt-mirror":      "http://mirror/ubuntu",
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: dmz http proxy
+  apt-mirror: http://dmz-mirror/ubuntu
+`,
+	})
+	s.state.spaces = []string{"dmz"}
+
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	s.state.Stub.CheckCalls(c, []testing.StubCall{
+		{"ModelConfig", nil},
+		{"SpacesForEntity", []interface{}{s.tag}},
+		{"APIHostPortsForAgents", nil},
+	})
+
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		JujuProxySettings: params.ProxyConfig{
+			HTTP: "dmz http proxy", NoProxy: expectedNoProxy},
+		APTProxySettings: params.ProxyConfig{
+			HTTP: "http://dmz http proxy"},
+		AptMirror:      "http://dmz-mirror/ubuntu",
+		SpaceAptMirror: "http://dmz-mirror/ubuntu",
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigForOtherSpaces(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"juju-http-proxy": "http proxy",
+		"apt-mirror":      "http://mirror/ubuntu",
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: dmz http proxy
+  apt-mirror: http://dmz-mirror/ubuntu
+`,
+	})
+	s.state.spaces = []string{"internal"}
+
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results[0].Error, gc.IsNil)
+	c.Assert(cfg.Results[0].JujuProxySettings.HTTP, gc.Equals, "http proxy")
+	c.Assert(cfg.Results[0].AptMirror, gc.Equals, "http://mirror/ubuntu")
+	c.Assert(cfg.Results[0].SpaceAptMirror, gc.Equals, "")
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigForSpacesConflicting(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: dmz http proxy
+internal:
+  juju-http-proxy: internal http proxy
+`,
+	})
+	s.state.spaces = []string{"dmz", "internal"}
+
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results[0].Error, gc.ErrorMatches,
+		`spaces "dmz" and "internal" override "juju-http-proxy" with conflicting values in space-proxy-settings`)
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigForSpacesError(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"space-proxy-settings": "dmz: {juju-http-proxy: dmz http proxy}",
+	})
+	s.state.SetErrors(nil, errors.New("boom"))
+
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
 type stubBackend struct {
 	*testing.Stub
 
@@ -254,6 +357,9 @@ type stubBackend struct {
 	configAttrs coretesting.Attrs
 	hpWatcher   workertest.NotAWatcher
 	confWatcher workertest.NotAWatcher
+	spaces      []string
+
+	spacesWatcher workertest.NotAWatcher
 }
 
 func (sb *stubBackend) SetUp(c *gc.C) {
@@ -267,11 +373,13 @@ func (sb *stubBackend) SetUp(c *gc.C) {
 	}
 	sb.hpWatcher = workertest.NewFakeWatcher(1, 1)
 	sb.confWatcher = workertest.NewFakeWatcher(1, 1)
+	sb.spacesWatcher = workertest.NewFakeWatcher(1, 1)
 }
 
 func (sb *stubBackend) Kill() {
 	sb.hpWatcher.Kill()
 	sb.confWatcher.Kill()
+	sb.spacesWatcher.Kill()
 }
 
 func (sb *stubBackend) SetModelConfig(ca coretesting.Attrs) {
@@ -308,3 +416,19 @@ func (sb *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
 	sb.MethodCall(sb, "WatchForModelConfigChanges")
 	return sb.confWatcher
 }
+
+func (sb *stubBackend) SpacesForEntity(tag names.Tag) ([]string, error) {
+	sb.MethodCall(sb, "SpacesForEntity", tag)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.spaces, nil
+}
+
+func (sb *stubBackend) WatchSpacesForEntity(tag names.Tag) (state.NotifyWatcher, error) {
+	sb.MethodCall(sb, "WatchSpacesForEntity", tag)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.spacesWatcher, nil
+}
@@ -4,6 +4,10 @@
 package proxyupdater
 
 import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -32,3 +36,58 @@ func (s *stateShim) WatchAPIHostPortsForAgents() state.NotifyWatcher {
 func (s *stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
 	return s.m.WatchForModelConfigChanges()
 }
+
+// machineForEntity returns the machine hosting the given agent, or nil
+// if the agent is not hosted on a machine.
+func (s *stateShim) machineForEntity(tag names.Tag) (*state.Machine, error) {
+	var machineId string
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		machineId = tag.Id()
+	case names.UnitTag:
+		unit, err := s.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err = unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			// Units without machines, such as those in CAAS
+			// models, are not connected to any spaces.
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, nil
+	}
+	machine, err := s.st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
+
+func (s *stateShim) SpacesForEntity(tag names.Tag) ([]string, error) {
+	machine, err := s.machineForEntity(tag)
+	if err != nil || machine == nil {
+		return nil, errors.Trace(err)
+	}
+	spaces, err := machine.AllSpaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spaces.SortedValues(), nil
+}
+
+func (s *stateShim) WatchSpacesForEntity(tag names.Tag) (state.NotifyWatcher, error) {
+	machine, err := s.machineForEntity(tag)
+	if err != nil || machine == nil {
+		return nil, errors.Trace(err)
+	}
+	// The machine's spaces change with its addresses, and with the
+	// spaces that the subnets of those addresses belong to.
+	return common.NewMultiNotifyWatcher(
+		machine.WatchAddresses(),
+		s.st.WatchSubnetChanges(),
+	), nil
+}
@@ -17,8 +17,8 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
-// Right now, these are defined as constants, but the plan is to maybe make
-// them configurable in the future
+// These are the defaults, which an application's hook retry policy may
+// override.
 const (
 	MinRetryTime    = 5 * time.Second
 	MaxRetryTime    = 5 * time.Minute
@@ -89,25 +89,76 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the model.
-			// The rest are hardcoded.
-			results.Results[i].Result = &params.RetryStrategy{
-				ShouldRetry:     config.AutomaticallyRetryHooks(),
-				MinRetryTime:    MinRetryTime,
-				MaxRetryTime:    MaxRetryTime,
-				JitterRetryTime: JitterRetryTime,
-				RetryTimeFactor: RetryTimeFactor,
-			}
-			err = nil
+			results.Results[i].Result, err = h.retryStrategy(tag, config.AutomaticallyRetryHooks())
 		}
 		results.Results[i].Error = common.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the model. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// retryStrategy returns the retry strategy for the agent with the given
+// tag. The model's automatically-retry-hooks setting decides whether
+// hooks are retried, unless the agent's application config sets a hook
+// retry policy of its own.
+func (h *RetryStrategyAPI) retryStrategy(tag names.Tag, shouldRetry bool) (*params.RetryStrategy, error) {
+	strategy := &params.RetryStrategy{
+		ShouldRetry:     shouldRetry,
+		MinRetryTime:    MinRetryTime,
+		MaxRetryTime:    MaxRetryTime,
+		JitterRetryTime: JitterRetryTime,
+		RetryTimeFactor: RetryTimeFactor,
+	}
+	app, err := h.application(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := appConfig.HookRetryPolicy()
+	if err != nil {
+		return nil, errors.Annotatef(err, "application %q hook retry policy", app.Name())
+	}
+	if policy.MaxRetries != nil {
+		strategy.ShouldRetry = *policy.MaxRetries > 0
+		strategy.MaxRetries = *policy.MaxRetries
+	}
+	if policy.InitialDelay != 0 {
+		strategy.MinRetryTime = policy.InitialDelay
+	}
+	if policy.MaxDelay != 0 {
+		strategy.MaxRetryTime = policy.MaxDelay
+	}
+	if strategy.MaxRetryTime < strategy.MinRetryTime {
+		strategy.MaxRetryTime = strategy.MinRetryTime
+	}
+	strategy.RetryHooks = policy.Hooks
+	return strategy, nil
+}
+
+// application returns the application of the agent with the given tag.
+func (h *RetryStrategyAPI) application(tag names.Tag) (*state.Application, error) {
+	var appName string
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		appName = tag.Id()
+	case names.UnitTag:
+		unit, err := h.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appName = unit.ApplicationName()
+	default:
+		return nil, errors.NotValidf("agent tag %q", tag)
+	}
+	app, err := h.st.Application(appName)
+	return app, errors.Trace(err)
+}
+
+// WatchRetryStrategy watches for changes to the model config and to the
+// application config of each agent, either of which may change its retry
+// strategy.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -124,7 +175,16 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
+			var app *state.Application
+			app, err = h.application(tag)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+			watch := common.NewMultiNotifyWatcher(
+				h.model.WatchForModelConfigChanges(),
+				app.WatchApplicationConfig(),
+			)
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
@@ -5,6 +5,8 @@
 package retrystrategy_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -139,6 +142,50 @@ func (s *retryStrategySuite) setRetryStrategy(c *gc.C, automaticallyRetryHooks b
 	c.Assert(modelConfig.AutomaticallyRetryHooks(), gc.Equals, automaticallyRetryHooks)
 }
 
+func (s *retryStrategySuite) setHookRetryPolicy(c *gc.C, attrs map[string]interface{}) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateApplicationConfig(attrs, nil, coreapplication.HookRetryFields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicy(c *gc.C) {
+	s.setRetryStrategy(c, false)
+	s.setHookRetryPolicy(c, map[string]interface{}{
+		coreapplication.HookRetryMaxRetriesKey:   3,
+		coreapplication.HookRetryInitialDelayKey: "10s",
+		coreapplication.HookRetryHooksKey:        "install *-relation-changed",
+	})
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result, jc.DeepEquals, &params.RetryStrategy{
+		ShouldRetry:     true,
+		MinRetryTime:    10 * time.Second,
+		MaxRetryTime:    retrystrategy.MaxRetryTime,
+		JitterRetryTime: retrystrategy.JitterRetryTime,
+		RetryTimeFactor: retrystrategy.RetryTimeFactor,
+		MaxRetries:      3,
+		RetryHooks:      []string{"install", "*-relation-changed"},
+	})
+}
+
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicyNoRetries(c *gc.C) {
+	s.setHookRetryPolicy(c, map[string]interface{}{
+		coreapplication.HookRetryMaxRetriesKey: 0,
+	})
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result.ShouldRetry, jc.IsFalse)
+}
+
 func (s *retryStrategySuite) TestWatchRetryStrategyUnauthenticated(c *gc.C) {
 	svc, err := s.unit.Application()
 	c.Assert(err, jc.ErrorIsNil)
@@ -194,3 +241,22 @@ func (s *retryStrategySuite) TestWatchRetryStrategy(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
 }
+
+func (s *retryStrategySuite) TestWatchRetryStrategyApplicationConfig(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.UnitTag().String()}}}
+	r, err := s.strategy.WatchRetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+
+	resource := s.resources.Get(r.Results[0].NotifyWatcherId)
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.setHookRetryPolicy(c, map[string]interface{}{
+		coreapplication.HookRetryMaxRetriesKey: 0,
+	})
+	wc.AssertOneChange()
+}
@@ -54,7 +54,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv6
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -85,7 +85,9 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -117,7 +119,9 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -628,6 +632,121 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) setupVolumeResize(c *gc.C) names.VolumeTag {
+	s.setupVolumes(c)
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	storageTag := names.NewStorageTag("data/0")
+	storageVolume, err := s.storageBackend.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "zing",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	return storageVolume.VolumeTag()
+}
+
+func (s *iaasProvisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	volumeTag := s.setupVolumeResize(c)
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{volumeTag.String()},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{{
+			Result: params.VolumeResizeParams{
+				VolumeTag: volumeTag.String(),
+				VolumeId:  "zing",
+				Provider:  "modelscoped",
+				Size:      1024,
+			},
+		}, {
+			Error: &params.Error{Message: `resize request for volume 0/0 not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestSetVolumeSizes(c *gc.C) {
+	volumeTag := s.setupVolumeResize(c)
+	results, err := s.api.SetVolumeSizes(params.StorageSizes{
+		Sizes: []params.StorageSize{
+			{Tag: volumeTag.String(), Size: 1024},
+			{Tag: "volume-0-0", Size: 512},
+			{Tag: "volume-42", Size: 1024},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot set size for volume "0/0": cannot shrink volume from 1024MiB to 512MiB`}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	volume, err := s.storageBackend.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(1024))
+}
+
+func (s *iaasProvisionerSuite) TestVolumeEncryptionKeys(c *gc.C) {
+	s.setupVolumes(c)
+	machineTag := names.NewMachineTag("0")
+	volumeTag := names.NewVolumeTag("0/0")
+	err := s.storageBackend.SetVolumeAttachmentInfo(machineTag, volumeTag, state.VolumeAttachmentInfo{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.CreateVolumeAttachmentPlan(machineTag, volumeTag, state.VolumeAttachmentPlanInfo{
+		DeviceType: storage.DeviceTypeLocal,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeEncryptionKeys(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{
+			{MachineTag: "machine-0", AttachmentTag: "volume-0-0"},
+			{MachineTag: "machine-0", AttachmentTag: "volume-2"},
+			{MachineTag: "machine-1", AttachmentTag: "volume-1"},
+			{MachineTag: "machine-0", AttachmentTag: "filesystem-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: ""},
+			{Error: &params.Error{Message: `volume attachment plan "2" on host "0" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
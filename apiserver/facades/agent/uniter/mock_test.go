@@ -24,6 +24,7 @@ type fakeStorage struct {
 	watchVolumeAttachment  func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices      func(names.MachineTag) state.NotifyWatcher
 	watchStorageAttachment func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchStorageInstance   func(names.StorageTag) state.NotifyWatcher
 }
 
 func (s *fakeStorage) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
@@ -66,11 +67,17 @@ func (s *fakeStorage) WatchStorageAttachment(st names.StorageTag, u names.UnitTa
 	return s.watchStorageAttachment(st, u)
 }
 
+func (s *fakeStorage) WatchStorageInstance(st names.StorageTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchStorageInstance", st)
+	return s.watchStorageInstance(st)
+}
+
 type fakeStorageInstance struct {
 	state.StorageInstance
 	tag   names.StorageTag
 	owner names.Tag
 	kind  state.StorageKind
+	move  *state.StorageMove
 }
 
 func (i *fakeStorageInstance) StorageTag() names.StorageTag {
@@ -89,11 +96,19 @@ func (i *fakeStorageInstance) Kind() state.StorageKind {
 	return i.kind
 }
 
+func (i *fakeStorageInstance) Move() (state.StorageMove, bool) {
+	if i.move == nil {
+		return state.StorageMove{}, false
+	}
+	return *i.move, true
+}
+
 type fakeVolume struct {
 	state.Volume
-	tag    names.VolumeTag
-	params *state.VolumeParams
-	info   *state.VolumeInfo
+	tag       names.VolumeTag
+	params    *state.VolumeParams
+	info      *state.VolumeInfo
+	encrypted bool
 }
 
 func (v *fakeVolume) VolumeTag() names.VolumeTag {
@@ -104,6 +119,10 @@ func (v *fakeVolume) Tag() names.Tag {
 	return v.tag
 }
 
+func (v *fakeVolume) Encrypted() bool {
+	return v.encrypted
+}
+
 func (v *fakeVolume) Params() (state.VolumeParams, bool) {
 	if v.params == nil {
 		return state.VolumeParams{}, false
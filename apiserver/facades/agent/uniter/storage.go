@@ -205,6 +205,9 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		params.StorageKind(stateStorageInstance.Kind()),
 		info.Location,
 		params.Life(stateStorageAttachment.Life().String()),
+		info.Size,
+		info.MoveTarget,
+		info.MoveCopied,
 	}, nil
 }
 
@@ -347,6 +350,96 @@ func (s *StorageAPI) removeOneStorageAttachment(id params.StorageAttachmentId, c
 	return err
 }
 
+// CompleteStorageMoves completes the moves of storage attachments to
+// other storage pools, once their units have copied the storage
+// contents to the storage that is being moved to.
+func (s *StorageAPI) CompleteStorageMoves(args params.StorageMoveCompletions) (params.ErrorResults, error) {
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Completions)),
+	}
+	for i, arg := range args.Completions {
+		err := s.completeOneStorageMove(arg, canAccess)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (s *StorageAPI) completeOneStorageMove(arg params.StorageMoveCompletion, canAccess common.AuthFunc) error {
+	storageTag, move, err := s.storageMoveTo(arg.StorageTag, arg.UnitTag, arg.Target, canAccess)
+	if err != nil {
+		return err
+	}
+	return s.storage.CompleteStorageMove(storageTag, move.Target())
+}
+
+// SetStorageMoveProgress records the progress of units in copying the
+// contents of storage attachments to the storage that they are being
+// moved to, so that an interrupted copy may be resumed.
+func (s *StorageAPI) SetStorageMoveProgress(args params.StorageMoveProgresses) (params.ErrorResults, error) {
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Progress)),
+	}
+	for i, arg := range args.Progress {
+		err := s.setOneStorageMoveProgress(arg, canAccess)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (s *StorageAPI) setOneStorageMoveProgress(arg params.StorageMoveProgress, canAccess common.AuthFunc) error {
+	storageTag, move, err := s.storageMoveTo(arg.StorageTag, arg.UnitTag, arg.Target, canAccess)
+	if err != nil {
+		return err
+	}
+	return s.storage.SetStorageMoveProgress(storageTag, move.Target(), arg.Copied)
+}
+
+// storageMoveTo returns the tag of the specified storage attachment's
+// storage instance, and the details of its in-progress move, ensuring
+// that the storage is still being moved to the given target location.
+func (s *StorageAPI) storageMoveTo(
+	storageTagString, unitTagString, target string, canAccess common.AuthFunc,
+) (names.StorageTag, state.StorageMove, error) {
+	stateStorageAttachment, err := s.getOneStateStorageAttachment(canAccess, params.StorageAttachmentId{
+		StorageTag: storageTagString,
+		UnitTag:    unitTagString,
+	})
+	if err != nil {
+		return names.StorageTag{}, state.StorageMove{}, err
+	}
+	storageTag := stateStorageAttachment.StorageInstance()
+	storageInstance, err := s.storage.StorageInstance(storageTag)
+	if err != nil {
+		return names.StorageTag{}, state.StorageMove{}, err
+	}
+	move, ok := storageInstance.Move()
+	if !ok {
+		return names.StorageTag{}, state.StorageMove{}, errors.NotFoundf("move of storage %q", storageTag.Id())
+	}
+	// Make sure that the unit is copying the storage contents
+	// to the location that the storage is still being moved to.
+	storageAttachment, err := s.fromStateStorageAttachment(stateStorageAttachment)
+	if err != nil {
+		return names.StorageTag{}, state.StorageMove{}, err
+	}
+	if target == "" || storageAttachment.MoveTarget != target {
+		// The move may have been cancelled and restarted
+		// since the unit started copying the contents.
+		return names.StorageTag{}, state.StorageMove{}, errors.NotFoundf(
+			"move of storage %q to %q", storageTag.Id(), target,
+		)
+	}
+	return storageTag, move, nil
+}
+
 // AddUnitStorage validates and creates additional storage instances for units.
 // Failures on an individual storage instance do not block remaining
 // instances from being processed.
@@ -434,7 +527,8 @@ func accessUnitTag(tag string, canAccess func(names.Tag) bool) (names.UnitTag, e
 
 // watchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the
-// tags specified.
+// tags specified, to changes in the size of the storage, and to moves of
+// the storage to another storage pool.
 func watchStorageAttachment(
 	st storageInterface,
 	stVolume storageVolumeInterface,
@@ -475,6 +569,9 @@ func watchStorageAttachment(
 			// attachment is provisioned.
 			watchers = append(watchers, stVolume.WatchBlockDevices(hostTag.(names.MachineTag)))
 		}
+		// The storage instance changes when a move to
+		// another storage pool starts or is completed.
+		watchers = append(watchers, st.WatchStorageInstance(storageTag))
 	case state.StorageKindFilesystem:
 		if stFile == nil {
 			return nil, errors.NotImplementedf("FilesystemStorage instance")
@@ -483,9 +580,16 @@ func watchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
+		// We need to watch both the filesystem attachment, and the
+		// filesystem itself, whose size may change when resized.
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 		}
+		// The storage instance changes when a move to another
+		// storage pool starts, when the filesystem being moved
+		// to has been mounted, and when the move is completed.
+		watchers = append(watchers, st.WatchStorageInstance(storageTag))
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 	}
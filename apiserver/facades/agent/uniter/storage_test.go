@@ -85,6 +85,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	blockDevicesWatcher.changes <- struct{}{}
+	storageInstanceWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	storageInstanceWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: "66",
@@ -115,6 +119,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(m, gc.DeepEquals, machineTag)
 			return blockDevicesWatcher
 		},
+		watchStorageInstance: func(s names.StorageTag) state.NotifyWatcher {
+			calls = append(calls, "WatchStorageInstance")
+			c.Assert(s, gc.DeepEquals, storageTag)
+			return storageInstanceWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(st, st, resources, getCanAccess)
@@ -136,6 +145,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
+		"WatchStorageInstance",
 		"WatchStorageAttachment",
 	})
 }
@@ -173,6 +183,14 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemSizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemSizeWatcher.changes <- struct{}{}
+	storageInstanceWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	storageInstanceWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: assignedMachine,
@@ -198,6 +216,16 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemSizeWatcher
+		},
+		watchStorageInstance: func(s names.StorageTag) state.NotifyWatcher {
+			calls = append(calls, "WatchStorageInstance")
+			c.Assert(s, gc.DeepEquals, storageTag)
+			return storageInstanceWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(st, st, resources, getCanAccess)
@@ -218,6 +246,8 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
+		"WatchStorageInstance",
 		"WatchStorageAttachment",
 	})
 }
@@ -488,7 +518,9 @@ type mockStorageState struct {
 	storageInstanceVolume         func(names.StorageTag) (state.Volume, error)
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchStorageInstance          func(names.StorageTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -536,10 +568,18 @@ func (m *mockStorageState) WatchStorageAttachment(s names.StorageTag, u names.Un
 	return m.watchStorageAttachment(s, u)
 }
 
+func (m *mockStorageState) WatchStorageInstance(s names.StorageTag) state.NotifyWatcher {
+	return m.watchStorageInstance(s)
+}
+
 func (m *mockStorageState) WatchFilesystemAttachment(hostTag names.Tag, f names.FilesystemTag) state.NotifyWatcher {
 	return m.watchFilesystemAttachment(hostTag, f)
 }
 
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) WatchVolumeAttachment(hostTag names.Tag, v names.VolumeTag) state.NotifyWatcher {
 	return m.watchVolumeAttachment(hostTag, v)
 }
@@ -614,6 +654,10 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
+func (m *mockStorageInstance) Move() (state.StorageMove, bool) {
+	return state.StorageMove{}, false
+}
+
 type watchStorageAttachmentSuite struct {
 	storageTag               names.StorageTag
 	machineTag               names.MachineTag
@@ -623,6 +667,7 @@ type watchStorageAttachmentSuite struct {
 	volume                   *fakeVolume
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
+	storageInstanceWatcher   *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
 }
 
@@ -640,6 +685,7 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
 	s.volumeAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
+	s.storageInstanceWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.st = &fakeStorage{
 		storageInstance: func(tag names.StorageTag) (state.StorageInstance, error) {
@@ -654,6 +700,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		watchBlockDevices: func(names.MachineTag) state.NotifyWatcher {
 			return s.blockDevicesWatcher
 		},
+		watchStorageInstance: func(names.StorageTag) state.NotifyWatcher {
+			return s.storageInstanceWatcher
+		},
 		watchStorageAttachment: func(names.StorageTag, names.UnitTag) state.NotifyWatcher {
 			return s.storageAttachmentWatcher
 		},
@@ -678,6 +727,12 @@ func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentBlockDevicesChan
 	})
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentStorageInstanceChange(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.storageInstanceWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, change func()) {
 	s.testWatchStorageAttachment(c, change)
 	s.st.CheckCallNames(c,
@@ -685,6 +740,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
+		"WatchStorageInstance",
 		"WatchStorageAttachment",
 	)
 }
@@ -40,10 +40,9 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v12) of the Uniter API,
-// Removes the embedded LXDProfileAPI, which in turn removes the following;
-// RemoveUpgradeCharmProfileData, WatchUnitLXDProfileUpgradeNotifications
-// and WatchLXDProfileUpgradeNotifications
+// UniterAPI implements the latest version (v16) of the Uniter API,
+// which adds CompleteStorageMoves and SetStorageMoveProgress, and
+// opening ports for application endpoints.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -80,11 +79,33 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
-// UniterAPIV11 implements the latest version (v11) of the Uniter API,
-// which adds CloudAPIVersion.
+// UniterAPIV15 adds RecordHookExecutions.
+type UniterAPIV15 struct {
+	UniterAPI
+}
+
+// UniterAPIV14 adds LogActionsOutput.
+type UniterAPIV14 struct {
+	UniterAPIV15
+}
+
+// UniterAPIV13 adds State and SetState.
+type UniterAPIV13 struct {
+	UniterAPIV14
+}
+
+// UniterAPIV12 removes the embedded LXDProfileAPI, which in turn removes
+// the following; RemoveUpgradeCharmProfileData,
+// WatchUnitLXDProfileUpgradeNotifications and
+// WatchLXDProfileUpgradeNotifications
+type UniterAPIV12 struct {
+	UniterAPIV13
+}
+
+// UniterAPIV11 adds CloudAPIVersion.
 type UniterAPIV11 struct {
 	*LXDProfileAPI
-	UniterAPI
+	UniterAPIV12
 }
 
 // UniterAPIV10 adds WatchUnitLXDProfileUpgradeNotifications and
@@ -219,9 +240,53 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV15 creates an instance of the V15 uniter API.
+func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV15{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV14 creates an instance of the V14 uniter API.
+func NewUniterAPIV14(context facade.Context) (*UniterAPIV14, error) {
+	uniterAPI, err := NewUniterAPIV15(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV14{
+		UniterAPIV15: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV13 creates an instance of the V13 uniter API.
+func NewUniterAPIV13(context facade.Context) (*UniterAPIV13, error) {
+	uniterAPI, err := NewUniterAPIV14(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV13{
+		UniterAPIV14: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV12 creates an instance of the V12 uniter API.
+func NewUniterAPIV12(context facade.Context) (*UniterAPIV12, error) {
+	uniterAPI, err := NewUniterAPIV13(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV12{
+		UniterAPIV13: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV11 creates an instance of the V11 uniter API.
 func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV12(context)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +296,7 @@ func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
 	accessUnit := unitAccessor(authorizer, st)
 	return &UniterAPIV11{
 		LXDProfileAPI: NewExternalLXDProfileAPI(st, resources, authorizer, accessUnit, logger),
-		UniterAPI:     *uniterAPI,
+		UniterAPIV12:  *uniterAPI,
 	}, nil
 }
 
@@ -916,8 +981,135 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// State isn't on the v12 API.
+func (u *UniterAPIV12) State(_, _ struct{}) {}
+
+// SetState isn't on the v12 API.
+func (u *UniterAPIV12) SetState(_, _ struct{}) {}
+
+// State returns the charm state stored for each given unit.
+func (u *UniterAPI) State(args params.Entities) (params.UnitStateResults, error) {
+	result := params.UnitStateResults{
+		Results: make([]params.UnitStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		state, err := unit.State()
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.State = state
+	}
+	return result, nil
+}
+
+// SetState replaces the charm state stored for each given unit. An error
+// will be returned if a unit is dead.
+func (u *UniterAPI) SetState(args params.SetUnitStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if err := unit.SetState(arg.State); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// CompleteStorageMoves isn't on the v15 API.
+func (u *UniterAPIV15) CompleteStorageMoves(_, _ struct{}) {}
+
+// SetStorageMoveProgress isn't on the v15 API.
+func (u *UniterAPIV15) SetStorageMoveProgress(_, _ struct{}) {}
+
+// RecordHookExecutions isn't on the v14 API.
+func (u *UniterAPIV14) RecordHookExecutions(_, _ struct{}) {}
+
+// RecordHookExecutions records the timing and outcome of hooks run
+// by each given unit.
+func (u *UniterAPI) RecordHookExecutions(args params.RecordHookExecutionsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		executions := make([]state.HookExecution, len(arg.Executions))
+		for j, e := range arg.Executions {
+			executions[j] = state.HookExecution{
+				Hook:     e.Hook,
+				Outcome:  e.Outcome,
+				Queued:   e.Queued,
+				Started:  e.Started,
+				Finished: e.Finished,
+			}
+		}
+		if err := unit.RecordHookExecutions(executions); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
 // OpenPorts sets the policy of the port range with protocol to be
-// opened, for all given units.
+// opened, for all given units. The port range is opened for the given
+// application endpoint, or for all endpoints if none is given.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -937,7 +1129,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsForEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1197,6 +1389,31 @@ func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.
 	return result, nil
 }
 
+// LogActionsOutput isn't on the v13 API.
+func (u *UniterAPIV13) LogActionsOutput(_, _ struct{}) {}
+
+// LogActionsOutput records lines written to stdout or stderr by running
+// actions, so that they can be followed before the actions finish.
+func (u *UniterAPI) LogActionsOutput(args params.ActionOutputParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.m.ActionByTag)
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Output)),
+	}
+	for i, output := range args.Output {
+		action, err := actionFn(output.Tag)
+		if err == nil {
+			err = action.LogOutput(output.Stream, output.Lines)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1340,6 +1557,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				_, result.Results[i].Paused = unit.Paused()
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
@@ -2191,7 +2409,7 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 		if err != nil {
 			return params.NetworkInfoResults{}, err
 		}
-		corenetwork.SortAddresses(addr)
+		corenetwork.SortAddressesForFamily(addr, modelCfg.PreferredAddressFamily())
 
 		// We record the interface addresses as the machine local ones - these
 		// are used later as the binding addresses.
@@ -2233,6 +2451,9 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 			}
 		}
 
+		// Put the addresses of the model's preferred address family first.
+		corenetwork.SortAddressValuesForFamily(info.IngressAddresses, modelCfg.PreferredAddressFamily())
+
 		// If there is no egress subnet explicitly defined for a given binding,
 		// default to the first ingress address. This matches the behaviour when
 		// there's a relation in place.
@@ -966,6 +966,81 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestState(c *gc.C) {
+	err := s.wordpressUnit.SetState(map[string]string{"seen": "yes"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.State(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{State: map[string]string{"seen": "yes"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetState(c *gc.C) {
+	args := params.SetUnitStateArgs{Args: []params.SetUnitStateArg{
+		{Tag: "unit-mysql-0", State: map[string]string{"one": "1"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{"two": "2"}},
+		{Tag: "unit-foo-42", State: map[string]string{"three": "3"}},
+	}}
+	result, err := s.uniter.SetState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	state, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, gc.DeepEquals, map[string]string{"two": "2"})
+}
+
+func (s *uniterSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Hook:     "config-changed",
+		Outcome:  "failed",
+		Queued:   started.Add(-time.Second),
+		Started:  started,
+		Finished: started.Add(3 * time.Second),
+	}
+	args := params.RecordHookExecutionsArgs{Args: []params.RecordHookExecutionsArg{
+		{Tag: "unit-mysql-0", Executions: []params.HookExecution{execution}},
+		{Tag: "unit-wordpress-0", Executions: []params.HookExecution{execution}},
+		{Tag: "unit-foo-42", Executions: []params.HookExecution{execution}},
+	}}
+	result, err := s.uniter.RecordHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 1)
+	c.Assert(executions[0].Hook, gc.Equals, "config-changed")
+	c.Assert(executions[0].Outcome, gc.Equals, "failed")
+	c.Assert(executions[0].Finished.Sub(executions[0].Started), gc.Equals, 3*time.Second)
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -1013,6 +1088,24 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 443, ToPort: 443, Endpoint: "admin"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `.*endpoint "admin" for application "wordpress" not valid`)
+
+	openedPorts, err := s.wordpressUnit.OpenedEndpointPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openedPorts, gc.DeepEquals, map[string][]network.PortRange{
+		"url": {{Protocol: "tcp", FromPort: 80, ToPort: 80}},
+	})
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -1154,6 +1247,38 @@ func (s *uniterSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Timestamp, gc.NotNil)
 }
 
+func (s *uniterSuite) TestLogActionOutput(c *gc.C) {
+	anAction, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrongAction, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionOutputParams{Output: []params.ActionOutputLines{
+		{Tag: anAction.Tag().String(), Stream: "stdout", Lines: []string{"one", "two"}},
+		{Tag: anAction.Tag().String(), Stream: "stdin", Lines: []string{"three"}},
+		{Tag: wrongAction.Tag().String(), Stream: "stdout", Lines: []string{"world"}},
+	}}
+	result, err := s.uniter.LogActionsOutput(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `action output stream "stdin" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := anAction.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "one")
+	c.Assert(messages[0].Stream, gc.Equals, "stdout")
+	c.Assert(messages[1].Message, gc.Equals, "two")
+}
+
 func (s *uniterSuite) TestWatchActionNotifications(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -2762,6 +2887,144 @@ func (s *uniterSuite) TestStorageAttachments(c *gc.C) {
 	}})
 }
 
+// setupStorageMove adds a unit with block storage, and starts moving
+// the storage to another pool, provisioning the volume being moved to.
+func (s *uniterSuite) setupStorageMove(c *gc.C) (*state.Unit, names.StorageTag, state.StorageMove) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	sCons := map[string]state.StorageConstraints{
+		"data": {Pool: "", Size: 1024, Count: 1},
+	}
+	application := s.AddTestingApplicationWithStorage(c, "storage-block", ch, sCons)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	assignedMachineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(assignedMachineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag := names.NewStorageTag("data/0")
+	volume, err := sb.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.SetVolumeAttachmentInfo(
+		machine.MachineTag(), volume.VolumeTag(),
+		state.VolumeAttachmentInfo{DeviceName: "xvdf1"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Move the storage, and provision the volume that it
+	// is being moved to.
+	err = sb.MoveStorageInstance(storageTag, "modelscoped-block")
+	c.Assert(err, jc.ErrorIsNil)
+	storageInstance, err := sb.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	move, ok := storageInstance.Move()
+	c.Assert(ok, jc.IsTrue)
+	err = sb.SetVolumeInfo(move.Volume, state.VolumeInfo{VolumeId: "vol-456", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.SetVolumeAttachmentInfo(
+		machine.MachineTag(), move.Volume,
+		state.VolumeAttachmentInfo{DeviceName: "xvdf2"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetMachineBlockDevices(
+		state.BlockDeviceInfo{DeviceName: "xvdf1"},
+		state.BlockDeviceInfo{DeviceName: "xvdf2"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return unit, storageTag, move
+}
+
+func (s *uniterSuite) TestCompleteStorageMoves(c *gc.C) {
+	unit, storageTag, move := s.setupStorageMove(c)
+	uniterAPI := s.newUniterAPI(c, s.State, apiservertesting.FakeAuthorizer{Tag: unit.Tag()})
+	result, err := uniterAPI.CompleteStorageMoves(params.StorageMoveCompletions{
+		Completions: []params.StorageMoveCompletion{{
+			StorageTag: storageTag.String(),
+			UnitTag:    unit.Tag().String(),
+			Target:     "/dev/xvdf1",
+		}, {
+			StorageTag: storageTag.String(),
+			UnitTag:    unit.Tag().String(),
+			Target:     "/dev/xvdf2",
+		}, {
+			StorageTag: storageTag.String(),
+			UnitTag:    s.wordpressUnit.Tag().String(),
+			Target:     "/dev/xvdf2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{&params.Error{Message: `move of storage "data/0" to "/dev/xvdf1" not found`, Code: params.CodeNotFound}},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := sb.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume.VolumeTag(), gc.Equals, move.Volume)
+}
+
+func (s *uniterSuite) TestSetStorageMoveProgress(c *gc.C) {
+	unit, storageTag, _ := s.setupStorageMove(c)
+	uniterAPI := s.newUniterAPI(c, s.State, apiservertesting.FakeAuthorizer{Tag: unit.Tag()})
+	result, err := uniterAPI.SetStorageMoveProgress(params.StorageMoveProgresses{
+		Progress: []params.StorageMoveProgress{{
+			StorageTag: storageTag.String(),
+			UnitTag:    unit.Tag().String(),
+			Target:     "/dev/xvdf1",
+			Copied:     1024,
+		}, {
+			StorageTag: storageTag.String(),
+			UnitTag:    unit.Tag().String(),
+			Target:     "/dev/xvdf2",
+			Copied:     512,
+		}, {
+			StorageTag: storageTag.String(),
+			UnitTag:    s.wordpressUnit.Tag().String(),
+			Target:     "/dev/xvdf2",
+			Copied:     1024,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{&params.Error{Message: `move of storage "data/0" to "/dev/xvdf1" not found`, Code: params.CodeNotFound}},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	storageInstance, err := sb.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	move, ok := storageInstance.Move()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(move.Copied, gc.Equals, uint64(512))
+
+	attachment, err := uniterAPI.StorageAttachments(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{
+			StorageTag: storageTag.String(),
+			UnitTag:    unit.Tag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Results[0].Result.MoveTarget, gc.Equals, "/dev/xvdf2")
+	c.Assert(attachment.Results[0].Result.MoveCopied, gc.Equals, uint64(512))
+}
+
 func (s *uniterSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -3164,6 +3427,21 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshPaused(c *gc.C) {
+	err := s.wordpressUnit.Pause("admin", "maintenance")
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{
+			{Life: params.Alive, Resolved: params.ResolvedNone, Paused: true},
+		},
+	})
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -3903,6 +4181,33 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 	})
 }
 
+func (s *uniterNetworkInfoSuite) TestNetworkInfoPrefersIPv6Addresses(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"preferred-address-family": "ipv6"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	space, err := s.State.Space("public")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(network.SubnetInfo{CIDR: "2001:db8::/64", SpaceID: space.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine0.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "2001:db8::10/64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.NetworkInfoParams{
+		Unit:     s.wordpressUnit.Tag().String(),
+		Bindings: []string{"admin-api"},
+	}
+	result, err := s.uniter.NetworkInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	info := result.Results["admin-api"]
+	c.Assert(info.Error, gc.IsNil)
+	c.Check(info.IngressAddresses, jc.DeepEquals, []string{"2001:db8::10", "8.8.8.10", "8.8.4.10", "8.8.4.11"})
+	c.Check(info.EgressSubnets, jc.DeepEquals, []string{"2001:db8::10/128"})
+}
+
 func (s *uniterNetworkInfoSuite) TestNetworkInfoL2Binding(c *gc.C) {
 	c.Skip("L2 not supported yet")
 	s.addRelationAndAssertInScope(c)
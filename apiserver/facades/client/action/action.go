@@ -6,7 +6,9 @@ package action
 import (
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
@@ -37,6 +39,16 @@ type APIv3 struct {
 
 // APIv4 provides the Action API facade for version 4.
 type APIv4 struct {
+	*APIv5
+}
+
+// APIv5 provides the Action API facade for version 5.
+type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Action API facade for version 6.
+type APIv6 struct {
 	*ActionAPI
 }
 
@@ -60,13 +72,31 @@ func NewActionAPIV3(ctx facade.Context) (*APIv3, error) {
 
 // NewActionAPIV4 returns an initialized ActionAPI for version 4.
 func NewActionAPIV4(ctx facade.Context) (*APIv4, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV5(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv4{api}, nil
 }
 
+// NewActionAPIV5 returns an initialized ActionAPI for version 5.
+func NewActionAPIV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewActionAPIV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
+// NewActionAPIV6 returns an initialized ActionAPI for version 6.
+func NewActionAPIV6(ctx facade.Context) (*APIv6, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -268,6 +298,255 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// EnqueueOperation queues up an action to be run on a number of units
+// as a single operation, subject to the operation's concurrency limit,
+// failure policy and leader ordering.
+func (a *ActionAPI) EnqueueOperation(arg params.RunOperationArgs) (params.OperationResult, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+
+	receivers, err := a.operationReceivers(arg.Receivers)
+	if err != nil {
+		return params.OperationResult{Error: common.ServerError(err)}, nil
+	}
+
+	// Validate the parameters against each receiver's charm, and fill
+	// in any defaults, before anything is enqueued. Receivers on which
+	// the action is not valid are left out of the operation, and their
+	// errors reported alongside its actions.
+	receivers, payloads, failed := a.prepareOperationPayloads(receivers, arg.Name, arg.Parameters)
+	if len(receivers) == 0 {
+		return params.OperationResult{Actions: failed, Error: failed[0].Error}, nil
+	}
+	var last *names.UnitTag
+	if arg.LeaderLast {
+		if receivers, last, err = a.leaderLast(receivers); err != nil {
+			return params.OperationResult{Error: common.ServerError(err)}, nil
+		}
+	}
+
+	op, err := a.model.EnqueueOperation(state.OperationArgs{
+		ActionName:    arg.Name,
+		Parameters:    payloads,
+		Receivers:     receivers,
+		Last:          last,
+		Parallel:      arg.Parallel,
+		StopOnFailure: arg.StopOnFailure,
+	})
+	if err != nil {
+		return params.OperationResult{Error: common.ServerError(err)}, nil
+	}
+	result, err := a.operationResult(op)
+	if err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+	result.Actions = append(result.Actions, failed...)
+	return result, nil
+}
+
+// prepareOperationPayloads validates the action's parameters against
+// the charm of each receiver, returning the receivers on which the
+// action is valid, its parameters with defaults inserted for each of
+// their applications, and an error result for each other receiver.
+func (a *ActionAPI) prepareOperationPayloads(
+	receivers []names.UnitTag, name string, parameters map[string]interface{},
+) ([]names.UnitTag, map[string]map[string]interface{}, []params.ActionResult) {
+	var (
+		valid  []names.UnitTag
+		failed []params.ActionResult
+	)
+	payloads := make(map[string]map[string]interface{})
+	for _, receiver := range receivers {
+		payload, err := a.prepareActionPayload(receiver, name, parameters)
+		if err != nil {
+			failed = append(failed, params.ActionResult{
+				Action: &params.Action{
+					Receiver:   receiver.String(),
+					Name:       name,
+					Parameters: parameters,
+				},
+				Error: common.ServerError(err),
+			})
+			continue
+		}
+		appName, _ := names.UnitApplication(receiver.Id())
+		if _, ok := payloads[appName]; !ok {
+			payloads[appName] = payload
+		}
+		valid = append(valid, receiver)
+	}
+	return valid, payloads, failed
+}
+
+// prepareActionPayload validates the action's parameters against the
+// receiver's charm, and returns them with any defaults inserted.
+func (a *ActionAPI) prepareActionPayload(
+	receiver names.UnitTag, name string, parameters map[string]interface{},
+) (map[string]interface{}, error) {
+	unit, err := a.state.Unit(receiver.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.PrepareActionPayload(name, parameters)
+}
+
+// operationReceivers resolves the receivers of an operation into unit
+// tags. Application tags stand for every unit of the application, and
+// "<application>/leader" for the application's leader.
+func (a *ActionAPI) operationReceivers(receivers []string) ([]names.UnitTag, error) {
+	var leaders map[string]string
+	var result []names.UnitTag
+	seen := set.NewStrings()
+	add := func(unitName string) {
+		if !seen.Contains(unitName) {
+			seen.Add(unitName)
+			result = append(result, names.NewUnitTag(unitName))
+		}
+	}
+	for _, receiver := range receivers {
+		if strings.HasSuffix(receiver, "/leader") {
+			if leaders == nil {
+				var err error
+				if leaders, err = a.state.ApplicationLeaders(); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			appName := strings.TrimSuffix(receiver, "/leader")
+			leader, ok := leaders[appName]
+			if !ok {
+				return nil, errors.Errorf("could not determine leader for %q", appName)
+			}
+			add(leader)
+			continue
+		}
+		tag, err := names.ParseTag(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.UnitTag:
+			add(tag.Id())
+		case names.ApplicationTag:
+			app, err := a.state.Application(tag.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := app.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			unitNames := make([]string, len(units))
+			for i, unit := range units {
+				unitNames[i] = unit.Name()
+			}
+			naturalsort.Sort(unitNames)
+			for _, unitName := range unitNames {
+				add(unitName)
+			}
+		default:
+			return nil, errors.NotValidf("operation receiver %q", receiver)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no units to run the operation on")
+	}
+	return result, nil
+}
+
+// leaderLast moves the application leader to the end of the operation,
+// so that it only runs once every other unit has finished. All of the
+// receivers must belong to the same application.
+func (a *ActionAPI) leaderLast(receivers []names.UnitTag) ([]names.UnitTag, *names.UnitTag, error) {
+	appName, err := names.UnitApplication(receivers[0].Id())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, receiver := range receivers[1:] {
+		if other, _ := names.UnitApplication(receiver.Id()); other != appName {
+			return nil, nil, errors.NotValidf("running the leader last across applications %q and %q", appName, other)
+		}
+	}
+	leaders, err := a.state.ApplicationLeaders()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	leader, ok := leaders[appName]
+	if !ok {
+		return nil, nil, errors.Errorf("could not determine leader for %q", appName)
+	}
+	var others []names.UnitTag
+	var last *names.UnitTag
+	for _, receiver := range receivers {
+		if receiver.Id() == leader {
+			tag := receiver
+			last = &tag
+			continue
+		}
+		others = append(others, receiver)
+	}
+	return others, last, nil
+}
+
+// Operations returns the operations with the given ids, along with the
+// actions they have enqueued so far.
+func (a *ActionAPI) Operations(arg params.OperationIDs) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	response := params.OperationResults{Results: make([]params.OperationResult, len(arg.IDs))}
+	for i, id := range arg.IDs {
+		op, err := a.model.Operation(id)
+		if err != nil {
+			response.Results[i] = params.OperationResult{
+				OperationID: id,
+				Error:       common.ServerError(err),
+			}
+			continue
+		}
+		result, err := a.operationResult(op)
+		if err != nil {
+			return params.OperationResults{}, errors.Trace(err)
+		}
+		response.Results[i] = result
+	}
+	return response, nil
+}
+
+func (a *ActionAPI) operationResult(op *state.Operation) (params.OperationResult, error) {
+	result := params.OperationResult{
+		OperationID:   op.Id(),
+		ActionName:    op.ActionName(),
+		Status:        string(op.Status()),
+		Message:       op.Message(),
+		Parallel:      op.Parallel(),
+		StopOnFailure: op.StopOnFailure(),
+		Enqueued:      op.Enqueued(),
+		Completed:     op.Completed(),
+		Queued:        op.Queued(),
+	}
+	actions, err := op.Actions()
+	if err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+	for _, action := range actions {
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			result.Actions = append(result.Actions, params.ActionResult{Error: common.ServerError(err)})
+			continue
+		}
+		result.Actions = append(result.Actions, common.MakeActionResult(receiverTag, action))
+	}
+	return result, nil
+}
+
+// EnqueueOperation isn't on the v4 API.
+func (*APIv4) EnqueueOperation(_, _ struct{}) {}
+
+// Operations isn't on the v4 API.
+func (*APIv4) Operations(_, _ struct{}) {}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
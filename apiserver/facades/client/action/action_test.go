@@ -269,6 +269,106 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
+	wordpress1 := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine1,
+	})
+	claimer, err := s.LeaseManager.Claimer("application-leadership", s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.Claim("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.action.EnqueueOperation(params.RunOperationArgs{
+		Receivers:     []string{s.wordpress.Tag().String()},
+		Name:          "fakeaction",
+		Parallel:      1,
+		StopOnFailure: true,
+		LeaderLast:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.OperationID, gc.Equals, "1")
+	c.Assert(result.Status, gc.Equals, "running")
+	c.Assert(result.Parallel, gc.Equals, 1)
+	c.Assert(result.StopOnFailure, jc.IsTrue)
+	c.Assert(result.Queued, jc.DeepEquals, []string{"wordpress/0"})
+	c.Assert(result.Actions, gc.HasLen, 1)
+	c.Assert(result.Actions[0].Action.Receiver, gc.Equals, wordpress1.Tag().String())
+
+	ops, err := s.action.Operations(params.OperationIDs{IDs: []string{"1", "2"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops.Results, gc.HasLen, 2)
+	c.Assert(ops.Results[0].Error, gc.IsNil)
+	c.Assert(ops.Results[0].OperationID, gc.Equals, "1")
+	c.Assert(ops.Results[0].Actions, gc.HasLen, 1)
+	c.Assert(ops.Results[1].Error, gc.ErrorMatches, `operation "2" not found`)
+}
+
+func (s *actionSuite) TestEnqueueOperationValidatesEachReceiver(c *gc.C) {
+	dummyUnit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.dummy,
+		Machine:     s.machine1,
+	})
+
+	result, err := s.action.EnqueueOperation(params.RunOperationArgs{
+		Receivers: []string{s.wordpressUnit.Tag().String(), dummyUnit.Tag().String()},
+		Name:      "snapshot",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Actions, gc.HasLen, 2)
+
+	// The action is enqueued with the defaults from the dummy charm.
+	c.Assert(result.Actions[0].Error, gc.IsNil)
+	c.Assert(result.Actions[0].Action.Receiver, gc.Equals, dummyUnit.Tag().String())
+	c.Assert(result.Actions[0].Action.Parameters, jc.DeepEquals, map[string]interface{}{
+		"outfile": "foo.bz2",
+	})
+
+	// The wordpress charm has no such action.
+	c.Assert(result.Actions[1].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(result.Actions[1].Error, gc.ErrorMatches, `action "snapshot" not defined on unit "wordpress/0"`)
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestEnqueueOperationErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   params.RunOperationArgs
+		errMsg string
+	}{{
+		args:   params.RunOperationArgs{Name: "fakeaction"},
+		errMsg: "no units to run the operation on",
+	}, {
+		args: params.RunOperationArgs{
+			Receivers:  []string{s.wordpressUnit.Tag().String(), s.mysqlUnit.Tag().String()},
+			Name:       "fakeaction",
+			LeaderLast: true,
+		},
+		errMsg: `running the leader last across applications "wordpress" and "mysql" not valid`,
+	}, {
+		args: params.RunOperationArgs{
+			Receivers: []string{s.wordpressUnit.Tag().String()},
+			Name:      "nope",
+		},
+		errMsg: `action "nope" not defined on unit "wordpress/0"`,
+	}, {
+		args: params.RunOperationArgs{
+			Receivers: []string{s.machine0.Tag().String()},
+			Name:      "fakeaction",
+		},
+		errMsg: `operation receiver "machine-0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		result, err := s.action.EnqueueOperation(test.args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result.Error, gc.ErrorMatches, test.errMsg)
+	}
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
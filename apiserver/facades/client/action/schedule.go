@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules adds schedules on which the controller runs
+// actions against applications or units.
+func (a *ActionAPI) AddActionSchedules(args params.ActionScheduleArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Schedules))}
+	for i, arg := range args.Schedules {
+		receiver, err := names.ParseTag(arg.Receiver)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		_, err = a.model.AddActionSchedule(state.ActionScheduleArgs{
+			Name:            arg.Name,
+			Receiver:        receiver,
+			ActionName:      arg.ActionName,
+			Parameters:      arg.Parameters,
+			Cron:            arg.Cron,
+			MissedRunPolicy: state.ActionMissedRunPolicy(arg.MissedRunPolicy),
+			OverlapPolicy:   state.ActionOverlapPolicy(arg.OverlapPolicy),
+		})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ActionSchedules returns all of the action schedules in the model.
+func (a *ActionAPI) ActionSchedules() (params.ActionSchedulesResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedulesResult{}, errors.Trace(err)
+	}
+
+	schedules, err := a.model.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedulesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.ActionSchedulesResult{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		receiver, err := schedule.Receiver()
+		if err != nil {
+			return params.ActionSchedulesResult{Error: common.ServerError(err)}, nil
+		}
+		result.Schedules[i] = params.ActionSchedule{
+			Name:            schedule.Name(),
+			Receiver:        receiver.String(),
+			ActionName:      schedule.ActionName(),
+			Parameters:      schedule.Parameters(),
+			Cron:            schedule.Cron(),
+			MissedRunPolicy: string(schedule.MissedRunPolicy()),
+			OverlapPolicy:   string(schedule.OverlapPolicy()),
+			Created:         schedule.Created(),
+			NextRun:         schedule.NextRun(),
+			LastRun:         schedule.LastRun(),
+			LastActions:     schedule.LastActions(),
+			LastMessage:     schedule.LastMessage(),
+		}
+	}
+	return result, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// names. Actions already enqueued by a schedule are left to run.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Names))}
+	for i, name := range args.Names {
+		result.Results[i].Error = common.ServerError(a.model.RemoveActionSchedule(name))
+	}
+	return result, nil
+}
+
+// AddActionSchedules isn't on the v5 API.
+func (*APIv5) AddActionSchedules(_, _ struct{}) {}
+
+// ActionSchedules isn't on the v5 API.
+func (*APIv5) ActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the v5 API.
+func (*APIv5) RemoveActionSchedules(_, _ struct{}) {}
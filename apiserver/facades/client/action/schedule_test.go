@@ -0,0 +1,89 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestAddActionSchedules(c *gc.C) {
+	result, err := s.action.AddActionSchedules(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Name:          "nightly",
+			Receiver:      s.dummy.Tag().String(),
+			ActionName:    "snapshot",
+			Cron:          "0 2 * * *",
+			OverlapPolicy: "queue",
+		}, {
+			Name:       "broken",
+			Receiver:   s.dummy.Tag().String(),
+			ActionName: "snapshot",
+			Cron:       "whenever",
+		}, {
+			Name:       "bad-receiver",
+			Receiver:   "dummy",
+			ActionName: "snapshot",
+			Cron:       "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `invalid schedule "whenever": .*`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"dummy" is not a valid tag`)
+
+	schedules, err := s.action.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Error, gc.IsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 1)
+	schedule := schedules.Schedules[0]
+	c.Assert(schedule.Name, gc.Equals, "nightly")
+	c.Assert(schedule.Receiver, gc.Equals, "application-dummy")
+	c.Assert(schedule.ActionName, gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters, jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	c.Assert(schedule.Cron, gc.Equals, "0 2 * * *")
+	c.Assert(schedule.MissedRunPolicy, gc.Equals, "skip")
+	c.Assert(schedule.OverlapPolicy, gc.Equals, "queue")
+	c.Assert(schedule.NextRun.After(schedule.Created), jc.IsTrue)
+}
+
+func (s *actionSuite) TestAddActionSchedulesBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestAddActionSchedulesBlocked")
+	_, err := s.action.AddActionSchedules(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Name:       "nightly",
+			Receiver:   s.dummy.Tag().String(),
+			ActionName: "snapshot",
+			Cron:       "@daily",
+		}},
+	})
+	s.AssertBlocked(c, err, "TestAddActionSchedulesBlocked")
+}
+
+func (s *actionSuite) TestRemoveActionSchedules(c *gc.C) {
+	_, err := s.action.AddActionSchedules(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Name:       "nightly",
+			Receiver:   s.dummy.Tag().String(),
+			ActionName: "snapshot",
+			Cron:       "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.action.RemoveActionSchedules(params.ActionScheduleNames{
+		Names: []string{"nightly", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `action schedule "missing" not found`)
+
+	schedules, err := s.action.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 0)
+}
@@ -81,6 +81,30 @@ type APIv9 struct {
 // APIv10 provides the Application API facade for version 10.
 // It adds --force and --max-wait parameters to remove-saas.
 type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+// It adds MergeBindings.
+type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+// It adds UnitsInfo.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds PauseUnits and ResumeUnits.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds endpoint expose settings to Expose and Unexpose.
+type APIv14 struct {
 	*APIBase
 }
 
@@ -171,13 +195,45 @@ func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
 }
 
 func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV11(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv10{api}, nil
 }
 
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacadeV12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -407,7 +463,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(application.HookRetryFields, nil)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -415,6 +471,12 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	for name, field := range application.HookRetryFields {
+		if _, ok := configSchema[name]; ok {
+			return nil, nil, errors.Errorf("config field %q clashes with hook retry config", name)
+		}
+		configSchema[name] = field
+	}
 	return AddTrustSchemaAndDefaults(configSchema, defaults)
 }
 
@@ -617,6 +679,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := applicationConfig.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -1224,11 +1289,30 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposed := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for endpoint, settings := range args.ExposedEndpoints {
+		exposed[endpoint] = state.ExposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposed)
+}
+
+// Expose changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open. Endpoint expose
+// settings are not understood by the v13 API.
+func (api *APIv13) Expose(args params.ApplicationExpose) error {
+	args.ExposedEndpoints = nil
+	return api.APIv14.Expose(args)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If endpoints are
+// specified, only their expose settings are removed.
 func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -1240,7 +1324,18 @@ func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
-	return app.ClearExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.ClearExposed()
+	}
+	return app.UnsetExposeSettings(args.ExposedEndpoints)
+}
+
+// Unexpose changes the juju-managed firewall to unexpose any ports that
+// were also explicitly marked by units as open. Endpoint expose
+// settings are not understood by the v13 API.
+func (api *APIv13) Unexpose(args params.ApplicationUnexpose) error {
+	args.ExposedEndpoints = nil
+	return api.APIv14.Unexpose(args)
 }
 
 // AddUnits adds a given number of units to an application.
@@ -1730,6 +1825,32 @@ func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	return app.SetConstraints(args.Constraints)
 }
 
+// MergeBindings merges the supplied endpoint bindings with those of each
+// input application. Endpoints not mentioned retain their current bindings.
+func (api *APIBase) MergeBindings(in params.ApplicationMergeBindingsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	res := make([]params.ErrorResult, len(in.Args))
+	for i, arg := range in.Args {
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			res[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			res[i].Error = common.ServerError(err)
+			continue
+		}
+		res[i].Error = common.ServerError(app.MergeBindings(arg.Bindings))
+	}
+	return params.ErrorResults{Results: res}, nil
+}
+
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
 func (api *APIBase) AddRelation(args params.AddRelation) (_ params.AddRelationResults, err error) {
 	var rel Relation
@@ -1909,6 +2030,7 @@ func (api *APIBase) consumeOne(arg params.ConsumeApplicationArg) error {
 				Alias:         arg.ControllerInfo.Alias,
 				Addrs:         arg.ControllerInfo.Addrs,
 				CACert:        arg.ControllerInfo.CACert,
+				RelayProxy:    arg.ControllerInfo.RelayProxy,
 			}, sourceModelTag.Id()); err != nil {
 				return errors.Trace(err)
 			}
@@ -2337,6 +2459,9 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 // ApplicationInfo isn't on the v8 API.
 func (u *APIv8) ApplicationInfo(_, _ struct{}) {}
 
+// MergeBindings isn't on the v10 API.
+func (u *APIv10) MergeBindings(_, _ struct{}) {}
+
 // ApplicationsInfo returns applications information.
 func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfoResults, error) {
 	out := make([]params.ApplicationInfoResult, len(in.Entities))
@@ -2379,6 +2504,132 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 	return params.ApplicationInfoResults{out}, nil
 }
 
+// UnitsInfo isn't on the v11 API.
+func (u *APIv11) UnitsInfo(_, _ struct{}) {}
+
+// PauseUnits isn't on the v12 API.
+func (u *APIv12) PauseUnits(_, _ struct{}) {}
+
+// ResumeUnits isn't on the v12 API.
+func (u *APIv12) ResumeUnits(_, _ struct{}) {}
+
+// PauseUnits stops the agents of the given units from running hooks
+// until the units are resumed, recording the authenticated user and
+// the given reason as who paused them and why.
+func (api *APIBase) PauseUnits(args params.PauseUnitsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	by := api.authorizer.GetAuthTag().Id()
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		unit, err := api.unitFromTag(entity.Tag)
+		if err == nil {
+			err = unit.Pause(by, args.Reason)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ResumeUnits lets the agents of the given paused units run hooks
+// again.
+func (api *APIBase) ResumeUnits(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		unit, err := api.unitFromTag(entity.Tag)
+		if err == nil {
+			err = unit.Resume()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *APIBase) unitFromTag(tagString string) (Unit, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Unit(tag.Id())
+}
+
+// UnitsInfo returns information about the given units, including the
+// state stored for them by their charms.
+func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	out := make([]params.UnitInfoResult, len(in.Entities))
+	for i, one := range in.Entities {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		info, err := api.unitInfo(tag)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		out[i].Result = info
+	}
+	return params.UnitInfoResults{out}, nil
+}
+
+func (api *APIBase) unitInfo(tag names.UnitTag) (*params.UnitInfo, error) {
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.UnitInfo{
+		Tag:  tag.String(),
+		Life: unit.Life().String(),
+	}
+	if curl, _ := unit.CharmURL(); curl != nil {
+		info.Charm = curl.String()
+	}
+	if info.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil && !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	info.Machine = machineId
+	info.Principal, _ = unit.PrincipalName()
+	if info.State, err = unit.State(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	executions, err := unit.HookExecutions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, e := range executions {
+		info.HookExecutions = append(info.HookExecutions, params.HookExecution{
+			Hook:     e.Hook,
+			Outcome:  e.Outcome,
+			Queued:   e.Queued,
+			Started:  e.Started,
+			Finished: e.Finished,
+		})
+	}
+	return info, nil
+}
+
 // lxdCharmProfiler massages a *state.Charm into a LXDProfiler
 // inside of the core package.
 type lxdCharmProfiler struct {
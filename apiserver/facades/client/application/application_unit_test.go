@@ -55,7 +55,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv10
+	api          *application.APIv14
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -85,7 +85,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv10{api}
+	s.api = &application.APIv14{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -144,6 +144,15 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 						tag:        names.NewUnitTag("postgresql/0"),
 						machineId:  "machine-0",
 						agentTools: agentTools,
+						charmURL:   charm.MustParseURL("cs:postgresql-42"),
+						state:      map[string]string{"seen": "yes"},
+						hooks: []state.HookExecution{{
+							Hook:     "config-changed",
+							Outcome:  "succeeded",
+							Queued:   time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+							Started:  time.Date(2020, 4, 1, 12, 0, 1, 0, time.UTC),
+							Finished: time.Date(2020, 4, 1, 12, 0, 4, 0, time.UTC),
+						}},
 					},
 					{
 						name:       "postgresql/1",
@@ -1398,6 +1407,29 @@ func (s *ApplicationSuite) TestApplicationUpdateSeries(c *gc.C) {
 	c.Assert(len(app.Calls()), gc.Equals, 5)
 }
 
+func (s *ApplicationSuite) TestMergeBindings(c *gc.C) {
+	bindings := map[string]string{"db": "alpha"}
+	results, err := s.api.MergeBindings(params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: names.NewApplicationTag("postgresql").String(),
+			Bindings:       bindings,
+		}, {
+			ApplicationTag: names.NewApplicationTag("name").String(),
+			Bindings:       bindings,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "application \"name\" not found", Code: "not found"}},
+		}})
+	s.backend.CheckCall(c, 0, "Application", "postgresql")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "MergeBindings")
+	app.CheckCall(c, 0, "MergeBindings", bindings)
+}
+
 func (s *ApplicationSuite) TestApplicationUpdateSeriesNoParams(c *gc.C) {
 	results, err := s.api.UpdateApplicationSeries(
 		params.UpdateSeriesArgs{
@@ -1686,6 +1718,45 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestPauseUnits(c *gc.C) {
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-postgresql-5"}, {Tag: "application-postgresql"}}
+	result, err := s.api.PauseUnits(params.PauseUnitsArgs{Entities: entities, Reason: "kernel upgrade"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "postgresql/5" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckCallNames(c, "Pause")
+	unit.CheckCall(c, 0, "Pause", "admin", "kernel upgrade")
+}
+
+func (s *ApplicationSuite) TestBlockPauseUnits(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.PauseUnits(params.PauseUnitsArgs{Entities: []params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestPauseUnitsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.PauseUnits(params.PauseUnitsArgs{Entities: []params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestResumeUnits(c *gc.C) {
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-postgresql-1"}}
+	result, err := s.api.ResumeUnits(params.Entities{Entities: entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}, {}}})
+	for i := 0; i < 2; i++ {
+		s.backend.applications["postgresql"].units[i].CheckCallNames(c, "Resume")
+	}
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
@@ -1707,6 +1778,46 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
 
+func (s *ApplicationSuite) TestExposeEndpoints(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"db":  {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+			"web": {ExposeToSpaces: []string{"public"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "MergeExposeSettings")
+	app.CheckCall(c, 0, "MergeExposeSettings", map[string]state.ExposedEndpoint{
+		"db":  {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"web": {ExposeToSpaces: []string{"public"}},
+	})
+}
+
+func (s *ApplicationSuite) TestExposeEndpointsV13(c *gc.C) {
+	api := &application.APIv13{s.api}
+	err := api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"db": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCallNames(c, "SetExposed")
+}
+
+func (s *ApplicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	err := s.api.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "postgresql",
+		ExposedEndpoints: []string{"db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UnsetExposeSettings")
+	app.CheckCall(c, 0, "UnsetExposeSettings", []string{"db"})
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	entities := []params.Entity{{Tag: "application-postgresql"}}
 	result, err := s.api.ApplicationsInfo(params.Entities{entities})
@@ -1777,3 +1888,35 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "Series", "Channel", "EndpointBindings", "IsPrincipal", "IsExposed", "IsRemote")
 }
+
+func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-postgresql-5"}, {Tag: "application-postgresql"}}
+	result, err := s.api.UnitsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(*result.Results[0].Result, gc.DeepEquals, params.UnitInfo{
+		Tag:             "unit-postgresql-0",
+		Life:            "alive",
+		Charm:           "cs:postgresql-42",
+		WorkloadVersion: "9.6",
+		Machine:         "machine-0",
+		State:           map[string]string{"seen": "yes"},
+		HookExecutions: []params.HookExecution{{
+			Hook:     "config-changed",
+			Outcome:  "succeeded",
+			Queued:   time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+			Started:  time.Date(2020, 4, 1, 12, 0, 1, 0, time.UTC),
+			Finished: time.Date(2020, 4, 1, 12, 0, 4, 0, time.UTC),
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "postgresql/5" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckCallNames(c, "Life", "CharmURL", "WorkloadVersion", "AssignedMachineId", "PrincipalName", "State", "HookExecutions")
+}
+
+func (s *ApplicationSuite) TestUnitsInfoPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.UnitsInfo(params.Entities{[]params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -77,9 +77,12 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	EndpointBindings() (map[string]string, error)
 	Endpoints() ([]state.Endpoint, error)
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 	IsExposed() bool
 	IsPrincipal() bool
 	IsRemote() bool
+	MergeBindings(map[string]string) error
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
@@ -89,6 +92,7 @@ type Application interface {
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(string, charm.Settings) error
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
+	UnsetExposeSettings([]string) error
 	SetScale(int, int64, bool) error
 	ChangeScale(int) (int, error)
 	AgentTools() (*tools.Tools, error)
@@ -140,6 +144,13 @@ type Unit interface {
 	Life() state.Life
 	Resolve(retryHooks bool) error
 	AgentTools() (*tools.Tools, error)
+	CharmURL() (*charm.URL, bool)
+	PrincipalName() (string, bool)
+	WorkloadVersion() (string, error)
+	State() (map[string]string, error)
+	HookExecutions() ([]state.HookExecution, error)
+	Pause(by, reason string) error
+	Resume() error
 
 	AssignedMachineId() (string, error)
 	AssignWithPolicy(state.AssignmentPolicy) error
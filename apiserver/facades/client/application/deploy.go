@@ -204,9 +204,10 @@ func stateStorageConstraints(cons map[string]storage.Constraints) map[string]sta
 	result := make(map[string]state.StorageConstraints)
 	for name, cons := range cons {
 		result[name] = state.StorageConstraints{
-			Pool:  cons.Pool,
-			Size:  cons.Size,
-			Count: cons.Count,
+			Pool:       cons.Pool,
+			Size:       cons.Size,
+			Count:      cons.Count,
+			SnapshotId: cons.SnapshotId,
 		}
 	}
 	return result
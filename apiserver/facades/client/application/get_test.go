@@ -28,7 +28,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv10
+	applicationAPI *application.APIv14
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -59,12 +59,12 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv10{api}
+	s.applicationAPI = &application.APIv14{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{s.applicationAPI}}}}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{s.applicationAPI}}}}}}}}}}
 	results, err := v4.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -84,7 +84,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{s.applicationAPI}}}}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{s.applicationAPI}}}}}}}}}
 	results, err := v5.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -120,14 +120,14 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 				"value":       "My Title",
 			},
 		},
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withHookRetryConfigInfo(false, map[string]interface{}{
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
-			}},
+			}}),
 		Series: "quantal",
 	})
 }
@@ -141,6 +141,9 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 
 	schemaFields, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
+	for name, field := range coreapplication.HookRetryFields {
+		schemaFields[name] = field
+	}
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
@@ -201,7 +204,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{api}}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{api}}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -273,7 +276,7 @@ var getTests = []struct {
 				"type":        "int",
 			},
 		},
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withHookRetryConfigInfo(true, map[string]interface{}{
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -281,7 +284,7 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
-		},
+		}),
 		Series: "quantal",
 	},
 }, {
@@ -331,7 +334,7 @@ var getTests = []struct {
 				"value": float64(0),
 			},
 		},
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withHookRetryConfigInfo(true, map[string]interface{}{
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -339,7 +342,7 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
-		},
+		}),
 		Series: "quantal",
 	},
 }, {
@@ -348,7 +351,7 @@ var getTests = []struct {
 	expect: params.ApplicationGetResults{
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withHookRetryConfigInfo(true, map[string]interface{}{
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -356,7 +359,7 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
-		},
+		}),
 	},
 }}
 
@@ -413,3 +416,21 @@ func (s *getSuite) TestGetMaxResolutionInt(c *gc.C) {
 		"value":       asFloat,
 	})
 }
+
+// withHookRetryConfigInfo adds the description of the unset hook retry
+// application config to appConfig. Field types are strings in results
+// read through the API.
+func withHookRetryConfigInfo(viaAPI bool, appConfig map[string]interface{}) map[string]interface{} {
+	for name, field := range coreapplication.HookRetryFields {
+		var fieldType interface{} = field.Type
+		if viaAPI {
+			fieldType = string(field.Type)
+		}
+		appConfig[name] = map[string]interface{}{
+			"description": field.Description,
+			"source":      "unset",
+			"type":        fieldType,
+		}
+	}
+	return appConfig
+}
@@ -206,6 +206,11 @@ func (a *mockApplication) UpdateApplicationSeries(series string, force bool) err
 	return a.NextErr()
 }
 
+func (a *mockApplication) MergeBindings(bindings map[string]string) error {
+	a.MethodCall(a, "MergeBindings", bindings)
+	return a.NextErr()
+}
+
 func (a *mockApplication) Series() string {
 	a.MethodCall(a, "Series")
 	a.PopNoErr()
@@ -237,6 +242,16 @@ func (a *mockApplication) SetExposed() error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) MergeExposeSettings(exposed map[string]state.ExposedEndpoint) error {
+	a.MethodCall(a, "MergeExposeSettings", exposed)
+	return a.NextErr()
+}
+
+func (a *mockApplication) UnsetExposeSettings(endpoints []string) error {
+	a.MethodCall(a, "UnsetExposeSettings", endpoints)
+	return a.NextErr()
+}
+
 func (a *mockApplication) IsExposed() bool {
 	a.MethodCall(a, "IsExposed")
 	return a.exposed
@@ -740,6 +755,9 @@ type mockUnit struct {
 	machineId  string
 	name       string
 	agentTools *tools.Tools
+	charmURL   *charm.URL
+	state      map[string]string
+	hooks      []state.HookExecution
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -791,6 +809,46 @@ func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	return u.agentTools, u.NextErr()
 }
 
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	return state.Alive
+}
+
+func (u *mockUnit) CharmURL() (*charm.URL, bool) {
+	u.MethodCall(u, "CharmURL")
+	return u.charmURL, false
+}
+
+func (u *mockUnit) PrincipalName() (string, bool) {
+	u.MethodCall(u, "PrincipalName")
+	return "", false
+}
+
+func (u *mockUnit) WorkloadVersion() (string, error) {
+	u.MethodCall(u, "WorkloadVersion")
+	return "9.6", u.NextErr()
+}
+
+func (u *mockUnit) State() (map[string]string, error) {
+	u.MethodCall(u, "State")
+	return u.state, u.NextErr()
+}
+
+func (u *mockUnit) HookExecutions() ([]state.HookExecution, error) {
+	u.MethodCall(u, "HookExecutions")
+	return u.hooks, u.NextErr()
+}
+
+func (u *mockUnit) Pause(by, reason string) error {
+	u.MethodCall(u, "Pause", by, reason)
+	return u.NextErr()
+}
+
+func (u *mockUnit) Resume() error {
+	u.MethodCall(u, "Resume")
+	return u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
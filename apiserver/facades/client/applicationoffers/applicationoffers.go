@@ -4,6 +4,7 @@
 package applicationoffers
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/juju/errors"
@@ -15,7 +16,9 @@ import (
 	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
@@ -31,8 +34,14 @@ type environFromModelFunc func(string) (environs.Environ, error)
 // implementation of the api end point.
 type OffersAPI struct {
 	BaseAPI
-	dataDir     string
-	authContext *commoncrossmodel.AuthContext
+	dataDir       string
+	authContext   *commoncrossmodel.AuthContext
+	auditRecorder AuditRecorder
+}
+
+// AuditRecorder records requests in the audit log.
+type AuditRecorder interface {
+	AddRequest(auditlog.RequestArgs) error
 }
 
 // OffersAPIV2 implements the cross model interface V2.
@@ -40,6 +49,11 @@ type OffersAPIV2 struct {
 	*OffersAPI
 }
 
+// OffersAPIV3 implements the cross model interface V3.
+type OffersAPIV3 struct {
+	*OffersAPIV2
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -70,6 +84,11 @@ func createOffersAPI(
 			callContext:          callCtx,
 		},
 	}
+	// The audit recorder is only available when audit logging
+	// is enabled.
+	if r, ok := resources.Get("auditRecorder").(common.ValueResource); ok {
+		api.auditRecorder = r.Value.(AuditRecorder)
+	}
 	return api, nil
 }
 
@@ -122,6 +141,15 @@ func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
 	return &OffersAPIV2{OffersAPI: apiV1}, nil
 }
 
+// NewOffersAPIV3 returns a new application offers OffersAPIV3 facade.
+func NewOffersAPIV3(ctx facade.Context) (*OffersAPIV3, error) {
+	apiV2, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV3{OffersAPIV2: apiV2}, nil
+}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
@@ -161,12 +189,16 @@ func (api *OffersAPI) makeAddOfferArgsFromParams(backend Backend, addOfferParams
 		ApplicationName:        addOfferParams.ApplicationName,
 		ApplicationDescription: addOfferParams.ApplicationDescription,
 		Endpoints:              addOfferParams.Endpoints,
+		MaxConnections:         addOfferParams.MaxConnections,
 		Owner:                  api.Authorizer.GetAuthTag().Id(),
 		HasRead:                []string{common.EveryoneTagName},
 	}
 	if result.OfferName == "" {
 		result.OfferName = result.ApplicationName
 	}
+	if result.MaxConnections < 0 {
+		return result, errors.NotValidf("negative max connections %d", result.MaxConnections)
+	}
 	application, err := backend.Application(addOfferParams.ApplicationName)
 	if err != nil {
 		return result, errors.Annotatef(err, "getting offered application %v", addOfferParams.ApplicationName)
@@ -247,8 +279,23 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 	}
 	offerTag := names.NewApplicationOfferTag(url.ApplicationName)
 
+	if err := api.checkOfferAdmin(backend, isControllerAdmin, offerTag.Id()); err != nil {
+		return errors.Trace(err)
+	}
+
+	targetUserTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Annotate(err, "could not modify offer access")
+	}
+	return api.changeOfferAccess(backend, offerTag, targetUserTag, arg.Action, offerAccess)
+}
+
+// checkOfferAdmin ensures that the logged in user is a controller or model
+// admin, or has been granted admin access to the named offer.
+func (api *OffersAPI) checkOfferAdmin(backend Backend, isControllerAdmin bool, offerName string) error {
 	canModifyOffer := isControllerAdmin
 	if !canModifyOffer {
+		var err error
 		if canModifyOffer, err = api.Authorizer.HasPermission(permission.AdminAccess, backend.ModelTag()); err != nil {
 			return errors.Trace(err)
 		}
@@ -256,7 +303,7 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 
 	if !canModifyOffer {
 		apiUser := api.Authorizer.GetAuthTag().(names.UserTag)
-		offer, err := backend.ApplicationOffer(offerTag.Id())
+		offer, err := backend.ApplicationOffer(offerName)
 		if err != nil {
 			return common.ErrPerm
 		}
@@ -270,12 +317,7 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 	if !canModifyOffer {
 		return common.ErrPerm
 	}
-
-	targetUserTag, err := names.ParseUserTag(arg.UserTag)
-	if err != nil {
-		return errors.Annotate(err, "could not modify offer access")
-	}
-	return api.changeOfferAccess(backend, offerTag, targetUserTag, arg.Action, offerAccess)
+	return nil
 }
 
 // changeOfferAccess performs the requested access grant or revoke action for the
@@ -458,10 +500,16 @@ func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOf
 		return consumeResults, common.ServerError(err)
 	}
 
+	controllerConfig, err := api.ControllerModel.ControllerConfig()
+	if err != nil {
+		return consumeResults, common.ServerError(err)
+	}
+
 	controllerInfo := &params.ExternalControllerInfo{
 		ControllerTag: api.ControllerModel.ControllerTag().String(),
 		Addrs:         addrs,
 		CACert:        caCert,
+		RelayProxy:    controllerConfig.CrossModelRelayProxy(),
 	}
 
 	for i, result := range offers.Results {
@@ -583,3 +631,149 @@ func destroyOffers(api *OffersAPI, offerURLs []string, force bool) (params.Error
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// RevokeOfferConnections removes, or suspends, the specified connections to
+// offers. The consuming models see the relation removed or suspended via their
+// remote relations workers.
+func (api *OffersAPIV3) RevokeOfferConnections(args params.RevokeOfferConnectionsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if len(args.Args) == 0 {
+		return result, nil
+	}
+
+	isControllerAdmin, err := api.Authorizer.HasPermission(permission.SuperuserAccess, api.ControllerModel.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			result.Results[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		err := api.revokeOneOfferConnection(models[i].model.UUID(), isControllerAdmin, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *OffersAPI) revokeOneOfferConnection(modelUUID string, isControllerAdmin bool, arg params.RevokeOfferConnectionArg) error {
+	backend, releaser, err := api.StatePool.Get(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	url, err := jujucrossmodel.ParseOfferURL(arg.OfferURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.checkOfferAdmin(backend, isControllerAdmin, url.ApplicationName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.NewBlockChecker(backend).ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	offer, err := backend.ApplicationOffer(url.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conns, err := backend.OfferConnections(offer.OfferUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var conn OfferConnection
+	for _, oc := range conns {
+		if oc.RelationId() == arg.RelationId {
+			conn = oc
+			break
+		}
+	}
+	if conn == nil {
+		return errors.NotFoundf("connection %d to offer %q", arg.RelationId, arg.OfferURL)
+	}
+	rel, err := backend.KeyRelation(conn.RelationKey())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := api.auditRevokeOfferConnection(arg, conn); err != nil {
+		return errors.Trace(err)
+	}
+	if !arg.Suspend {
+		if err := rel.Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+		// As when the consuming side removes the relation, also remove
+		// the proxy for the consuming application.
+		for _, ep := range rel.Endpoints() {
+			remoteApp, err := backend.RemoteApplication(ep.ApplicationName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if remoteApp.IsConsumerProxy() {
+				if err := remoteApp.Destroy(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		return nil
+	}
+
+	if rel.Suspended() {
+		return nil
+	}
+	reason := arg.Reason
+	if reason == "" {
+		reason = "suspended by offerer"
+	}
+	if err := rel.SetSuspended(true, reason); err != nil {
+		return errors.Trace(err)
+	}
+	if err := rel.SetStatus(status.StatusInfo{
+		Status:  status.Suspending,
+		Message: reason,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// auditRevokeOfferConnection records the revocation of the connection
+// in the audit log, along with the consumer whose connection it was.
+// The audit log is only written if it is enabled.
+func (api *OffersAPI) auditRevokeOfferConnection(arg params.RevokeOfferConnectionArg, conn OfferConnection) error {
+	if api.auditRecorder == nil {
+		return nil
+	}
+	details, err := json.Marshal(map[string]interface{}{
+		"offer-url":         arg.OfferURL,
+		"relation-id":       arg.RelationId,
+		"suspend":           arg.Suspend,
+		"reason":            arg.Reason,
+		"source-model-uuid": conn.SourceModelUUID(),
+		"consumer":          conn.UserName(),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.auditRecorder.AddRequest(auditlog.RequestArgs{
+		Facade:  "ApplicationOffers",
+		Method:  "RevokeOfferConnection",
+		Version: 3,
+		Args:    string(details),
+	}))
+}
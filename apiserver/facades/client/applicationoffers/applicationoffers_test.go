@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
@@ -68,10 +69,12 @@ func (s *applicationOffersSuite) assertOffer(c *gc.C, expectedErr error) {
 		OfferName:       "offer-test",
 		ApplicationName: applicationName,
 		Endpoints:       map[string]string{"db": "db"},
+		MaxConnections:  5,
 	}
 	all := params.AddApplicationOffers{Offers: []params.AddApplicationOffer{one}}
 	s.applicationOffers.addOffer = func(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error) {
 		c.Assert(offer.OfferName, gc.Equals, one.OfferName)
+		c.Assert(offer.MaxConnections, gc.Equals, 5)
 		c.Assert(offer.ApplicationName, gc.Equals, one.ApplicationName)
 		c.Assert(offer.ApplicationDescription, gc.Equals, "A pretty popular blog engine")
 		c.Assert(offer.Owner, gc.Equals, "admin")
@@ -1050,7 +1053,8 @@ func (s *applicationOffersSuite) TestFindMissingModelInMultipleFilters(c *gc.C)
 
 type consumeSuite struct {
 	baseSuite
-	api *applicationoffers.OffersAPIV2
+	api           *applicationoffers.OffersAPIV3
+	auditRecorder *mockAuditRecorder
 }
 
 var _ = gc.Suite(&consumeSuite{})
@@ -1064,6 +1068,8 @@ func (s *consumeSuite) SetUpTest(c *gc.C) {
 
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
+	s.auditRecorder = &mockAuditRecorder{}
+	resources.RegisterNamed("auditRecorder", common.ValueResource{Value: s.auditRecorder})
 
 	getEnviron := func(modelUUID string) (environs.Environ, error) {
 		return s.env, nil
@@ -1077,7 +1083,9 @@ func (s *consumeSuite) SetUpTest(c *gc.C) {
 		context.NewCloudCallContext(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &applicationoffers.OffersAPIV2{OffersAPI: apiV1}
+	s.api = &applicationoffers.OffersAPIV3{
+		OffersAPIV2: &applicationoffers.OffersAPIV2{OffersAPI: apiV1},
+	}
 }
 
 func (s *consumeSuite) TestConsumeDetailsRejectsEndpoints(c *gc.C) {
@@ -1159,6 +1167,31 @@ func (s *consumeSuite) TestConsumeDetailsWithPermission(c *gc.C) {
 	c.Check(cav[3].Condition, gc.Equals, "declared username someone")
 }
 
+func (s *consumeSuite) TestConsumeDetailsRelayProxy(c *gc.C) {
+	s.setupOffer()
+	s.mockState.relayProxy = "http://relay.example.com:3128"
+	st := s.mockStatePool.st[testing.ModelTag.Id()]
+	st.(*mockState).users["someone"] = &mockUser{"someone"}
+	apiUser := names.NewUserTag("someone")
+	offer := names.NewApplicationOfferTag("hosted-mysql")
+	err := st.CreateOfferAccess(offer, apiUser, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer.Tag = apiUser
+	results, err := s.api.GetConsumeDetails(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].ControllerInfo, jc.DeepEquals, &params.ExternalControllerInfo{
+		ControllerTag: testing.ControllerTag.String(),
+		Addrs:         []string{"192.168.1.1:17070"},
+		CACert:        testing.CACert,
+		RelayProxy:    "http://relay.example.com:3128",
+	})
+}
+
 func (s *consumeSuite) TestConsumeDetailsDefaultEndpoint(c *gc.C) {
 	s.setupOffer()
 
@@ -1395,3 +1428,128 @@ func (s *consumeSuite) TestDestroyOffersPermission(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 1)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
 }
+
+func (s *consumeSuite) setupOfferConnection() *mockRelation {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	rel := &mockRelation{
+		id: 1,
+		endpoints: []state.Endpoint{
+			{ApplicationName: "mysql"},
+			{ApplicationName: "wordpress"},
+		},
+	}
+	st.relations["hosted-mysql:server wordpress:db"] = rel
+	st.remoteApps = map[string]crossmodel.RemoteApplication{
+		"wordpress": &mockRemoteApplication{consumerProxy: true},
+	}
+	st.connections = []applicationoffers.OfferConnection{
+		&mockOfferConnection{
+			username:    "fred",
+			modelUUID:   testing.ModelTag.Id(),
+			relationKey: "hosted-mysql:server wordpress:db",
+			relationId:  1,
+		},
+	}
+	return rel
+}
+
+func (s *consumeSuite) TestRevokeOfferConnections(c *gc.C) {
+	rel := s.setupOfferConnection()
+
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.RevokeOfferConnections(params.RevokeOfferConnectionsArgs{
+		Args: []params.RevokeOfferConnectionArg{
+			{OfferURL: "fred/prod.hosted-mysql", RelationId: 1},
+			{OfferURL: "fred/prod.hosted-mysql", RelationId: 2},
+			{OfferURL: "fred/prod.unknown", RelationId: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{
+			Error: &params.Error{Message: `connection 2 to offer "fred/prod.hosted-mysql" not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: `application offer "unknown" not found`, Code: "not found"},
+		},
+	})
+	c.Assert(rel.destroyed, jc.IsTrue)
+	c.Assert(rel.suspended, jc.IsFalse)
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	c.Assert(st.remoteApps["wordpress"].(*mockRemoteApplication).destroyed, jc.IsTrue)
+	c.Assert(s.auditRecorder.requests, gc.HasLen, 1)
+	req := s.auditRecorder.requests[0]
+	c.Assert(req.Facade, gc.Equals, "ApplicationOffers")
+	c.Assert(req.Method, gc.Equals, "RevokeOfferConnection")
+	c.Assert(req.Args, jc.JSONEquals, map[string]interface{}{
+		"offer-url":         "fred/prod.hosted-mysql",
+		"relation-id":       1,
+		"suspend":           false,
+		"reason":            "",
+		"source-model-uuid": testing.ModelTag.Id(),
+		"consumer":          "fred",
+	})
+}
+
+func (s *consumeSuite) TestRevokeOfferConnectionsSuspend(c *gc.C) {
+	rel := s.setupOfferConnection()
+
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.RevokeOfferConnections(params.RevokeOfferConnectionsArgs{
+		Args: []params.RevokeOfferConnectionArg{
+			{OfferURL: "fred/prod.hosted-mysql", RelationId: 1, Suspend: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(rel.destroyed, jc.IsFalse)
+	c.Assert(rel.suspended, jc.IsTrue)
+	c.Assert(rel.suspendedReason, gc.Equals, "suspended by offerer")
+	c.Assert(rel.status.Status, gc.Equals, status.Suspending)
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	c.Assert(st.remoteApps["wordpress"].(*mockRemoteApplication).destroyed, jc.IsFalse)
+	c.Assert(s.auditRecorder.requests, gc.HasLen, 1)
+	c.Assert(s.auditRecorder.requests[0].Args, jc.JSONEquals, map[string]interface{}{
+		"offer-url":         "fred/prod.hosted-mysql",
+		"relation-id":       1,
+		"suspend":           true,
+		"reason":            "",
+		"source-model-uuid": testing.ModelTag.Id(),
+		"consumer":          "fred",
+	})
+}
+
+func (s *consumeSuite) TestRevokeOfferConnectionsBlocked(c *gc.C) {
+	rel := s.setupOfferConnection()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	st.blocks = map[state.BlockType]string{state.ChangeBlock: "TestRevokeOfferConnectionsBlocked"}
+
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.RevokeOfferConnections(params.RevokeOfferConnectionsArgs{
+		Args: []params.RevokeOfferConnectionArg{
+			{OfferURL: "fred/prod.hosted-mysql", RelationId: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "TestRevokeOfferConnectionsBlocked")
+	c.Assert(params.IsCodeOperationBlocked(results.Results[0].Error), jc.IsTrue)
+	c.Assert(rel.destroyed, jc.IsFalse)
+	c.Assert(s.auditRecorder.requests, gc.HasLen, 0)
+}
+
+func (s *consumeSuite) TestRevokeOfferConnectionsPermission(c *gc.C) {
+	rel := s.setupOfferConnection()
+
+	s.authorizer.Tag = names.NewUserTag("mary")
+	results, err := s.api.RevokeOfferConnections(params.RevokeOfferConnectionsArgs{
+		Args: []params.RevokeOfferConnectionArg{
+			{OfferURL: "fred/prod.hosted-mysql", RelationId: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+	c.Assert(rel.destroyed, jc.IsFalse)
+}
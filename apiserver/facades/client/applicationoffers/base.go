@@ -170,6 +170,7 @@ func (api *BaseAPI) applicationOffersFromModel(
 		}
 		// Only admins can see some sensitive details of the offer.
 		if isAdmin {
+			offer.MaxConnections = appOffer.MaxConnections
 			if err := api.getOfferAdminDetails(backend, app, &offer); err != nil {
 				logger.Warningf("cannot get offer admin details: %v", err)
 			}
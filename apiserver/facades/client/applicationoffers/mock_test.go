@@ -18,6 +18,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/network"
 	corenetwork "github.com/juju/juju/core/network"
@@ -211,8 +213,37 @@ func (m *mockSubnet) AvailabilityZones() []string {
 
 type mockRelation struct {
 	crossmodel.Relation
-	id       int
-	endpoint state.Endpoint
+	id              int
+	endpoint        state.Endpoint
+	destroyed       bool
+	suspended       bool
+	suspendedReason string
+	status          status.StatusInfo
+	endpoints       []state.Endpoint
+}
+
+func (m *mockRelation) Endpoints() []state.Endpoint {
+	return m.endpoints
+}
+
+func (m *mockRelation) Destroy() error {
+	m.destroyed = true
+	return nil
+}
+
+func (m *mockRelation) Suspended() bool {
+	return m.suspended
+}
+
+func (m *mockRelation) SetSuspended(suspended bool, reason string) error {
+	m.suspended = suspended
+	m.suspendedReason = reason
+	return nil
+}
+
+func (m *mockRelation) SetStatus(info status.StatusInfo) error {
+	m.status = info
+	return nil
 }
 
 func (m *mockRelation) Status() (status.StatusInfo, error) {
@@ -295,6 +326,35 @@ type mockState struct {
 	connections       []applicationoffers.OfferConnection
 	accessPerms       map[offerAccess]permission.Access
 	relationNetworks  state.RelationNetworks
+	remoteApps        map[string]crossmodel.RemoteApplication
+	relayProxy        string
+	blocks            map[state.BlockType]string
+}
+
+type mockBlock struct {
+	state.Block
+	message string
+}
+
+func (b mockBlock) Message() string {
+	return b.message
+}
+
+func (m *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	msg, ok := m.blocks[t]
+	if !ok {
+		return nil, false, nil
+	}
+	return mockBlock{message: msg}, true, nil
+}
+
+type mockAuditRecorder struct {
+	requests []auditlog.RequestArgs
+}
+
+func (r *mockAuditRecorder) AddRequest(req auditlog.RequestArgs) error {
+	r.requests = append(r.requests, req)
+	return nil
 }
 
 func (m *mockState) GetAddressAndCertGetter() common.AddressAndCertGetter {
@@ -305,6 +365,14 @@ func (m *mockState) ControllerTag() names.ControllerTag {
 	return testing.ControllerTag
 }
 
+func (m *mockState) ControllerConfig() (controller.Config, error) {
+	cfg := controller.Config{}
+	if m.relayProxy != "" {
+		cfg[controller.CrossModelRelayProxy] = m.relayProxy
+	}
+	return cfg, nil
+}
+
 func (m *mockState) Charm(*charm.URL) (crossmodel.Charm, error) {
 	return &mockCharm{}, nil
 }
@@ -365,6 +433,29 @@ func (m *mockState) KeyRelation(key string) (crossmodel.Relation, error) {
 	return rel, nil
 }
 
+func (m *mockState) RemoteApplication(name string) (crossmodel.RemoteApplication, error) {
+	app, ok := m.remoteApps[name]
+	if !ok {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	return app, nil
+}
+
+type mockRemoteApplication struct {
+	crossmodel.RemoteApplication
+	consumerProxy bool
+	destroyed     bool
+}
+
+func (m *mockRemoteApplication) IsConsumerProxy() bool {
+	return m.consumerProxy
+}
+
+func (m *mockRemoteApplication) Destroy() error {
+	m.destroyed = true
+	return nil
+}
+
 func (m *mockState) OfferConnections(offerUUID string) ([]applicationoffers.OfferConnection, error) {
 	return m.connections, nil
 }
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/permission"
@@ -57,6 +58,7 @@ type Backend interface {
 	commoncrossmodel.Backend
 	Charm(*charm.URL) (commoncrossmodel.Charm, error)
 	ApplicationOffer(name string) (*crossmodel.ApplicationOffer, error)
+	ControllerConfig() (controller.Config, error)
 	Model() (Model, error)
 	OfferConnections(string) ([]OfferConnection, error)
 	Space(string) (Space, error)
@@ -66,6 +68,7 @@ type Backend interface {
 	UpdateOfferAccess(offer names.ApplicationOfferTag, user names.UserTag, access permission.Access) error
 	RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error
 	GetOfferUsers(offerUUID string) (map[string]permission.Access, error)
+	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
 
 var GetStateAccess = func(st *state.State) Backend {
@@ -80,6 +83,10 @@ type stateShim struct {
 	st *state.State
 }
 
+func (s stateShim) ControllerConfig() (controller.Config, error) {
+	return s.st.ControllerConfig()
+}
+
 func (s stateShim) CreateOfferAccess(offer names.ApplicationOfferTag, user names.UserTag, access permission.Access) error {
 	return s.st.CreateOfferAccess(offer, user, access)
 }
@@ -96,6 +103,10 @@ func (s stateShim) GetOfferUsers(offerUUID string) (map[string]permission.Access
 	return s.st.GetOfferUsers(offerUUID)
 }
 
+func (s stateShim) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	return s.st.GetBlockForType(t)
+}
+
 func (s *stateShim) Space(name string) (Space, error) {
 	sp, err := s.st.Space(name)
 	return &spaceShim{sp}, err
@@ -7,6 +7,7 @@ package bundle
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -56,6 +57,14 @@ type APIv4 struct {
 	*BundleAPI
 }
 
+// APIv5 provides the Bundle API facade for version 5. It is otherwise
+// identical to V4 with the exception that the V5 ExportBundle accepts
+// arguments, allowing environment specific values to be split out into
+// the overlay.
+type APIv5 struct {
+	*BundleAPI
+}
+
 // BundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type BundleAPI struct {
@@ -105,6 +114,16 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -351,7 +370,24 @@ func getChangesMapArgs(
 }
 
 // ExportBundle exports the current model configuration as bundle.
-func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
+func (b *APIv2) ExportBundle() (params.StringResult, error) {
+	return b.BundleAPI.ExportBundle(params.ExportBundleParams{})
+}
+
+// ExportBundle exports the current model configuration as bundle.
+func (b *APIv3) ExportBundle() (params.StringResult, error) {
+	return b.BundleAPI.ExportBundle(params.ExportBundleParams{})
+}
+
+// ExportBundle exports the current model configuration as bundle.
+func (b *APIv4) ExportBundle() (params.StringResult, error) {
+	return b.BundleAPI.ExportBundle(params.ExportBundleParams{})
+}
+
+// ExportBundle exports the current model configuration as bundle. When
+// requested, environment specific values are moved from the base bundle
+// into the overlay so that the base bundle can be deployed to other models.
+func (b *BundleAPI) ExportBundle(arg params.ExportBundleParams) (params.StringResult, error) {
 	fail := func(failErr error) (params.StringResult, error) {
 		return params.StringResult{}, common.ServerError(failErr)
 	}
@@ -378,6 +414,9 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	if err != nil {
 		return fail(err)
 	}
+	if arg.SplitOverlay {
+		splitEnvironmentSpecific(base, overlay)
+	}
 
 	var buf bytes.Buffer
 	enc := yaml.NewEncoder(&buf)
@@ -402,6 +441,31 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	return params.StringResult{Result: yamlOut}, nil
 }
 
+// splitEnvironmentSpecific moves the machines, placement directives and
+// trust settings from the base bundle into the overlay.
+func splitEnvironmentSpecific(base, overlay *charm.BundleData) {
+	if len(base.Machines) != 0 {
+		overlay.Machines = base.Machines
+		base.Machines = nil
+	}
+	for name, app := range base.Applications {
+		if len(app.To) == 0 && app.Placement_ == "" && !app.RequiresTrust {
+			continue
+		}
+		if overlay.Applications == nil {
+			overlay.Applications = make(map[string]*charm.ApplicationSpec)
+		}
+		overlayApp, ok := overlay.Applications[name]
+		if !ok {
+			overlayApp = &charm.ApplicationSpec{}
+			overlay.Applications[name] = overlayApp
+		}
+		overlayApp.To, app.To = app.To, nil
+		overlayApp.Placement_, app.Placement_ = app.Placement_, ""
+		overlayApp.RequiresTrust, app.RequiresTrust = app.RequiresTrust, false
+	}
+}
+
 // bundleOutput has the same top level keys as the charm.BundleData
 // but in a more user oriented output order, with the description first,
 // then the distro series, then the apps, machines and releations.
@@ -461,10 +525,15 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		var newApplication *charm.ApplicationSpec
 		appSeries := application.Series()
 		usedSeries.Add(appSeries)
-		// Only care about endpoints with non-empty bindings.
+		// Only care about endpoints with non-empty bindings, unless the
+		// application default binding is a non-default space. In that case
+		// endpoints bound to the default space must be exported explicitly,
+		// otherwise they would follow the application default on redeploy.
+		appBindings := application.EndpointBindings()
+		explicitDefault := appBindings[""] != ""
 		bindings := make(map[string]string)
-		for ep, space := range application.EndpointBindings() {
-			if space != "" {
+		for ep, space := range appBindings {
+			if space != "" || explicitDefault {
 				bindings[ep] = space
 			}
 		}
@@ -524,6 +593,15 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 			}
 		}
 
+		newApplication.Storage = b.storage(application.StorageConstraints())
+		if isCaas {
+			appDevices, err := b.devices(application.Name())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			newApplication.Devices = appDevices
+		}
+
 		// If this application has been trusted by the operator, set the
 		// Trust field of the ApplicationSpec to true
 		if appConfig := application.ApplicationConfig(); appConfig != nil {
@@ -564,7 +642,14 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		data.Machines[machine.Id()] = newMachine
 	}
 
+	// Consumer proxies represent the consumers of this model's offers
+	// and are recreated when those consumers relate to the offers again.
+	consumerProxies := set.NewStrings()
 	for _, application := range model.RemoteApplications() {
+		if application.IsConsumerProxy() {
+			consumerProxies.Add(application.Name())
+			continue
+		}
 		newSaas := &charm.SaasSpec{
 			URL: application.URL(),
 		}
@@ -604,6 +689,10 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 			if endpoint.Role() == "peer" {
 				continue
 			}
+			if consumerProxies.Contains(endpoint.ApplicationName()) {
+				endpointRelation = nil
+				break
+			}
 			endpointRelation = append(endpointRelation, endpoint.ApplicationName()+":"+endpoint.Name())
 		}
 		if len(endpointRelation) != 0 {
@@ -621,6 +710,55 @@ func (b *BundleAPI) filterOfferACL(in map[string]string) map[string]string {
 	return in
 }
 
+// storage returns the bundle storage directives for the given application
+// storage constraints.
+func (b *BundleAPI) storage(cons map[string]description.StorageConstraint) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(cons))
+	for name, constraint := range cons {
+		var directive []string
+		if pool := constraint.Pool(); pool != "" {
+			directive = append(directive, pool)
+		}
+		directive = append(directive,
+			strconv.FormatUint(constraint.Count(), 10),
+			strconv.FormatUint(constraint.Size(), 10)+"M",
+		)
+		result[name] = strings.Join(directive, ",")
+	}
+	return result
+}
+
+// devices returns the bundle device constraints for the named application.
+func (b *BundleAPI) devices(appName string) (map[string]string, error) {
+	cons, err := b.backend.DeviceConstraints(appName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "device constraints for %q", appName)
+	}
+	if len(cons) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]string, len(cons))
+	for name, constraint := range cons {
+		directive := []string{
+			strconv.FormatInt(constraint.Count, 10),
+			string(constraint.Type),
+		}
+		if len(constraint.Attributes) != 0 {
+			var attrs []string
+			for k, v := range constraint.Attributes {
+				attrs = append(attrs, k+"="+v)
+			}
+			sort.Strings(attrs)
+			directive = append(directive, strings.Join(attrs, ";"))
+		}
+		result[name] = strings.Join(directive, ",")
+	}
+	return result, nil
+}
+
 func (b *BundleAPI) constraints(cons description.Constraints) []string {
 	if cons == nil {
 		return []string{}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -1563,3 +1564,159 @@ relations:
 	c.Assert(result, gc.Equals, expectedResult)
 	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
 }
+
+func (s *bundleSuite) TestExportBundleWithStorageAndDefaultBindings(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+
+	appArgs := s.minimalApplicationArgs(description.IAAS)
+	appArgs.EndpointBindings = map[string]string{"": "vlan2", "juju-info": "", "another": "vlan3"}
+	appArgs.StorageConstraints = map[string]description.StorageConstraintArgs{
+		"data": {Pool: "ebs", Size: 1024, Count: 2},
+	}
+	app := s.st.model.AddApplication(appArgs)
+	app.SetStatus(minimalStatusArgs())
+	u := app.AddUnit(minimalUnitArgs(app.Type()))
+	u.SetAgentStatus(minimalStatusArgs())
+
+	s.st.model.SetStatus(description.StatusArgs{Value: "available"})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	expectedResult := params.StringResult{nil, `
+series: trusty
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu
+    num_units: 1
+    to:
+    - "0"
+    options:
+      key: value
+    storage:
+      data: ebs,2,1024M
+    bindings:
+      "": vlan2
+      another: vlan3
+      juju-info: ""
+`[1:]}
+
+	c.Assert(result, gc.Equals, expectedResult)
+}
+
+func (s *bundleSuite) TestExportBundleSkipsConsumerProxies(c *gc.C) {
+	model := s.newModel("iaas", "wordpress", "mysql")
+	remoteApp := model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:             names.NewApplicationTag("remote-1234"),
+		IsConsumerProxy: true,
+	})
+	remoteApp.SetStatus(minimalStatusArgs())
+	rel := model.AddRelation(description.RelationArgs{
+		Id:  43,
+		Key: "remote key",
+	})
+	rel.SetStatus(minimalStatusArgs())
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "db",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "remote-1234",
+		Name:            "db",
+	})
+	model.SetStatus(description.StatusArgs{Value: "available"})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Not(jc.Contains), "remote-1234")
+	c.Assert(result.Result, gc.Not(jc.Contains), "saas")
+}
+
+func (s *bundleSuite) TestExportBundleSplitOverlay(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+
+	appArgs := s.minimalApplicationArgs(description.IAAS)
+	appArgs.ApplicationConfig = map[string]interface{}{
+		appFacade.TrustConfigOptionName: true,
+	}
+	app := s.st.model.AddApplication(appArgs)
+	app.SetStatus(minimalStatusArgs())
+	u := app.AddUnit(minimalUnitArgs(app.Type()))
+	u.SetAgentStatus(minimalStatusArgs())
+	s.st.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "trusty",
+	})
+
+	s.st.model.SetStatus(description.StatusArgs{Value: "available"})
+
+	api, err := bundle.NewBundleAPI(s.st, s.auth, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	facade := &bundle.APIv5{api}
+	result, err := facade.ExportBundle(params.ExportBundleParams{SplitOverlay: true})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedResult := params.StringResult{nil, `
+series: trusty
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu
+    num_units: 1
+    options:
+      key: value
+    bindings:
+      juju-info: vlan2
+--- # overlay.yaml
+applications:
+  ubuntu:
+    to:
+    - "0"
+    trust: true
+machines:
+  "0": {}
+`[1:]}
+
+	c.Assert(result, gc.Equals, expectedResult)
+}
+
+func (s *bundleSuite) TestExportKubernetesBundleWithDevices(c *gc.C) {
+	model := s.newModel("caas", "wordpress", "mysql")
+	model.SetStatus(description.StatusArgs{Value: "available"})
+	s.st.devices = map[string]map[string]state.DeviceConstraints{
+		"mysql": {
+			"bitcoinminer": {
+				Type:       "nvidia.com/gpu",
+				Count:      2,
+				Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
+			},
+		},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := `
+bundle: kubernetes
+applications:
+  mysql:
+    charm: cs:mysql
+    scale: 1
+    devices:
+      bitcoinminer: 2,nvidia.com/gpu,gpu=nvidia-tesla-p100
+  wordpress:
+    charm: cs:wordpress
+    scale: 2
+relations:
+- - wordpress:db
+  - mysql:mysql
+`[1:]
+	c.Assert(result, gc.Equals, params.StringResult{nil, output})
+}
@@ -14,7 +14,8 @@ import (
 type mockState struct {
 	testing.Stub
 	bundle.Backend
-	model description.Model
+	model   description.Model
+	devices map[string]map[string]state.DeviceConstraints
 }
 
 func (m *mockState) ExportPartial(config state.ExportConfig) (description.Model, error) {
@@ -38,6 +39,14 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) DeviceConstraints(appName string) (map[string]state.DeviceConstraints, error) {
+	m.MethodCall(m, "DeviceConstraints", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.devices[appName], nil
+}
+
 func newMockState() *mockState {
 	st := &mockState{
 		Stub: testing.Stub{},
@@ -1358,6 +1358,13 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	if leader := context.leaders[unit.ApplicationName()]; leader == unit.Name() {
 		result.Leader = true
 	}
+	if pause, paused := unit.Paused(); paused {
+		result.Paused = &params.UnitPauseStatus{
+			By:     pause.By,
+			Reason: pause.Reason,
+			Since:  &pause.Since,
+		}
+	}
 	containerInfo, err := unit.ContainerInfo()
 	if err != nil && !errors.IsNotFound(err) {
 		logger.Debugf("error fetching container info: %v", err)
@@ -352,6 +352,29 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestPausedUnit(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	paused, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = paused.Pause("admin", "kernel upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := status.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+
+	pausedStatus := appStatus.Units[paused.Name()].Paused
+	c.Assert(pausedStatus, gc.NotNil)
+	c.Check(pausedStatus.By, gc.Equals, "admin")
+	c.Check(pausedStatus.Reason, gc.Equals, "kernel upgrade")
+	c.Check(pausedStatus.Since, gc.NotNil)
+	c.Check(appStatus.Units[running.Name()].Paused, gc.IsNil)
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 	setGenerationsControllerConfig(c, s.State)
 	// Create a host model because controller models can't be migrated.
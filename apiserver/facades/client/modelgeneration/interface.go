@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 )
 
@@ -44,6 +45,9 @@ type Generation interface {
 	Commit(string) (int, error)
 	Abort(string) error
 	Config() map[string]settings.ItemChanges
+	PromotionPolicy() (model.PromotionPolicy, bool)
+	SetPromotionPolicy(model.PromotionPolicy) error
+	ClearPromotionPolicy() error
 }
 
 // Application describes application state used by the model generation API.
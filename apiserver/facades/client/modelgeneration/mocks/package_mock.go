@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	cache "github.com/juju/juju/core/cache"
+	model "github.com/juju/juju/core/model"
 	settings "github.com/juju/juju/core/settings"
 	charm_v6 "gopkg.in/juju/charm.v6"
 	names_v3 "gopkg.in/juju/names.v3"
@@ -231,6 +232,18 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// ClearPromotionPolicy mocks base method
+func (m *MockGeneration) ClearPromotionPolicy() error {
+	ret := m.ctrl.Call(m, "ClearPromotionPolicy")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearPromotionPolicy indicates an expected call of ClearPromotionPolicy
+func (mr *MockGenerationMockRecorder) ClearPromotionPolicy() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPromotionPolicy", reflect.TypeOf((*MockGeneration)(nil).ClearPromotionPolicy))
+}
+
 // Commit mocks base method
 func (m *MockGeneration) Commit(arg0 string) (int, error) {
 	ret := m.ctrl.Call(m, "Commit", arg0)
@@ -280,6 +293,31 @@ func (mr *MockGenerationMockRecorder) CreatedBy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedBy", reflect.TypeOf((*MockGeneration)(nil).CreatedBy))
 }
 
+// PromotionPolicy mocks base method
+func (m *MockGeneration) PromotionPolicy() (model.PromotionPolicy, bool) {
+	ret := m.ctrl.Call(m, "PromotionPolicy")
+	ret0, _ := ret[0].(model.PromotionPolicy)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// PromotionPolicy indicates an expected call of PromotionPolicy
+func (mr *MockGenerationMockRecorder) PromotionPolicy() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromotionPolicy", reflect.TypeOf((*MockGeneration)(nil).PromotionPolicy))
+}
+
+// SetPromotionPolicy mocks base method
+func (m *MockGeneration) SetPromotionPolicy(arg0 model.PromotionPolicy) error {
+	ret := m.ctrl.Call(m, "SetPromotionPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPromotionPolicy indicates an expected call of SetPromotionPolicy
+func (mr *MockGenerationMockRecorder) SetPromotionPolicy(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPromotionPolicy", reflect.TypeOf((*MockGeneration)(nil).SetPromotionPolicy), arg0)
+}
+
 // MockApplication is a mock of Application interface
 type MockApplication struct {
 	ctrl     *gomock.Controller
@@ -31,12 +31,16 @@ type API struct {
 	modelCache        ModelCache
 }
 
-type APIV1 struct {
+type APIV2 struct {
 	*API
 }
 
-// NewModelGenerationFacadeV2 provides the signature required for facade registration.
-func NewModelGenerationFacadeV2(ctx facade.Context) (*API, error) {
+type APIV1 struct {
+	*APIV2
+}
+
+// NewModelGenerationFacadeV3 provides the signature required for facade registration.
+func NewModelGenerationFacadeV3(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
@@ -50,6 +54,15 @@ func NewModelGenerationFacadeV2(ctx facade.Context) (*API, error) {
 	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc})
 }
 
+// NewModelGenerationFacadeV2 provides the signature required for facade registration.
+func NewModelGenerationFacadeV2(ctx facade.Context) (*APIV2, error) {
+	v3, err := NewModelGenerationFacadeV3(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV2{v3}, nil
+}
+
 // NewModelGenerationFacade provides the signature required for facade registration.
 func NewModelGenerationFacade(ctx facade.Context) (*APIV1, error) {
 	v2, err := NewModelGenerationFacadeV2(ctx)
@@ -206,6 +219,40 @@ func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	return result, nil
 }
 
+// SetBranchPromotionPolicy attaches the input promotion policy to the input
+// branch, so that it is committed or aborted automatically based on the
+// health of its tracking units. If no policy is supplied, any existing policy
+// is removed from the branch.
+func (api *API) SetBranchPromotionPolicy(arg params.BranchPromotionPolicyArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+
+	if arg.Policy == nil {
+		err = branch.ClearPromotionPolicy()
+	} else {
+		err = branch.SetPromotionPolicy(model.PromotionPolicy{
+			HealthyFor: arg.Policy.HealthyFor,
+			ActionName: arg.Policy.ActionName,
+			OnError:    model.BranchErrorPolicy(arg.Policy.OnError),
+		})
+	}
+	result.Error = common.ServerError(err)
+	return result, nil
+}
+
 // BranchInfo will return details of branch identified by the input argument,
 // including units on the branch and the configuration disjoint with the
 // master generation.
@@ -288,12 +335,20 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		apps = append(apps, branchApp)
 	}
 
-	return params.Generation{
+	gen := params.Generation{
 		BranchName:   branch.BranchName(),
 		Created:      branch.Created(),
 		CreatedBy:    branch.CreatedBy(),
 		Applications: apps,
-	}, nil
+	}
+	if policy, ok := branch.PromotionPolicy(); ok {
+		gen.PromotionPolicy = &params.BranchPromotionPolicy{
+			HealthyFor: policy.HealthyFor,
+			ActionName: policy.ActionName,
+			OnError:    string(policy.OnError),
+		}
+	}
+	return gen, nil
 }
 
 // HasActiveBranch returns a true result if the input model has an "in-flight"
@@ -320,6 +375,13 @@ func (api *API) HasActiveBranch(arg params.BranchArg) (params.BoolResult, error)
 	return result, nil
 }
 
+// Mask the new methods from the V2 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// SetBranchPromotionPolicy isn't on the V2 API.
+func (*APIV2) SetBranchPromotionPolicy(_, _ struct{}) {}
+
 func generationResultsError(err error) (params.GenerationResults, error) {
 	return params.GenerationResults{Error: common.ServerError(err)}, nil
 }
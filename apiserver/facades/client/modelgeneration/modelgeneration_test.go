@@ -4,6 +4,8 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/cache"
@@ -130,6 +132,54 @@ func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
 }
 
+func (s *modelGenerationSuite) TestSetBranchPromotionPolicySuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().SetPromotionPolicy(model.PromotionPolicy{
+		HealthyFor: 10 * time.Minute,
+		OnError:    model.BranchErrorAbort,
+	}).Return(nil)
+
+	result, err := s.api.SetBranchPromotionPolicy(params.BranchPromotionPolicyArg{
+		BranchName: s.newBranchName,
+		Policy: &params.BranchPromotionPolicy{
+			HealthyFor: 10 * time.Minute,
+			OnError:    "abort",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestSetBranchPromotionPolicyClear(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().ClearPromotionPolicy().Return(nil)
+
+	result, err := s.api.SetBranchPromotionPolicy(params.BranchPromotionPolicyArg{
+		BranchName: s.newBranchName,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestSetBranchPromotionPolicyError(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().SetPromotionPolicy(gomock.Any()).Return(errors.NotValidf("branch error policy \"boom\""))
+
+	result, err := s.api.SetBranchPromotionPolicy(params.BranchPromotionPolicyArg{
+		BranchName: s.newBranchName,
+		Policy: &params.BranchPromotionPolicy{
+			ActionName: "smoke-test",
+			OnError:    "boom",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, `branch error policy "boom" not valid`)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectHasActiveBranch(nil)
@@ -169,6 +219,7 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	s.expectAssignedUnits(units[:2])
 	s.expectCreated()
 	s.expectCreatedBy()
+	s.expectPromotionPolicy()
 
 	// Flex the code path based on whether we are getting all branches
 	// or a sub-set.
@@ -192,6 +243,10 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	c.Assert(gen.BranchName, gc.Equals, s.newBranchName)
 	c.Assert(gen.Created, gc.Equals, int64(666))
 	c.Assert(gen.CreatedBy, gc.Equals, s.apiUser)
+	c.Assert(gen.PromotionPolicy, gc.DeepEquals, &params.BranchPromotionPolicy{
+		ActionName: "smoke-test",
+		OnError:    "untrack",
+	})
 	c.Assert(gen.Applications, gc.HasLen, 1)
 
 	genApp := gen.Applications[0]
@@ -291,6 +346,13 @@ func (s *modelGenerationSuite) expectCreatedBy() {
 	s.mockGen.EXPECT().CreatedBy().Return(s.apiUser)
 }
 
+func (s *modelGenerationSuite) expectPromotionPolicy() {
+	s.mockGen.EXPECT().PromotionPolicy().Return(model.PromotionPolicy{
+		ActionName: "smoke-test",
+		OnError:    model.BranchErrorUntrack,
+	}, true)
+}
+
 func (s *modelGenerationSuite) expectConfig() {
 	s.mockGen.EXPECT().Config().Return(map[string]settings.ItemChanges{"redis": {
 		settings.MakeAddition("password", "added-pass"),
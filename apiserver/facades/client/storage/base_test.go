@@ -75,7 +75,13 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPIv7: storage.StorageAPIv7{
+						StorageAPIv8: storage.StorageAPIv8{
+							StorageAPI: *newAPI,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -112,6 +118,9 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	moveStorageInstanceCall                 = "moveStorageInstance"
+	cancelStorageMoveCall                   = "cancelStorageMove"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -320,6 +329,18 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
+		moveStorageInstance: func(tag names.StorageTag, pool string) error {
+			s.stub.AddCall(moveStorageInstanceCall, tag, pool)
+			return s.stub.NextErr()
+		},
+		cancelStorageMove: func(tag names.StorageTag) error {
+			s.stub.AddCall(cancelStorageMoveCall, tag)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -71,6 +71,9 @@ type mockStorageAccessor struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	moveStorageInstance                 func(names.StorageTag, string) error
+	cancelStorageMove                   func(names.StorageTag) error
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -181,6 +184,18 @@ func (st *mockStorageAccessor) ReleaseStorageInstance(tag names.StorageTag, dest
 	return st.releaseStorageInstance(tag, destroyAttached, force)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockStorageAccessor) MoveStorageInstance(tag names.StorageTag, pool string) error {
+	return st.moveStorageInstance(tag, pool)
+}
+
+func (st *mockStorageAccessor) CancelStorageMove(tag names.StorageTag) error {
+	return st.cancelStorageMove(tag)
+}
+
 func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -191,10 +206,11 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 
 type mockVolume struct {
 	state.Volume
-	tag     names.VolumeTag
-	storage *names.StorageTag
-	info    *state.VolumeInfo
-	life    state.Life
+	tag       names.VolumeTag
+	storage   *names.StorageTag
+	info      *state.VolumeInfo
+	life      state.Life
+	encrypted bool
 }
 
 func (m *mockVolume) StorageInstance() (names.StorageTag, error) {
@@ -226,6 +242,10 @@ func (m *mockVolume) Life() state.Life {
 	return m.life
 }
 
+func (m *mockVolume) Encrypted() bool {
+	return m.encrypted
+}
+
 func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Attached}, nil
 }
@@ -305,6 +325,7 @@ type mockStorageInstance struct {
 	owner      names.Tag
 	storageTag names.Tag
 	life       state.Life
+	move       *state.StorageMove
 }
 
 func (m *mockStorageInstance) Kind() state.StorageKind {
@@ -331,6 +352,13 @@ func (m *mockStorageInstance) Life() state.Life {
 	return m.life
 }
 
+func (m *mockStorageInstance) Move() (state.StorageMove, bool) {
+	if m.move == nil {
+		return state.StorageMove{}, false
+	}
+	return *m.move, true
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	storage *mockStorageInstance
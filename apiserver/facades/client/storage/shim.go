@@ -61,6 +61,18 @@ type storageInterface interface {
 
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool, bool, time.Duration) error
+
+	// ResizeStorageInstance requests that the storage instance with the
+	// specified tag be grown to the specified size, in MiB.
+	ResizeStorageInstance(names.StorageTag, uint64) error
+
+	// MoveStorageInstance starts moving the storage instance with the
+	// specified tag to the named storage pool.
+	MoveStorageInstance(names.StorageTag, string) error
+
+	// CancelStorageMove cancels the in-progress move of the storage
+	// instance with the specified tag.
+	CancelStorageMove(names.StorageTag) error
 }
 
 type storageVolume interface {
@@ -77,9 +89,6 @@ type storageVolume interface {
 	// MachineVolumeAttachments is required for volume functionality.
 	MachineVolumeAttachments(machine names.MachineTag) ([]state.VolumeAttachment, error)
 
-	// Volume is required for volume functionality.
-	Volume(tag names.VolumeTag) (state.Volume, error)
-
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
 }
@@ -29,7 +29,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v9) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -40,9 +40,24 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// StorageAPIv8 implements the storage v8 API.
+type StorageAPIv8 struct {
+	StorageAPI
+}
+
+// StorageAPIv7 implements the storage v7 API.
+type StorageAPIv7 struct {
+	StorageAPIv8
+}
+
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPIv7
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -103,14 +118,47 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV8 returns a new storage v8 API facade.
+func NewStorageAPIV8(context facade.Context) (*StorageAPIv8, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv8{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewStorageAPIV7 returns a new storage v7 API facade.
+func NewStorageAPIV7(context facade.Context) (*StorageAPIv7, error) {
+	storageAPI, err := NewStorageAPIV8(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv7{
+		StorageAPIv8: *storageAPI,
+	}, nil
+}
+
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPIV7(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPIv7: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -301,6 +349,16 @@ func createStorageDetails(
 			if machineTag.Id() != "" {
 				details.MachineTag = machineTag.String()
 			}
+			if location != "" {
+				used, free, err := storagecommon.StorageAttachmentUsage(
+					st, st.VolumeAccess(), st.FilesystemAccess(), a, machineTag,
+				)
+				if err == nil {
+					details.UsedSize, details.FreeSize = used, free
+				} else if !errors.IsNotFound(err) && !errors.IsNotImplemented(err) {
+					return nil, errors.Trace(err)
+				}
+			}
 			storageAttachmentDetails[a.Unit().String()] = details
 		}
 	}
@@ -310,6 +368,11 @@ func createStorageDetails(
 		ownerTag = owner.String()
 	}
 
+	var movingTo string
+	if move, ok := si.Move(); ok {
+		movingTo = move.Pool
+	}
+
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
 		OwnerTag:    ownerTag,
@@ -318,6 +381,7 @@ func createStorageDetails(
 		Status:      common.EntityStatusFromState(aStatus),
 		Persistent:  persistent,
 		Attachments: storageAttachmentDetails,
+		MovingTo:    movingTo,
 	}, nil
 }
 
@@ -624,6 +688,7 @@ func createVolumeDetails(
 	details := &params.VolumeDetails{
 		VolumeTag: v.VolumeTag().String(),
 		Life:      params.Life(v.Life().String()),
+		Encrypted: v.Encrypted(),
 	}
 
 	if info, err := v.Info(); err == nil {
@@ -869,7 +934,7 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 	}
 
 	paramsToState := func(p params.StorageConstraints) state.StorageConstraints {
-		s := state.StorageConstraints{Pool: p.Pool}
+		s := state.StorageConstraints{Pool: p.Pool, SnapshotId: p.SnapshotId}
 		if p.Size != nil {
 			s.Size = *p.Size
 		}
@@ -1174,6 +1239,265 @@ func (a *StorageAPI) importFilesystem(
 	}, nil
 }
 
+// CreateSnapshots takes a snapshot of the volume backing each of the
+// specified storage instances. A "CHANGE" block can block this operation.
+func (a *StorageAPI) CreateSnapshots(args params.CreateStorageSnapshotsArgs) (params.StorageSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.StorageSnapshotResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		snapshot, err := a.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshot
+	}
+	return params.StorageSnapshotResults{Results: results}, nil
+}
+
+func (a *StorageAPI) createSnapshot(arg params.CreateStorageSnapshotArg) (*params.StorageSnapshot, error) {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, volumeInfo, snapshotter, err := a.storageVolumeSnapshotter(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := snapshotter.CreateVolumeSnapshots(a.callContext, []storage.VolumeSnapshotParams{{
+		VolumeId:    volumeInfo.VolumeId,
+		Description: arg.Description,
+		ResourceTags: map[string]string{
+			tags.JujuModel:      a.backend.ModelTag().Id(),
+			tags.JujuController: a.backend.ControllerTag().Id(),
+		},
+	}})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating snapshot")
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotate(results[0].Error, "creating snapshot")
+	}
+	snapshot := storageSnapshot(*results[0].Snapshot, storageTag, volume.VolumeTag(), volumeInfo.Pool)
+	return &snapshot, nil
+}
+
+// ListSnapshots returns the snapshots of the volumes backing each of
+// the specified storage instances.
+func (a *StorageAPI) ListSnapshots(args params.Entities) (params.StorageSnapshotsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotsResults{}, errors.Trace(err)
+	}
+	results := make([]params.StorageSnapshotsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshots, err := a.listSnapshots(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshots
+	}
+	return params.StorageSnapshotsResults{Results: results}, nil
+}
+
+func (a *StorageAPI) listSnapshots(tag string) ([]params.StorageSnapshot, error) {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, volumeInfo, snapshotter, err := a.storageVolumeSnapshotter(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots, err := snapshotter.ListVolumeSnapshots(a.callContext, []string{volumeInfo.VolumeId})
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	result := make([]params.StorageSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		result[i] = storageSnapshot(snapshot, storageTag, volume.VolumeTag(), volumeInfo.Pool)
+	}
+	return result, nil
+}
+
+// storageVolumeSnapshotter returns the provisioned volume backing the
+// specified storage instance, along with the storage.VolumeSnapshotter
+// for the volume's storage provider.
+func (a *StorageAPI) storageVolumeSnapshotter(
+	storageTag names.StorageTag,
+) (state.Volume, state.VolumeInfo, storage.VolumeSnapshotter, error) {
+	volume, volumeInfo, providerType, volumeSource, err := a.storageVolumeSource(storageTag)
+	if err != nil {
+		return nil, state.VolumeInfo{}, nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, state.VolumeInfo{}, nil, errors.NotSupportedf(
+			"volume snapshots with storage provider %q", providerType,
+		)
+	}
+	return volume, volumeInfo, snapshotter, nil
+}
+
+// storageVolumeSource returns the provisioned volume backing the
+// specified storage instance, along with the type of the volume's
+// storage provider and its storage.VolumeSource.
+func (a *StorageAPI) storageVolumeSource(
+	storageTag names.StorageTag,
+) (state.Volume, state.VolumeInfo, storage.ProviderType, storage.VolumeSource, error) {
+	volume, err := a.storageAccess.VolumeAccess().StorageInstanceVolume(storageTag)
+	if err != nil {
+		return nil, state.VolumeInfo{}, "", nil, errors.Trace(err)
+	}
+	volumeInfo, err := volume.Info()
+	if err != nil {
+		return nil, state.VolumeInfo{}, "", nil, errors.Trace(err)
+	}
+	providerType, cfg, err := storagecommon.StoragePoolConfig(volumeInfo.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, state.VolumeInfo{}, "", nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, state.VolumeInfo{}, "", nil, errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, state.VolumeInfo{}, "", nil, errors.Trace(err)
+	}
+	return volume, volumeInfo, providerType, volumeSource, nil
+}
+
+// Resize requests that the storage instances be grown to the specified
+// sizes. The resize is carried out asynchronously by the storage
+// provisioner; a "CHANGE" block can block this operation.
+func (a *StorageAPI) Resize(args params.ResizeStorageArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		if err := a.resize(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) resize(arg params.ResizeStorageArg) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, _, providerType, volumeSource, err := a.storageVolumeSource(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := volumeSource.(storage.VolumeResizer); !ok {
+		return errors.NotSupportedf("resizing volumes with storage provider %q", providerType)
+	}
+	return a.storageAccess.ResizeStorageInstance(storageTag, arg.Size)
+}
+
+// MoveStorage requests that the storage instances be moved to the
+// specified storage pools. A volume or filesystem is provisioned from the
+// target pool and the unit that the storage is attached to copies the
+// storage contents to it; a "CHANGE" block can block this operation.
+func (a *StorageAPI) MoveStorage(args params.MoveStorageArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		if err := a.moveStorage(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) moveStorage(arg params.MoveStorageArg) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Pool == "" {
+		return errors.NotValidf("empty pool name")
+	}
+	return a.storageAccess.MoveStorageInstance(storageTag, arg.Pool)
+}
+
+// CancelStorageMoves cancels the in-progress moves of the specified
+// storage instances, destroying the volumes provisioned for them.
+func (a *StorageAPI) CancelStorageMoves(args params.Entities) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err == nil {
+			err = a.storageAccess.CancelStorageMove(storageTag)
+		}
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func storageSnapshot(
+	snapshot storage.VolumeSnapshot,
+	storageTag names.StorageTag,
+	volumeTag names.VolumeTag,
+	pool string,
+) params.StorageSnapshot {
+	result := params.StorageSnapshot{
+		SnapshotId:  snapshot.SnapshotId,
+		StorageTag:  storageTag.String(),
+		VolumeTag:   volumeTag.String(),
+		VolumeId:    snapshot.VolumeId,
+		Pool:        pool,
+		Size:        snapshot.Size,
+		Status:      snapshot.Status,
+		Description: snapshot.Description,
+	}
+	if !snapshot.Created.IsZero() {
+		created := snapshot.Created
+		result.Created = &created
+	}
+	return result
+}
+
 // RemovePool deletes the named pool
 func (a *StorageAPI) RemovePool(p params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -1212,6 +1536,17 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v9 api version
+func (*StorageAPIv8) MoveStorage(_, _ struct{})        {}
+func (*StorageAPIv8) CancelStorageMoves(_, _ struct{}) {}
+
+// Added in v8 api version
+func (*StorageAPIv7) Resize(_, _ struct{}) {}
+
+// Added in v7 api version
+func (*StorageAPIv6) CreateSnapshots(_, _ struct{}) {}
+func (*StorageAPIv6) ListSnapshots(_, _ struct{})   {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -70,6 +71,35 @@ func (s *storageSuite) TestStorageListFilesystem(c *gc.C) {
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, wantedDetails)
 }
 
+func (s *storageSuite) TestStorageListFilesystemUsage(c *gc.C) {
+	s.filesystem.info = &state.FilesystemInfo{Size: 1024}
+	s.filesystemAttachment.info = &state.FilesystemAttachmentInfo{MountPoint: "/srv/data"}
+	s.storageAccessor.blockDevices = func(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
+		c.Assert(m, gc.Equals, s.machineTag)
+		return []state.BlockDeviceInfo{{
+			DeviceName: "sdb",
+			MountPoint: "/srv/data",
+			UsedSize:   768,
+			FreeSize:   256,
+		}}, nil
+	}
+	found, err := s.api.ListStorageDetails(
+		params.StorageFilters{[]params.StorageFilter{{}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+
+	wantedDetails := s.createTestStorageDetails()
+	attachment := wantedDetails.Attachments[s.unitTag.String()]
+	attachment.Location = "/srv/data"
+	attachment.UsedSize = 768
+	attachment.FreeSize = 256
+	wantedDetails.Attachments[s.unitTag.String()] = attachment
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, wantedDetails)
+}
+
 func (s *storageSuite) TestStorageListVolume(c *gc.C) {
 	s.storageInstance.kind = state.StorageKindBlock
 	found, err := s.api.ListStorageDetails(
@@ -238,11 +268,10 @@ func (s *storageSuite) createTestStorageDetails() params.StorageDetails {
 		},
 		Attachments: map[string]params.StorageAttachmentDetails{
 			s.unitTag.String(): {
-				s.storageTag.String(),
-				s.unitTag.String(),
-				s.machineTag.String(),
-				"", // location
-				"alive",
+				StorageTag: s.storageTag.String(),
+				UnitTag:    s.unitTag.String(),
+				MachineTag: s.machineTag.String(),
+				Life:       "alive",
 			},
 		},
 	}
@@ -296,17 +325,30 @@ func (s *storageSuite) TestShowStorage(c *gc.C) {
 		},
 		Attachments: map[string]params.StorageAttachmentDetails{
 			s.unitTag.String(): {
-				s.storageTag.String(),
-				s.unitTag.String(),
-				s.machineTag.String(),
-				"",
-				"alive",
+				StorageTag: s.storageTag.String(),
+				UnitTag:    s.unitTag.String(),
+				MachineTag: s.machineTag.String(),
+				Life:       "alive",
 			},
 		},
 	}
 	c.Assert(one.Result, jc.DeepEquals, &expected)
 }
 
+func (s *storageSuite) TestShowStorageMoving(c *gc.C) {
+	s.storageInstance.move = &state.StorageMove{
+		Pool:   "fast",
+		Volume: names.NewVolumeTag("23"),
+	}
+	found, err := s.api.StorageDetails(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result.MovingTo, gc.Equals, "fast")
+}
+
 func (s *storageSuite) TestShowStorageInvalidId(c *gc.C) {
 	storageTag := "foo"
 	entity := params.Entity{Tag: storageTag}
@@ -371,7 +413,13 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPIv8: facadestorage.StorageAPIv8{
+					StorageAPI: *s.api,
+				},
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +490,13 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPIv8: facadestorage.StorageAPIv8{
+					StorageAPI: *s.api,
+				},
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +566,13 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPIv8: facadestorage.StorageAPIv8{
+					StorageAPI: *s.api,
+				},
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +628,13 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPIv8: facadestorage.StorageAPIv8{
+					StorageAPI: *s.api,
+				},
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
@@ -877,6 +943,262 @@ func (s *storageSuite) TestListStorageAsNonAdminOnNotOwnedModel(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
 }
 
+func (s *storageSuite) setupSnapshotVolumeSource(c *gc.C) *dummy.VolumeSource {
+	s.state.modelTag = coretesting.ModelTag
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-0", Pool: "radiance", Size: 1024}
+	volumeSource := &dummy.VolumeSource{}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	return volumeSource
+}
+
+func (s *storageSuite) TestCreateSnapshots(c *gc.C) {
+	volumeSource := s.setupSnapshotVolumeSource(c)
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	volumeSource.CreateVolumeSnapshotsFunc = func(
+		ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams,
+	) ([]storage.CreateVolumeSnapshotsResult, error) {
+		return []storage.CreateVolumeSnapshotsResult{{
+			Snapshot: &storage.VolumeSnapshot{
+				SnapshotId:  "snap-0",
+				VolumeId:    params[0].VolumeId,
+				Size:        1024,
+				Status:      "pending",
+				Description: params[0].Description,
+				Created:     created,
+			},
+		}}, nil
+	}
+
+	results, err := s.api.CreateSnapshots(params.CreateStorageSnapshotsArgs{
+		Storage: []params.CreateStorageSnapshotArg{{
+			StorageTag:  s.storageTag.String(),
+			Description: "before upgrade",
+		}, {
+			StorageTag: "storage-db-dir-1000",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Result: &params.StorageSnapshot{
+			SnapshotId:  "snap-0",
+			StorageTag:  s.storageTag.String(),
+			VolumeTag:   s.volumeTag.String(),
+			VolumeId:    "vol-0",
+			Pool:        "radiance",
+			Size:        1024,
+			Status:      "pending",
+			Description: "before upgrade",
+			Created:     &created,
+		},
+	}, {
+		Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `storage db-dir/1000 not found`,
+		},
+	}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"CreateVolumeSnapshots", []interface{}{
+			s.callContext,
+			[]storage.VolumeSnapshotParams{{
+				VolumeId:    "vol-0",
+				Description: "before upgrade",
+				ResourceTags: map[string]string{
+					"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+				},
+			}},
+		}},
+	})
+}
+
+func (s *storageSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-0", Pool: "radiance"}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			// Hide the dummy source's VolumeSnapshotter methods.
+			return struct{ storage.VolumeSource }{&dummy.VolumeSource{}}, nil
+		},
+	}
+	results, err := s.api.CreateSnapshots(params.CreateStorageSnapshotsArgs{
+		Storage: []params.CreateStorageSnapshotArg{{StorageTag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: `volume snapshots with storage provider "radiance" not supported`,
+		},
+	}})
+}
+
+func (s *storageSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "snapshots")
+	_, err := s.api.CreateSnapshots(params.CreateStorageSnapshotsArgs{
+		Storage: []params.CreateStorageSnapshotArg{{StorageTag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "snapshots")
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	volumeSource := s.setupSnapshotVolumeSource(c)
+	volumeSource.ListVolumeSnapshotsFunc = func(
+		ctx context.ProviderCallContext, volumeIds []string,
+	) ([]storage.VolumeSnapshot, error) {
+		return []storage.VolumeSnapshot{{
+			SnapshotId: "snap-0",
+			VolumeId:   volumeIds[0],
+			Size:       1024,
+			Status:     "completed",
+		}}, nil
+	}
+
+	results, err := s.api.ListSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{
+			SnapshotId: "snap-0",
+			StorageTag: s.storageTag.String(),
+			VolumeTag:  s.volumeTag.String(),
+			VolumeId:   "vol-0",
+			Pool:       "radiance",
+			Size:       1024,
+			Status:     "completed",
+		}},
+	}})
+	volumeSource.CheckCall(c, 0, "ListVolumeSnapshots", s.callContext, []string{"vol-0"})
+}
+
+func (s *storageSuite) TestListSnapshotsNotProvisioned(c *gc.C) {
+	results, err := s.api.ListSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `volume 22 not provisioned`)
+}
+
+func (s *storageSuite) TestResize(c *gc.C) {
+	s.setupSnapshotVolumeSource(c)
+	results, err := s.api.Resize(params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{
+			StorageTag: s.storageTag.String(),
+			Size:       2048,
+		}, {
+			StorageTag: "volume-0",
+			Size:       2048,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, resizeStorageInstanceCall, s.storageTag, uint64(2048))
+}
+
+func (s *storageSuite) TestResizeNotSupported(c *gc.C) {
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-0", Pool: "radiance"}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			// Hide the dummy source's VolumeResizer methods.
+			return struct{ storage.VolumeSource }{&dummy.VolumeSource{}}, nil
+		},
+	}
+	results, err := s.api.Resize(params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: `resizing volumes with storage provider "radiance" not supported`,
+		},
+	}})
+	for _, call := range s.stub.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), resizeStorageInstanceCall)
+	}
+}
+
+func (s *storageSuite) TestMoveStorage(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotSupportedf("moving filesystem storage"))
+	results, err := s.api.MoveStorage(params.MoveStorageArgs{
+		Storage: []params.MoveStorageArg{{
+			StorageTag: s.storageTag.String(),
+			Pool:       "fast",
+		}, {
+			StorageTag: "storage-data-1",
+			Pool:       "fast",
+		}, {
+			StorageTag: "volume-0",
+			Pool:       "fast",
+		}, {
+			StorageTag: s.storageTag.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Code:    params.CodeNotSupported,
+			Message: "moving filesystem storage not supported",
+		}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: "empty pool name not valid"}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{moveStorageInstanceCall, []interface{}{s.storageTag, "fast"}},
+		{moveStorageInstanceCall, []interface{}{names.NewStorageTag("data/1"), "fast"}},
+	})
+}
+
+func (s *storageSuite) TestMoveStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "move")
+	_, err := s.api.MoveStorage(params.MoveStorageArgs{
+		Storage: []params.MoveStorageArg{{StorageTag: s.storageTag.String(), Pool: "fast"}},
+	})
+	s.assertBlocked(c, err, "move")
+}
+
+func (s *storageSuite) TestCancelStorageMoves(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf("move of storage %q", s.storageTag.Id()))
+	results, err := s.api.CancelStorageMoves(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.storageTag.String()},
+			{Tag: "volume-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `move of storage "data/0" not found`,
+		}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, cancelStorageMoveCall, s.storageTag)
+}
+
+func (s *storageSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "resize")
+	_, err := s.api.Resize(params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	s.assertBlocked(c, err, "resize")
+}
+
 type filesystemImporter struct {
 	*dummy.FilesystemSource
 }
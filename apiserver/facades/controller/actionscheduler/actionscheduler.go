@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend describes the model state methods used by the action scheduler API.
+type Backend interface {
+	// WatchActionSchedules returns a watcher that notifies
+	// when action schedules are added, changed or removed.
+	WatchActionSchedules() state.NotifyWatcher
+
+	// RunDueActionSchedules enqueues the actions of every schedule
+	// that is due at the input time, and returns the time at which
+	// the next schedule is due.
+	RunDueActionSchedules(now time.Time) (time.Time, error)
+}
+
+// API provides access to the ActionScheduler API facade.
+// It is used by the model's action scheduler worker to run
+// the actions of schedules as they come due.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+	clock     clock.Clock
+}
+
+// NewActionSchedulerAPI returns a new action scheduler API
+// for the input backend.
+func NewActionSchedulerAPI(
+	backend Backend, resources facade.Resources, authorizer facade.Authorizer, clock clock.Clock,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+		clock:     clock,
+	}, nil
+}
+
+// WatchActionSchedules returns a notify watcher that fires
+// when the model's action schedules change.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	w := api.backend.WatchActionSchedules()
+
+	// Consume the initial event before sending the result.
+	if _, ok := <-w.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(w)
+	} else {
+		return result, watcher.EnsureErr(w)
+	}
+	return result, nil
+}
+
+// RunDueActionSchedules enqueues the actions of every schedule that is
+// due, and returns the time at which the next schedule is due. A zero
+// time indicates that there are no schedules.
+func (api *API) RunDueActionSchedules() (params.ActionScheduleRunResult, error) {
+	next, err := api.backend.RunDueActionSchedules(api.clock.Now())
+	if err != nil {
+		return params.ActionScheduleRunResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ActionScheduleRunResult{NextRun: next}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type actionSchedulerSuite struct {
+	testing.IsolationSuite
+
+	clock     *testclock.Clock
+	resources *common.Resources
+	backend   *fakeBackend
+	api       *actionscheduler.API
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 1, 30, 0, 0, time.UTC))
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.backend = &fakeBackend{}
+
+	var err error
+	s.api, err = actionscheduler.NewActionSchedulerAPI(
+		s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: true}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *actionSchedulerSuite) TestNotController(c *gc.C) {
+	_, err := actionscheduler.NewActionSchedulerAPI(
+		s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: false}, s.clock)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *actionSchedulerSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.NotifyWatcherId, gc.Equals, "1")
+	c.Check(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *actionSchedulerSuite) TestRunDueActionSchedules(c *gc.C) {
+	s.backend.next = time.Date(2020, 4, 1, 2, 0, 0, 0, time.UTC)
+	result, err := s.api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.NextRun, gc.Equals, s.backend.next)
+	c.Check(s.backend.ran, gc.Equals, s.clock.Now())
+}
+
+func (s *actionSchedulerSuite) TestRunDueActionSchedulesError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	result, err := s.api.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, "boom")
+}
+
+type fakeBackend struct {
+	next time.Time
+	ran  time.Time
+	err  error
+}
+
+func (b *fakeBackend) WatchActionSchedules() state.NotifyWatcher {
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	return statetesting.NewMockNotifyWatcher(ch)
+}
+
+func (b *fakeBackend) RunDueActionSchedules(now time.Time) (time.Time, error) {
+	b.ran = now
+	return b.next, b.err
+}
@@ -0,0 +1,255 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/model"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.branchpromoter")
+
+const (
+	// DecisionCommit indicates that a branch should be committed.
+	DecisionCommit = "commit"
+
+	// DecisionAbort indicates that all units should stop tracking a branch
+	// and that the branch should be aborted.
+	DecisionAbort = "abort"
+
+	// DecisionUntrack indicates that some units should stop tracking
+	// a branch.
+	DecisionUntrack = "untrack"
+
+	// promoterUser is recorded as the user that completed
+	// branches committed or aborted by promotion policies.
+	promoterUser = "promotion-policy"
+)
+
+// Backend describes the model state methods used by the branch promoter API.
+type Backend interface {
+	// Branch returns the in-flight branch with the input name.
+	Branch(name string) (Generation, error)
+
+	// FindActionsByName returns all actions with the input name.
+	FindActionsByName(name string) ([]Action, error)
+}
+
+// Generation describes the branch methods used by the branch promoter API.
+type Generation interface {
+	BranchName() string
+	Created() int64
+	PromotionPolicy() (model.PromotionPolicy, bool)
+	AssignedUnits() map[string][]string
+	UnassignUnits([]string) error
+	Commit(string) (int, error)
+	Abort(string) error
+	RecordPromotionDecision(string, string) error
+}
+
+// Action describes the action methods used by the branch promoter API.
+type Action interface {
+	Receiver() string
+	Enqueued() time.Time
+	Status() string
+}
+
+// ModelCache describes the cached model methods
+// used by the branch promoter API.
+type ModelCache interface {
+	Branches() []cache.Branch
+	Unit(string) (cache.Unit, error)
+	WatchBranchPromotion() cache.NotifyWatcher
+}
+
+// API provides access to the BranchPromoter API facade.
+// It is used by the model's branch promoter worker to evaluate branch
+// promotion policies and act on the decisions that it makes.
+type API struct {
+	backend   Backend
+	cache     ModelCache
+	resources facade.Resources
+}
+
+// NewBranchPromoterAPI returns a new branch promoter API
+// for the input backend and cached model.
+func NewBranchPromoterAPI(
+	backend Backend, cache ModelCache, resources facade.Resources, authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		cache:     cache,
+		resources: resources,
+	}, nil
+}
+
+// WatchBranchPromotion returns a notify watcher that fires when a change
+// occurs that could alter the outcome of evaluating branch promotion policies.
+func (api *API) WatchBranchPromotion() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	w := api.cache.WatchBranchPromotion()
+
+	// Consume the initial event before sending the result.
+	if _, ok := <-w.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(w)
+	} else {
+		return result, errors.New("cannot obtain initial branch promotion change")
+	}
+	return result, nil
+}
+
+// BranchPromotionInfo returns information about all in-flight branches
+// that have promotion policies, including the state of their tracking units.
+func (api *API) BranchPromotionInfo() (params.BranchPromotionInfoResults, error) {
+	result := params.BranchPromotionInfoResults{}
+	for _, b := range api.cache.Branches() {
+		policy, ok := b.PromotionPolicy()
+		if !ok {
+			continue
+		}
+		info, err := api.oneBranchPromotionInfo(b, policy)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			return result, nil
+		}
+		result.Results = append(result.Results, info)
+	}
+	return result, nil
+}
+
+func (api *API) oneBranchPromotionInfo(
+	branch cache.Branch, policy model.PromotionPolicy,
+) (params.BranchPromotionInfo, error) {
+	info := params.BranchPromotionInfo{
+		BranchName: branch.Name(),
+		Policy: params.BranchPromotionPolicy{
+			HealthyFor: policy.HealthyFor,
+			ActionName: policy.ActionName,
+			OnError:    string(policy.OnError),
+		},
+		Created:   time.Unix(branch.Created(), 0).UTC(),
+		PolicySet: time.Unix(branch.PromotionPolicySet(), 0).UTC(),
+	}
+
+	actionStatuses, err := api.latestActionStatuses(policy.ActionName, time.Unix(branch.Created(), 0))
+	if err != nil {
+		return info, errors.Trace(err)
+	}
+
+	for _, units := range branch.AssignedUnits() {
+		for _, unitName := range units {
+			unit, err := api.cache.Unit(unitName)
+			if errors.IsNotFound(err) {
+				// The unit may have been removed since the branch was
+				// cached. It will be dropped from the branch shortly.
+				logger.Debugf("unit %q tracking branch %q not found", unitName, branch.Name())
+				continue
+			}
+			if err != nil {
+				return info, errors.Trace(err)
+			}
+
+			workload := unit.WorkloadStatus()
+			info.Units = append(info.Units, params.BranchPromotionUnit{
+				UnitName:       unitName,
+				WorkloadStatus: workload.Status.String(),
+				WorkloadSince:  workload.Since,
+				AgentStatus:    unit.AgentStatus().Status.String(),
+				ActionStatus:   actionStatuses[unitName],
+			})
+		}
+	}
+	return info, nil
+}
+
+// latestActionStatuses returns the status of the most recently enqueued
+// action with the input name for each unit, keyed by unit name.
+// Only actions enqueued after the input time are considered.
+func (api *API) latestActionStatuses(actionName string, after time.Time) (map[string]string, error) {
+	statuses := make(map[string]string)
+	if actionName == "" {
+		return statuses, nil
+	}
+
+	actions, err := api.backend.FindActionsByName(actionName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	latest := make(map[string]time.Time)
+	for _, a := range actions {
+		enqueued := a.Enqueued()
+		if enqueued.Before(after) {
+			continue
+		}
+		receiver := a.Receiver()
+		if last, ok := latest[receiver]; ok && last.After(enqueued) {
+			continue
+		}
+		latest[receiver] = enqueued
+		statuses[receiver] = a.Status()
+	}
+	return statuses, nil
+}
+
+// PromoteBranches acts upon the input decisions made by branch
+// promotion policies, recording each one in the model's status history.
+func (api *API) PromoteBranches(args params.BranchPromotionDecisions) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Decisions)),
+	}
+	for i, decision := range args.Decisions {
+		result.Results[i].Error = common.ServerError(api.promoteOneBranch(decision))
+	}
+	return result, nil
+}
+
+func (api *API) promoteOneBranch(decision params.BranchPromotionDecision) error {
+	branch, err := api.backend.Branch(decision.BranchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := branch.PromotionPolicy(); !ok {
+		return errors.NotValidf("decision for branch %q without a promotion policy", decision.BranchName)
+	}
+
+	switch decision.Decision {
+	case DecisionCommit:
+		_, err = branch.Commit(promoterUser)
+	case DecisionAbort:
+		var units []string
+		for _, appUnits := range branch.AssignedUnits() {
+			units = append(units, appUnits...)
+		}
+		if err = branch.UnassignUnits(units); err == nil {
+			err = branch.Abort(promoterUser)
+		}
+	case DecisionUntrack:
+		for _, unitName := range decision.Units {
+			if !names.IsValidUnit(unitName) {
+				return errors.NotValidf("unit name %q", unitName)
+			}
+		}
+		err = branch.UnassignUnits(decision.Units)
+	default:
+		return errors.NotValidf("branch promotion decision %q", decision.Decision)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "applying %s decision to branch %q", decision.Decision, decision.BranchName)
+	}
+
+	return errors.Trace(branch.RecordPromotionDecision(decision.Decision, decision.Reason))
+}
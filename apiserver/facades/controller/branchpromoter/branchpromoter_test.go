@@ -0,0 +1,336 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/branchpromoter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/cache/cachetest"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+)
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type branchPromoterSuite struct {
+	testing.IsolationSuite
+
+	ctrl      *cachetest.TestController
+	resources *common.Resources
+	backend   *fakeBackend
+	api       *branchpromoter.API
+}
+
+var _ = gc.Suite(&branchPromoterSuite{})
+
+func (s *branchPromoterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.ctrl = cachetest.NewTestController(
+		cachetest.ModelEvents, cachetest.UnitEvents, cachetest.BranchEvents)
+	s.ctrl.Init(c)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, s.ctrl.Controller) })
+
+	s.ctrl.SendChange(cache.ModelChange{ModelUUID: modelUUID, Name: "testing"})
+	_ = s.ctrl.NextChange(c)
+
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	s.backend = &fakeBackend{
+		branch: &fakeGeneration{
+			name:   "new-branch",
+			policy: &model.PromotionPolicy{HealthyFor: time.Minute, OnError: model.BranchErrorAbort},
+			assigned: map[string][]string{
+				"redis": {"redis/0", "redis/1"},
+			},
+		},
+	}
+
+	mc, err := s.ctrl.Model(modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api, err = branchpromoter.NewBranchPromoterAPI(
+		s.backend, modelCacheShim{mc}, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *branchPromoterSuite) TestNotController(c *gc.C) {
+	_, err := branchpromoter.NewBranchPromoterAPI(
+		s.backend, nil, s.resources, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *branchPromoterSuite) TestWatchBranchPromotion(c *gc.C) {
+	result, err := s.api.WatchBranchPromotion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(s.resources.Count(), gc.Equals, 1)
+	c.Check(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
+}
+
+func (s *branchPromoterSuite) TestBranchPromotionInfo(c *gc.C) {
+	since := time.Now()
+	for _, name := range []string{"redis/0", "redis/1"} {
+		s.ctrl.SendChange(cache.UnitChange{
+			ModelUUID:      modelUUID,
+			Name:           name,
+			Application:    "redis",
+			WorkloadStatus: status.StatusInfo{Status: status.Active, Since: &since},
+			AgentStatus:    status.StatusInfo{Status: status.Idle},
+		})
+		_ = s.ctrl.NextChange(c)
+	}
+
+	s.ctrl.SendChange(cache.BranchChange{
+		ModelUUID:     modelUUID,
+		Id:            "0",
+		Name:          "new-branch",
+		AssignedUnits: map[string][]string{"redis": {"redis/0", "redis/1"}},
+		Created:       since.Add(-time.Hour).Unix(),
+		PromotionPolicy: &model.PromotionPolicy{
+			ActionName: "smoke-test",
+			OnError:    model.BranchErrorUntrack,
+		},
+		PromotionPolicySet: since.Add(-time.Minute).Unix(),
+	})
+	_ = s.ctrl.NextChange(c)
+
+	// Branches without a policy are not returned.
+	s.ctrl.SendChange(cache.BranchChange{
+		ModelUUID:     modelUUID,
+		Id:            "1",
+		Name:          "no-policy",
+		AssignedUnits: map[string][]string{"redis": {"redis/0"}},
+	})
+	_ = s.ctrl.NextChange(c)
+
+	s.backend.actions = []branchpromoter.Action{
+		// Superseded by a later run.
+		fakeAction{receiver: "redis/0", enqueued: since.Add(-time.Minute), status: "failed"},
+		fakeAction{receiver: "redis/0", enqueued: since, status: "completed"},
+		// Enqueued before the branch was created.
+		fakeAction{receiver: "redis/1", enqueued: since.Add(-2 * time.Hour), status: "completed"},
+	}
+
+	result, err := s.api.BranchPromotionInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+
+	info := result.Results[0]
+	c.Check(info.BranchName, gc.Equals, "new-branch")
+	c.Check(info.Policy, gc.DeepEquals, params.BranchPromotionPolicy{
+		ActionName: "smoke-test",
+		OnError:    "untrack",
+	})
+	c.Check(info.Created, gc.Equals, time.Unix(since.Add(-time.Hour).Unix(), 0).UTC())
+	c.Check(info.PolicySet, gc.Equals, time.Unix(since.Add(-time.Minute).Unix(), 0).UTC())
+	c.Check(info.Units, jc.SameContents, []params.BranchPromotionUnit{
+		{
+			UnitName:       "redis/0",
+			WorkloadStatus: "active",
+			WorkloadSince:  &since,
+			AgentStatus:    "idle",
+			ActionStatus:   "completed",
+		},
+		{
+			UnitName:       "redis/1",
+			WorkloadStatus: "active",
+			WorkloadSince:  &since,
+			AgentStatus:    "idle",
+		},
+	})
+	s.backend.CheckCall(c, 0, "FindActionsByName", "smoke-test")
+}
+
+func (s *branchPromoterSuite) TestPromoteBranchesCommit(c *gc.C) {
+	result, err := s.api.PromoteBranches(params.BranchPromotionDecisions{
+		Decisions: []params.BranchPromotionDecision{{
+			BranchName: "new-branch",
+			Decision:   "commit",
+			Reason:     "all units active for 1m0s",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	s.backend.branch.CheckCalls(c, []testing.StubCall{
+		{"Commit", []interface{}{"promotion-policy"}},
+		{"RecordPromotionDecision", []interface{}{"commit", "all units active for 1m0s"}},
+	})
+}
+
+func (s *branchPromoterSuite) TestPromoteBranchesAbort(c *gc.C) {
+	result, err := s.api.PromoteBranches(params.BranchPromotionDecisions{
+		Decisions: []params.BranchPromotionDecision{{
+			BranchName: "new-branch",
+			Decision:   "abort",
+			Reason:     "unit redis/1 is in error",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	s.backend.branch.CheckCalls(c, []testing.StubCall{
+		{"UnassignUnits", []interface{}{[]string{"redis/0", "redis/1"}}},
+		{"Abort", []interface{}{"promotion-policy"}},
+		{"RecordPromotionDecision", []interface{}{"abort", "unit redis/1 is in error"}},
+	})
+}
+
+func (s *branchPromoterSuite) TestPromoteBranchesUntrack(c *gc.C) {
+	result, err := s.api.PromoteBranches(params.BranchPromotionDecisions{
+		Decisions: []params.BranchPromotionDecision{{
+			BranchName: "new-branch",
+			Decision:   "untrack",
+			Units:      []string{"redis/1"},
+			Reason:     "unit redis/1 is in error",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	s.backend.branch.CheckCalls(c, []testing.StubCall{
+		{"UnassignUnits", []interface{}{[]string{"redis/1"}}},
+		{"RecordPromotionDecision", []interface{}{"untrack", "unit redis/1 is in error"}},
+	})
+}
+
+func (s *branchPromoterSuite) TestPromoteBranchesErrors(c *gc.C) {
+	s.backend.branch.SetErrors(errors.New("boom"))
+
+	result, err := s.api.PromoteBranches(params.BranchPromotionDecisions{
+		Decisions: []params.BranchPromotionDecision{
+			{BranchName: "new-branch", Decision: "commit"},
+			{BranchName: "new-branch", Decision: "explode"},
+			{BranchName: "new-branch", Decision: "untrack", Units: []string{"not a unit"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `applying commit decision to branch "new-branch": boom`)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `branch promotion decision "explode" not valid`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `unit name "not a unit" not valid`)
+}
+
+func (s *branchPromoterSuite) TestPromoteBranchesNoPolicy(c *gc.C) {
+	s.backend.branch.policy = nil
+
+	result, err := s.api.PromoteBranches(params.BranchPromotionDecisions{
+		Decisions: []params.BranchPromotionDecision{{BranchName: "new-branch", Decision: "commit"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches,
+		`decision for branch "new-branch" without a promotion policy not valid`)
+	s.backend.branch.CheckNoCalls(c)
+}
+
+type modelCacheShim struct {
+	*cache.Model
+}
+
+func (m modelCacheShim) WatchBranchPromotion() cache.NotifyWatcher {
+	return m.Model.WatchBranchPromotion()
+}
+
+type fakeBackend struct {
+	testing.Stub
+
+	branch  *fakeGeneration
+	actions []branchpromoter.Action
+}
+
+func (b *fakeBackend) Branch(name string) (branchpromoter.Generation, error) {
+	b.AddCall("Branch", name)
+	if name != b.branch.name {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	return b.branch, nil
+}
+
+func (b *fakeBackend) FindActionsByName(name string) ([]branchpromoter.Action, error) {
+	b.AddCall("FindActionsByName", name)
+	return b.actions, b.NextErr()
+}
+
+type fakeGeneration struct {
+	testing.Stub
+
+	name     string
+	policy   *model.PromotionPolicy
+	assigned map[string][]string
+}
+
+func (g *fakeGeneration) BranchName() string {
+	return g.name
+}
+
+func (g *fakeGeneration) Created() int64 {
+	return 0
+}
+
+func (g *fakeGeneration) PromotionPolicy() (model.PromotionPolicy, bool) {
+	if g.policy == nil {
+		return model.PromotionPolicy{}, false
+	}
+	return *g.policy, true
+}
+
+func (g *fakeGeneration) AssignedUnits() map[string][]string {
+	return g.assigned
+}
+
+func (g *fakeGeneration) UnassignUnits(units []string) error {
+	g.AddCall("UnassignUnits", units)
+	return g.NextErr()
+}
+
+func (g *fakeGeneration) Commit(user string) (int, error) {
+	g.AddCall("Commit", user)
+	return 1, g.NextErr()
+}
+
+func (g *fakeGeneration) Abort(user string) error {
+	g.AddCall("Abort", user)
+	return g.NextErr()
+}
+
+func (g *fakeGeneration) RecordPromotionDecision(decision, reason string) error {
+	g.AddCall("RecordPromotionDecision", decision, reason)
+	return g.NextErr()
+}
+
+type fakeAction struct {
+	receiver string
+	enqueued time.Time
+	status   string
+}
+
+func (a fakeAction) Receiver() string {
+	return a.receiver
+}
+
+func (a fakeAction) Enqueued() time.Time {
+	return a.enqueued
+}
+
+func (a fakeAction) Status() string {
+	return a.status
+}
@@ -0,0 +1,11 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+import "github.com/juju/juju/state"
+
+// NewStateBackend returns the state backend used by the facade.
+func NewStateBackend(m *state.Model) Backend {
+	return &backendShim{Model: m}
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mc, err := ctx.Controller().Model(st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewBranchPromoterAPI(&backendShim{Model: m}, &modelCacheShim{Model: mc}, ctx.Resources(), ctx.Auth())
+}
+
+type backendShim struct {
+	*state.Model
+}
+
+// Branch wraps the state model branch method,
+// returning the locally defined Generation interface.
+func (b *backendShim) Branch(name string) (Generation, error) {
+	gen, err := b.Model.Branch(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gen, nil
+}
+
+// FindActionsByName wraps the state model method,
+// returning a collection of the locally defined Action interface.
+func (b *backendShim) FindActionsByName(name string) ([]Action, error) {
+	actions, err := b.Model.FindActionsByName(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res := make([]Action, len(actions))
+	for i, a := range actions {
+		res[i] = actionShim{Action: a}
+	}
+	return res, nil
+}
+
+type actionShim struct {
+	state.Action
+}
+
+// Status returns the status of the action as a string.
+func (a actionShim) Status() string {
+	return string(a.Action.Status())
+}
+
+type modelCacheShim struct {
+	*cache.Model
+}
+
+// WatchBranchPromotion returns the cache watcher
+// as the cache NotifyWatcher interface.
+func (m *modelCacheShim) WatchBranchPromotion() cache.NotifyWatcher {
+	return m.Model.WatchBranchPromotion()
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/branchpromoter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+// stateSuite exercises the facade against a real state backend,
+// ensuring that decisions act upon branches as persisted.
+type stateSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&stateSuite{})
+
+func (s *stateSuite) TestPromoteBranchesAbort(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	c.Assert(s.Model.AddBranch("new-branch", "test-user"), jc.ErrorIsNil)
+	branch, err := s.Model.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(unit.Name()), jc.ErrorIsNil)
+	c.Assert(branch.SetPromotionPolicy(model.PromotionPolicy{
+		HealthyFor: time.Minute,
+		OnError:    model.BranchErrorAbort,
+	}), jc.ErrorIsNil)
+
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	api, err := branchpromoter.NewBranchPromoterAPI(
+		branchpromoter.NewStateBackend(s.Model), nil, resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.PromoteBranches(params.BranchPromotionDecisions{
+		Decisions: []params.BranchPromotionDecision{{
+			BranchName: "new-branch",
+			Decision:   "abort",
+			Reason:     "unit in error",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	c.Assert(branch.Refresh(), jc.ErrorIsNil)
+	c.Check(branch.IsCompleted(), jc.IsTrue)
+	c.Check(branch.CompletedBy(), gc.Equals, "promotion-policy")
+	c.Check(branch.AssignedUnits(), gc.DeepEquals, map[string][]string{app.Name(): {}})
+}
@@ -16,12 +16,14 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.crosscontroller")
 
 type localControllerInfoFunc func() ([]string, string, error)
+type localRelayProxyFunc func() (string, error)
 type watchLocalControllerInfoFunc func() state.NotifyWatcher
 
 // CrossControllerAPI provides access to the CrossModelRelations API facade.
 type CrossControllerAPI struct {
 	resources                facade.Resources
 	localControllerInfo      localControllerInfoFunc
+	localRelayProxy          localRelayProxyFunc
 	watchLocalControllerInfo watchLocalControllerInfoFunc
 }
 
@@ -32,7 +34,21 @@ func NewStateCrossControllerAPI(ctx facade.Context) (*CrossControllerAPI, error)
 	return NewCrossControllerAPI(
 		ctx.Resources(),
 		func() ([]string, string, error) { return common.StateControllerInfo(st) },
-		st.WatchAPIHostPortsForClients,
+		func() (string, error) {
+			cfg, err := st.ControllerConfig()
+			if err != nil {
+				return "", err
+			}
+			return cfg.CrossModelRelayProxy(), nil
+		},
+		func() state.NotifyWatcher {
+			// The relay proxy is held in controller config, so
+			// changes to it are reported along with address changes.
+			return common.NewMultiNotifyWatcher(
+				st.WatchAPIHostPortsForClients(),
+				st.WatchControllerConfig(),
+			)
+		},
 	)
 }
 
@@ -40,11 +56,13 @@ func NewStateCrossControllerAPI(ctx facade.Context) (*CrossControllerAPI, error)
 func NewCrossControllerAPI(
 	resources facade.Resources,
 	localControllerInfo localControllerInfoFunc,
+	localRelayProxy localRelayProxyFunc,
 	watchLocalControllerInfo watchLocalControllerInfoFunc,
 ) (*CrossControllerAPI, error) {
 	return &CrossControllerAPI{
 		resources:                resources,
 		localControllerInfo:      localControllerInfo,
+		localRelayProxy:          localRelayProxy,
 		watchLocalControllerInfo: watchLocalControllerInfo,
 	}, nil
 }
@@ -64,7 +82,9 @@ func (api *CrossControllerAPI) WatchControllerInfo() (params.NotifyWatchResults,
 	return results, nil
 }
 
-// ControllerInfo returns the API info for the controller.
+// ControllerInfo returns the API info for the controller, including
+// the proxy through which other controllers should connect to it, if
+// one is configured.
 func (api *CrossControllerAPI) ControllerInfo() (params.ControllerAPIInfoResults, error) {
 	results := params.ControllerAPIInfoResults{
 		Results: make([]params.ControllerAPIInfoResult, 1),
@@ -74,7 +94,13 @@ func (api *CrossControllerAPI) ControllerInfo() (params.ControllerAPIInfoResults
 		results.Results[0].Error = common.ServerError(err)
 		return results, nil
 	}
+	relayProxy, err := api.localRelayProxy()
+	if err != nil {
+		results.Results[0].Error = common.ServerError(err)
+		return results, nil
+	}
 	results.Results[0].Addresses = addrs
 	results.Results[0].CACert = caCert
+	results.Results[0].RelayProxy = relayProxy
 	return results, nil
 }
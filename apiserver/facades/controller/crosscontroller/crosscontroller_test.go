@@ -24,6 +24,7 @@ type CrossControllerSuite struct {
 	resources                *common.Resources
 	watcher                  *mockNotifyWatcher
 	localControllerInfo      func() ([]string, string, error)
+	localRelayProxy          func() (string, error)
 	watchLocalControllerInfo func() state.NotifyWatcher
 	api                      *crosscontroller.CrossControllerAPI
 }
@@ -35,12 +36,16 @@ func (s *CrossControllerSuite) SetUpTest(c *gc.C) {
 	s.localControllerInfo = func() ([]string, string, error) {
 		return []string{"addr1", "addr2"}, "ca-cert", nil
 	}
+	s.localRelayProxy = func() (string, error) {
+		return "", nil
+	}
 	s.watchLocalControllerInfo = func() state.NotifyWatcher {
 		return s.watcher
 	}
 	api, err := crosscontroller.NewCrossControllerAPI(
 		s.resources,
 		func() ([]string, string, error) { return s.localControllerInfo() },
+		func() (string, error) { return s.localRelayProxy() },
 		func() state.NotifyWatcher { return s.watchLocalControllerInfo() },
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -60,6 +65,21 @@ func (s *CrossControllerSuite) TestControllerInfo(c *gc.C) {
 	})
 }
 
+func (s *CrossControllerSuite) TestControllerInfoRelayProxy(c *gc.C) {
+	s.localRelayProxy = func() (string, error) {
+		return "http://relay.example.com:3128", nil
+	}
+	results, err := s.api.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ControllerAPIInfoResults{
+		[]params.ControllerAPIInfoResult{{
+			Addresses:  []string{"addr1", "addr2"},
+			CACert:     "ca-cert",
+			RelayProxy: "http://relay.example.com:3128",
+		}},
+	})
+}
+
 func (s *CrossControllerSuite) TestControllerInfoError(c *gc.C) {
 	s.localControllerInfo = func() ([]string, string, error) {
 		return nil, "", errors.New("nope")
@@ -223,6 +223,20 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+
+	// A new relation is a new connection to the offer, so check the offer
+	// has room for it before adding anything; otherwise a rejected
+	// connection would leave the remote application and relation behind.
+	localRel, err := api.st.EndpointsRelation(*localEndpoint, remoteEndpoint)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if err != nil {
+		if err := api.st.CheckOfferConnectionLimit(appOffer.OfferUUID); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	_, err = api.st.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:            uniqueRemoteApplicationName,
 		OfferUUID:       relation.OfferUUID,
@@ -238,11 +252,7 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	logger.Debugf("added remote application %v to local model with token %v from model %v", uniqueRemoteApplicationName, relation.ApplicationToken, sourceModelTag.Id())
 
 	// Now add the relation if it doesn't already exist.
-	localRel, err := api.st.EndpointsRelation(*localEndpoint, remoteEndpoint)
-	if err != nil && !errors.IsNotFound(err) {
-		return nil, errors.Trace(err)
-	}
-	if err != nil { // not found
+	if localRel == nil {
 		localRel, err = api.st.AddRelation(*localEndpoint, remoteEndpoint)
 		// Again, if it already exists, that's fine.
 		if err != nil && !errors.IsAlreadyExists(err) {
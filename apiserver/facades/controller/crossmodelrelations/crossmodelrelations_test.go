@@ -272,6 +272,54 @@ func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C
 	s.assertRegisterRemoteRelations(c)
 }
 
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsMaxConnections(c *gc.C) {
+	app := &mockApplication{}
+	app.eps = []state.Endpoint{{
+		ApplicationName: "offeredapp",
+		Relation:        charm.Relation{Name: "local"},
+	}}
+	s.st.applications["offeredapp"] = app
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"offer-uuid": {
+			OfferUUID:       "offer-uuid",
+			OfferName:       "offered",
+			ApplicationName: "offeredapp",
+			MaxConnections:  1,
+		}}
+	s.st.offerConnections[1] = &mockOfferConnection{
+		offerUUID:       "offer-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "offeredapp:local remote-othertoken:remote",
+		relationId:      1,
+	}
+	mac, err := s.bakery.NewMacaroon(
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("offer-uuid", "offer-uuid"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:  "app-token",
+			SourceModelTag:    coretesting.ModelTag.String(),
+			RelationToken:     "rel-token",
+			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:         "offer-uuid",
+			LocalEndpointName: "local",
+			Macaroons:         macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `offer "offered" already has the maximum of 1 connections`)
+
+	// Nothing is left behind by the rejected connection.
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	c.Assert(s.st.relations, gc.HasLen, 0)
+	c.Assert(s.st.offerConnections, gc.HasLen, 1)
+}
+
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
@@ -119,6 +119,23 @@ func (st *mockState) AddOfferConnection(arg state.AddOfferConnectionParams) (cro
 	return oc, nil
 }
 
+func (st *mockState) CheckOfferConnectionLimit(offerUUID string) error {
+	offer, ok := st.offers[offerUUID]
+	if !ok || offer.MaxConnections == 0 {
+		return nil
+	}
+	count := 0
+	for _, oc := range st.offerConnections {
+		if oc.offerUUID == offerUUID {
+			count++
+		}
+	}
+	if count >= offer.MaxConnections {
+		return errors.QuotaLimitExceededf("offer %q already has the maximum of %d connections", offer.OfferName, offer.MaxConnections)
+	}
+	return nil
+}
+
 func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	if r, ok := st.firewallRules[service]; ok {
 		return r, nil
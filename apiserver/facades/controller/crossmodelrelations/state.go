@@ -23,6 +23,11 @@ type CrossModelRelationsState interface {
 	// relation made from a remote model to an offer in the local model.
 	AddOfferConnection(state.AddOfferConnectionParams) (OfferConnection, error)
 
+	// CheckOfferConnectionLimit returns an error satisfying
+	// errors.IsQuotaLimitExceeded if the offer already has the
+	// maximum number of connections it allows.
+	CheckOfferConnectionLimit(offerUUID string) error
+
 	// OfferConnectionForRelation returns the offer connection details for the given relation key.
 	OfferConnectionForRelation(string) (OfferConnection, error)
 }
@@ -43,6 +48,10 @@ func (st stateShim) AddOfferConnection(arg state.AddOfferConnectionParams) (Offe
 	return st.st.AddOfferConnection(arg)
 }
 
+func (st stateShim) CheckOfferConnectionLimit(offerUUID string) error {
+	return st.st.CheckOfferConnectionLimit(offerUUID)
+}
+
 func (st stateShim) OfferConnectionForRelation(relationKey string) (OfferConnection, error) {
 	return st.st.OfferConnectionForRelation(relationKey)
 }
@@ -91,6 +91,7 @@ func (s *ExternalControllerUpdaterAPI) ExternalControllerInfo(args params.Entiti
 			Alias:         info.Alias,
 			Addrs:         info.Addrs,
 			CACert:        info.CACert,
+			RelayProxy:    info.RelayProxy,
 		}
 	}
 	return result, nil
@@ -112,6 +113,7 @@ func (s *ExternalControllerUpdaterAPI) SetExternalControllerInfo(args params.Set
 			Alias:         arg.Info.Alias,
 			Addrs:         arg.Info.Addrs,
 			CACert:        arg.Info.CACert,
+			RelayProxy:    arg.Info.RelayProxy,
 		}); err != nil {
 			result.Results[i].Error = common.ServerError(err)
 			continue
@@ -51,6 +51,11 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -92,6 +97,17 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -211,7 +227,7 @@ func (f *FirewallerAPIV3) watchOneModelOpenedPorts(tag names.Tag) (string, []str
 
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
-// them.
+// them, and the endpoints they were opened for.
 func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (params.MachinePortsResults, error) {
 	result := params.MachinePortsResults{
 		Results: make([]params.MachinePortsResult, len(args.Params)),
@@ -246,6 +262,7 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			endpoints := ports.AllPortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -258,6 +275,7 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 					params.MachinePortRange{
 						UnitTag:   unitTag,
 						PortRange: params.FromNetworkPortRange(portRange),
+						Endpoint:  endpoints[portRange],
 					})
 			}
 		}
@@ -527,3 +545,68 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetExposeInfo returns the exposed flag and the endpoint expose
+// settings for each given application. The spaces of each endpoint
+// are resolved to the CIDRs of their subnets, which are included in
+// the endpoint's CIDRs.
+func (f *FirewallerAPIV6) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Exposed = application.IsExposed()
+			result.Results[i].ExposedEndpoints, err = f.exposedEndpoints(application.ExposedEndpoints())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchSubnetChanges returns a NotifyWatcher that notifies of changes
+// to the model's subnets, which may change the CIDRs of the spaces that
+// applications are exposed to.
+func (f *FirewallerAPIV6) WatchSubnetChanges() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := f.st.WatchSubnetChanges()
+	// Consume the initial event before sending the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = f.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPIV6) exposedEndpoints(exposed map[string]state.ExposedEndpoint) (map[string]params.ExposedEndpoint, error) {
+	if len(exposed) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.ExposedEndpoint, len(exposed))
+	for endpoint, settings := range exposed {
+		cidrs := append([]string(nil), settings.ExposeToCIDRs...)
+		for _, spaceName := range settings.ExposeToSpaces {
+			spaceCIDRs, err := f.st.SpaceSubnetCIDRs(spaceName)
+			if err != nil {
+				return nil, errors.Annotatef(err, "resolving space %q for endpoint %q", spaceName, endpoint)
+			}
+			cidrs = append(cidrs, spaceCIDRs...)
+		}
+		result[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return result, nil
+}
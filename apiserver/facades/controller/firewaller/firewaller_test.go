@@ -104,7 +104,7 @@ func (s *firewallerSuite) openPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.units[0].OpenPort("tcp", 4321)
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.units[2].OpenPorts("udp", 1111, 2222)
+	err = s.units[2].OpenPortsForEndpoint("url", "udp", 1111, 2222)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -185,7 +185,7 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 	expectPortsMachine2 := []params.MachinePortRange{
 		{UnitTag: unit2Tag, PortRange: params.PortRange{
 			FromPort: 1111, ToPort: 2222, Protocol: "udp",
-		}},
+		}, Endpoint: "url"},
 	}
 	result, err := s.firewaller.GetMachinePorts(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -276,3 +276,76 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", []string{s.subnet.ID()}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {
+			ExposeToSpaces: []string{"internal"},
+			ExposeToCIDRs:  []string{"192.168.0.0/16"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"": {
+						ExposeToSpaces: []string{"internal"},
+						ExposeToCIDRs:  []string{"192.168.0.0/16", "10.20.30.0/24"},
+					},
+				},
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestWatchSubnetChanges(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.WatchSubnetChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// The initial event has been consumed; moving a
+	// subnet to a space is reported.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+	_, err = s.State.AddSpace("internal", "", []string{s.subnet.ID()}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
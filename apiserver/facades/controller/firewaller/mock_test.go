@@ -77,6 +77,14 @@ func (st *mockState) ControllerInfo(modelUUID string) ([]string, string, error)
 	}
 }
 
+func (st *mockState) ControllerRelayProxy(modelUUID string) (string, error) {
+	if info, ok := st.controllerInfo[modelUUID]; !ok {
+		return "", errors.NotFoundf("controller info for %v", modelUUID)
+	} else {
+		return info.ControllerInfo().RelayProxy, nil
+	}
+}
+
 func (st *mockState) GetMacaroon(entity names.Tag) (*macaroon.Macaroon, error) {
 	st.MethodCall(st, "GetMacaroon", entity)
 	if err := st.NextErr(); err != nil {
@@ -98,6 +106,12 @@ func (st *mockState) WatchSubnets(func(id interface{}) bool) state.StringsWatche
 	return st.subnetsWatcher
 }
 
+func (st *mockState) WatchSubnetChanges() state.NotifyWatcher {
+	st.MethodCall(st, "WatchSubnetChanges")
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil
+}
+
 func (st *mockState) WatchOpenedPorts() state.StringsWatcher {
 	st.MethodCall(st, "WatchOpenedPorts")
 	// TODO - implement when remaining firewaller tests become unit tests
@@ -126,6 +140,10 @@ func (st *mockState) SubnetByID(id string) (firewaller.Subnet, error) {
 	return nil, errors.NotImplementedf("SubnetByID")
 }
 
+func (st *mockState) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	return nil, errors.NotImplementedf("SpaceSubnetCIDRs")
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
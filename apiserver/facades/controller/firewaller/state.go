@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -29,6 +30,10 @@ type State interface {
 	SubnetByID(id string) (Subnet, error)
 
 	Subnet(cidr string) (Subnet, error)
+
+	SpaceSubnetCIDRs(spaceName string) ([]string, error)
+
+	WatchSubnetChanges() state.NotifyWatcher
 }
 
 // TODO(wallyworld) - for tests, remove when remaining firewaller tests become unit tests.
@@ -58,6 +63,10 @@ func (st stateShim) WatchOpenedPorts() state.StringsWatcher {
 	return st.st.WatchOpenedPorts()
 }
 
+func (st stateShim) WatchSubnetChanges() state.NotifyWatcher {
+	return st.st.WatchSubnetChanges()
+}
+
 func (s stateShim) FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.st)
 	return api.Rule(service)
@@ -75,3 +84,20 @@ func (s stateShim) SubnetByID(id string) (Subnet, error) {
 func (s stateShim) Subnet(cidr string) (Subnet, error) {
 	return s.st.Subnet(cidr)
 }
+
+// SpaceSubnetCIDRs returns the CIDRs of the subnets in the named space.
+func (s stateShim) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	space, err := s.st.Space(spaceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		cidrs[i] = subnet.CIDR()
+	}
+	return cidrs, nil
+}
@@ -61,6 +61,14 @@ func (st *mockState) ControllerInfo(modelUUID string) ([]string, string, error)
 	}
 }
 
+func (st *mockState) ControllerRelayProxy(modelUUID string) (string, error) {
+	if info, ok := st.controllerInfo[modelUUID]; !ok {
+		return "", errors.NotFoundf("controller info for %v", modelUUID)
+	} else {
+		return info.ControllerInfo().RelayProxy, nil
+	}
+}
+
 func (st *mockState) ModelUUID() string {
 	return coretesting.ModelTag.Id()
 }
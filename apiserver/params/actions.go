@@ -149,3 +149,128 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// ActionOutputParams holds the arguments for recording lines of output
+// written by running actions.
+type ActionOutputParams struct {
+	Output []ActionOutputLines `json:"output"`
+}
+
+// ActionOutputLines holds lines written by an action to one of its
+// output streams, "stdout" or "stderr".
+type ActionOutputLines struct {
+	Tag    string   `json:"tag"`
+	Stream string   `json:"stream"`
+	Lines  []string `json:"lines"`
+}
+
+// ActionMessage is a progress message logged by an action, or a line
+// of output written by it while running.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+
+	// Stream is empty for progress messages, and names the output
+	// stream for lines of output.
+	Stream string `json:"stream,omitempty"`
+}
+
+// ActionStreamRecords holds a batch of messages streamed from a
+// running action. Once the action has finished, Done is set along with
+// its final status.
+type ActionStreamRecords struct {
+	Messages []ActionMessage `json:"messages,omitempty"`
+	Status   string          `json:"status,omitempty"`
+	Done     bool            `json:"done,omitempty"`
+}
+
+// RunOperationArgs holds the arguments for enqueueing an action on a
+// number of units as a single operation.
+type RunOperationArgs struct {
+	// Receivers are unit tags, "<application>/leader", or application
+	// tags standing for every unit of the application.
+	Receivers     []string               `json:"receivers"`
+	Name          string                 `json:"name"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+	Parallel      int                    `json:"parallel,omitempty"`
+	StopOnFailure bool                   `json:"stop-on-failure,omitempty"`
+	LeaderLast    bool                   `json:"leader-last,omitempty"`
+}
+
+// OperationIDs holds the ids of operations to query.
+type OperationIDs struct {
+	IDs []string `json:"ids"`
+}
+
+// OperationResults is a slice of OperationResult for bulk requests.
+type OperationResults struct {
+	Results []OperationResult `json:"results,omitempty"`
+}
+
+// OperationResult describes an operation and the actions it has
+// enqueued so far.
+type OperationResult struct {
+	OperationID   string         `json:"operation"`
+	ActionName    string         `json:"action-name,omitempty"`
+	Status        string         `json:"status,omitempty"`
+	Message       string         `json:"message,omitempty"`
+	Parallel      int            `json:"parallel,omitempty"`
+	StopOnFailure bool           `json:"stop-on-failure,omitempty"`
+	Enqueued      time.Time      `json:"enqueued,omitempty"`
+	Completed     time.Time      `json:"completed,omitempty"`
+	Queued        []string       `json:"queued,omitempty"`
+	Actions       []ActionResult `json:"actions,omitempty"`
+	Error         *Error         `json:"error,omitempty"`
+}
+
+// ActionScheduleArgs holds the action schedules to add.
+type ActionScheduleArgs struct {
+	Schedules []ActionScheduleArg `json:"schedules"`
+}
+
+// ActionScheduleArg describes an action to be run by the controller
+// on a cron schedule.
+type ActionScheduleArg struct {
+	Name            string                 `json:"name"`
+	Receiver        string                 `json:"receiver"`
+	ActionName      string                 `json:"action-name"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Cron            string                 `json:"cron"`
+	MissedRunPolicy string                 `json:"missed-run-policy,omitempty"`
+	OverlapPolicy   string                 `json:"overlap-policy,omitempty"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionSchedulesResult holds the action schedules in a model.
+type ActionSchedulesResult struct {
+	Schedules []ActionSchedule `json:"schedules,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// ActionSchedule describes an action schedule and the outcome of
+// its most recent run.
+type ActionSchedule struct {
+	Name            string                 `json:"name"`
+	Receiver        string                 `json:"receiver"`
+	ActionName      string                 `json:"action-name"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Cron            string                 `json:"cron"`
+	MissedRunPolicy string                 `json:"missed-run-policy"`
+	OverlapPolicy   string                 `json:"overlap-policy"`
+	Created         time.Time              `json:"created"`
+	NextRun         time.Time              `json:"next-run"`
+	LastRun         time.Time              `json:"last-run,omitempty"`
+	LastActions     map[string]string      `json:"last-actions,omitempty"`
+	LastMessage     string                 `json:"last-message,omitempty"`
+}
+
+// ActionScheduleRunResult holds the time at which the
+// next action schedule in a model is due to run.
+type ActionScheduleRunResult struct {
+	NextRun time.Time `json:"next-run,omitempty"`
+	Error   *Error    `json:"error,omitempty"`
+}
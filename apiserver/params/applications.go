@@ -149,6 +149,21 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints maps endpoint names to the sources they are
+	// exposed to. The empty endpoint name applies to all endpoints.
+	// If no endpoints are specified, the application is exposed to
+	// everyone. This field is only understood by Application facade
+	// version 14 and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint describes the sources that an exposed application
+// endpoint may be accessed from. If neither spaces nor CIDRs are
+// specified, the endpoint may be accessed from anywhere.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -240,6 +255,12 @@ type ApplicationCharmRelationsResults struct {
 // ApplicationUnexpose holds parameters for the application Unexpose call.
 type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints contains the endpoints whose expose settings
+	// are removed. If empty, the whole application is unexposed.
+	// This field is only understood by Application facade version 14
+	// and greater.
+	ExposedEndpoints []string `json:"exposed-endpoints,omitempty"`
 }
 
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
@@ -439,3 +460,39 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// UnitInfo holds information about a unit.
+type UnitInfo struct {
+	Tag             string            `json:"tag"`
+	Life            string            `json:"life"`
+	Charm           string            `json:"charm,omitempty"`
+	WorkloadVersion string            `json:"workload-version,omitempty"`
+	Machine         string            `json:"machine,omitempty"`
+	Principal       string            `json:"principal,omitempty"`
+	State           map[string]string `json:"state,omitempty"`
+	HookExecutions  []HookExecution   `json:"hook-executions,omitempty"`
+}
+
+// UnitInfoResult holds a unit info or a retrieval error.
+type UnitInfoResult struct {
+	Result *UnitInfo `json:"result,omitempty"`
+	Error  *Error    `json:"error,omitempty"`
+}
+
+// UnitInfoResults holds units associated with entities.
+type UnitInfoResults struct {
+	Results []UnitInfoResult `json:"results"`
+}
+
+// ApplicationMergeBindingsArgs holds the parameters for updating the
+// endpoint bindings of one or more applications.
+type ApplicationMergeBindingsArgs struct {
+	Args []ApplicationMergeBindings `json:"args"`
+}
+
+// ApplicationMergeBindings holds a list of endpoint bindings to merge
+// with those of an existing application.
+type ApplicationMergeBindings struct {
+	ApplicationTag string            `json:"application-tag"`
+	Bindings       map[string]string `json:"bindings"`
+}
@@ -91,6 +91,7 @@ type ApplicationOfferAdminDetails struct {
 	ApplicationName string            `json:"application-name"`
 	CharmURL        string            `json:"charm-url"`
 	Connections     []OfferConnection `json:"connections,omitempty"`
+	MaxConnections  int               `json:"max-connections,omitempty"`
 }
 
 // OfferConnection holds details about a connection to an offer.
@@ -121,6 +122,7 @@ type AddApplicationOffer struct {
 	ApplicationName        string            `json:"application-name"`
 	ApplicationDescription string            `json:"application-description"`
 	Endpoints              map[string]string `json:"endpoints"`
+	MaxConnections         int               `json:"max-connections,omitempty"`
 }
 
 // DestroyApplicationOffers holds parameters for the DestroyOffers call.
@@ -129,6 +131,21 @@ type DestroyApplicationOffers struct {
 	Force     bool     `json:"force,omitempty"`
 }
 
+// RevokeOfferConnectionsArgs holds parameters for the RevokeOfferConnections call.
+type RevokeOfferConnectionsArgs struct {
+	Args []RevokeOfferConnectionArg `json:"args"`
+}
+
+// RevokeOfferConnectionArg identifies a connection to an offer by the
+// id of its relation in the offering model. If Suspend is true, the
+// relation is suspended with the given reason rather than removed.
+type RevokeOfferConnectionArg struct {
+	OfferURL   string `json:"offer-url"`
+	RelationId int    `json:"relation-id"`
+	Suspend    bool   `json:"suspend,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
 // RemoteEndpoint represents a remote application endpoint.
 type RemoteEndpoint struct {
 	Name      string             `json:"name"`
@@ -630,4 +647,5 @@ type ExternalControllerInfo struct {
 	Alias         string   `json:"controller-alias"`
 	Addrs         []string `json:"addrs"`
 	CACert        string   `json:"ca-cert"`
+	RelayProxy    string   `json:"relay-proxy,omitempty"`
 }
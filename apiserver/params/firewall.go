@@ -60,3 +60,55 @@ func (v KnownServiceValue) Validate() error {
 	}
 	return errors.NotValidf("known service %q", v)
 }
+
+// ExposeInfoResults holds the expose information of a number of
+// applications.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// ExposeInfoResult holds the expose information of an application.
+type ExposeInfoResult struct {
+	// Exposed indicates whether the application is exposed.
+	Exposed bool `json:"exposed,omitempty"`
+
+	// ExposedEndpoints holds the expose settings of the application's
+	// endpoints, keyed by endpoint name. The CIDRs of the subnets in
+	// any spaces are included in each endpoint's CIDRs.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// HostFirewallRulesResults holds the host firewall rules for a number
+// of machines.
+type HostFirewallRulesResults struct {
+	Results []HostFirewallRulesResult `json:"results"`
+}
+
+// HostFirewallRulesResult holds the host firewall rules for a machine.
+type HostFirewallRulesResult struct {
+	// Enabled indicates whether the host firewall is enabled for the
+	// machine's model.
+	Enabled bool `json:"enabled"`
+
+	// InternalCIDRs holds the sources that may access any port on the
+	// machine.
+	InternalCIDRs []string `json:"internal-cidrs,omitempty"`
+
+	// Rules holds the ingress rules for the ports opened on the machine.
+	Rules []IngressRule `json:"rules,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// IngressRule is a rule allowing ingress to a range of ports from a
+// set of sources.
+type IngressRule struct {
+	// PortRange is the range of ports the rule applies to.
+	PortRange PortRange `json:"port-range"`
+
+	// SourceCIDRs is the list of sources allowed access. If empty,
+	// access is allowed from everywhere.
+	SourceCIDRs []string `json:"source-cidrs,omitempty"`
+}
@@ -268,9 +268,10 @@ type ControllerConfigResult struct {
 
 // ControllerAPIInfoResult holds controller api address details.
 type ControllerAPIInfoResult struct {
-	Addresses []string `json:"addresses"`
-	CACert    string   `json:"cacert"`
-	Error     *Error   `json:"error,omitempty"`
+	Addresses  []string `json:"addresses"`
+	CACert     string   `json:"cacert"`
+	RelayProxy string   `json:"relay-proxy,omitempty"`
+	Error      *Error   `json:"error,omitempty"`
 }
 
 // ControllerAPIInfoResults holds controller api address details results.
@@ -845,6 +846,7 @@ type UnitRefreshResult struct {
 	Resolved   ResolvedMode
 	Error      *Error
 	ProviderID string `json:"provider-id,omitempty"`
+	Paused     bool   `json:"paused,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -876,6 +878,50 @@ type GoalStateResult struct {
 	Error  *Error     `json:"error"`
 }
 
+// UnitStateResults holds the results of a State API call.
+type UnitStateResults struct {
+	Results []UnitStateResult `json:"results"`
+}
+
+// UnitStateResult holds the charm state stored for a unit.
+type UnitStateResult struct {
+	State map[string]string `json:"state,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// SetUnitStateArgs holds the arguments for a SetState API call.
+type SetUnitStateArgs struct {
+	Args []SetUnitStateArg `json:"args"`
+}
+
+// SetUnitStateArg holds the charm state to store for a unit, replacing
+// any state it already has.
+type SetUnitStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// HookExecution holds the timing and outcome of a hook run by a unit.
+type HookExecution struct {
+	Hook     string    `json:"hook"`
+	Outcome  string    `json:"outcome"`
+	Queued   time.Time `json:"queued"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+}
+
+// RecordHookExecutionsArgs holds the hook executions to record for a
+// number of units.
+type RecordHookExecutionsArgs struct {
+	Args []RecordHookExecutionsArg `json:"args"`
+}
+
+// RecordHookExecutionsArg holds the hook executions to record for a unit.
+type RecordHookExecutionsArg struct {
+	Tag        string          `json:"tag"`
+	Executions []HookExecution `json:"executions"`
+}
+
 // GoalStateStatus goal-state at unit level
 type GoalStateStatus struct {
 	Status string     `json:"status"`
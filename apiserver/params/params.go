@@ -368,6 +368,12 @@ type UnitsResolved struct {
 	All   bool     `json:"all,omitempty"`
 }
 
+// PauseUnitsArgs holds parameters for the PauseUnits call.
+type PauseUnitsArgs struct {
+	Entities []Entity `json:"entities"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
 // AddApplicationUnitsResults holds the names of the units added by the
 // AddUnits call.
 type AddApplicationUnitsResults struct {
@@ -606,6 +612,7 @@ type ContainerConfig struct {
 	AptMirror                  string                 `json:"apt-mirror"`
 	CloudInitUserData          map[string]interface{} `json:"cloudinit-userdata,omitempty"`
 	ContainerInheritProperties string                 `json:"container-inherit-properties,omitempty"`
+	PreferredAddressFamily     string                 `json:"preferred-address-family,omitempty"`
 	*UpdateBehavior
 }
 
@@ -897,6 +904,14 @@ type BundleChangesParams struct {
 	BundleURL      string `json:"bundleURL"`
 }
 
+// ExportBundleParams holds parameters for making Bundle.ExportBundle calls.
+type ExportBundleParams struct {
+	// SplitOverlay moves environment specific values, such as machines,
+	// placement directives and trust, out of the base bundle and into
+	// the overlay document.
+	SplitOverlay bool `json:"split-overlay,omitempty"`
+}
+
 // BundleChangesResults holds results of the Bundle.GetChanges call.
 type BundleChangesResults struct {
 	// Changes holds the list of changes required to deploy the bundle.
@@ -1205,11 +1220,98 @@ type Generation struct {
 	// Created is the user who created the generation.
 	CreatedBy string `json:"created-by"`
 
+	// PromotionPolicy, if set, describes how the generation
+	// is automatically committed or aborted.
+	PromotionPolicy *BranchPromotionPolicy `json:"promotion-policy,omitempty"`
+
 	// Applications holds the collection of application changes
 	// made under this generation.
 	Applications []GenerationApplication `json:"applications"`
 }
 
+// BranchPromotionPolicy describes the conditions under which
+// an in-flight branch is automatically committed or aborted.
+type BranchPromotionPolicy struct {
+	// HealthyFor is the duration for which all tracking units must be
+	// active before the branch is committed.
+	HealthyFor time.Duration `json:"healthy-for,omitempty"`
+
+	// ActionName is the name of an action that must complete successfully
+	// on all tracking units before the branch is committed.
+	ActionName string `json:"action,omitempty"`
+
+	// OnError is either "abort" or "untrack", indicating what to do when a
+	// tracking unit goes into an error state.
+	OnError string `json:"on-error"`
+}
+
+// BranchPromotionPolicyArg identifies an in-flight branch and the
+// promotion policy to attach to it. A nil policy removes any existing one.
+type BranchPromotionPolicyArg struct {
+	BranchName string                 `json:"branch"`
+	Policy     *BranchPromotionPolicy `json:"policy,omitempty"`
+}
+
+// BranchPromotionUnit describes the state of a unit tracking a branch,
+// for the purposes of evaluating the branch's promotion policy.
+type BranchPromotionUnit struct {
+	UnitName string `json:"unit"`
+
+	// WorkloadStatus and WorkloadSince describe the
+	// current workload status of the unit.
+	WorkloadStatus string     `json:"workload-status"`
+	WorkloadSince  *time.Time `json:"workload-since,omitempty"`
+
+	// AgentStatus is the current status of the unit agent.
+	AgentStatus string `json:"agent-status"`
+
+	// ActionStatus is the status of the most recent run on this unit
+	// of the action named by the branch's promotion policy.
+	// It is empty if there is no such action.
+	ActionStatus string `json:"action-status,omitempty"`
+}
+
+// BranchPromotionInfo describes an in-flight branch
+// with a promotion policy and the units tracking it.
+type BranchPromotionInfo struct {
+	BranchName string                `json:"branch"`
+	Policy     BranchPromotionPolicy `json:"policy"`
+	Units      []BranchPromotionUnit `json:"units"`
+
+	// Created is when the branch was created, and PolicySet
+	// is when its promotion policy was set.
+	Created   time.Time `json:"created"`
+	PolicySet time.Time `json:"policy-set"`
+}
+
+// BranchPromotionInfoResults holds the results of a call to
+// BranchPromoter.BranchPromotionInfo.
+type BranchPromotionInfoResults struct {
+	Results []BranchPromotionInfo `json:"results"`
+	Error   *Error                `json:"error,omitempty"`
+}
+
+// BranchPromotionDecision describes an action taken by
+// a branch promotion policy for an in-flight branch.
+type BranchPromotionDecision struct {
+	BranchName string `json:"branch"`
+
+	// Decision is one of "commit", "abort" or "untrack".
+	Decision string `json:"decision"`
+
+	// Units are the units that should stop tracking the branch.
+	// It is only relevant to "untrack" decisions.
+	Units []string `json:"units,omitempty"`
+
+	// Reason describes why the decision was made.
+	Reason string `json:"reason"`
+}
+
+// BranchPromotionDecisions holds a collection of branch promotion decisions.
+type BranchPromotionDecisions struct {
+	Decisions []BranchPromotionDecision `json:"decisions"`
+}
+
 // GenerationResults transports a collection of generation details.
 type GenerationResults struct {
 	// Generations holds the details of the requested generations.
@@ -15,6 +15,14 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+
+	// MaxRetries, if not zero, is the number of times a failed hook
+	// is retried before retries stop.
+	MaxRetries int `json:"max-retries,omitempty"`
+
+	// RetryHooks, if not empty, holds the patterns matching the names
+	// of the hooks that are retried.
+	RetryHooks []string `json:"retry-hooks,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
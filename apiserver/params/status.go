@@ -200,11 +200,21 @@ type UnitStatus struct {
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
 
+	// Paused, if not nil, describes why the unit's hooks are paused.
+	Paused *UnitPauseStatus `json:"paused,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
 }
 
+// UnitPauseStatus holds who paused a unit's hooks, why, and when.
+type UnitPauseStatus struct {
+	By     string     `json:"by"`
+	Reason string     `json:"reason,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+}
+
 // RelationStatus holds status info about a relation.
 type RelationStatus struct {
 	Id        int              `json:"id"`
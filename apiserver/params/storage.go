@@ -98,6 +98,57 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the attached volume or filesystem in MiB,
+	// as seen by the host, if known.
+	Size uint64 `json:"size,omitempty"`
+
+	// MoveTarget, if non-empty, is the location of the storage
+	// that the attachment's contents are being moved to.
+	MoveTarget string `json:"move-target,omitempty"`
+
+	// MoveCopied is the number of bytes of the attachment's contents
+	// recorded as copied to MoveTarget.
+	MoveCopied uint64 `json:"move-copied,omitempty"`
+}
+
+// StorageMoveCompletions contains the storage attachments whose
+// moves to another storage pool have been completed by their units.
+type StorageMoveCompletions struct {
+	Completions []StorageMoveCompletion `json:"completions"`
+}
+
+// StorageMoveCompletion identifies a storage attachment whose contents
+// have been copied to the storage that it is being moved to.
+type StorageMoveCompletion struct {
+	StorageTag string `json:"storage-tag"`
+	UnitTag    string `json:"unit-tag"`
+
+	// Target is the location of the storage that the storage
+	// attachment's contents were copied to.
+	Target string `json:"target"`
+}
+
+// StorageMoveProgresses contains the progress of units in copying
+// storage contents to the storage that it is being moved to.
+type StorageMoveProgresses struct {
+	Progress []StorageMoveProgress `json:"progress"`
+}
+
+// StorageMoveProgress records the progress of a unit in copying the
+// contents of a storage attachment to the storage that it is being
+// moved to.
+type StorageMoveProgress struct {
+	StorageTag string `json:"storage-tag"`
+	UnitTag    string `json:"unit-tag"`
+
+	// Target is the location of the storage that the storage
+	// attachment's contents are being copied to.
+	Target string `json:"target"`
+
+	// Copied is the number of bytes of the storage attachment's
+	// contents that have been copied to Target.
+	Copied uint64 `json:"copied"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -258,6 +309,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -274,6 +326,35 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// VolumeTag is the tag of the volume to resize.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Size is the size, in MiB, that the volume should be grown to.
+	Size uint64 `json:"size"`
+}
+
+// StorageSize records the size of a volume or filesystem.
+type StorageSize struct {
+	// Tag is the tag of the volume or filesystem.
+	Tag string `json:"tag"`
+
+	// Size is the size of the volume or filesystem in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StorageSizes holds the sizes of multiple volumes or filesystems.
+type StorageSizes struct {
+	Sizes []StorageSize `json:"sizes"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -355,6 +436,17 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParamsResult holds parameters for resizing a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds parameters for resizing multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -419,6 +511,7 @@ type FilesystemParams struct {
 	Attributes    map[string]interface{}      `json:"attributes,omitempty"`
 	Tags          map[string]string           `json:"tags,omitempty"`
 	Attachment    *FilesystemAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId    string                      `json:"snapshot-id,omitempty"`
 }
 
 // RemoveFilesystemParams holds the parameters for destroying or releasing
@@ -534,6 +627,10 @@ type StorageDetails struct {
 	// Attachments contains a mapping from unit tag to
 	// storage attachment details.
 	Attachments map[string]StorageAttachmentDetails `json:"attachments,omitempty"`
+
+	// MovingTo, if non-empty, is the name of the storage pool
+	// that the storage is being moved to.
+	MovingTo string `json:"moving-to,omitempty"`
 }
 
 // StorageFilter holds filter terms for listing storage details.
@@ -589,6 +686,12 @@ type StorageAttachmentDetails struct {
 	// Juju controllers older than 2.2 do not populate this
 	// field, so it may be omitted.
 	Life Life `json:"life,omitempty"`
+
+	// UsedSize and FreeSize are the used and free capacity, in MiB,
+	// of the attached storage, as last reported by the machine agent.
+	// They are omitted if the usage is not known.
+	UsedSize uint64 `json:"used-size,omitempty"`
+	FreeSize uint64 `json:"free-size,omitempty"`
 }
 
 // StoragePool holds data for a pool instance.
@@ -697,6 +800,10 @@ type VolumeDetails struct {
 	// Status contains the status of the volume.
 	Status EntityStatus `json:"status"`
 
+	// Encrypted reports whether the volume is encrypted at rest
+	// by Juju.
+	Encrypted bool `json:"encrypted,omitempty"`
+
 	// MachineAttachments contains a mapping from
 	// machine tag to volume attachment information.
 	MachineAttachments map[string]VolumeAttachmentDetails `json:"machine-attachments,omitempty"`
@@ -842,6 +949,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count *uint64 `json:"count,omitempty"`
+
+	// SnapshotId is the storage provider's ID of a volume snapshot
+	// from which to create the storage instance.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // StorageAddParams holds storage details to add to a unit dynamically.
@@ -933,6 +1044,113 @@ type ImportStorageDetails struct {
 	StorageTag string `json:"storage-tag"`
 }
 
+// CreateStorageSnapshotsArgs contains the arguments for taking snapshots
+// of storage instances.
+type CreateStorageSnapshotsArgs struct {
+	Storage []CreateStorageSnapshotArg `json:"storage"`
+}
+
+// CreateStorageSnapshotArg identifies a storage instance to snapshot.
+type CreateStorageSnapshotArg struct {
+	// StorageTag is the tag of the storage instance whose volume
+	// is to be snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// Description is recorded with the snapshot, if the storage
+	// provider supports it.
+	Description string `json:"description,omitempty"`
+}
+
+// StorageSnapshot describes a snapshot of the volume backing a
+// storage instance.
+type StorageSnapshot struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// StorageTag is the tag of the storage instance that was snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Pool is the storage pool of the snapshotted volume. Storage
+	// created from the snapshot must use a pool with the same
+	// storage provider.
+	Pool string `json:"pool"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Status is the provider-specific status of the snapshot.
+	Status string `json:"status,omitempty"`
+
+	// Description is the description given to the snapshot.
+	Description string `json:"description,omitempty"`
+
+	// Created is when the snapshot was taken, if known.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// StorageSnapshotResults contains the results of taking snapshots
+// of storage instances.
+type StorageSnapshotResults struct {
+	Results []StorageSnapshotResult `json:"results"`
+}
+
+// StorageSnapshotResult contains the result of taking a snapshot
+// of a storage instance.
+type StorageSnapshotResult struct {
+	Result *StorageSnapshot `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// StorageSnapshotsResults contains the results of listing the
+// snapshots of storage instances.
+type StorageSnapshotsResults struct {
+	Results []StorageSnapshotsResult `json:"results"`
+}
+
+// StorageSnapshotsResult contains the snapshots of a storage instance.
+type StorageSnapshotsResult struct {
+	Result []StorageSnapshot `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// ResizeStorageArgs contains arguments for resizing storage instances.
+type ResizeStorageArgs struct {
+	Storage []ResizeStorageArg `json:"storage"`
+}
+
+// ResizeStorageArg identifies a storage instance to resize, and the
+// size to grow it to.
+type ResizeStorageArg struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// MoveStorageArgs contains arguments for moving storage instances
+// to other storage pools.
+type MoveStorageArgs struct {
+	Storage []MoveStorageArg `json:"storage"`
+}
+
+// MoveStorageArg identifies a storage instance to move, and the
+// storage pool to move it to.
+type MoveStorageArg struct {
+	// StorageTag is the tag of the storage instance to move.
+	StorageTag string `json:"storage-tag"`
+
+	// Pool is the name of the storage pool to move the storage
+	// instance to.
+	Pool string `json:"pool"`
+}
+
 // AddStorageResults contains the results of adding storage to units.
 type AddStorageResults struct {
 	Results []AddStorageResult `json:"results"`
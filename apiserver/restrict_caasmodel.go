@@ -15,11 +15,13 @@ import (
 var commonModelFacadeNames = set.NewStrings(
 	"Action",
 	"ActionPruner",
+	"ActionScheduler",
 	"AllWatcher",
 	"Agent",
 	"Annotations",
 	"Application",
 	"Block",
+	"BranchPromoter",
 	"CharmRevisionUpdater",
 	"Charms",
 	"Cleaner",
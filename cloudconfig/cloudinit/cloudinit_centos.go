@@ -13,6 +13,7 @@ import (
 	"github.com/juju/packaging/config"
 	"gopkg.in/yaml.v2"
 
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
 
@@ -260,6 +261,6 @@ func (cfg *centOSCloudConfig) updateProxySettings(PackageManagerProxyConfig) err
 
 // AddNetworkConfig is defined on the NetworkingConfig interface.
 // TODO(wpk) This has to be implemented for CentOS on VSphere to work properly!
-func (cfg *centOSCloudConfig) AddNetworkConfig(interfaces []network.InterfaceInfo) error {
+func (cfg *centOSCloudConfig) AddNetworkConfig(interfaces []network.InterfaceInfo, family corenetwork.AddressFamilyPreference) error {
 	return nil
 }
@@ -7,6 +7,7 @@ package cloudinit
 import (
 	"github.com/juju/packaging"
 
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
 
@@ -123,6 +124,6 @@ func (cfg *windowsCloudConfig) updateProxySettings(PackageManagerProxyConfig) er
 }
 
 // AddNetworkConfig is defined on the NetworkingConfig interface.
-func (cfg *windowsCloudConfig) AddNetworkConfig(interfaces []network.InterfaceInfo) error {
+func (cfg *windowsCloudConfig) AddNetworkConfig(interfaces []network.InterfaceInfo, family corenetwork.AddressFamilyPreference) error {
 	return nil
 }
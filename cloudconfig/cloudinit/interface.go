@@ -18,6 +18,7 @@ import (
 	"github.com/juju/proxy"
 	"github.com/juju/utils/shell"
 
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
 
@@ -414,8 +415,9 @@ type HostnameConfig interface {
 
 // NetworkingConfig is the interface for managing configuration of network
 type NetworkingConfig interface {
-	// AddNetworkConfig adds network config from interfaces to the container.
-	AddNetworkConfig(interfaces []network.InterfaceInfo) error
+	// AddNetworkConfig adds network config from interfaces to the container,
+	// configured for the given preferred address family.
+	AddNetworkConfig(interfaces []network.InterfaceInfo, family corenetwork.AddressFamilyPreference) error
 }
 
 // New returns a new Config with no options set.
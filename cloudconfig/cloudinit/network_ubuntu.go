@@ -137,8 +137,9 @@ func GenerateENITemplate(interfaces []network.InterfaceInfo) (string, error) {
 }
 
 // GenerateNetplan renders a netplan file for one or more network
-// interfaces, using the given non-empty list of interfaces.
-func GenerateNetplan(interfaces []network.InterfaceInfo) (string, error) {
+// interfaces, using the given non-empty list of interfaces, configured
+// for the given preferred address family.
+func GenerateNetplan(interfaces []network.InterfaceInfo, family corenetwork.AddressFamilyPreference) (string, error) {
 	if len(interfaces) == 0 {
 		return "", errors.Errorf("missing container network config")
 	}
@@ -148,12 +149,11 @@ func GenerateNetplan(interfaces []network.InterfaceInfo) (string, error) {
 	netPlan.Network.Version = 2
 	for _, info := range interfaces {
 		var iface netplan.Ethernet
-		if cidr := info.CIDRAddress(); cidr != "" {
+		cidr := info.CIDRAddress()
+		if cidr != "" {
 			iface.Addresses = append(iface.Addresses, cidr)
-		} else if info.ConfigType == network.ConfigDHCP {
-			t := true
-			iface.DHCP4 = &t
 		}
+		iface.SetAddressFamily(family, cidr == "" && info.ConfigType == network.ConfigDHCP)
 
 		for _, dns := range info.DNSServers {
 			iface.Nameservers.Addresses = append(iface.Nameservers.Addresses, dns.Value)
@@ -296,14 +296,15 @@ func PrepareNetworkConfigFromInterfaces(interfaces []network.InterfaceInfo) *Pre
 
 // AddNetworkConfig adds configuration scripts for specified interfaces
 // to cloudconfig - using boot textfiles and boot commands. It currently
-// supports e/n/i and netplan.
-func (cfg *ubuntuCloudConfig) AddNetworkConfig(interfaces []network.InterfaceInfo) error {
+// supports e/n/i and netplan; only the netplan configuration honours the
+// preferred address family.
+func (cfg *ubuntuCloudConfig) AddNetworkConfig(interfaces []network.InterfaceInfo, family corenetwork.AddressFamilyPreference) error {
 	if len(interfaces) != 0 {
 		eni, err := GenerateENITemplate(interfaces)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		netPlan, err := GenerateNetplan(interfaces)
+		netPlan, err := GenerateNetplan(interfaces, family)
 		if err != nil {
 			return errors.Trace(err)
 		}
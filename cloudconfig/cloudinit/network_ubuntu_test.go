@@ -422,28 +422,45 @@ func (s *NetworkUbuntuSuite) TestGenerateENIConfig(c *gc.C) {
 }
 
 func (s *NetworkUbuntuSuite) TestGenerateNetplan(c *gc.C) {
-	data, err := cloudinit.GenerateNetplan(nil)
+	data, err := cloudinit.GenerateNetplan(nil, corenetwork.PreferIPv4)
 	c.Assert(err, gc.ErrorMatches, "missing container network config")
 	c.Assert(data, gc.Equals, "")
 
 	netConfig := container.BridgeNetworkConfig("foo", 0, nil)
-	data, err = cloudinit.GenerateNetplan(netConfig.Interfaces)
+	data, err = cloudinit.GenerateNetplan(netConfig.Interfaces, corenetwork.PreferIPv4)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(data, gc.Equals, s.expectedBaseNetplan)
 
 	// Test with all interface types.
 	netConfig = container.BridgeNetworkConfig("foo", 0, s.fakeInterfaces)
-	data, err = cloudinit.GenerateNetplan(netConfig.Interfaces)
+	data, err = cloudinit.GenerateNetplan(netConfig.Interfaces, corenetwork.PreferIPv4)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(data, gc.Equals, s.expectedFullNetplan)
 }
 
+func (s *NetworkUbuntuSuite) TestGenerateNetplanDualStack(c *gc.C) {
+	netConfig := container.BridgeNetworkConfig("foo", 0, nil)
+	data, err := cloudinit.GenerateNetplan(netConfig.Interfaces, corenetwork.DualStack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, gc.Equals, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      match:
+        name: eth0
+      accept-ra: true
+      dhcp4: true
+      dhcp6: true
+`[1:])
+}
+
 func (s *NetworkUbuntuSuite) TestAddNetworkConfigSampleConfig(c *gc.C) {
 	netConfig := container.BridgeNetworkConfig("foo", 0, s.fakeInterfaces)
 	cloudConf, err := cloudinit.New("xenial")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cloudConf, gc.NotNil)
-	err = cloudConf.AddNetworkConfig(netConfig.Interfaces)
+	err = cloudConf.AddNetworkConfig(netConfig.Interfaces, corenetwork.PreferIPv4)
 	c.Assert(err, jc.ErrorIsNil)
 
 	expected := s.expectedSampleConfigHeader
@@ -458,7 +475,7 @@ func (s *NetworkUbuntuSuite) TestAddNetworkConfigFallbackConfig(c *gc.C) {
 	cloudConf, err := cloudinit.New("xenial")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cloudConf, gc.NotNil)
-	err = cloudConf.AddNetworkConfig(netConfig.Interfaces)
+	err = cloudConf.AddNetworkConfig(netConfig.Interfaces, corenetwork.PreferIPv4)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cloudConf, gc.NotNil)
 	expected := s.expectedSampleConfigHeader
@@ -62,7 +62,7 @@ func CloudInitUserData(
 	if networkConfig != nil {
 		interfaces = networkConfig.Interfaces
 	}
-	err = cloudConfig.AddNetworkConfig(interfaces)
+	err = cloudConfig.AddNetworkConfig(interfaces, instanceConfig.PreferredAddressFamily)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
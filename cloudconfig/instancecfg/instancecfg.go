@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/tags"
@@ -186,6 +187,10 @@ type InstanceConfig struct {
 	// #1269921.
 	NetBondReconfigureDelay int
 
+	// PreferredAddressFamily is the model's preferred IP address family,
+	// which the instance's network configuration honours.
+	PreferredAddressFamily corenetwork.AddressFamilyPreference
+
 	// Profiles is a slice of (lxd) profile names to be used by a container
 	Profiles []string
 }
@@ -950,6 +955,7 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 	); err != nil {
 		return errors.Trace(err)
 	}
+	icfg.PreferredAddressFamily = cfg.PreferredAddressFamily()
 	if icfg.Controller != nil {
 		// Add NUMACTL preference. Needed to work for both bootstrap and high availability
 		// Only makes sense for controller
@@ -60,6 +60,28 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// EnqueueOperation queues up an action to be run on a number of
+	// units as a single operation.
+	EnqueueOperation(params.RunOperationArgs) (params.OperationResult, error)
+
+	// Operations fetches operations, and the actions they have started,
+	// by id.
+	Operations(params.OperationIDs) (params.OperationResults, error)
+
+	// StreamActionOutput follows the messages and output of the action
+	// with the given id until it finishes.
+	StreamActionOutput(id string) (action.ActionOutputStream, error)
+
+	// AddActionSchedules adds schedules on which the controller
+	// runs actions.
+	AddActionSchedules(params.ActionScheduleArgs) (params.ErrorResults, error)
+
+	// ActionSchedules returns the action schedules in the model.
+	ActionSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveActionSchedules removes action schedules by name.
+	RemoveActionSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -30,6 +30,12 @@ const leaderSnippet = "(" + names.ApplicationSnippet + ")/leader"
 
 var validLeader = regexp.MustCompile("^" + leaderSnippet + "$")
 
+// allSnippet is a regular expression for unit ID-like syntax that is used
+// to indicate every unit of an application.
+const allSnippet = "(" + names.ApplicationSnippet + ")/all"
+
+var validAll = regexp.MustCompile("^" + allSnippet + "$")
+
 // nameRule describes the name format of an action or keyName must match to be valid.
 var nameRule = charm.GetActionNameRule()
 
@@ -49,6 +55,9 @@ type callCommand struct {
 	parseStrings  bool
 	background    bool
 	maxWait       time.Duration
+	parallel      int
+	stopOnFailure bool
+	leaderLast    bool
 	out           cmd.Output
 	args          [][]string
 }
@@ -62,6 +71,8 @@ To queue an action to be run in the background without waiting for it to finish,
 use the --background option.
 
 To set the maximum time to wait for an action to complete, use the --max-wait option.
+While waiting for an action on a single unit, the messages it logs and the
+lines it writes to stdout and stderr are shown as they happen.
 
 By default, the output of a single action will just be that action's stdout.
 For multiple actions, each action stdout is printed with the action id.
@@ -69,10 +80,20 @@ To see more detailed information about run timings etc, use --format yaml.
 
 Valid unit identifiers are: 
   a standard unit ID, such as mysql/0 or;
-  leader syntax of the form <application>/leader, such as mysql/leader.
+  leader syntax of the form <application>/leader, such as mysql/leader or;
+  all syntax of the form <application>/all, such as mysql/all.
 
 If the leader syntax is used, the leader unit for the application will be
-resolved before the action is enqueued.
+resolved before the action is enqueued. The all syntax runs the action on
+every unit of the application.
+
+Using the all syntax, or any of the --parallel, --stop-on-failure or
+--leader-last options, runs the action on the units as a single operation
+whose progress can be followed with 'juju show-operation <ID>'. The
+--parallel option limits how many units run the action at once, and
+--stop-on-failure stops the action being started on further units once it
+has failed on one. The --leader-last option runs the action on the
+application leader only once it has finished on every other unit.
 
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
@@ -93,6 +114,7 @@ Examples:
     juju call mysql/3 backup --format yaml
     juju call mysql/3 backup
     juju call mysql/leader backup
+    juju call mysql/all backup --parallel 2 --stop-on-failure --leader-last
     juju show-operation <ID>
     juju call mysql/3 backup --params parameters.yml
     juju call mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
@@ -114,6 +136,9 @@ func (c *callCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.BoolVar(&c.background, "background", false, "Run the action in the background")
 	f.DurationVar(&c.maxWait, "max-wait", 0, "Maximum wait time for an action to complete")
+	f.IntVar(&c.parallel, "parallel", 0, "Maximum number of units to run the action on at once")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "Stop starting the action on further units once it fails on one")
+	f.BoolVar(&c.leaderLast, "leader-last", false, "Run the action on the application leader after every other unit")
 }
 
 func (c *callCommand) Info() *cmd.Info {
@@ -128,7 +153,7 @@ func (c *callCommand) Info() *cmd.Info {
 // Init gets the unit tag(s), action name and action arguments.
 func (c *callCommand) Init(args []string) (err error) {
 	for _, arg := range args {
-		if names.IsValidUnit(arg) || validLeader.MatchString(arg) {
+		if names.IsValidUnit(arg) || validLeader.MatchString(arg) || validAll.MatchString(arg) {
 			c.unitReceivers = append(c.unitReceivers, arg)
 		} else if nameRule.MatchString(arg) {
 			c.actionName = arg
@@ -150,6 +175,9 @@ func (c *callCommand) Init(args []string) (err error) {
 	if !c.background && c.maxWait == 0 {
 		c.maxWait = 60 * time.Second
 	}
+	if c.parallel < 0 {
+		return errors.Errorf("invalid --parallel value %d", c.parallel)
+	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
@@ -229,6 +257,10 @@ func (c *callCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.isOperation() {
+		return c.runOperation(ctx, actionParams)
+	}
+
 	actions := make([]params.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -291,21 +323,20 @@ func (c *callCommand) Run(ctx *cmd.Context) error {
 		return nil
 	}
 
-	var wait *time.Timer
-	if c.maxWait < 0 {
-		// Indefinite wait. Discard the tick.
-		wait = time.NewTimer(0 * time.Second)
-		_ = <-wait.C
-	} else {
-		wait = time.NewTimer(c.maxWait)
-	}
-
+	wait := c.waitTimer()
 	for _, result := range results.Results {
 		tag, err := names.ParseActionTag(result.Action.Tag)
 		if err != nil {
 			return err
 		}
+		// The messages and output of a single action are shown as it
+		// runs.
+		var follower *actionFollower
+		if len(results.Results) == 1 {
+			follower = followAction(c.api, tag.Id(), ctx.Stderr)
+		}
 		result, err = GetActionResult(c.api, tag.Id(), wait)
+		follower.Stop(err == nil)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -321,6 +352,93 @@ func (c *callCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, info)
 }
 
+// isOperation reports whether the action should be run as a single
+// operation across its units.
+func (c *callCommand) isOperation() bool {
+	if c.parallel > 0 || c.stopOnFailure || c.leaderLast {
+		return true
+	}
+	for _, unitReceiver := range c.unitReceivers {
+		if validAll.MatchString(unitReceiver) {
+			return true
+		}
+	}
+	return false
+}
+
+// runOperation enqueues the action on the receivers as a single
+// operation, and waits for it to finish unless running in the
+// background.
+func (c *callCommand) runOperation(ctx *cmd.Context, actionParams map[string]interface{}) error {
+	if c.api.BestAPIVersion() < 5 {
+		return errors.New("running an action as an operation is unsupported by this API" +
+			"\neither upgrade your controller, or specify units without --parallel," +
+			" --stop-on-failure, --leader-last or the <application>/all syntax")
+	}
+	receivers := make([]string, len(c.unitReceivers))
+	for i, unitReceiver := range c.unitReceivers {
+		switch {
+		case validAll.MatchString(unitReceiver):
+			receivers[i] = names.NewApplicationTag(strings.TrimSuffix(unitReceiver, "/all")).String()
+		case validLeader.MatchString(unitReceiver):
+			receivers[i] = unitReceiver
+		default:
+			receivers[i] = names.NewUnitTag(unitReceiver).String()
+		}
+	}
+	result, err := c.api.EnqueueOperation(params.RunOperationArgs{
+		Receivers:     receivers,
+		Name:          c.actionName,
+		Parameters:    actionParams,
+		Parallel:      c.parallel,
+		StopOnFailure: c.stopOnFailure,
+		LeaderLast:    c.leaderLast,
+	})
+	if err != nil {
+		return err
+	}
+	// Units on which the action is not valid are left out of the
+	// operation.
+	for _, action := range result.Actions {
+		if action.Error == nil || action.Action == nil {
+			continue
+		}
+		receiver := action.Action.Receiver
+		if tag, err := names.ParseUnitTag(receiver); err == nil {
+			receiver = tag.Id()
+		}
+		ctx.Warningf("not running %s on %s: %v", c.actionName, receiver, action.Error)
+	}
+
+	if c.background {
+		ctx.Infof("Scheduled Operation %s", result.OperationID)
+		ctx.Infof("Check status with 'juju show-operation %s'", result.OperationID)
+		return nil
+	}
+	ctx.Infof("Running Operation %s", result.OperationID)
+
+	result, err = GetOperationResult(c.api, result.OperationID, c.waitTimer())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Message != "" {
+		ctx.Infof("Operation %s %s: %s", result.OperationID, result.Status, result.Message)
+	}
+	return c.out.Write(ctx, formatOperationActions(result.Actions))
+}
+
+// waitTimer returns a timer which fires once the maximum wait time has
+// passed, or never if the wait is indefinite.
+func (c *callCommand) waitTimer() *time.Timer {
+	if c.maxWait < 0 {
+		// Indefinite wait. Discard the tick.
+		wait := time.NewTimer(0 * time.Second)
+		_ = <-wait.C
+		return wait
+	}
+	return time.NewTimer(c.maxWait)
+}
+
 // filteredOutputKeys are those we don't want to display as part of the
 // results map for plain output.
 var filteredOutputKeys = set.NewStrings("Code", "Stdout", "Stderr", "StdoutEncoding", "StderrEncoding")
@@ -209,6 +209,16 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		expectUnits:  []string{"mysql/leader"},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{},
+	}, {
+		should:       "work with all identifier",
+		args:         []string{"mysql/all", "valid-action-name", "--parallel", "2"},
+		expectUnits:  []string{"mysql/all"},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{},
+	}, {
+		should:      "fail with negative parallel",
+		args:        []string{"mysql/all", "valid-action-name", "--parallel", "-1"},
+		expectError: "invalid --parallel value -1",
 	}}
 
 	for i, t := range tests {
@@ -758,3 +768,140 @@ Check status with 'juju show-operation %s'`[1:],
 		}
 	}
 }
+
+func (s *CallSuite) TestRunOperationBackground(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		apiVersion:       5,
+		operationResults: []params.OperationResult{{OperationID: "1", Status: "running"}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewCallCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"mysql/all", "mysql/leader", "mysql/3", "some-action",
+		"--parallel", "2", "--stop-on-failure", "--leader-last", "--background")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.operationArgs, jc.DeepEquals, params.RunOperationArgs{
+		Receivers:     []string{"application-mysql", "mysql/leader", "unit-mysql-3"},
+		Name:          "some-action",
+		Parameters:    map[string]interface{}{},
+		Parallel:      2,
+		StopOnFailure: true,
+		LeaderLast:    true,
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Scheduled Operation 1
+Check status with 'juju show-operation 1'
+`[1:])
+}
+
+func (s *CallSuite) TestRunOperationReportsRejectedUnits(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		apiVersion: 5,
+		operationResults: []params.OperationResult{{
+			OperationID: "1",
+			Status:      "running",
+			Actions: []params.ActionResult{{
+				Action: &params.Action{Receiver: "unit-mysql-0", Name: "some-action"},
+			}, {
+				Action: &params.Action{Receiver: "unit-mysql-1", Name: "some-action"},
+				Error:  &params.Error{Message: `action "some-action" not defined on unit "mysql/1"`},
+			}},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewCallCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"mysql/all", "some-action", "--parallel", "2", "--background")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+WARNING not running some-action on mysql/1: action "some-action" not defined on unit "mysql/1"
+Scheduled Operation 1
+Check status with 'juju show-operation 1'
+`[1:])
+}
+
+func (s *CallSuite) TestRunOperationWaits(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		apiVersion: 5,
+		operationResults: []params.OperationResult{{
+			OperationID: "1",
+			Status:      "aborted",
+			Message:     `action on unit "mysql/0" failed`,
+			Actions: []params.ActionResult{{
+				Action: &params.Action{
+					Tag:      names.NewActionTag("mysql-1").String(),
+					Receiver: names.NewUnitTag(validUnitId).String(),
+				},
+				Status: "failed",
+				Output: map[string]interface{}{"outcome": "oops"},
+			}},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewCallCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"mysql/all", "some-action", "--stop-on-failure", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Running Operation 1
+Operation 1 aborted: action on unit "mysql/0" failed
+`[1:])
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  id: mysql-1
+  results:
+    outcome: oops
+  status: failed
+`[1:])
+}
+
+func (s *CallSuite) TestRunOperationUnsupported(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiVersion: 4}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewCallCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "mysql/all", "some-action")
+	c.Assert(err, gc.ErrorMatches, "running an action as an operation is unsupported by this API\n.*")
+}
+
+func (s *CallSuite) TestRunStreamsOutput(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		apiVersion:       5,
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status: "completed",
+		}},
+		streamRecords: []params.ActionStreamRecords{{
+			Messages: []params.ActionMessage{{Message: "working", Stream: "stdout"}},
+			Status:   "completed",
+			Done:     true,
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewCallCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, validUnitId, "some-action", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.streamedAction, gc.Equals, validActionId)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Running Operation `+validActionId+`
+working
+`[1:])
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  id: `+validActionId+`
+  status: completed
+`[1:])
+}
@@ -149,6 +149,24 @@ func NewRunActionCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Run
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunActionCommand{c}
 }
 
+func NewScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &scheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &schedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewUnscheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &unscheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// operationStatusRunning is the status of an operation which still has
+// actions queued, pending or running.
+const operationStatusRunning = "running"
+
+// validOperationId matches operation ids, which unlike action ids are
+// plain numbers.
+var validOperationId = regexp.MustCompile("^[0-9]+$")
+
+// GetOperationResult repeatedly fetches an operation until all of its
+// actions have finished, and then returns it. It waits for a maximum
+// of "wait" before returning with the latest operation status.
+func GetOperationResult(api APIClient, requestedId string, wait *time.Timer) (params.OperationResult, error) {
+	tick := time.NewTimer(2 * time.Second)
+	return operationTimerLoop(api, requestedId, wait, tick)
+}
+
+// operationTimerLoop loops indefinitely to query the given API, until
+// "wait" times out, using the "tick" timer to delay the API queries.
+func operationTimerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.OperationResult, error) {
+	for {
+		result, err := fetchOperation(api, requestedId)
+		if err != nil {
+			return result, err
+		}
+		if result.Status != operationStatusRunning {
+			return result, nil
+		}
+
+		// Block until a tick happens, or the timeout arrives.
+		select {
+		case _ = <-wait.C:
+			return result, errors.NewTimeout(nil, "timeout reached")
+		case _ = <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// fetchOperation queries the given API for the operation with the
+// given id.
+func fetchOperation(api APIClient, requestedId string) (params.OperationResult, error) {
+	none := params.OperationResult{}
+	results, err := api.Operations(params.OperationIDs{IDs: []string{requestedId}})
+	if err != nil {
+		return none, err
+	}
+	if len(results.Results) != 1 {
+		return none, errors.Errorf("expected 1 result for operation %s, got %d", requestedId, len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return none, result.Error
+	}
+	return result, nil
+}
+
+// FormatOperationResult converts the given OperationResult into a
+// map[string]interface{} for cmd.Output to write. The operation's
+// actions are keyed by unit, formatted as by FormatActionResult.
+func FormatOperationResult(result params.OperationResult) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":     result.OperationID,
+		"action": result.ActionName,
+		"status": result.Status,
+	}
+	if result.Message != "" {
+		response["message"] = result.Message
+	}
+	if result.Parallel > 0 {
+		response["parallel"] = result.Parallel
+	}
+	if result.StopOnFailure {
+		response["stop-on-failure"] = true
+	}
+	if len(result.Queued) > 0 {
+		response["queued"] = result.Queued
+	}
+	responseTiming := make(map[string]string)
+	if !result.Enqueued.IsZero() {
+		responseTiming["enqueued"] = result.Enqueued.String()
+	}
+	if !result.Completed.IsZero() {
+		responseTiming["completed"] = result.Completed.String()
+	}
+	if len(responseTiming) > 0 {
+		response["timing"] = responseTiming
+	}
+	response["units"] = formatOperationActions(result.Actions)
+	return response
+}
+
+// formatOperationActions formats an operation's actions keyed by the
+// unit they ran on.
+func formatOperationActions(actions []params.ActionResult) map[string]interface{} {
+	units := make(map[string]interface{}, len(actions))
+	for _, action := range actions {
+		if action.Action == nil {
+			continue
+		}
+		d := FormatActionResult(action)
+		if tag, err := names.ParseActionTag(action.Action.Tag); err == nil {
+			d["id"] = tag.Id()
+		}
+		receiver := action.Action.Receiver
+		if tag, err := names.ParseUnitTag(receiver); err == nil {
+			receiver = tag.Id()
+		}
+		units[receiver] = d
+	}
+	return units
+}
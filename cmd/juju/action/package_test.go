@@ -15,6 +15,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	actionapi "github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/jujuclient"
@@ -143,6 +144,13 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	operationArgs      params.RunOperationArgs
+	operationResults   []params.OperationResult
+	streamRecords      []params.ActionStreamRecords
+	streamedAction     string
+	scheduleArgs       params.ActionScheduleArgs
+	schedules          []params.ActionSchedule
+	removedSchedules   []string
 	apiVersion         int
 	apiErr             error
 }
@@ -248,3 +256,62 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) EnqueueOperation(args params.RunOperationArgs) (params.OperationResult, error) {
+	c.operationArgs = args
+	if len(c.operationResults) == 0 {
+		return params.OperationResult{}, c.apiErr
+	}
+	return c.operationResults[0], c.apiErr
+}
+
+func (c *fakeAPIClient) Operations(args params.OperationIDs) (params.OperationResults, error) {
+	var results params.OperationResults
+	for _, id := range args.IDs {
+		for _, op := range c.operationResults {
+			if op.OperationID == id {
+				results.Results = append(results.Results, op)
+			}
+		}
+	}
+	return results, c.apiErr
+}
+
+func (c *fakeAPIClient) StreamActionOutput(id string) (actionapi.ActionOutputStream, error) {
+	if c.streamRecords == nil {
+		return nil, errors.NotSupportedf("streaming")
+	}
+	c.streamedAction = id
+	return &fakeActionOutputStream{records: c.streamRecords}, nil
+}
+
+func (c *fakeAPIClient) AddActionSchedules(args params.ActionScheduleArgs) (params.ErrorResults, error) {
+	c.scheduleArgs = args
+	return params.ErrorResults{Results: make([]params.ErrorResult, len(args.Schedules))}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	c.removedSchedules = args.Names
+	return params.ErrorResults{Results: make([]params.ErrorResult, len(args.Names))}, c.apiErr
+}
+
+type fakeActionOutputStream struct {
+	records []params.ActionStreamRecords
+}
+
+func (s *fakeActionOutputStream) Next() (params.ActionStreamRecords, error) {
+	if len(s.records) == 0 {
+		return params.ActionStreamRecords{}, errors.New("stream closed")
+	}
+	next := s.records[0]
+	s.records = s.records[1:]
+	return next, nil
+}
+
+func (s *fakeActionOutputStream) Close() error {
+	return nil
+}
@@ -0,0 +1,398 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// checkSchedulesSupported returns an error if the controller
+// does not support action schedules.
+func checkSchedulesSupported(api APIClient) error {
+	if api.BestAPIVersion() < 6 {
+		return errors.New("action schedules are unsupported by this API\nupgrade your controller to manage them")
+	}
+	return nil
+}
+
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+// scheduleCommand adds a schedule on which the controller runs an
+// action against an application or unit.
+type scheduleCommand struct {
+	ActionCommandBase
+	name         string
+	receiver     names.Tag
+	actionName   string
+	cron         string
+	missedRuns   string
+	overlap      string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+}
+
+const scheduleDoc = `
+Add a schedule on which the controller runs an action against every unit of
+an application, or against a single unit. Each run of the schedule enqueues
+ordinary actions, which can be inspected with the other action commands.
+
+The schedule is a cron expression of the form
+"<minute> <hour> <day of month> <month> <day of week>", or one of the
+descriptors @hourly, @daily, @weekly, @monthly and @yearly. Schedules are
+evaluated in UTC unless the expression is prefixed with "TZ=<zone> ".
+
+The --missed-runs option controls what happens when runs were missed because
+the controller was unavailable: "skip" (the default) waits for the next
+scheduled run, and "run-once" runs the action once as soon as possible.
+
+The --overlap option controls what happens when a run comes due while the
+action enqueued by the previous run is still pending or running on a unit:
+"skip" (the default) leaves that unit out of the run, and "queue" enqueues
+the action behind the previous one.
+
+Params are given as for 'juju run-action', and are validated against the
+charm's action schema when the schedule is added.
+
+Examples:
+
+    juju schedule-action nightly-backup mysql backup --cron "0 2 * * *"
+    juju schedule-action rotate mysql/0 rotate-logs --cron @hourly --overlap queue
+    juju schedule-action weekly mysql backup --cron @weekly --missed-runs run-once out=weekly.tar.bz2
+
+See also:
+    schedules
+    unschedule-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.cron, "cron", "", "Cron expression on which to run the action")
+	f.StringVar(&c.missedRuns, "missed-runs", "skip", `What to do about missed runs: "skip" or "run-once"`)
+	f.StringVar(&c.overlap, "overlap", "skip", `What to do when a previous run is still in progress: "skip" or "queue"`)
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *scheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<schedule name> <application or unit> <action name> [key.key.key...=value]",
+		Purpose: "Run an action on a recurring schedule.",
+		Doc:     scheduleDoc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *scheduleCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("a schedule name, application or unit, and action name are required")
+	}
+	c.name = args[0]
+	switch receiver := args[1]; {
+	case names.IsValidUnit(receiver):
+		c.receiver = names.NewUnitTag(receiver)
+	case names.IsValidApplication(receiver):
+		c.receiver = names.NewApplicationTag(receiver)
+	default:
+		return errors.Errorf("invalid application or unit name %q", receiver)
+	}
+	c.actionName = args[2]
+	if !nameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	if c.cron == "" {
+		return errors.New("no schedule specified, use --cron")
+	}
+
+	c.args = make([][]string, 0)
+	for _, arg := range args[3:] {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		for _, key := range keySlice {
+			if valid := nameRule.MatchString(key); !valid {
+				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+					"and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		c.args = append(c.args, append(keySlice, thisArg[1]))
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if err := checkSchedulesSupported(api); err != nil {
+		return err
+	}
+
+	actionParams := map[string]interface{}{}
+	if c.paramsYAML.Path != "" {
+		b, err := c.paramsYAML.Read(ctx)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(b, &actionParams); err != nil {
+			return err
+		}
+	}
+	for _, argSlice := range c.args {
+		valueIndex := len(argSlice) - 1
+		value := interface{}(argSlice[valueIndex])
+		if !c.parseStrings {
+			if err := yaml.Unmarshal([]byte(argSlice[valueIndex]), &value); err != nil {
+				return err
+			}
+		}
+		addValueToMap(argSlice[:valueIndex], value, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return err
+	}
+	typedParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.New("params must contain a YAML map with string keys")
+	}
+
+	results, err := api.AddActionSchedules(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Name:            c.name,
+			Receiver:        c.receiver.String(),
+			ActionName:      c.actionName,
+			Parameters:      typedParams,
+			Cron:            c.cron,
+			MissedRunPolicy: c.missedRuns,
+			OverlapPolicy:   c.overlap,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if err := results.OneError(); err != nil {
+		return err
+	}
+	ctx.Infof("Scheduled action %q on %s as %q", c.actionName, c.receiver.Id(), c.name)
+	return nil
+}
+
+func NewSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&schedulesCommand{})
+}
+
+// schedulesCommand lists the action schedules in a model.
+type schedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const schedulesDoc = `
+List the schedules on which the controller runs actions, along with when
+each schedule next runs, when it last ran, and the actions it last enqueued.
+Times are shown in UTC.
+
+Examples:
+
+    juju schedules
+    juju schedules --format yaml
+
+See also:
+    schedule-action
+    unschedule-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *schedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *schedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedules",
+		Purpose: "List action schedules.",
+		Doc:     schedulesDoc,
+		Aliases: []string{"list-schedules"},
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *schedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *schedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if err := checkSchedulesSupported(api); err != nil {
+		return err
+	}
+
+	schedules, err := api.ActionSchedules()
+	if err != nil {
+		return err
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules to display.")
+		return nil
+	}
+	return c.out.Write(ctx, formatSchedules(schedules))
+}
+
+// scheduleInfo is the serialisation of an action schedule.
+type scheduleInfo struct {
+	Receiver    string                 `yaml:"receiver" json:"receiver"`
+	Action      string                 `yaml:"action" json:"action"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Cron        string                 `yaml:"cron" json:"cron"`
+	MissedRuns  string                 `yaml:"missed-runs" json:"missed-runs"`
+	Overlap     string                 `yaml:"overlap" json:"overlap"`
+	NextRun     string                 `yaml:"next-run" json:"next-run"`
+	LastRun     string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	LastActions map[string]string      `yaml:"last-actions,omitempty" json:"last-actions,omitempty"`
+	Message     string                 `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func formatSchedules(schedules []params.ActionSchedule) map[string]scheduleInfo {
+	result := make(map[string]scheduleInfo, len(schedules))
+	for _, s := range schedules {
+		info := scheduleInfo{
+			Receiver:    s.Receiver,
+			Action:      s.ActionName,
+			Parameters:  s.Parameters,
+			Cron:        s.Cron,
+			MissedRuns:  s.MissedRunPolicy,
+			Overlap:     s.OverlapPolicy,
+			NextRun:     common.FormatTime(&s.NextRun, true),
+			LastActions: s.LastActions,
+			Message:     s.LastMessage,
+		}
+		if tag, err := names.ParseTag(s.Receiver); err == nil {
+			info.Receiver = tag.Id()
+		}
+		if !s.LastRun.IsZero() {
+			info.LastRun = common.FormatTime(&s.LastRun, true)
+		}
+		result[s.Name] = info
+	}
+	return result
+}
+
+// printSchedulesTabular prints action schedules in tabular format.
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	var scheduleNames []string
+	for name := range schedules {
+		scheduleNames = append(scheduleNames, name)
+	}
+	naturalsort.Sort(scheduleNames)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "Name\tReceiver\tAction\tSchedule\tNext run\tLast run\tMessage\n")
+	for _, name := range scheduleNames {
+		s := schedules[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, s.Receiver, s.Action, s.Cron, s.NextRun, s.LastRun, s.Message)
+	}
+	return tw.Flush()
+}
+
+func NewUnscheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&unscheduleCommand{})
+}
+
+// unscheduleCommand removes action schedules.
+type unscheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const unscheduleDoc = `
+Remove action schedules, so that the controller no longer runs their actions.
+Actions already enqueued by a schedule are left to run.
+
+Examples:
+
+    juju unschedule-action nightly-backup
+
+See also:
+    schedule-action
+    schedules
+`
+
+// Info is part of the cmd.Command interface.
+func (c *unscheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "unschedule-action",
+		Args:    "<schedule name> [<schedule name> ...]",
+		Purpose: "Remove action schedules.",
+		Doc:     unscheduleDoc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *unscheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedules specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *unscheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if err := checkSchedulesSupported(api); err != nil {
+		return err
+	}
+
+	results, err := api.RemoveActionSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	return results.Combine()
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestScheduleInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"nightly", "mysql"},
+		err:  "a schedule name, application or unit, and action name are required",
+	}, {
+		args: []string{"nightly", "mysql", "backup"},
+		err:  "no schedule specified, use --cron",
+	}, {
+		args: []string{"nightly", "Bad", "backup", "--cron", "@daily"},
+		err:  `invalid application or unit name "Bad"`,
+	}, {
+		args: []string{"nightly", "mysql", "Backup", "--cron", "@daily"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"nightly", "mysql", "backup", "--cron", "@daily", "out"},
+		err:  `argument "out" must be of the form key.key.key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		s.patchAPIClient(&fakeAPIClient{apiVersion: 6})
+		_, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ScheduleSuite) TestSchedule(c *gc.C) {
+	client := &fakeAPIClient{apiVersion: 6}
+	s.patchAPIClient(client)
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store),
+		"nightly", "mysql", "backup", "--cron", "0 2 * * *", "--overlap", "queue", "out=nightly.tar.bz2", "file.kind=xz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Scheduled action \"backup\" on mysql as \"nightly\"\n")
+	c.Assert(client.scheduleArgs, jc.DeepEquals, params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Name:       "nightly",
+			Receiver:   "application-mysql",
+			ActionName: "backup",
+			Parameters: map[string]interface{}{
+				"out":  "nightly.tar.bz2",
+				"file": map[string]interface{}{"kind": "xz"},
+			},
+			Cron:            "0 2 * * *",
+			MissedRunPolicy: "skip",
+			OverlapPolicy:   "queue",
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestScheduleUnit(c *gc.C) {
+	client := &fakeAPIClient{apiVersion: 6}
+	s.patchAPIClient(client)
+	_, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store),
+		"hourly", "mysql/0", "rotate-logs", "--cron", "@hourly", "--missed-runs", "run-once")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.scheduleArgs.Schedules, gc.HasLen, 1)
+	c.Assert(client.scheduleArgs.Schedules[0].Receiver, gc.Equals, "unit-mysql-0")
+	c.Assert(client.scheduleArgs.Schedules[0].MissedRunPolicy, gc.Equals, "run-once")
+}
+
+func (s *ScheduleSuite) TestScheduleUnsupported(c *gc.C) {
+	s.patchAPIClient(&fakeAPIClient{apiVersion: 5})
+	_, err := cmdtesting.RunCommand(c, action.NewScheduleCommandForTest(s.store),
+		"nightly", "mysql", "backup", "--cron", "@daily")
+	c.Assert(err, gc.ErrorMatches, "action schedules are unsupported by this API\nupgrade your controller to manage them")
+}
+
+func (s *ScheduleSuite) TestSchedules(c *gc.C) {
+	s.patchAPIClient(&fakeAPIClient{
+		apiVersion: 6,
+		schedules: []params.ActionSchedule{{
+			Name:            "nightly",
+			Receiver:        "application-mysql",
+			ActionName:      "backup",
+			Cron:            "0 2 * * *",
+			MissedRunPolicy: "skip",
+			OverlapPolicy:   "skip",
+			NextRun:         time.Date(2020, 4, 2, 2, 0, 0, 0, time.UTC),
+			LastRun:         time.Date(2020, 4, 1, 2, 0, 0, 0, time.UTC),
+			LastActions:     map[string]string{"mysql/0": "2", "mysql/1": "3"},
+		}, {
+			Name:            "hourly",
+			Receiver:        "unit-mysql-0",
+			ActionName:      "rotate-logs",
+			Cron:            "@hourly",
+			MissedRunPolicy: "run-once",
+			OverlapPolicy:   "queue",
+			NextRun:         time.Date(2020, 4, 1, 13, 0, 0, 0, time.UTC),
+		}},
+	})
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name     Receiver  Action       Schedule   Next run              Last run              Message\n"+
+		"hourly   mysql/0   rotate-logs  @hourly    2020-04-01 13:00:00Z                        \n"+
+		"nightly  mysql     backup       0 2 * * *  2020-04-02 02:00:00Z  2020-04-01 02:00:00Z  \n")
+
+	ctx, err = cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+hourly:
+  receiver: mysql/0
+  action: rotate-logs
+  cron: '@hourly'
+  missed-runs: run-once
+  overlap: queue
+  next-run: 2020-04-01 13:00:00Z
+nightly:
+  receiver: mysql
+  action: backup
+  cron: 0 2 * * *
+  missed-runs: skip
+  overlap: skip
+  next-run: 2020-04-02 02:00:00Z
+  last-run: 2020-04-01 02:00:00Z
+  last-actions:
+    mysql/0: "2"
+    mysql/1: "3"
+`[1:])
+}
+
+func (s *ScheduleSuite) TestSchedulesNone(c *gc.C) {
+	s.patchAPIClient(&fakeAPIClient{apiVersion: 6})
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules to display.\n")
+}
+
+func (s *ScheduleSuite) TestUnschedule(c *gc.C) {
+	client := &fakeAPIClient{apiVersion: 6}
+	s.patchAPIClient(client)
+	_, err := cmdtesting.RunCommand(c, action.NewUnscheduleCommandForTest(s.store), "nightly", "hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.removedSchedules, jc.DeepEquals, []string{"nightly", "hourly"})
+
+	_, err = cmdtesting.RunCommand(c, action.NewUnscheduleCommandForTest(s.store))
+	c.Assert(err, gc.ErrorMatches, "no schedules specified")
+}
@@ -29,6 +29,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -40,6 +41,14 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the messages logged by a running action, and the lines it writes
+to stdout and stderr, as they happen, use the --watch option. The results
+are shown once the action has finished.
+
+If the ID is that of an operation started by running an action on a number
+of units at once, the operation's status is shown along with the results of
+each of its actions.
 `
 
 // Set up the output.
@@ -56,6 +65,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show the action's messages and output as it runs")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -124,6 +134,18 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
+	if validOperationId.MatchString(c.requestedId) && api.BestAPIVersion() >= 5 {
+		if c.watch {
+			return errors.New("--watch is not supported for operations")
+		}
+		return c.showOperation(ctx, api, wait)
+	}
+	if c.watch {
+		if err := c.watchAction(ctx, api); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	result, err := GetActionResult(api, c.requestedId, wait)
 	if err != nil {
 		return errors.Trace(err)
@@ -138,6 +160,42 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, info)
 }
 
+// watchAction writes the messages of the requested action as they are
+// logged, until the action finishes.
+func (c *showOutputCommand) watchAction(ctx *cmd.Context, api APIClient) error {
+	if api.BestAPIVersion() < 5 {
+		return errors.New("watching action output is unsupported by this API" +
+			"\nupgrade your controller, or use --wait")
+	}
+	tag, err := getActionTagByPrefix(api, c.requestedId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stream, err := api.StreamActionOutput(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stream.Close()
+	return writeActionMessages(stream, ctx.Stderr)
+}
+
+// showOperation writes the status of the requested operation, and the
+// results of its actions.
+func (c *showOutputCommand) showOperation(ctx *cmd.Context, api APIClient, wait *time.Timer) error {
+	// A running operation is still worth showing once the wait is over.
+	result, err := GetOperationResult(api, c.requestedId, wait)
+	if err != nil && !errors.IsTimeout(err) {
+		return errors.Trace(err)
+	}
+	if c.out.Name() != "plain" {
+		return c.out.Write(ctx, FormatOperationResult(result))
+	}
+	if result.Message != "" {
+		ctx.Infof("Operation %s %s: %s", result.OperationID, result.Status, result.Message)
+	}
+	return c.out.Write(ctx, formatOperationActions(result.Actions))
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/cmd/cmdtesting"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -365,3 +366,71 @@ func makeFakeClient(
 	}
 	return client
 }
+
+func (s *ShowOutputSuite) TestRunOperation(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 5,
+		operationResults: []params.OperationResult{{
+			OperationID: "3",
+			ActionName:  "backup",
+			Status:      "running",
+			Parallel:    1,
+			Queued:      []string{"mysql/1"},
+			Actions: []params.ActionResult{{
+				Action: &params.Action{
+					Tag:      names.NewActionTag("mysql-5").String(),
+					Receiver: names.NewUnitTag(validUnitId).String(),
+				},
+				Status: "running",
+			}},
+		}},
+	}
+	expected := `
+action: backup
+id: "3"
+parallel: 1
+queued:
+- mysql/1
+status: running
+units:
+  mysql/0:
+    id: mysql-5
+    status: running
+`[1:]
+	testRunHelper(c, s, client, "", expected, "yaml", "", "3", "-m")
+}
+
+func (s *ShowOutputSuite) TestRunWatch(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion:       5,
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+		actionResults:    []params.ActionResult{{Status: "completed"}},
+		streamRecords: []params.ActionStreamRecords{{
+			Messages: []params.ActionMessage{{
+				Timestamp: time.Date(2020, time.April, 1, 10, 0, 0, 0, time.Local),
+				Message:   "starting backup",
+			}},
+		}, {
+			Messages: []params.ActionMessage{{Message: "copied 10 files", Stream: "stdout"}},
+			Status:   "completed",
+			Done:     true,
+		}},
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch", "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Check(client.streamedAction, gc.Equals, validActionId)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "10:00:00 starting backup\ncopied 10 files\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "status: completed\n")
+}
+
+func (s *ShowOutputSuite) TestRunWatchUnsupported(c *gc.C) {
+	client := &fakeAPIClient{apiVersion: 4}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.ErrorMatches, "watching action output is unsupported by this API\nupgrade your controller, or use --wait")
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/action"
+)
+
+// streamFlushWait is how long to wait for the last of a finished
+// action's streamed messages before giving up on them.
+const streamFlushWait = 5 * time.Second
+
+// actionFollower writes the messages and output of a running action as
+// they are logged, while its result is waited for.
+type actionFollower struct {
+	stream action.ActionOutputStream
+	done   chan struct{}
+}
+
+// followAction starts writing the messages of the action with the
+// given id to w. It returns nil if the messages cannot be streamed,
+// in which case the action's result is simply polled for.
+func followAction(api APIClient, id string, w io.Writer) *actionFollower {
+	if api.BestAPIVersion() < 5 {
+		return nil
+	}
+	stream, err := api.StreamActionOutput(id)
+	if err != nil {
+		logger.Debugf("not streaming output of action %s: %v", id, err)
+		return nil
+	}
+	f := &actionFollower{stream: stream, done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		if err := writeActionMessages(stream, w); err != nil {
+			logger.Debugf("streaming output of action %s: %v", id, err)
+		}
+	}()
+	return f
+}
+
+// Stop waits a short time for any remaining messages of the action,
+// if it has finished, and then closes the stream.
+func (f *actionFollower) Stop(finished bool) {
+	if f == nil {
+		return
+	}
+	if finished {
+		select {
+		case <-f.done:
+		case <-time.After(streamFlushWait):
+		}
+	}
+	_ = f.stream.Close()
+	<-f.done
+}
+
+// writeActionMessages writes each message read from the stream to w,
+// until the stream reports that the action has finished. Lines written
+// by the action to stdout or stderr are written as they are; messages
+// logged with action-log are prefixed with the time they were logged.
+func writeActionMessages(stream action.ActionOutputStream, w io.Writer) error {
+	for {
+		records, err := stream.Next()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, m := range records.Messages {
+			if m.Stream != "" {
+				fmt.Fprintln(w, m.Message)
+				continue
+			}
+			fmt.Fprintf(w, "%s %s\n", m.Timestamp.Local().Format("15:04:05"), m.Message)
+		}
+		if records.Done {
+			return nil
+		}
+	}
+}
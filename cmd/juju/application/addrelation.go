@@ -212,6 +212,7 @@ func (c *addRelationCommand) maybeConsumeOffer(targetClient applicationAddRelati
 			Alias:         offerURL.Source,
 			Addrs:         consumeDetails.ControllerInfo.Addrs,
 			CACert:        consumeDetails.ControllerInfo.CACert,
+			RelayProxy:    consumeDetails.ControllerInfo.RelayProxy,
 		}
 	}
 	_, err = targetClient.Consume(arg)
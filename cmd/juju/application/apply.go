@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const applyDoc = `
+Apply converges the model to exactly match the given bundle. In addition
+to the changes made by "juju deploy", applications, relations, units and
+offers which are not declared in the bundle are removed before anything
+is added, endpoint bindings which differ from the bundle are updated, and
+config options the bundle does not set are reset to their defaults.
+Constraints and the number of units of CAAS applications are updated as
+for deploy.
+
+The full plan is printed before anything is changed. If the plan removes
+anything from the model, confirmation is required unless --prune is given.
+Running apply again once the model matches the bundle makes no changes, so
+it can safely be run repeatedly, for example from a CI pipeline.
+
+As with diff-bundle, existing machines are always considered when mapping
+bundle machines to the model. The map-machines option can be used to
+provide explicit mappings.
+
+Examples:
+    juju apply ./bundle.yaml
+    juju apply ./bundle.yaml --dry-run
+    juju apply ./bundle.yaml --overlay production.yaml --prune
+    juju apply canonical-kubernetes --channel edge --map-machines 3=4
+
+See also:
+    deploy
+    diff-bundle
+`
+
+// NewApplyCommand returns a command which converges the current model to
+// match a bundle.
+func NewApplyCommand() modelcmd.ModelCommand {
+	deployCmd := newDeployCommand()
+	deployCmd.reconcile = true
+	return modelcmd.Wrap(&applyCommand{DeployCommand: deployCmd})
+}
+
+// applyCommand is a deploy command restricted to bundles which also
+// removes anything from the model that the bundle does not declare.
+type applyCommand struct {
+	*DeployCommand
+}
+
+// Info is part of cmd.Command.
+func (c *applyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "apply",
+		Args:    "<bundle>",
+		Purpose: "Converges the model to match a bundle.",
+		Doc:     applyDoc,
+	})
+}
+
+// SetFlags is part of cmd.Command.
+func (c *applyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to use when getting the bundle from the charm store")
+	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what applying the bundle would do")
+	f.BoolVar(&c.prune, "prune", false, "Remove entities not in the bundle without prompting for confirmation")
+	f.BoolVar(&c.Trust, "trust", false, "Allows charms to run hooks that require access credentials")
+	f.BoolVar(&c.Force, "force", false, "Allow a bundle to be applied which bypasses checks such as supported series or LXD profile allow list")
+	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
+	c.flagSet = f
+}
+
+// Init is part of cmd.Command.
+func (c *applyCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no bundle specified")
+	case 1:
+		c.CharmOrBundle = args[0]
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	_, mapping, err := parseMachineMap(c.machineMap)
+	if err != nil {
+		return errors.Annotate(err, "error in --map-machines")
+	}
+	c.UseExisting = true
+	c.BundleMachines = mapping
+	return nil
+}
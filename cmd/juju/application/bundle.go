@@ -96,6 +96,12 @@ type bundleDeploySpec struct {
 	force  bool
 	trust  bool
 
+	// reconcile causes the model to be converged to exactly match the
+	// bundle, removing anything the bundle does not declare. Removals
+	// are only executed without confirmation when prune is set.
+	reconcile bool
+	prune     bool
+
 	bundleDataSource  charm.BundleDataSource
 	bundleDir         string
 	bundleURL         *charm.URL
@@ -160,6 +166,14 @@ func deployBundle(bundleData *charm.BundleData, spec bundleDeploySpec) (map[*cha
 	if err := h.getChanges(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if spec.reconcile {
+		if err := h.getReconcileChanges(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := h.confirmReconcileChanges(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	if err := h.handleChanges(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -171,11 +185,15 @@ type bundleHandler struct {
 	dryRun bool
 	force  bool
 	trust  bool
+	prune  bool
 
 	// bundleDir is the path where the bundle file is located for local bundles.
 	bundleDir string
 	// changes holds the changes to be applied in order to deploy the bundle.
 	changes []bundlechanges.Change
+	// reconcileChanges holds the removals and binding updates required to
+	// converge the model to the bundle. It is only populated by juju apply.
+	reconcileChanges []reconcileChange
 
 	// applications are all the applications defined in the bundle.
 	// Used primarily for iterating over sorted values.
@@ -230,6 +248,10 @@ type bundleHandler struct {
 	// handlers (addCharm, addApplication etc.) and by updateUnitStatus.
 	unitStatus map[string]string
 
+	// status is the model status read when building the model
+	// representation.
+	status *params.FullStatus
+
 	modelConfig *config.Config
 
 	model *bundlechanges.Model
@@ -268,6 +290,7 @@ func makeBundleHandler(bundleData *charm.BundleData, spec bundleDeploySpec) *bun
 		dryRun:               spec.dryRun,
 		force:                spec.force,
 		trust:                spec.trust,
+		prune:                spec.prune,
 		bundleDir:            spec.bundleDir,
 		applications:         applications,
 		results:              make(map[string]string),
@@ -299,6 +322,7 @@ func (h *bundleHandler) makeModel(
 	if err != nil {
 		return errors.Annotate(err, "cannot get model status")
 	}
+	h.status = status
 	h.model, err = buildModelRepresentation(status, h.api, useExistingMachines, bundleMachines)
 	if err != nil {
 		return errors.Trace(err)
@@ -424,7 +448,7 @@ func (h *bundleHandler) handleChanges() error {
 	}
 	defer h.watcher.Stop()
 
-	if len(h.changes) == 0 {
+	if len(h.changes) == 0 && len(h.reconcileChanges) == 0 {
 		h.ctx.Infof("No changes to apply.")
 		return nil
 	}
@@ -435,6 +459,18 @@ func (h *bundleHandler) handleChanges() error {
 		fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
 	}
 
+	// Anything the bundle no longer declares is removed first,
+	// so that it cannot collide with what replaces it.
+	for _, change := range h.reconcileChanges {
+		if !isRemoval(change) {
+			continue
+		}
+		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		if err := h.applyReconcileChange(change); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Deploy the bundle.
 	for i, change := range h.changes {
 		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
@@ -475,6 +511,15 @@ func (h *bundleHandler) handleChanges() error {
 			return errors.Trace(err)
 		}
 	}
+	for _, change := range h.reconcileChanges {
+		if isRemoval(change) {
+			continue
+		}
+		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		if err := h.applyReconcileChange(change); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if !h.dryRun {
 		h.ctx.Infof("Deploy of bundle completed.")
@@ -1165,6 +1210,7 @@ func (h *bundleHandler) consumeOffer(change *bundlechanges.ConsumeOfferChange) e
 			Alias:         consumeDetails.ControllerInfo.Alias,
 			Addrs:         consumeDetails.ControllerInfo.Addrs,
 			CACert:        consumeDetails.ControllerInfo.CACert,
+			RelayProxy:    consumeDetails.ControllerInfo.RelayProxy,
 		}
 	}
 	localName, err := h.api.Consume(arg)
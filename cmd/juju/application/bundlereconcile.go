@@ -0,0 +1,410 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+)
+
+// reconcileChange is a change required to converge a model to a bundle
+// which the bundlechanges library does not produce, because it only ever
+// adds to or updates a model.
+type reconcileChange interface {
+	// Description returns a human readable description of the change.
+	Description() string
+}
+
+// removeOfferChange removes an offer not declared in the bundle.
+type removeOfferChange struct {
+	offer string
+}
+
+// Description implements reconcileChange.
+func (ch removeOfferChange) Description() string {
+	return fmt.Sprintf("remove offer %s", ch.offer)
+}
+
+// removeRelationChange removes a relation not declared in the bundle.
+type removeRelationChange struct {
+	endpoints []string
+}
+
+// Description implements reconcileChange.
+func (ch removeRelationChange) Description() string {
+	return fmt.Sprintf("remove relation %s", strings.Join(ch.endpoints, " - "))
+}
+
+// removeUnitChange removes a unit in excess of the bundle's num_units.
+type removeUnitChange struct {
+	unit string
+}
+
+// Description implements reconcileChange.
+func (ch removeUnitChange) Description() string {
+	return fmt.Sprintf("remove unit %s", ch.unit)
+}
+
+// removeApplicationChange removes an application not declared in the bundle.
+type removeApplicationChange struct {
+	application string
+}
+
+// Description implements reconcileChange.
+func (ch removeApplicationChange) Description() string {
+	return fmt.Sprintf("remove application %s", ch.application)
+}
+
+// setBindingsChange updates the endpoint bindings of an application to
+// match those declared in the bundle.
+type setBindingsChange struct {
+	application string
+	bindings    map[string]string
+}
+
+// Description implements reconcileChange.
+func (ch setBindingsChange) Description() string {
+	var endpoints []string
+	for endpoint, space := range ch.bindings {
+		if endpoint == "" {
+			endpoint = "<default>"
+		}
+		endpoints = append(endpoints, fmt.Sprintf("%s=%s", endpoint, space))
+	}
+	sort.Strings(endpoints)
+	return fmt.Sprintf("set endpoint bindings for %s to %s", ch.application, strings.Join(endpoints, ", "))
+}
+
+// resetConfigChange resets application config options which have been
+// set in the model but are not declared in the bundle.
+type resetConfigChange struct {
+	application string
+	keys        []string
+}
+
+// Description implements reconcileChange.
+func (ch resetConfigChange) Description() string {
+	return fmt.Sprintf("reset config for %s: %s", ch.application, strings.Join(ch.keys, ", "))
+}
+
+// isRemoval reports whether the change removes something from the model.
+// Removals are run before the bundle's own changes, so that entities the
+// bundle replaces are gone before their replacements are added.
+func isRemoval(change reconcileChange) bool {
+	switch change.(type) {
+	case setBindingsChange, resetConfigChange:
+		return false
+	}
+	return true
+}
+
+// computeReconcileChanges returns the changes, in execution order, needed
+// to remove everything from the model that the bundle does not declare, to
+// update endpoint bindings which differ from the bundle, and to reset config
+// options the bundle does not set. The bindings argument holds the current
+// endpoint bindings, and the options argument the config options explicitly
+// set, keyed by application name. Entities which are already dying are
+// ignored so that the result converges to nothing once the model matches
+// the bundle.
+func computeReconcileChanges(
+	data *charm.BundleData,
+	status *params.FullStatus,
+	bindings map[string]map[string]string,
+	options map[string]map[string]interface{},
+) []reconcileChange {
+	var changes []reconcileChange
+
+	// Offers must go before the applications they expose.
+	var offers []string
+	for name, offer := range status.Offers {
+		spec, ok := data.Applications[offer.ApplicationName]
+		if ok {
+			if _, declared := spec.Offers[name]; declared {
+				continue
+			}
+		}
+		offers = append(offers, name)
+	}
+	sort.Strings(offers)
+	for _, offer := range offers {
+		changes = append(changes, removeOfferChange{offer: offer})
+	}
+
+	removedApps := set.NewStrings()
+	for name, app := range status.Applications {
+		if _, ok := data.Applications[name]; ok || isDying(app.Life) {
+			continue
+		}
+		removedApps.Add(name)
+	}
+
+	// Relations involving removed applications go with the application.
+	var relations [][]string
+	for _, rel := range status.Relations {
+		if len(rel.Endpoints) != 2 || isDying(rel.Status.Life) {
+			continue
+		}
+		ep1, ep2 := rel.Endpoints[0], rel.Endpoints[1]
+		if removedApps.Contains(ep1.ApplicationName) || removedApps.Contains(ep2.ApplicationName) {
+			continue
+		}
+		if bundleDeclaresRelation(data, ep1, ep2) {
+			continue
+		}
+		relations = append(relations, []string{
+			ep1.ApplicationName + ":" + ep1.Name,
+			ep2.ApplicationName + ":" + ep2.Name,
+		})
+	}
+	sort.Slice(relations, func(i, j int) bool {
+		return strings.Join(relations[i], " ") < strings.Join(relations[j], " ")
+	})
+	for _, endpoints := range relations {
+		changes = append(changes, removeRelationChange{endpoints: endpoints})
+	}
+
+	// Scaling of CAAS applications is handled by bundlechanges.
+	if status.Model.Type != string(model.CAAS) {
+		for _, name := range sortedApplicationNames(status.Applications) {
+			app := status.Applications[name]
+			spec, ok := data.Applications[name]
+			if !ok || isDying(app.Life) || len(app.SubordinateTo) > 0 {
+				continue
+			}
+			var units []string
+			for unitName, unit := range app.Units {
+				if !isDying(unit.AgentStatus.Life) {
+					units = append(units, unitName)
+				}
+			}
+			if len(units) <= spec.NumUnits {
+				continue
+			}
+			sort.Slice(units, func(i, j int) bool {
+				return unitNumber(units[i]) > unitNumber(units[j])
+			})
+			for _, unit := range units[:len(units)-spec.NumUnits] {
+				changes = append(changes, removeUnitChange{unit: unit})
+			}
+		}
+	}
+
+	for _, name := range removedApps.SortedValues() {
+		changes = append(changes, removeApplicationChange{application: name})
+	}
+
+	for _, name := range sortedApplicationNames(status.Applications) {
+		spec, ok := data.Applications[name]
+		current, known := bindings[name]
+		if !ok || !known || isDying(status.Applications[name].Life) {
+			continue
+		}
+		changed := make(map[string]string)
+		for endpoint, space := range spec.EndpointBindings {
+			if current[endpoint] != space {
+				changed[endpoint] = space
+			}
+		}
+		if len(changed) > 0 {
+			changes = append(changes, setBindingsChange{application: name, bindings: changed})
+		}
+	}
+
+	for _, name := range sortedApplicationNames(status.Applications) {
+		spec, ok := data.Applications[name]
+		if !ok || isDying(status.Applications[name].Life) {
+			continue
+		}
+		var keys []string
+		for key := range options[name] {
+			if _, declared := spec.Options[key]; !declared {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			sort.Strings(keys)
+			changes = append(changes, resetConfigChange{application: name, keys: keys})
+		}
+	}
+	return changes
+}
+
+// bundleDeclaresRelation reports whether the bundle declares a relation
+// between the two model endpoints, in either order. Bundle endpoints may
+// omit the endpoint name, in which case they match any endpoint of the
+// application.
+func bundleDeclaresRelation(data *charm.BundleData, ep1, ep2 params.EndpointStatus) bool {
+	matches := func(bundleEndpoint string, ep params.EndpointStatus) bool {
+		parts := strings.SplitN(bundleEndpoint, ":", 2)
+		if parts[0] != ep.ApplicationName {
+			return false
+		}
+		return len(parts) == 1 || parts[1] == ep.Name
+	}
+	for _, rel := range data.Relations {
+		if len(rel) != 2 {
+			continue
+		}
+		if matches(rel[0], ep1) && matches(rel[1], ep2) || matches(rel[0], ep2) && matches(rel[1], ep1) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDying(value string) bool {
+	return value == string(life.Dying) || value == string(life.Dead)
+}
+
+func unitNumber(unitName string) int {
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
+
+func sortedApplicationNames(apps map[string]params.ApplicationStatus) []string {
+	result := make([]string, 0, len(apps))
+	for name := range apps {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getReconcileChanges computes the changes, beyond those produced by
+// bundlechanges, needed to converge the model to the bundle.
+func (h *bundleHandler) getReconcileChanges() error {
+	var tags []names.ApplicationTag
+	for _, name := range sortedApplicationNames(h.status.Applications) {
+		if spec, ok := h.data.Applications[name]; ok && len(spec.EndpointBindings) > 0 {
+			tags = append(tags, names.NewApplicationTag(name))
+		}
+	}
+	bindings := make(map[string]map[string]string)
+	if len(tags) > 0 {
+		results, err := h.api.ApplicationsInfo(tags)
+		if errors.IsNotSupported(err) {
+			h.ctx.Warningf("endpoint bindings will not be updated: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "getting endpoint bindings")
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				return errors.Annotatef(result.Error, "getting endpoint bindings for %q", tags[i].Id())
+			}
+			bindings[tags[i].Id()] = result.Result.EndpointBindings
+		}
+	}
+	options := make(map[string]map[string]interface{})
+	if h.model != nil {
+		for name, app := range h.model.Applications {
+			options[name] = app.Options
+		}
+	}
+	h.reconcileChanges = computeReconcileChanges(h.data, h.status, bindings, options)
+	return nil
+}
+
+// confirmReconcileChanges prints the full plan and asks the user to
+// confirm it when it includes removals, unless pruning was requested up
+// front.
+func (h *bundleHandler) confirmReconcileChanges() error {
+	if h.dryRun || h.prune || len(h.reconcileChanges) == 0 {
+		return nil
+	}
+	fmt.Fprintf(h.ctx.Stdout, "Changes to apply bundle:\n")
+	for _, change := range h.reconcileChanges {
+		if isRemoval(change) {
+			fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		}
+	}
+	for _, change := range h.changes {
+		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+	}
+	for _, change := range h.reconcileChanges {
+		if !isRemoval(change) {
+			fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		}
+	}
+	fmt.Fprintf(h.ctx.Stdout, "\nThe model will be pruned to match the bundle.\nContinue [y/N]? ")
+	if err := jujucmd.UserConfirmYes(h.ctx); err != nil {
+		return errors.Annotate(err, "bundle apply")
+	}
+	return nil
+}
+
+// applyReconcileChange executes a single reconcile change. Removals which
+// are already in progress are not treated as errors.
+func (h *bundleHandler) applyReconcileChange(change reconcileChange) error {
+	if h.dryRun {
+		return nil
+	}
+	switch change := change.(type) {
+	case removeOfferChange:
+		offerURL := h.qualifiedOfferURL(change.offer)
+		if err := h.api.DestroyOffers(false, offerURL); err != nil {
+			return errors.Annotatef(err, "cannot remove offer %s", change.offer)
+		}
+	case removeRelationChange:
+		if err := h.api.DestroyRelation(nil, nil, change.endpoints...); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "cannot remove relation %s", strings.Join(change.endpoints, " "))
+		}
+	case removeUnitChange:
+		results, err := h.api.DestroyUnits(application.DestroyUnitsParams{
+			Units: []string{change.unit},
+		})
+		if err == nil && len(results) > 0 && results[0].Error != nil {
+			err = results[0].Error
+		}
+		if err != nil && !params.IsCodeNotFound(err) {
+			return errors.Annotatef(err, "cannot remove unit %s", change.unit)
+		}
+	case removeApplicationChange:
+		results, err := h.api.DestroyApplications(application.DestroyApplicationsParams{
+			Applications: []string{change.application},
+		})
+		if err == nil && len(results) > 0 && results[0].Error != nil {
+			err = results[0].Error
+		}
+		if err != nil && !params.IsCodeNotFound(err) {
+			return errors.Annotatef(err, "cannot remove application %s", change.application)
+		}
+	case setBindingsChange:
+		if err := h.api.MergeBindings(change.application, change.bindings); err != nil {
+			return errors.Annotatef(err, "cannot set endpoint bindings for %s", change.application)
+		}
+	case resetConfigChange:
+		if err := h.api.UnsetApplicationConfig(model.GenerationMaster, change.application, change.keys); err != nil {
+			return errors.Annotatef(err, "cannot reset config for %s", change.application)
+		}
+	default:
+		return errors.Errorf("unknown reconcile change type: %T", change)
+	}
+	return nil
+}
+
+// qualifiedOfferURL returns the URL of an offer in the target model,
+// qualified with the model owner so that it is valid whichever user
+// owns the model.
+func (h *bundleHandler) qualifiedOfferURL(offer string) string {
+	modelName, owner := h.targetModelName, h.accountUser
+	if name, ownerTag, err := jujuclient.SplitModelName(h.targetModelName); err == nil {
+		modelName, owner = name, ownerTag.Id()
+	}
+	return crossmodel.MakeURL(owner, modelName, offer, "")
+}
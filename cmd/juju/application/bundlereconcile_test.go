@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ReconcileChangesSuite struct{}
+
+var _ = gc.Suite(&ReconcileChangesSuite{})
+
+func (s *ReconcileChangesSuite) bundle(c *gc.C) *charm.BundleData {
+	data, err := charm.ReadBundleData(strings.NewReader(`
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    bindings:
+      db: alpha
+  wordpress:
+    charm: cs:wordpress
+    num_units: 2
+    options:
+      tuning: optimized
+    offers:
+      blog:
+        endpoints:
+        - website
+relations:
+- - wordpress:db
+  - mysql
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *ReconcileChangesSuite) status() *params.FullStatus {
+	units := func(names ...string) map[string]params.UnitStatus {
+		result := make(map[string]params.UnitStatus)
+		for _, name := range names {
+			result[name] = params.UnitStatus{}
+		}
+		return result
+	}
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{Type: "iaas"},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql":     {Units: units("mysql/0")},
+			"wordpress": {Units: units("wordpress/0", "wordpress/3", "wordpress/10")},
+			"memcached": {Units: units("memcached/0")},
+		},
+		Relations: []params.RelationStatus{{
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql", Name: "db"},
+				{ApplicationName: "wordpress", Name: "db"},
+			},
+		}, {
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "wordpress", Name: "cache"},
+				{ApplicationName: "memcached", Name: "cache"},
+			},
+		}, {
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "wordpress", Name: "logging"},
+				{ApplicationName: "mysql", Name: "logging"},
+			},
+		}},
+		Offers: map[string]params.ApplicationOfferStatus{
+			"blog":  {ApplicationName: "wordpress"},
+			"mysql": {ApplicationName: "mysql"},
+		},
+	}
+}
+
+func (s *ReconcileChangesSuite) TestComputeReconcileChanges(c *gc.C) {
+	changes := computeReconcileChanges(s.bundle(c), s.status(), map[string]map[string]string{
+		"mysql": {"": "alpha", "db": "beta"},
+	}, map[string]map[string]interface{}{
+		"wordpress": {"tuning": "single", "blog-title": "My Blog", "debug": true},
+		"memcached": {"size": 1024},
+	})
+	c.Assert(changes, jc.DeepEquals, []reconcileChange{
+		removeOfferChange{offer: "mysql"},
+		removeRelationChange{endpoints: []string{"wordpress:logging", "mysql:logging"}},
+		removeUnitChange{unit: "wordpress/10"},
+		removeApplicationChange{application: "memcached"},
+		setBindingsChange{application: "mysql", bindings: map[string]string{"db": "alpha"}},
+		resetConfigChange{application: "wordpress", keys: []string{"blog-title", "debug"}},
+	})
+	var descriptions []string
+	for _, change := range changes {
+		descriptions = append(descriptions, change.Description())
+	}
+	c.Assert(descriptions, jc.DeepEquals, []string{
+		"remove offer mysql",
+		"remove relation wordpress:logging - mysql:logging",
+		"remove unit wordpress/10",
+		"remove application memcached",
+		"set endpoint bindings for mysql to db=alpha",
+		"reset config for wordpress: blog-title, debug",
+	})
+	var removals []bool
+	for _, change := range changes {
+		removals = append(removals, isRemoval(change))
+	}
+	c.Assert(removals, jc.DeepEquals, []bool{true, true, true, true, false, false})
+}
+
+func (s *ReconcileChangesSuite) TestQualifiedOfferURL(c *gc.C) {
+	h := &bundleHandler{targetModelName: "default", accountUser: "bob"}
+	c.Assert(h.qualifiedOfferURL("blog"), gc.Equals, "bob/default.blog")
+
+	h = &bundleHandler{targetModelName: "mary/prod", accountUser: "bob"}
+	c.Assert(h.qualifiedOfferURL("blog"), gc.Equals, "mary/prod.blog")
+}
+
+func (s *ReconcileChangesSuite) TestComputeReconcileChangesIgnoresDying(c *gc.C) {
+	status := s.status()
+	status.Offers = nil
+	status.Relations = status.Relations[:1]
+	memcached := status.Applications["memcached"]
+	memcached.Life = "dying"
+	status.Applications["memcached"] = memcached
+	wordpress := status.Applications["wordpress"]
+	wordpress.Units["wordpress/10"] = params.UnitStatus{
+		AgentStatus: params.DetailedStatus{Life: "dying"},
+	}
+	changes := computeReconcileChanges(s.bundle(c), status, map[string]map[string]string{
+		"mysql": {"db": "alpha"},
+	}, map[string]map[string]interface{}{
+		"memcached": {"size": 1024},
+	})
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *ReconcileChangesSuite) TestComputeReconcileChangesCAASSkipsUnits(c *gc.C) {
+	status := s.status()
+	status.Model.Type = "caas"
+	changes := computeReconcileChanges(s.bundle(c), status, nil, nil)
+	for _, change := range changes {
+		_, ok := change.(removeUnitChange)
+		c.Check(ok, jc.IsFalse, gc.Commentf("unexpected change %v", change))
+	}
+}
+
+type ApplyCommandSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ApplyCommandSuite{})
+
+func (s *ApplyCommandSuite) initCommand(args ...string) error {
+	command := NewApplyCommand()
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return cmdtesting.InitCommand(command, args)
+}
+
+func (s *ApplyCommandSuite) TestInitNoBundle(c *gc.C) {
+	err := s.initCommand()
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *ApplyCommandSuite) TestInitTooManyArgs(c *gc.C) {
+	err := s.initCommand("bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ApplyCommandSuite) TestInitRejectsCharmFlags(c *gc.C) {
+	err := s.initCommand("bundle.yaml", "--config", "foo=bar")
+	c.Assert(err, gc.ErrorMatches, "option provided but not defined: --config")
+}
+
+func (s *ApplyCommandSuite) TestInitMapMachines(c *gc.C) {
+	err := s.initCommand("bundle.yaml", "--map-machines", "foo")
+	c.Assert(err, gc.ErrorMatches, `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`)
+}
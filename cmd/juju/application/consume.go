@@ -167,6 +167,7 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 			Alias:         consumeDetails.ControllerInfo.Alias,
 			Addrs:         consumeDetails.ControllerInfo.Addrs,
 			CACert:        consumeDetails.ControllerInfo.CACert,
+			RelayProxy:    consumeDetails.ControllerInfo.RelayProxy,
 		}
 	}
 	localName, err := targetClient.Consume(arg)
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
@@ -62,13 +63,19 @@ type ApplicationAPI interface {
 	AddMachines(machineParams []apiparams.AddMachineParams) ([]apiparams.AddMachinesResult, error)
 	AddRelation(endpoints, viaCIDRs []string) (*apiparams.AddRelationResults, error)
 	AddUnits(application.AddUnitsParams) ([]string, error)
+	ApplicationsInfo([]names.ApplicationTag) ([]apiparams.ApplicationInfoResult, error)
+	DestroyApplications(application.DestroyApplicationsParams) ([]apiparams.DestroyApplicationResult, error)
+	DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error
+	DestroyUnits(application.DestroyUnitsParams) ([]apiparams.DestroyUnitResult, error)
 	Expose(application string) error
 	GetAnnotations(tags []string) ([]apiparams.AnnotationsGetResult, error)
 	GetConfig(branchName string, appNames ...string) ([]map[string]interface{}, error)
 	GetConstraints(appNames ...string) ([]constraints.Value, error)
+	MergeBindings(application string, bindings map[string]string) error
 	SetAnnotation(annotations map[string]map[string]string) ([]apiparams.ErrorResult, error)
 	SetCharm(string, application.SetCharmConfig) error
 	SetConstraints(application string, constraints constraints.Value) error
+	UnsetApplicationConfig(branchName, application string, options []string) error
 	Update(apiparams.ApplicationUpdate) error
 	ScaleApplication(application.ScaleApplicationParams) (apiparams.ScaleApplicationResult, error)
 	Consume(arg crossmodel.ConsumeApplicationArgs) (string, error)
@@ -98,6 +105,7 @@ type CharmDeployAPI interface {
 type OfferAPI interface {
 	Offer(modelUUID, application string, endpoints []string, offerName, descr string) ([]apiparams.ErrorResult, error)
 	GrantOffer(user, access string, offerURLs ...string) error
+	DestroyOffers(force bool, offerURLs ...string) error
 }
 
 type ConsumeDetails interface {
@@ -269,6 +277,10 @@ func (a *deployAPIAdapter) GetAnnotations(tags []string) ([]apiparams.Annotation
 
 // NewDeployCommand returns a command to deploy applications.
 func NewDeployCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(newDeployCommand())
+}
+
+func newDeployCommand() *DeployCommand {
 	steps := []DeployStep{
 		&RegisterMeteredCharm{
 			PlanURL:      romulus.DefaultAPIRoot,
@@ -324,8 +336,7 @@ func NewDeployCommand() modelcmd.ModelCommand {
 		}
 		return applicationoffers.NewClient(root), nil
 	}
-
-	return modelcmd.Wrap(deployCmd)
+	return deployCmd
 }
 
 type DeployCommand struct {
@@ -411,6 +422,11 @@ type DeployCommand struct {
 	flagSet    *gnuflag.FlagSet
 
 	unknownModel bool
+
+	// reconcile and prune are set by the apply command, which only
+	// accepts bundles and converges the model to exactly match them.
+	reconcile bool
+	prune     bool
 }
 
 const kubernetesSeriesName = "kubernetes"
@@ -1209,6 +1225,19 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 		step.SetPlanURL(apiRoot.PlanURL())
 	}
 
+	if c.reconcile {
+		deploy, err := findDeployerFIFO(
+			func() (deployFn, error) { return c.maybeReadLocalBundle(ctx) },
+			c.maybeReadCharmstoreBundleFn(apiRoot),
+		)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("%q is not a bundle", c.CharmOrBundle)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return block.ProcessBlockedError(deploy(ctx, apiRoot), block.BlockChange)
+	}
+
 	deploy, err := findDeployerFIFO(
 		func() (deployFn, error) { return c.maybeReadLocalBundle(ctx) },
 		func() (deployFn, error) { return c.maybeReadLocalCharm(apiRoot) },
@@ -1365,6 +1394,8 @@ func (c *DeployCommand) maybeReadLocalBundle(ctx *cmd.Context) (deployFn, error)
 			dryRun:              c.DryRun,
 			force:               c.Force,
 			trust:               c.Trust,
+			reconcile:           c.reconcile,
+			prune:               c.prune,
 			bundleDataSource:    ds,
 			bundleOverlayFile:   c.BundleOverlayFile,
 			channel:             c.Channel,
@@ -1548,6 +1579,8 @@ func (c *DeployCommand) maybeReadCharmstoreBundleFn(apiRoot DeployAPI) func() (d
 				dryRun:              c.DryRun,
 				force:               c.Force,
 				trust:               c.Trust,
+				reconcile:           c.reconcile,
+				prune:               c.prune,
 				bundleDataSource:    newResolvedBundle(bundle),
 				bundleURL:           bundleURL,
 				bundleOverlayFile:   c.BundleOverlayFile,
@@ -4,10 +4,14 @@
 package application
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -20,8 +24,21 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access may be restricted to specific endpoints of the application with
+the --endpoints option, and to specific sources with the --to-spaces
+and --to-cidrs options. Sources given without --endpoints apply to all
+of the application's endpoints. Expose settings for endpoints are
+merged with any settings already in place for the application.
+
+The sources of an endpoint apply to the ports that the application's
+units open for that endpoint with "open-port --endpoint", along with any
+sources given for all endpoints. Ports opened for all endpoints may be
+accessed from the sources of every exposed endpoint.
+
 Examples:
     juju expose wordpress
+    juju expose postgresql --endpoints db --to-cidrs 10.0.0.0/8
+    juju expose mysql --endpoints db,admin --to-spaces internal
 
 See also: 
     unexpose`[1:]
@@ -35,6 +52,13 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       []string
+	ExposeToSpaces  []string
+	ExposeToCIDRs   []string
+
+	endpoints string
+	toSpaces  string
+	toCIDRs   string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -46,18 +70,42 @@ func (c *exposeCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags is part of the cmd.Command interface.
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-delimited list of endpoints to expose")
+	f.StringVar(&c.toSpaces, "to-spaces", "", "Comma-delimited list of spaces allowed to access the exposed endpoints")
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-delimited list of CIDRs allowed to access the exposed endpoints")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	c.Endpoints = splitCommaList(c.endpoints)
+	c.ExposeToSpaces = splitCommaList(c.toSpaces)
+	c.ExposeToCIDRs = splitCommaList(c.toCIDRs)
 	return cmd.CheckEmpty(args[1:])
 }
 
+// splitCommaList splits a comma-delimited list, ignoring empty items.
+func splitCommaList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
 	Unexpose(applicationName string) error
+	ExposeEndpoints(applicationName string, exposed map[string]params.ExposedEndpoint) error
+	UnexposeEndpoints(applicationName string, endpoints []string) error
 }
 
 func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
@@ -76,5 +124,29 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.Endpoints) == 0 && len(c.ExposeToSpaces) == 0 && len(c.ExposeToCIDRs) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+	return block.ProcessBlockedError(
+		client.ExposeEndpoints(c.ApplicationName, c.exposedEndpoints()),
+		block.BlockChange,
+	)
+}
+
+// exposedEndpoints returns the endpoint expose settings requested by
+// the command line. Sources given without any endpoints apply to all
+// of the application's endpoints, which are keyed by the empty string.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	endpoints := c.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{""}
+	}
+	exposed := make(map[string]params.ExposedEndpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		exposed[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: c.ExposeToSpaces,
+			ExposeToCIDRs:  c.ExposeToCIDRs,
+		}
+	}
+	return exposed
 }
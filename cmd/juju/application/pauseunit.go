@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var pauseUnitHelpSummary = `
+Stops units from running hooks until they are resumed.`[1:]
+
+var pauseUnitHelpDetails = `
+A paused unit stays deployed and its agent keeps running, but the agent
+runs no hooks, actions or commands for it. Events that arrive while the
+unit is paused are handled once it is resumed, so that the unit catches
+up with any changes made in the meantime. A hook that is already running
+when the unit is paused is allowed to finish.
+
+The user who paused a unit, and the reason given, are shown by juju
+status until the unit is resumed.
+
+Examples:
+    juju pause-unit mysql/0
+    juju pause-unit mysql/0 mysql/1 --reason "kernel upgrade"
+
+See also:
+    resume-unit
+    status`
+
+// NewPauseUnitCommand returns a command to pause the hooks of units.
+func NewPauseUnitCommand() cmd.Command {
+	cmd := &pauseUnitCommand{}
+	cmd.newAPIFunc = func() (PauseUnitsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type pauseUnitCommand struct {
+	modelcmd.ModelCommandBase
+	unitNames  []string
+	reason     string
+	newAPIFunc func() (PauseUnitsAPI, error)
+}
+
+func (c *pauseUnitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "pause-unit",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: pauseUnitHelpSummary,
+		Doc:     pauseUnitHelpDetails,
+	})
+}
+
+func (c *pauseUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.reason, "reason", "", "Why the units are being paused")
+}
+
+func (c *pauseUnitCommand) Init(args []string) error {
+	unitNames, err := parseUnitNames(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.unitNames = unitNames
+	return nil
+}
+
+// PauseUnitsAPI defines the API methods that the pause-unit and
+// resume-unit commands use.
+type PauseUnitsAPI interface {
+	Close() error
+	BestAPIVersion() int
+	PauseUnits(units []string, reason string) error
+	ResumeUnits(units []string) error
+}
+
+func (c *pauseUnitCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 13 {
+		return errors.New("pausing a unit is not supported by this version of Juju")
+	}
+	err = client.PauseUnits(c.unitNames, c.reason)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+func parseUnitNames(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no units specified")
+	}
+	for _, name := range args {
+		if !names.IsValidUnit(name) {
+			return nil, errors.NotValidf("unit name %q", name)
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var resumeUnitHelpSummary = `
+Lets paused units run hooks again.`[1:]
+
+var resumeUnitHelpDetails = `
+Resuming a unit lets its agent handle the events that arrived while the
+unit was paused. Resuming a unit that is not paused does nothing.
+
+Examples:
+    juju resume-unit mysql/0
+    juju resume-unit mysql/0 mysql/1
+
+See also:
+    pause-unit
+    status`
+
+// NewResumeUnitCommand returns a command to resume paused units.
+func NewResumeUnitCommand() cmd.Command {
+	cmd := &resumeUnitCommand{}
+	cmd.newAPIFunc = func() (PauseUnitsAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type resumeUnitCommand struct {
+	modelcmd.ModelCommandBase
+	unitNames  []string
+	newAPIFunc func() (PauseUnitsAPI, error)
+}
+
+func (c *resumeUnitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resume-unit",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: resumeUnitHelpSummary,
+		Doc:     resumeUnitHelpDetails,
+	})
+}
+
+func (c *resumeUnitCommand) Init(args []string) error {
+	unitNames, err := parseUnitNames(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.unitNames = unitNames
+	return nil
+}
+
+func (c *resumeUnitCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 13 {
+		return errors.New("resuming a unit is not supported by this version of Juju")
+	}
+	err = client.ResumeUnits(c.unitNames)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
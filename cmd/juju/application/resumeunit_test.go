@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ResumeUnitSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockPauseUnitsAPI
+}
+
+func (s *ResumeUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockPauseUnitsAPI{Stub: &testing.Stub{}, version: 13}
+}
+
+var _ = gc.Suite(&ResumeUnitSuite{})
+
+func (s *ResumeUnitSuite) runResumeUnit(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, application.NewResumeUnitCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitInvalidArguments(c *gc.C) {
+	err := s.runResumeUnit(c)
+	c.Assert(err, gc.ErrorMatches, "no units specified")
+
+	err = s.runResumeUnit(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitOldServer(c *gc.C) {
+	s.mockAPI.version = 12
+	err := s.runResumeUnit(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "resuming a unit is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ResumeUnitSuite) TestResumeUnitSuccess(c *gc.C) {
+	err := s.runResumeUnit(c, "mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "ResumeUnits", []string{"mysql/0", "mysql/1"})
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
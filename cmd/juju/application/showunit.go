@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showUnitDoc = `
+The command takes deployed unit names as an argument.
+
+The output includes the state the unit's charm has stored for it with
+the state-set hook tool, and the most recent hooks the unit has run,
+with how long each waited to run and how long it took.
+
+Examples:
+    $ juju show-unit mysql/0
+    $ juju show-unit mysql/0 wordpress/1
+
+`
+
+// NewShowUnitCommand returns a command that displays units info.
+func NewShowUnitCommand() cmd.Command {
+	s := &showUnitCommand{}
+	s.newAPIFunc = func() (UnitsInfoAPI, error) {
+		return s.newUnitAPI()
+	}
+	return modelcmd.Wrap(s)
+}
+
+// showUnitCommand displays unit information.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+
+	out        cmd.Output
+	isoTime    bool
+	units      []string
+	newAPIFunc func() (UnitsInfoAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showUnitCommand) Info() *cmd.Info {
+	showCmd := &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name>",
+		Purpose: "Displays information about a unit.",
+		Doc:     showUnitDoc,
+	}
+	return jujucmd.Info(showCmd)
+}
+
+// Init implements Command.Init.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.units = args
+	var invalid []string
+	for _, one := range c.units {
+		if !names.IsValidUnit(one) {
+			invalid = append(invalid, one)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	plural := "s"
+	if len(invalid) == 1 {
+		plural = ""
+	}
+	return errors.NotValidf(`unit name%v %v`, plural, strings.Join(invalid, `, `))
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// UnitsInfoAPI defines the API methods that show-unit command uses.
+type UnitsInfoAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UnitsInfo([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+func (c *showUnitCommand) newUnitAPI() (UnitsInfoAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if v := client.BestAPIVersion(); v < 12 {
+		// old client does not support showing units.
+		return errors.NotSupportedf("show units on API server version %v", v)
+	}
+
+	tags := make([]names.UnitTag, len(c.units))
+	for i, one := range c.units {
+		tags[i] = names.NewUnitTag(one)
+	}
+	results, err := client.UnitsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	var valid []params.UnitInfo
+	for _, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{result.Error})
+			continue
+		}
+		valid = append(valid, *result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+
+	output, err := formatUnitInfos(valid, c.isoTime)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatUnitInfos takes a set of params.UnitInfo and creates a mapping
+// from unit name to unit info.
+func formatUnitInfos(all []params.UnitInfo, isoTime bool) (map[string]UnitInfo, error) {
+	if len(all) == 0 {
+		return nil, nil
+	}
+	output := make(map[string]UnitInfo)
+	for _, one := range all {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		output[tag.Id()] = UnitInfo{
+			Life:            one.Life,
+			Charm:           one.Charm,
+			WorkloadVersion: one.WorkloadVersion,
+			Machine:         one.Machine,
+			Principal:       one.Principal,
+			CharmState:      one.State,
+			RecentHooks:     formatHookExecutions(one.HookExecutions, isoTime),
+		}
+	}
+	return output, nil
+}
+
+// formatHookExecutions returns the given hook executions, most recent
+// first, with their timings as durations.
+func formatHookExecutions(all []params.HookExecution, isoTime bool) []HookExecution {
+	if len(all) == 0 {
+		return nil
+	}
+	output := make([]HookExecution, len(all))
+	for i, one := range all {
+		output[len(all)-1-i] = HookExecution{
+			Hook:     one.Hook,
+			Outcome:  one.Outcome,
+			Started:  common.FormatTime(&one.Started, isoTime),
+			Queued:   one.Started.Sub(one.Queued).Round(time.Millisecond).String(),
+			Duration: one.Finished.Sub(one.Started).Round(time.Millisecond).String(),
+		}
+	}
+	return output
+}
+
+// UnitInfo defines the serialization behaviour of the unit information.
+type UnitInfo struct {
+	Life            string            `yaml:"life" json:"life"`
+	Charm           string            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	WorkloadVersion string            `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	Machine         string            `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Principal       string            `yaml:"principal,omitempty" json:"principal,omitempty"`
+	CharmState      map[string]string `yaml:"charm-state,omitempty" json:"charm-state,omitempty"`
+	RecentHooks     []HookExecution   `yaml:"recent-hooks,omitempty" json:"recent-hooks,omitempty"`
+}
+
+// HookExecution defines the serialization behaviour of a hook run by
+// the unit.
+type HookExecution struct {
+	Hook     string `yaml:"hook" json:"hook"`
+	Outcome  string `yaml:"outcome" json:"outcome"`
+	Started  string `yaml:"started" json:"started"`
+	Queued   string `yaml:"queued-for" json:"queued-for"`
+	Duration string `yaml:"duration" json:"duration"`
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowUnitSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockShowUnitAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.mockAPI = &mockShowUnitAPI{
+		version: 12,
+		unitsInfoFunc: func(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+			results := make([]params.UnitInfoResult, len(tags))
+			for i, tag := range tags {
+				results[i].Result = &params.UnitInfo{
+					Tag:             tag.String(),
+					Life:            "alive",
+					Charm:           "cs:wordpress-42",
+					WorkloadVersion: "5.3",
+					Machine:         "0",
+					State:           map[string]string{"seen": "yes"},
+				}
+			}
+			return results, nil
+		},
+	}
+}
+
+func (s *ShowUnitSuite) runShowUnit(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewShowUnitCommandForTest(s.mockAPI, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *ShowUnitSuite) TestShowNoArguments(c *gc.C) {
+	_, err := s.runShowUnit(c)
+	c.Assert(err, gc.ErrorMatches, "a unit name must be supplied")
+}
+
+func (s *ShowUnitSuite) TestShowInvalidNames(c *gc.C) {
+	_, err := s.runShowUnit(c, "wordpress", "wordpress/0", "oo/x")
+	c.Assert(err, gc.ErrorMatches, "unit names wordpress, oo/x not valid")
+}
+
+func (s *ShowUnitSuite) TestShowUnsupported(c *gc.C) {
+	s.mockAPI.version = 11
+	_, err := s.runShowUnit(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "show units on API server version 11 not supported")
+}
+
+func (s *ShowUnitSuite) TestShowApiError(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]params.UnitInfoResult, error) {
+		return []params.UnitInfoResult{
+			{Error: &params.Error{Message: "boom"}},
+		}, nil
+	}
+	_, err := s.runShowUnit(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ShowUnitSuite) TestShow(c *gc.C) {
+	out, err := s.runShowUnit(c, "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+wordpress/0:
+  life: alive
+  charm: cs:wordpress-42
+  workload-version: "5.3"
+  machine: "0"
+  charm-state:
+    seen: "yes"
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowRecentHooks(c *gc.C) {
+	queued := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.mockAPI.unitsInfoFunc = func(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+		return []params.UnitInfoResult{{
+			Result: &params.UnitInfo{
+				Tag:  "unit-wordpress-0",
+				Life: "alive",
+				HookExecutions: []params.HookExecution{{
+					Hook:     "install",
+					Outcome:  "succeeded",
+					Queued:   queued,
+					Started:  queued.Add(1500 * time.Millisecond),
+					Finished: queued.Add(time.Minute),
+				}, {
+					Hook:     "config-changed",
+					Outcome:  "failed",
+					Queued:   queued.Add(2 * time.Minute),
+					Started:  queued.Add(2 * time.Minute),
+					Finished: queued.Add(2*time.Minute + 250*time.Millisecond),
+				}},
+			},
+		}}, nil
+	}
+	out, err := s.runShowUnit(c, "wordpress/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+wordpress/0:
+  life: alive
+  recent-hooks:
+  - hook: config-changed
+    outcome: failed
+    started: 2020-04-01 12:02:00Z
+    queued-for: 0s
+    duration: 250ms
+  - hook: install
+    outcome: succeeded
+    started: 2020-04-01 12:00:01Z
+    queued-for: 1.5s
+    duration: 58.5s
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowJSON(c *gc.C) {
+	out, err := s.runShowUnit(c, "wordpress/0", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `{"wordpress/0":{"life":"alive","charm":"cs:wordpress-42","workload-version":"5.3","machine":"0","charm-state":{"seen":"yes"}}}`+"\n")
+}
+
+type mockShowUnitAPI struct {
+	version       int
+	unitsInfoFunc func([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+func (s mockShowUnitAPI) Close() error {
+	return nil
+}
+
+func (s mockShowUnitAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockShowUnitAPI) UnitsInfo(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+	return s.unitsInfoFunc(tags)
+}
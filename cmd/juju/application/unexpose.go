@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	jujucmd "github.com/juju/juju/cmd"
@@ -21,8 +22,12 @@ Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to deny public access to the application.
 An application is unexposed by default when it gets created.
 
+The --endpoints option removes the expose settings of only the given
+endpoints; the application is unexposed once no expose settings remain.
+
 Examples:
     juju unexpose wordpress
+    juju unexpose postgresql --endpoints db
 
 See also: 
     expose`[1:]
@@ -36,6 +41,9 @@ func NewUnexposeCommand() modelcmd.ModelCommand {
 type unexposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       []string
+
+	endpoints string
 }
 
 func (c *unexposeCommand) Info() *cmd.Info {
@@ -47,11 +55,18 @@ func (c *unexposeCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags is part of the cmd.Command interface.
+func (c *unexposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-delimited list of endpoints to unexpose")
+}
+
 func (c *unexposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	c.Endpoints = splitCommaList(c.endpoints)
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -71,5 +86,11 @@ func (c *unexposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Unexpose(c.ApplicationName), block.BlockChange)
+	if len(c.Endpoints) == 0 {
+		return block.ProcessBlockedError(client.Unexpose(c.ApplicationName), block.BlockChange)
+	}
+	return block.ProcessBlockedError(
+		client.UnexposeEndpoints(c.ApplicationName, c.Endpoints),
+		block.BlockChange,
+	)
 }
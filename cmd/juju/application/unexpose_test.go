@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type UnexposeSuite struct {
@@ -81,3 +82,15 @@ func (s *UnexposeSuite) TestBlockUnexpose(c *gc.C) {
 	err = runExpose(c, "some-application-name")
 	s.AssertBlocked(c, err, ".*TestBlockUnexpose.*")
 }
+
+func (s *UnexposeSuite) TestUnexposeEndpoints(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	err := runExpose(c, "some-application-name", "--endpoints", "server", "--to-cidrs", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", true)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", false)
+}
@@ -6,10 +6,12 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"os"
 
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
 
@@ -34,6 +36,10 @@ type debugHooksCommand struct {
 	sshCommand
 	hooks []string
 
+	capture       bool
+	stopCapture   bool
+	fetchCaptures string
+
 	getActionAPI func() (ActionsAPI, error)
 }
 
@@ -42,6 +48,19 @@ Interactively debug hooks or actions remotely on an application unit.
 
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+With --capture, matching hooks and actions are not interrupted. Instead,
+each time one runs, the unit records its environment, config, leadership,
+relation data and storage, and every hook tool it invokes with the result.
+Hooks continue to be captured until --stop-capture is used, and all the
+captures made for a unit can be downloaded with --fetch-captures.
+
+Examples:
+
+    juju debug-hooks mysql/0
+    juju debug-hooks --capture mysql/0 config-changed db-relation-changed
+    juju debug-hooks --stop-capture mysql/0
+    juju debug-hooks --fetch-captures mysql-0-hooks.tar.gz mysql/0
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
@@ -54,6 +73,13 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.BoolVar(&c.capture, "capture", false, "Capture matching hooks as they run, without interrupting them")
+	f.BoolVar(&c.stopCapture, "stop-capture", false, "Stop capturing hooks")
+	f.StringVar(&c.fetchCaptures, "fetch-captures", "", "Download the unit's hook captures to this gzipped tar file")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
@@ -62,6 +88,18 @@ func (c *debugHooksCommand) Init(args []string) error {
 	if !names.IsValidUnit(c.Target) {
 		return errors.Errorf("%q is not a valid unit name", c.Target)
 	}
+	modes := 0
+	for _, on := range []bool{c.capture, c.stopCapture, c.fetchCaptures != ""} {
+		if on {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return errors.New("only one of --capture, --stop-capture and --fetch-captures may be specified")
+	}
+	if (c.stopCapture || c.fetchCaptures != "") && len(args) > 1 {
+		return errors.New("hook or action names cannot be specified with --stop-capture or --fetch-captures")
+	}
 
 	// If any of the hooks is "*", then debug all hooks.
 	c.hooks = append([]string{}, args[1:]...)
@@ -191,9 +229,44 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	var clientScript string
+	switch {
+	case c.capture:
+		clientScript = unitdebug.ClientCaptureScript(debugctx, c.hooks)
+	case c.stopCapture:
+		clientScript = unitdebug.ClientStopCaptureScript(debugctx)
+	case c.fetchCaptures != "":
+		clientScript = unitdebug.ClientFetchCapturesScript(debugctx)
+	default:
+		clientScript = unitdebug.ClientScript(debugctx, c.hooks)
+	}
+	script := base64.StdEncoding.EncodeToString([]byte(clientScript))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
 	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
 	c.Args = args
-	return c.sshCommand.Run(ctx)
+	if !c.capture && !c.stopCapture && c.fetchCaptures == "" {
+		return c.sshCommand.Run(ctx)
+	}
+
+	// Capturing hooks is not interactive, and downloading
+	// captures needs the script's output to be left intact.
+	noPty := false
+	c.pty.b = &noPty
+	if c.fetchCaptures == "" {
+		return c.sshCommand.Run(ctx)
+	}
+	path := ctx.AbsPath(c.fetchCaptures)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	fetchCtx := *ctx
+	fetchCtx.Stdout = f
+	if err := c.sshCommand.Run(&fetchCtx); err != nil {
+		_ = os.Remove(path)
+		return errors.Trace(err)
+	}
+	ctx.Infof("Hook captures for %s written to %s", c.Target, c.fetchCaptures)
+	return nil
 }
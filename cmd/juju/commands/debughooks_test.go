@@ -110,6 +110,32 @@ var debugHooksTests = []struct {
 	args:        []string{"mysql/0", "juju-info-relation-joined"},
 	hostChecker: validAddresses("0.public"),
 	expected:    nil,
+}, {
+	info:        `capture hooks without interrupting them`,
+	args:        []string{"--capture", "mysql/0", "start"},
+	hostChecker: validAddresses("0.public"),
+	expected: &argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		argsMatch:       `ubuntu@0\.public sudo /bin/bash .+`,
+	},
+}, {
+	info:        `stop capturing hooks`,
+	args:        []string{"--stop-capture", "mysql/0"},
+	hostChecker: validAddresses("0.public"),
+	expected: &argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		argsMatch:       `ubuntu@0\.public sudo /bin/bash .+`,
+	},
+}, {
+	info:  `capture modes are exclusive`,
+	args:  []string{"--capture", "--stop-capture", "mysql/0"},
+	error: `only one of --capture, --stop-capture and --fetch-captures may be specified`,
+}, {
+	info:  `hooks cannot be named when stopping captures`,
+	args:  []string{"--stop-capture", "mysql/0", "start"},
+	error: `hook or action names cannot be specified with --stop-capture or --fetch-captures`,
 }, {
 	info:  `invalid unit syntax`,
 	args:  []string{"mysql"},
@@ -58,6 +58,9 @@ func (dummyHookContext) HookRelation() (jujuc.ContextRelation, error) {
 func (dummyHookContext) RemoteUnitName() (string, error) {
 	return "", errors.NotFoundf("RemoteUnitName")
 }
+func (dummyHookContext) RemoteSettingsDiff() (*jujuc.SettingsDiff, error) {
+	return nil, errors.NotFoundf("RemoteSettingsDiff")
+}
 func (dummyHookContext) Relation(id int) (jujuc.ContextRelation, error) {
 	return nil, errors.NotFoundf("Relation")
 }
@@ -64,10 +64,14 @@ Currently available charm hook tools are:
     open-port                register a port or range to open
     opened-ports             lists all ports or ranges opened by the unit
     pod-spec-set             set pod spec information
+    relation-diff            show changes to the remote unit's relation settings
     relation-get             get relation settings
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    state-delete             delete unit charm state
+    state-get                print unit charm state
+    state-set                set unit charm state
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -108,11 +112,15 @@ var expectedCommands = []string{
 	"payload-status-set",
 	"payload-unregister",
 	"pod-spec-set",
+	"relation-diff",
 	"relation-get",
 	"relation-ids",
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"state-delete",
+	"state-get",
+	"state-set",
 	"status-get",
 	"status-set",
 	"storage-add",
@@ -275,6 +275,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(crossmodel.NewShowOfferedEndpointCommand())
 	r.Register(crossmodel.NewListEndpointsCommand())
 	r.Register(crossmodel.NewFindEndpointsCommand())
+	r.Register(crossmodel.NewRevokeOfferConnectionCommand())
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
@@ -299,6 +300,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewPauseUnitCommand())
+	r.Register(application.NewResumeUnitCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 
@@ -371,6 +374,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(model.NewBranchCommand())
 		r.Register(model.NewDiffCommand())
 		r.Register(model.NewAbortCommand())
+		r.Register(model.NewBranchPolicyCommand())
 	}
 
 	r.Register(newMigrateCommand())
@@ -386,6 +390,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewListCommand())
 	r.Register(action.NewShowCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewScheduleCommand())
+	r.Register(action.NewSchedulesCommand())
+	r.Register(action.NewUnscheduleCommand())
 	if featureflag.Enabled(feature.JujuV3) {
 		r.Register(action.NewCallCommand())
 	} else {
@@ -400,12 +407,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewApplyCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewApplicationGetConstraintsCommand())
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewShowUnitCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -424,6 +433,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewCreateSnapshotCommand())
+	r.Register(storage.NewListSnapshotsCommand())
+	r.Register(storage.NewResizeStorageCommand())
+	r.Register(storage.NewMoveStorageCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -439,6 +439,7 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"agreements",
+	"apply",
 	"attach",
 	"attach-resource",
 	"attach-storage",
@@ -459,6 +460,7 @@ var commandNames = []string{
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"create-wallet",
 	"credentials",
 	"debug-hook",
@@ -513,10 +515,12 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-schedules",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -529,8 +533,10 @@ var commandNames = []string{
 	"model-default",
 	"model-defaults",
 	"models",
+	"move-storage",
 	"offer",
 	"offers",
+	"pause-unit",
 	"payloads",
 	"plans",
 	"regions",
@@ -553,16 +559,21 @@ var commandNames = []string{
 	"remove-storage-pool",
 	"remove-unit",
 	"remove-user",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
 	"restore-backup",
 	"resume-relation",
+	"resume-unit",
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
+	"revoke-offer-connection",
 	"run",
 	"scale-application",
+	"schedule-action",
+	"schedules",
 	"scp",
 	"set-credential",
 	"set-constraints",
@@ -587,6 +598,7 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
 	"show-wallet",
 	"sla",
@@ -596,6 +608,7 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
@@ -604,6 +617,7 @@ var commandNames = []string{
 	"trust",
 	"unexpose",
 	"unregister",
+	"unschedule-action",
 	"update-cloud",
 	"update-clouds",
 	"update-public-clouds",
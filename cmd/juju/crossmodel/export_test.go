@@ -70,3 +70,11 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore, api RemoveAPI) cmd.Co
 	aCmd.SetClientStore(store)
 	return modelcmd.WrapController(aCmd)
 }
+
+func NewRevokeOfferConnectionCommandForTest(store jujuclient.ClientStore, api RevokeConnectionAPI) cmd.Command {
+	aCmd := &revokeConnectionCommand{newAPIFunc: func(controllerName string) (RevokeConnectionAPI, error) {
+		return api, nil
+	}}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
@@ -29,6 +29,10 @@ relation id of the relation.
 
 The summary output shows one row per offer, with a count of active/total relations.
 
+The --connections option changes the tabular output to show one row per
+connection, including the consuming model and the relation status and message.
+The relation id shown can be used with revoke-offer-connection.
+
 The YAML output shows additional information about the source of connections, including
 the source model UUID.
 
@@ -48,10 +52,12 @@ Examples:
     $ juju offers --allowed-consumer mary
     $ juju offers hosted-mysql
     $ juju offers hosted-mysql --active-only
+    $ juju offers --connections
 
 See also:
    find-offers   
    show-offer
+   revoke-offer-connection
 `
 
 // listCommand returns storage instances.
@@ -64,6 +70,7 @@ type listCommand struct {
 	refreshModels func(jujuclient.ClientStore, string) error
 
 	activeOnly        bool
+	showConnections   bool
 	interfaceName     string
 	applicationName   string
 	connectedUserName string
@@ -121,6 +128,7 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.consumerName, "allowed-consumer", "", "return results where the user is allowed to consume the offer")
 	f.StringVar(&c.connectedUserName, "connected-user", "", "return results where the user has a connection to the offer")
 	f.BoolVar(&c.activeOnly, "active-only", false, "only return results where the offer is in use")
+	f.BoolVar(&c.showConnections, "connections", false, "show one row per connection to the offers in tabular output")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -190,6 +198,9 @@ func (c *listCommand) Run(ctx *cmd.Context) (err error) {
 		return errors.Annotate(err, "failed to format found applications")
 	}
 
+	if c.showConnections && c.out.Name() == "tabular" {
+		return c.out.WriteFormatter(ctx, formatListConnectionsTabular, data)
+	}
 	return c.out.Write(ctx, data)
 }
 
@@ -224,6 +235,10 @@ type ListOfferItem struct {
 
 	// Users are the users who can consume the offer.
 	Users map[string]OfferUser `yaml:"users,omitempty" json:"users,omitempty"`
+
+	// MaxConnections is the maximum number of concurrent connections
+	// allowed to the offer, or 0 if there is no limit.
+	MaxConnections int `yaml:"max-connections,omitempty" json:"max-connections,omitempty"`
 }
 
 type offeredApplications map[string]ListOfferItem
@@ -272,6 +287,7 @@ func convertOfferToListItem(url *crossmodel.OfferURL, offer *crossmodel.Applicat
 		OfferURL:        offer.OfferURL,
 		Endpoints:       convertCharmEndpoints(offer.Endpoints...),
 		Users:           convertUsers(offer.Users...),
+		MaxConnections:  offer.MaxConnections,
 	}
 	for _, conn := range offer.Connections {
 		item.Connections = append(item.Connections, offerConnectionDetails{
@@ -220,6 +220,25 @@ zdiff-db2  fred  1            joined  server    mysql      provider
 	)
 }
 
+func (s *ListSuite) TestListConnections(c *gc.C) {
+	s.setupListTabular()
+	s.applications[1].MaxConnections = 5
+	s.assertValidList(
+		c,
+		[]string{"--connections"},
+		`
+Offer       Connected  Relation id  User  Source model  Endpoint  Status  Since  Message
+adiff-db2   1          3            mary  model-uuid3   db        joined         
+hosted-db2  0          -                                                         
+zdiff-db2   3/5        1            fred  model-uuid2   server    joined         
+                       1            mary  model-uuid3   server    joined         
+                       2            mary  model-uuid1   db        joined         
+
+`[1:],
+		"",
+	)
+}
+
 func (s *ListSuite) TestListYAML(c *gc.C) {
 	// Since applications are in the map and ordering is unreliable, ensure that there is only one endpoint.
 	// We only need one to demonstrate display anyway :D
@@ -140,6 +140,53 @@ func formatListEndpointsTabular(writer io.Writer, offers offeredApplications) er
 	return nil
 }
 
+func formatListConnectionsTabular(writer io.Writer, value interface{}) error {
+	offers, ok := value.(offeredApplications)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", offers, value)
+	}
+	return formatListOfferConnectionsTabular(writer, offers)
+}
+
+// formatListOfferConnectionsTabular returns a tabular listing with one row
+// per connection to the listed offers.
+func formatListOfferConnectionsTabular(writer io.Writer, offers offeredApplications) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	allOffers := offerItems{}
+	for _, offer := range offers {
+		allOffers = append(allOffers, offer)
+	}
+	sort.Sort(allOffers)
+
+	w.Println("Offer", "Connected", "Relation id", "User", "Source model", "Endpoint", "Status", "Since", "Message")
+	for _, offer := range allOffers {
+		connected := fmt.Sprint(len(offer.Connections))
+		if offer.MaxConnections > 0 {
+			connected = fmt.Sprintf("%d/%d", len(offer.Connections), offer.MaxConnections)
+		}
+		if len(offer.Connections) == 0 {
+			w.Println(offer.OfferName, connected, "-", "", "", "", "", "", "")
+			continue
+		}
+
+		sort.Sort(byUserRelationId(offer.Connections))
+		for i, conn := range offer.Connections {
+			if i == 0 {
+				w.Print(offer.OfferName, connected)
+			} else {
+				w.Print("", "")
+			}
+			w.Print(conn.RelationId, conn.Username, conn.SourceModelUUID, conn.Endpoint)
+			w.PrintColor(RelationStatusColor(relation.Status(conn.Status.Current)), conn.Status.Current)
+			w.Println(conn.Status.Since, conn.Status.Message)
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
 // RelationStatusColor returns a context used to print the status with the relevant color.
 func RelationStatusColor(status relation.Status) *ansiterm.Context {
 	switch status {
@@ -26,16 +26,23 @@ Deployed application endpoints are offered for use by consumers.
 By default, the offer is named after the application, unless
 an offer name is explicitly specified.
 
+The number of consumers which may be connected to the offer at
+any one time can be limited using the --max-connections option.
+Offering the endpoints again without the option removes the limit.
+
 Examples:
 
 $ juju offer mysql:db
 $ juju offer mymodel.mysql:db
 $ juju offer db2:db hosted-db2
 $ juju offer db2:db,log hosted-db2
+$ juju offer db2:db hosted-db2 --max-connections 5
 
 See also:
     consume
+    offers
     relate
+    revoke-offer-connection
 `
 )
 
@@ -66,6 +73,10 @@ type offerCommand struct {
 
 	// QualifiedModelName stores the name of the model hosting the offer.
 	QualifiedModelName string
+
+	// MaxConnections is the maximum number of consumers which may be
+	// connected to the offer at any one time, or 0 for no limit.
+	MaxConnections int
 }
 
 // NewApplicationOffersAPI returns an application offers api for the root api endpoint
@@ -99,12 +110,16 @@ func (c *offerCommand) Init(args []string) error {
 		argCount = 2
 		c.OfferName = args[1]
 	}
+	if c.MaxConnections < 0 {
+		return errors.New("--max-connections must not be negative")
+	}
 	return cmd.CheckEmpty(args[argCount:])
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.IntVar(&c.MaxConnections, "max-connections", 0, "Maximum number of consumers connected to the offer at any one time")
 }
 
 // Run implements Command.Run.
@@ -151,7 +166,13 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 		c.OfferName = c.Application
 	}
 	// TODO (anastasiamac 2015-11-16) Add a sensible way for user to specify long-ish (at times) description when offering
-	results, err := api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	var results []params.ErrorResult
+	if c.MaxConnections > 0 {
+		results, err = api.OfferWithMaxConnections(
+			modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "", c.MaxConnections)
+	} else {
+		results, err = api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	}
 	if err != nil {
 		return err
 	}
@@ -173,6 +194,7 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 type OfferAPI interface {
 	Close() error
 	Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error)
+	OfferWithMaxConnections(modelUUID, application string, endpoints []string, offerName string, desc string, maxConnections int) ([]params.ErrorResult, error)
 }
 
 // applicationParse is used to split an application string
@@ -107,6 +107,17 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db", "admin"})
 }
 
+func (s *offerSuite) TestOfferMaxConnections(c *gc.C) {
+	s.args = []string{"tst:db", "--max-connections", "3"}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	c.Assert(s.mockAPI.maxConnections["tst"], gc.Equals, 3)
+}
+
+func (s *offerSuite) TestOfferNegativeMaxConnections(c *gc.C) {
+	s.args = []string{"tst:db", "--max-connections", "-1"}
+	s.assertOfferErrorOutput(c, "--max-connections must not be negative")
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, expectedApplication string, endpoints []string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -119,6 +130,7 @@ type mockOfferAPI struct {
 	offers           map[string][]string
 	applications     map[string]string
 	descs            map[string]string
+	maxConnections   map[string]int
 }
 
 func newMockOfferAPI() *mockOfferAPI {
@@ -126,6 +138,7 @@ func newMockOfferAPI() *mockOfferAPI {
 	mock.offers = make(map[string][]string)
 	mock.descs = make(map[string]string)
 	mock.applications = make(map[string]string)
+	mock.maxConnections = make(map[string]int)
 	return mock
 }
 
@@ -151,3 +164,13 @@ func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string,
 	s.descs[offerName] = desc
 	return result, nil
 }
+
+func (s *mockOfferAPI) OfferWithMaxConnections(
+	modelUUID, application string, endpoints []string, offerName, desc string, maxConnections int,
+) ([]params.ErrorResult, error) {
+	result, err := s.Offer(modelUUID, application, endpoints, offerName, desc)
+	if err == nil {
+		s.maxConnections[offerName] = maxConnections
+	}
+	return result, err
+}
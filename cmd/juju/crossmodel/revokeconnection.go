@@ -0,0 +1,181 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/applicationoffers"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// NewRevokeOfferConnectionCommand returns a command used to remove or
+// suspend a consumer's connection to an offer.
+func NewRevokeOfferConnectionCommand() cmd.Command {
+	revokeCmd := &revokeConnectionCommand{}
+	revokeCmd.newAPIFunc = func(controllerName string) (RevokeConnectionAPI, error) {
+		return revokeCmd.NewApplicationOffersAPI(controllerName)
+	}
+	return modelcmd.WrapController(revokeCmd)
+}
+
+type revokeConnectionCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func(string) (RevokeConnectionAPI, error)
+
+	offerURL   string
+	relationId int
+
+	suspend   bool
+	message   string
+	assumeYes bool
+}
+
+const revokeOfferConnectionDoc = `
+Remove, or suspend, a consumer's connection to an offer.
+
+A connection is identified by the offer and the id of the relation
+backing the connection in the offering model, as shown by
+"juju offers --connections".
+
+By default the relation is removed in the offering model, and the
+consuming model removes its side of the relation in turn. The consumer
+may relate to the offer again while they hold consume access to it;
+use "juju revoke" to prevent this.
+
+If the --suspend option is specified, the relation is suspended
+instead of being removed, and can later be resumed with
+"juju resume-relation" in the offering model.
+
+The offer may be specified by its URL or just by its name, in which
+case the offer is considered to reside in the current model.
+
+Examples:
+
+    juju revoke-offer-connection hosted-mysql 3
+    juju revoke-offer-connection fred/prod.hosted-mysql 3 -y
+    juju revoke-offer-connection hosted-mysql 3 --suspend --message "maintenance"
+
+See also:
+    offers
+    offer
+    revoke
+    suspend-relation
+`
+
+// Info implements Command.Info.
+func (c *revokeConnectionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke-offer-connection",
+		Args:    "<offer-url> <relation-id>",
+		Purpose: "Removes or suspends a connection to an offer.",
+		Doc:     revokeOfferConnectionDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *revokeConnectionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.suspend, "suspend", false, "suspend the connection rather than removing it")
+	f.StringVar(&c.message, "message", "", "reason for suspension, shown to the consumer")
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+}
+
+// Init implements Command.Init.
+func (c *revokeConnectionCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("an offer and relation id must be specified")
+	}
+	c.offerURL = args[0]
+	relationId, err := strconv.Atoi(args[1])
+	if err != nil || relationId < 0 {
+		return errors.NotValidf("relation ID %q", args[1])
+	}
+	c.relationId = relationId
+	if c.message != "" && !c.suspend {
+		return errors.New("--message can only be used with --suspend")
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// RevokeConnectionAPI defines the API methods that the revoke offer
+// connection command uses.
+type RevokeConnectionAPI interface {
+	Close() error
+	RevokeOfferConnection(offerURL string, relationId int) error
+	SuspendOfferConnection(offerURL string, relationId int, reason string) error
+}
+
+// NewApplicationOffersAPI returns an application offers api.
+func (c *revokeConnectionCommand) NewApplicationOffersAPI(controllerName string) (*applicationoffers.Client, error) {
+	root, err := c.CommandBase.NewAPIRoot(c.ClientStore(), controllerName, "")
+	if err != nil {
+		return nil, err
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+var revokeOfferConnectionMsg = `
+WARNING! This command will remove relation %d to offer %v
+in both the offering and the consuming model.
+
+Continue [y/N]? `[1:]
+
+// Run implements Command.Run.
+func (c *revokeConnectionCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	url, err := crossmodel.ParseOfferURL(c.offerURL)
+	if err != nil {
+		currentModel, err := c.ClientStore().CurrentModel(controllerName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if url, err = makeURLFromCurrentModel(c.offerURL, "", currentModel); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	offerSource := url.Source
+	if offerSource == "" {
+		offerSource = controllerName
+	}
+	url.Source = ""
+	offerURL := url.String()
+
+	if !c.assumeYes && !c.suspend {
+		fmt.Fprintf(ctx.Stdout, revokeOfferConnectionMsg, c.relationId, offerURL)
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "offer connection removal")
+		}
+	}
+
+	api, err := c.newAPIFunc(offerSource)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	blockType := block.BlockRemove
+	if c.suspend {
+		blockType = block.BlockChange
+		err = api.SuspendOfferConnection(offerURL, c.relationId, c.message)
+	} else {
+		err = api.RevokeOfferConnection(offerURL, c.relationId)
+	}
+	if errors.IsNotImplemented(err) {
+		return errors.NotSupportedf("on this juju controller, revoke-offer-connection")
+	}
+	return block.ProcessBlockedError(err, blockType)
+}
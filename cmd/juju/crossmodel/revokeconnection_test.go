@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type revokeConnectionSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockRevokeConnectionAPI
+}
+
+var _ = gc.Suite(&revokeConnectionSuite{})
+
+func (s *revokeConnectionSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	s.mockAPI = &mockRevokeConnectionAPI{}
+}
+
+func (s *revokeConnectionSuite) runRevoke(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewRevokeOfferConnectionCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *revokeConnectionSuite) TestInitNoArgs(c *gc.C) {
+	_, err := s.runRevoke(c)
+	c.Assert(err, gc.ErrorMatches, "an offer and relation id must be specified")
+}
+
+func (s *revokeConnectionSuite) TestInitBadRelationId(c *gc.C) {
+	_, err := s.runRevoke(c, "fred/model.db2", "foo")
+	c.Assert(err, gc.ErrorMatches, `relation ID "foo" not valid`)
+}
+
+func (s *revokeConnectionSuite) TestInitMessageWithoutSuspend(c *gc.C) {
+	_, err := s.runRevoke(c, "fred/model.db2", "1", "--message", "why")
+	c.Assert(err, gc.ErrorMatches, "--message can only be used with --suspend")
+}
+
+func (s *revokeConnectionSuite) TestRevoke(c *gc.C) {
+	_, err := s.runRevoke(c, "fred/model.db2", "3", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"RevokeOfferConnection", []interface{}{"fred/model.db2", 3}},
+		{"Close", nil},
+	})
+}
+
+func (s *revokeConnectionSuite) TestRevokeNameOnly(c *gc.C) {
+	_, err := s.runRevoke(c, "db2", "3", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "RevokeOfferConnection", "fred/test.db2", 3)
+}
+
+func (s *revokeConnectionSuite) TestRevokePrompts(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("n\n")
+	com := crossmodel.NewRevokeOfferConnectionCommandForTest(s.store, s.mockAPI)
+	err := cmdtesting.InitCommand(com, []string{"fred/model.db2", "3"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = com.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "offer connection removal: aborted")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+WARNING! This command will remove relation 3 to offer fred/model.db2
+in both the offering and the consuming model.
+
+Continue [y/N]? `[1:])
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *revokeConnectionSuite) TestSuspend(c *gc.C) {
+	_, err := s.runRevoke(c, "fred/model.db2", "3", "--suspend", "--message", "maintenance")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"SuspendOfferConnection", []interface{}{"fred/model.db2", 3, "maintenance"}},
+		{"Close", nil},
+	})
+}
+
+func (s *revokeConnectionSuite) TestRevokeOldAPI(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotImplementedf("RevokeOfferConnections()"))
+	_, err := s.runRevoke(c, "fred/model.db2", "3", "-y")
+	c.Assert(err, gc.ErrorMatches, "on this juju controller, revoke-offer-connection not supported")
+}
+
+type mockRevokeConnectionAPI struct {
+	jujutesting.Stub
+}
+
+func (m *mockRevokeConnectionAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockRevokeConnectionAPI) RevokeOfferConnection(offerURL string, relationId int) error {
+	m.MethodCall(m, "RevokeOfferConnection", offerURL, relationId)
+	return m.NextErr()
+}
+
+func (m *mockRevokeConnectionAPI) SuspendOfferConnection(offerURL string, relationId int, reason string) error {
+	m.MethodCall(m, "SuspendOfferConnection", offerURL, relationId, reason)
+	return m.NextErr()
+}
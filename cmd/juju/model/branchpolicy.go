@@ -0,0 +1,169 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+)
+
+const (
+	branchPolicySummary = "Sets a policy for automatically committing or aborting a branch."
+	branchPolicyDoc     = `
+A branch promotion policy allows a branch to be committed automatically
+once the units tracking it are known to be healthy, or backed out when
+they are not.
+
+A branch is committed when either all of its tracking units have reported
+an "active" workload status for the duration supplied with --healthy-for,
+or when the action named with --action has completed successfully on all
+of them. At least one of these conditions must be supplied.
+
+If a tracking unit goes into an error state, the --on-error option
+determines the outcome. With "abort" (the default), all units stop tracking
+the branch and the branch is aborted. With "untrack", only the units in
+error stop tracking the branch, which otherwise remains in-flight.
+
+Decisions made by the policy are recorded in the model's status history.
+
+Examples:
+    juju branch-policy upgrade-postgresql --healthy-for 10m
+    juju branch-policy upgrade-postgresql --action smoke-test --on-error untrack
+    juju branch-policy upgrade-postgresql --clear
+
+See also:
+    add-branch
+    track
+    branch
+    commit
+    abort
+`
+)
+
+// NewBranchPolicyCommand wraps branchPolicyCommand with sane model settings.
+func NewBranchPolicyCommand() cmd.Command {
+	return modelcmd.Wrap(&branchPolicyCommand{})
+}
+
+// branchPolicyCommand supplies the "branch-policy" CLI command used to set
+// or clear the promotion policy of an in-flight branch.
+type branchPolicyCommand struct {
+	modelcmd.ModelCommandBase
+
+	api BranchPolicyCommandAPI
+
+	branchName string
+	healthyFor time.Duration
+	actionName string
+	onError    string
+	clear      bool
+}
+
+// BranchPolicyCommandAPI describes API methods required
+// to execute the branch-policy command.
+//
+//go:generate mockgen -package mocks -destination ./mocks/branchpolicy_mock.go github.com/juju/juju/cmd/juju/model BranchPolicyCommandAPI
+type BranchPolicyCommandAPI interface {
+	Close() error
+
+	// SetBranchPromotionPolicy sets or clears the
+	// promotion policy for the input branch.
+	SetBranchPromotionPolicy(branchName string, policy *model.PromotionPolicy) error
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *branchPolicyCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "branch-policy",
+		Args:    "<branch name>",
+		Purpose: branchPolicySummary,
+		Doc:     branchPolicyDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *branchPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.healthyFor, "healthy-for", 0, "Commit once all tracking units are active for this long")
+	f.StringVar(&c.actionName, "action", "", "Commit once this action completes on all tracking units")
+	f.StringVar(&c.onError, "on-error", string(model.BranchErrorAbort), `What to do when a tracking unit errors: "abort" or "untrack"`)
+	f.BoolVar(&c.clear, "clear", false, "Remove the promotion policy from the branch")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *branchPolicyCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf("expected a branch name")
+	}
+	if err := model.ValidateBranchName(args[0]); err != nil {
+		return err
+	}
+	c.branchName = args[0]
+
+	if c.clear {
+		if c.healthyFor != 0 || c.actionName != "" {
+			return errors.New("--clear cannot be used with --healthy-for or --action")
+		}
+		return nil
+	}
+	return c.policy().Validate()
+}
+
+func (c *branchPolicyCommand) policy() *model.PromotionPolicy {
+	if c.clear {
+		return nil
+	}
+	return &model.PromotionPolicy{
+		HealthyFor: c.healthyFor,
+		ActionName: c.actionName,
+		OnError:    model.BranchErrorPolicy(c.onError),
+	}
+}
+
+// getAPI returns the API that supplies methods
+// required to execute this command.
+func (c *branchPolicyCommand) getAPI() (BranchPolicyCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *branchPolicyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	policy := c.policy()
+	if err = client.SetBranchPromotionPolicy(c.branchName, policy); err != nil {
+		return err
+	}
+
+	var msg string
+	if policy == nil {
+		msg = fmt.Sprintf("Promotion policy removed from branch %q\n", c.branchName)
+	} else {
+		msg = fmt.Sprintf("Branch %q will %s\n", c.branchName, policy)
+	}
+	_, err = ctx.Stdout.Write([]byte(msg))
+	return err
+}
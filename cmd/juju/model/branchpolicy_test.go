@@ -0,0 +1,107 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+)
+
+type branchPolicySuite struct {
+	generationBaseSuite
+}
+
+var _ = gc.Suite(&branchPolicySuite{})
+
+func (s *branchPolicySuite) TestInit(c *gc.C) {
+	err := s.runInit(s.branchName, "--healthy-for", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *branchPolicySuite) TestInitNoName(c *gc.C) {
+	err := s.runInit("--healthy-for", "10m")
+	c.Assert(err, gc.ErrorMatches, "expected a branch name")
+}
+
+func (s *branchPolicySuite) TestInitInvalidName(c *gc.C) {
+	err := s.runInit(coremodel.GenerationMaster, "--healthy-for", "10m")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *branchPolicySuite) TestInitNoCondition(c *gc.C) {
+	err := s.runInit(s.branchName)
+	c.Assert(err, gc.ErrorMatches, "promotion policy without a healthy duration or action not valid")
+}
+
+func (s *branchPolicySuite) TestInitInvalidOnError(c *gc.C) {
+	err := s.runInit(s.branchName, "--action", "smoke-test", "--on-error", "ignore")
+	c.Assert(err, gc.ErrorMatches, `branch error policy "ignore" not valid`)
+}
+
+func (s *branchPolicySuite) TestInitClearWithCondition(c *gc.C) {
+	err := s.runInit(s.branchName, "--clear", "--action", "smoke-test")
+	c.Assert(err, gc.ErrorMatches, "--clear cannot be used with --healthy-for or --action")
+}
+
+func (s *branchPolicySuite) TestRunCommand(c *gc.C) {
+	ctrl, api := setUpBranchPolicyMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetBranchPromotionPolicy(s.branchName, &coremodel.PromotionPolicy{
+		HealthyFor: 10 * time.Minute,
+		ActionName: "smoke-test",
+		OnError:    coremodel.BranchErrorUntrack,
+	}).Return(nil)
+
+	ctx, err := s.runCommand(c, api, "--healthy-for", "10m", "--action", "smoke-test", "--on-error", "untrack")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`Branch "new-branch" will commit when active for 10m0s or action "smoke-test" succeeded; untrack on error`+"\n")
+}
+
+func (s *branchPolicySuite) TestRunCommandClear(c *gc.C) {
+	ctrl, api := setUpBranchPolicyMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetBranchPromotionPolicy(s.branchName, nil).Return(nil)
+
+	ctx, err := s.runCommand(c, api, "--clear")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "Promotion policy removed from branch \"new-branch\"\n")
+}
+
+func (s *branchPolicySuite) TestRunCommandFail(c *gc.C) {
+	ctrl, api := setUpBranchPolicyMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetBranchPromotionPolicy(s.branchName, gomock.Any()).Return(errors.Errorf("fail"))
+
+	_, err := s.runCommand(c, api, "--healthy-for", "5m")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *branchPolicySuite) runInit(args ...string) error {
+	return cmdtesting.InitCommand(model.NewBranchPolicyCommandForTest(nil, s.store), args)
+}
+
+func (s *branchPolicySuite) runCommand(c *gc.C, api model.BranchPolicyCommandAPI, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewBranchPolicyCommandForTest(api, s.store), append([]string{s.branchName}, args...)...)
+}
+
+func setUpBranchPolicyMocks(c *gc.C) (*gomock.Controller, *mocks.MockBranchPolicyCommandAPI) {
+	ctrl := gomock.NewController(c)
+	api := mocks.NewMockBranchPolicyCommandAPI(ctrl)
+	api.EXPECT().Close()
+	return ctrl, api
+}
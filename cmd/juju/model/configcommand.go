@@ -33,6 +33,12 @@ are displayed.
 Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys. You can also specify a yaml file containing key values.
+
+The --space option displays the values that apply to machines in the given
+space, with any proxy and apt-mirror overrides for the space taken from the
+space-proxy-settings key. Machines in several spaces get the overrides of
+all of them; if those spaces override a setting with different values, the
+machines get no proxy settings until the conflict is resolved.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -46,6 +52,7 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --space dmz juju-http-proxy
 
 See also:
     models
@@ -74,6 +81,7 @@ type configCommand struct {
 	reset      []string // Holds the keys to be reset until parsed.
 	resetKeys  []string // Holds the keys to be reset once parsed.
 	setOptions common.ConfigFlag
+	space      string
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -118,6 +126,7 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.StringVar(&c.space, "space", "", "Display the values that apply to machines in the given space")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -186,6 +195,9 @@ func (c *configCommand) handleArgs(args []string) error {
 // parseSetKeys iterates over the args and make sure that the key=value pairs
 // are valid.
 func (c *configCommand) parseSetKeys(args []string) error {
+	if c.space != "" {
+		return errors.New("cannot use --space when setting model values")
+	}
 	for _, arg := range args {
 		if err := c.setOptions.Set(arg); err != nil {
 			return errors.Trace(err)
@@ -202,6 +214,9 @@ func (c *configCommand) parseResetKeys() error {
 	if len(c.reset) == 0 {
 		return nil
 	}
+	if c.space != "" {
+		return errors.New("cannot use --space when resetting model values")
+	}
 	var resetKeys []string
 	for _, value := range c.reset {
 		keys := strings.Split(strings.Trim(value, ","), ",")
@@ -317,6 +332,12 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 		}
 	}
 
+	if c.space != "" {
+		if err := applySpaceOverrides(attrs, c.space); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if len(c.keys) == 1 {
 		key := c.keys[0]
 		if value, found := attrs[key]; found {
@@ -353,6 +374,26 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return c.out.Write(ctx, attrs)
 }
 
+// applySpaceOverrides replaces the values in attrs with those from
+// space-proxy-settings for the given space.
+func applySpaceOverrides(attrs config.ConfigValues, space string) error {
+	raw, _ := attrs[config.SpaceProxySettingsKey].Value.(string)
+	if raw == "" {
+		return nil
+	}
+	settings, err := config.ParseSpaceProxySettings(raw)
+	if err != nil {
+		return errors.Annotate(err, config.SpaceProxySettingsKey)
+	}
+	for key, value := range settings[space] {
+		attrs[key] = config.ConfigValue{
+			Value:  value,
+			Source: "space",
+		}
+	}
+	return nil
+}
+
 // verifyKnownKeys is a helper to validate the keys we are operating with
 // against the set of known attributes from the model.
 func (c *configCommand) verifyKnownKeys(client configCommandAPI, keys []string) error {
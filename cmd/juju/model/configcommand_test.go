@@ -51,6 +51,18 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:       "reset cannot have k=v pairs",
 			args:       []string{"--reset", "a,b,c=d,e"},
 			errorMatch: `--reset accepts a comma delimited set of keys "a,b,c", received: "c=d"`,
+		}, {
+			desc:       "space cannot be used with reset",
+			args:       []string{"--space", "dmz", "--reset", "one"},
+			errorMatch: "cannot use --space when resetting model values",
+		}, {
+			desc:       "space cannot be used with set",
+			args:       []string{"--space", "dmz", "special=foo"},
+			errorMatch: "cannot use --space when setting model values",
+		}, {
+			desc:   "space can be used with get",
+			args:   []string{"--space", "dmz", "one"},
+			nilErr: true,
 		}, {
 			// Test get
 			desc:   "get all succeeds",
@@ -171,6 +183,26 @@ func (s *ConfigCommandSuite) TestAllValuesTabular(c *gc.C) {
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *ConfigCommandSuite) TestSpaceValues(c *gc.C) {
+	s.fake.values["juju-http-proxy"] = "http://model-proxy:3128"
+	s.fake.values["space-proxy-settings"] = "dmz: {juju-http-proxy: 'http://dmz-proxy:3128'}"
+
+	context, err := s.run(c, "--space", "dmz", "juju-http-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "http://dmz-proxy:3128\n")
+
+	context, err = s.run(c, "--space", "dmz", "--format=yaml", "juju-http-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"juju-http-proxy:\n"+
+		"  value: http://dmz-proxy:3128\n"+
+		"  source: space\n")
+
+	context, err = s.run(c, "--space", "internal", "juju-http-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "http://model-proxy:3128\n")
+}
+
 func (s *ConfigCommandSuite) TestSetAgentVersion(c *gc.C) {
 	_, err := s.run(c, "agent-version=2.0.0")
 	c.Assert(err, gc.ErrorMatches, `"agent-version"" must be set via "upgrade-model"`)
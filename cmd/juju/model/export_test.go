@@ -197,6 +197,14 @@ func NewAbortCommandForTest(api AbortCommandAPI, store jujuclient.ClientStore) c
 	return modelcmd.Wrap(cmd)
 }
 
+func NewBranchPolicyCommandForTest(api BranchPolicyCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &branchPolicyCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewCommitCommandForTest(api CommitCommandAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &commitCommand{
 		api: api,
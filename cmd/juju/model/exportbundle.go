@@ -5,6 +5,7 @@ package model
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -35,6 +36,7 @@ type exportBundleCommand struct {
 	out        cmd.Output
 	newAPIFunc func() (ExportBundleAPI, ConfigAPI, error)
 	Filename   string
+	Overlay    string
 }
 
 const exportBundleHelpDoc = `
@@ -43,10 +45,16 @@ Exports the current model configuration as a reusable bundle.
 If --filename is not used, the configuration is printed to stdout.
  --filename specifies an output file.
 
+If --overlay is used, values specific to this model, such as machines,
+placement directives, trust and offer details, are written to the given
+overlay file instead of the bundle. The resulting bundle can then be
+deployed to other models, and the overlay applied to recreate this one.
+
 Examples:
 
     juju export-bundle
 	juju export-bundle --filename mymodel.yaml
+	juju export-bundle --filename mymodel.yaml --overlay production.yaml
 
 `
 
@@ -63,6 +71,7 @@ func (c *exportBundleCommand) Info() *cmd.Info {
 func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Bundle file")
+	f.StringVar(&c.Overlay, "overlay", "", "Overlay file for model specific values")
 }
 
 // Init implements Command.
@@ -74,7 +83,7 @@ func (c *exportBundleCommand) Init(args []string) error {
 type ExportBundleAPI interface {
 	BestAPIVersion() int
 	Close() error
-	ExportBundle() (string, error)
+	ExportBundle(splitOverlay bool) (string, error)
 }
 
 // ConfigAPI specifies the used function calls of the ApplicationFacade.
@@ -103,7 +112,7 @@ func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
 		_ = cfgClient.Close()
 	}()
 
-	result, err := bundleClient.ExportBundle()
+	result, err := bundleClient.ExportBundle(c.Overlay != "")
 	if err != nil {
 		return err
 	}
@@ -117,24 +126,45 @@ func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if c.Overlay != "" {
+		var overlay string
+		if parts := strings.SplitN(result, overlaySeparator, 2); len(parts) == 2 {
+			result, overlay = parts[0], parts[1]
+		}
+		if err := writeFile(c.Overlay, overlay); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Overlay successfully exported to %s", c.Overlay)
+	}
+
 	if c.Filename == "" {
 		_, err := fmt.Fprintf(ctx.Stdout, "%v", result)
 		return err
 	}
-	filename := c.Filename
+	if err := writeFile(c.Filename, result); err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintln(ctx.Stdout, "Bundle successfully exported to", c.Filename)
+
+	return nil
+}
+
+// overlaySeparator separates the base bundle from the overlay in the
+// multi-document YAML returned by the controller.
+const overlaySeparator = "--- # overlay.yaml\n"
+
+func writeFile(filename, content string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return errors.Annotate(err, "while creating local file")
 	}
 	defer file.Close()
 
-	_, err = file.WriteString(result)
+	_, err = file.WriteString(content)
 	if err != nil {
 		return errors.Annotate(err, "while copying in local file")
 	}
-
-	fmt.Fprintln(ctx.Stdout, "Bundle successfully exported to", filename)
-
 	return nil
 }
 
@@ -96,7 +96,7 @@ func (s *ExportBundleCommandSuite) TestExportBundleSuccessNoFilename(c *gc.C) {
 	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store))
 	c.Assert(err, jc.ErrorIsNil)
 	s.fakeBundle.CheckCalls(c, []jujutesting.StubCall{
-		{"ExportBundle", nil},
+		{"ExportBundle", []interface{}{false}},
 	})
 
 	out := cmdtesting.Stdout(ctx)
@@ -139,7 +139,7 @@ func (s *ExportBundleCommandSuite) TestExportBundleSuccessFilename(c *gc.C) {
 	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--filename", s.fakeBundle.filename)
 	c.Assert(err, jc.ErrorIsNil)
 	s.fakeBundle.CheckCalls(c, []jujutesting.StubCall{
-		{"ExportBundle", nil},
+		{"ExportBundle", []interface{}{false}},
 	})
 
 	out := cmdtesting.Stdout(ctx)
@@ -173,7 +173,7 @@ func (s *ExportBundleCommandSuite) TestExportBundleSuccesssOverwriteFilename(c *
 	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--filename", s.fakeBundle.filename)
 	c.Assert(err, jc.ErrorIsNil)
 	s.fakeBundle.CheckCalls(c, []jujutesting.StubCall{
-		{"ExportBundle", nil},
+		{"ExportBundle", []interface{}{false}},
 	})
 
 	out := cmdtesting.Stdout(ctx)
@@ -183,6 +183,50 @@ func (s *ExportBundleCommandSuite) TestExportBundleSuccesssOverwriteFilename(c *
 	c.Assert(string(output), gc.Equals, "fake-data")
 }
 
+func (s *ExportBundleCommandSuite) TestExportBundleSplitOverlay(c *gc.C) {
+	dir := c.MkDir()
+	s.fakeBundle.filename = filepath.Join(dir, "mymodel")
+	overlayFile := filepath.Join(dir, "overlay.yaml")
+	s.fakeBundle.bestAPIVersion = 5
+	s.fakeBundle.result = "" +
+		"applications:\n" +
+		"  mysql:\n" +
+		"    charm: cs:mysql\n" +
+		"    num_units: 1\n" +
+		"--- # overlay.yaml\n" +
+		"applications:\n" +
+		"  mysql:\n" +
+		"    to:\n" +
+		"    - \"0\"\n" +
+		"machines:\n" +
+		"  \"0\": {}\n"
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store),
+		"--filename", s.fakeBundle.filename, "--overlay", overlayFile)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fakeBundle.CheckCalls(c, []jujutesting.StubCall{
+		{"ExportBundle", []interface{}{true}},
+	})
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf("Bundle successfully exported to %s\n", s.fakeBundle.filename))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, fmt.Sprintf("Overlay successfully exported to %s\n", overlayFile))
+	output, err := ioutil.ReadFile(s.fakeBundle.filename)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, ""+
+		"applications:\n"+
+		"  mysql:\n"+
+		"    charm: cs:mysql\n"+
+		"    num_units: 1\n")
+	output, err = ioutil.ReadFile(overlayFile)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, ""+
+		"applications:\n"+
+		"  mysql:\n"+
+		"    to:\n"+
+		"    - \"0\"\n"+
+		"machines:\n"+
+		"  \"0\": {}\n")
+}
+
 func (s *ExportBundleCommandSuite) TestPatchOfExportedBundleToExposeTrustFlag(c *gc.C) {
 	s.fakeBundle.result = "applications:\n" +
 		"  aws-integrator:\n" +
@@ -307,8 +351,8 @@ func (f *fakeExportBundleClient) BestAPIVersion() int { return f.bestAPIVersion
 
 func (f *fakeExportBundleClient) Close() error { return nil }
 
-func (f *fakeExportBundleClient) ExportBundle() (string, error) {
-	f.MethodCall(f, "ExportBundle")
+func (f *fakeExportBundleClient) ExportBundle(splitOverlay bool) (string, error) {
+	f.MethodCall(f, "ExportBundle", splitOverlay)
 	if err := f.NextErr(); err != nil {
 		return "", err
 	}
@@ -26,6 +26,14 @@ type ReloadCommand struct {
 
 const ReloadCommandDoc = `
 Reloades spaces and subnets from substrate
+
+For providers that report subnets but not spaces, such as OpenStack and LXD,
+the subnets are added to the spaces chosen by the subnet-space-rules model
+config, or to the default space if no rule matches. Spaces named by the rules
+are created if they do not exist. For example:
+
+    juju model-config subnet-space-rules='[{space: dmz, cidr: 10.10.0.0/16}, {space: internal, name: internal-*}]'
+    juju reload-spaces
 `
 
 // Info is defined on the cmd.Command interface.
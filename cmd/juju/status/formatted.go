@@ -222,6 +222,7 @@ type unitStatus struct {
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 
 	Leader        bool                  `json:"leader,omitempty" yaml:"leader,omitempty"`
+	Paused        *unitPauseStatus      `json:"paused,omitempty" yaml:"paused,omitempty"`
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
@@ -232,6 +233,12 @@ type unitStatus struct {
 	Branch        string                `json:"branch,omitempty" yaml:"branch,omitempty"`
 }
 
+type unitPauseStatus struct {
+	By     string `json:"by" yaml:"by"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Since  string `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
 func (s *formattedStatus) applicationScale(name string) (string, bool) {
 	// The current unit count are units that are either in Idle or Executing status.
 	// In other words, units that are active and available.
@@ -421,6 +421,16 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Branch:             info.branchRef,
 	}
 
+	if paused := info.unit.Paused; paused != nil {
+		out.Paused = &unitPauseStatus{
+			By:     paused.By,
+			Reason: paused.Reason,
+		}
+		if paused.Since != nil {
+			out.Paused.Since = common.FormatTime(paused.Since, sf.isoTime)
+		}
+	}
+
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
 		out.MeterStatus = &meterStatus{
 			Color:   ms.Color,
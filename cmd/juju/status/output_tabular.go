@@ -199,6 +199,9 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		if agentDoing != "" {
 			message = fmt.Sprintf("(%s) %s", agentDoing, message)
 		}
+		if u.Paused != nil {
+			message = fmt.Sprintf("(%s) %s", pausedNote(u.Paused), message)
+		}
 		if u.Leader {
 			name += "*"
 		}
@@ -449,6 +452,15 @@ func FormatMachineTabular(writer io.Writer, forceColor bool, value interface{})
 // agentDoing returns what hook or action, if any,
 // the agent is currently executing.
 // The hook name or action is extracted from the agent message.
+// pausedNote describes who paused a unit, and why.
+func pausedNote(paused *unitPauseStatus) string {
+	note := "paused by " + paused.By
+	if paused.Reason != "" {
+		note += ": " + paused.Reason
+	}
+	return note
+}
+
 func agentDoing(agentStatus statusInfoContents) string {
 	if agentStatus.Current != status.Executing {
 		return ""
@@ -5125,6 +5125,43 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularPausedUnit(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Idle,
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Active,
+							Message: "ready",
+						},
+						Paused: &unitPauseStatus{
+							By:     "admin",
+							Reason: "kernel upgrade",
+						},
+					},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Notes
+foo                       1                  0      
+
+Unit   Workload  Agent  Machine  Public address  Ports  Message
+foo/0  active    idle                                   (paused by admin: kernel upgrade) ready
+`[1:])
+}
+
 func (s *StatusSuite) TestFormatTabularCAASModel(c *gc.C) {
 	status := formattedStatus{
 		Model: modelStatus{
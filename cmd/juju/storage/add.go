@@ -39,7 +39,7 @@ A storage directive consists of a storage name as per charm specification
 and storage constraints, e.g. pool, count, size.
 
 The acceptable format for storage constraints is a comma separated
-sequence of: POOL, COUNT, and SIZE, where
+sequence of: POOL, COUNT, SIZE and snapshot:SNAPSHOT, where
 
     POOL identifies the storage pool. POOL can be a string
     starting with a letter, followed by zero or more digits
@@ -54,6 +54,10 @@ sequence of: POOL, COUNT, and SIZE, where
     the set (M, G, T, P, E, Z, Y), which are all treated as
     powers of 1024.
 
+    SNAPSHOT is the provider ID of a volume snapshot, as listed
+    by "juju storage-snapshots", from which to create the storage
+    instances. The snapshot must belong to the pool's provider.
+
 Storage constraints can be optionally omitted.
 Model default values will be used for all omitted constraint values.
 There is no need to comma-separate omitted constraints. 
@@ -74,6 +78,11 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+    # Add 1 ebs storage instance for "data" storage to unit u/0,
+    # restored from a snapshot:
+
+      juju add-storage u/0 data=ebs,snapshot:snap-0123456789abcdef0
 `
 	addCommandAgs = `<unit name> <charm storage name>[=<storage constraints>]`
 )
@@ -188,9 +197,10 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 			UnitTag:     c.unitTag.String(),
 			StorageName: one,
 			Constraints: params.StorageConstraints{
-				cons.Pool,
-				&cons.Size,
-				&cons.Count,
+				Pool:       cons.Pool,
+				Size:       &cons.Size,
+				Count:      &cons.Count,
+				SnapshotId: cons.SnapshotId,
 			},
 		})
 	}
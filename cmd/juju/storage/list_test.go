@@ -70,6 +70,23 @@ transcode/1   shared-fs/0   filesystem  1.0GiB  attached
 `[1:])
 }
 
+func (s *ListSuite) TestListUsage(c *gc.C) {
+	s.mockAPI.includeUsage = true
+	s.assertValidList(
+		c,
+		nil,
+		// Default format is tabular
+		`
+Unit          Storage id    Type        Pool      Size    Used  Status    Message
+              persistent/1  filesystem                          detached  
+postgresql/0  db-dir/1100   block                 3.0MiB        attached  
+transcode/0   db-dir/1000   block                               pending   creating volume
+transcode/0   shared-fs/0   filesystem  radiance  1.0GiB  75%   attached  
+transcode/1   shared-fs/0   filesystem  radiance  1.0GiB        attached  
+
+`[1:])
+}
+
 func (s *ListSuite) TestListYAML(c *gc.C) {
 	now := time.Now()
 	s.mockAPI.time = now
@@ -284,6 +301,7 @@ volumes:
           location: /mnt/pieces
     size: 1024
     persistent: true
+    encrypted: true
     status:
       current: attached
       since: %s
@@ -326,6 +344,7 @@ type mockListAPI struct {
 	listFilesystems func([]string) ([]params.FilesystemDetailsListResult, error)
 	listVolumes     func([]string) ([]params.VolumeDetailsListResult, error)
 	omitPool        bool
+	includeUsage    bool
 	time            time.Time
 }
 
@@ -400,6 +419,13 @@ func (s *mockListAPI) ListStorageDetails() ([]params.StorageDetails, error) {
 		},
 		Persistent: true,
 	}}
+	if s.includeUsage {
+		results[2].Attachments["unit-transcode-0"] = params.StorageAttachmentDetails{
+			Location: "there",
+			UsedSize: 768,
+			FreeSize: 256,
+		}
+	}
 	return results, nil
 }
 
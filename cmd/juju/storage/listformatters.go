@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"fmt"
 	"io"
 	"sort"
 	"strconv"
@@ -22,6 +23,7 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 
 	storagePool, storageSize := getStoragePoolAndSize(s)
 	units, byUnit := sortStorageInstancesByUnitId(s)
+	haveUsage := hasStorageUsage(byUnit)
 
 	w.Print("Unit", "Storage id", "Type")
 	if len(storagePool) > 0 {
@@ -30,7 +32,13 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 		// We omit the column in that case.
 		w.Print("Pool")
 	}
-	w.Println("Size", "Status", "Message")
+	w.Print("Size")
+	if haveUsage {
+		// Usage is only known once machine agents have
+		// reported it, so omit the column until then.
+		w.Print("Used")
+	}
+	w.Println("Status", "Message")
 
 	for _, unit := range units {
 		// Then sort by storage ids
@@ -50,6 +58,9 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 				w.Print(storagePool[info.storageId])
 			}
 			w.Print(humanizeStorageSize(storageSize[storageId]))
+			if haveUsage {
+				w.Print(formatStorageUsage(info.used, info.free))
+			}
 			w.PrintStatus(info.status.Current)
 			w.Println(info.status.Message)
 		}
@@ -75,7 +86,7 @@ func sortStorageInstancesByUnitId(s CombinedStorage) ([]string, map[string]map[s
 			}
 			continue
 		}
-		for unitId := range storageInfo.Attachments.Units {
+		for unitId, attachment := range storageInfo.Attachments.Units {
 			byStorage := byUnit[unitId]
 			if byStorage == nil {
 				byStorage = make(map[string]storageAttachmentInfo)
@@ -86,6 +97,8 @@ func sortStorageInstancesByUnitId(s CombinedStorage) ([]string, map[string]map[s
 				unitId:    unitId,
 				kind:      storageInfo.Kind,
 				status:    storageInfo.Status,
+				used:      attachment.UsedSize,
+				free:      attachment.FreeSize,
 			}
 		}
 	}
@@ -99,6 +112,29 @@ func sortStorageInstancesByUnitId(s CombinedStorage) ([]string, map[string]map[s
 	return units, byUnit
 }
 
+// hasStorageUsage reports whether usage is known for any of
+// the storage attachments.
+func hasStorageUsage(byUnit map[string]map[string]storageAttachmentInfo) bool {
+	for _, byStorage := range byUnit {
+		for _, info := range byStorage {
+			if info.used+info.free > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatStorageUsage returns the used capacity of storage
+// as a percentage of its total capacity.
+func formatStorageUsage(used, free uint64) string {
+	total := used + free
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", used*100/total)
+}
+
 func getStoragePoolAndSize(s CombinedStorage) (map[string]string, map[string]uint64) {
 	storageSize := make(map[string]uint64)
 	storagePool := make(map[string]string)
@@ -183,6 +219,9 @@ func FormatStorageListForStatusTabular(writer *ansiterm.TabWriter, s CombinedSto
 			}
 			w.Print(getFilesystemAttachment(s, info).MountPoint)
 			w.Print(humanizeStorageSize(storageSize[storageId]))
+			if haveUsage {
+				w.Print(formatStorageUsage(info.used, info.free))
+			}
 			w.PrintStatus(info.status.Current)
 			w.Println(info.status.Message)
 		}
@@ -204,6 +243,8 @@ type storageAttachmentInfo struct {
 	unitId    string
 	kind      string
 	status    EntityStatus
+	used      uint64
+	free      uint64
 }
 
 // slashSeparatedIds represents a list of slash separated ids.
@@ -0,0 +1,157 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// StorageMoveAPI defines the API methods that the move-storage
+// command uses.
+type StorageMoveAPI interface {
+	Close() error
+	MoveStorage(storageId, pool string) error
+	CancelStorageMove(storageId string) error
+}
+
+// NewMoveStorageCommand returns a command used to move storage
+// instances between storage pools.
+func NewMoveStorageCommand() cmd.Command {
+	cmd := &moveStorageCommand{}
+	cmd.newAPIFunc = func() (StorageMoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	moveStorageCommandDoc = `
+Moves a storage instance to another storage pool.
+
+A new volume or filesystem, depending on the kind of storage, is
+provisioned from the target pool and attached to the machine alongside
+the storage's current one; a new filesystem is mounted at a location
+of its own. The "storage-moving" hook is then run for the unit the
+storage is attached to, with the location of the new volume or
+filesystem in $JUJU_STORAGE_MOVE_TARGET. If the charm does not
+implement the hook, the contents of the storage are copied with dd
+for block storage, or rsync for filesystem storage, and then compared
+with the original. The unit agent will only copy a block device that
+is unmounted and not in use, so the workload must stop using block
+storage before it is moved; the hook fails otherwise, and may be
+retried with "juju resolved" once the storage has been unmounted.
+Once the copy has been verified, the storage is switched over to the
+new volume or filesystem, and the old one is released if its storage
+provider supports releasing storage, or destroyed otherwise.
+
+The progress of the move, including how much of a block device has
+been copied, is recorded by the controller, so the copy resumes from
+where it left off if the unit agent is restarted. Until the copy has
+completed, the move may be cancelled with --cancel, which destroys
+the new volume or filesystem.
+
+Only storage attached to a single unit on a machine can be moved.
+
+Examples:
+    # Move pgdata/0 to the ebs-ssd pool.
+    juju move-storage pgdata/0 ebs-ssd
+
+    # Cancel the move of pgdata/0.
+    juju move-storage --cancel pgdata/0
+
+See also:
+    storage
+    show-storage
+    storage-pools
+`
+	moveStorageCommandArgs = `<storage> <pool>`
+)
+
+type moveStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageMoveAPI, error)
+	storageId  string
+	pool       string
+	cancel     bool
+}
+
+// Info implements Command.Info.
+func (c *moveStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "move-storage",
+		Purpose: "Moves a storage instance to another storage pool.",
+		Doc:     moveStorageCommandDoc,
+		Args:    moveStorageCommandArgs,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *moveStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.cancel, "cancel", false, "Cancel an in-progress move")
+}
+
+// Init implements Command.Init.
+func (c *moveStorageCommand) Init(args []string) error {
+	if c.cancel {
+		if len(args) != 1 {
+			return errors.New("move-storage --cancel requires a storage ID")
+		}
+	} else if len(args) != 2 {
+		return errors.New("move-storage requires a storage ID and a pool name")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageId = args[0]
+	if !c.cancel {
+		c.pool = args[1]
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *moveStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if c.cancel {
+		if err := api.CancelStorageMove(c.storageId); err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "cancel a storage move")
+			}
+			return block.ProcessBlockedError(
+				errors.Annotatef(err, "could not cancel move of storage %s", c.storageId),
+				block.BlockChange,
+			)
+		}
+		ctx.Infof("cancelled move of %s", c.storageId)
+		return nil
+	}
+
+	if err := api.MoveStorage(c.storageId, c.pool); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "move storage")
+		}
+		return block.ProcessBlockedError(
+			errors.Annotatef(err, "could not move storage %s", c.storageId),
+			block.BlockChange,
+		)
+	}
+	ctx.Infof("moving %s to pool %s", c.storageId, c.pool)
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type MoveSuite struct {
+	testing.IsolationSuite
+	api *mockMoveAPI
+}
+
+var _ = gc.Suite(&MoveSuite{})
+
+func (s *MoveSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockMoveAPI{}
+}
+
+func (s *MoveSuite) TestMove(c *gc.C) {
+	command := storage.NewMoveStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "pgdata/0", "ebs-ssd")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "MoveStorage", "Close")
+	s.api.CheckCall(c, 0, "MoveStorage", "pgdata/0", "ebs-ssd")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "moving pgdata/0 to pool ebs-ssd\n")
+}
+
+func (s *MoveSuite) TestMoveError(c *gc.C) {
+	s.api.SetErrors(errors.New("storage is already in the pool"))
+	command := storage.NewMoveStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command, "pgdata/0", "ebs")
+	c.Assert(err, gc.ErrorMatches, "could not move storage pgdata/0: storage is already in the pool")
+}
+
+func (s *MoveSuite) TestCancel(c *gc.C) {
+	command := storage.NewMoveStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "--cancel", "pgdata/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "CancelStorageMove", "Close")
+	s.api.CheckCall(c, 0, "CancelStorageMove", "pgdata/0")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "cancelled move of pgdata/0\n")
+}
+
+func (s *MoveSuite) TestMoveInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "move-storage requires a storage ID and a pool name",
+	}, {
+		args: []string{"pgdata/0"},
+		err:  "move-storage requires a storage ID and a pool name",
+	}, {
+		args: []string{"foo/bar", "ebs"},
+		err:  `storage ID "foo/bar" not valid`,
+	}, {
+		args: []string{"--cancel"},
+		err:  "move-storage --cancel requires a storage ID",
+	}, {
+		args: []string{"--cancel", "pgdata/0", "ebs"},
+		err:  "move-storage --cancel requires a storage ID",
+	}} {
+		command := storage.NewMoveStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+		_, err := cmdtesting.RunCommand(c, command, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+type mockMoveAPI struct {
+	testing.Stub
+}
+
+func (m *mockMoveAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockMoveAPI) MoveStorage(storageId, pool string) error {
+	m.MethodCall(m, "MoveStorage", storageId, pool)
+	return m.NextErr()
+}
+
+func (m *mockMoveAPI) CancelStorageMove(storageId string) error {
+	m.MethodCall(m, "CancelStorageMove", storageId)
+	return m.NextErr()
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ResizeSuite struct {
+	testing.IsolationSuite
+	api *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeSuite{})
+
+func (s *ResizeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockResizeAPI{}
+}
+
+func (s *ResizeSuite) TestResize(c *gc.C) {
+	command := storage.NewResizeStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "pgdata/0", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Resize", "Close")
+	s.api.CheckCall(c, 0, "Resize", "pgdata/0", uint64(2048))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing pgdata/0 to 2.0 GiB\n")
+}
+
+func (s *ResizeSuite) TestResizeError(c *gc.C) {
+	s.api.SetErrors(errors.New("new size 2048MiB must be larger than current size 4096MiB"))
+	command := storage.NewResizeStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command, "pgdata/0", "2G")
+	c.Assert(err, gc.ErrorMatches, "could not resize storage pgdata/0: new size 2048MiB must be larger than current size 4096MiB")
+}
+
+func (s *ResizeSuite) TestResizeInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"pgdata/0"},
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"foo/bar", "2G"},
+		err:  `storage ID "foo/bar" not valid`,
+	}, {
+		args: []string{"pgdata/0", "lots"},
+		err:  `cannot parse size "lots": .*`,
+	}, {
+		args: []string{"pgdata/0", "0"},
+		err:  `size "0" not valid`,
+	}} {
+		command := storage.NewResizeStorageCommandForTest(s.api, jujuclienttesting.MinimalStore())
+		_, err := cmdtesting.RunCommand(c, command, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+type mockResizeAPI struct {
+	testing.Stub
+}
+
+func (m *mockResizeAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockResizeAPI) Resize(storageId string, size uint64) error {
+	m.MethodCall(m, "Resize", storageId, size)
+	return m.NextErr()
+}
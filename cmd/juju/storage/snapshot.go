@@ -0,0 +1,330 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// StorageSnapshotAPI defines the API methods that the storage
+// snapshot commands use.
+type StorageSnapshotAPI interface {
+	Close() error
+	ListStorageDetails() ([]params.StorageDetails, error)
+	CreateSnapshots(storageIds []string, description string) ([]params.StorageSnapshotResult, error)
+	ListSnapshots(storageIds []string) ([]params.StorageSnapshotsResult, error)
+}
+
+// NewCreateSnapshotCommand returns a command used to snapshot
+// the volumes backing storage instances.
+func NewCreateSnapshotCommand() cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	createSnapshotCommandDoc = `
+Creates a snapshot of the volume backing each of the specified
+storage instances. Specify one or more storage IDs, as output
+by "juju storage".
+
+Snapshots are taken by the storage provider; only providers that
+support volume snapshots (e.g. ebs, cinder, gce) can be used.
+The resulting snapshot IDs may later be used to create new
+storage with the "snapshot" storage directive:
+
+    juju add-storage u/1 data=ebs,snapshot:<snapshot-id>
+
+Examples:
+    # Snapshot the volume backing pgdata/0.
+    juju create-storage-snapshot pgdata/0
+
+    # Snapshot several storage instances with a description.
+    juju create-storage-snapshot --description "before upgrade" pgdata/0 pgdata/1
+
+See also:
+    storage-snapshots
+    add-storage
+`
+	createSnapshotCommandArgs = `<storage> [<storage> ...]`
+)
+
+type createSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc  func() (StorageSnapshotAPI, error)
+	storageIds  []string
+	description string
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Creates snapshots of storage volumes.",
+		Doc:     createSnapshotCommandDoc,
+		Args:    createSnapshotCommandArgs,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *createSnapshotCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.description, "description", "", "Description to record with the snapshots")
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds, c.description)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "create storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("created snapshot %s of %s", result.Result.SnapshotId, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewListSnapshotsCommand returns a command used to list the
+// snapshots of the volumes backing storage instances.
+func NewListSnapshotsCommand() cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listSnapshotsCommandDoc = `
+Lists the snapshots of the volumes backing storage instances.
+If no storage IDs are specified, snapshots are listed for all
+storage in the model whose provider supports snapshots.
+
+Examples:
+    juju storage-snapshots
+    juju storage-snapshots pgdata/0
+    juju storage-snapshots --format yaml
+
+See also:
+    create-storage-snapshot
+`
+
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	out        cmd.Output
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	storageIds []string
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "storage-snapshots",
+		Args:    "[<storage> ...]",
+		Purpose: "Lists snapshots of storage volumes.",
+		Doc:     listSnapshotsCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// SnapshotInfo defines the serialization behaviour of a storage
+// volume snapshot.
+type SnapshotInfo struct {
+	Storage     string     `yaml:"storage" json:"storage"`
+	Volume      string     `yaml:"volume,omitempty" json:"volume,omitempty"`
+	VolumeId    string     `yaml:"volume-id" json:"volume-id"`
+	Pool        string     `yaml:"pool,omitempty" json:"pool,omitempty"`
+	Size        uint64     `yaml:"size" json:"size"`
+	Status      string     `yaml:"status,omitempty" json:"status,omitempty"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Created     *time.Time `yaml:"created,omitempty" json:"created,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	storageIds := c.storageIds
+	listAll := len(storageIds) == 0
+	if listAll {
+		details, err := api.ListStorageDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, d := range details {
+			if d.Kind != params.StorageKindBlock {
+				continue
+			}
+			tag, err := names.ParseStorageTag(d.StorageTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			storageIds = append(storageIds, tag.Id())
+		}
+		if len(storageIds) == 0 {
+			if c.out.Name() == "tabular" {
+				ctx.Infof("No storage snapshots to display.")
+			}
+			return nil
+		}
+	}
+
+	results, err := api.ListSnapshots(storageIds)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshots := make(map[string]SnapshotInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			// When listing everything, quietly skip storage
+			// that cannot have snapshots.
+			if listAll && (params.IsCodeNotSupported(result.Error) ||
+				params.IsCodeNotFound(result.Error) ||
+				params.IsCodeNotProvisioned(result.Error)) {
+				continue
+			}
+			fmt.Fprintf(ctx.Stderr, "%s: %s\n", storageIds[i], result.Error)
+			continue
+		}
+		for _, s := range result.Result {
+			snapshots[s.SnapshotId] = convertToSnapshotInfo(s)
+		}
+	}
+	if len(snapshots) == 0 {
+		if c.out.Name() == "tabular" {
+			ctx.Infof("No storage snapshots to display.")
+		}
+		return nil
+	}
+	return c.out.Write(ctx, snapshots)
+}
+
+func convertToSnapshotInfo(s params.StorageSnapshot) SnapshotInfo {
+	info := SnapshotInfo{
+		VolumeId:    s.VolumeId,
+		Pool:        s.Pool,
+		Size:        s.Size,
+		Status:      s.Status,
+		Description: s.Description,
+		Created:     s.Created,
+	}
+	if tag, err := names.ParseStorageTag(s.StorageTag); err == nil {
+		info.Storage = tag.Id()
+	}
+	if tag, err := names.ParseVolumeTag(s.VolumeTag); err == nil {
+		info.Volume = tag.Id()
+	}
+	return info
+}
+
+// formatSnapshotListTabular writes a tabular summary of
+// storage volume snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Snapshot", "Storage", "Volume", "Size", "Status", "Created", "Description")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := snapshots[ids[i]], snapshots[ids[j]]
+		if a.Storage != b.Storage {
+			return a.Storage < b.Storage
+		}
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		s := snapshots[id]
+		var created string
+		if s.Created != nil {
+			created = common.FormatTime(s.Created, false)
+		}
+		print(id, s.Storage, s.Volume, humanizeStorageSize(s.Size), s.Status, created, s.Description)
+	}
+	return tw.Flush()
+}
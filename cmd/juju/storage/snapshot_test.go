@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type SnapshotSuite struct {
+	testing.IsolationSuite
+	api *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+func (s *SnapshotSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockSnapshotAPI{}
+}
+
+func (s *SnapshotSuite) TestCreateSnapshot(c *gc.C) {
+	s.api.createResults = []params.StorageSnapshotResult{
+		{Result: &params.StorageSnapshot{SnapshotId: "snap-0"}},
+		{Error: &params.Error{Message: "volume snapshots not supported", Code: params.CodeNotSupported}},
+	}
+	command := storage.NewCreateSnapshotCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "--description", "nightly", "pgdata/0", "pgdata/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	s.api.CheckCallNames(c, "CreateSnapshots", "Close")
+	s.api.CheckCall(c, 0, "CreateSnapshots", []string{"pgdata/0", "pgdata/1"}, "nightly")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+created snapshot snap-0 of pgdata/0
+failed to snapshot pgdata/1: volume snapshots not supported
+`[1:])
+}
+
+func (s *SnapshotSuite) TestCreateSnapshotInitErrors(c *gc.C) {
+	command := storage.NewCreateSnapshotCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+
+	command = storage.NewCreateSnapshotCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	_, err = cmdtesting.RunCommand(c, command, "foo/bar")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo/bar" not valid`)
+}
+
+func (s *SnapshotSuite) TestListSnapshotsAll(c *gc.C) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.api.details = []params.StorageDetails{
+		{StorageTag: "storage-pgdata-0", Kind: params.StorageKindBlock},
+		{StorageTag: "storage-pgdata-1", Kind: params.StorageKindBlock},
+		{StorageTag: "storage-shared-0", Kind: params.StorageKindFilesystem},
+	}
+	s.api.listResults = []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{
+			SnapshotId:  "snap-0",
+			StorageTag:  "storage-pgdata-0",
+			VolumeTag:   "volume-0",
+			VolumeId:    "vol-0",
+			Size:        1024,
+			Status:      "completed",
+			Description: "nightly",
+			Created:     &created,
+		}},
+	}, {
+		Error: &params.Error{Message: "not supported", Code: params.CodeNotSupported},
+	}}
+	command := storage.NewListSnapshotsCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "ListStorageDetails", "ListSnapshots", "Close")
+	s.api.CheckCall(c, 1, "ListSnapshots", []string{"pgdata/0", "pgdata/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+snap-0:
+  storage: pgdata/0
+  volume: "0"
+  volume-id: vol-0
+  size: 1024
+  status: completed
+  description: nightly
+  created: 2020-01-02T03:04:05Z
+`[1:])
+}
+
+func (s *SnapshotSuite) TestListSnapshotsErrorReported(c *gc.C) {
+	s.api.listResults = []params.StorageSnapshotsResult{{
+		Error: &params.Error{Message: "not supported", Code: params.CodeNotSupported},
+	}}
+	command := storage.NewListSnapshotsCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "pgdata/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "ListSnapshots", "Close")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+pgdata/0: not supported
+No storage snapshots to display.
+`[1:])
+}
+
+type mockSnapshotAPI struct {
+	testing.Stub
+	details       []params.StorageDetails
+	createResults []params.StorageSnapshotResult
+	listResults   []params.StorageSnapshotsResult
+}
+
+func (m *mockSnapshotAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockSnapshotAPI) ListStorageDetails() ([]params.StorageDetails, error) {
+	m.MethodCall(m, "ListStorageDetails")
+	return m.details, m.NextErr()
+}
+
+func (m *mockSnapshotAPI) CreateSnapshots(storageIds []string, description string) ([]params.StorageSnapshotResult, error) {
+	m.MethodCall(m, "CreateSnapshots", storageIds, description)
+	return m.createResults, m.NextErr()
+}
+
+func (m *mockSnapshotAPI) ListSnapshots(storageIds []string) ([]params.StorageSnapshotsResult, error) {
+	m.MethodCall(m, "ListSnapshots", storageIds)
+	return m.listResults, m.NextErr()
+}
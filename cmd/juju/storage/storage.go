@@ -39,6 +39,7 @@ type StorageInfo struct {
 	Status      EntityStatus        `yaml:"status" json:"status"`
 	Persistent  bool                `yaml:"persistent" json:"persistent"`
 	Attachments *StorageAttachments `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+	MovingTo    string              `yaml:"moving-to,omitempty" json:"moving-to,omitempty"`
 }
 
 // StorageAttachments contains details about all attachments to a storage
@@ -62,6 +63,11 @@ type UnitStorageAttachment struct {
 	// Life is the lifecycle state of the storage attachment.
 	Life string `yaml:"life,omitempty" json:"life,omitempty"`
 
+	// UsedSize and FreeSize are the used and free capacity, in MiB,
+	// of the attached storage, if known.
+	UsedSize uint64 `yaml:"used-size,omitempty" json:"used-size,omitempty"`
+	FreeSize uint64 `yaml:"free-size,omitempty" json:"free-size,omitempty"`
+
 	// TODO(axw) per-unit status when we have it in state.
 }
 
@@ -98,6 +104,7 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 			common.FormatTime(details.Status.Since, false),
 		},
 		Persistent: details.Persistent,
+		MovingTo:   details.MovingTo,
 	}
 
 	if len(details.Attachments) > 0 {
@@ -116,9 +123,11 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 				machineId = machineTag.Id()
 			}
 			unitStorageAttachments[unitTag.Id()] = UnitStorageAttachment{
-				machineId,
-				attachmentDetails.Location,
-				string(attachmentDetails.Life),
+				MachineId: machineId,
+				Location:  attachmentDetails.Location,
+				Life:      string(attachmentDetails.Life),
+				UsedSize:  attachmentDetails.UsedSize,
+				FreeSize:  attachmentDetails.FreeSize,
 			}
 		}
 		info.Attachments = &StorageAttachments{unitStorageAttachments}
@@ -42,6 +42,9 @@ type VolumeInfo struct {
 	// from params.Volume
 	Persistent bool `yaml:"persistent" json:"persistent"`
 
+	// Encrypted reports whether the volume is encrypted at rest by Juju.
+	Encrypted bool `yaml:"encrypted,omitempty" json:"encrypted,omitempty"`
+
 	// Life is the lifecycle state of the volume.
 	Life string `yaml:"life,omitempty" json:"life,omitempty"`
 
@@ -127,6 +130,7 @@ func createVolumeInfo(details params.VolumeDetails) (names.VolumeTag, VolumeInfo
 	info.Pool = details.Info.Pool
 	info.Size = details.Info.Size
 	info.Persistent = details.Info.Persistent
+	info.Encrypted = details.Encrypted
 	info.Life = string(details.Life)
 	info.Status = EntityStatus{
 		details.Status.Status,
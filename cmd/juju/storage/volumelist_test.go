@@ -315,7 +315,8 @@ func (s *mockListAPI) ListVolumes(machines []string) ([]params.VolumeDetailsList
 				Persistent: true,
 				Size:       1024,
 			},
-			Status: createTestStatus(status.Attached, "", s.time),
+			Status:    createTestStatus(status.Attached, "", s.time),
+			Encrypted: true,
 			MachineAttachments: map[string]params.VolumeAttachmentDetails{
 				"machine-0": {
 					VolumeAttachmentInfo: params.VolumeAttachmentInfo{
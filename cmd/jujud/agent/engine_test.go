@@ -33,7 +33,9 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
+		"branch-promoter",        // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
 		"environ-tracker",
@@ -55,7 +57,9 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"application-scaler",
+		"branch-promoter",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
@@ -141,6 +145,7 @@ var (
 		"api-address-updater",
 		"disk-manager",
 		"fan-configurer",
+		"host-firewaller",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -91,7 +91,8 @@ var (
 	jujuDumpLogs     = paths.MustSucceed(paths.JujuDumpLogs(series.MustHostSeries()))
 	jujuIntrospect   = paths.MustSucceed(paths.JujuIntrospect(series.MustHostSeries()))
 	jujuUpdateSeries = paths.MustSucceed(paths.JujuUpdateSeries(series.MustHostSeries()))
-	jujudSymlinks    = []string{jujuRun, jujuDumpLogs, jujuIntrospect, jujuUpdateSeries}
+	jujuHookReplay   = paths.MustSucceed(paths.JujuHookReplay(series.MustHostSeries()))
+	jujudSymlinks    = []string{jujuRun, jujuDumpLogs, jujuIntrospect, jujuUpdateSeries, jujuHookReplay}
 
 	// The following are defined as variables to allow the tests to
 	// intercept calls to the functions. In every case, they should
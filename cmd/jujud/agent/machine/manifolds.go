@@ -59,6 +59,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/globalclockupdater"
+	"github.com/juju/juju/worker/hostfirewaller"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/httpserver"
 	"github.com/juju/juju/worker/httpserverargs"
@@ -802,6 +803,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			ExternalUpdate:  externalUpdateProxyFunc,
 			InProcessUpdate: proxyconfig.DefaultConfig.Set,
 			RunFunc:         proxyupdater.RunWithStdIn,
+			AptSourcesFile:  "/etc/apt/sources.list",
 		})),
 
 		hostKeyReporterName: ifNotMigrating(hostkeyreporter.Manifold(hostkeyreporter.ManifoldConfig{
@@ -842,6 +844,15 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The hostfirewaller worker periodically applies the host firewall
+		// rules for the machine it runs on, when the model's host-firewall
+		// setting is enabled. This worker will be run on all Juju-managed
+		// machines (one per machine agent).
+		hostFirewallerName: ifNotMigrating(hostfirewaller.Manifold(hostfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
+
 		// The api address updater is a leaf worker that rewrites agent config
 		// as the state server addresses change. We should only need one of
 		// these in a consolidated agent.
@@ -1062,6 +1073,7 @@ const (
 	rebootName                    = "reboot-executor"
 	loggingConfigUpdaterName      = "logging-config-updater"
 	diskManagerName               = "disk-manager"
+	hostFirewallerName            = "host-firewaller"
 	proxyConfigUpdater            = "proxy-config-updater"
 	apiAddressUpdaterName         = "api-address-updater"
 	machinerName                  = "machiner"
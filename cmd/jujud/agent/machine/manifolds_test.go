@@ -75,6 +75,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"external-controller-updater",
 			"fan-configurer",
 			"global-clock-updater",
+			"host-firewaller",
 			"host-key-reporter",
 			"http-server",
 			"http-server-args",
@@ -537,6 +538,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"host-firewaller": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"host-key-reporter": {
 		"agent",
 		"api-caller",
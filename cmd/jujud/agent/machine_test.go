@@ -52,6 +52,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	envtesting "github.com/juju/juju/environs/testing"
+	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -725,6 +726,27 @@ func (s *MachineLegacyLeasesSuite) TestMachineAgentSymlinks(c *gc.C) {
 	s.waitStopped(c, state.JobManageModel, a, done)
 }
 
+func (s *MachineLegacyLeasesSuite) TestMachineAgentSymlinkJujuHookReplay(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Cannot read symlinks on windows")
+	}
+
+	stm, _, _ := s.primeAgent(c, state.JobManageModel)
+	a := s.newAgent(c, stm)
+	defer a.Stop()
+	a.rootDir = c.MkDir()
+	_, done := s.waitForOpenState(c, a)
+
+	// juju-hook-replay is dispatched by jujud on its name, so it must
+	// link to the agent's jujud.
+	fullLink := utils.EnsureBaseDir(a.rootDir, jujuHookReplay)
+	linkTarget, err := symlink.Read(fullLink)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filepath.Base(linkTarget), gc.Equals, jujunames.Jujud)
+
+	s.waitStopped(c, state.JobManageModel, a, done)
+}
+
 func (s *MachineLegacyLeasesSuite) TestMachineAgentSymlinkJujuRunExists(c *gc.C) {
 	if runtime.GOOS == "windows" {
 		// Cannot make symlink to nonexistent file on windows or
@@ -770,7 +792,7 @@ func (s *MachineLegacyLeasesSuite) TestMachineAgentUninstall(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// juju-* symlinks should have been removed on termination.
-	for _, link := range []string{jujuRun, jujuDumpLogs, jujuIntrospect} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuIntrospect, jujuHookReplay} {
 		_, err = os.Stat(utils.EnsureBaseDir(a.rootDir, link))
 		c.Assert(err, jc.Satisfies, os.IsNotExist)
 	}
@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookreplay
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+// HookReplayCommand runs a charm hook locally, serving the hook tools
+// it invokes from a context file.
+type HookReplayCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	charmDir    string
+	contextPath string
+	hook        string
+
+	// HookToolPath is the executable that hook tools are linked to.
+	// If it is empty, the running executable is used.
+	HookToolPath string
+}
+
+const hookReplayCommandDoc = `
+Run a charm hook on this machine against a context file rather than
+a live controller.
+
+juju-hook-replay is part of jujud, and is run through a link to jujud
+named juju-hook-replay. Hook tools are served by the same executable.
+
+The context file describes everything the hook can read through hook
+tools: the unit's config, leadership and leader settings, addresses,
+relations and their settings, storage, network-get results and charm
+state. Hook tools run exactly as they do on a unit, but read from the
+context file, and changes they make are reported once the hook exits.
+
+The context file has the layout of the capture.yaml file written by
+"juju debug-hooks --capture", and a capture directory may be given in
+place of a context file to replay the captured hook. For example:
+
+    unit: mysql/0
+    hook: db-relation-changed
+    context:
+      config:
+        port: 3306
+      leader: true
+      relations:
+      - id: 3
+        endpoint: db
+        local:
+          user: admin
+        units:
+          wordpress/0:
+            private-address: 10.0.0.2
+      relation-id: 3
+      remote-unit: wordpress/0
+      network:
+        db:
+          bind-addresses:
+          - interfacename: eth0
+            addresses:
+            - address: 10.0.0.1
+              cidr: 10.0.0.0/24
+          ingress-addresses:
+          - 10.0.0.1
+      state:
+        initialised: "true"
+
+The hook runs in the charm directory with the environment of
+juju-hook-replay, plus the variables the unit agent would set. Its
+output is written as it runs, and the outcome is then written in the
+chosen format. If the hook fails, juju-hook-replay exits with the
+hook's exit code.
+
+Examples:
+
+    juju-hook-replay ./mysql db-relation-changed.yaml
+    juju-hook-replay --hook config-changed ./mysql captures/install-20200101T000000.000
+    juju-hook-replay --output result.yaml ./mysql context.yaml
+`
+
+// Info implements cmd.Command.
+func (c *HookReplayCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "juju-hook-replay",
+		Args:    "<charm dir> <context file|capture dir>",
+		Purpose: "run a charm hook against a recorded or hand-written context",
+		Doc:     hookReplayCommandDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *HookReplayCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+	f.StringVar(&c.hook, "hook", "", "run this hook instead of the one named in the context")
+}
+
+// Init implements cmd.Command.
+func (c *HookReplayCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no charm directory specified")
+	case 1:
+		return errors.New("no context file specified")
+	}
+	c.charmDir, c.contextPath = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *HookReplayCommand) Run(ctx *cmd.Context) error {
+	file, err := replay.ReadContextFile(ctx.AbsPath(c.contextPath))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.hook != "" {
+		file.Hook = c.hook
+	}
+	replayCtx, err := replay.NewContext(*file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hookToolPath := c.HookToolPath
+	if hookToolPath == "" {
+		if hookToolPath, err = os.Executable(); err != nil {
+			return errors.Annotate(err, "finding hook tool executable")
+		}
+	}
+	result, err := replay.Run(replay.Config{
+		CharmDir:     ctx.AbsPath(c.charmDir),
+		Context:      replayCtx,
+		HookToolPath: hookToolPath,
+		Stdout:       ctx.Stdout,
+		Stderr:       ctx.Stderr,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.out.Write(ctx, result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Code != 0 {
+		return cmd.NewRcPassthroughError(result.Code)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookreplay_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/jujud/hookreplay"
+)
+
+type HookReplaySuite struct {
+	charmDir    string
+	contextPath string
+}
+
+var _ = gc.Suite(&HookReplaySuite{})
+
+func (s *HookReplaySuite) SetUpTest(c *gc.C) {
+	s.charmDir = c.MkDir()
+	err := os.Mkdir(filepath.Join(s.charmDir, "hooks"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.contextPath = filepath.Join(c.MkDir(), "context.yaml")
+	err = ioutil.WriteFile(s.contextPath, []byte(`
+unit: mysql/0
+hook: install
+context:
+  config:
+    port: 3306
+`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HookReplaySuite) writeHook(c *gc.C, name, script string) {
+	path := filepath.Join(s.charmDir, "hooks", name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/bash\n"+script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HookReplaySuite) newCommand() cmd.Command {
+	return &hookreplay.HookReplayCommand{HookToolPath: "/bin/true"}
+}
+
+func (s *HookReplaySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{
+		{nil, "no charm directory specified"},
+		{[]string{"charm"}, "no context file specified"},
+		{[]string{"charm", "context.yaml", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(s.newCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *HookReplaySuite) TestRun(c *gc.C) {
+	s.writeHook(c, "install", "echo installing $JUJU_UNIT_NAME\n")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), s.charmDir, s.contextPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+installing mysql/0
+unit: mysql/0
+hook: install
+code: 0
+changes: {}
+`[1:])
+}
+
+func (s *HookReplaySuite) TestRunOtherHook(c *gc.C) {
+	s.writeHook(c, "start", "echo starting\n")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--hook", "start", "--format", "json", s.charmDir, s.contextPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "starting\n"+
+		`{"unit":"mysql/0","hook":"start","code":0,"changes":{}}`+"\n")
+}
+
+func (s *HookReplaySuite) TestRunHookFails(c *gc.C) {
+	s.writeHook(c, "install", "exit 5\n")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.charmDir, s.contextPath)
+	c.Assert(err, jc.Satisfies, cmd.IsRcPassthroughError)
+	c.Assert(err.(*cmd.RcPassthroughError).Code, gc.Equals, 5)
+}
+
+func (s *HookReplaySuite) TestRunMissingHook(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.charmDir, s.contextPath)
+	c.Assert(err, gc.ErrorMatches, "install does not exist")
+}
@@ -0,0 +1,18 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookreplay_test
+
+import (
+	"runtime"
+	"testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+func TestPackage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook replay uses unix sockets and shell hooks")
+	}
+	gc.TestingT(t)
+}
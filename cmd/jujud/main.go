@@ -27,6 +27,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
+	"github.com/juju/juju/cmd/jujud/hookreplay"
 	"github.com/juju/juju/cmd/jujud/introspect"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	components "github.com/juju/juju/component/all"
@@ -298,6 +299,8 @@ func Main(args []string) int {
 		code = cmd.Main(dumplogs.NewCommand(), ctx, args[1:])
 	case jujunames.JujuIntrospect:
 		code = cmd.Main(&introspect.IntrospectCommand{}, ctx, args[1:])
+	case jujunames.JujuHookReplay:
+		code = cmd.Main(&hookreplay.HookReplayCommand{}, ctx, args[1:])
 	default:
 		code, err = hookToolMain(commandName, ctx, args)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
@@ -138,6 +139,7 @@ func (broker *kvmBroker) StartInstance(ctx context.ProviderCallContext, args env
 		kvmLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	args.InstanceConfig.PreferredAddressFamily = corenetwork.AddressFamilyPreference(config.PreferredAddressFamily)
 
 	storageConfig := &container.StorageConfig{
 		AllowMount: true,
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/lxdprofile"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
@@ -137,6 +138,7 @@ func (broker *lxdBroker) StartInstance(ctx context.ProviderCallContext, args env
 		lxdLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	args.InstanceConfig.PreferredAddressFamily = corenetwork.AddressFamilyPreference(config.PreferredAddressFamily)
 
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
@@ -103,6 +103,11 @@ func (s *Server) GetNICsFromProfile(profName string) (map[string]device, error)
 	return nics, nil
 }
 
+// NetworkSupported returns true if the server supports the network API.
+func (s *Server) NetworkSupported() bool {
+	return s.networkAPISupport
+}
+
 // VerifyNetworkDevice attempts to ensure that there is a network usable by LXD
 // and that there is a NIC device with said network as its parent.
 // If there are no NIC devices, and this server is *not* in cluster mode,
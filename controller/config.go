@@ -264,6 +264,13 @@ const (
 
 	// MeteringURL is the key for the url to use for metrics
 	MeteringURL = "metering-url"
+
+	// CrossModelRelayProxy is the URL of an HTTP proxy through which
+	// other controllers connect to this controller's API for cross
+	// model relations. It is used when this controller's API addresses
+	// are not reachable from consuming controllers, for example when
+	// the controller sits behind NAT.
+	CrossModelRelayProxy = "cross-model-relay-proxy"
 )
 
 var (
@@ -305,6 +312,7 @@ var (
 		CAASImageRepo,
 		Features,
 		MeteringURL,
+		CrossModelRelayProxy,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -333,6 +341,7 @@ var (
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
+		CrossModelRelayProxy,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -687,6 +696,13 @@ func (c Config) MeteringURL() string {
 	return url
 }
 
+// CrossModelRelayProxy returns the URL of the proxy through which other
+// controllers connect to this controller for cross model relations, or
+// "" if they connect directly.
+func (c Config) CrossModelRelayProxy() string {
+	return c.asString(CrossModelRelayProxy)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -788,6 +804,12 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[CrossModelRelayProxy].(string); ok && v != "" {
+		if err := validateRelayProxy(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	var auditLogMaxSize int
 	if v, ok := c[AuditLogMaxSize].(string); ok {
 		if size, err := utils.ParseSize(v); err != nil {
@@ -861,6 +883,19 @@ func (c Config) validateSpaceConfig(key, topic string) error {
 	return nil
 }
 
+// validateRelayProxy checks that the cross model relay proxy is an
+// absolute http URL naming a host to connect to.
+func validateRelayProxy(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return errors.Annotate(err, "invalid cross model relay proxy")
+	}
+	if u.Scheme != "http" || u.Host == "" {
+		return errors.NotValidf("cross model relay proxy %q (expected http://host:port)", value)
+	}
+	return nil
+}
+
 // AsSpaceConstraints checks to see whether config has spaces names populated
 // for management and/or HA (Mongo).
 // Non-empty values are merged with any input spaces and returned as a new
@@ -929,6 +964,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.List(schema.String()),
 	CharmStoreURL:           schema.String(),
 	MeteringURL:             schema.String(),
+	CrossModelRelayProxy:    schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
@@ -962,6 +998,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.Omit,
 	CharmStoreURL:           csclient.ServerURL,
 	MeteringURL:             romulus.DefaultAPIRoot,
+	CrossModelRelayProxy:    schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1105,4 +1142,8 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tstring,
 		Description: `The url for metrics`,
 	},
+	CrossModelRelayProxy: {
+		Type:        environschema.Tstring,
+		Description: `The url of an HTTP proxy through which other controllers connect to this controller for cross model relations`,
+	},
 }
@@ -202,6 +202,20 @@ var validateTests = []struct {
 		controller.ModelLogsSize: "0",
 	},
 	expectError: "model logs size less than 1 MB not valid",
+}, {
+	about: "cross model relay proxy without scheme",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.CrossModelRelayProxy: "relay.example.com:3128",
+	},
+	expectError: `cross model relay proxy "relay.example.com:3128" \(expected http://host:port\) not valid`,
+}, {
+	about: "cross model relay proxy with unsupported scheme",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.CrossModelRelayProxy: "socks5://relay.example.com:1080",
+	},
+	expectError: `cross model relay proxy "socks5://relay.example.com:1080" \(expected http://host:port\) not valid`,
 }, {
 	about: "invalid CAAS docker image repo",
 	config: controller.Config{
@@ -572,6 +586,26 @@ func (s *ConfigSuite) TestCharmstoreURLSettingValue(c *gc.C) {
 	c.Assert(cfg.CharmStoreURL(), gc.Equals, csURL)
 }
 
+func (s *ConfigSuite) TestCrossModelRelayProxy(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CrossModelRelayProxy(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.CrossModelRelayProxy: "http://relay.example.com:3128",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CrossModelRelayProxy(), gc.Equals, "http://relay.example.com:3128")
+}
+
 func (s *ConfigSuite) TestMeteringURLDefault(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
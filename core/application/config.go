@@ -71,7 +71,8 @@ func (c *Config) schemaChecker() (schema.Checker, error) {
 
 // Validate returns an error if the config is not valid.
 func (c *Config) Validate() error {
-	return nil
+	_, err := c.Attributes().HookRetryPolicy()
+	return errors.Trace(err)
 }
 
 // Attributes returns all the config attributes.
@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+)
+
+const (
+	// HookRetryMaxRetriesKey is the application config key holding
+	// the number of times a failed hook is automatically retried,
+	// not counting its first run. Zero disables automatic retries for
+	// the application.
+	HookRetryMaxRetriesKey = "hook-retry-max-retries"
+
+	// HookRetryInitialDelayKey is the application config key holding
+	// the delay before a failed hook is first retried.
+	HookRetryInitialDelayKey = "hook-retry-initial-delay"
+
+	// HookRetryMaxDelayKey is the application config key holding the
+	// longest delay between retries of a failed hook.
+	HookRetryMaxDelayKey = "hook-retry-max-delay"
+
+	// HookRetryHooksKey is the application config key holding the
+	// space-separated names of the hooks that are automatically
+	// retried. Names may contain shell wildcards.
+	HookRetryHooksKey = "hook-retry-hooks"
+)
+
+// HookRetryFields holds the schema for the application config that
+// controls how failed hooks are retried.
+var HookRetryFields = environschema.Fields{
+	HookRetryMaxRetriesKey: {
+		Description: "How many times a failed hook is automatically retried after it first fails; 0 disables retries. If unset, the model's automatically-retry-hooks setting applies",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	HookRetryInitialDelayKey: {
+		Description: "How long to wait before first retrying a failed hook, eg 10s",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	HookRetryMaxDelayKey: {
+		Description: "The longest time to wait between retries of a failed hook, eg 10m",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	HookRetryHooksKey: {
+		Description: "Space-separated names of the hooks to retry automatically, which may contain wildcards, eg \"install *-relation-changed\". If unset, all hooks are retried",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// HookRetryPolicy describes how an application's failed hooks are
+// automatically retried.
+type HookRetryPolicy struct {
+	// MaxRetries, if not nil, is the number of times a failed hook
+	// is retried after its first run. Zero means no retries.
+	MaxRetries *int
+
+	// InitialDelay, if not zero, is the delay before a failed hook is
+	// first retried.
+	InitialDelay time.Duration
+
+	// MaxDelay, if not zero, is the longest delay between retries.
+	MaxDelay time.Duration
+
+	// Hooks, if not empty, holds the patterns matching the names of
+	// the hooks that are retried.
+	Hooks []string
+}
+
+// HookRetryPolicy returns the hook retry policy held in the config.
+func (c ConfigAttributes) HookRetryPolicy() (HookRetryPolicy, error) {
+	var policy HookRetryPolicy
+	if _, ok := c[HookRetryMaxRetriesKey]; ok {
+		maxRetries := c.GetInt(HookRetryMaxRetriesKey, 0)
+		if maxRetries < 0 {
+			return HookRetryPolicy{}, errors.NotValidf("negative %s %d", HookRetryMaxRetriesKey, maxRetries)
+		}
+		policy.MaxRetries = &maxRetries
+	}
+	var err error
+	if policy.InitialDelay, err = c.hookRetryDelay(HookRetryInitialDelayKey); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MaxDelay, err = c.hookRetryDelay(HookRetryMaxDelayKey); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.InitialDelay != 0 && policy.MaxDelay != 0 && policy.InitialDelay > policy.MaxDelay {
+		return HookRetryPolicy{}, errors.NotValidf(
+			"%s %v greater than %s %v",
+			HookRetryInitialDelayKey, policy.InitialDelay,
+			HookRetryMaxDelayKey, policy.MaxDelay,
+		)
+	}
+	policy.Hooks = strings.Fields(c.GetString(HookRetryHooksKey, ""))
+	for _, pattern := range policy.Hooks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return HookRetryPolicy{}, errors.NotValidf("%s pattern %q", HookRetryHooksKey, pattern)
+		}
+	}
+	return policy, nil
+}
+
+func (c ConfigAttributes) hookRetryDelay(key string) (time.Duration, error) {
+	value := c.GetString(key, "")
+	if value == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NotValidf("%s %q", key, value)
+	}
+	if delay <= 0 {
+		return 0, errors.NotValidf("non-positive %s %q", key, value)
+	}
+	return delay, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetrySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookRetrySuite{})
+
+func (s *HookRetrySuite) TestHookRetryPolicyUnset(c *gc.C) {
+	policy, err := application.ConfigAttributes{}.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{})
+}
+
+func (s *HookRetrySuite) TestHookRetryPolicy(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.HookRetryMaxRetriesKey:   0,
+		application.HookRetryInitialDelayKey: "10s",
+		application.HookRetryMaxDelayKey:     "1m",
+		application.HookRetryHooksKey:        "install *-relation-changed",
+	}.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	maxRetries := 0
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{
+		MaxRetries:   &maxRetries,
+		InitialDelay: 10 * time.Second,
+		MaxDelay:     time.Minute,
+		Hooks:        []string{"install", "*-relation-changed"},
+	})
+}
+
+func (s *HookRetrySuite) TestHookRetryPolicyInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{application.HookRetryMaxRetriesKey: -1},
+		err:   "negative hook-retry-max-retries -1 not valid",
+	}, {
+		attrs: application.ConfigAttributes{application.HookRetryInitialDelayKey: "soon"},
+		err:   `hook-retry-initial-delay "soon" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.HookRetryMaxDelayKey: "-1s"},
+		err:   `non-positive hook-retry-max-delay "-1s" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{
+			application.HookRetryInitialDelayKey: "1h",
+			application.HookRetryMaxDelayKey:     "1m",
+		},
+		err: "hook-retry-initial-delay 1h0m0s greater than hook-retry-max-delay 1m0s not valid",
+	}, {
+		attrs: application.ConfigAttributes{application.HookRetryHooksKey: "install ["},
+		err:   `hook-retry-hooks pattern "\[" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := test.attrs.HookRetryPolicy()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -6,6 +6,7 @@ package cache
 import (
 	"github.com/juju/pubsub"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 )
 
@@ -80,6 +81,34 @@ func (b *Branch) CompletedBy() string {
 	return b.details.CompletedBy
 }
 
+// PromotionPolicy returns the policy used to automatically commit or abort
+// the branch, and a boolean indicating whether such a policy is set.
+func (b *Branch) PromotionPolicy() (model.PromotionPolicy, bool) {
+	if b.details.PromotionPolicy == nil {
+		return model.PromotionPolicy{}, false
+	}
+	return *b.details.PromotionPolicy, true
+}
+
+// PromotionPolicySet returns a Unix timestamp indicating when the
+// branch's promotion policy was set.
+func (b *Branch) PromotionPolicySet() int64 {
+	return b.details.PromotionPolicySet
+}
+
+// IsTracking returns true if the unit with the input name is tracking
+// the branch.
+func (b *Branch) IsTracking(unitName string) bool {
+	for _, units := range b.details.AssignedUnits {
+		for _, u := range units {
+			if u == unitName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (b *Branch) setDetails(details BranchChange) {
 	// If this is the first receipt of details, set the removal message.
 	if b.removalMessage == nil {
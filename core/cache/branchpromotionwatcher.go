@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/pubsub"
+)
+
+// BranchPromotionWatcher notifies when a change occurs that could alter the
+// outcome of evaluating branch promotion policies in a model.
+// This is the case when a branch is changed or removed,
+// or when the details of a unit tracking a branch with a policy change.
+type BranchPromotionWatcher struct {
+	*notifyWatcherBase
+
+	model *Model
+}
+
+func newBranchPromotionWatcher(model *Model, hub *pubsub.SimpleHub, res *Resident) *BranchPromotionWatcher {
+	w := &BranchPromotionWatcher{
+		notifyWatcherBase: newNotifyWatcherBase(),
+		model:             model,
+	}
+
+	deregister := res.registerWorker(w)
+	multi := hub.NewMultiplexer()
+	multi.Add(branchChange, w.branchChanged)
+	multi.Add(modelBranchRemove, w.branchRemoved)
+	multi.Add(modelUnitChange, w.unitChanged)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		multi.Unsubscribe()
+		deregister()
+		return nil
+	})
+
+	return w
+}
+
+func (w *BranchPromotionWatcher) branchChanged(_ string, _ interface{}) {
+	// We can not know whether the change was to a policy that has since
+	// been removed, so we notify on all branch changes.
+	w.notify()
+}
+
+func (w *BranchPromotionWatcher) branchRemoved(_ string, _ interface{}) {
+	w.notify()
+}
+
+// unitChanged notifies if the changed unit
+// tracks a branch that has a promotion policy.
+func (w *BranchPromotionWatcher) unitChanged(_ string, msg interface{}) {
+	unit, ok := msg.(Unit)
+	if !ok {
+		logger.Criticalf("programming error, payload type incorrect %T", msg)
+		return
+	}
+
+	defer w.model.doLocked()()
+
+	for _, b := range w.model.branches {
+		if _, hasPolicy := b.PromotionPolicy(); hasPolicy && b.IsTracking(unit.Name()) {
+			w.notify()
+			return
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
@@ -257,6 +258,14 @@ type BranchChange struct {
 	Completed     int64
 	CompletedBy   string
 	GenerationId  int
+
+	// PromotionPolicy is nil if the branch
+	// has no policy for automatic promotion.
+	PromotionPolicy *model.PromotionPolicy
+
+	// PromotionPolicySet is a Unix timestamp indicating
+	// when the promotion policy was set.
+	PromotionPolicySet int64
 }
 
 func (b BranchChange) copy() BranchChange {
@@ -293,6 +302,11 @@ func (b BranchChange) copy() BranchChange {
 	}
 	b.Config = cConfig
 
+	if b.PromotionPolicy != nil {
+		policy := *b.PromotionPolicy
+		b.PromotionPolicy = &policy
+	}
+
 	return b
 }
 
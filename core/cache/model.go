@@ -23,6 +23,8 @@ const (
 	modelUnitAdd = "model-unit-add"
 	// A unit has been removed from the model.
 	modelUnitRemove = "model-unit-remove"
+	// The details of a unit in the model have changed.
+	modelUnitChange = "model-unit-change"
 	// A branch has been removed from the model.
 	modelBranchRemove = "model-branch-remove"
 )
@@ -250,6 +252,15 @@ func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
 	return w, nil
 }
 
+// WatchBranchPromotion returns a notify watcher that fires when a change
+// occurs that could alter the outcome of evaluating branch promotion
+// policies in this model.
+func (m *Model) WatchBranchPromotion() *BranchPromotionWatcher {
+	defer m.doLocked()()
+
+	return newBranchPromotionWatcher(m, m.hub, m.Resident)
+}
+
 // updateApplication adds or updates the application in the model.
 func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	m.mu.Lock()
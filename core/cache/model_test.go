@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
@@ -305,6 +306,72 @@ func (s *ModelSuite) TestRemoveBranchPublishesName(c *gc.C) {
 	}
 }
 
+func (s *ModelSuite) TestBranchPromotionWatcherBranchChanges(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchBranchPromotion()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	m.UpdateBranch(branchChange, s.Manager)
+	wc.AssertOneChange()
+
+	err := m.RemoveBranch(cache.RemoveBranch{
+		ModelUUID: branchChange.ModelUUID,
+		Id:        branchChange.Id,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *ModelSuite) TestBranchPromotionWatcherTrackingUnitChanges(c *gc.C) {
+	m := s.NewModel(modelChange)
+
+	br := branchChange
+	br.PromotionPolicy = &model.PromotionPolicy{
+		HealthyFor: time.Minute,
+		OnError:    model.BranchErrorAbort,
+	}
+	m.UpdateBranch(br, s.Manager)
+
+	w := m.WatchBranchPromotion()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// A unit tracking the branch causes a notification.
+	unit := unitChange
+	unit.Name = "redis/0"
+	unit.Application = "redis"
+	m.UpdateUnit(unit, s.Manager)
+	wc.AssertOneChange()
+
+	// A unit not tracking the branch does not.
+	m.UpdateUnit(unitChange, s.Manager)
+	wc.AssertNoChange()
+}
+
+func (s *ModelSuite) TestBranchPromotionWatcherNoPolicy(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateBranch(branchChange, s.Manager)
+
+	w := m.WatchBranchPromotion()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// The branch has no promotion policy,
+	// so tracking unit changes are of no interest.
+	unit := unitChange
+	unit.Name = "redis/0"
+	unit.Application = "redis"
+	m.UpdateUnit(unit, s.Manager)
+	wc.AssertNoChange()
+}
+
 func (s *ModelSuite) TestWaitForUnitNewChange(c *gc.C) {
 	m := s.NewModel(modelChange)
 	done := m.WaitForUnit("application-name/0", func(u *cache.Unit) bool {
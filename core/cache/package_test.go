@@ -113,6 +113,7 @@ func (*ImportSuite) TestImports(c *gc.C) {
 		"core/instance",
 		"core/life",
 		"core/lxdprofile",
+		"core/model",
 		"core/network",
 		"core/settings",
 		"core/status",
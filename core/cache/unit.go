@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/core/status"
 )
 
 // Unit represents a unit in a cached model.
@@ -77,6 +78,16 @@ func (u *Unit) Ports() []network.Port {
 	return u.details.Ports
 }
 
+// WorkloadStatus returns the current workload status of the unit.
+func (u *Unit) WorkloadStatus() status.StatusInfo {
+	return u.details.WorkloadStatus
+}
+
+// AgentStatus returns the current status of the unit agent.
+func (u *Unit) AgentStatus() status.StatusInfo {
+	return u.details.AgentStatus
+}
+
 // Config settings returns the effective charm configuration for this unit
 // taking into account whether it is tracking a model branch.
 func (u *Unit) ConfigSettings() (charm.Settings, error) {
@@ -171,6 +182,7 @@ func (u *Unit) setDetails(details UnitChange) {
 	if machineChange || u.details.Subordinate {
 		u.model.hub.Publish(modelUnitAdd, toPublish)
 	}
+	u.model.hub.Publish(modelUnitChange, toPublish)
 	// Publish change event for those that may be waiting.
 	u.model.hub.Publish(unitChangeTopic(details.Name), &toPublish)
 }
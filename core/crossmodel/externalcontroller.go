@@ -24,6 +24,11 @@ type ControllerInfo struct {
 	// CACert holds the CA certificate that will be used to validate
 	// the API server's certificate, in PEM format.
 	CACert string
+
+	// RelayProxy optionally holds the URL of an HTTP proxy through
+	// which connections to the controller's API servers are made,
+	// for controllers whose addresses are not directly reachable.
+	RelayProxy string
 }
 
 // Validate returns an error if the ControllerInfo contains bad data.
@@ -29,6 +29,10 @@ type ApplicationOffer struct {
 	// Endpoints is the collection of endpoint names offered (internal->published).
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]charm.Relation
+
+	// MaxConnections is the maximum number of concurrent connections
+	// allowed to the offer, or 0 if there is no limit.
+	MaxConnections int
 }
 
 // AddApplicationOfferArgs contains parameters used to create an application offer.
@@ -53,6 +57,10 @@ type AddApplicationOfferArgs struct {
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]string
 
+	// MaxConnections is the maximum number of concurrent connections
+	// allowed to the offer, or 0 if there is no limit.
+	MaxConnections int
+
 	// Icon is an icon to display when browsing the ApplicationOffers, which by default
 	// comes from the charm.
 	Icon []byte
@@ -41,6 +41,10 @@ type ApplicationOfferDetails struct {
 
 	// Users are the users able to access the offer.
 	Users []OfferUserDetails
+
+	// MaxConnections is the maximum number of concurrent connections
+	// allowed to the offer, or 0 if there is no limit.
+	MaxConnections int
 }
 
 // OfferUserDetails holds the details about a user's access to an offer.
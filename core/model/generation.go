@@ -4,6 +4,10 @@
 package model
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/juju/errors"
 )
 
@@ -26,6 +30,70 @@ func ValidateBranchName(name string) error {
 	return nil
 }
 
+// BranchErrorPolicy describes how a branch promotion policy reacts
+// to a unit tracking the branch going into an error state.
+type BranchErrorPolicy string
+
+const (
+	// BranchErrorAbort indicates that all units should stop tracking the
+	// branch, after which the branch is aborted.
+	BranchErrorAbort BranchErrorPolicy = "abort"
+
+	// BranchErrorUntrack indicates that only the units in error should stop
+	// tracking the branch. The branch remains in-flight.
+	BranchErrorUntrack BranchErrorPolicy = "untrack"
+)
+
+// Validate returns an error if the policy is not one of the known values.
+func (p BranchErrorPolicy) Validate() error {
+	switch p {
+	case BranchErrorAbort, BranchErrorUntrack:
+		return nil
+	}
+	return errors.NotValidf("branch error policy %q", p)
+}
+
+// PromotionPolicy describes the conditions under which an in-flight branch
+// is automatically committed to the model, or backed out of it.
+type PromotionPolicy struct {
+	// HealthyFor, if non-zero, is the duration for which all units tracking
+	// the branch must report an "active" workload status before the branch
+	// is committed.
+	HealthyFor time.Duration `yaml:"healthy-for,omitempty"`
+
+	// ActionName, if set, is the name of an action that must complete
+	// successfully on all units tracking the branch before it is committed.
+	ActionName string `yaml:"action,omitempty"`
+
+	// OnError indicates what to do when a unit tracking
+	// the branch goes into an error state.
+	OnError BranchErrorPolicy `yaml:"on-error"`
+}
+
+// Validate returns an error if the policy is not suitable
+// for attaching to a branch.
+func (p PromotionPolicy) Validate() error {
+	if p.HealthyFor < 0 {
+		return errors.NotValidf("negative healthy duration %v", p.HealthyFor)
+	}
+	if p.HealthyFor == 0 && p.ActionName == "" {
+		return errors.NotValidf("promotion policy without a healthy duration or action")
+	}
+	return errors.Trace(p.OnError.Validate())
+}
+
+// String returns a brief human-readable description of the policy.
+func (p PromotionPolicy) String() string {
+	var conds []string
+	if p.HealthyFor > 0 {
+		conds = append(conds, fmt.Sprintf("active for %v", p.HealthyFor))
+	}
+	if p.ActionName != "" {
+		conds = append(conds, fmt.Sprintf("action %q succeeded", p.ActionName))
+	}
+	return fmt.Sprintf("commit when %s; %s on error", strings.Join(conds, " or "), p.OnError)
+}
+
 // GenerationUnits indicates which units from an application are and are not
 // tracking a model branch.
 type GenerationUnits struct {
@@ -65,6 +133,10 @@ type Generation struct {
 	// Created is the user who created the generation.
 	CreatedBy string `yaml:"created-by"`
 
+	// PromotionPolicy, if set, describes how the generation
+	// is automatically committed or aborted.
+	PromotionPolicy *PromotionPolicy `yaml:"promotion-policy,omitempty"`
+
 	// Applications is a collection of applications with changes in this
 	// generation including advanced units and modified configuration.
 	Applications []GenerationApplication `yaml:"applications"`
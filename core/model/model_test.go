@@ -4,6 +4,8 @@
 package model_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -55,3 +57,37 @@ func (*ModelSuite) TestValidateBranchName(c *gc.C) {
 		}
 	}
 }
+
+func (*ModelSuite) TestValidatePromotionPolicy(c *gc.C) {
+	for _, t := range []struct {
+		policy model.PromotionPolicy
+		valid  bool
+	}{
+		{model.PromotionPolicy{}, false},
+		{model.PromotionPolicy{HealthyFor: time.Minute}, false},
+		{model.PromotionPolicy{HealthyFor: -time.Minute, OnError: model.BranchErrorAbort}, false},
+		{model.PromotionPolicy{OnError: model.BranchErrorAbort}, false},
+		{model.PromotionPolicy{HealthyFor: time.Minute, OnError: "explode"}, false},
+		{model.PromotionPolicy{HealthyFor: time.Minute, OnError: model.BranchErrorAbort}, true},
+		{model.PromotionPolicy{ActionName: "smoke-test", OnError: model.BranchErrorUntrack}, true},
+		{model.PromotionPolicy{
+			HealthyFor: time.Minute, ActionName: "smoke-test", OnError: model.BranchErrorUntrack}, true},
+	} {
+		err := t.policy.Validate()
+		if t.valid {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
+
+func (*ModelSuite) TestPromotionPolicyString(c *gc.C) {
+	policy := model.PromotionPolicy{
+		HealthyFor: 10 * time.Minute,
+		ActionName: "smoke-test",
+		OnError:    model.BranchErrorUntrack,
+	}
+	c.Check(policy.String(), gc.Equals,
+		`commit when active for 10m0s or action "smoke-test" succeeded; untrack on error`)
+}
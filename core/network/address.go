@@ -334,9 +334,16 @@ func SelectControllerAddress(addresses []Address, machineLocal bool) (Address, b
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return SelectPublicAddressForFamily(addresses, PreferIPv4)
+}
+
+// SelectPublicAddressForFamily is like SelectPublicAddress, but prefers
+// addresses of the given family. When both families are preferred
+// (DualStack), IPv4 addresses are picked over IPv6 ones of the same scope.
+func SelectPublicAddressForFamily(addresses []Address, family AddressFamilyPreference) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, publicMatcher(family.single()))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -348,9 +355,16 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 // no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return SelectInternalAddressForFamily(addresses, machineLocal, PreferIPv4)
+}
+
+// SelectInternalAddressForFamily is like SelectInternalAddress, but
+// prefers addresses of the given family. When both families are preferred
+// (DualStack), IPv4 addresses are picked over IPv6 ones of the same scope.
+func SelectInternalAddressForFamily(addresses []Address, machineLocal bool, family AddressFamilyPreference) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, family.single()))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -361,9 +375,17 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 // used as an endpoint for juju internal communication.
 // I nil slice is returned if there are no suitable addresses identified.
 func SelectInternalAddresses(addresses []Address, machineLocal bool) []Address {
-	indexes := bestAddressIndexes(len(addresses), func(i int) Address {
+	return SelectInternalAddressesForFamily(addresses, machineLocal, PreferIPv4)
+}
+
+// SelectInternalAddressesForFamily is like SelectInternalAddresses, but
+// prefers addresses of the given family. When both families are preferred
+// (DualStack), the best addresses of both families are returned, with the
+// IPv4 addresses first.
+func SelectInternalAddressesForFamily(addresses []Address, machineLocal bool, family AddressFamilyPreference) []Address {
+	indexes := bestAddressIndexesForFamily(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, family.single()), family == DualStack)
 	if len(indexes) == 0 {
 		return nil
 	}
@@ -380,9 +402,17 @@ func SelectInternalAddresses(addresses []Address, machineLocal bool) []Address {
 // communication and returns them in NetAddr form. If there are no
 // suitable addresses, an empty slice is returned.
 func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
-	indexes := bestAddressIndexes(len(hps), func(i int) Address {
+	return SelectInternalHostPortsForFamily(hps, machineLocal, PreferIPv4)
+}
+
+// SelectInternalHostPortsForFamily is like SelectInternalHostPorts, but
+// prefers addresses of the given family. When both families are preferred
+// (DualStack), the best HostPorts of both families are returned, with the
+// IPv4 ones first.
+func SelectInternalHostPortsForFamily(hps []HostPort, machineLocal bool, family AddressFamilyPreference) []string {
+	indexes := bestAddressIndexesForFamily(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, family.single()), family == DualStack)
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -396,9 +426,16 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 // returns them in NetAddr form. If there are no suitable addresses
 // then an empty slice is returned.
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	return PrioritizeInternalHostPortsForFamily(hps, machineLocal, PreferIPv4)
+}
+
+// PrioritizeInternalHostPortsForFamily is like PrioritizeInternalHostPorts,
+// but orders addresses of the given family first within each scope. IPv4
+// addresses come first when both families are preferred (DualStack).
+func PrioritizeInternalHostPortsForFamily(hps []HostPort, machineLocal bool, family AddressFamilyPreference) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, family.single()))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -407,77 +444,87 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-func publicMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopePublic:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
-		}
-		return exactScope
-	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
-			return firstFallbackScopeIPv4
+// publicMatcher returns a scopeMatchFunc preferring public addresses
+// of the given family.
+func publicMatcher(family AddressFamilyPreference) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		preferred := family.prefers(addr.Type)
+		switch addr.Scope {
+		case ScopePublic:
+			return exactScope.orPreferred(preferred)
+		case ScopeCloudLocal:
+			return firstFallbackScope.orPreferred(preferred)
+		case ScopeFanLocal, ScopeUnknown:
+			return secondFallbackScope.orPreferred(preferred)
 		}
-		return firstFallbackScope
-	case ScopeFanLocal, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return secondFallbackScopeIPv4
-		}
-		return secondFallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func internalAddressMatcher(machineLocal bool) scopeMatchFunc {
+func internalAddressMatcher(machineLocal bool, family AddressFamilyPreference) scopeMatchFunc {
 	if machineLocal {
-		return cloudOrMachineLocalMatch
-	}
-	return cloudLocalMatch
-}
-
-func cloudLocalMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		return cloudOrMachineLocalMatcher(family)
+	}
+	return cloudLocalMatcher(family)
+}
+
+// cloudLocalMatcher returns a scopeMatchFunc preferring cloud-local
+// addresses of the given family.
+func cloudLocalMatcher(family AddressFamilyPreference) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		preferred := family.prefers(addr.Type)
+		switch addr.Scope {
+		case ScopeCloudLocal:
+			return exactScope.orPreferred(preferred)
+		case ScopeFanLocal:
+			return firstFallbackScope.orPreferred(preferred)
+		case ScopePublic, ScopeUnknown:
+			return secondFallbackScope.orPreferred(preferred)
 		}
-		return exactScope
-	case ScopeFanLocal:
-		if addr.Type == IPv4Address {
-			return firstFallbackScopeIPv4
-		}
-		return firstFallbackScope
-	case ScopePublic, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return secondFallbackScopeIPv4
-		}
-		return secondFallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func cloudOrMachineLocalMatch(addr Address) scopeMatch {
-	if addr.Scope == ScopeMachineLocal {
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+// cloudOrMachineLocalMatcher returns a scopeMatchFunc preferring
+// machine-local addresses of the given family, followed by cloud-local ones.
+func cloudOrMachineLocalMatcher(family AddressFamilyPreference) scopeMatchFunc {
+	cloudLocalMatch := cloudLocalMatcher(family)
+	return func(addr Address) scopeMatch {
+		if addr.Scope == ScopeMachineLocal {
+			return exactScope.orPreferred(family.prefers(addr.Type))
 		}
-		return exactScope
+		return cloudLocalMatch(addr)
 	}
-	return cloudLocalMatch(addr)
 }
 
 type scopeMatch int
 
 const (
 	invalidScope scopeMatch = iota
-	exactScopeIPv4
+	exactScopePreferred
 	exactScope
-	firstFallbackScopeIPv4
+	firstFallbackScopePreferred
 	firstFallbackScope
-	secondFallbackScopeIPv4
+	secondFallbackScopePreferred
 	secondFallbackScope
 )
 
+// orPreferred returns the preferred-family variant of the (non-preferred)
+// scope match m when preferred is true, and m otherwise.
+func (m scopeMatch) orPreferred(preferred bool) scopeMatch {
+	if preferred {
+		return m - 1
+	}
+	return m
+}
+
+// allowedMatchTypes holds the valid scope matches, from best to worst.
+var allowedMatchTypes = []scopeMatch{
+	exactScopePreferred, exactScope,
+	firstFallbackScopePreferred, firstFallbackScope,
+	secondFallbackScopePreferred, secondFallbackScope,
+}
+
 type scopeMatchFunc func(addr Address) scopeMatch
 
 type addressByIndexFunc func(index int) Address
@@ -497,11 +544,29 @@ func bestAddressIndex(numAddr int, getAddrFunc addressByIndexFunc, matchFunc sco
 // matching scope and type (according to the matchFunc). An empty slice is
 // returned if there were no suitable addresses.
 func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc) []int {
+	return bestAddressIndexesForFamily(numAddr, getAddrFunc, matchFunc, false)
+}
+
+// bestAddressIndexesForFamily is like bestAddressIndexes, but when
+// bothFamilies is true the indexes of the preferred and non-preferred
+// family addresses with the best matching scope are returned together,
+// preferred family first.
+func bestAddressIndexesForFamily(
+	numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc, bothFamilies bool,
+) []int {
 	// Categorise addresses by scope and type matching quality.
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, firstFallbackScopeIPv4, firstFallbackScope, secondFallbackScopeIPv4, secondFallbackScope}
+	if bothFamilies {
+		for i := 0; i < len(allowedMatchTypes); i += 2 {
+			indexes := append(matches[allowedMatchTypes[i]], matches[allowedMatchTypes[i+1]]...)
+			if len(indexes) > 0 {
+				return indexes
+			}
+		}
+		return []int{}
+	}
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
@@ -516,7 +581,6 @@ func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matc
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, firstFallbackScopeIPv4, firstFallbackScope, secondFallbackScopeIPv4, secondFallbackScope}
 	var prioritized []int
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
@@ -532,8 +596,7 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 	matches := make(map[scopeMatch][]int)
 	for i := 0; i < numAddr; i++ {
 		matchType := matchFunc(getAddrFunc(i))
-		switch matchType {
-		case exactScopeIPv4, exactScope, firstFallbackScopeIPv4, firstFallbackScope, secondFallbackScopeIPv4, secondFallbackScope:
+		if matchType != invalidScope {
 			matches[matchType] = append(matches[matchType], i)
 		}
 	}
@@ -549,6 +612,13 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - link-local next;
 // - non-hostnames with unknown scope last.
 func (a Address) sortOrder() int {
+	return a.sortOrderForFamily(PreferIPv4)
+}
+
+// sortOrderForFamily is like sortOrder, but within each scope the addresses
+// of the preferred family come first. IPv4 addresses come first when both
+// families are preferred (DualStack).
+func (a Address) sortOrderForFamily(family AddressFamilyPreference) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -568,23 +638,26 @@ func (a Address) sortOrder() int {
 		if a.Value == "localhost" {
 			order++
 		}
-	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		if !family.single().prefers(a.Type) {
+			order++
+		}
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesForFamilySlice struct {
+	addrs  []Address
+	family AddressFamilyPreference
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesForFamilySlice) Len() int      { return len(a.addrs) }
+func (a addressesForFamilySlice) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesForFamilySlice) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrderForFamily(a.family)
+	order2 := addr2.sortOrderForFamily(a.family)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -594,7 +667,13 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 // SortAddresses sorts the given Address slice according to the sortOrder of
 // each address. See Address.sortOrder() for more info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	SortAddressesForFamily(addrs, PreferIPv4)
+}
+
+// SortAddressesForFamily sorts the given Address slice like SortAddresses,
+// but placing addresses of the preferred family first within each scope.
+func SortAddressesForFamily(addrs []Address, family AddressFamilyPreference) {
+	sort.Sort(addressesForFamilySlice{addrs: addrs, family: family})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
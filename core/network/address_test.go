@@ -806,6 +806,104 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	))
 }
 
+func (*AddressSuite) TestSortAddressesForFamilyIPv6(c *gc.C) {
+	addrs := network.NewAddresses(
+		"127.0.0.1",
+		"::1",
+		"fc00::1",
+		"2001:db8::1",
+		"172.16.0.1",
+		"8.8.8.8",
+	)
+	network.SortAddressesForFamily(addrs, network.PreferIPv6)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1",
+		"8.8.8.8",
+		"fc00::1",
+		"172.16.0.1",
+		"::1",
+		"127.0.0.1",
+	))
+}
+
+func (*AddressSuite) TestSelectPublicAddressForFamily(c *gc.C) {
+	addrs := []network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	}
+	addr, ok := network.SelectPublicAddressForFamily(addrs, network.PreferIPv4)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+
+	addr, ok = network.SelectPublicAddressForFamily(addrs, network.PreferIPv6)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+
+	addr, ok = network.SelectPublicAddressForFamily(addrs, network.DualStack)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+}
+
+func (*AddressSuite) TestSelectInternalAddressForFamilyFallsBack(c *gc.C) {
+	// A cloud-local IPv4 address is still better than a public IPv6 one.
+	addrs := []network.Address{
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	}
+	addr, ok := network.SelectInternalAddressForFamily(addrs, false, network.PreferIPv6)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (*AddressSuite) TestSelectInternalAddressesForFamily(c *gc.C) {
+	addrs := []network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("127.0.0.1", network.ScopeMachineLocal),
+	}
+	c.Check(network.SelectInternalAddressesForFamily(addrs, false, network.PreferIPv4), jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	})
+	c.Check(network.SelectInternalAddressesForFamily(addrs, false, network.PreferIPv6), jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	})
+	c.Check(network.SelectInternalAddressesForFamily(addrs, false, network.DualStack), jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	})
+	c.Check(network.SelectInternalAddressesForFamily(addrs, true, network.DualStack), jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("127.0.0.1", network.ScopeMachineLocal),
+	})
+}
+
+func (*AddressSuite) TestSelectInternalHostPortsForFamily(c *gc.C) {
+	hps := network.NewHostPorts(17070, "fc00::1", "8.8.8.8", "10.0.0.1")
+	c.Check(network.SelectInternalHostPortsForFamily(hps, false, network.PreferIPv4), jc.DeepEquals, []string{
+		"10.0.0.1:17070",
+	})
+	c.Check(network.SelectInternalHostPortsForFamily(hps, false, network.PreferIPv6), jc.DeepEquals, []string{
+		"[fc00::1]:17070",
+	})
+	c.Check(network.SelectInternalHostPortsForFamily(hps, false, network.DualStack), jc.DeepEquals, []string{
+		"10.0.0.1:17070", "[fc00::1]:17070",
+	})
+}
+
+func (*AddressSuite) TestPrioritizeInternalHostPortsForFamily(c *gc.C) {
+	hps := network.NewHostPorts(17070, "fc00::1", "8.8.8.8", "10.0.0.1")
+	c.Check(network.PrioritizeInternalHostPortsForFamily(hps, false, network.PreferIPv4), jc.DeepEquals, []string{
+		"10.0.0.1:17070", "[fc00::1]:17070", "8.8.8.8:17070",
+	})
+	c.Check(network.PrioritizeInternalHostPortsForFamily(hps, false, network.PreferIPv6), jc.DeepEquals, []string{
+		"[fc00::1]:17070", "10.0.0.1:17070", "8.8.8.8:17070",
+	})
+	c.Check(network.PrioritizeInternalHostPortsForFamily(hps, false, network.DualStack), jc.DeepEquals, []string{
+		"10.0.0.1:17070", "[fc00::1]:17070", "8.8.8.8:17070",
+	})
+}
+
 func (*AddressSuite) TestIPv4ToDecimal(c *gc.C) {
 	zeroIP, err := network.IPv4ToDecimal(net.ParseIP("0.0.0.0"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// AddressFamilyPreference describes which IP address family should be
+// preferred when selecting, sorting and rendering addresses.
+type AddressFamilyPreference string
+
+const (
+	// PreferIPv4 prefers IPv4 addresses over IPv6 ones. This is the default.
+	PreferIPv4 AddressFamilyPreference = "ipv4"
+
+	// PreferIPv6 prefers IPv6 addresses over IPv4 ones.
+	PreferIPv6 AddressFamilyPreference = "ipv6"
+
+	// DualStack uses addresses of both families where possible, listing
+	// IPv4 addresses first where a single order is required.
+	DualStack AddressFamilyPreference = "dual"
+)
+
+// Validate returns an error if the preference is not one of the known
+// address family preferences.
+func (p AddressFamilyPreference) Validate() error {
+	switch p {
+	case PreferIPv4, PreferIPv6, DualStack:
+		return nil
+	}
+	return errors.NotValidf("address family preference %q", p)
+}
+
+// UsesIPv6 returns true if IPv6 addresses are to be used alongside, or
+// in preference to, IPv4 ones.
+func (p AddressFamilyPreference) UsesIPv6() bool {
+	return p == PreferIPv6 || p == DualStack
+}
+
+// single returns the preference to use when only one address family
+// can come first.
+func (p AddressFamilyPreference) single() AddressFamilyPreference {
+	if p == PreferIPv6 {
+		return PreferIPv6
+	}
+	return PreferIPv4
+}
+
+// prefers returns true if addresses of the given type are preferred.
+// When both families are preferred (DualStack), addresses of both types
+// are preferred.
+func (p AddressFamilyPreference) prefers(addrType AddressType) bool {
+	switch addrType {
+	case IPv4Address:
+		return p != PreferIPv6
+	case IPv6Address:
+		return p.UsesIPv6()
+	}
+	return false
+}
+
+// SortAddressValuesForFamily stably sorts the given address values so
+// that IP addresses of the family that is not preferred come after all
+// others. When both families are preferred (DualStack), IPv4 addresses
+// come before IPv6 ones. Values that are not IP addresses keep their
+// relative position.
+func SortAddressValuesForFamily(values []string, family AddressFamilyPreference) {
+	family = family.single()
+	sort.SliceStable(values, func(i, j int) bool {
+		return !family.Demotes(values[i]) && family.Demotes(values[j])
+	})
+}
+
+// Demotes returns true if the given address value is an IP address of
+// the family that is not preferred. No address is demoted when both
+// families are preferred (DualStack).
+func (p AddressFamilyPreference) Demotes(value string) bool {
+	addrType := DeriveAddressType(value)
+	return addrType != HostName && !p.prefers(addrType)
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/testing"
+)
+
+type FamilySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FamilySuite{})
+
+func (*FamilySuite) TestValidate(c *gc.C) {
+	for _, p := range []network.AddressFamilyPreference{
+		network.PreferIPv4, network.PreferIPv6, network.DualStack,
+	} {
+		c.Check(p.Validate(), jc.ErrorIsNil)
+	}
+	err := network.AddressFamilyPreference("ipv5").Validate()
+	c.Assert(err, gc.ErrorMatches, `address family preference "ipv5" not valid`)
+}
+
+func (*FamilySuite) TestUsesIPv6(c *gc.C) {
+	c.Check(network.PreferIPv4.UsesIPv6(), jc.IsFalse)
+	c.Check(network.PreferIPv6.UsesIPv6(), jc.IsTrue)
+	c.Check(network.DualStack.UsesIPv6(), jc.IsTrue)
+}
+
+func (*FamilySuite) TestSortAddressValuesForFamily(c *gc.C) {
+	values := []string{"fc00::1", "example.com", "10.0.0.1", "2001:db8::1", "8.8.8.8"}
+
+	network.SortAddressValuesForFamily(values, network.PreferIPv4)
+	c.Check(values, jc.DeepEquals, []string{"example.com", "10.0.0.1", "8.8.8.8", "fc00::1", "2001:db8::1"})
+
+	network.SortAddressValuesForFamily(values, network.PreferIPv6)
+	c.Check(values, jc.DeepEquals, []string{"example.com", "fc00::1", "2001:db8::1", "10.0.0.1", "8.8.8.8"})
+
+	network.SortAddressValuesForFamily(values, network.DualStack)
+	c.Check(values, jc.DeepEquals, []string{"example.com", "10.0.0.1", "8.8.8.8", "fc00::1", "2001:db8::1"})
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"net"
+	"path"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+)
+
+// SubnetSpaceRule maps subnets discovered from a provider to a space,
+// either by CIDR or by a shell pattern matched against the subnet name.
+type SubnetSpaceRule struct {
+	// Space is the name of the space that matching subnets are added to.
+	Space string `yaml:"space"`
+
+	// CIDR, if set, matches subnets contained in this range.
+	CIDR string `yaml:"cidr,omitempty"`
+
+	// Name, if set, is a shell pattern matched against the provider
+	// name of the subnet, or its provider ID if it has no name.
+	Name string `yaml:"name,omitempty"`
+}
+
+// Validate returns an error if the rule does not name a valid space,
+// or does not have exactly one of a valid CIDR or name pattern.
+func (r SubnetSpaceRule) Validate() error {
+	if !names.IsValidSpace(r.Space) {
+		return errors.NotValidf("space name %q", r.Space)
+	}
+	switch {
+	case r.CIDR == "" && r.Name == "":
+		return errors.Errorf("rule for space %q must specify a cidr or a name", r.Space)
+	case r.CIDR != "" && r.Name != "":
+		return errors.Errorf("rule for space %q cannot specify both a cidr and a name", r.Space)
+	case r.CIDR != "":
+		if _, _, err := net.ParseCIDR(r.CIDR); err != nil {
+			return errors.NotValidf("cidr %q for space %q", r.CIDR, r.Space)
+		}
+	default:
+		if _, err := path.Match(r.Name, ""); err != nil {
+			return errors.NotValidf("name pattern %q for space %q", r.Name, r.Space)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the subnet is matched by the rule.
+func (r SubnetSpaceRule) Matches(subnet SubnetInfo) bool {
+	if r.CIDR != "" {
+		_, ruleNet, err := net.ParseCIDR(r.CIDR)
+		if err != nil {
+			return false
+		}
+		_, subnetNet, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			return false
+		}
+		ruleSize, _ := ruleNet.Mask.Size()
+		subnetSize, _ := subnetNet.Mask.Size()
+		return ruleNet.Contains(subnetNet.IP) && subnetSize >= ruleSize
+	}
+	name := subnet.ProviderName
+	if name == "" {
+		name = string(subnet.ProviderId)
+	}
+	matched, _ := path.Match(r.Name, name)
+	return matched
+}
+
+// SubnetSpaceRules is an ordered list of rules mapping subnets to spaces.
+type SubnetSpaceRules []SubnetSpaceRule
+
+// Validate returns an error if any of the rules is not valid.
+func (rules SubnetSpaceRules) Validate() error {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// SpaceFor returns the space of the first rule matching the subnet,
+// or the default space name if no rule matches.
+func (rules SubnetSpaceRules) SpaceFor(subnet SubnetInfo) string {
+	for _, rule := range rules {
+		if rule.Matches(subnet) {
+			return rule.Space
+		}
+	}
+	return DefaultSpaceName
+}
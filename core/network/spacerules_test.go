@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/testing"
+)
+
+type SpaceRulesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SpaceRulesSuite{})
+
+func (*SpaceRulesSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		rule network.SubnetSpaceRule
+		err  string
+	}{{
+		rule: network.SubnetSpaceRule{Space: "dmz", CIDR: "10.0.0.0/8"},
+	}, {
+		rule: network.SubnetSpaceRule{Space: "dmz", Name: "dmz-*"},
+	}, {
+		rule: network.SubnetSpaceRule{Space: "Not Valid", CIDR: "10.0.0.0/8"},
+		err:  `space name "Not Valid" not valid`,
+	}, {
+		rule: network.SubnetSpaceRule{Space: "dmz"},
+		err:  `rule for space "dmz" must specify a cidr or a name`,
+	}, {
+		rule: network.SubnetSpaceRule{Space: "dmz", CIDR: "10.0.0.0/8", Name: "dmz-*"},
+		err:  `rule for space "dmz" cannot specify both a cidr and a name`,
+	}, {
+		rule: network.SubnetSpaceRule{Space: "dmz", CIDR: "10.0.0.0"},
+		err:  `cidr "10.0.0.0" for space "dmz" not valid`,
+	}, {
+		rule: network.SubnetSpaceRule{Space: "dmz", Name: "dmz-["},
+		err:  `name pattern "dmz-\[" for space "dmz" not valid`,
+	}} {
+		c.Logf("test %d: %+v", i, test.rule)
+		err := test.rule.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (*SpaceRulesSuite) TestSpaceFor(c *gc.C) {
+	rules := network.SubnetSpaceRules{
+		{Space: "dmz", CIDR: "10.10.0.0/16"},
+		{Space: "internal", Name: "internal-*"},
+		{Space: "storage", CIDR: "10.0.0.0/8"},
+	}
+
+	for i, test := range []struct {
+		subnet network.SubnetInfo
+		space  string
+	}{{
+		subnet: network.SubnetInfo{CIDR: "10.10.1.0/24", ProviderName: "internal-a"},
+		space:  "dmz",
+	}, {
+		subnet: network.SubnetInfo{CIDR: "10.20.1.0/24", ProviderName: "internal-a"},
+		space:  "internal",
+	}, {
+		subnet: network.SubnetInfo{CIDR: "192.168.1.0/24", ProviderId: "internal-b"},
+		space:  "internal",
+	}, {
+		subnet: network.SubnetInfo{CIDR: "10.20.1.0/24", ProviderName: "other"},
+		space:  "storage",
+	}, {
+		// The rule range must contain the whole subnet.
+		subnet: network.SubnetInfo{CIDR: "10.0.0.0/7", ProviderName: "other"},
+		space:  network.DefaultSpaceName,
+	}, {
+		subnet: network.SubnetInfo{CIDR: "192.168.1.0/24", ProviderName: "other"},
+		space:  network.DefaultSpaceName,
+	}} {
+		c.Logf("test %d: %+v", i, test.subnet)
+		c.Check(rules.SpaceFor(test.subnet), gc.Equals, test.space)
+	}
+}
@@ -43,6 +43,11 @@ type SubnetInfo struct {
 	// containing this subnet, for example VPC id for EC2.
 	ProviderNetworkId Id
 
+	// ProviderName is the human-readable name of the subnet in the
+	// provider, if it has one. It is used to match subnets against
+	// the subnet-space-rules during discovery, and is not persisted.
+	ProviderName string
+
 	// VLANTag needs to be between 1 and 4094 for VLANs and 0 for
 	// normal networks. It's defined by IEEE 802.1Q standard, and used
 	// to define a VLAN network. For more information, see:
@@ -149,6 +149,11 @@ const (
 	// The unit needs manual intervention to get back to the Running state.
 	Blocked Status = "blocked"
 
+	// Warning is set by Juju, rather than by the charm, when:
+	// The unit's storage is nearly full. The status that the charm last
+	// set is restored when the storage is no longer nearly full.
+	Warning Status = "warning"
+
 	// Active is set when:
 	// The unit believes it is correctly offering all the services it has
 	// been asked to offer.
@@ -295,6 +300,8 @@ func (status Status) KnownWorkloadStatus() bool {
 	switch status {
 	case Error: // include error so that we can filter on what the spec says is valid
 		return true
+	case Warning: // set by Juju, so not valid for charms to set
+		return true
 	default:
 		return false
 	}
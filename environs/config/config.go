@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/controller"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
@@ -221,6 +223,33 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// StorageUsageThresholdKey is the key for the percentage of a
+	// storage instance's capacity that may be used before a warning
+	// status is set on the units it is attached to.
+	StorageUsageThresholdKey = "storage-usage-warning-threshold"
+
+	// HostFirewallKey is the key for whether machine agents maintain
+	// a host-level firewall mirroring the ports opened by units and
+	// the application exposure rules.
+	HostFirewallKey = "host-firewall"
+
+	// PreferredAddressFamilyKey is the key for the IP address family
+	// (ipv4, ipv6 or dual) preferred when selecting machine and unit
+	// addresses, opening ports and rendering network configuration.
+	PreferredAddressFamilyKey = "preferred-address-family"
+
+	// SpaceProxySettingsKey is the key for the proxy and apt-mirror
+	// settings that override the model-wide values for machines in
+	// particular spaces. The value is YAML, mapping space names to
+	// the overridden settings.
+	SpaceProxySettingsKey = "space-proxy-settings"
+
+	// SubnetSpaceRulesKey is the key for the rules used to add the
+	// subnets discovered from the provider to spaces. The value is a
+	// YAML list of rules, each mapping subnets to a space by CIDR or
+	// by subnet name pattern.
+	SubnetSpaceRulesKey = "subnet-space-rules"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -411,6 +440,11 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultStorageUsageWarningThreshold is the default value for
+	// StorageUsageThresholdKey; storage usage warnings are disabled
+	// by default.
+	DefaultStorageUsageWarningThreshold = 0
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -453,6 +487,11 @@ var defaultConfigValues = map[string]interface{}{
 	TransmitVendorMetricsKey:      true,
 	UpdateStatusHookInterval:      DefaultUpdateStatusHookInterval,
 	EgressSubnets:                 "",
+	StorageUsageThresholdKey:      DefaultStorageUsageWarningThreshold,
+	HostFirewallKey:               false,
+	PreferredAddressFamilyKey:     string(corenetwork.PreferIPv4),
+	SpaceProxySettingsKey:         "",
+	SubnetSpaceRulesKey:           "",
 	FanConfig:                     "",
 	CloudInitUserDataKey:          "",
 	ContainerInheritPropertiesKey: "",
@@ -651,6 +690,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[StorageUsageThresholdKey].(int); ok {
+		if v < 0 || v > 100 {
+			return errors.NotValidf("storage usage warning threshold %d (must be between 0 and 100)", v)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -716,6 +761,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if raw, ok := cfg.defined[SpaceProxySettingsKey].(string); ok && raw != "" {
+		if _, err := ParseSpaceProxySettings(raw); err != nil {
+			return errors.Annotate(err, SpaceProxySettingsKey)
+		}
+	}
+
+	if raw, ok := cfg.defined[SubnetSpaceRulesKey].(string); ok && raw != "" {
+		if _, err := parseSubnetSpaceRules(raw); err != nil {
+			return errors.Annotate(err, SubnetSpaceRulesKey)
+		}
+	}
+
 	if raw, ok := cfg.defined[ContainerInheritPropertiesKey].(string); ok && raw != "" {
 		rawProperties := strings.Split(raw, ",")
 		propertySet := set.NewStrings()
@@ -854,6 +911,143 @@ func (c *Config) ProxySSH() bool {
 	return value
 }
 
+// StorageUsageWarningThreshold returns the percentage of a storage
+// instance's capacity that may be used before a warning is set on the
+// status of its filesystem or volume, and the workload status of the
+// units it is attached to. A value of 0 disables the warning.
+func (c *Config) StorageUsageWarningThreshold() int {
+	if value, ok := c.defined[StorageUsageThresholdKey].(int); ok {
+		return value
+	}
+	return DefaultStorageUsageWarningThreshold
+}
+
+// HostFirewall returns whether machine agents should maintain a
+// host-level firewall mirroring the ports opened by units and the
+// application exposure rules.
+func (c *Config) HostFirewall() bool {
+	value, _ := c.defined[HostFirewallKey].(bool)
+	return value
+}
+
+// PreferredAddressFamily returns the IP address family preferred when
+// selecting addresses, opening ports and rendering network configuration.
+func (c *Config) PreferredAddressFamily() corenetwork.AddressFamilyPreference {
+	if value, ok := c.defined[PreferredAddressFamilyKey].(string); ok && value != "" {
+		return corenetwork.AddressFamilyPreference(value)
+	}
+	return corenetwork.PreferIPv4
+}
+
+// spaceProxySettingKeys holds the settings that may be overridden
+// per space with space-proxy-settings.
+var spaceProxySettingKeys = set.NewStrings(
+	HTTPProxyKey, HTTPSProxyKey, FTPProxyKey, NoProxyKey,
+	JujuHTTPProxyKey, JujuHTTPSProxyKey, JujuFTPProxyKey, JujuNoProxyKey,
+	AptHTTPProxyKey, AptHTTPSProxyKey, AptFTPProxyKey, AptNoProxyKey,
+	"apt-mirror",
+)
+
+// ParseSpaceProxySettings parses the YAML value of space-proxy-settings,
+// ensuring that only proxy and apt-mirror settings are overridden.
+func ParseSpaceProxySettings(raw string) (map[string]map[string]string, error) {
+	spaces, err := ensureStringMaps(raw)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]map[string]string)
+	for space, v := range spaces {
+		values, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("settings for space %q must be a map", space)
+		}
+		settings := make(map[string]string)
+		for key, value := range values {
+			if !spaceProxySettingKeys.Contains(key) {
+				return nil, errors.Errorf("%q cannot be set for space %q", key, space)
+			}
+			str, ok := value.(string)
+			if !ok {
+				return nil, errors.Errorf("%q for space %q must be a string", key, space)
+			}
+			settings[key] = str
+		}
+		result[space] = settings
+	}
+	return result, nil
+}
+
+// SpaceProxySettings returns the proxy and apt-mirror settings that
+// override the model-wide values, keyed by space name.
+func (c *Config) SpaceProxySettings() map[string]map[string]string {
+	raw := c.asString(SpaceProxySettingsKey)
+	if raw == "" {
+		return nil
+	}
+	// The raw data has already passed Validate()
+	settings, _ := ParseSpaceProxySettings(raw)
+	return settings
+}
+
+// ForSpaces returns the configuration that applies to a machine in the
+// given spaces, with the overrides from space-proxy-settings applied.
+// An error is returned if several of the spaces override the same
+// setting with different values, as there is no way to choose between
+// them.
+func (c *Config) ForSpaces(spaces []string) (*Config, error) {
+	overrides := c.SpaceProxySettings()
+	attrs := make(map[string]interface{})
+	overriddenBy := make(map[string]string)
+	for _, space := range spaces {
+		settings := overrides[space]
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := settings[key]
+			if other, ok := overriddenBy[key]; ok && attrs[key] != value {
+				return nil, errors.Errorf(
+					"spaces %q and %q override %q with conflicting values in %s",
+					other, space, key, SpaceProxySettingsKey,
+				)
+			}
+			attrs[key] = value
+			overriddenBy[key] = space
+		}
+	}
+	if len(attrs) == 0 {
+		return c, nil
+	}
+	return c.Apply(attrs)
+}
+
+// parseSubnetSpaceRules parses and validates the YAML value of
+// subnet-space-rules.
+func parseSubnetSpaceRules(raw string) (corenetwork.SubnetSpaceRules, error) {
+	var rules corenetwork.SubnetSpaceRules
+	if err := yaml.UnmarshalStrict([]byte(raw), &rules); err != nil {
+		return nil, errors.Annotate(err, "must be a valid YAML list of rules")
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rules, nil
+}
+
+// SubnetSpaceRules returns the rules used to add the subnets discovered
+// from the provider to spaces.
+func (c *Config) SubnetSpaceRules() corenetwork.SubnetSpaceRules {
+	raw := c.asString(SubnetSpaceRulesKey)
+	if raw == "" {
+		return nil
+	}
+	// The raw data has already passed Validate()
+	rules, _ := parseSubnetSpaceRules(raw)
+	return rules
+}
+
 // NetBondReconfigureDelay returns the duration in seconds that should be
 // passed to the bridge script when bridging bonded interfaces.
 func (c *Config) NetBondReconfigureDelay() int {
@@ -1510,6 +1704,11 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
+	StorageUsageThresholdKey:      schema.Omit,
+	HostFirewallKey:               schema.Omit,
+	PreferredAddressFamilyKey:     schema.Omit,
+	SpaceProxySettingsKey:         schema.Omit,
+	SubnetSpaceRulesKey:           schema.Omit,
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
@@ -1993,6 +2192,32 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HostFirewallKey: {
+		Description: "Whether machine agents maintain a host-level firewall mirroring the ports opened by units and the application exposure rules",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	PreferredAddressFamilyKey: {
+		Description: "The IP address family preferred for machine and unit addresses, network-get output, the firewaller and network configuration: ipv4, ipv6 or dual",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"ipv4", "ipv6", "dual"},
+		Group:       environschema.EnvironGroup,
+	},
+	SpaceProxySettingsKey: {
+		Description: "Proxy and apt-mirror settings (in yaml format) keyed by space name, overriding the model-wide values for machines in those spaces; it is an error for a machine to be in several spaces that override a setting with different values",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SubnetSpaceRulesKey: {
+		Description: "Rules (in yaml format) adding the subnets discovered from the provider to spaces, matching subnets by cidr or by name pattern",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StorageUsageThresholdKey: {
+		Description: "The percentage of a storage instance's capacity that may be used before a warning is set on its filesystem or volume status and on the workload status of its units (0, the default, disables the warning)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
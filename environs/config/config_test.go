@@ -21,6 +21,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/cert"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/testing"
@@ -1318,6 +1319,209 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestStorageUsageWarningThresholdDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.StorageUsageWarningThreshold(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestStorageUsageWarningThresholdValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"storage-usage-warning-threshold": 90,
+	})
+	c.Assert(cfg.StorageUsageWarningThreshold(), gc.Equals, 90)
+}
+
+func (s *ConfigSuite) TestStorageUsageWarningThresholdInvalid(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"storage-usage-warning-threshold": 101,
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, `storage usage warning threshold 101 \(must be between 0 and 100\) not valid`)
+}
+
+func (s *ConfigSuite) TestHostFirewallDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HostFirewall(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestHostFirewallValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"host-firewall": true,
+	})
+	c.Assert(cfg.HostFirewall(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestPreferredAddressFamilyDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PreferredAddressFamily(), gc.Equals, corenetwork.PreferIPv4)
+}
+
+func (s *ConfigSuite) TestPreferredAddressFamilyValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"preferred-address-family": "dual",
+	})
+	c.Assert(cfg.PreferredAddressFamily(), gc.Equals, corenetwork.DualStack)
+}
+
+func (s *ConfigSuite) TestPreferredAddressFamilyInvalid(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"preferred-address-family": "ipv5",
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, `preferred-address-family: expected one of \[ipv4 ipv6 dual\], got "ipv5"`)
+}
+
+func (s *ConfigSuite) TestSpaceProxySettings(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: http://dmz-proxy:3128
+  apt-mirror: http://dmz-mirror/ubuntu
+internal:
+  juju-http-proxy: http://internal-proxy:3128
+`,
+	})
+	c.Assert(cfg.SpaceProxySettings(), gc.DeepEquals, map[string]map[string]string{
+		"dmz": {
+			"juju-http-proxy": "http://dmz-proxy:3128",
+			"apt-mirror":      "http://dmz-mirror/ubuntu",
+		},
+		"internal": {
+			"juju-http-proxy": "http://internal-proxy:3128",
+		},
+	})
+}
+
+func (s *ConfigSuite) TestSpaceProxySettingsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "dmz: [",
+		err:   `space-proxy-settings: must be valid YAML: .*`,
+	}, {
+		value: "dmz: http://proxy",
+		err:   `space-proxy-settings: settings for space "dmz" must be a map`,
+	}, {
+		value: "dmz:\n  default-series: bionic",
+		err:   `space-proxy-settings: "default-series" cannot be set for space "dmz"`,
+	}, {
+		value: "dmz:\n  juju-http-proxy: [a, b]",
+		err:   `space-proxy-settings: "juju-http-proxy" for space "dmz" must be a string`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		attrs := testing.FakeConfig().Merge(testing.Attrs{
+			"space-proxy-settings": test.value,
+		})
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestForSpaces(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"juju-http-proxy": "http://model-proxy:3128",
+		"apt-mirror":      "http://model-mirror/ubuntu",
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: http://dmz-proxy:3128
+  apt-mirror: http://dmz-mirror/ubuntu
+internal:
+  juju-http-proxy: http://internal-proxy:3128
+`,
+	})
+
+	spaceCfg, err := cfg.ForSpaces(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spaceCfg.JujuHTTPProxy(), gc.Equals, "http://model-proxy:3128")
+	c.Check(spaceCfg.AptMirror(), gc.Equals, "http://model-mirror/ubuntu")
+
+	spaceCfg, err = cfg.ForSpaces([]string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spaceCfg.JujuHTTPProxy(), gc.Equals, "http://internal-proxy:3128")
+	c.Check(spaceCfg.AptMirror(), gc.Equals, "http://model-mirror/ubuntu")
+	c.Check(spaceCfg.AptHTTPProxy(), gc.Equals, "http://internal-proxy:3128")
+}
+
+func (s *ConfigSuite) TestForSpacesSeveralSpaces(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"juju-http-proxy": "http://model-proxy:3128",
+		"apt-mirror":      "http://model-mirror/ubuntu",
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: http://proxy:3128
+  apt-mirror: http://dmz-mirror/ubuntu
+internal:
+  juju-http-proxy: http://proxy:3128
+  juju-https-proxy: https://internal-proxy:3129
+`,
+	})
+
+	// Overrides from several spaces are combined, and the spaces may
+	// override the same setting with the same value.
+	spaceCfg, err := cfg.ForSpaces([]string{"dmz", "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spaceCfg.JujuHTTPProxy(), gc.Equals, "http://proxy:3128")
+	c.Check(spaceCfg.JujuHTTPSProxy(), gc.Equals, "https://internal-proxy:3129")
+	c.Check(spaceCfg.AptMirror(), gc.Equals, "http://dmz-mirror/ubuntu")
+}
+
+func (s *ConfigSuite) TestForSpacesConflictingOverrides(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"space-proxy-settings": `
+dmz:
+  juju-http-proxy: http://dmz-proxy:3128
+internal:
+  juju-http-proxy: http://internal-proxy:3128
+`,
+	})
+
+	_, err := cfg.ForSpaces([]string{"dmz", "internal"})
+	c.Assert(err, gc.ErrorMatches,
+		`spaces "dmz" and "internal" override "juju-http-proxy" with conflicting values in space-proxy-settings`)
+}
+
+func (s *ConfigSuite) TestSubnetSpaceRules(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"subnet-space-rules": `
+- space: dmz
+  cidr: 10.10.0.0/16
+- space: internal
+  name: internal-*
+`,
+	})
+	c.Assert(cfg.SubnetSpaceRules(), jc.DeepEquals, corenetwork.SubnetSpaceRules{
+		{Space: "dmz", CIDR: "10.10.0.0/16"},
+		{Space: "internal", Name: "internal-*"},
+	})
+}
+
+func (s *ConfigSuite) TestSubnetSpaceRulesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "space: dmz",
+		err:   `subnet-space-rules: must be a valid YAML list of rules: .*`,
+	}, {
+		value: "- {space: dmz, cidr: 10.0.0.0/8, zone: a}",
+		err:   `subnet-space-rules: must be a valid YAML list of rules: .*`,
+	}, {
+		value: "- {space: dmz}",
+		err:   `subnet-space-rules: rule for space "dmz" must specify a cidr or a name`,
+	}, {
+		value: "- {space: dmz, cidr: 10.0.0.0}",
+		err:   `subnet-space-rules: cidr "10.0.0.0" for space "dmz" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		attrs := testing.FakeConfig().Merge(testing.Attrs{
+			"subnet-space-rules": test.value,
+		})
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
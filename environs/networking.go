@@ -112,6 +112,25 @@ func supportsNetworking(environ BootstrapEnviron) (NetworkingEnviron, bool) {
 	return ne, ok
 }
 
+// SubnetDiscovery defines the method used to discover the subnets
+// available to a model. It is part of the Networking interface, and may
+// be implemented on its own by environs whose subnets can be imported,
+// but which do not support the rest of that interface.
+type SubnetDiscovery interface {
+	// Subnets returns basic information about subnets known
+	// by the provider for the environment.
+	Subnets(
+		ctx context.ProviderCallContext, inst instance.Id, subnetIds []corenetwork.Id,
+	) ([]corenetwork.SubnetInfo, error)
+}
+
+// SupportsSubnetDiscovery is a convenience helper to check if an
+// environment can report the subnets available to the model.
+func SupportsSubnetDiscovery(environ BootstrapEnviron) (SubnetDiscovery, bool) {
+	sd, ok := environ.(SubnetDiscovery)
+	return sd, ok
+}
+
 // SupportsSpaces checks if the environment implements NetworkingEnviron
 // and also if it supports spaces.
 func SupportsSpaces(ctx context.ProviderCallContext, env BootstrapEnviron) bool {
@@ -12,5 +12,6 @@ const (
 	JujuRun          = "juju-run"
 	JujuDumpLogs     = "juju-dumplogs"
 	JujuIntrospect   = "juju-introspect"
+	JujuHookReplay   = "juju-hook-replay"
 	JujuUpdateSeries = "juju-updateseries"
 )
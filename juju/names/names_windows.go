@@ -11,5 +11,6 @@ const (
 	JujuRun          = "juju-run.exe"
 	JujuDumpLogs     = "juju-dumplogs.exe"
 	JujuIntrospect   = "juju-introspect.exe"
+	JujuHookReplay   = "juju-hook-replay.exe"
 	JujuUpdateSeries = "juju-updateseries.exe"
 )
@@ -24,6 +24,7 @@ const (
 	jujuDumpLogs
 	jujuIntrospect
 	jujuUpdateSeries
+	jujuHookReplay
 	instanceCloudInitDir
 	cloudInitCfgDir
 	curtinInstallConfig
@@ -47,6 +48,7 @@ var nixVals = map[osVarType]string{
 	jujuDumpLogs:         "/usr/bin/juju-dumplogs",
 	jujuIntrospect:       "/usr/bin/juju-introspect",
 	jujuUpdateSeries:     "/usr/bin/juju-updateseries",
+	jujuHookReplay:       "/usr/bin/juju-hook-replay",
 	certDir:              "/etc/juju/certs.d",
 	metricsSpoolDir:      "/var/lib/juju/metricspool",
 	uniterStateDir:       "/var/lib/juju/uniter/state",
@@ -65,6 +67,7 @@ var winVals = map[osVarType]string{
 	jujuDumpLogs:     "C:/Juju/bin/juju-dumplogs.exe",
 	jujuIntrospect:   "C:/Juju/bin/juju-introspect.exe",
 	jujuUpdateSeries: "C:/Juju/bin/juju-updateseries.exe",
+	jujuHookReplay:   "C:/Juju/bin/juju-hook-replay.exe",
 	certDir:          "C:/Juju/certs",
 	metricsSpoolDir:  "C:/Juju/lib/juju/metricspool",
 	uniterStateDir:   "C:/Juju/lib/juju/uniter/state",
@@ -172,6 +175,12 @@ func JujuUpdateSeries(series string) (string, error) {
 	return osVal(series, jujuUpdateSeries)
 }
 
+// JujuHookReplay returns the absolute path to the juju-hook-replay
+// binary for a particular series.
+func JujuHookReplay(series string) (string, error) {
+	return osVal(series, jujuHookReplay)
+}
+
 func MustSucceed(s string, e error) string {
 	if e != nil {
 		panic(e)
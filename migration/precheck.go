@@ -75,6 +75,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -88,6 +89,7 @@ type PrecheckUnit interface {
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
 	ShouldBeAssigned() bool
+	Paused() (state.UnitPause, bool)
 }
 
 // PrecheckRelation describes the state interface for relations needed
@@ -347,6 +349,12 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		// Expose settings can't be represented in the model
+		// description, and dropping them would expose the application
+		// to everyone.
+		if len(app.ExposedEndpoints()) > 0 {
+			return nil, errors.Errorf("application %s has expose settings", app.Name())
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
@@ -372,6 +380,10 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
 		}
 
+		if _, paused := unit.Paused(); paused {
+			return errors.Errorf("unit %s is paused", unit.Name())
+		}
+
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
 			return errors.Trace(err)
 		}
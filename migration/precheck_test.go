@@ -176,6 +176,21 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestApplicationExposeSettings(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				exposed: map[string]state.ExposedEndpoint{
+					"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has expose settings")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -270,6 +285,21 @@ func (s *SourcePrecheckSuite) TestUnitNotIdle(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "unit foo/0 not idle or executing (failed)")
 }
 
+func (s *SourcePrecheckSuite) TestUnitPaused(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", paused: true},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "unit foo/0 is paused")
+}
+
 func (s *SourcePrecheckSuite) TestUnitLostLegacy(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -965,6 +995,7 @@ type fakeApp struct {
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	exposed  map[string]state.ExposedEndpoint
 }
 
 func (a *fakeApp) Name() string {
@@ -991,6 +1022,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	return a.exposed
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -999,6 +1034,7 @@ type fakeUnit struct {
 	charmURL    string
 	agentStatus status.Status
 	lost        bool
+	paused      bool
 }
 
 func (u *fakeUnit) Name() string {
@@ -1053,6 +1089,13 @@ func (u *fakeUnit) AgentPresence() (bool, error) {
 	return !u.lost, nil
 }
 
+func (u *fakeUnit) Paused() (state.UnitPause, bool) {
+	if !u.paused {
+		return state.UnitPause{}, false
+	}
+	return state.UnitPause{By: "admin", Reason: "maintenance"}, true
+}
+
 type fakeRelation struct {
 	key           string
 	crossModel    bool
@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package iptables
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/juju/juju/network"
+)
+
+// HostFirewallChain is the name of the iptables and ip6tables chain
+// maintained by machine agents when the host firewall is enabled.
+const HostFirewallChain = "juju-host-firewall"
+
+// HostFirewallCommand represents the commands required to (re)build
+// the host firewall chains, and to send new incoming connections
+// through them.
+//
+// The chains accept loopback traffic, established connections, SSH,
+// traffic from the internal CIDRs and traffic matching the ingress
+// rules; all other new connections are dropped. IPv4 traffic is
+// managed with iptables and IPv6 traffic with ip6tables; each source
+// CIDR is only rendered for its own address family, and ingress rules
+// with sources of only one family are skipped for the other.
+type HostFirewallCommand struct {
+	// InternalCIDRs are the sources allowed to access any port.
+	InternalCIDRs []string
+
+	// Rules are the ingress rules for the ports opened by units.
+	Rules []network.IngressRule
+}
+
+// Render renders the command to a string which can be executed via
+// bash in order to install the host firewall chains.
+func (c HostFirewallCommand) Render() string {
+	return strings.Join([]string{
+		c.render(iptablesCommand, false),
+		c.render(ip6tablesCommand, true),
+	}, " && ")
+}
+
+func (c HostFirewallCommand) render(command string, ipv6 bool) string {
+	commands := []string{
+		fmt.Sprintf("(sudo %[1]s -n -L %[2]s >/dev/null 2>&1 || sudo %[1]s -N %[2]s)", command, HostFirewallChain),
+		fmt.Sprintf("sudo %s -F %s", command, HostFirewallChain),
+		renderInternal(command, "-i lo"),
+		renderInternal(command, "-m state --state ESTABLISHED,RELATED"),
+	}
+	if ipv6 {
+		// Neighbour discovery and router advertisements are
+		// required for IPv6 to function at all.
+		commands = append(commands, renderInternal(command, "-p ipv6-icmp"))
+	}
+	commands = append(commands, renderInternal(command, "-p tcp --dport 22"))
+	if cidrs := familyCIDRs(c.InternalCIDRs, ipv6); len(cidrs) > 0 {
+		commands = append(commands, renderInternal(command, "-s "+strings.Join(cidrs, ",")))
+	}
+	for _, rule := range c.Rules {
+		if len(rule.SourceCIDRs) > 0 {
+			cidrs := familyCIDRs(rule.SourceCIDRs, ipv6)
+			if len(cidrs) == 0 {
+				continue
+			}
+			rule.SourceCIDRs = cidrs
+		}
+		commands = append(commands, renderIngressRule(command, "-A", HostFirewallChain, rule, ""))
+	}
+	commands = append(commands,
+		fmt.Sprintf(
+			"sudo %s -A %s -m state --state NEW -j DROP -m comment --comment '%s'",
+			command, HostFirewallChain, iptablesInternalComment,
+		),
+		fmt.Sprintf(
+			"(sudo %[1]s -C INPUT -j %[2]s || sudo %[1]s -I INPUT -j %[2]s)",
+			command, HostFirewallChain,
+		),
+	)
+	return strings.Join(commands, " && ")
+}
+
+// RemoveHostFirewallCommand represents the commands required to remove
+// the host firewall chains, if they exist.
+type RemoveHostFirewallCommand struct{}
+
+// Render renders the command to a string which can be executed via
+// bash in order to remove the host firewall chains.
+func (RemoveHostFirewallCommand) Render() string {
+	commands := make([]string, 2)
+	for i, command := range []string{iptablesCommand, ip6tablesCommand} {
+		commands[i] = fmt.Sprintf(
+			"(sudo %[1]s -D INPUT -j %[2]s 2>/dev/null || true) && "+
+				"(sudo %[1]s -F %[2]s 2>/dev/null || true) && "+
+				"(sudo %[1]s -X %[2]s 2>/dev/null || true)",
+			command, HostFirewallChain,
+		)
+	}
+	return strings.Join(commands, " && ")
+}
+
+func renderInternal(command, match string) string {
+	return fmt.Sprintf(
+		"sudo %s -A %s %s -j ACCEPT -m comment --comment '%s'",
+		command, HostFirewallChain, match, iptablesInternalComment,
+	)
+}
+
+// familyCIDRs returns the IPv6 CIDRs in the given list if ipv6 is
+// true, and the IPv4 CIDRs otherwise.
+func familyCIDRs(cidrs []string, ipv6 bool) []string {
+	var result []string
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil || (ip.To4() == nil) != ipv6 {
+			continue
+		}
+		result = append(result, cidr)
+	}
+	return result
+}
@@ -26,6 +26,9 @@ const (
 	// iptablesInternalCommand is the comment attached to iptables
 	// rules that are not directly related to ingress rules.
 	iptablesInternalComment = "juju internal"
+
+	iptablesCommand  = "iptables"
+	ip6tablesCommand = "ip6tables"
 )
 
 // DropCommand represents an iptables DROP target command.
@@ -104,39 +107,47 @@ func (c IngressRuleCommand) Render() string {
 	// existing rules first, and only insert or remove as
 	// needed. Fixing the firewaller is much more difficult,
 	// and it really needs an overhaul.
-	checkCommand := c.render("-C")
+	checkCommand := renderIngressRule(iptablesCommand, "-C", "INPUT", c.Rule, c.DestinationAddress)
 	if c.Delete {
-		deleteCommand := c.render("-D")
+		deleteCommand := renderIngressRule(iptablesCommand, "-D", "INPUT", c.Rule, c.DestinationAddress)
 		return fmt.Sprintf("(%s) && (%s)", checkCommand, deleteCommand)
 	}
-	insertCommand := c.render("-I")
+	insertCommand := renderIngressRule(iptablesCommand, "-I", "INPUT", c.Rule, c.DestinationAddress)
 	return fmt.Sprintf("(%s) || (%s)", checkCommand, insertCommand)
 }
 
-func (c IngressRuleCommand) render(commandFlag string) string {
+// renderIngressRule renders the given iptables or ip6tables command
+// for the ingress rule.
+func renderIngressRule(command, commandFlag, chain string, rule network.IngressRule, destinationAddress string) string {
+	protocol := rule.Protocol
+	icmpEcho := "--icmp-type 8"
+	if command == ip6tablesCommand && protocol == "icmp" {
+		protocol = "ipv6-icmp"
+		icmpEcho = "--icmpv6-type 128"
+	}
 	args := []string{
-		"sudo", "iptables",
-		commandFlag, "INPUT",
+		"sudo", command,
+		commandFlag, chain,
 		"-j ACCEPT",
-		"-p", c.Rule.Protocol,
+		"-p", protocol,
 	}
-	if c.DestinationAddress != "" {
-		args = append(args, "-d", c.DestinationAddress)
+	if destinationAddress != "" {
+		args = append(args, "-d", destinationAddress)
 	}
-	if c.Rule.Protocol == "icmp" {
-		args = append(args, "--icmp-type 8")
+	if rule.Protocol == "icmp" {
+		args = append(args, icmpEcho)
 	} else {
-		if c.Rule.ToPort-c.Rule.FromPort > 0 {
+		if rule.ToPort-rule.FromPort > 0 {
 			args = append(args,
 				"-m multiport --dports",
-				fmt.Sprintf("%d:%d", c.Rule.FromPort, c.Rule.ToPort),
+				fmt.Sprintf("%d:%d", rule.FromPort, rule.ToPort),
 			)
 		} else {
-			args = append(args, "--dport", fmt.Sprint(c.Rule.FromPort))
+			args = append(args, "--dport", fmt.Sprint(rule.FromPort))
 		}
 	}
-	if len(c.Rule.SourceCIDRs) > 0 {
-		args = append(args, "-s", strings.Join(c.Rule.SourceCIDRs, ","))
+	if len(rule.SourceCIDRs) > 0 {
+		args = append(args, "-s", strings.Join(rule.SourceCIDRs, ","))
 	}
 	// Comment always comes last.
 	args = append(args,
@@ -202,6 +202,56 @@ type renderer interface {
 	Render() string
 }
 
+func (*IptablesSuite) TestHostFirewallCommand(c *gc.C) {
+	assertRender(c,
+		iptables.HostFirewallCommand{
+			InternalCIDRs: []string{"10.0.0.1/32", "2001:db8::1/128", "10.0.0.2/32"},
+			Rules: []network.IngressRule{
+				network.MustNewIngressRule("tcp", 80, 80),
+				network.MustNewIngressRule("tcp", 5432, 5432, "10.0.0.0/8", "2001:db8::/32"),
+				network.MustNewIngressRule("udp", 53, 53, "2001:db8::/32"),
+				network.MustNewIngressRule("icmp", -1, -1),
+			},
+		},
+		"(sudo iptables -n -L juju-host-firewall >/dev/null 2>&1 || sudo iptables -N juju-host-firewall) && "+
+			"sudo iptables -F juju-host-firewall && "+
+			"sudo iptables -A juju-host-firewall -i lo -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo iptables -A juju-host-firewall -m state --state ESTABLISHED,RELATED -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo iptables -A juju-host-firewall -p tcp --dport 22 -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo iptables -A juju-host-firewall -s 10.0.0.1/32,10.0.0.2/32 -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo iptables -A juju-host-firewall -j ACCEPT -p tcp --dport 80 -m comment --comment 'juju ingress' && "+
+			"sudo iptables -A juju-host-firewall -j ACCEPT -p tcp --dport 5432 -s 10.0.0.0/8 -m comment --comment 'juju ingress' && "+
+			"sudo iptables -A juju-host-firewall -j ACCEPT -p icmp --icmp-type 8 -m comment --comment 'juju ingress' && "+
+			"sudo iptables -A juju-host-firewall -m state --state NEW -j DROP -m comment --comment 'juju internal' && "+
+			"(sudo iptables -C INPUT -j juju-host-firewall || sudo iptables -I INPUT -j juju-host-firewall) && "+
+			"(sudo ip6tables -n -L juju-host-firewall >/dev/null 2>&1 || sudo ip6tables -N juju-host-firewall) && "+
+			"sudo ip6tables -F juju-host-firewall && "+
+			"sudo ip6tables -A juju-host-firewall -i lo -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo ip6tables -A juju-host-firewall -m state --state ESTABLISHED,RELATED -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo ip6tables -A juju-host-firewall -p ipv6-icmp -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo ip6tables -A juju-host-firewall -p tcp --dport 22 -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo ip6tables -A juju-host-firewall -s 2001:db8::1/128 -j ACCEPT -m comment --comment 'juju internal' && "+
+			"sudo ip6tables -A juju-host-firewall -j ACCEPT -p tcp --dport 80 -m comment --comment 'juju ingress' && "+
+			"sudo ip6tables -A juju-host-firewall -j ACCEPT -p tcp --dport 5432 -s 2001:db8::/32 -m comment --comment 'juju ingress' && "+
+			"sudo ip6tables -A juju-host-firewall -j ACCEPT -p udp --dport 53 -s 2001:db8::/32 -m comment --comment 'juju ingress' && "+
+			"sudo ip6tables -A juju-host-firewall -j ACCEPT -p ipv6-icmp --icmpv6-type 128 -m comment --comment 'juju ingress' && "+
+			"sudo ip6tables -A juju-host-firewall -m state --state NEW -j DROP -m comment --comment 'juju internal' && "+
+			"(sudo ip6tables -C INPUT -j juju-host-firewall || sudo ip6tables -I INPUT -j juju-host-firewall)",
+	)
+}
+
+func (*IptablesSuite) TestRemoveHostFirewallCommand(c *gc.C) {
+	assertRender(c,
+		iptables.RemoveHostFirewallCommand{},
+		"(sudo iptables -D INPUT -j juju-host-firewall 2>/dev/null || true) && "+
+			"(sudo iptables -F juju-host-firewall 2>/dev/null || true) && "+
+			"(sudo iptables -X juju-host-firewall 2>/dev/null || true) && "+
+			"(sudo ip6tables -D INPUT -j juju-host-firewall 2>/dev/null || true) && "+
+			"(sudo ip6tables -F juju-host-firewall 2>/dev/null || true) && "+
+			"(sudo ip6tables -X juju-host-firewall 2>/dev/null || true)",
+	)
+}
+
 func assertRender(c *gc.C, r renderer, expect string) {
 	c.Assert(r.Render(), gc.Equals, expect)
 }
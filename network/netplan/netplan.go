@@ -15,6 +15,8 @@ import (
 
 	"github.com/juju/errors"
 	goyaml "gopkg.in/yaml.v2"
+
+	corenetwork "github.com/juju/juju/core/network"
 )
 
 // Representation of netplan YAML format as Go structures
@@ -273,6 +275,24 @@ func (np *Netplan) shouldCreateBridge(deviceId string, bridgeName string) (bool,
 	return true, nil
 }
 
+// SetAddressFamily configures the interface for the address families used
+// with the given preference. DHCP interfaces always use DHCPv4, and also use
+// DHCPv6 when IPv6 is preferred or used alongside IPv4. In that case router
+// advertisements are accepted, whether or not the interface uses DHCP.
+func (intf *Interface) SetAddressFamily(family corenetwork.AddressFamilyPreference, dhcp bool) {
+	t := true
+	if dhcp {
+		intf.DHCP4 = &t
+	}
+	if !family.UsesIPv6() {
+		return
+	}
+	if dhcp {
+		intf.DHCP6 = &t
+	}
+	intf.AcceptRA = &t
+}
+
 // createBridgeFromInterface will create a bridge stealing the interface details, and wiping the existing interface
 // except for MTU so that IP Address information is never duplicated.
 func (np *Netplan) createBridgeFromInterface(bridgeName, deviceId string, intf *Interface) {
@@ -19,6 +19,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/yaml.v2"
 
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network/netplan"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -106,6 +107,26 @@ network:
 `)
 }
 
+func (s *NetplanSuite) TestSetAddressFamily(c *gc.C) {
+	var intf netplan.Interface
+	intf.SetAddressFamily(corenetwork.PreferIPv4, true)
+	c.Check(*intf.DHCP4, jc.IsTrue)
+	c.Check(intf.DHCP6, gc.IsNil)
+	c.Check(intf.AcceptRA, gc.IsNil)
+
+	intf = netplan.Interface{}
+	intf.SetAddressFamily(corenetwork.DualStack, true)
+	c.Check(*intf.DHCP4, jc.IsTrue)
+	c.Check(*intf.DHCP6, jc.IsTrue)
+	c.Check(*intf.AcceptRA, jc.IsTrue)
+
+	intf = netplan.Interface{}
+	intf.SetAddressFamily(corenetwork.PreferIPv6, false)
+	c.Check(intf.DHCP4, gc.IsNil)
+	c.Check(intf.DHCP6, gc.IsNil)
+	c.Check(*intf.AcceptRA, jc.IsTrue)
+}
+
 func (s *NetplanSuite) TestBasicBond(c *gc.C) {
 	checkNetplanRoundTrips(c, `
 network:
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -286,6 +287,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -387,6 +389,7 @@ func (v *ebsVolumeSource) createVolume(ctx context.ProviderCallContext, p storag
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(maybeConvertCredentialError(err, ctx))
@@ -980,6 +983,67 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(
+	ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams,
+) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, p.Description)
+		if err != nil {
+			err = maybeConvertCredentialError(err, ctx)
+			if common.IsCredentialNotValid(err) {
+				return nil, errors.Trace(err)
+			}
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %q", p.VolumeId)
+			continue
+		}
+		if err := tagResources(v.env.ec2, ctx, p.ResourceTags, resp.Id); err != nil {
+			results[i].Error = errors.Annotate(err, "tagging snapshot")
+			continue
+		}
+		snapshot := ebsSnapshot(resp.Snapshot)
+		results[i].Snapshot = &snapshot
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volumeIds []string) ([]storage.VolumeSnapshot, error) {
+	if len(volumeIds) == 0 {
+		return nil, nil
+	}
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volumeIds...)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	snapshots := make([]storage.VolumeSnapshot, len(resp.Snapshots))
+	for i, snap := range resp.Snapshots {
+		snapshots[i] = ebsSnapshot(snap)
+	}
+	return snapshots, nil
+}
+
+// ebsSnapshot converts an EC2 snapshot to a storage.VolumeSnapshot.
+func ebsSnapshot(snap ec2.Snapshot) storage.VolumeSnapshot {
+	result := storage.VolumeSnapshot{
+		SnapshotId:  snap.Id,
+		VolumeId:    snap.VolumeId,
+		Status:      snap.Status,
+		Description: snap.Description,
+	}
+	// EC2 reports the volume size in GiB, as a string.
+	if size, err := strconv.ParseUint(snap.VolumeSize, 10, 64); err == nil {
+		result.Size = gibToMib(size)
+	}
+	if created, err := time.Parse(time.RFC3339, snap.StartTime); err == nil {
+		result.Created = created
+	}
+	return result
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"time"
@@ -1074,6 +1075,157 @@ func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
 }
 
+func (s *ebsSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	var snapshotIds []string
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		query := resp.Request.URL.Query()
+		if query.Get("Action") == "CreateVolume" {
+			snapshotIds = append(snapshotIds, query.Get("SnapshotId"))
+		}
+		return nil
+	}
+	params := s.createVolumesParams("")
+	params[0].SnapshotId = "snap-42"
+	results, err := vs.CreateVolumes(s.cloudCallCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(snapshotIds, gc.Not(gc.HasLen), 0)
+	c.Assert(snapshotIds[0], gc.Equals, "snap-42")
+	for _, snapshotId := range snapshotIds[1:] {
+		c.Assert(snapshotId, gc.Equals, "")
+	}
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeSnapshotter))
+
+	s.srv.proxy.ModifyResponse = makeSnapshotResponseModifier("CreateSnapshot", func(query url.Values) interface{} {
+		return &awsec2.CreateSnapshotResp{
+			Snapshot: awsec2.Snapshot{
+				Id:          "snap-0",
+				VolumeId:    query.Get("VolumeId"),
+				VolumeSize:  "10",
+				Status:      "pending",
+				StartTime:   "2020-04-01T12:00:00.000Z",
+				Description: query.Get("Description"),
+			},
+		}
+	})
+	results, err := vs.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.cloudCallCtx, []storage.VolumeSnapshotParams{{
+		VolumeId:    "vol-0",
+		Description: "before upgrade",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId:  "snap-0",
+			VolumeId:    "vol-0",
+			Size:        10240,
+			Status:      "pending",
+			Description: "before upgrade",
+			Created:     time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}})
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshotsErrors(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code:    "InvalidVolume.NotFound",
+			Message: "The volume 'vol-42' does not exist.",
+		}}})
+	}
+	results, err := vs.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.cloudCallCtx, []storage.VolumeSnapshotParams{{
+		VolumeId: "vol-42",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating snapshot of volume "vol-42": .*does not exist.*`)
+	c.Assert(results[0].Snapshot, gc.IsNil)
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshotsCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	results, err := vs.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.cloudCallCtx, []storage.VolumeSnapshotParams{{
+		VolumeId: "vol-42",
+	}})
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *ebsSuite) TestListVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeSnapshotter))
+
+	var filters url.Values
+	s.srv.proxy.ModifyResponse = makeSnapshotResponseModifier("DescribeSnapshots", func(query url.Values) interface{} {
+		filters = query
+		return &awsec2.SnapshotsResp{
+			Snapshots: []awsec2.Snapshot{{
+				Id:         "snap-0",
+				VolumeId:   "vol-0",
+				VolumeSize: "10",
+				Status:     "completed",
+				StartTime:  "2020-04-01T12:00:00.000Z",
+			}, {
+				// Unparseable sizes and times are left unset.
+				Id:       "snap-1",
+				VolumeId: "vol-1",
+				Status:   "pending",
+			}},
+		}
+	})
+	snapshots, err := vs.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.cloudCallCtx, []string{"vol-0", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filters.Get("Filter.1.Name"), gc.Equals, "volume-id")
+	c.Assert([]string{filters.Get("Filter.1.Value.1"), filters.Get("Filter.1.Value.2")}, jc.SameContents, []string{"vol-0", "vol-1"})
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snap-0",
+		VolumeId:   "vol-0",
+		Size:       10240,
+		Status:     "completed",
+		Created:    time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+	}, {
+		SnapshotId: "snap-1",
+		VolumeId:   "vol-1",
+		Status:     "pending",
+	}})
+}
+
+func (s *ebsSuite) TestListVolumeSnapshotsNoVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		c.Errorf("unexpected request %q", resp.Request.URL.Query().Get("Action"))
+		return nil
+	}
+	snapshots, err := vs.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.cloudCallCtx, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+}
+
+func (s *ebsSuite) TestListVolumeSnapshotsCredentialError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "Blocked",
+		}}})
+	}
+	snapshots, err := vs.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.cloudCallCtx, []string{"vol-0"})
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(snapshots, gc.IsNil)
+}
+
 type blockDeviceMappingSuite struct {
 	testing.BaseSuite
 }
@@ -1218,6 +1370,23 @@ func makeDescribeVolumesResponseModifier(modify func(*awsec2.VolumesResp) error)
 	}
 }
 
+// makeSnapshotResponseModifier returns a response modifier that replaces
+// the response to the given snapshot action with the value returned by
+// respond. The ec2test server does not implement snapshots.
+func makeSnapshotResponseModifier(action string, respond func(url.Values) interface{}) func(*http.Response) error {
+	return func(resp *http.Response) error {
+		query := resp.Request.URL.Query()
+		if query.Get("Action") != action {
+			return nil
+		}
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		return replaceResponseBody(resp, respond(query))
+	}
+}
+
 func replaceResponseBody(resp *http.Response, value interface{}) error {
 	var buf bytes.Buffer
 	if err := xml.NewEncoder(&buf).Encode(value); err != nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -76,6 +77,8 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeResizer = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -221,6 +224,7 @@ func (v *volumeSource) createOneVolume(ctx context.ProviderCallContext, p storag
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Labels:             resourceTagsToDiskLabels(p.ResourceTags),
+		SourceSnapshot:     p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -389,6 +393,114 @@ func (v *volumeSource) ImportVolume(ctx context.ProviderCallContext, volName str
 	}, nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) CreateVolumeSnapshots(
+	ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams,
+) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneSnapshot(p)
+		if err != nil {
+			results[i].Error = google.HandleCredentialError(err, ctx)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot snapshot volume %q", p.VolumeId)
+	}
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	snapshot, err := v.gce.CreateSnapshot(zone, p.VolumeId, google.SnapshotSpec{
+		Name:        "snapshot-" + snapshotUUID.String(),
+		Description: p.Description,
+		Labels:      resourceTagsToDiskLabels(p.ResourceTags),
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot snapshot volume %q", p.VolumeId)
+	}
+	result := gceToJujuVolumeSnapshot(snapshot)
+	return &result, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(
+	ctx context.ProviderCallContext, params []storage.VolumeResizeParams,
+) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = google.HandleCredentialError(err, ctx)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(p storage.VolumeResizeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+	}
+	if disk.Size >= p.Size {
+		return disk.Size, nil
+	}
+	// GCE disks are sized in whole GiB.
+	sizeGB := mibToGib(p.Size)
+	if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+		return 0, errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+	}
+	return sizeGB * 1024, nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volNames []string) ([]storage.VolumeSnapshot, error) {
+	if len(volNames) == 0 {
+		return nil, nil
+	}
+	// Snapshots are global, so list them all and filter
+	// by source disk locally.
+	snapshots, err := v.gce.Snapshots()
+	if err != nil {
+		return nil, google.HandleCredentialError(err, ctx)
+	}
+	wanted := set.NewStrings(volNames...)
+	var results []storage.VolumeSnapshot
+	for _, snapshot := range snapshots {
+		if wanted.Contains(snapshot.DiskName) {
+			results = append(results, gceToJujuVolumeSnapshot(snapshot))
+		}
+	}
+	return results, nil
+}
+
+func gceToJujuVolumeSnapshot(snapshot *google.Snapshot) storage.VolumeSnapshot {
+	result := storage.VolumeSnapshot{
+		SnapshotId:  snapshot.Name,
+		VolumeId:    snapshot.DiskName,
+		Size:        snapshot.Size,
+		Status:      snapshot.Status,
+		Description: snapshot.Description,
+	}
+	if created, err := time.Parse(time.RFC3339, snapshot.Created); err == nil {
+		result.Created = created
+	}
+	return result
+}
+
 func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volNames []string) ([]storage.DescribeVolumesResult, error) {
 	results := make([]storage.DescribeVolumesResult, len(volNames))
 	for i, vol := range volNames {
@@ -4,6 +4,8 @@
 package gce_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -357,6 +359,101 @@ func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, volName)
 }
 
+func (s *volumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	s.FakeConn.Snapshot = &google.Snapshot{
+		Name:     "snapshot-0",
+		DiskName: volName,
+		Zone:     "home-zone",
+		Status:   google.SnapshotStatusCreating,
+	}
+	c.Assert(s.source, gc.Implements, new(storage.VolumeSnapshotter))
+	res, err := s.source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.CallCtx, []storage.VolumeSnapshotParams{{
+		VolumeId:    volName,
+		Description: "before upgrade",
+		ResourceTags: map[string]string{
+			"juju-model-uuid": "foo",
+			"unwanted":        "bar",
+		},
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].Snapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		SnapshotId: "snapshot-0",
+		VolumeId:   volName,
+		Status:     google.SnapshotStatusCreating,
+	})
+
+	called, calls := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].VolumeName, gc.Equals, volName)
+	c.Assert(calls[0].SnapshotSpec.Name, gc.Matches, "snapshot-.*")
+	c.Assert(calls[0].SnapshotSpec.Description, gc.Equals, "before upgrade")
+	c.Assert(calls[0].SnapshotSpec.Labels, jc.DeepEquals, map[string]string{
+		"juju-model-uuid": "foo",
+	})
+}
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	c.Assert(s.source, gc.Implements, new(storage.VolumeResizer))
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		VolumeId: volName,
+		Size:     1500,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+
+	called, calls := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].ID, gc.Equals, volName)
+	c.Assert(calls[0].SizeGB, gc.Equals, uint64(2))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		VolumeId: volName,
+		Size:     512,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 1024}})
+
+	called, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	s.FakeConn.Snapshots_ = []*google.Snapshot{{
+		Name:     "snapshot-0",
+		DiskName: volName,
+		Size:     1024,
+		Status:   google.SnapshotStatusReady,
+		Created:  "2020-01-02T03:04:05Z",
+	}, {
+		Name:     "snapshot-1",
+		DiskName: "home-zone--other",
+		Status:   google.SnapshotStatusReady,
+	}}
+	res, err := s.source.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.CallCtx, []string{volName})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snapshot-0",
+		VolumeId:   volName,
+		Size:       1024,
+		Status:     google.SnapshotStatusReady,
+		Created:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}})
+}
+
 func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
 	attachments := []storage.VolumeAttachmentParams{*s.attachmentParams}
@@ -59,6 +59,14 @@ type gceConnection interface {
 	// SetDiskLabels sets the labels on a disk, ensuring that the disk's
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(zone, id, labelFingerprint string, labels map[string]string) error
+	// ResizeDisk will grow the disk identified by <id> in <zone>
+	// to <sizeGB> gigabytes.
+	ResizeDisk(zone, id string, sizeGB uint64) error
+	// CreateSnapshot will start taking a snapshot, described by <spec>,
+	// of the disk identified by <diskName> in <zone>.
+	CreateSnapshot(zone, diskName string, spec google.SnapshotSpec) (*google.Snapshot, error)
+	// Snapshots will return a list of all disk snapshots found in the project.
+	Snapshots() ([]*google.Snapshot, error)
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
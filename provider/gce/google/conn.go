@@ -84,6 +84,16 @@ type rawConnectionWrapper interface {
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(project, zone, id, labelFingerprint string, labels map[string]string) error
 
+	// ResizeDisk grows the disk identified by id to sizeGB gigabytes.
+	ResizeDisk(project, zone, id string, sizeGB int64) error
+
+	// CreateSnapshot will start taking a snapshot of the disk
+	// identified by diskName, as specified in spec.
+	CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error
+
+	// ListSnapshots returns a list of the snapshots in the given project.
+	ListSnapshots(project string) ([]*compute.Snapshot, error)
+
 	// AttachDisk will attach the disk described in attachedDisks (if it exists) into
 	// the instance with id instanceId.
 	AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error
@@ -65,6 +65,38 @@ func (gce *Connection) SetDiskLabels(zone, name, labelFingerprint string, labels
 	return errors.Annotatef(err, "cannot update labels for disk %q in zone %q", name, zone)
 }
 
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGB uint64) error {
+	err := gce.raw.ResizeDisk(gce.projectID, zone, name, int64(sizeGB))
+	return errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+}
+
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, diskName string, spec SnapshotSpec) (*Snapshot, error) {
+	cs := spec.newSnapshot()
+	if err := gce.raw.CreateSnapshot(gce.projectID, zone, diskName, cs); err != nil {
+		return nil, errors.Annotatef(err, "cannot create snapshot %q", spec.Name)
+	}
+	snapshot := NewSnapshot(cs)
+	snapshot.Zone = zone
+	snapshot.DiskName = diskName
+	snapshot.Status = SnapshotStatusCreating
+	return snapshot, nil
+}
+
+// Snapshots implements storage section of gceConnection.
+func (gce *Connection) Snapshots() ([]*Snapshot, error) {
+	computeSnapshots, err := gce.raw.ListSnapshots(gce.projectID)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	snapshots := make([]*Snapshot, len(computeSnapshots))
+	for i, snapshot := range computeSnapshots {
+		snapshots[i] = NewSnapshot(snapshot)
+	}
+	return snapshots, nil
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
@@ -75,6 +75,64 @@ func (s *connSuite) TestConnectionDisk(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
 }
 
+func (s *connSuite) TestConnectionCreateDiskFromSnapshot(c *gc.C) {
+	spec, _, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	spec.SourceSnapshot = "snapshot-0"
+
+	_, err = s.Conn.CreateDisks("home-zone", []google.DiskSpec{spec})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].ComputeDisk.SourceSnapshot, gc.Equals, "global/snapshots/snapshot-0")
+}
+
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	snapshot, err := s.Conn.CreateSnapshot("home-zone", fakeVolName, google.SnapshotSpec{
+		Name:        "snapshot-0",
+		Description: "before upgrade",
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, &google.Snapshot{
+		Name:        "snapshot-0",
+		Description: "before upgrade",
+		DiskName:    fakeVolName,
+		Zone:        "home-zone",
+		Status:      google.SnapshotStatusCreating,
+	})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot.Name, gc.Equals, "snapshot-0")
+}
+
+func (s *connSuite) TestConnectionSnapshots(c *gc.C) {
+	s.FakeConn.Snapshots = []*compute.Snapshot{{
+		Name:              "snapshot-0",
+		SourceDisk:        "https://www.googleapis.com/compute/v1/projects/spam/zones/home-zone/disks/" + fakeVolName,
+		DiskSizeGb:        1,
+		Status:            "READY",
+		CreationTimestamp: "2020-01-02T03:04:05.000-08:00",
+	}}
+
+	snapshots, err := s.Conn.Snapshots()
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []*google.Snapshot{{
+		Name:     "snapshot-0",
+		DiskName: fakeVolName,
+		Zone:     "home-zone",
+		Size:     1024,
+		Status:   "READY",
+		Created:  "2020-01-02T03:04:05.000-08:00",
+	}})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListSnapshots")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+}
+
 func (s *connSuite) TestConnectionSetDiskLabels(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
@@ -94,6 +152,18 @@ func (s *connSuite) TestConnectionSetDiskLabels(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Labels, jc.DeepEquals, labels)
 }
 
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGB, gc.Equals, int64(20))
+}
+
 func (s *connSuite) TestConnectionAttachDisk(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
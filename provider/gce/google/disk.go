@@ -111,6 +111,9 @@ type DiskSpec struct {
 	// Labels holds labels/metadata for the disk. Labels are used for
 	// storing volume resource tags.
 	Labels map[string]string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be initialized, if any. (detached only)
+	SourceSnapshot string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -172,13 +175,17 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Labels:      ds.Labels,
-	}, nil
+	}
+	if ds.SourceSnapshot != "" {
+		disk.SourceSnapshot = "global/snapshots/" + ds.SourceSnapshot
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
@@ -255,3 +262,75 @@ func NewDisk(cd *compute.Disk) *Disk {
 	}
 	return d
 }
+
+// The snapshot statuses that GCE reports.
+const (
+	SnapshotStatusCreating = "CREATING"
+	SnapshotStatusReady    = "READY"
+)
+
+// SnapshotSpec holds all the data needed to request a new snapshot
+// of a disk on GCE.
+type SnapshotSpec struct {
+	// Name is the name of the snapshot, which must comply with the
+	// same rules as disk names.
+	Name string
+	// Description is a free-form description of the snapshot.
+	Description string
+	// Labels holds labels/metadata for the snapshot.
+	Labels map[string]string
+}
+
+func (ss *SnapshotSpec) newSnapshot() *compute.Snapshot {
+	return &compute.Snapshot{
+		Name:        ss.Name,
+		Description: ss.Description,
+		Labels:      ss.Labels,
+	}
+}
+
+// Snapshot represents a snapshot of a gce disk.
+type Snapshot struct {
+	// Name is a unique identifier string for each snapshot.
+	Name string
+
+	// Description holds the description given to the snapshot.
+	Description string
+
+	// DiskName is the name of the disk that the snapshot was taken of.
+	DiskName string
+
+	// Zone holds the name of the zone in which the snapshotted
+	// disk lives.
+	Zone string
+
+	// Size is the size of the snapshotted disk in mbit.
+	Size uint64
+
+	// Status holds the status of the snapshot.
+	Status string
+
+	// Created holds the time at which the snapshot was created, in
+	// RFC3339 format.
+	Created string
+}
+
+// NewSnapshot returns a Snapshot representing the given compute snapshot.
+func NewSnapshot(cs *compute.Snapshot) *Snapshot {
+	// cs.SourceDisk is a URL in the form:
+	// .../projects/project/zones/zone/disks/disk
+	var diskName, zone string
+	if cs.SourceDisk != "" {
+		diskName = path.Base(cs.SourceDisk)
+		zone = path.Base(path.Dir(path.Dir(cs.SourceDisk)))
+	}
+	return &Snapshot{
+		Name:        cs.Name,
+		Description: cs.Description,
+		DiskName:    diskName,
+		Zone:        zone,
+		Size:        gibToMib(cs.DiskSizeGb),
+		Status:      cs.Status,
+		Created:     cs.CreationTimestamp,
+	}
+}
@@ -292,6 +292,46 @@ func (rc *rawConn) SetDiskLabels(project, zone, id, labelFingerprint string, lab
 	return errors.Trace(err)
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, id string, sizeGB int64) error {
+	ds := rc.Service.Disks
+	call := ds.Resize(project, zone, id, &compute.DisksResizeRequest{SizeGb: sizeGB})
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong, logOperationErrors))
+}
+
+func (rc *rawConn) CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error {
+	ds := rc.Service.Disks
+	call := ds.CreateSnapshot(project, zone, diskName, spec)
+	// The operation only completes once the snapshot has been
+	// uploaded, which may take some time, so we do not wait for
+	// it; progress is reported by the snapshot's status.
+	_, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not create snapshot of disk %q", diskName)
+	}
+	return nil
+}
+
+func (rc *rawConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := rc.Service.Snapshots.List(project)
+	var results []*compute.Snapshot
+	for {
+		snapshotList, err := call.Do()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, snapshotList.Items...)
+		if snapshotList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(snapshotList.NextPageToken)
+	}
+	return results, nil
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
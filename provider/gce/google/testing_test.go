@@ -167,9 +167,11 @@ type fakeCall struct {
 	AttachedDisk     *compute.AttachedDisk
 	DeviceName       string
 	ComputeDisk      *compute.Disk
+	Snapshot         *compute.Snapshot
 	Metadata         *compute.Metadata
 	LabelFingerprint string
 	Labels           map[string]string
+	SizeGB           int64
 }
 
 type fakeConn struct {
@@ -184,6 +186,7 @@ type fakeConn struct {
 	FailOnCall    int
 	Disks         []*compute.Disk
 	Disk          *compute.Disk
+	Snapshots     []*compute.Snapshot
 	AttachedDisks []*compute.AttachedDisk
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
@@ -424,6 +427,54 @@ func (rc *fakeConn) SetDiskLabels(project, zone, id, labelFingerprint string, la
 	return err
 }
 
+func (rc *fakeConn) ResizeDisk(project, zone, id string, sizeGB int64) error {
+	call := fakeCall{
+		FuncName:  "ResizeDisk",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        id,
+		SizeGB:    sizeGB,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error {
+	call := fakeCall{
+		FuncName:  "CreateSnapshot",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        diskName,
+		Snapshot:  spec,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := fakeCall{
+		FuncName:  "ListSnapshots",
+		ProjectID: project,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Snapshots, err
+}
+
 func (rc *fakeConn) AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error {
 	call := fakeCall{
 		FuncName:     "AttachDisk",
@@ -504,6 +504,8 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	SnapshotSpec     google.SnapshotSpec
+	SizeGB           uint64
 }
 
 type fakeConn struct {
@@ -520,6 +522,8 @@ type fakeConn struct {
 	GoogleDisk    *google.Disk
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
+	Snapshot      *google.Snapshot
+	Snapshots_    []*google.Snapshot
 
 	Err        error
 	FailOnCall int
@@ -678,6 +682,33 @@ func (fc *fakeConn) SetDiskLabels(zone, id, labelFingerprint string, labels map[
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeDisk(zone, id string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ResizeDisk",
+		ZoneName: zone,
+		ID:       id,
+		SizeGB:   sizeGB,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CreateSnapshot(zone, diskName string, spec google.SnapshotSpec) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CreateSnapshot",
+		ZoneName:     zone,
+		VolumeName:   diskName,
+		SnapshotSpec: spec,
+	})
+	return fc.Snapshot, fc.err()
+}
+
+func (fc *fakeConn) Snapshots() ([]*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "Snapshots",
+	})
+	return fc.Snapshots_, fc.err()
+}
+
 func (fc *fakeConn) AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:   "AttachDisk",
@@ -208,7 +208,7 @@ func (env *environ) getContainerSpec(
 		if err != nil {
 			return cSpec, errors.Trace(err)
 		}
-		if err := cloudCfg.AddNetworkConfig(info); err != nil {
+		if err := cloudCfg.AddNetworkConfig(info, args.InstanceConfig.PreferredAddressFamily); err != nil {
 			return cSpec, errors.Trace(err)
 		}
 
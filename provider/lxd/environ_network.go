@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.SubnetDiscovery = (*environ)(nil)
+
+// networkAddressKeys maps the LXD network config keys holding the
+// gateway address and prefix of a managed network to the suffix used
+// for the provider ID of the corresponding subnet.
+var networkAddressKeys = []struct {
+	key, suffix string
+}{
+	{"ipv4.address", "ipv4"},
+	{"ipv6.address", "ipv6"},
+}
+
+// Subnets (SubnetDiscovery) returns the subnets of the networks managed
+// by the LXD server, so that they can be added to the model's spaces.
+// Each managed network has a subnet for each address family configured
+// on it, named after the network.
+func (env *environ) Subnets(
+	ctx context.ProviderCallContext, inst instance.Id, subnetIds []network.Id,
+) ([]network.SubnetInfo, error) {
+	if inst != instance.UnknownId {
+		return nil, errors.NotSupportedf("subnets for instance")
+	}
+	server := env.server()
+	if !server.NetworkSupported() {
+		return nil, errors.NotSupportedf("subnet discovery without the LXD network API")
+	}
+	networks, err := server.GetNetworks()
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Annotate(err, "listing networks")
+	}
+
+	wanted := set.NewStrings()
+	for _, id := range subnetIds {
+		wanted.Add(string(id))
+	}
+	var subnets []network.SubnetInfo
+	for _, n := range networks {
+		if !n.Managed {
+			continue
+		}
+		for _, addr := range networkAddressKeys {
+			_, ipNet, err := net.ParseCIDR(n.Config[addr.key])
+			if err != nil {
+				// Not configured, or without a prefix.
+				continue
+			}
+			id := network.Id(fmt.Sprintf("%s-%s", n.Name, addr.suffix))
+			if !wanted.IsEmpty() && !wanted.Contains(string(id)) {
+				continue
+			}
+			subnets = append(subnets, network.SubnetInfo{
+				CIDR:              ipNet.String(),
+				ProviderId:        id,
+				ProviderNetworkId: network.Id(n.Name),
+				ProviderName:      n.Name,
+			})
+		}
+	}
+	logger.Tracef("found subnets %#v", subnets)
+	return subnets, nil
+}
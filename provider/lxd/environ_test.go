@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	envtesting "github.com/juju/juju/environs/testing"
@@ -375,6 +376,74 @@ func (s *environSuite) TestInstanceAvailabilityZoneNamesInvalidCredentials(c *gc
 	})
 }
 
+func (s *environSuite) TestSubnets(c *gc.C) {
+	s.Client.Networks = []api.Network{{
+		Name:    "lxdbr0",
+		Managed: true,
+		NetworkPut: api.NetworkPut{Config: map[string]string{
+			"ipv4.address": "10.0.8.1/24",
+			"ipv6.address": "fd42:1:2:3::1/64",
+		}},
+	}, {
+		Name:    "lxdbr1",
+		Managed: true,
+		NetworkPut: api.NetworkPut{Config: map[string]string{
+			"ipv4.address": "10.0.9.1/24",
+			"ipv6.address": "none",
+		}},
+	}, {
+		Name: "eth0",
+	}}
+
+	subnetEnv, ok := environs.SupportsSubnetDiscovery(s.Env)
+	c.Assert(ok, jc.IsTrue)
+	subnets, err := subnetEnv.Subnets(s.callCtx, instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:              "10.0.8.0/24",
+		ProviderId:        "lxdbr0-ipv4",
+		ProviderNetworkId: "lxdbr0",
+		ProviderName:      "lxdbr0",
+	}, {
+		CIDR:              "fd42:1:2:3::/64",
+		ProviderId:        "lxdbr0-ipv6",
+		ProviderNetworkId: "lxdbr0",
+		ProviderName:      "lxdbr0",
+	}, {
+		CIDR:              "10.0.9.0/24",
+		ProviderId:        "lxdbr1-ipv4",
+		ProviderNetworkId: "lxdbr1",
+		ProviderName:      "lxdbr1",
+	}})
+
+	subnets, err = subnetEnv.Subnets(s.callCtx, instance.UnknownId, []network.Id{"lxdbr1-ipv4"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].CIDR, gc.Equals, "10.0.9.0/24")
+}
+
+func (s *environSuite) TestSubnetsInvalidCredentials(c *gc.C) {
+	c.Assert(s.invalidCredential, jc.IsFalse)
+	s.Client.Stub.SetErrors(errTestUnAuth)
+
+	subnetEnv, ok := environs.SupportsSubnetDiscovery(s.Env)
+	c.Assert(ok, jc.IsTrue)
+	_, err := subnetEnv.Subnets(s.callCtx, instance.UnknownId, nil)
+	c.Assert(err, gc.ErrorMatches, "listing networks: not authorized")
+	c.Assert(s.invalidCredential, jc.IsTrue)
+}
+
+func (s *environSuite) TestSubnetsNetworkAPINotSupported(c *gc.C) {
+	s.Client.NetworkIsSupported = false
+	s.Stub.ResetCalls()
+
+	subnetEnv, ok := environs.SupportsSubnetDiscovery(s.Env)
+	c.Assert(ok, jc.IsTrue)
+	_, err := subnetEnv.Subnets(s.callCtx, instance.UnknownId, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.Stub.CheckCallNames(c, "NetworkSupported")
+}
+
 type environProfileSuite struct {
 	lxd.EnvironSuite
 
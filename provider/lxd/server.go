@@ -73,6 +73,8 @@ type Server interface {
 	HostArch() string
 	EnableHTTPSListener() error
 	GetNICsFromProfile(profName string) (map[string]map[string]string, error)
+	NetworkSupported() bool
+	GetNetworks() (networks []lxdapi.Network, err error)
 	IsClustered() bool
 	UseTargetServer(name string) (*lxd.Server, error)
 	GetClusterMembers() (members []lxdapi.ClusterMember, err error)
@@ -305,6 +305,19 @@ func (mr *MockServerMockRecorder) GetNICsFromProfile(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNICsFromProfile", reflect.TypeOf((*MockServer)(nil).GetNICsFromProfile), arg0)
 }
 
+// GetNetworks mocks base method
+func (m *MockServer) GetNetworks() ([]api.Network, error) {
+	ret := m.ctrl.Call(m, "GetNetworks")
+	ret0, _ := ret[0].([]api.Network)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworks indicates an expected call of GetNetworks
+func (mr *MockServerMockRecorder) GetNetworks() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworks", reflect.TypeOf((*MockServer)(nil).GetNetworks))
+}
+
 // GetProfile mocks base method
 func (m *MockServer) GetProfile(arg0 string) (*api.Profile, string, error) {
 	ret := m.ctrl.Call(m, "GetProfile", arg0)
@@ -448,6 +461,18 @@ func (mr *MockServerMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockServer)(nil).Name))
 }
 
+// NetworkSupported mocks base method
+func (m *MockServer) NetworkSupported() bool {
+	ret := m.ctrl.Call(m, "NetworkSupported")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NetworkSupported indicates an expected call of NetworkSupported
+func (mr *MockServerMockRecorder) NetworkSupported() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkSupported", reflect.TypeOf((*MockServer)(nil).NetworkSupported))
+}
+
 // RemoveContainer mocks base method
 func (m *MockServer) RemoveContainer(arg0 string) error {
 	ret := m.ctrl.Call(m, "RemoveContainer", arg0)
@@ -325,6 +325,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.Client = &StubClient{
 		Stub:               s.Stub,
 		StorageIsSupported: true,
+		NetworkIsSupported: true,
 		Server: &api.Server{
 			ServerPut: api.ServerPut{
 				Config: map[string]interface{}{},
@@ -434,10 +435,12 @@ type StubClient struct {
 	Server             *api.Server
 	Profile            *api.Profile
 	StorageIsSupported bool
+	NetworkIsSupported bool
 	Volumes            map[string][]api.StorageVolume
 	ServerCert         string
 	ServerHostArch     string
 	ServerVer          string
+	Networks           []api.Network
 }
 
 func (conn *StubClient) FilterContainers(prefix string, statuses ...string) ([]lxd.Container, error) {
@@ -706,6 +709,16 @@ func (conn *StubClient) GetNICsFromProfile(profName string) (map[string]map[stri
 	return conn.Profile.Devices, conn.NextErr()
 }
 
+func (conn *StubClient) NetworkSupported() bool {
+	conn.AddCall("NetworkSupported")
+	return conn.NetworkIsSupported
+}
+
+func (conn *StubClient) GetNetworks() ([]api.Network, error) {
+	conn.AddCall("GetNetworks")
+	return conn.Networks, conn.NextErr()
+}
+
 func (conn *StubClient) IsClustered() bool {
 	conn.AddCall("IsClustered")
 	return true
@@ -213,6 +213,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -251,6 +252,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -532,6 +534,76 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// CreateVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(
+	ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams,
+) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		// TODO: set the resource tags as snapshot metadata, once
+		// the goose Cinder client supports snapshot metadata.
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    arg.VolumeId,
+			Description: arg.Description,
+			// Force allows snapshots of volumes that are
+			// attached to a running server.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %q", arg.VolumeId)
+			if denied := common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx); denied {
+				break
+			}
+			continue
+		}
+		info := cinderToJujuVolumeSnapshot(snapshot)
+		results[i].Snapshot = &info
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (s *cinderVolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volumeIds []string) ([]storage.VolumeSnapshot, error) {
+	if len(volumeIds) == 0 {
+		return nil, nil
+	}
+	// Cinder cannot filter snapshots by volume, so get all
+	// snapshots and filter them locally.
+	cinderSnapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	wanted := make(map[string]bool)
+	for _, volumeId := range volumeIds {
+		wanted[volumeId] = true
+	}
+	var snapshots []storage.VolumeSnapshot
+	for i, snapshot := range cinderSnapshots {
+		if wanted[snapshot.VolumeID] {
+			snapshots = append(snapshots, cinderToJujuVolumeSnapshot(&cinderSnapshots[i]))
+		}
+	}
+	return snapshots, nil
+}
+
+// cinderTimeLayout is the layout of the timestamps reported by Cinder.
+const cinderTimeLayout = "2006-01-02T15:04:05.999999"
+
+func cinderToJujuVolumeSnapshot(snapshot *cinder.Snapshot) storage.VolumeSnapshot {
+	result := storage.VolumeSnapshot{
+		SnapshotId:  snapshot.ID,
+		VolumeId:    snapshot.VolumeID,
+		Size:        uint64(snapshot.Size * 1024),
+		Status:      snapshot.Status,
+		Description: snapshot.Description,
+	}
+	if created, err := time.Parse(cinderTimeLayout, snapshot.CreatedAt); err == nil {
+		result.Created = created
+	}
+	return result
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -625,6 +697,8 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
 }
 
 type endpointResolver interface {
@@ -700,6 +774,24 @@ func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata m
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
 
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
 // DeleteVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) DeleteVolume(volumeId string) error {
 	if err := ga.cinderClient.DeleteVolume(volumeId); err != nil {
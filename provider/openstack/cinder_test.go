@@ -715,6 +715,95 @@ func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			c.Assert(args.SnapshotId, gc.Equals, "snap-0")
+			return &cinder.Volume{ID: mockVolId}, nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   mockVolSize / 1024,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Provider:   openstack.CinderProviderType,
+		Tag:        mockVolumeTag,
+		Size:       1024,
+		SnapshotId: "snap-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, mockVolId)
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			return &cinder.Snapshot{
+				ID:          "snap-0",
+				VolumeID:    args.VolumeId,
+				Size:        1,
+				Status:      "creating",
+				Description: args.Description,
+				CreatedAt:   "2020-01-02T03:04:05.000000",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeSnapshotter))
+
+	results, err := volSource.(storage.VolumeSnapshotter).CreateVolumeSnapshots(
+		s.callCtx, []storage.VolumeSnapshotParams{{
+			VolumeId:    mockVolId,
+			Description: "before upgrade",
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId:  "snap-0",
+			VolumeId:    mockVolId,
+			Size:        1024,
+			Status:      "creating",
+			Description: "before upgrade",
+			Created:     time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CreateSnapshot", []interface{}{cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    mockVolId,
+			Description: "before upgrade",
+			Force:       true,
+		}}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{
+				{ID: "snap-0", VolumeID: mockVolId, Size: 1, Status: "available"},
+				{ID: "snap-1", VolumeID: "other", Size: 1, Status: "available"},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshots, err := volSource.(storage.VolumeSnapshotter).ListVolumeSnapshots(s.callCtx, []string{mockVolId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snap-0",
+		VolumeId:   mockVolId,
+		Size:       1024,
+		Status:     "available",
+	}})
+}
+
 func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
 	mockAdapter := &mockAdapter{
 		getVolume: func(volumeId string) (*cinder.Volume, error) {
@@ -759,6 +848,8 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -828,6 +919,22 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
@@ -1207,6 +1207,7 @@ func (s *localServerSuite) TestSubnetsFindAll(c *gc.C) {
 		expectedSubnetMap[network.Id(os.Id)] = network.SubnetInfo{
 			CIDR:              os.Cidr,
 			ProviderId:        network.Id(os.Id),
+			ProviderName:      os.Name,
 			VLANTag:           0,
 			AvailabilityZones: net.AvailabilityZones,
 			ProviderSpaceId:   "",
@@ -1244,6 +1245,7 @@ func (s *localServerSuite) TestSubnetsFindAllWithExternal(c *gc.C) {
 		expectedSubnetMap[network.Id(os.Id)] = network.SubnetInfo{
 			CIDR:              os.Cidr,
 			ProviderId:        network.Id(os.Id),
+			ProviderName:      os.Name,
 			VLANTag:           0,
 			AvailabilityZones: net.AvailabilityZones,
 			ProviderSpaceId:   "",
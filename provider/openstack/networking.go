@@ -322,6 +322,7 @@ func makeSubnetInfo(neutron *neutron.Client, subnet neutron.SubnetV2) (corenetwo
 	info := corenetwork.SubnetInfo{
 		CIDR:              subnet.Cidr,
 		ProviderId:        corenetwork.Id(subnet.Id),
+		ProviderName:      subnet.Name,
 		VLANTag:           0,
 		AvailabilityZones: net.AvailabilityZones,
 	}
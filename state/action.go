@@ -48,6 +48,14 @@ const (
 	ActionRunning ActionStatus = "running"
 )
 
+const (
+	// ActionStdout names the stream of an action's standard output.
+	ActionStdout = "stdout"
+
+	// ActionStderr names the stream of an action's standard error.
+	ActionStderr = "stderr"
+)
+
 type actionNotificationDoc struct {
 	// DocId is the composite _id that can be matched by an
 	// idPrefixWatcher that is configured to watch for the
@@ -108,12 +116,21 @@ type actionDoc struct {
 
 	// Logs holds the progress messages logged by the action.
 	Logs []ActionMessage `bson:"messages"`
+
+	// Operation is the id of the operation which enqueued the action,
+	// if any.
+	Operation string `bson:"operation,omitempty"`
 }
 
-// ActionMessage represents a progress message logged by an action.
+// ActionMessage represents a progress message logged by an action, or
+// a line of output written by it while running.
 type ActionMessage struct {
 	Message   string    `bson:"message"`
 	Timestamp time.Time `bson:"timestamp"`
+
+	// Stream is empty for progress messages, and names the output
+	// stream, "stdout" or "stderr", for lines of output.
+	Stream string `bson:"stream,omitempty" json:",omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -235,28 +252,49 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 		return nil, errors.Trace(err)
 	}
 
-	err = m.st.db().RunTransaction([]txn.Op{
-		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
-			Update: bson.D{{"$set", bson.D{
-				{"status", finalStatus},
-				{"message", message},
-				{"results", results},
-				{"completed", a.st.nowToTheSecond()},
-			}}},
-		}, {
-			C:      actionNotificationsC,
-			Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
-			Remove: true,
-		}})
-	if err != nil {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			anAction, err := m.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			a = anAction.(*action)
+		}
+		switch a.Status() {
+		case ActionCompleted, ActionCancelled, ActionFailed:
+			return nil, errors.Errorf("action %q already finished with status %v", a.Id(), a.Status())
+		}
+		ops := []txn.Op{
+			{
+				C:  actionsC,
+				Id: a.doc.DocId,
+				Assert: bson.D{{"status", bson.D{
+					{"$nin", []interface{}{
+						ActionCompleted,
+						ActionCancelled,
+						ActionFailed,
+					}}}}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", finalStatus},
+					{"message", message},
+					{"results", results},
+					{"completed", a.st.nowToTheSecond()},
+				}}},
+			}, {
+				C:      actionNotificationsC,
+				Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+				Remove: true,
+			}}
+		if a.doc.Operation != "" {
+			opOps, err := a.st.operationActionFinishedOps(a.doc.Operation, a.Receiver(), finalStatus)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, opOps...)
+		}
+		return ops, nil
+	}
+	if err = m.st.db().Run(buildTxn); err != nil {
 		return nil, err
 	}
 	return m.Action(a.Id())
@@ -270,6 +308,7 @@ func (a *action) Messages() []ActionMessage {
 		result[i] = ActionMessage{
 			Message:   m.Message,
 			Timestamp: m.Timestamp.UTC(),
+			Stream:    m.Stream,
 		}
 	}
 	return result
@@ -277,6 +316,23 @@ func (a *action) Messages() []ActionMessage {
 
 // Log adds message to the action's progress message array.
 func (a *action) Log(message string) error {
+	return a.appendMessages("", []string{message})
+}
+
+// LogOutput adds lines written by the action to the given output
+// stream to the action's message array, so they can be followed while
+// the action is running.
+func (a *action) LogOutput(stream string, lines []string) error {
+	if stream != ActionStdout && stream != ActionStderr {
+		return errors.NotValidf("action output stream %q", stream)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return a.appendMessages(stream, lines)
+}
+
+func (a *action) appendMessages(stream string, lines []string) error {
 	m, err := a.st.Model()
 	if err != nil {
 		return errors.Trace(err)
@@ -292,13 +348,18 @@ func (a *action) Log(message string) error {
 		if s := a.Status(); s != ActionRunning {
 			return nil, errors.Errorf("cannot log message to operation %q with status %v", a.Id(), s)
 		}
+		now := a.st.nowToTheSecond().UTC()
+		messages := make([]ActionMessage, len(lines))
+		for i, line := range lines {
+			messages[i] = ActionMessage{Message: line, Timestamp: now, Stream: stream}
+		}
 		ops := []txn.Op{
 			{
 				C:      actionsC,
 				Id:     a.doc.DocId,
 				Assert: bson.D{{"status", ActionRunning}},
 				Update: bson.D{{"$push", bson.D{
-					{"messages", ActionMessage{Message: message, Timestamp: a.st.nowToTheSecond().UTC()}},
+					{"messages", bson.D{{"$each", messages}}},
 				}}},
 			}}
 		return ops, nil
@@ -555,11 +616,15 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 	return actions, errors.Trace(iter.Close())
 }
 
-// PruneActions removes action entries until
+// PruneActions removes action and operation entries until
 // only logs newer than <maxLogTime> remain and also ensures
-// that the collection is smaller than <maxLogsMB> after the
+// that each collection is smaller than <maxLogsMB> after the
 // deletion.
 func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
 	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = pruneCollection(st, maxHistoryTime, maxHistoryMB, operationsC, "completed", GoTime)
 	return errors.Trace(err)
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/txn"
 	gc "gopkg.in/check.v1"
@@ -329,6 +330,37 @@ func (s *ActionSuite) TestActionLogMessageRace(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot log message to operation "dummy-1" with status completed`)
 }
 
+func (s *ActionSuite) TestActionLogOutput(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	anAction, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = anAction.LogOutput("stdin", []string{"nope"})
+	c.Assert(err, gc.ErrorMatches, `action output stream "stdin" not valid`)
+
+	err = anAction.Log("starting")
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput(state.ActionStdout, []string{"one", "two"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput(state.ActionStderr, []string{"oops"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	now := clock.Now().UTC()
+	c.Assert(a.Messages(), jc.DeepEquals, []state.ActionMessage{
+		{Message: "starting", Timestamp: now},
+		{Message: "one", Timestamp: now, Stream: "stdout"},
+		{Message: "two", Timestamp: now, Stream: "stdout"},
+		{Message: "oops", Timestamp: now, Stream: "stderr"},
+	})
+}
+
 // makeUnits prepares units with given Action schemas
 func makeUnits(c *gc.C, s *ActionSuite, units map[string]*state.Unit, schemas map[string]string) {
 	// A few dummy charms that haven't been used yet
@@ -991,6 +1023,15 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	}}
 	checkExpected(wc, expected)
 
+	// Only messages not already reported are sent.
+	err = fa1.Log("last")
+	c.Assert(err, jc.ErrorIsNil)
+	expected = []state.ActionMessage{{
+		Timestamp: startNow,
+		Message:   "last",
+	}}
+	checkExpected(wc, expected)
+
 	// But on a new watcher we see all 3 events.
 	w2 := s.State.WatchActionLogs(fa1.Id())
 	defer statetesting.AssertStop(c, w)
@@ -1005,6 +1046,9 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	}, {
 		Timestamp: makeTimestamp(2 * time.Second),
 		Message:   "yet another",
+	}, {
+		Timestamp: makeTimestamp(3 * time.Second),
+		Message:   "last",
 	}}
 	checkExpected(wc2, expected)
 }
@@ -1192,3 +1236,56 @@ func (s *ActionPruningSuite) TestDoNotPruneIncompleteActions(c *gc.C) {
 
 	c.Assert(actionsLen, gc.Equals, numZeroValueEntries)
 }
+
+func (s *ActionPruningSuite) countOperations(c *gc.C, ids []string) int {
+	count := 0
+	for _, id := range ids {
+		_, err := s.Model.Operation(id)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		count++
+	}
+	return count
+}
+
+func (s *ActionPruningSuite) TestPruneOperationsByAge(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	const numCurrentOperations = 5
+	const numExpiredOperations = 5
+	const numIncompleteOperations = 5
+	const ageOfExpired = 10 * time.Hour
+
+	current := state.PrimeOperations(c, clock.Now(), s.State, numCurrentOperations)
+	expired := state.PrimeOperations(c, clock.Now().Add(-1*ageOfExpired), s.State, numExpiredOperations)
+	// Completed times with the zero value are designated not complete.
+	incomplete := state.PrimeOperations(c, time.Time{}, s.State, numIncompleteOperations)
+
+	err = state.PruneActions(s.State, 1*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.countOperations(c, current), gc.Equals, numCurrentOperations)
+	c.Assert(s.countOperations(c, expired), gc.Equals, 0)
+	c.Assert(s.countOperations(c, incomplete), gc.Equals, numIncompleteOperations)
+}
+
+func (s *ActionPruningSuite) TestPruneOperationsBySize(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	const numOperations = 15 //At slightly > 1MB per entry
+	const maxLogSize = 5     //MB
+	ids := state.PrimeOperations(c, clock.Now(), s.State, numOperations)
+
+	err = state.PruneActions(s.State, 0, maxLogSize)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// As with actions, the remaining count should be relatively close
+	// to the max log size, since each entry is about 1MB in size.
+	c.Assert(float64(s.countOperations(c, ids)), jc.LessThan, 1.5*maxLogSize)
+}
@@ -0,0 +1,629 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/robfig/cron.v2"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionMissedRunPolicy determines what happens to a scheduled run which
+// the controller was unable to start on time.
+type ActionMissedRunPolicy string
+
+const (
+	// MissedRunSkip skips missed runs; the action next runs at its
+	// following scheduled time.
+	MissedRunSkip ActionMissedRunPolicy = "skip"
+
+	// MissedRunOnce runs the action once as soon as possible, however
+	// many runs were missed.
+	MissedRunOnce ActionMissedRunPolicy = "run-once"
+)
+
+// ActionOverlapPolicy determines what happens when a scheduled run is
+// due on a unit whose previous scheduled action has not finished.
+type ActionOverlapPolicy string
+
+const (
+	// OverlapSkip skips the run on a unit whose previous scheduled
+	// action is still pending or running.
+	OverlapSkip ActionOverlapPolicy = "skip"
+
+	// OverlapQueue enqueues the action regardless, so that it runs once
+	// the previous one has finished.
+	OverlapQueue ActionOverlapPolicy = "queue"
+)
+
+// actionScheduleGracePeriod is how late a scheduled run may be started
+// before it is considered to have been missed.
+const actionScheduleGracePeriod = 5 * time.Minute
+
+// ActionScheduleArgs holds the parameters for adding an action schedule.
+type ActionScheduleArgs struct {
+	// Name uniquely identifies the schedule within the model.
+	Name string
+
+	// Receiver is the application or unit to run the action on. An
+	// application's action is run on each of its units alive at the
+	// time of the run.
+	Receiver names.Tag
+
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters are the action's parameters.
+	Parameters map[string]interface{}
+
+	// Cron is the schedule in cron syntax, for example "0 2 * * *" or
+	// "@daily". Times are in UTC unless the schedule is prefixed with
+	// a time zone, as in "TZ=Europe/London 0 2 * * *".
+	Cron string
+
+	// MissedRunPolicy determines what happens to runs which could not
+	// be started on time. It defaults to MissedRunSkip.
+	MissedRunPolicy ActionMissedRunPolicy
+
+	// OverlapPolicy determines what happens to runs due on units whose
+	// previous scheduled action has not finished. It defaults to
+	// OverlapSkip.
+	OverlapPolicy ActionOverlapPolicy
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args ActionScheduleArgs) Validate() error {
+	if !names.IsValidApplication(args.Name) {
+		return errors.NotValidf("schedule name %q", args.Name)
+	}
+	if args.Receiver == nil {
+		return errors.NotValidf("schedule without receiver")
+	}
+	switch args.Receiver.Kind() {
+	case names.ApplicationTagKind, names.UnitTagKind:
+	default:
+		return errors.NotValidf("schedule receiver %q", args.Receiver)
+	}
+	if args.ActionName == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if _, err := parseActionCron(args.Cron); err != nil {
+		return errors.Trace(err)
+	}
+	switch args.MissedRunPolicy {
+	case "", MissedRunSkip, MissedRunOnce:
+	default:
+		return errors.NotValidf("missed run policy %q", args.MissedRunPolicy)
+	}
+	switch args.OverlapPolicy {
+	case "", OverlapSkip, OverlapQueue:
+	default:
+		return errors.NotValidf("overlap policy %q", args.OverlapPolicy)
+	}
+	return nil
+}
+
+// parseActionCron parses a schedule in cron syntax. Schedules without a
+// time zone are interpreted in UTC.
+func parseActionCron(spec string) (cron.Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, errors.NotValidf("empty schedule")
+	}
+	zoned := spec
+	if !strings.HasPrefix(zoned, "TZ=") {
+		zoned = "TZ=UTC " + zoned
+	}
+	schedule, err := cron.Parse(zoned)
+	if err != nil {
+		return nil, errors.NewNotValid(err, fmt.Sprintf("invalid schedule %q", spec))
+	}
+	return schedule, nil
+}
+
+// actionScheduleDoc records an action which the controller runs on a
+// schedule.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	TxnRevno  int64  `bson:"txn-revno"`
+
+	Name            string                 `bson:"name"`
+	Receiver        string                 `bson:"receiver"`
+	ActionName      string                 `bson:"action-name"`
+	Parameters      map[string]interface{} `bson:"parameters"`
+	Cron            string                 `bson:"cron"`
+	MissedRunPolicy ActionMissedRunPolicy  `bson:"missed-run-policy"`
+	OverlapPolicy   ActionOverlapPolicy    `bson:"overlap-policy"`
+	Created         time.Time              `bson:"created"`
+	NextRun         time.Time              `bson:"next-run"`
+
+	// LastRun is the time the schedule last came due, whether or not
+	// any actions were enqueued.
+	LastRun time.Time `bson:"last-run"`
+
+	// LastActions maps each unit to the id of the most recent action
+	// enqueued on it by the schedule.
+	LastActions map[string]string `bson:"last-actions,omitempty"`
+
+	// LastMessage describes any runs skipped when the schedule last
+	// came due, or why the schedule could not be run.
+	LastMessage string `bson:"last-message"`
+}
+
+// ActionSchedule is an action which the controller runs on a schedule.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Name returns the schedule's name.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Receiver returns the tag of the application or unit the action runs on.
+func (s *ActionSchedule) Receiver() (names.Tag, error) {
+	return names.ParseTag(s.doc.Receiver)
+}
+
+// ActionName returns the name of the scheduled action.
+func (s *ActionSchedule) ActionName() string {
+	return s.doc.ActionName
+}
+
+// Parameters returns the parameters of the scheduled action, with any
+// defaults inserted.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Cron returns the schedule in cron syntax.
+func (s *ActionSchedule) Cron() string {
+	return s.doc.Cron
+}
+
+// MissedRunPolicy returns the policy for runs which could not be
+// started on time.
+func (s *ActionSchedule) MissedRunPolicy() ActionMissedRunPolicy {
+	return s.doc.MissedRunPolicy
+}
+
+// OverlapPolicy returns the policy for runs due on units whose previous
+// scheduled action has not finished.
+func (s *ActionSchedule) OverlapPolicy() ActionOverlapPolicy {
+	return s.doc.OverlapPolicy
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns the time the schedule is next due.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// LastRun returns the time the schedule last came due, or the zero
+// time if it never has.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// LastActions returns the ids of the most recent actions enqueued by
+// the schedule, keyed by unit name.
+func (s *ActionSchedule) LastActions() map[string]string {
+	result := make(map[string]string, len(s.doc.LastActions))
+	for unitName, id := range s.doc.LastActions {
+		result[unitName] = id
+	}
+	return result
+}
+
+// LastMessage describes any runs which were skipped when the schedule
+// last came due, or why the schedule could not be run.
+func (s *ActionSchedule) LastMessage() string {
+	return s.doc.LastMessage
+}
+
+// Refresh reloads the schedule from the database.
+func (s *ActionSchedule) Refresh() error {
+	doc, err := s.st.actionScheduleDoc(s.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc = *doc
+	return nil
+}
+
+func (st *State) actionScheduleDoc(name string) (*actionScheduleDoc, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &doc, nil
+}
+
+// ActionSchedule returns the action schedule with the given name.
+func (m *Model) ActionSchedule(name string) (*ActionSchedule, error) {
+	doc, err := m.st.actionScheduleDoc(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: m.st, doc: *doc}, nil
+}
+
+// ActionSchedules returns all of the model's action schedules, ordered
+// by name.
+func (m *Model) ActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+// AddActionSchedule adds a schedule on which the controller runs an
+// action. The action's parameters are validated against the charm of
+// the receiver, and any defaults are inserted.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args.MissedRunPolicy == "" {
+		args.MissedRunPolicy = MissedRunSkip
+	}
+	if args.OverlapPolicy == "" {
+		args.OverlapPolicy = OverlapSkip
+	}
+	schedule, err := parseActionCron(args.Cron)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	payload, err := m.st.prepareScheduledActionPayload(args.Receiver, args.ActionName, args.Parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	receiverCollection, receiverId, err := m.st.tagToCollectionAndId(args.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	doc := actionScheduleDoc{
+		DocId:           m.st.docID(args.Name),
+		ModelUUID:       m.st.ModelUUID(),
+		Name:            args.Name,
+		Receiver:        args.Receiver.String(),
+		ActionName:      args.ActionName,
+		Parameters:      payload,
+		Cron:            args.Cron,
+		MissedRunPolicy: args.MissedRunPolicy,
+		OverlapPolicy:   args.OverlapPolicy,
+		Created:         now,
+		NextRun:         schedule.Next(now),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := m.st.actionScheduleDoc(args.Name); err == nil {
+				return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+			}
+			if notDead, err := isNotDead(m.st, receiverCollection, receiverId); err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, errors.Errorf("%s is not alive", names.ReadableString(args.Receiver))
+			}
+		}
+		return []txn.Op{{
+			C:      receiverCollection,
+			Id:     receiverId,
+			Assert: notDeadDoc,
+		}, {
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot add action schedule %q", args.Name)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// prepareScheduledActionPayload validates the parameters against the
+// receiver's charm, and returns them with any defaults inserted.
+func (st *State) prepareScheduledActionPayload(
+	receiver names.Tag, actionName string, payload map[string]interface{},
+) (map[string]interface{}, error) {
+	appName := receiver.Id()
+	if receiver.Kind() == names.UnitTagKind {
+		var err error
+		if appName, err = names.UnitApplication(receiver.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	app, err := st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, ok := actions.PredefinedActionsSpec[actionName]
+	if !ok {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var specs map[string]charm.ActionSpec
+		if chActions := ch.Actions(); chActions != nil {
+			specs = chActions.ActionSpecs
+		}
+		if spec, ok = specs[actionName]; !ok {
+			return nil, errors.Errorf("action %q not defined on application %q", actionName, appName)
+		}
+	}
+	if err := spec.ValidateParams(payload); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spec.InsertDefaults(payload)
+}
+
+// RemoveActionSchedule removes the action schedule with the given name.
+// Actions already enqueued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(name string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", name)
+	}
+	return errors.Annotatef(err, "cannot remove action schedule %q", name)
+}
+
+// WatchActionSchedules returns a NotifyWatcher which triggers whenever
+// an action schedule is added, changed or removed.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, actionSchedulesC, isLocalID(m.st))
+}
+
+// RunDueActionSchedules enqueues the actions of every schedule which is
+// due at the given time, according to each schedule's missed run and
+// overlap policies. A schedule which cannot be run records the error as
+// its last message and does not prevent the other schedules from running.
+// It returns the earliest time at which a schedule is next due, or the
+// zero time if there are no schedules.
+func (m *Model) RunDueActionSchedules(now time.Time) (time.Time, error) {
+	schedules, err := m.ActionSchedules()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	var next time.Time
+	for _, schedule := range schedules {
+		if !schedule.doc.NextRun.After(now) {
+			if runErr := schedule.run(now); runErr != nil {
+				logger.Errorf("running action schedule %q: %v", schedule.Name(), runErr)
+				if err := schedule.recordFailure(now, runErr); err != nil {
+					return time.Time{}, errors.Annotatef(err, "recording failure of action schedule %q", schedule.Name())
+				}
+			}
+		}
+		if next.IsZero() || schedule.doc.NextRun.Before(next) {
+			next = schedule.doc.NextRun
+		}
+	}
+	return next, nil
+}
+
+// run enqueues the schedule's actions, if it is still due, and records
+// when it is next due.
+func (s *ActionSchedule) run(now time.Time) error {
+	var doc actionScheduleDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.NextRun.After(now) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		doc = s.doc
+		ops, err := s.st.runActionScheduleOps(&doc, now)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"txn-revno", s.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"next-run", doc.NextRun},
+				{"last-run", doc.LastRun},
+				{"last-actions", doc.LastActions},
+				{"last-message", doc.LastMessage},
+			}}},
+		}}, ops...), nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if doc.DocId != "" {
+		doc.TxnRevno = s.doc.TxnRevno + 1
+		s.doc = doc
+	}
+	return nil
+}
+
+// recordFailure records that the schedule could not be run when it came
+// due, and moves it on to its following scheduled time so that it is not
+// retried until then.
+func (s *ActionSchedule) recordFailure(now time.Time, runErr error) error {
+	schedule, err := parseActionCron(s.doc.Cron)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	nextRun := schedule.Next(now)
+	message := fmt.Sprintf("run failed: %v", runErr)
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"next-run", nextRun},
+			{"last-run", now},
+			{"last-message", message},
+		}}},
+	}}
+	err = s.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		// The schedule has been removed.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.NextRun = nextRun
+	s.doc.LastRun = now
+	s.doc.LastMessage = message
+	s.doc.TxnRevno++
+	return nil
+}
+
+// runActionScheduleOps returns the operations needed to enqueue the
+// scheduled action on each of the receiver's units, and updates the
+// document with the outcome of the run.
+func (st *State) runActionScheduleOps(doc *actionScheduleDoc, now time.Time) ([]txn.Op, error) {
+	schedule, err := parseActionCron(doc.Cron)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	due := doc.NextRun
+	doc.NextRun = schedule.Next(now)
+	doc.LastRun = now
+	doc.LastMessage = ""
+
+	if now.Sub(due) > actionScheduleGracePeriod && doc.MissedRunPolicy != MissedRunOnce {
+		doc.LastMessage = fmt.Sprintf("missed run due at %s skipped", due.UTC().Format(time.RFC3339))
+		return nil, nil
+	}
+
+	unitNames, err := st.actionScheduleUnits(doc.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	lastActions := make(map[string]string)
+	var ops []txn.Op
+	var overlapping []string
+	for _, unitName := range unitNames {
+		if id, ok := doc.LastActions[unitName]; ok && doc.OverlapPolicy != OverlapQueue {
+			running, err := st.actionOutstanding(id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if running {
+				overlapping = append(overlapping, unitName)
+				lastActions[unitName] = id
+				continue
+			}
+		}
+		adoc, unitOps, err := st.enqueueUnitActionOps(unitName, doc.ActionName, doc.Parameters)
+		if err != nil {
+			return nil, errors.Trace(err)
+		} else if adoc == nil {
+			continue
+		}
+		lastActions[unitName] = st.localID(adoc.DocId)
+		ops = append(ops, unitOps...)
+	}
+	doc.LastActions = lastActions
+	if len(overlapping) > 0 {
+		doc.LastMessage = fmt.Sprintf("previous run still in progress on %s", strings.Join(overlapping, ", "))
+	}
+	return ops, nil
+}
+
+// actionScheduleUnits returns the names of the alive units a schedule's
+// action runs on.
+func (st *State) actionScheduleUnits(receiver string) ([]string, error) {
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tag.Kind() == names.UnitTagKind {
+		unit, err := st.Unit(tag.Id())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			return nil, nil
+		}
+		return []string{unit.Name()}, nil
+	}
+	app, err := st.Application(tag.Id())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []string
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			result = append(result, unit.Name())
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// actionOutstanding reports whether the action with the given id is
+// still pending or running.
+func (st *State) actionOutstanding(id string) (bool, error) {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	err := actions.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotatef(err, "cannot get action %q", id)
+	}
+	return doc.Status == ActionPending || doc.Status == ActionRunning, nil
+}
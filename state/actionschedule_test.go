@@ -0,0 +1,278 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	model *state.Model
+	app   *state.Application
+	units []*state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.app = s.AddTestingApplication(c, "dummy", ch)
+	s.units = nil
+	for i := 0; i < 2; i++ {
+		unit, err := s.app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, args state.ActionScheduleArgs) *state.ActionSchedule {
+	if args.Name == "" {
+		args.Name = "nightly"
+	}
+	if args.Receiver == nil {
+		args.Receiver = s.app.ApplicationTag()
+	}
+	if args.ActionName == "" {
+		args.ActionName = "snapshot"
+	}
+	if args.Cron == "" {
+		args.Cron = "0 2 * * *"
+	}
+	schedule, err := s.model.AddActionSchedule(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleArgs{})
+	c.Assert(schedule.Name(), gc.Equals, "nightly")
+	receiver, err := schedule.Receiver()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(receiver, gc.Equals, s.app.ApplicationTag())
+	c.Assert(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	c.Assert(schedule.MissedRunPolicy(), gc.Equals, state.MissedRunSkip)
+	c.Assert(schedule.OverlapPolicy(), gc.Equals, state.OverlapSkip)
+
+	next := schedule.NextRun().UTC()
+	c.Assert(next.After(schedule.Created()), jc.IsTrue)
+	c.Assert(next.Hour(), gc.Equals, 2)
+	c.Assert(next.Minute(), gc.Equals, 0)
+
+	schedules, err := s.model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0].Name(), gc.Equals, "nightly")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleValidates(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Cron: "not a schedule"},
+		err:  `invalid schedule "not a schedule": .*`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "Bad Name"},
+		err:  `schedule name "Bad Name" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{MissedRunPolicy: "sometimes"},
+		err:  `missed run policy "sometimes" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{OverlapPolicy: "sometimes"},
+		err:  `overlap policy "sometimes" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{ActionName: "missing"},
+		err:  `action "missing" not defined on application "dummy"`,
+	}, {
+		args: state.ActionScheduleArgs{Parameters: map[string]interface{}{"outfile": 1}},
+		err:  `(?s).*outfile.*`,
+	}} {
+		c.Logf("test %d", i)
+		args := test.args
+		if args.Name == "" {
+			args.Name = "nightly"
+		}
+		args.Receiver = s.app.ApplicationTag()
+		if args.ActionName == "" {
+			args.ActionName = "snapshot"
+		}
+		if args.Cron == "" {
+			args.Cron = "@daily"
+		}
+		_, err := s.model.AddActionSchedule(args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleAlreadyExists(c *gc.C) {
+	s.addSchedule(c, state.ActionScheduleArgs{})
+	_, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "nightly",
+		Receiver:   s.units[0].UnitTag(),
+		ActionName: "snapshot",
+		Cron:       "@hourly",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule "nightly": action schedule "nightly" already exists`)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	s.addSchedule(c, state.ActionScheduleArgs{})
+	err := s.model.RemoveActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule("nightly")
+	c.Assert(err, gc.ErrorMatches, `action schedule "nightly" not found`)
+	err = s.model.RemoveActionSchedule("nightly")
+	c.Assert(err, gc.ErrorMatches, `action schedule "nightly" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedules(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleArgs{})
+	due := schedule.NextRun()
+
+	// Nothing is due yet.
+	next, err := s.model.RunDueActionSchedules(due.Add(-time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, jc.DeepEquals, due)
+
+	next, err = s.model.RunDueActionSchedules(due)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, jc.DeepEquals, due.Add(24*time.Hour))
+
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun(), jc.DeepEquals, due)
+	c.Assert(schedule.LastMessage(), gc.Equals, "")
+	lastActions := schedule.LastActions()
+	c.Assert(lastActions, gc.HasLen, 2)
+	for _, unit := range s.units {
+		action, err := s.model.Action(lastActions[unit.Name()])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(action.Name(), gc.Equals, "snapshot")
+		c.Assert(action.Receiver(), gc.Equals, unit.Name())
+		c.Assert(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	}
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesOverlapSkip(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleArgs{Receiver: s.units[0].UnitTag()})
+	due := schedule.NextRun()
+	_, err := s.model.RunDueActionSchedules(due)
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	first := schedule.LastActions()["dummy/0"]
+
+	// The first action is still pending when the next run comes due.
+	_, err = s.model.RunDueActionSchedules(schedule.NextRun())
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastActions(), jc.DeepEquals, map[string]string{"dummy/0": first})
+	c.Assert(schedule.LastMessage(), gc.Equals, "previous run still in progress on dummy/0")
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesOverlapQueue(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleArgs{
+		Receiver:      s.units[0].UnitTag(),
+		OverlapPolicy: state.OverlapQueue,
+	})
+	_, err := s.model.RunDueActionSchedules(schedule.NextRun())
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	first := schedule.LastActions()["dummy/0"]
+
+	_, err = s.model.RunDueActionSchedules(schedule.NextRun())
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastActions()["dummy/0"], gc.Not(gc.Equals), first)
+	c.Assert(schedule.LastMessage(), gc.Equals, "")
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesMissedRun(c *gc.C) {
+	skip := s.addSchedule(c, state.ActionScheduleArgs{Name: "skip"})
+	once := s.addSchedule(c, state.ActionScheduleArgs{Name: "once", MissedRunPolicy: state.MissedRunOnce})
+
+	// The controller was unavailable for two days.
+	now := skip.NextRun().Add(48*time.Hour + time.Hour)
+	next, err := s.model.RunDueActionSchedules(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, jc.DeepEquals, skip.NextRun().Add(72*time.Hour))
+
+	err = skip.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skip.LastActions(), gc.HasLen, 0)
+	c.Assert(skip.LastMessage(), gc.Matches, "missed run due at .* skipped")
+
+	err = once.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(once.LastActions(), gc.HasLen, 2)
+	c.Assert(once.NextRun(), jc.DeepEquals, next)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesRecordsFailure(c *gc.C) {
+	broken := s.addSchedule(c, state.ActionScheduleArgs{Name: "broken"})
+	nightly := s.addSchedule(c, state.ActionScheduleArgs{Name: "nightly"})
+	due := broken.NextRun()
+
+	// Corrupt the first schedule's receiver so that it cannot be run.
+	col := s.State.MongoSession().DB("juju").C(state.ActionSchedulesC)
+	err := col.UpdateId(s.State.ModelUUID()+":broken", bson.M{"$set": bson.M{"receiver": "bogus"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	next, err := s.model.RunDueActionSchedules(due)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, jc.DeepEquals, due.Add(24*time.Hour))
+
+	err = broken.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(broken.LastRun(), jc.DeepEquals, due)
+	c.Assert(broken.NextRun(), jc.DeepEquals, next)
+	c.Assert(broken.LastMessage(), gc.Matches, `run failed: .*"bogus" is not a valid tag`)
+
+	// The other schedule still ran.
+	err = nightly.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nightly.LastActions(), gc.HasLen, 2)
+	c.Assert(nightly.LastMessage(), gc.Equals, "")
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesSkipsDeadUnits(c *gc.C) {
+	schedule := s.addSchedule(c, state.ActionScheduleArgs{})
+	err := s.units[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.RunDueActionSchedules(schedule.NextRun())
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastActions(), gc.HasLen, 1)
+	c.Assert(schedule.LastActions()["dummy/0"], gc.Not(gc.Equals), "")
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.addSchedule(c, state.ActionScheduleArgs{})
+	wc.AssertOneChange()
+
+	err := s.model.RemoveActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -278,7 +278,10 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	mdoc, err := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -369,7 +372,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
@@ -428,7 +434,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		}
 	}
 
-	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	parentDoc, err := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	newId, err := st.newContainerId(parentDoc.Id, containerType)
 	if err != nil {
 		return nil, nil, err
@@ -437,7 +446,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
 	if err != nil {
@@ -488,13 +500,20 @@ func (st *State) machineTemplateVolumeAttachmentParams(t MachineTemplate) ([]sto
 	return out, nil
 }
 
-func (st *State) machineDocForTemplate(template MachineTemplate, id string) *machineDoc {
+func (st *State) machineDocForTemplate(template MachineTemplate, id string) (*machineDoc, error) {
+	family := network.PreferIPv4
+	if len(template.Addresses) > 0 {
+		var err error
+		if family, err = st.PreferredAddressFamily(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	// We ignore the error from Select*Address as an error indicates
 	// no address is available, in which case the empty address is returned
 	// and setting the preferred address to an empty one is the correct
 	// thing to do when none is available.
-	privateAddr, _ := network.SelectInternalAddress(template.Addresses, false)
-	publicAddr, _ := network.SelectPublicAddress(template.Addresses)
+	privateAddr, _ := network.SelectInternalAddressForFamily(template.Addresses, false, family)
+	publicAddr, _ := network.SelectPublicAddressForFamily(template.Addresses, family)
 	logger.Infof(
 		"new machine %q has preferred addresses: private %q, public %q",
 		id, privateAddr, publicAddr,
@@ -513,7 +532,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPrivateAddress: fromNetworkAddress(privateAddr, OriginMachine),
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		Placement:               template.Placement,
-	}
+	}, nil
 }
 
 // insertNewMachineOps returns operations to insert the given machine document
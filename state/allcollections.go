@@ -411,6 +411,14 @@ func allCollections() CollectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// operationsC holds groups of actions run across units
+		// subject to a common concurrency and failure policy.
+		operationsC: {},
+
+		// actionSchedulesC holds the schedules on which the
+		// controller runs actions.
+		actionSchedulesC: {},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -526,6 +534,14 @@ func allCollections() CollectionSchema {
 		// eg addresses.
 		cloudServicesC: {},
 
+		// unitStatesC holds the key/value state that charms keep
+		// for their units between hooks.
+		unitStatesC: {},
+
+		// unitHookExecutionsC holds the timing and outcome of the
+		// most recent hooks run by each unit.
+		unitHookExecutionsC: {},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -544,6 +560,7 @@ func allCollections() CollectionSchema {
 const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
+	actionSchedulesC           = "actionschedules"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
@@ -590,6 +607,7 @@ const (
 	modelsC                    = "models"
 	modelEntityRefsC           = "modelEntityRefs"
 	openedPortsC               = "openedPorts"
+	operationsC                = "operations"
 	payloadsC                  = "payloads"
 	permissionsC               = "permissions"
 	podSpecsC                  = "podSpecs"
@@ -620,6 +638,8 @@ const (
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
 	unitsC                     = "units"
+	unitStatesC                = "unitstates"
+	unitHookExecutionsC        = "unithookexecutions"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
@@ -1383,6 +1383,14 @@ func (g *backingGeneration) updated(st *State, store *multiwatcherStore, id stri
 		CompletedBy:   g.CompletedBy,
 		GenerationId:  g.GenerationId,
 	}
+	if p := g.PromotionPolicy; p != nil {
+		info.PromotionPolicy = &multiwatcher.PromotionPolicy{
+			HealthyFor: p.HealthyFor,
+			ActionName: p.ActionName,
+			OnError:    p.OnError,
+			Set:        p.Set,
+		}
+	}
 	store.Update(info)
 	return nil
 
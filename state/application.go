@@ -64,6 +64,11 @@ type applicationDoc struct {
 	TxnRevno             int64        `bson:"txn-revno"`
 	MetricCredentials    []byte       `bson:"metric-credentials"`
 
+	// ExposedEndpoints maps the application's endpoints to the sources
+	// they are exposed to. The empty endpoint name applies to all of
+	// the application's endpoints.
+	ExposedEndpoints map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
 	PasswordHash string `bson:"passwordhash"`
@@ -595,23 +600,31 @@ func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag, and any endpoint expose
+// settings, from the application.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	if !exposed {
+		a.doc.ExposedEndpoints = nil
+	}
 	return nil
 }
 
@@ -2056,6 +2069,8 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 			Remove: true,
 		},
 		removeMeterStatusOp(a.st, u.globalMeterStatusKey()),
+		removeUnitStateOp(a.st, u.globalKey()),
+		removeUnitHookExecutionsOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalCloudContainerKey()),
@@ -2505,6 +2520,40 @@ func (a *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// MergeBindings merges the input endpoint bindings with those currently
+// stored for the application. Endpoints absent from the input retain
+// their current bindings.
+func (a *Application) MergeBindings(bindings map[string]string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"charmurl", a.doc.CharmURL}},
+		}, bindingsOp}, nil
+	}
+	err := a.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "merging endpoint bindings for application %q", a)
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
@@ -2773,6 +2822,7 @@ func deriveApplicationStatus(statuses []status.StatusInfo) status.StatusInfo {
 var statusServerities = map[status.Status]int{
 	status.Error:       100,
 	status.Blocked:     90,
+	status.Warning:     85,
 	status.Waiting:     80,
 	status.Maintenance: 70,
 	status.Active:      60,
@@ -3388,6 +3388,41 @@ func (s *ApplicationSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertApplicationRemovedWithItsBindings(c, application)
 }
 
+func (s *ApplicationSuite) TestMergeBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("ha", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, map[string]string{
+		"server": "db",
+	})
+
+	err = application.MergeBindings(map[string]string{"cluster": "ha"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	setBindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"client":  "",
+		"cluster": "ha",
+	})
+
+	// Merging the same bindings again is a no-op.
+	err = application.MergeBindings(map[string]string{"cluster": "ha"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestMergeBindingsInvalidSpace(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	err := application.MergeBindings(map[string]string{"server": "nowhere"})
+	c.Assert(err, gc.ErrorMatches, `merging endpoint bindings for application "yoursql": .*"nowhere".*`)
+}
+
 func (s *ApplicationSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ExposedEndpoint describes the sources that an exposed application
+// endpoint may be accessed from. If neither spaces nor CIDRs are
+// specified, the endpoint may be accessed from anywhere.
+type ExposedEndpoint struct {
+	// ExposeToSpaces contains the names of the spaces whose subnets
+	// may access the endpoint.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs contains the CIDRs that may access the endpoint.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllowsAll reports whether the endpoint may be accessed from anywhere.
+func (e ExposedEndpoint) AllowsAll() bool {
+	return len(e.ExposeToSpaces) == 0 && len(e.ExposeToCIDRs) == 0
+}
+
+// ExposedEndpoints returns the expose settings of the application's
+// endpoints, keyed by endpoint name. The empty endpoint name applies
+// to all of the application's endpoints. An exposed application
+// without any endpoint expose settings may be accessed from anywhere.
+//
+// The settings of an endpoint apply to the ports opened by the units
+// for that endpoint, as well as the settings for all endpoints. Ports
+// opened for all endpoints may be accessed from the sources of every
+// exposed endpoint.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for endpoint, exposed := range a.doc.ExposedEndpoints {
+		result[endpoint] = exposed
+	}
+	return result
+}
+
+// MergeExposeSettings marks the application as exposed and merges the
+// given endpoint expose settings with those currently stored for the
+// application. The settings of endpoints absent from the input are
+// retained.
+func (a *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		if err := a.validateExposeSettings(exposed); err != nil {
+			return nil, errors.Trace(err)
+		}
+		merged := a.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for endpoint, settings := range exposed {
+			merged[endpoint] = settings
+		}
+		return a.exposeSettingsOps(true, merged), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "merging expose settings for application %q", a)
+	}
+	return nil
+}
+
+// UnsetExposeSettings removes the expose settings of the given
+// endpoints. If no endpoint expose settings remain, the application
+// is unexposed.
+func (a *Application) UnsetExposeSettings(endpoints []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		if !a.doc.Exposed {
+			return nil, jujutxn.ErrNoOperations
+		}
+		remaining := a.ExposedEndpoints()
+		for _, endpoint := range endpoints {
+			if _, ok := remaining[endpoint]; !ok {
+				return nil, errors.NotFoundf("expose settings for endpoint %q", endpoint)
+			}
+			delete(remaining, endpoint)
+		}
+		return a.exposeSettingsOps(len(remaining) > 0, remaining), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "unsetting expose settings for application %q", a)
+	}
+	return nil
+}
+
+// exposeSettingsOps returns the operations required to set the
+// application's exposed flag and replace its endpoint expose settings.
+func (a *Application) exposeSettingsOps(exposed bool, settings map[string]ExposedEndpoint) []txn.Op {
+	update := bson.D{{"$set", bson.D{
+		{"exposed", exposed},
+		{"exposed-endpoints", settings},
+	}}}
+	if len(settings) == 0 {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		}
+	}
+	return []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+		Update: update,
+	}}
+}
+
+// validateExposeSettings checks that the endpoints, spaces and CIDRs
+// in the given expose settings are valid for the application.
+func (a *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	var endpoints []Endpoint
+	for endpoint, settings := range exposed {
+		if endpoint != "" {
+			if endpoints == nil {
+				var err error
+				if endpoints, err = a.Endpoints(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if !hasEndpoint(endpoints, endpoint) {
+				return errors.NotValidf("endpoint %q for application %q", endpoint, a.Name())
+			}
+		}
+		for _, spaceName := range settings.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Annotatef(err, "exposing endpoint %q", endpoint)
+			}
+		}
+		for _, cidr := range settings.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q for endpoint %q", cidr, endpoint)
+			}
+		}
+	}
+	return nil
+}
+
+func hasEndpoint(endpoints []Endpoint, name string) bool {
+	for _, ep := range endpoints {
+		if ep.Name == name {
+			return true
+		}
+	}
+	return false
+}
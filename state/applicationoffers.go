@@ -53,6 +53,16 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the applicationbob.
 	Endpoints map[string]string `bson:"endpoints"`
+
+	// MaxConnections is the maximum number of concurrent connections
+	// allowed to the offer, or 0 if there is no limit.
+	MaxConnections int `bson:"max-connections,omitempty"`
+
+	// ConnectionCount is the number of connections currently made
+	// to the offer. It is maintained alongside the offer connection
+	// records so that the connection limit can be asserted in the
+	// same transaction that adds a connection.
+	ConnectionCount int `bson:"connection-count,omitempty"`
 }
 
 var _ crossmodel.ApplicationOffers = (*applicationOffers)(nil)
@@ -525,13 +535,17 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 				return nil, errors.Trace(err)
 			}
 		}
+		update := bson.M{"$set": doc}
+		if doc.MaxConnections == 0 {
+			update["$unset"] = bson.M{"max-connections": 1}
+		}
 		ops := []txn.Op{
 			model.assertActiveOp(),
 			{
 				C:      applicationOffersC,
 				Id:     doc.DocID,
 				Assert: txn.DocExists,
-				Update: bson.M{"$set": doc},
+				Update: update,
 			},
 		}
 		ops = append(ops, refOps...)
@@ -552,6 +566,7 @@ func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string
 		ApplicationName:        offer.ApplicationName,
 		ApplicationDescription: offer.ApplicationDescription,
 		Endpoints:              offer.Endpoints,
+		MaxConnections:         offer.MaxConnections,
 	}
 	return doc
 }
@@ -736,6 +751,7 @@ func (s *applicationOffers) makeApplicationOffer(doc applicationOfferDoc) (*cros
 		OfferUUID:              doc.OfferUUID,
 		ApplicationName:        doc.ApplicationName,
 		ApplicationDescription: doc.ApplicationDescription,
+		MaxConnections:         doc.MaxConnections,
 	}
 	app, err := s.st.Application(doc.ApplicationName)
 	if err != nil {
@@ -45,6 +45,8 @@ type BlockDeviceInfo struct {
 	InUse          bool     `bson:"inuse"`
 	MountPoint     string   `bson:"mountpoint,omitempty"`
 	SerialId       string   `bson:"serialid,omitempty"`
+	UsedSize       uint64   `bson:"usedsize,omitempty"`
+	FreeSize       uint64   `bson:"freesize,omitempty"`
 }
 
 // WatchBlockDevices returns a new NotifyWatcher watching for
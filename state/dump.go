@@ -5,6 +5,12 @@ package state
 
 import "github.com/juju/errors"
 
+// redactedFields holds, for each collection, the document fields
+// that contain secrets and so are never included in a dump.
+var redactedFields = map[string][]string{
+	volumesC: {"encryptionkey"},
+}
+
 // DumpAll returns a map of collection names to a slice of documents
 // in that collection. Every document that is related to the current
 // model is returned in the map.
@@ -54,6 +60,9 @@ func getAllModelDocs(mb modelBackend, collectionName string) ([]map[string]inter
 	iter := coll.Find(nil).Sort("_id").Iter()
 	defer iter.Close()
 	for iter.Next(&doc) {
+		for _, field := range redactedFields[collectionName] {
+			delete(doc, field)
+		}
 		result = append(result, doc)
 		doc = nil
 	}
@@ -51,6 +51,7 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+	ActionSchedulesC  = actionSchedulesC
 )
 
 var (
@@ -584,9 +585,10 @@ func RemoveEndpointBindingsForApplication(c *gc.C, app *Application) {
 }
 
 func RemoveOfferConnectionsForRelation(c *gc.C, rel *Relation) {
-	removeOps := removeOfferConnectionsForRelationOps(rel.Id())
+	removeOps, err := rel.st.removeOfferConnectionsForRelationOps(rel.Id())
+	c.Assert(err, jc.ErrorIsNil)
 	txnError := rel.st.db().RunTransaction(removeOps)
-	err := onAbort(txnError, nil) // ignore ErrAborted as it asserts DocExists
+	err = onAbort(txnError, nil) // ignore ErrAborted as it asserts DocExists
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -709,6 +711,36 @@ func PrimeActions(c *gc.C, age time.Time, unit *Unit, count int) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// PrimeOperations adds count completed operations to the model, each
+// slightly over 1MB in size, and returns their ids.
+func PrimeOperations(c *gc.C, age time.Time, st *State, count int) []string {
+	operationCollection, closer := st.db().GetCollection(operationsC)
+	defer closer()
+
+	operationCollectionWriter := operationCollection.Writeable()
+
+	const numBytes = 1 * 1000 * 1000
+	var padding [numBytes]byte
+	var operationDocs []interface{}
+	var ids []string
+	for i := 0; i < count; i++ {
+		id, err := jutils.NewUUID()
+		c.Assert(err, jc.ErrorIsNil)
+		ids = append(ids, id.String())
+		operationDocs = append(operationDocs, operationDoc{
+			DocId:     st.docID(id.String()),
+			ModelUUID: st.ModelUUID(),
+			Completed: age,
+			Status:    OperationCompleted,
+			Message:   string(padding[:numBytes]),
+		})
+	}
+
+	err := operationCollectionWriter.Insert(operationDocs...)
+	c.Assert(err, jc.ErrorIsNil)
+	return ids
+}
+
 // GetInternalWorkers returns the internal workers managed by a State
 // to allow inspection in tests.
 func GetInternalWorkers(st *State) worker.Worker {
@@ -47,6 +47,10 @@ type externalControllerDoc struct {
 	// controller's target API server's TLS certificate.
 	CACert string `bson:"cacert"`
 
+	// RelayProxy holds the URL of the proxy through which
+	// connections to the external controller are made, if any.
+	RelayProxy string `bson:"relay-proxy,omitempty"`
+
 	// Models holds model UUIDs hosted on this controller.
 	Models []string `bson:"models"`
 }
@@ -63,6 +67,7 @@ func (rc *externalController) ControllerInfo() crossmodel.ControllerInfo {
 		Alias:         rc.doc.Alias,
 		Addrs:         rc.doc.Addrs,
 		CACert:        rc.doc.CACert,
+		RelayProxy:    rc.doc.RelayProxy,
 	}
 }
 
@@ -91,10 +96,11 @@ func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelU
 		return nil, errors.Trace(err)
 	}
 	doc := externalControllerDoc{
-		Id:     controller.ControllerTag.Id(),
-		Alias:  controller.Alias,
-		Addrs:  controller.Addrs,
-		CACert: controller.CACert,
+		Id:         controller.ControllerTag.Id(),
+		Alias:      controller.Alias,
+		Addrs:      controller.Addrs,
+		CACert:     controller.CACert,
+		RelayProxy: controller.RelayProxy,
 	}
 	buildTxn := func(int) ([]txn.Op, error) {
 		model, err := ec.st.Model()
@@ -112,18 +118,24 @@ func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelU
 		if err == nil {
 			models := set.NewStrings(existing.Models...)
 			models = models.Union(set.NewStrings(modelUUIDs...))
+			fields := bson.D{
+				{"addresses", doc.Addrs},
+				{"alias", doc.Alias},
+				{"cacert", doc.CACert},
+				{"models", models.Values()},
+			}
+			var update bson.D
+			if doc.RelayProxy != "" {
+				fields = append(fields, bson.DocElem{"relay-proxy", doc.RelayProxy})
+				update = bson.D{{"$set", fields}}
+			} else {
+				update = bson.D{{"$set", fields}, {"$unset", bson.D{{"relay-proxy", nil}}}}
+			}
 			ops = []txn.Op{{
 				C:      externalControllersC,
 				Id:     existing.Id,
 				Assert: txn.DocExists,
-				Update: bson.D{
-					{"$set",
-						bson.D{{"addresses", doc.Addrs},
-							{"alias", doc.Alias},
-							{"cacert", doc.CACert},
-							{"models", models.Values()}},
-					},
-				},
+				Update: update,
 			}, model.assertActiveOp()}
 		} else {
 			doc.Models = modelUUIDs
@@ -105,6 +105,33 @@ func (s *externalControllerSuite) TestSaveIdempotent(c *gc.C) {
 	s.assertSavedControllerInfo(c, uuid1)
 }
 
+func (s *externalControllerSuite) TestSaveRelayProxy(c *gc.C) {
+	controllerInfo := crossmodel.ControllerInfo{
+		ControllerTag: testing.ControllerTag,
+		Alias:         "controller-alias",
+		Addrs:         []string{"192.168.1.0:1234", "10.0.0.1:1234"},
+		CACert:        testing.CACert,
+		RelayProxy:    "http://relay.example.com:3128",
+	}
+	uuid1 := utils.MustNewUUID().String()
+	ec, err := s.externalControllers.Save(controllerInfo, uuid1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec.ControllerInfo(), jc.DeepEquals, controllerInfo)
+
+	// Saving without a relay proxy clears it.
+	controllerInfo.RelayProxy = ""
+	ec, err = s.externalControllers.Save(controllerInfo, uuid1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec.ControllerInfo(), jc.DeepEquals, controllerInfo)
+	s.assertSavedControllerInfo(c, uuid1)
+
+	coll, closer := state.GetCollection(s.State, "externalControllers")
+	defer closer()
+	n, err := coll.Find(bson.D{{"relay-proxy", bson.D{{"$exists", true}}}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
 func (s *externalControllerSuite) TestUpdateModels(c *gc.C) {
 	controllerInfo := crossmodel.ControllerInfo{
 		ControllerTag: testing.ControllerTag,
@@ -155,6 +155,10 @@ type FilesystemParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if not empty, is the provider ID of the volume
+	// snapshot from which to create the filesystem's backing volume.
+	SnapshotId string `bson:"snapshot-id,omitempty"`
 }
 
 // FilesystemInfo describes information about a filesystem.
@@ -1084,6 +1088,7 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 			params.volumeInfo,
 			params.Pool,
 			params.Size,
+			params.SnapshotId,
 		}
 		volumeOps, volumeTag, err = sb.addVolumeOps(volumeParams, hostId)
 		if err != nil {
@@ -1305,6 +1310,38 @@ func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetPar
 	}}
 }
 
+// SetFilesystemSize records the size, in MiB, of a provisioned filesystem
+// after it has been resized.
+func (sb *storageBackend) SetFilesystemSize(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size for filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := getFilesystemByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < info.Size {
+			return nil, errors.Errorf(
+				"cannot shrink filesystem from %dMiB to %dMiB",
+				info.Size, size,
+			)
+		}
+		if size == info.Size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"info.size", info.Size}},
+			Update: bson.D{{"$set", bson.D{{"info.size", size}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 // SetFilesystemAttachmentInfo sets the FilesystemAttachmentInfo for the
 // specified filesystem attachment.
 func (sb *storageBackend) SetFilesystemAttachmentInfo(
@@ -1343,6 +1380,15 @@ func (sb *storageBackend) SetFilesystemAttachmentInfo(
 		// and info are mutually exclusive.
 		_, unsetParams := fsa.Params()
 		ops := setFilesystemAttachmentInfoOps(hostTag, filesystemTag, info, unsetParams)
+		if unsetParams {
+			// A storage instance being moved to the filesystem
+			// may now have its contents copied to it.
+			moveOps, err := storageMoveFilesystemMountedOps(sb, filesystemTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, moveOps...)
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
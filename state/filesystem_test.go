@@ -171,6 +171,36 @@ func (s *FilesystemStateSuite) TestSetFilesystemInfoImmutable(c *gc.C) {
 	s.assertFilesystemInfo(c, filesystemTag, filesystemInfoSet)
 }
 
+func (s *FilesystemStateSuite) TestSetFilesystemSize(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	hostTag := s.maybeAssignUnit(c, u)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	if _, ok := hostTag.(names.MachineTag); ok {
+		machine := unitMachine(c, s.st, u)
+		err := machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{Size: 123, FilesystemId: "fs-id"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.SetFilesystemSize(filesystemTag, 100)
+	c.Assert(err, gc.ErrorMatches, `cannot set size for filesystem ".*0/0": cannot shrink filesystem from 123MiB to 100MiB`)
+
+	err = s.storageBackend.SetFilesystemSize(filesystemTag, 456)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemInfo(c, filesystemTag, state.FilesystemInfo{
+		Size: 456, FilesystemId: "fs-id", Pool: "rootfs",
+	})
+}
+
+func (s *FilesystemStateSuite) TestResizeStorageInstanceNoBackingVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	s.maybeAssignUnit(c, u)
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *FilesystemStateSuite) maybeAssignUnit(c *gc.C, u *state.Unit) names.Tag {
 	m, err := s.st.Model()
 	c.Assert(err, jc.ErrorIsNil)
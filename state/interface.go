@@ -104,6 +104,7 @@ type ModelAccessor interface {
 type ControllerAccessor interface {
 	ControllerConfig() (controller.Config, error)
 	ControllerInfo(modelUUID string) (addrs []string, CACert string, _ error)
+	ControllerRelayProxy(modelUUID string) (string, error)
 }
 
 // UnitsWatcher defines the methods needed to retrieve an entity (a
@@ -225,6 +226,10 @@ type Action interface {
 	// Log adds message to the action's progress message array.
 	Log(message string) error
 
+	// LogOutput adds lines written by the action to the given output
+	// stream to the action's message array.
+	LogOutput(stream string, lines []string) error
+
 	// Messages returns the action's progress messages.
 	Messages() []ActionMessage
 }
@@ -1524,13 +1524,16 @@ func (m *Machine) PublicAddress() (corenetwork.Address, error) {
 // maybeGetNewAddress determines if the current address is the most appropriate
 // match, and if not it selects the best from the slice of all available
 // addresses. It returns the new address and a bool indicating if a different
-// one was picked.
+// one was picked. An address of the address family that is not preferred by
+// the model is replaced when one of the preferred family is available, so
+// that changing the preference takes effect on existing machines.
 func maybeGetNewAddress(
 	addr address,
 	providerAddresses,
 	machineAddresses []address,
 	getAddr func([]address) corenetwork.Address,
 	checkScope func(address) bool,
+	family corenetwork.AddressFamilyPreference,
 ) (address, bool) {
 	// For picking the best address, try provider addresses first.
 	var newAddr address
@@ -1546,8 +1549,9 @@ func maybeGetNewAddress(
 	// first. If the stored address is unavailable we also *must* check for
 	// a new address so we do that next. If the original is a machine
 	// address and a provider address is available we want to switch to
-	// that. Finally we check to see if a better match on scope from the
-	// same origin is available.
+	// that. We then check to see if a better match on scope from the
+	// same origin is available. Finally we switch to an address of the
+	// preferred family from the same origin if the original is not one.
 	if addr.Value == "" {
 		return newAddr, newAddr.Value != ""
 	}
@@ -1565,6 +1569,11 @@ func maybeGetNewAddress(
 			return newAddr, checkScope(newAddr)
 		}
 	}
+	if family.Demotes(addr.Value) && !family.Demotes(newAddr.Value) {
+		if addr.Origin == newAddr.Origin && checkScope(newAddr) {
+			return newAddr, true
+		}
+	}
 	return addr, false
 }
 
@@ -1621,7 +1630,9 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPublicAddressOps(
+	providerAddresses []address, machineAddresses []address, family corenetwork.AddressFamilyPreference,
+) ([]txn.Op, *address) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef(
 		"machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v",
@@ -1633,11 +1644,11 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) corenetwork.Address {
-		addr, _ := corenetwork.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := corenetwork.SelectPublicAddressForFamily(networkAddresses(addresses), family)
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope, family)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, nil
@@ -1647,7 +1658,9 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, &newAddr
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPrivateAddressOps(
+	providerAddresses []address, machineAddresses []address, family corenetwork.AddressFamilyPreference,
+) ([]txn.Op, *address) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1656,11 +1669,11 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) corenetwork.Address {
-		addr, _ := corenetwork.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := corenetwork.SelectInternalAddressForFamily(networkAddresses(addresses), false, family)
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope, family)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, nil
@@ -1752,6 +1765,25 @@ func (m *Machine) setAddresses(machineAddresses, providerAddresses *[]corenetwor
 	return nil
 }
 
+// reselectPreferredAddresses re-selects the preferred private and public
+// addresses of all machines in the model from their existing addresses.
+// It is called when the model's preferred address family changes.
+func (st *State) reselectPreferredAddresses() error {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.Life() == Dead {
+			continue
+		}
+		if err := m.setAddresses(nil, nil); err != nil && errors.Cause(err) != ErrDead {
+			return errors.Annotatef(err, "cannot reselect addresses of machine %v", m)
+		}
+	}
+	return nil
+}
+
 func (m *Machine) setAddressesOps(
 	machineAddresses, providerAddresses *[]corenetwork.Address,
 ) (_ []txn.Op, machineStateAddresses, providerStateAddresses []address, newPrivate, newPublic *address, _ error) {
@@ -1760,10 +1792,15 @@ func (m *Machine) setAddressesOps(
 		return nil, nil, nil, nil, nil, ErrDead
 	}
 
+	family, err := m.st.PreferredAddressFamily()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+
 	fromNetwork := func(in []corenetwork.Address, origin Origin) []address {
 		sorted := make([]corenetwork.Address, len(in))
 		copy(sorted, in)
-		corenetwork.SortAddresses(sorted)
+		corenetwork.SortAddressesForFamily(sorted, family)
 		return fromNetworkAddresses(sorted, origin)
 	}
 
@@ -1783,11 +1820,13 @@ func (m *Machine) setAddressesOps(
 		C:      machinesC,
 		Id:     m.doc.DocID,
 		Assert: notDeadDoc,
-		Update: bson.D{{"$set", set}},
 	}}
+	if len(set) > 0 {
+		ops[0].Update = bson.D{{"$set", set}}
+	}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses, family)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses, family)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
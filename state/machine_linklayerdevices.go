@@ -1132,6 +1132,27 @@ func addAddressToResult(networkInfos []network.NetworkInfo, address *Address) ([
 	return append(networkInfos, networkInfo), nil
 }
 
+// defaultSpaceAddresses returns the values of the machine addresses to
+// report for the default space, given its preferred private address.
+// Dual-stack models also get the best internal address of the other
+// address family, if there is one.
+func (m *Machine) defaultSpaceAddresses(privateAddress corenetwork.Address) (set.Strings, error) {
+	values := set.NewStrings(privateAddress.Value)
+	family, err := m.st.PreferredAddressFamily()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if family != corenetwork.DualStack {
+		return values, nil
+	}
+	for _, addr := range corenetwork.SelectInternalAddressesForFamily(m.Addresses(), false, family) {
+		if addr.Type != privateAddress.Type {
+			values.Add(addr.Value)
+		}
+	}
+	return values, nil
+}
+
 // GetNetworkInfoForSpaces returns MachineNetworkInfoResult with a list of devices for each space in spaces
 // TODO(wpk): 2017-05-04 This does not work for L2-only devices as it iterates over addresses, needs to be fixed.
 // When changing the method we have to keep the ordering.
@@ -1140,6 +1161,10 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string]Machine
 
 	var privateAddress corenetwork.Address
 
+	// defaultAddresses holds the values of the addresses reported for the
+	// default space: the preferred private address and, for dual-stack
+	// models, the best internal address of the other family.
+	defaultAddresses := set.NewStrings()
 	if spaces.Contains(corenetwork.DefaultSpaceName) {
 		var err error
 		privateAddress, err = m.PrivateAddress()
@@ -1147,6 +1172,10 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string]Machine
 			results[corenetwork.DefaultSpaceName] = MachineNetworkInfoResult{Error: errors.Annotatef(
 				err, "getting machine %q preferred private address", m.MachineTag())}
 			spaces.Remove(corenetwork.DefaultSpaceName)
+		} else if defaultAddresses, err = m.defaultSpaceAddresses(privateAddress); err != nil {
+			results[corenetwork.DefaultSpaceName] = MachineNetworkInfoResult{Error: errors.Annotatef(
+				err, "getting machine %q default space addresses", m.MachineTag())}
+			spaces.Remove(corenetwork.DefaultSpaceName)
 		}
 	}
 
@@ -1181,7 +1210,7 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string]Machine
 					results[space] = r
 				}
 			}
-			if spaces.Contains(corenetwork.DefaultSpaceName) && privateAddress.Value == addr.Value() {
+			if spaces.Contains(corenetwork.DefaultSpaceName) && defaultAddresses.Contains(addr.Value()) {
 				r := results[corenetwork.DefaultSpaceName]
 				r.NetworkInfos, err = addAddressToResult(r.NetworkInfos, addr)
 				if err != nil {
@@ -1196,11 +1225,12 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string]Machine
 	// For a spaceless model we won't find a subnet that's linked to privateAddress,
 	// we have to work around that and at least return minimal information.
 	if r, ok := results[corenetwork.DefaultSpaceName]; !ok && spaces.Contains(corenetwork.DefaultSpaceName) {
-		r.NetworkInfos = []network.NetworkInfo{{
-			Addresses: []network.InterfaceAddress{{
-				Address: privateAddress.Value,
-			}},
-		}}
+		addresses := []network.InterfaceAddress{{Address: privateAddress.Value}}
+		defaultAddresses.Remove(privateAddress.Value)
+		for _, value := range defaultAddresses.SortedValues() {
+			addresses = append(addresses, network.InterfaceAddress{Address: value})
+		}
+		r.NetworkInfos = []network.NetworkInfo{{Addresses: addresses}}
 		results[corenetwork.DefaultSpaceName] = r
 	}
 	actualSpacesStr := network.QuoteSpaceSet(actualSpaces)
@@ -2000,6 +2000,71 @@ func (s *MachineSuite) TestPrivateAddressSetOnNewMachine(c *gc.C) {
 	c.Assert(addr, jc.DeepEquals, corenetwork.NewAddress("10.0.0.1"))
 }
 
+func (s *MachineSuite) TestPreferredAddressesHonourAddressFamily(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"preferred-address-family": "ipv6"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProviderAddresses(
+		corenetwork.NewAddress("10.0.0.1"),
+		corenetwork.NewAddress("fc00::1"),
+		corenetwork.NewAddress("8.8.8.8"),
+		corenetwork.NewAddress("2001:db8::1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := m.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+	addr, err = m.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+}
+
+func (s *MachineSuite) TestPreferredAddressesReselectedOnAddressFamilyChange(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProviderAddresses(
+		corenetwork.NewAddress("10.0.0.1"),
+		corenetwork.NewAddress("fc00::1"),
+		corenetwork.NewAddress("8.8.8.8"),
+		corenetwork.NewAddress("2001:db8::1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := m.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "10.0.0.1")
+	addr, err = m.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"preferred-address-family": "ipv6"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = m.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+	addr, err = m.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+
+	// Setting the same addresses again keeps the new selection.
+	err = m.SetProviderAddresses(
+		corenetwork.NewAddress("10.0.0.1"),
+		corenetwork.NewAddress("fc00::1"),
+		corenetwork.NewAddress("8.8.8.8"),
+		corenetwork.NewAddress("2001:db8::1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = m.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+}
+
 func (s *MachineSuite) TestPublicAddressEmptyAddresses(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -173,6 +173,10 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if err := export.actionSchedules(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if err := export.cloudimagemetadata(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -496,6 +500,11 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 		// Don't bother including a subnet if there are no ports open on it.
 		if doc.MachineID == machineId && len(doc.Ports) > 0 {
 			args := description.OpenedPortsArgs{SubnetID: doc.SubnetID}
+			// The endpoints that port ranges are opened for can't be
+			// represented in the model description, so they are
+			// exported as opened for all endpoints. Applications with
+			// expose settings, the only thing endpoints affect, are
+			// rejected by the migration precheck.
 			for _, p := range doc.Ports {
 				args.OpenedPorts = append(args.OpenedPorts, description.PortRangeArgs{
 					UnitName: p.UnitName,
@@ -578,6 +587,11 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
+	unitStates, err := e.readAllUnitStates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	bindings, err := e.readAllEndpointBindings()
 	if err != nil {
 		return errors.Trace(err)
@@ -627,6 +641,7 @@ func (e *exporter) applications() error {
 			application:      application,
 			units:            applicationUnits,
 			meterStatus:      meterStatus,
+			unitStates:       unitStates,
 			podSpecs:         podSpecs,
 			cloudServices:    cloudServices,
 			cloudContainers:  cloudContainers,
@@ -695,6 +710,7 @@ type addApplicationContext struct {
 	application      *Application
 	units            []*Unit
 	meterStatus      map[string]*meterStatusDoc
+	unitStates       map[string]map[string]string
 	leader           string
 	payloads         map[string][]payload.FullPayloadInfo
 	resources        resource.ApplicationResources
@@ -905,6 +921,9 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 			e.statusHistoryArgs(globalCCKey)
 		}
 		exUnit.SetAnnotations(e.getAnnotations(globalKey))
+		if unitState, found := ctx.unitStates[globalKey]; found {
+			exUnit.SetCharmState(unitState)
+		}
 
 		constraintsArgs, err := e.constraintsArgs(agentKey)
 		if err != nil {
@@ -1308,6 +1327,40 @@ func (e *exporter) actions() error {
 	return nil
 }
 
+func (e *exporter) actionSchedules() error {
+	if e.cfg.SkipActions {
+		return nil
+	}
+
+	m, err := e.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	schedules, err := m.ActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d action schedules", len(schedules))
+	for _, schedule := range schedules {
+		e.model.AddActionSchedule(description.ActionScheduleArgs{
+			Name:            schedule.doc.Name,
+			Receiver:        schedule.doc.Receiver,
+			ActionName:      schedule.doc.ActionName,
+			Parameters:      schedule.doc.Parameters,
+			Cron:            schedule.doc.Cron,
+			MissedRunPolicy: string(schedule.doc.MissedRunPolicy),
+			OverlapPolicy:   string(schedule.doc.OverlapPolicy),
+			Created:         schedule.doc.Created,
+			NextRun:         schedule.doc.NextRun,
+			LastRun:         schedule.doc.LastRun,
+			LastActions:     schedule.LastActions(),
+			LastMessage:     schedule.doc.LastMessage,
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.db().GetCollection(relationScopesC)
 	defer closer()
@@ -1382,6 +1435,22 @@ func (e *exporter) readAllMeterStatus() (map[string]*meterStatusDoc, error) {
 	return result, nil
 }
 
+func (e *exporter) readAllUnitStates() (map[string]map[string]string, error) {
+	unitStates, closer := e.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var docs []unitStateDoc
+	if err := unitStates.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all unit state docs")
+	}
+	e.logger.Debugf("found %d unit state docs", len(docs))
+	result := make(map[string]map[string]string)
+	for _, doc := range docs {
+		result[e.st.localID(doc.DocID)] = unescapeStateKeys(doc.State)
+	}
+	return result, nil
+}
+
 func (e *exporter) readAllPodSpecs() (map[string]string, error) {
 	specs, closer := e.st.db().GetCollection(podSpecsC)
 	defer closer()
@@ -1884,6 +1953,12 @@ func (e *exporter) volumes() error {
 }
 
 func (e *exporter) addVolume(vol *volume, volAttachments []volumeAttachmentDoc, attachmentPlans []volumeAttachmentPlanDoc) error {
+	if vol.Encrypted() {
+		// The encryption key is not part of the model description,
+		// so the volume's contents would be unreadable after the
+		// migration.
+		return errors.NotSupportedf("migrating encrypted %s", names.ReadableString(vol.VolumeTag()))
+	}
 	args := description.VolumeArgs{
 		Tag: vol.VolumeTag(),
 	}
@@ -2155,6 +2230,12 @@ func (e *exporter) storageInstances() error {
 }
 
 func (e *exporter) addStorage(instance *storageInstance, attachments []names.UnitTag) error {
+	if _, ok := instance.Move(); ok {
+		// The volume that the storage is being moved to is not
+		// associated with the storage, so the move could not be
+		// completed after the migration.
+		return errors.NotSupportedf("migrating storage %q while it is being moved", instance.StorageTag().Id())
+	}
 	owner, ok := instance.Owner()
 	if !ok {
 		owner = nil
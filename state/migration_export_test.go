@@ -628,6 +628,49 @@ func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	s.assertMigrateUnits(c, s.State)
 }
 
+func (s *MigrationExportSuite) TestUnitState(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetState(map[string]string{"seen.peers": "2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units := model.Applications()[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].CharmState(), jc.DeepEquals, map[string]string{"seen.peers": "2"})
+}
+
+func (s *MigrationExportSuite) TestActionSchedules(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "nightly",
+		Receiver:   app.ApplicationTag(),
+		ActionName: "snapshot",
+		Cron:       "0 2 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	schedules := model.ActionSchedules()
+	c.Assert(schedules, gc.HasLen, 1)
+	exported := schedules[0]
+	c.Check(exported.Name(), gc.Equals, "nightly")
+	c.Check(exported.Receiver(), gc.Equals, app.ApplicationTag().String())
+	c.Check(exported.ActionName(), gc.Equals, "snapshot")
+	c.Check(exported.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	c.Check(exported.Cron(), gc.Equals, "0 2 * * *")
+	c.Check(exported.MissedRunPolicy(), gc.Equals, "skip")
+	c.Check(exported.OverlapPolicy(), gc.Equals, "skip")
+	c.Check(exported.Created().Equal(schedule.Created()), jc.IsTrue)
+	c.Check(exported.NextRun().Equal(schedule.NextRun()), jc.IsTrue)
+
+	model, err = s.State.ExportPartial(state.ExportConfig{SkipActions: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.ActionSchedules(), gc.HasLen, 0)
+}
+
 func (s *MigrationExportSuite) TestCAASUnits(c *gc.C) {
 	caasSt := s.Factory.MakeCAASModel(c, nil)
 	s.AddCleanup(func(_ *gc.C) { caasSt.Close() })
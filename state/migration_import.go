@@ -166,6 +166,9 @@ func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err
 	if err := restore.actions(); err != nil {
 		return nil, nil, errors.Annotate(err, "actions")
 	}
+	if err := restore.actionSchedules(); err != nil {
+		return nil, nil, errors.Annotate(err, "action schedules")
+	}
 
 	if err := restore.modelUsers(); err != nil {
 		return nil, nil, errors.Annotate(err, "modelUsers")
@@ -1017,6 +1020,11 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 			return errors.Trace(err)
 		}
 	}
+	if unitState := u.CharmState(); len(unitState) > 0 {
+		if err := unit.SetState(unitState); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if err := i.importStatusHistory(unit.globalKey(), u.WorkloadStatusHistory()); err != nil {
 		return errors.Trace(err)
 	}
@@ -1547,6 +1555,47 @@ func (i *importer) actions() error {
 	return nil
 }
 
+func (i *importer) actionSchedules() error {
+	i.logger.Debugf("importing action schedules")
+	var ops []txn.Op
+	for _, schedule := range i.model.ActionSchedules() {
+		// The next run time is carried over so that the migration
+		// neither repeats nor misses a run; any run due while the
+		// model was migrating is handled by the schedule's missed
+		// run policy.
+		doc := &actionScheduleDoc{
+			DocId:           i.st.docID(schedule.Name()),
+			ModelUUID:       i.st.ModelUUID(),
+			Name:            schedule.Name(),
+			Receiver:        schedule.Receiver(),
+			ActionName:      schedule.ActionName(),
+			Parameters:      schedule.Parameters(),
+			Cron:            schedule.Cron(),
+			MissedRunPolicy: ActionMissedRunPolicy(schedule.MissedRunPolicy()),
+			OverlapPolicy:   ActionOverlapPolicy(schedule.OverlapPolicy()),
+			Created:         schedule.Created(),
+			NextRun:         schedule.NextRun(),
+			LastRun:         schedule.LastRun(),
+			LastActions:     schedule.LastActions(),
+			LastMessage:     schedule.LastMessage(),
+		}
+		ops = append(ops, txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := i.st.db().RunTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing action schedules succeeded")
+	return nil
+}
+
 func (i *importer) addAction(action description.Action) error {
 	modelUUID := i.st.ModelUUID()
 	newDoc := &actionDoc{
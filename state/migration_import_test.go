@@ -1098,6 +1098,20 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestUnitState(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetState(map[string]string{"seen.peers": "2", "leader": "no"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+
+	imported, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	state, err := imported.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, map[string]string{"seen.peers": "2", "leader": "no"})
+}
+
 func (s *MigrationImportSuite) TestSpaces(c *gc.C) {
 	space := s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
@@ -1458,6 +1472,39 @@ func (s *MigrationImportSuite) TestAction(c *gc.C) {
 	c.Check(action.Status(), gc.Equals, state.ActionPending)
 }
 
+func (s *MigrationImportSuite) TestActionSchedules(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	original, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:          "nightly",
+		Receiver:      app.ApplicationTag(),
+		ActionName:    "snapshot",
+		Cron:          "0 2 * * *",
+		OverlapPolicy: state.OverlapQueue,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt := s.importModel(c, s.State)
+	defer func() {
+		c.Assert(newSt.Close(), jc.ErrorIsNil)
+	}()
+
+	schedules, err := newModel.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	schedule := schedules[0]
+	c.Check(schedule.Name(), gc.Equals, "nightly")
+	c.Check(schedule.ActionName(), gc.Equals, "snapshot")
+	c.Check(schedule.Cron(), gc.Equals, "0 2 * * *")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	c.Check(schedule.MissedRunPolicy(), gc.Equals, state.MissedRunSkip)
+	c.Check(schedule.OverlapPolicy(), gc.Equals, state.OverlapQueue)
+	c.Check(schedule.Created().Equal(original.Created()), jc.IsTrue)
+	c.Check(schedule.NextRun().Equal(original.NextRun()), jc.IsTrue)
+	receiver, err := schedule.Receiver()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(receiver, gc.Equals, app.ApplicationTag())
+}
+
 func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Volumes: []state.HostVolumeParams{{
@@ -42,6 +42,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		applicationsC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		unitStatesC,
 		payloadsC,
 		"resources",
 
@@ -64,6 +65,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// actions
 		actionsC,
+		actionSchedulesC,
 
 		// storage
 		filesystemsC,
@@ -167,6 +169,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// TODO(actions): export operations once the model
+		// description can represent them. Until then, a migrated
+		// operation's actions are migrated on their own, and any
+		// actions it has yet to enqueue are dropped.
+		operationsC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -199,6 +207,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// Resources are transferred separately
 		"storedResources",
+
+		// Hook execution timings are diagnostic only; units start
+		// recording them afresh after migration.
+		unitHookExecutionsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -388,6 +400,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedEndpoints is not migrated; migration prechecks refuse
+		// applications with endpoint expose settings.
+		"ExposedEndpoints",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -417,6 +432,8 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Application",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// Paused is not migrated; migration prechecks refuse paused units.
+		"Paused",
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
@@ -464,6 +481,18 @@ func (s *MigrationSuite) TestMeterStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, meterStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestUnitStateDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"State",
+	)
+	s.AssertExportedFields(c, unitStateDoc{}, fields)
+}
+
 func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 	fields := set.NewStrings(
 		// DocID itself isn't migrated
@@ -631,7 +660,13 @@ func (s *MigrationSuite) TestBlockDeviceFields(c *gc.C) {
 		"MountPoint",
 		"SerialId",
 	)
-	s.AssertExportedFields(c, BlockDeviceInfo{}, migrated)
+	// Usage is reported again by the machine agent
+	// once the model has been migrated.
+	ignored = set.NewStrings(
+		"UsedSize",
+		"FreeSize",
+	)
+	s.AssertExportedFields(c, BlockDeviceInfo{}, migrated.Union(ignored))
 }
 
 func (s *MigrationSuite) TestSubnetDocFields(c *gc.C) {
@@ -712,6 +747,8 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Operations are not migrated.
+		"Operation",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -729,6 +766,30 @@ func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	s.AssertExportedFields(c, actionDoc{}, migrated.Union(ignored))
 }
 
+func (s *MigrationSuite) TestActionScheduleDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		// DocId and TxnRevno are recreated on import.
+		"DocId",
+		"TxnRevno",
+	)
+	migrated := set.NewStrings(
+		"Name",
+		"Receiver",
+		"ActionName",
+		"Parameters",
+		"Cron",
+		"MissedRunPolicy",
+		"OverlapPolicy",
+		"Created",
+		"NextRun",
+		"LastRun",
+		"LastActions",
+		"LastMessage",
+	)
+	s.AssertExportedFields(c, actionScheduleDoc{}, migrated.Union(ignored))
+}
+
 func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
@@ -736,6 +797,12 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"Life",
 		"HostId",    // recreated from pool properties
 		"Releasing", // only when dying; can't migrate dying storage
+		// RequestedSize is not migrated: an outstanding resize
+		// request is dropped, and may be made again in the
+		// target model.
+		"RequestedSize",
+		// Models with encrypted volumes cannot be migrated.
+		"EncryptionKey",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -748,8 +815,11 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	// The info and params fields ar structs.
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "WWN", "Size", "Pool", "VolumeId", "Persistent"))
+	// SnapshotId is not migrated: it is only used until the
+	// storage is provisioned, and unprovisioned storage is
+	// created empty in the target model.
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "SnapshotId"))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -792,8 +862,11 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 	// The info and params fields ar structs.
 	s.AssertExportedFields(c, FilesystemInfo{}, set.NewStrings(
 		"Size", "Pool", "FilesystemId"))
+	// SnapshotId is not migrated: it is only used until the
+	// storage is provisioned, and unprovisioned storage is
+	// created empty in the target model.
 	s.AssertExportedFields(c, FilesystemParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "SnapshotId"))
 }
 
 func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
@@ -822,6 +895,9 @@ func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
 		"DocID",
 		"Life",
 		"Releasing", // only when dying; can't migrate dying storage
+		// Models with storage being moved between pools
+		// cannot be migrated.
+		"Move",
 	)
 	migrated := set.NewStrings(
 		"Id",
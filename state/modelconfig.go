@@ -9,6 +9,7 @@ import (
 	"github.com/juju/version"
 
 	"github.com/juju/juju/controller"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 )
@@ -39,6 +40,16 @@ func (m *Model) AgentVersion() (version.Number, error) {
 	return ver, nil
 }
 
+// PreferredAddressFamily returns the IP address family preferred by the
+// model config when selecting machine addresses.
+func (st *State) PreferredAddressFamily() (corenetwork.AddressFamilyPreference, error) {
+	cfg, err := getModelConfig(st.db(), st.ModelUUID())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.PreferredAddressFamily(), nil
+}
+
 func getModelConfig(db Database, uuid string) (*config.Config, error) {
 	modelSettings, err := readSettings(db, settingsC, modelGlobalKey)
 	if err != nil {
@@ -367,7 +378,16 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if err := modelSettings.write(ops); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Machines keep their preferred addresses unless a better match is
+	// found, so they need to be re-selected for a new address family.
+	if validCfg.PreferredAddressFamily() != oldConfig.PreferredAddressFamily() {
+		return errors.Trace(st.reselectPreferredAddresses())
+	}
+	return nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 )
@@ -84,6 +85,30 @@ type generationDoc struct {
 
 	// CompletedBy is the user who committed this generation to the model.
 	CompletedBy string `bson:"completed-by"`
+
+	// PromotionPolicy, if set, describes the conditions under which this
+	// generation is automatically committed or aborted.
+	PromotionPolicy *promotionPolicyDoc `bson:"promotion-policy,omitempty"`
+}
+
+// promotionPolicyDoc is the state representation
+// of a core model branch PromotionPolicy.
+type promotionPolicyDoc struct {
+	HealthyFor time.Duration `bson:"healthy-for"`
+	ActionName string        `bson:"action"`
+	OnError    string        `bson:"on-error"`
+
+	// Set is a Unix timestamp indicating when the policy was set.
+	Set int64 `bson:"set"`
+}
+
+// corePolicy returns the core package representation of this policy.
+func (p *promotionPolicyDoc) corePolicy() model.PromotionPolicy {
+	return model.PromotionPolicy{
+		HealthyFor: p.HealthyFor,
+		ActionName: p.ActionName,
+		OnError:    model.BranchErrorPolicy(p.OnError),
+	}
 }
 
 // Generation represents the state of a model generation.
@@ -149,6 +174,91 @@ func (g *Generation) CompletedBy() string {
 	return g.doc.CompletedBy
 }
 
+// PromotionPolicy returns the policy used to automatically commit or abort
+// the generation, and a boolean indicating whether such a policy is set.
+func (g *Generation) PromotionPolicy() (model.PromotionPolicy, bool) {
+	if g.doc.PromotionPolicy == nil {
+		return model.PromotionPolicy{}, false
+	}
+	return g.doc.PromotionPolicy.corePolicy(), true
+}
+
+// PromotionPolicySet returns the Unix timestamp at which the current
+// promotion policy was set, or zero if there is no policy.
+func (g *Generation) PromotionPolicySet() int64 {
+	if g.doc.PromotionPolicy == nil {
+		return 0
+	}
+	return g.doc.PromotionPolicy.Set
+}
+
+// SetPromotionPolicy attaches the input policy to the generation,
+// replacing any that was previously set.
+func (g *Generation) SetPromotionPolicy(policy model.PromotionPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := &promotionPolicyDoc{
+		HealthyFor: policy.HealthyFor,
+		ActionName: policy.ActionName,
+		OnError:    string(policy.OnError),
+		Set:        g.st.clock().Now().Unix(),
+	}
+	return errors.Trace(g.updatePromotionPolicy(bson.D{{"$set", bson.D{{"promotion-policy", doc}}}}))
+}
+
+// ClearPromotionPolicy removes any promotion policy from the generation,
+// meaning that it must be committed or aborted manually.
+func (g *Generation) ClearPromotionPolicy() error {
+	return errors.Trace(g.updatePromotionPolicy(bson.D{{"$unset", bson.D{{"promotion-policy", nil}}}}))
+}
+
+func (g *Generation) updatePromotionPolicy(update bson.D) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{"txn-revno", g.doc.TxnRevno}},
+			}}},
+			Update: update,
+		}}, nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// RecordPromotionDecision writes an entry to the model's status history,
+// describing a decision made by the generation's promotion policy.
+// The model's current status is not changed.
+func (g *Generation) RecordPromotionDecision(decision, reason string) error {
+	modelStatus, err := getStatus(g.st.db(), modelGlobalKey, "model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := g.st.clock().Now()
+	doc := statusDoc{
+		Status:     modelStatus.Status,
+		StatusInfo: fmt.Sprintf("branch %q promotion policy: %s (%s)", g.doc.Name, decision, reason),
+		StatusData: utils.EscapeKeys(map[string]interface{}{
+			"branch":   g.doc.Name,
+			"decision": decision,
+			"reason":   reason,
+		}),
+		Updated: now.UnixNano(),
+	}
+	_, err = probablyUpdateStatusHistory(g.st.db(), modelGlobalKey, doc)
+	return errors.Trace(err)
+}
+
 // AssignApplication indicates that the application with the input name has had
 // changes in this generation.
 func (g *Generation) AssignApplication(appName string) error {
@@ -562,6 +672,42 @@ func (g *Generation) IsTracking(unitName string) bool {
 	return tracked
 }
 
+// UnassignUnits stops the units with the input names from tracking
+// the generation. Units that are not tracking it are ignored.
+func (g *Generation) UnassignUnits(unitNames []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for _, unitName := range unitNames {
+			if !g.IsTracking(unitName) {
+				continue
+			}
+			appName, err := names.UnitApplication(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, g.unassignUnitOps(unitName, appName)...)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	// Refresh so that subsequent operations on this generation,
+	// such as aborting it, see the updated assigned units.
+	return errors.Trace(g.Refresh())
+}
+
 func (g *Generation) unassignUnitOps(unitName, appName string) []txn.Op {
 	assignedField := "assigned-units"
 	appField := fmt.Sprintf("%s.%s", assignedField, appName)
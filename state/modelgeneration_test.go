@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -291,6 +292,93 @@ func (s *generationSuite) TestAbortCommittedBranch(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "branch was already committed")
 }
 
+func (s *generationSuite) TestAbortAfterUnassignUnits(c *gc.C) {
+	s.setupTestingClock(c)
+
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnit("riak/1"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	// Units not tracking the branch are ignored.
+	c.Assert(gen.UnassignUnits([]string{"riak/0", "riak/1", "riak/2"}), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestSetPromotionPolicy(c *gc.C) {
+	clock := testclock.NewClock(testing.NonZeroTime())
+	c.Assert(s.State.SetClockForTesting(clock), jc.ErrorIsNil)
+	gen := s.addBranch(c)
+
+	_, ok := gen.PromotionPolicy()
+	c.Check(ok, jc.IsFalse)
+	c.Check(gen.PromotionPolicySet(), gc.Equals, int64(0))
+	clock.Advance(time.Hour)
+
+	policy := model.PromotionPolicy{
+		HealthyFor: 10 * time.Minute,
+		ActionName: "smoke-test",
+		OnError:    model.BranchErrorUntrack,
+	}
+	c.Assert(gen.SetPromotionPolicy(policy), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	stored, ok := gen.PromotionPolicy()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(stored, gc.DeepEquals, policy)
+	c.Check(gen.PromotionPolicySet(), gc.Equals, clock.Now().Unix())
+
+	c.Assert(gen.ClearPromotionPolicy(), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, ok = gen.PromotionPolicy()
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *generationSuite) TestSetPromotionPolicyInvalid(c *gc.C) {
+	gen := s.addBranch(c)
+
+	err := gen.SetPromotionPolicy(model.PromotionPolicy{OnError: model.BranchErrorAbort})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *generationSuite) TestSetPromotionPolicyCompletedError(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.addBranch(c)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	err := gen.SetPromotionPolicy(model.PromotionPolicy{
+		HealthyFor: time.Minute,
+		OnError:    model.BranchErrorAbort,
+	})
+	c.Assert(err, gc.ErrorMatches, "branch was already aborted")
+}
+
+func (s *generationSuite) TestRecordPromotionDecision(c *gc.C) {
+	gen := s.addBranch(c)
+
+	c.Assert(gen.RecordPromotionDecision("commit", "all units active for 10m0s"), jc.ErrorIsNil)
+
+	history, err := s.Model.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Status, gc.Equals, status.Available)
+	c.Check(history[0].Message, gc.Equals,
+		`branch "new-branch" promotion policy: commit (all units active for 10m0s)`)
+	c.Check(history[0].Data, jc.DeepEquals, map[string]interface{}{
+		"branch":   newBranchName,
+		"decision": "commit",
+		"reason":   "all units active for 10m0s",
+	})
+
+	// The model status itself is unchanged.
+	modelStatus, err := s.Model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modelStatus.Message, gc.Equals, "")
+}
+
 func (s *generationSuite) TestBranchCharmConfigDeltas(c *gc.C) {
 	gen := s.setupAssignAllUnits(c)
 	c.Assert(gen.Config(), gc.HasLen, 0)
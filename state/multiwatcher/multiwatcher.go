@@ -517,19 +517,29 @@ type ItemChange struct {
 	NewValue interface{} `json:"new,omitempty"`
 }
 
+// PromotionPolicy is the multiwatcher representation
+// of a model branch promotion policy.
+type PromotionPolicy struct {
+	HealthyFor time.Duration `json:"healthy-for"`
+	ActionName string        `json:"action"`
+	OnError    string        `json:"on-error"`
+	Set        int64         `json:"set"`
+}
+
 // GenerationInfo holds data about a model generation (branch)
 // that is tracked by multiwatcherStore.
 type GenerationInfo struct {
-	ModelUUID     string                  `json:"model-uuid"`
-	Id            string                  `json:"id"`
-	Name          string                  `json:"name"`
-	AssignedUnits map[string][]string     `json:"assigned-units"`
-	Config        map[string][]ItemChange `json:"charm-config"`
-	Created       int64                   `json:"created"`
-	CreatedBy     string                  `json:"created-by"`
-	Completed     int64                   `json:"completed"`
-	CompletedBy   string                  `json:"completed-by"`
-	GenerationId  int                     `json:"generation-id"`
+	ModelUUID       string                  `json:"model-uuid"`
+	Id              string                  `json:"id"`
+	Name            string                  `json:"name"`
+	AssignedUnits   map[string][]string     `json:"assigned-units"`
+	Config          map[string][]ItemChange `json:"charm-config"`
+	Created         int64                   `json:"created"`
+	CreatedBy       string                  `json:"created-by"`
+	Completed       int64                   `json:"completed"`
+	CompletedBy     string                  `json:"completed-by"`
+	GenerationId    int                     `json:"generation-id"`
+	PromotionPolicy *PromotionPolicy        `json:"promotion-policy,omitempty"`
 }
 
 // EntityId returns a unique identifier for a generation.
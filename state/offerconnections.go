@@ -64,13 +64,39 @@ func (oc *OfferConnection) RelationKey() string {
 	return oc.doc.RelationKey
 }
 
-func removeOfferConnectionsForRelationOps(relId int) []txn.Op {
-	op := txn.Op{
+// removeOfferConnectionsForRelationOps returns the operations needed to
+// remove the offer connection for the relation, if there is one, and to
+// decrement the connection count of the offer it connects to.
+func (st *State) removeOfferConnectionsForRelationOps(relId int) ([]txn.Op, error) {
+	offerConnections, closer := st.db().GetCollection(offerConnectionsC)
+	defer closer()
+
+	var connDoc offerConnectionDoc
+	err := offerConnections.FindId(fmt.Sprintf("%d", relId)).One(&connDoc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer connection for relation %d", relId)
+	}
+	ops := []txn.Op{{
 		C:      offerConnectionsC,
-		Id:     fmt.Sprintf("%d", relId),
+		Id:     connDoc.DocID,
+		Assert: txn.DocExists,
 		Remove: true,
+	}}
+	offers := &applicationOffers{st: st}
+	offerDoc, err := offers.offerQuery(bson.D{{"offer-uuid", connDoc.OfferUUID}})
+	if err == mgo.ErrNotFound {
+		return ops, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot load application offer %q", connDoc.OfferUUID)
 	}
-	return []txn.Op{op}
+	return append(ops, txn.Op{
+		C:      applicationOffersC,
+		Id:     offerDoc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"connection-count", -1}}}},
+	}), nil
 }
 
 // String returns the details of the connection.
@@ -140,7 +166,9 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
-			return nil, errors.AlreadyExistsf("offer connection for relation id %d", args.RelationId)
+			if _, err := st.OfferConnectionForRelation(args.RelationKey); err == nil {
+				return nil, errors.AlreadyExistsf("offer connection for relation id %d", args.RelationId)
+			}
 		}
 		ops := []txn.Op{
 			model.assertActiveOp(),
@@ -151,6 +179,11 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 				Insert: &offerConnectionDoc,
 			},
 		}
+		countOps, err := st.incOfferConnectionCountOps(args.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, countOps...)
 		return ops, nil
 	}
 	if err = st.db().Run(buildTxn); err != nil {
@@ -159,6 +192,54 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 	return &OfferConnection{doc: offerConnectionDoc}, nil
 }
 
+// CheckOfferConnectionLimit returns an error satisfying
+// errors.IsQuotaLimitExceeded if the offer with the given UUID already
+// has the maximum number of connections it allows. The limit is still
+// enforced when the connection is added; this allows callers to reject
+// a connection before creating the entities it relates.
+func (st *State) CheckOfferConnectionLimit(offerUUID string) error {
+	_, err := st.incOfferConnectionCountOps(offerUUID)
+	return errors.Trace(err)
+}
+
+// incOfferConnectionCountOps returns the operations needed to record a
+// new connection to the offer, ensuring that the connection does not
+// exceed the offer's connection limit. An error satisfying
+// errors.IsQuotaLimitExceeded is returned if the offer is already at
+// its limit.
+func (st *State) incOfferConnectionCountOps(offerUUID string) ([]txn.Op, error) {
+	offers := &applicationOffers{st: st}
+	offerDoc, err := offers.offerQuery(bson.D{{"offer-uuid", offerUUID}})
+	if err == mgo.ErrNotFound {
+		// Connections for offers which have since been removed
+		// are still recorded so that they can be cleaned up.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot load application offer %q", offerUUID)
+	}
+	incOp := txn.Op{
+		C:      applicationOffersC,
+		Id:     offerDoc.DocID,
+		Update: bson.D{{"$inc", bson.D{{"connection-count", 1}}}},
+	}
+	if offerDoc.MaxConnections == 0 {
+		incOp.Assert = bson.D{{"max-connections", bson.D{{"$exists", false}}}}
+		return []txn.Op{incOp}, nil
+	}
+	if offerDoc.ConnectionCount >= offerDoc.MaxConnections {
+		return nil, errors.QuotaLimitExceededf(
+			"offer %q already has the maximum of %d connections", offerDoc.OfferName, offerDoc.MaxConnections)
+	}
+	incOp.Assert = bson.D{
+		{"max-connections", offerDoc.MaxConnections},
+		{"$or", []bson.D{
+			{{"connection-count", bson.D{{"$exists", false}}}},
+			{{"connection-count", bson.D{{"$lt", offerDoc.MaxConnections}}}},
+		}},
+	}
+	return []txn.Op{incOp}, nil
+}
+
 // OfferConnections returns the offer connections for an offer.
 func (st *State) OfferConnections(offerUUID string) (conns []*OfferConnection, err error) {
 	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -114,6 +115,138 @@ func (s *offerConnectionsSuite) TestAddOfferConnectionTwice(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxConnections(c *gc.C) {
+	offers := state.NewApplicationOffers(s.State)
+	offer, err := offers.AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+		MaxConnections:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.MaxConnections, gc.Equals, 1)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "mary",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" already has the maximum of 1 connections`)
+
+	// Removing the limit allows further connections.
+	_, err = offers.UpdateOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "mary",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerConnectionsSuite) addLimitedOffer(c *gc.C, maxConnections int) *crossmodel.ApplicationOffer {
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+		MaxConnections:  maxConnections,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return offer
+}
+
+func (s *offerConnectionsSuite) TestCheckOfferConnectionLimit(c *gc.C) {
+	offer := s.addLimitedOffer(c, 1)
+
+	err := s.State.CheckOfferConnectionLimit(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckOfferConnectionLimit(offer.OfferUUID)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(err, gc.ErrorMatches, `offer "hosted-mysql" already has the maximum of 1 connections`)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxConnectionsConcurrent(c *gc.C) {
+	offer := s.addLimitedOffer(c, 1)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+			SourceModelUUID: testing.ModelTag.Id(),
+			RelationId:      s.activeRel.Id(),
+			RelationKey:     s.activeRel.Tag().Id(),
+			Username:        "fred",
+			OfferUUID:       offer.OfferUUID,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "mary",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *offerConnectionsSuite) TestRemoveRelationReleasesOfferConnection(c *gc.C) {
+	offer := s.addLimitedOffer(c, 1)
+
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.activeRel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.OfferConnectionForRelation(s.activeRel.Tag().Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "mary",
+		OfferUUID:       offer.OfferUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *offerConnectionsSuite) TestOfferConnectionForRelation(c *gc.C) {
 	oc, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
 		SourceModelUUID: testing.ModelTag.Id(),
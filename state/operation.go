@@ -0,0 +1,417 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// OperationStatus represents the state of an operation.
+type OperationStatus string
+
+const (
+	// OperationRunning indicates that the operation still has actions
+	// which are queued, pending or running.
+	OperationRunning OperationStatus = "running"
+
+	// OperationCompleted indicates that every action in the operation
+	// completed successfully.
+	OperationCompleted OperationStatus = "completed"
+
+	// OperationFailed indicates that every action in the operation
+	// finished, and at least one of them failed.
+	OperationFailed OperationStatus = "failed"
+
+	// OperationAborted indicates that an action failed in an operation
+	// which stops on failure, so no further actions will be started.
+	OperationAborted OperationStatus = "aborted"
+)
+
+// OperationArgs holds the parameters for enqueueing an operation.
+type OperationArgs struct {
+	// ActionName is the name of the action to run on each unit.
+	ActionName string
+
+	// Parameters are the action's parameters for the units of each
+	// application, keyed by application name, with defaults from the
+	// application's charm already inserted.
+	Parameters map[string]map[string]interface{}
+
+	// Receivers are the units to run the action on, in the order in
+	// which the actions should be started.
+	Receivers []names.UnitTag
+
+	// Last, if set, is a unit whose action is only started once every
+	// other action in the operation has finished.
+	Last *names.UnitTag
+
+	// Parallel is the maximum number of the operation's actions which
+	// may be pending or running at once. Zero means no limit.
+	Parallel int
+
+	// StopOnFailure, if true, means no further actions are started
+	// once any action in the operation fails.
+	StopOnFailure bool
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args OperationArgs) Validate() error {
+	if args.ActionName == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if len(args.Receivers) == 0 && args.Last == nil {
+		return errors.NotValidf("operation without receivers")
+	}
+	if args.Parallel < 0 {
+		return errors.NotValidf("parallel %d", args.Parallel)
+	}
+	return nil
+}
+
+// operationDoc records a group of actions, one per unit, which are run
+// according to a common policy.
+type operationDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	TxnRevno  int64  `bson:"txn-revno"`
+
+	ActionName    string                            `bson:"action-name"`
+	Parameters    map[string]map[string]interface{} `bson:"parameters"`
+	Parallel      int                               `bson:"parallel"`
+	StopOnFailure bool                              `bson:"stop-on-failure"`
+
+	// Queue holds the names of the units whose actions have yet to be
+	// enqueued, in order.
+	Queue []string `bson:"queue"`
+
+	// Last holds the name of the unit whose action is enqueued only
+	// once all of the other actions have finished.
+	Last string `bson:"last,omitempty"`
+
+	// Outstanding is the number of the operation's actions which have
+	// been enqueued and have not yet finished.
+	Outstanding int `bson:"outstanding"`
+
+	// Failed is the number of the operation's actions which failed.
+	Failed int `bson:"failed"`
+
+	Status    OperationStatus `bson:"status"`
+	Message   string          `bson:"message"`
+	Enqueued  time.Time       `bson:"enqueued"`
+	Completed time.Time       `bson:"completed"`
+}
+
+// Operation is a group of actions run across a number of units,
+// subject to a concurrency limit and failure policy.
+type Operation struct {
+	st  *State
+	doc operationDoc
+}
+
+// Id returns the operation's id.
+func (op *Operation) Id() string {
+	return op.st.localID(op.doc.DocId)
+}
+
+// ActionName returns the name of the action run by the operation.
+func (op *Operation) ActionName() string {
+	return op.doc.ActionName
+}
+
+// Parallel returns the maximum number of the operation's actions that
+// may be outstanding at once, or zero if there is no limit.
+func (op *Operation) Parallel() int {
+	return op.doc.Parallel
+}
+
+// StopOnFailure reports whether the operation stops starting actions
+// once one has failed.
+func (op *Operation) StopOnFailure() bool {
+	return op.doc.StopOnFailure
+}
+
+// Queued returns the names of the units whose actions have not been
+// started yet, in the order they will be started.
+func (op *Operation) Queued() []string {
+	queued := append([]string(nil), op.doc.Queue...)
+	if op.doc.Last != "" {
+		queued = append(queued, op.doc.Last)
+	}
+	return queued
+}
+
+// Status returns the operation's status.
+func (op *Operation) Status() OperationStatus {
+	return op.doc.Status
+}
+
+// Message returns a message describing why the operation was aborted,
+// if it was.
+func (op *Operation) Message() string {
+	return op.doc.Message
+}
+
+// Enqueued returns the time the operation was added.
+func (op *Operation) Enqueued() time.Time {
+	return op.doc.Enqueued
+}
+
+// Completed returns the time the last of the operation's actions
+// finished, or the zero time if the operation is still running.
+func (op *Operation) Completed() time.Time {
+	return op.doc.Completed
+}
+
+// Refresh reloads the operation from the database.
+func (op *Operation) Refresh() error {
+	operations, closer := op.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.FindId(op.doc.DocId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("operation %q", op.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get operation %q", op.Id())
+	}
+	op.doc = doc
+	return nil
+}
+
+// Actions returns the actions which have been enqueued by the
+// operation so far.
+func (op *Operation) Actions() ([]Action, error) {
+	actions, closer := op.st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	err := actions.Find(bson.D{{"operation", op.Id()}}).Sort("enqueued", "_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get actions for operation %q", op.Id())
+	}
+	results := make([]Action, len(docs))
+	for i, doc := range docs {
+		results[i] = newAction(op.st, doc)
+	}
+	return results, nil
+}
+
+// Operation returns the operation with the given id.
+func (m *Model) Operation(id string) (*Operation, error) {
+	op := &Operation{st: m.st, doc: operationDoc{DocId: m.st.docID(id)}}
+	if err := op.Refresh(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return op, nil
+}
+
+// EnqueueOperation adds an operation to the model, and enqueues as many
+// of its actions as its policy allows. The remaining actions are
+// enqueued as earlier ones finish.
+func (m *Model) EnqueueOperation(args OperationArgs) (*Operation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := sequence(m.st, "operation")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Start numbering from 1 not 0.
+	id := strconv.Itoa(seq + 1)
+
+	queue := make([]string, len(args.Receivers))
+	for i, receiver := range args.Receivers {
+		queue[i] = receiver.Id()
+	}
+	var last string
+	if args.Last != nil {
+		last = args.Last.Id()
+	}
+
+	var doc operationDoc
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc = operationDoc{
+			DocId:         m.st.docID(id),
+			ModelUUID:     m.st.ModelUUID(),
+			ActionName:    args.ActionName,
+			Parameters:    args.Parameters,
+			Parallel:      args.Parallel,
+			StopOnFailure: args.StopOnFailure,
+			Queue:         queue,
+			Last:          last,
+			Status:        OperationRunning,
+			Enqueued:      m.st.nowToTheSecond(),
+		}
+		ops, err := m.st.startOperationActions(&doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{{
+			C:      operationsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, ops...), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot enqueue operation")
+	}
+	return &Operation{st: m.st, doc: doc}, nil
+}
+
+// startOperationActions returns the operations needed to enqueue as
+// many of the operation's queued actions as its policy allows, and
+// updates the document accordingly. Units which are dead are skipped.
+func (st *State) startOperationActions(doc *operationDoc) ([]txn.Op, error) {
+	if doc.Status != OperationRunning {
+		return nil, nil
+	}
+	var ops []txn.Op
+	next := func() string {
+		if len(doc.Queue) > 0 {
+			if doc.Parallel > 0 && doc.Outstanding >= doc.Parallel {
+				return ""
+			}
+			receiver := doc.Queue[0]
+			doc.Queue = doc.Queue[1:]
+			return receiver
+		}
+		if doc.Last != "" && doc.Outstanding == 0 {
+			receiver := doc.Last
+			doc.Last = ""
+			return receiver
+		}
+		return ""
+	}
+	for receiver := next(); receiver != ""; receiver = next() {
+		unitOps, err := st.enqueueOperationActionOps(doc, receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(unitOps) == 0 {
+			continue
+		}
+		ops = append(ops, unitOps...)
+		doc.Outstanding++
+	}
+	if doc.Outstanding == 0 && len(doc.Queue) == 0 && doc.Last == "" {
+		doc.Status = OperationCompleted
+		if doc.Failed > 0 {
+			doc.Status = OperationFailed
+		}
+		doc.Completed = st.nowToTheSecond()
+	}
+	return ops, nil
+}
+
+// enqueueOperationActionOps returns the operations needed to enqueue
+// the operation's action on the named unit, or none if the unit is
+// dead or gone.
+func (st *State) enqueueOperationActionOps(doc *operationDoc, unitName string) ([]txn.Op, error) {
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	adoc, ops, err := st.enqueueUnitActionOps(unitName, doc.ActionName, doc.Parameters[appName])
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if adoc == nil {
+		actionLogger.Debugf("skipping operation %q action on unit %q which is dead", st.localID(doc.DocId), unitName)
+		return nil, nil
+	}
+	adoc.Operation = st.localID(doc.DocId)
+	return ops, nil
+}
+
+// enqueueUnitActionOps returns a new action on the named unit, and the
+// operations needed to enqueue it. The action's document is inserted
+// by reference, so it may be updated until the operations are run. If
+// the unit is dead or gone, no action or operations are returned.
+func (st *State) enqueueUnitActionOps(unitName, actionName string, parameters map[string]interface{}) (*actionDoc, []txn.Op, error) {
+	notDead, err := isNotDead(st, unitsC, st.docID(unitName))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	} else if !notDead {
+		return nil, nil, nil
+	}
+	adoc, ndoc, err := newActionDoc(st, names.NewUnitTag(unitName), actionName, parameters)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return &adoc, []txn.Op{{
+		C:      unitsC,
+		Id:     st.docID(unitName),
+		Assert: notDeadDoc,
+	}, {
+		C:      actionsC,
+		Id:     adoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &adoc,
+	}, {
+		C:      actionNotificationsC,
+		Id:     ndoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: ndoc,
+	}}, nil
+}
+
+// operationActionFinishedOps returns the operations needed to record
+// that one of the operation's actions finished with the given status,
+// and to start any actions which can now run.
+func (st *State) operationActionFinishedOps(id, receiver string, status ActionStatus) ([]txn.Op, error) {
+	operations, closer := st.db().GetCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.FindId(st.docID(id)).One(&doc)
+	if err == mgo.ErrNotFound {
+		// The operation may have been pruned.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get operation %q", id)
+	}
+	revno := doc.TxnRevno
+
+	if doc.Outstanding > 0 {
+		doc.Outstanding--
+	}
+	if status == ActionFailed {
+		doc.Failed++
+		if doc.StopOnFailure && doc.Status == OperationRunning {
+			doc.Status = OperationAborted
+			doc.Message = fmt.Sprintf("action on unit %q failed", receiver)
+			doc.Queue = nil
+			doc.Last = ""
+		}
+	}
+	ops, err := st.startOperationActions(&doc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if doc.Status == OperationAborted && doc.Outstanding == 0 {
+		doc.Completed = st.nowToTheSecond()
+	}
+	return append([]txn.Op{{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"txn-revno", revno}},
+		Update: bson.D{{"$set", bson.D{
+			{"queue", doc.Queue},
+			{"last", doc.Last},
+			{"outstanding", doc.Outstanding},
+			{"failed", doc.Failed},
+			{"status", doc.Status},
+			{"message", doc.Message},
+			{"completed", doc.Completed},
+		}}},
+	}}, ops...), nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+type OperationSuite struct {
+	ConnSuite
+	model *state.Model
+	units []*state.Unit
+}
+
+var _ = gc.Suite(&OperationSuite{})
+
+func (s *OperationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OperationSuite) receivers(units ...*state.Unit) []names.UnitTag {
+	tags := make([]names.UnitTag, len(units))
+	for i, unit := range units {
+		tags[i] = unit.UnitTag()
+	}
+	return tags
+}
+
+func (s *OperationSuite) enqueue(c *gc.C, args state.OperationArgs) *state.Operation {
+	args.ActionName = "snapshot"
+	op, err := s.model.EnqueueOperation(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return op
+}
+
+func (s *OperationSuite) actionsByReceiver(c *gc.C, op *state.Operation) map[string]state.Action {
+	actions, err := op.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string]state.Action)
+	for _, action := range actions {
+		result[action.Receiver()] = action
+	}
+	return result
+}
+
+func (s *OperationSuite) finish(c *gc.C, action state.Action, status state.ActionStatus) {
+	_, err := action.Finish(state.ActionResults{Status: status})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *OperationSuite) TestEnqueueOperationValidates(c *gc.C) {
+	_, err := s.model.EnqueueOperation(state.OperationArgs{Receivers: s.receivers(s.units[0])})
+	c.Assert(err, gc.ErrorMatches, "empty action name not valid")
+	_, err = s.model.EnqueueOperation(state.OperationArgs{ActionName: "snapshot"})
+	c.Assert(err, gc.ErrorMatches, "operation without receivers not valid")
+	_, err = s.model.EnqueueOperation(state.OperationArgs{
+		ActionName: "snapshot",
+		Receivers:  s.receivers(s.units[0]),
+		Parallel:   -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "parallel -1 not valid")
+}
+
+func (s *OperationSuite) TestEnqueueOperationApplicationParameters(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	other := s.AddTestingApplication(c, "other", ch)
+	otherUnit, err := other.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op := s.enqueue(c, state.OperationArgs{
+		Receivers: s.receivers(s.units[0], otherUnit),
+		Parameters: map[string]map[string]interface{}{
+			"dummy": {"outfile": "dummy.bz2"},
+			"other": {"outfile": "other.bz2"},
+		},
+	})
+	actions := s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 2)
+	c.Assert(actions["dummy/0"].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "dummy.bz2"})
+	c.Assert(actions["other/0"].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "other.bz2"})
+}
+
+func (s *OperationSuite) TestEnqueueOperationUnlimited(c *gc.C) {
+	op := s.enqueue(c, state.OperationArgs{Receivers: s.receivers(s.units...)})
+	c.Assert(op.Id(), gc.Equals, "1")
+	c.Assert(op.Status(), gc.Equals, state.OperationRunning)
+	c.Assert(op.Queued(), gc.HasLen, 0)
+
+	actions := s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 3)
+
+	for _, unit := range s.units {
+		s.finish(c, actions[unit.Name()], state.ActionCompleted)
+	}
+	err := op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.OperationCompleted)
+	c.Assert(op.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *OperationSuite) TestEnqueueOperationParallel(c *gc.C) {
+	op := s.enqueue(c, state.OperationArgs{
+		Receivers: s.receivers(s.units...),
+		Parallel:  1,
+	})
+	c.Assert(op.Queued(), jc.DeepEquals, []string{"dummy/1", "dummy/2"})
+	actions := s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 1)
+
+	// Finishing an action starts the next one in the queue.
+	s.finish(c, actions["dummy/0"], state.ActionCompleted)
+	actions = s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 2)
+	c.Assert(actions["dummy/1"].Status(), gc.Equals, state.ActionPending)
+
+	// A failure doesn't stop the operation unless asked.
+	s.finish(c, actions["dummy/1"], state.ActionFailed)
+	actions = s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 3)
+
+	s.finish(c, actions["dummy/2"], state.ActionCompleted)
+	err := op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.OperationFailed)
+	c.Assert(op.Queued(), gc.HasLen, 0)
+}
+
+func (s *OperationSuite) TestEnqueueOperationStopOnFailure(c *gc.C) {
+	op := s.enqueue(c, state.OperationArgs{
+		Receivers:     s.receivers(s.units...),
+		Parallel:      1,
+		StopOnFailure: true,
+	})
+	actions := s.actionsByReceiver(c, op)
+	s.finish(c, actions["dummy/0"], state.ActionFailed)
+
+	err := op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.OperationAborted)
+	c.Assert(op.Message(), gc.Equals, `action on unit "dummy/0" failed`)
+	c.Assert(op.Queued(), gc.HasLen, 0)
+	c.Assert(op.Completed().IsZero(), jc.IsFalse)
+	c.Assert(s.actionsByReceiver(c, op), gc.HasLen, 1)
+}
+
+func (s *OperationSuite) TestEnqueueOperationLast(c *gc.C) {
+	last := s.units[0].UnitTag()
+	op := s.enqueue(c, state.OperationArgs{
+		Receivers: s.receivers(s.units[1], s.units[2]),
+		Last:      &last,
+		Parallel:  2,
+	})
+	c.Assert(op.Queued(), jc.DeepEquals, []string{"dummy/0"})
+	actions := s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 2)
+
+	// The last unit waits until all the others have finished, even
+	// though there is room for it to run.
+	s.finish(c, actions["dummy/1"], state.ActionCompleted)
+	c.Assert(s.actionsByReceiver(c, op), gc.HasLen, 2)
+	s.finish(c, actions["dummy/2"], state.ActionCompleted)
+	actions = s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 3)
+
+	s.finish(c, actions["dummy/0"], state.ActionCompleted)
+	err := op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.OperationCompleted)
+}
+
+func (s *OperationSuite) TestEnqueueOperationSkipsDeadUnits(c *gc.C) {
+	err := s.units[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	op := s.enqueue(c, state.OperationArgs{Receivers: s.receivers(s.units...)})
+	actions := s.actionsByReceiver(c, op)
+	c.Assert(actions, gc.HasLen, 2)
+	c.Assert(actions["dummy/1"], gc.IsNil)
+}
+
+func (s *OperationSuite) TestCancelledActionAdvancesOperation(c *gc.C) {
+	op := s.enqueue(c, state.OperationArgs{
+		Receivers: s.receivers(s.units...),
+		Parallel:  1,
+	})
+	actions := s.actionsByReceiver(c, op)
+	_, err := s.units[0].CancelAction(actions["dummy/0"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.actionsByReceiver(c, op), gc.HasLen, 2)
+}
+
+func (s *OperationSuite) TestOperationNotFound(c *gc.C) {
+	_, err := s.model.Operation("42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
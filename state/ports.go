@@ -37,6 +37,18 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint is the name of the application endpoint that the port
+	// range is opened for, or empty if it is opened for all of them.
+	Endpoint string `bson:",omitempty"`
+}
+
+// sameRange reports whether the two port ranges are the same range
+// opened by the same unit, regardless of the endpoints that they are
+// opened for.
+func (a PortRange) sameRange(b PortRange) bool {
+	a.Endpoint, b.Endpoint = "", ""
+	return a == b
 }
 
 // NewPortRange create a new port range and validate it.
@@ -121,8 +133,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. The endpoint that the range is
+	// opened for may be changed by opening it again.
+	if prA.sameRange(prB) {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -137,10 +150,14 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
 	proto := strings.ToLower(p.Protocol)
+	owner := fmt.Sprintf("%q", p.UnitName)
+	if p.Endpoint != "" {
+		owner += fmt.Sprintf(", endpoint %q", p.Endpoint)
+	}
 	if proto == "icmp" {
-		return fmt.Sprintf("%s (%q)", proto, p.UnitName)
+		return fmt.Sprintf("%s (%s)", proto, owner)
 	}
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, proto, p.UnitName)
+	return fmt.Sprintf("%d-%d/%s (%s)", p.FromPort, p.ToPort, proto, owner)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -195,6 +212,7 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 		return errors.Trace(err)
 	}
 	ports := Ports{st: p.st, doc: p.doc, areNew: p.areNew}
+	var reopened []PortRange
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
@@ -218,7 +236,8 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 		}
 
 		// Check for conflicts with existing ports.
-		for _, existingPorts := range p.doc.Ports {
+		reopened = nil
+		for i, existingPorts := range ports.doc.Ports {
 			if err := existingPorts.CheckConflicts(portRange); err != nil {
 				return nil, errors.Trace(err)
 			} else if existingPorts == portRange {
@@ -227,13 +246,22 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 				// and hence its txn-revno and trigger unnecessary
 				// watcher notifications.
 				return nil, statetxn.ErrNoOperations
+			} else if existingPorts.sameRange(portRange) {
+				// The same range is being opened for another
+				// endpoint, which replaces the one it was
+				// opened for.
+				reopened = append([]PortRange(nil), ports.doc.Ports...)
+				reopened[i] = portRange
 			}
 		}
 
 		ops := []txn.Op{
 			assertModelActiveOp(p.st.ModelUUID()),
 		}
-		if ports.areNew {
+		if reopened != nil {
+			assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
+			ops = append(ops, setPortsDocOps(p.st, ports.doc, assert, reopened...)...)
+		} else if ports.areNew {
 			// Create a new document.
 			assert := txn.DocMissing
 			ops = append(ops, addPortsDocOps(p.st, &ports.doc, assert, portRange)...)
@@ -250,7 +278,11 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 	}
 	// Mark object as created.
 	p.areNew = false
-	p.doc.Ports = append(p.doc.Ports, portRange)
+	if reopened != nil {
+		p.doc.Ports = reopened
+	} else {
+		p.doc.Ports = append(p.doc.Ports, portRange)
+	}
 	return nil
 }
 
@@ -295,7 +327,9 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			// Ports are closed for whichever endpoints
+			// they were opened for.
+			if existingPortsDef.sameRange(portRange) {
 				found = true
 				continue
 			}
@@ -364,6 +398,22 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// AllPortRangeEndpoints returns a map with network.PortRange as keys and
+// the names of the endpoints that the port ranges are opened for as
+// values. Port ranges opened for all endpoints have an empty name.
+func (p *Ports) AllPortRangeEndpoints() map[network.PortRange]string {
+	result := make(map[network.PortRange]string)
+	for _, portRange := range p.doc.Ports {
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		result[rawRange] = portRange.Endpoint
+	}
+	return result
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -508,17 +558,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
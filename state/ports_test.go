@@ -313,6 +313,82 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
 }
 
+func (s *PortsDocSuite) TestAllPortRangeEndpoints(c *gc.C) {
+	err := s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+		Endpoint: "url",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 300,
+		ToPort:   300,
+		UnitName: s.unit2.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.portsWithoutSubnet.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{100, 200, "tcp"}: "url",
+		{300, 300, "tcp"}: "",
+	})
+}
+
+func (s *PortsDocSuite) TestOpenPortsForAnotherEndpoint(c *gc.C) {
+	portRange := state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+		Endpoint: "url",
+	}
+	err := s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Opening the same range again for all endpoints
+	// replaces the endpoint it was opened for.
+	portRange.Endpoint = ""
+	err = s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.portsWithoutSubnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.PortsForUnit(s.unit1.Name()), jc.DeepEquals, []state.PortRange{portRange})
+
+	// Another unit cannot open the range for another endpoint.
+	err = s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit2.Name(),
+		Protocol: "tcp",
+		Endpoint: "db",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 100-200/tcp \("wordpress/1", endpoint "db"\): port ranges .* conflict`)
+}
+
+func (s *PortsDocSuite) TestClosePortsOpenedForEndpoint(c *gc.C) {
+	err := s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+		Endpoint: "url",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ports are closed regardless of the endpoint they were opened for.
+	err = s.portsWithoutSubnet.ClosePorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.AllPortRanges(), gc.HasLen, 0)
+}
+
 func (s *PortsDocSuite) TestICMP(c *gc.C) {
 	portRange := state.PortRange{
 		FromPort: -1,
@@ -555,7 +631,7 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		"port ranges .* conflict",
 	}, {
 		"invalid port range",
-		state.PortRange{"wordpress/0", 100, 80, "TCP"},
+		state.PortRange{"wordpress/0", 100, 80, "TCP", ""},
 		MustPortRange("wordpress/0", 80, 80, "TCP"),
 		"invalid port range 100-80",
 	}, {
@@ -611,18 +687,22 @@ func swapProtocol(protocol string) string {
 }
 
 func (p *PortRangeSuite) TestPortRangeString(c *gc.C) {
-	c.Assert(state.PortRange{"wordpress/42", 80, 80, "TCP"}.String(),
+	c.Assert(state.PortRange{"wordpress/42", 80, 80, "TCP", ""}.String(),
 		gc.Equals,
 		`80-80/tcp ("wordpress/42")`,
 	)
-	c.Assert(state.PortRange{"wordpress/0", 80, 100, "TCP"}.String(),
+	c.Assert(state.PortRange{"wordpress/0", 80, 100, "TCP", ""}.String(),
 		gc.Equals,
 		`80-100/tcp ("wordpress/0")`,
 	)
-	c.Assert(state.PortRange{"wordpress/0", -1, -1, "ICMP"}.String(),
+	c.Assert(state.PortRange{"wordpress/0", -1, -1, "ICMP", ""}.String(),
 		gc.Equals,
 		`icmp ("wordpress/0")`,
 	)
+	c.Assert(state.PortRange{"wordpress/0", 80, 80, "TCP", "url"}.String(),
+		gc.Equals,
+		`80-80/tcp ("wordpress/0", endpoint "url")`,
+	)
 }
 
 func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
@@ -633,22 +713,22 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		expectedErr  string
 	}{{
 		"single valid port",
-		state.PortRange{"wordpress/0", 80, 80, "tcp"},
+		state.PortRange{"wordpress/0", 80, 80, "tcp", ""},
 		1,
 		"",
 	}, {
 		"valid tcp port range",
-		state.PortRange{"wordpress/0", 80, 90, "tcp"},
+		state.PortRange{"wordpress/0", 80, 90, "tcp", ""},
 		11,
 		"",
 	}, {
 		"valid udp port range",
-		state.PortRange{"wordpress/0", 80, 90, "UDP"},
+		state.PortRange{"wordpress/0", 80, 90, "UDP", ""},
 		11,
 		"",
 	}, {
 		"invalid port range boundaries",
-		state.PortRange{"wordpress/0", 90, 80, "tcp"},
+		state.PortRange{"wordpress/0", 90, 80, "tcp", ""},
 		0,
 		"invalid port range.*",
 	}, {
@@ -663,37 +743,37 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		"invalid unit.*",
 	}, {
 		"negative lower bound",
-		state.PortRange{"wordpress/0", -10, 10, "tcp"},
+		state.PortRange{"wordpress/0", -10, 10, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"zero lower bound",
-		state.PortRange{"wordpress/0", 0, 10, "tcp"},
+		state.PortRange{"wordpress/0", 0, 10, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"negative upper bound",
-		state.PortRange{"wordpress/0", 10, -10, "tcp"},
+		state.PortRange{"wordpress/0", 10, -10, "tcp", ""},
 		0,
 		"invalid port range.*",
 	}, {
 		"zero upper bound",
-		state.PortRange{"wordpress/0", 10, 0, "tcp"},
+		state.PortRange{"wordpress/0", 10, 0, "tcp", ""},
 		0,
 		"invalid port range.*",
 	}, {
 		"too large lower bound",
-		state.PortRange{"wordpress/0", 65540, 99999, "tcp"},
+		state.PortRange{"wordpress/0", 65540, 99999, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"too large upper bound",
-		state.PortRange{"wordpress/0", 10, 99999, "tcp"},
+		state.PortRange{"wordpress/0", 10, 99999, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"longest valid range",
-		state.PortRange{"wordpress/0", 1, 65535, "tcp"},
+		state.PortRange{"wordpress/0", 1, 65535, "tcp", ""},
 		65535,
 		"",
 	}}
@@ -488,7 +488,10 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string,
 	re := r.st.RemoteEntities()
 	tokenOps := re.removeRemoteEntityOps(r.Tag())
 	ops = append(ops, tokenOps...)
-	offerOps := removeOfferConnectionsForRelationOps(r.Id())
+	offerOps, err := r.st.removeOfferConnectionsForRelationOps(r.Id())
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
 	ops = append(ops, offerOps...)
 	// This cleanup does not need to be forced.
 	cleanupOp := newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
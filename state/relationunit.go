@@ -691,6 +691,14 @@ func NetworksForRelation(
 		}
 	}
 
+	// Order the ingress addresses by the model's preferred address family,
+	// so that the first one, and hence the default egress, is of that family.
+	family, err := st.PreferredAddressFamily()
+	if err != nil {
+		return "", nil, nil, errors.Trace(err)
+	}
+	corenetwork.SortAddressValuesForFamily(ingress, family)
+
 	// If no egress subnets defined, We default to the ingress address.
 	if len(egress) == 0 && len(ingress) > 0 {
 		egress, err = network.FormatAsCIDR([]string{ingress[0]})
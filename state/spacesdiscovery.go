@@ -30,31 +30,86 @@ func (st *State) getModelSubnets() (set.Strings, error) {
 
 // ReloadSpaces loads spaces and subnets from provider specified by environ into state.
 // Currently it's an append-only operation, no spaces/subnets are deleted.
+//
+// Providers that cannot discover spaces, but can report their subnets,
+// have those subnets added to the spaces chosen by the model's
+// subnet-space-rules, or to the default space.
 func (st *State) ReloadSpaces(environ environs.BootstrapEnviron) error {
-	netEnviron, ok := environs.SupportsNetworking(environ)
+	ctx := CallContext(st)
+	if netEnviron, ok := environs.SupportsNetworking(environ); ok {
+		canDiscoverSpaces, err := netEnviron.SupportsSpaceDiscovery(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if canDiscoverSpaces {
+			spaces, err := netEnviron.Spaces(ctx)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Trace(st.SaveSpacesFromProvider(spaces))
+		}
+	}
+
+	subnetEnviron, ok := environs.SupportsSubnetDiscovery(environ)
 	if !ok {
 		return errors.NotSupportedf("spaces discovery in a non-networking environ")
 	}
+	logger.Debugf("environ does not support space discovery, falling back to subnet discovery")
+	subnets, err := subnetEnviron.Subnets(ctx, instance.UnknownId, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.saveDiscoveredSubnets(subnets))
+}
 
-	ctx := CallContext(st)
-	canDiscoverSpaces, err := netEnviron.SupportsSpaceDiscovery(ctx)
+// saveDiscoveredSubnets loads subnets into state, adding them to the
+// spaces chosen by the subnet-space-rules model config. Spaces named
+// by the rules are created if they do not exist.
+func (st *State) saveDiscoveredSubnets(subnets []corenetwork.SubnetInfo) error {
+	m, err := st.Model()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if canDiscoverSpaces {
-		spaces, err := netEnviron.Spaces(ctx)
-		if err != nil {
-			return errors.Trace(err)
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rules := cfg.SubnetSpaceRules()
+	if len(rules) == 0 {
+		return errors.Trace(st.SaveSubnetsFromProvider(subnets, ""))
+	}
+
+	var spaceNames []string
+	spaceSubnets := make(map[string][]corenetwork.SubnetInfo)
+	for _, subnet := range subnets {
+		spaceName := rules.SpaceFor(subnet)
+		if _, ok := spaceSubnets[spaceName]; !ok {
+			spaceNames = append(spaceNames, spaceName)
 		}
-		return errors.Trace(st.SaveSpacesFromProvider(spaces))
-	} else {
-		logger.Debugf("environ does not support space discovery, falling back to subnet discovery")
-		subnets, err := netEnviron.Subnets(ctx, instance.UnknownId, nil)
-		if err != nil {
+		// The space name allows subnets already in the
+		// default space to be moved to the matching space.
+		subnet.SpaceName = spaceName
+		spaceSubnets[spaceName] = append(spaceSubnets[spaceName], subnet)
+	}
+
+	for _, spaceName := range spaceNames {
+		var spaceID string
+		if spaceName != corenetwork.DefaultSpaceName {
+			space, err := st.Space(spaceName)
+			if errors.IsNotFound(err) {
+				logger.Debugf("adding space %q for discovered subnets", spaceName)
+				space, err = st.AddSpace(spaceName, "", nil, false)
+			}
+			if err != nil {
+				return errors.Trace(err)
+			}
+			spaceID = space.Id()
+		}
+		if err := st.SaveSubnetsFromProvider(spaceSubnets[spaceName], spaceID); err != nil {
 			return errors.Trace(err)
 		}
-		return errors.Trace(st.SaveSubnetsFromProvider(subnets, ""))
 	}
+	return nil
 }
 
 // SaveSubnetsFromProvider loads subnets into state.
@@ -31,6 +31,16 @@ type networkedEnviron struct {
 	callCtxUsed context.ProviderCallContext
 }
 
+type subnetsOnlyEnviron struct {
+	environs.Environ
+
+	subnets []network.SubnetInfo
+}
+
+func (e subnetsOnlyEnviron) Subnets(context.ProviderCallContext, instance.Id, []network.Id) ([]network.SubnetInfo, error) {
+	return e.subnets, nil
+}
+
 type SpacesDiscoverySuite struct {
 	ConnSuite
 
@@ -230,6 +240,66 @@ func (s *SpacesDiscoverySuite) TestReloadSpacesNetworklessEnviron(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "spaces discovery in a non-networking environ not supported")
 }
 
+func (s *SpacesDiscoverySuite) TestReloadSpacesSubnetsOnlyEnviron(c *gc.C) {
+	err := s.State.ReloadSpaces(subnetsOnlyEnviron{subnets: twoSubnets})
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	checkSubnetsEqual(c, subnets, twoSubnets)
+	for _, subnet := range subnets {
+		c.Check(subnet.SpaceID(), gc.Equals, network.DefaultSpaceId)
+	}
+}
+
+func (s *SpacesDiscoverySuite) TestReloadSpacesSubnetsWithRules(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"subnet-space-rules": "[{space: dmz, cidr: 10.100.0.0/16}, {space: internal, name: internal-*}]",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets := []network.SubnetInfo{
+		{ProviderId: "1", CIDR: "10.0.0.0/24", ProviderName: "internal-a"},
+		{ProviderId: "2", CIDR: "10.100.30.0/24", ProviderName: "internal-b"},
+		{ProviderId: "3", CIDR: "10.101.0.0/24", ProviderName: "other"},
+	}
+	err = s.State.ReloadSpaces(subnetsOnlyEnviron{subnets: subnets})
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces := make(map[string]string)
+	stateSubnets, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, subnet := range stateSubnets {
+		spaces[subnet.CIDR()] = subnet.SpaceName()
+	}
+	c.Check(spaces, jc.DeepEquals, map[string]string{
+		"10.0.0.0/24":    "internal",
+		"10.100.30.0/24": "dmz",
+		"10.101.0.0/24":  network.DefaultSpaceName,
+	})
+}
+
+func (s *SpacesDiscoverySuite) TestReloadSpacesSubnetsWithRulesMovesDefaultSpaceSubnets(c *gc.C) {
+	env := subnetsOnlyEnviron{subnets: []network.SubnetInfo{
+		{ProviderId: "1", CIDR: "10.0.0.0/24", ProviderName: "internal-a"},
+	}}
+	err := s.State.ReloadSpaces(env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"subnet-space-rules": "[{space: internal, name: internal-*}]",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ReloadSpaces(env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnet, err := s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnet.SpaceName(), gc.Equals, "internal")
+}
+
 func (s *SpacesDiscoverySuite) TestReloadSpacesSupportsSpaceDiscoveryBroken(c *gc.C) {
 	s.environ = networkedEnviron{
 		stub: &testing.Stub{},
@@ -3437,6 +3437,41 @@ func (s *StateSuite) TestParseModelTag(c *gc.C) {
 	c.Assert(id, gc.Equals, s.model.UUID())
 }
 
+func (s *StateSuite) TestWatchHostFirewallChanges(c *gc.C) {
+	// Check initial event.
+	w := s.State.WatchHostFirewallChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Adding an application and a unit on a machine triggers a change.
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Opening a port triggers a change.
+	err = unit.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Exposing the application triggers a change.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes unrelated to the host firewall do not.
+	err = unit.SetWorkloadVersion("1.0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Stop watcher, check closed.
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *StateSuite) TestWatchCleanups(c *gc.C) {
 	// Check initial event.
 	w := s.State.WatchCleanups()
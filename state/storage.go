@@ -54,6 +54,11 @@ type StorageInstance interface {
 	// Pool returns the name of the storage pool from which the storage
 	// instance has been or will be provisioned.
 	Pool() string
+
+	// Move returns the details of the storage instance's in-progress
+	// move to another storage pool, and a boolean indicating whether
+	// or not the storage instance is being moved.
+	Move() (StorageMove, bool)
 }
 
 // StorageAttachment represents the state of a unit's attachment to a storage
@@ -213,13 +218,18 @@ type storageInstanceDoc struct {
 	StorageName     string                     `bson:"storagename"`
 	AttachmentCount int                        `bson:"attachmentcount"`
 	Constraints     storageInstanceConstraints `bson:"constraints"`
+
+	// Move, if non-nil, records the storage instance's
+	// in-progress move to another storage pool.
+	Move *storageMoveDoc `bson:"move,omitempty"`
 }
 
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool       string `bson:"pool"`
+	Size       uint64 `bson:"size"`
+	SnapshotId string `bson:"snapshot-id,omitempty"`
 }
 
 type storageAttachment struct {
@@ -413,6 +423,71 @@ func (sb *storageBackend) destroyStorageInstance(
 	return sb.mb.db().Run(buildTxn)
 }
 
+// ResizeStorageInstance requests that the volume backing the specified
+// storage instance be grown to the given size, in MiB. The storage
+// provisioner will resize the volume, and then any filesystem on it.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		var volumeTag names.VolumeTag
+		var currentSize uint64
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := sb.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info, err := v.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeTag, currentSize = v.VolumeTag(), info.Size
+		case StorageKindFilesystem:
+			f, err := sb.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeTag, err = f.Volume()
+			if errors.Cause(err) == ErrNoBackingVolume {
+				return nil, errors.NotSupportedf("resizing filesystem storage not backed by a volume")
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info, err := f.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			currentSize = info.Size
+		default:
+			return nil, errors.NotSupportedf("resizing %s storage", s.Kind())
+		}
+		if size <= currentSize {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, currentSize,
+			)
+		}
+		return []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: isAliveDoc,
+		}, {
+			C:      volumesC,
+			Id:     volumeTag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 func (sb *storageBackend) destroyStorageInstanceOps(
 	s *storageInstance,
 	destroyAttachments bool,
@@ -605,6 +680,18 @@ func removeStorageInstanceOps(si *storageInstance, assert bson.D, force bool) ([
 		}
 		logger.Warningf("could not get volume when removing storage instance %v: %v", si.StorageTag().Id(), err)
 	}
+	if move, ok := si.Move(); ok {
+		// Abandon the in-progress move, destroying the volume
+		// or filesystem that the storage was being moved to.
+		moveOps, err := destroyStorageMoveTargetOps(si.sb, move)
+		if err != nil {
+			if !force {
+				return nil, errors.Trace(err)
+			}
+			logger.Warningf("could not get operations to destroy %v when removing storage instance %v: %v", names.ReadableString(move.Target()), si.StorageTag().Id(), err)
+		}
+		ops = append(ops, moveOps...)
+	}
 	return ops, nil
 }
 
@@ -832,8 +919,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:       cons.Pool,
+					Size:       cons.Size,
+					SnapshotId: cons.SnapshotId,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -1668,6 +1756,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// SnapshotId, if not empty, is the provider ID of the volume
+	// snapshot from which to create the storage instances.
+	SnapshotId string `bson:"snapshot-id,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1771,6 +1863,28 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateStoragePool(sb, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if cons.SnapshotId != "" {
+			if err := validateSnapshotPool(sb, cons.Pool); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSnapshotPool checks that storage in the named pool can be
+// created from a volume snapshot, which requires the pool's storage
+// to be backed by volumes.
+func validateSnapshotPool(sb *storageBackend, poolName string) error {
+	if poolName == "" {
+		return nil
+	}
+	providerType, provider, _, err := poolStorageProvider(sb, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return errors.NotSupportedf("creating storage from a snapshot with storage provider %q", providerType)
 	}
 	return nil
 }
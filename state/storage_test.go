@@ -608,6 +608,58 @@ func (s *StorageStateSuite) TestProviderFallbackToType(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *StorageStateSuite) TestAddUnitStorageFromSnapshot(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	cons := makeStorageCons("loop-pool", 1024, 1)
+	cons.SnapshotId = "snap-0123"
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": cons,
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("data/0"))
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.SnapshotId, gc.Equals, "snap-0123")
+}
+
+func (s *StorageStateSuite) TestAddUnitFilesystemFromSnapshot(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	cons := makeStorageCons("loop-pool", 1024, 1)
+	cons.SnapshotId = "snap-0123"
+	app := s.AddTestingApplicationWithStorage(c, "storage-filesystem", ch, map[string]state.StorageConstraints{
+		"data": cons,
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, names.NewStorageTag("data/0"))
+	fsParams, ok := filesystem.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(fsParams.SnapshotId, gc.Equals, "snap-0123")
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("data/0"))
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams.SnapshotId, gc.Equals, "snap-0123")
+}
+
+func (s *StorageStateSuite) TestAddApplicationStorageFromSnapshotNotVolumeBacked(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	cons := makeStorageCons("tmpfs-pool", 1024, 1)
+	cons.SnapshotId = "snap-0123"
+	_, err := s.st.AddApplication(state.AddApplicationArgs{
+		Name:    "storage-filesystem",
+		Charm:   ch,
+		Storage: map[string]state.StorageConstraints{"data": cons},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-filesystem": charm "storage-filesystem" store "data": creating storage from a snapshot with storage provider "tmpfs" not supported`)
+}
+
 func (s *StorageStateSuite) TestAddUnit(c *gc.C) {
 	s.assertStorageUnitsAdded(c)
 }
@@ -0,0 +1,534 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"path"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/juju/paths"
+)
+
+// StorageMove describes an in-progress move of a storage instance
+// to a volume or filesystem in another storage pool.
+type StorageMove struct {
+	// Pool is the name of the storage pool that the storage
+	// instance is being moved to.
+	Pool string
+
+	// Volume is the tag of the volume, provisioned from Pool,
+	// that the contents of block storage are being copied to.
+	Volume names.VolumeTag
+
+	// Filesystem is the tag of the filesystem, provisioned from
+	// Pool, that the contents of filesystem storage are being
+	// copied to.
+	Filesystem names.FilesystemTag
+
+	// Copied is the number of bytes of the storage contents that
+	// the unit has recorded as copied to the target, so that an
+	// interrupted copy may be resumed.
+	Copied uint64
+}
+
+// Target returns the tag of the volume or filesystem that the
+// storage instance is being moved to.
+func (m StorageMove) Target() names.Tag {
+	if m.Filesystem != (names.FilesystemTag{}) {
+		return m.Filesystem
+	}
+	return m.Volume
+}
+
+// storageMoveDoc records an in-progress storage move in the storage
+// instance document, so that the move may be resumed by the unit
+// agent after a restart.
+type storageMoveDoc struct {
+	Pool       string `bson:"pool"`
+	Volume     string `bson:"volume,omitempty"`
+	Filesystem string `bson:"filesystem,omitempty"`
+	Copied     uint64 `bson:"copied,omitempty"`
+
+	// Mounted records whether the filesystem being moved to has
+	// been mounted. It is only recorded so that watchers of the
+	// storage instance learn that the move target is ready.
+	Mounted bool `bson:"mounted,omitempty"`
+}
+
+// targetAssert returns a txn assertion that the storage move
+// recorded in a storage instance document is to the given target.
+func (m storageMoveDoc) targetAssert() bson.D {
+	if m.Filesystem != "" {
+		return bson.D{{"move.filesystem", m.Filesystem}}
+	}
+	return bson.D{{"move.volume", m.Volume}}
+}
+
+// Move returns the details of the storage instance's in-progress move
+// to another storage pool, and a boolean indicating whether or not the
+// storage instance is being moved.
+func (s *storageInstance) Move() (StorageMove, bool) {
+	if s.doc.Move == nil {
+		return StorageMove{}, false
+	}
+	move := StorageMove{
+		Pool:   s.doc.Move.Pool,
+		Copied: s.doc.Move.Copied,
+	}
+	if s.doc.Move.Filesystem != "" {
+		move.Filesystem = names.NewFilesystemTag(s.doc.Move.Filesystem)
+	} else {
+		move.Volume = names.NewVolumeTag(s.doc.Move.Volume)
+	}
+	return move, true
+}
+
+// MoveStorageInstance starts moving the specified storage instance to
+// the given storage pool. A volume or filesystem, depending on the kind
+// of storage, is provisioned from the pool and attached to the machine
+// that the storage instance is attached to. Once the unit has copied the
+// storage contents to it, CompleteStorageMove is used to swap the storage
+// over. A filesystem that is moved is mounted at a new location, which is
+// reported to the unit as the storage location once the move completes.
+//
+// Only storage that is attached to a unit on a machine may be moved.
+func (sb *storageBackend) MoveStorageInstance(tag names.StorageTag, pool string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot move storage %q to pool %q", tag.Id(), pool)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if move, ok := s.Move(); ok {
+			if move.Pool == pool {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.Errorf("storage is already being moved to pool %q", move.Pool)
+		}
+		if s.Pool() == pool {
+			return nil, errors.New("storage is already in the pool")
+		}
+		if s.doc.AttachmentCount != 1 {
+			// The unit is responsible for copying the
+			// storage contents, so there must be exactly
+			// one to do so.
+			return nil, errors.New("storage must be attached to exactly one unit")
+		}
+
+		var ops []txn.Op
+		var move storageMoveDoc
+		switch s.Kind() {
+		case StorageKindBlock:
+			ops, move, err = sb.moveVolumeOps(tag, pool)
+		case StorageKindFilesystem:
+			ops, move, err = sb.moveFilesystemOps(tag, pool)
+		default:
+			return nil, errors.NotSupportedf("moving %s storage", s.Kind())
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:  storageInstancesC,
+			Id: s.doc.Id,
+			Assert: append(bson.D{
+				{"move", bson.D{{"$exists", false}}},
+				{"attachmentcount", 1},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"move", move}}}},
+		})
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// moveVolumeOps returns txn.Ops to provision a volume from the given
+// pool, attached to the machine that the specified storage instance's
+// volume is attached to, for the storage to be moved to.
+func (sb *storageBackend) moveVolumeOps(tag names.StorageTag, pool string) ([]txn.Op, storageMoveDoc, error) {
+	v, err := sb.storageInstanceVolume(tag)
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	attachments, err := sb.VolumeAttachments(v.VolumeTag())
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	if len(attachments) != 1 {
+		return nil, storageMoveDoc{}, errors.NotSupportedf("moving storage not attached to a single machine")
+	}
+	machine, err := sb.storageMoveMachine(attachments[0].Host())
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	ops, volumeAttachments, _, err := sb.hostStorageOps(machine.Id(), &storageParams{
+		volumes: []HostVolumeParams{{
+			Volume: VolumeParams{Pool: pool, Size: info.Size},
+		}},
+	})
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	machineOps, err := addMachineStorageAttachmentsOps(machine, volumeAttachments, nil)
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	return append(ops, machineOps...), storageMoveDoc{
+		Pool:   pool,
+		Volume: volumeAttachments[0].tag.Id(),
+	}, nil
+}
+
+// moveFilesystemOps returns txn.Ops to provision a filesystem from the
+// given pool, attached to the machine that the specified storage
+// instance's filesystem is attached to, for the storage to be moved to.
+// The filesystem is mounted within the machine's storage directory, at
+// a location named for the storage instance and pool.
+func (sb *storageBackend) moveFilesystemOps(tag names.StorageTag, pool string) ([]txn.Op, storageMoveDoc, error) {
+	f, err := sb.storageInstanceFilesystem(tag)
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	attachments, err := sb.FilesystemAttachments(f.FilesystemTag())
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	if len(attachments) != 1 {
+		return nil, storageMoveDoc{}, errors.NotSupportedf("moving storage not attached to a single machine")
+	}
+	machine, err := sb.storageMoveMachine(attachments[0].Host())
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	storageDir, err := paths.StorageDir(machine.Series())
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	ops, volumeAttachments, filesystemAttachments, err := sb.hostStorageOps(machine.Id(), &storageParams{
+		filesystems: []HostFilesystemParams{{
+			Filesystem: FilesystemParams{Pool: pool, Size: info.Size},
+			Attachment: FilesystemAttachmentParams{
+				Location: path.Join(storageDir, tag.Id()+"-"+pool),
+			},
+		}},
+	})
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	machineOps, err := addMachineStorageAttachmentsOps(machine, volumeAttachments, filesystemAttachments)
+	if err != nil {
+		return nil, storageMoveDoc{}, errors.Trace(err)
+	}
+	return append(ops, machineOps...), storageMoveDoc{
+		Pool:       pool,
+		Filesystem: filesystemAttachments[0].tag.Id(),
+	}, nil
+}
+
+// storageMoveMachine returns the machine that storage being moved is
+// attached to, which must be alive for the unit on it to copy the
+// storage contents.
+func (sb *storageBackend) storageMoveMachine(host names.Tag) (*Machine, error) {
+	if host.Kind() != names.MachineTagKind {
+		return nil, errors.NotSupportedf("moving storage not attached to a single machine")
+	}
+	machine, err := sb.machine(host.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machine.Life() != Alive {
+		return nil, errors.Errorf("machine %s is not alive", machine.Id())
+	}
+	return machine, nil
+}
+
+// CompleteStorageMove completes the move of the specified storage
+// instance to the given volume or filesystem, once the storage contents
+// have been copied to it and the copy verified. The storage instance is
+// assigned the new volume or filesystem, and the one previously assigned
+// to it is released if its storage provider supports releasing storage,
+// or destroyed otherwise.
+func (sb *storageBackend) CompleteStorageMove(tag names.StorageTag, target names.Tag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete move of storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var oldTag names.Tag
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := sb.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			oldTag = v.VolumeTag()
+		case StorageKindFilesystem:
+			f, err := sb.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			oldTag = f.FilesystemTag()
+		default:
+			return nil, errors.NotSupportedf("moving %s storage", s.Kind())
+		}
+		move, ok := s.Move()
+		if !ok || move.Target() != target {
+			if oldTag == target {
+				// The move has already been completed.
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.NotFoundf("move to %s", names.ReadableString(target))
+		}
+
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: s.doc.Move.targetAssert(),
+			Update: bson.D{
+				{"$set", bson.D{{"constraints.pool", move.Pool}}},
+				{"$unset", bson.D{{"move", nil}}},
+			},
+		}}
+		var swapOps []txn.Op
+		if s.Kind() == StorageKindBlock {
+			swapOps, err = completeVolumeMoveOps(sb, tag, oldTag.(names.VolumeTag), move.Volume)
+		} else {
+			swapOps, err = completeFilesystemMoveOps(sb, tag, oldTag.(names.FilesystemTag), move.Filesystem)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, swapOps...), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// completeVolumeMoveOps returns txn.Ops to assign the new volume to
+// the specified storage instance, and to release or destroy the old.
+func completeVolumeMoveOps(sb *storageBackend, tag names.StorageTag, oldTag, newTag names.VolumeTag) ([]txn.Op, error) {
+	oldVolume, err := getVolumeByTag(sb.mb, oldTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newVolume, err := getVolumeByTag(sb.mb, newTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if newVolume.Life() != Alive {
+		return nil, errors.Errorf("%s is not alive", names.ReadableString(newTag))
+	}
+	release := checkStoragePoolReleasable(sb, oldVolume.pool()) == nil
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     newTag.Id(),
+		Assert: append(bson.D{{"storageid", bson.D{{"$exists", false}}}}, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"storageid", tag.Id()}}}},
+	}, {
+		C:      volumesC,
+		Id:     oldTag.Id(),
+		Assert: bson.D{{"storageid", tag.Id()}},
+		Update: bson.D{{"$unset", bson.D{{"storageid", nil}}}},
+	}}
+	destroyOps, err := destroyVolumeOps(sb, oldVolume, release, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, destroyOps...), nil
+}
+
+// completeFilesystemMoveOps returns txn.Ops to assign the new filesystem
+// to the specified storage instance, and to release or destroy the old.
+func completeFilesystemMoveOps(sb *storageBackend, tag names.StorageTag, oldTag, newTag names.FilesystemTag) ([]txn.Op, error) {
+	oldFilesystem, err := getFilesystemByTag(sb.mb, oldTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newFilesystem, err := getFilesystemByTag(sb.mb, newTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if newFilesystem.Life() != Alive {
+		return nil, errors.Errorf("%s is not alive", names.ReadableString(newTag))
+	}
+	release := checkStoragePoolReleasable(sb, oldFilesystem.pool()) == nil
+	ops := []txn.Op{{
+		C:      filesystemsC,
+		Id:     newTag.Id(),
+		Assert: append(bson.D{{"storageid", bson.D{{"$exists", false}}}}, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"storageid", tag.Id()}}}},
+	}, {
+		C:      filesystemsC,
+		Id:     oldTag.Id(),
+		Assert: bson.D{{"storageid", tag.Id()}},
+		Update: bson.D{{"$unset", bson.D{{"storageid", nil}}}},
+	}}
+	// The volumes backing volume-backed filesystems are assigned
+	// to the storage along with the filesystems.
+	if newFilesystem.doc.VolumeId != "" {
+		ops = append(ops, txn.Op{
+			C:      volumesC,
+			Id:     newFilesystem.doc.VolumeId,
+			Assert: bson.D{{"storageid", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"storageid", tag.Id()}}}},
+		})
+	}
+	if oldFilesystem.doc.VolumeId != "" {
+		ops = append(ops, txn.Op{
+			C:      volumesC,
+			Id:     oldFilesystem.doc.VolumeId,
+			Assert: bson.D{{"storageid", tag.Id()}},
+			Update: bson.D{{"$unset", bson.D{{"storageid", nil}}}},
+		})
+	}
+	destroyOps, err := destroyFilesystemOps(sb, oldFilesystem, release, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, destroyOps...), nil
+}
+
+// storageMoveFilesystemMountedOps returns txn.Ops to record that the
+// given filesystem has been mounted in the storage instance that is
+// being moved to it, if any.
+func storageMoveFilesystemMountedOps(sb *storageBackend, tag names.FilesystemTag) ([]txn.Op, error) {
+	coll, closer := sb.mb.db().GetCollection(storageInstancesC)
+	defer closer()
+	var doc storageInstanceDoc
+	err := coll.Find(bson.D{{"move.filesystem", tag.Id()}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting storage being moved to %s", names.ReadableString(tag))
+	}
+	return []txn.Op{{
+		C:      storageInstancesC,
+		Id:     doc.Id,
+		Assert: bson.D{{"move.filesystem", tag.Id()}},
+		Update: bson.D{{"$set", bson.D{{"move.mounted", true}}}},
+	}}, nil
+}
+
+// SetStorageMoveProgress records the number of bytes of the specified
+// storage instance's contents that have been copied to the volume or
+// filesystem that it is being moved to, so that the copy may be resumed
+// if it is interrupted.
+func (sb *storageBackend) SetStorageMoveProgress(tag names.StorageTag, target names.Tag, copied uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set progress of move of storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		move, ok := s.Move()
+		if !ok || move.Target() != target {
+			return nil, errors.NotFoundf("move to %s", names.ReadableString(target))
+		}
+		if move.Copied == copied {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: s.doc.Move.targetAssert(),
+			Update: bson.D{{"$set", bson.D{{"move.copied", copied}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// CancelStorageMove cancels the in-progress move of the specified
+// storage instance, destroying the volume or filesystem that was
+// provisioned for it.
+func (sb *storageBackend) CancelStorageMove(tag names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel move of storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := s.Move(); !ok {
+			if attempt > 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.NotFoundf("move of storage %q", tag.Id())
+		}
+		return cancelStorageMoveOps(sb, s)
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// cancelStorageMoveOps returns txn.Ops to cancel the in-progress move
+// of the given storage instance.
+func cancelStorageMoveOps(sb *storageBackend, s *storageInstance) ([]txn.Op, error) {
+	move, _ := s.Move()
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     s.doc.Id,
+		Assert: s.doc.Move.targetAssert(),
+		Update: bson.D{{"$unset", bson.D{{"move", nil}}}},
+	}}
+	destroyOps, err := destroyStorageMoveTargetOps(sb, move)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, destroyOps...), nil
+}
+
+// destroyStorageMoveTargetOps returns txn.Ops to destroy the volume or
+// filesystem that a storage instance was being moved to.
+func destroyStorageMoveTargetOps(sb *storageBackend, move StorageMove) ([]txn.Op, error) {
+	if move.Filesystem != (names.FilesystemTag{}) {
+		return destroyStorageMoveFilesystemOps(sb, move.Filesystem)
+	}
+	return destroyStorageMoveVolumeOps(sb, move.Volume)
+}
+
+// destroyStorageMoveVolumeOps returns txn.Ops to destroy the volume
+// that a storage instance was being moved to, if it still exists.
+func destroyStorageMoveVolumeOps(sb *storageBackend, tag names.VolumeTag) ([]txn.Op, error) {
+	v, err := getVolumeByTag(sb.mb, tag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if v.Life() != Alive {
+		return nil, nil
+	}
+	return destroyVolumeOps(sb, v, false, nil)
+}
+
+// destroyStorageMoveFilesystemOps returns txn.Ops to destroy the
+// filesystem that a storage instance was being moved to, if it still
+// exists.
+func destroyStorageMoveFilesystemOps(sb *storageBackend, tag names.FilesystemTag) ([]txn.Op, error) {
+	f, err := getFilesystemByTag(sb.mb, tag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if f.Life() != Alive {
+		return nil, nil
+	}
+	return destroyFilesystemOps(sb, f, false, nil)
+}
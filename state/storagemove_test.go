@@ -0,0 +1,307 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageMoveSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageMoveSuite{})
+
+func (s *StorageMoveSuite) setupProvisionedStorage(c *gc.C, pool string) (*state.Unit, names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, storageTag, volumeTag
+}
+
+func (s *StorageMoveSuite) setupProvisionedFilesystem(c *gc.C, pool string) (*state.Unit, names.StorageTag, names.FilesystemTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{Size: 1024, FilesystemId: "fs"})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, storageTag, filesystemTag
+}
+
+func (s *StorageMoveSuite) storageInstance(c *gc.C, tag names.StorageTag) state.StorageInstance {
+	si, err := s.storageBackend.StorageInstance(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	return si
+}
+
+func (s *StorageMoveSuite) TestMoveStorageInstance(c *gc.C) {
+	u, storageTag, volumeTag := s.setupProvisionedStorage(c, "loop-pool")
+	_, ok := s.storageInstance(c, storageTag).Move()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+
+	move, ok := s.storageInstance(c, storageTag).Move()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(move, jc.DeepEquals, state.StorageMove{
+		Pool:   "persistent-block",
+		Volume: names.NewVolumeTag("1"),
+	})
+
+	// The new volume is attached to the unit's machine, but
+	// is not assigned to the storage until the move completes.
+	target := s.volume(c, move.Volume)
+	params, ok := target.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "persistent-block")
+	c.Assert(params.Size, gc.Equals, uint64(1024))
+	_, err = target.StorageInstance()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+	machine := unitMachine(c, s.State, u)
+	s.volumeAttachment(c, machine.MachineTag(), move.Volume)
+	c.Assert(s.storageInstanceVolume(c, storageTag).VolumeTag(), gc.Equals, volumeTag)
+
+	// Moving to the same pool again is a no-op.
+	err = s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.MoveStorageInstance(storageTag, "modelscoped-block")
+	c.Assert(err, gc.ErrorMatches, `cannot move storage "data/0" to pool "modelscoped-block": storage is already being moved to pool "persistent-block"`)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageInstanceSamePool(c *gc.C) {
+	_, storageTag, _ := s.setupProvisionedStorage(c, "loop-pool")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "loop-pool")
+	c.Assert(err, gc.ErrorMatches, `cannot move storage "data/0" to pool "loop-pool": storage is already in the pool`)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageInstanceNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageMoveSuite) TestMoveStorageInstanceFilesystem(c *gc.C) {
+	u, storageTag, filesystemTag := s.setupProvisionedFilesystem(c, "tmpfs-pool")
+
+	err := s.storageBackend.MoveStorageInstance(storageTag, "loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+
+	move, ok := s.storageInstance(c, storageTag).Move()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(move.Pool, gc.Equals, "loop-pool")
+	c.Assert(move.Volume, gc.Equals, names.VolumeTag{})
+	c.Assert(move.Target(), gc.Equals, move.Filesystem)
+
+	// The new filesystem is mounted at a location of its own on
+	// the unit's machine, but is not assigned to the storage until
+	// the move completes.
+	target := s.filesystem(c, move.Filesystem)
+	params, ok := target.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "loop-pool")
+	c.Assert(params.Size, gc.Equals, uint64(1024))
+	_, err = target.Storage()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+	machine := unitMachine(c, s.State, u)
+	attachmentParams, ok := s.filesystemAttachment(c, machine.MachineTag(), move.Filesystem).Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(attachmentParams.Location, gc.Equals, "/var/lib/juju/storage/data/0-loop-pool")
+	c.Assert(s.storageInstanceFilesystem(c, storageTag).FilesystemTag(), gc.Equals, filesystemTag)
+}
+
+func (s *StorageMoveSuite) TestSetStorageMoveProgress(c *gc.C) {
+	_, storageTag, _ := s.setupProvisionedStorage(c, "loop-pool")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ := s.storageInstance(c, storageTag).Move()
+
+	err = s.storageBackend.SetStorageMoveProgress(storageTag, move.Volume, 512)
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ = s.storageInstance(c, storageTag).Move()
+	c.Assert(move.Copied, gc.Equals, uint64(512))
+
+	err = s.storageBackend.SetStorageMoveProgress(storageTag, names.NewVolumeTag("2"), 1024)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cannot set progress of move of storage "data/0": move to volume 2 not found`)
+
+	// The progress is discarded if the move is cancelled.
+	err = s.storageBackend.CancelStorageMove(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ = s.storageInstance(c, storageTag).Move()
+	c.Assert(move.Copied, gc.Equals, uint64(0))
+}
+
+func (s *StorageMoveSuite) TestCompleteStorageMove(c *gc.C) {
+	_, storageTag, volumeTag := s.setupProvisionedStorage(c, "loop-pool")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	targetTag := names.NewVolumeTag("1")
+
+	err = s.storageBackend.CompleteStorageMove(storageTag, targetTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	si := s.storageInstance(c, storageTag)
+	_, ok := si.Move()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(si.Pool(), gc.Equals, "persistent-block")
+	c.Assert(s.storageInstanceVolume(c, storageTag).VolumeTag(), gc.Equals, targetTag)
+
+	// The loop provider does not support releasing storage,
+	// so the old volume is destroyed.
+	old := s.volume(c, volumeTag)
+	c.Assert(old.Life(), gc.Equals, state.Dying)
+	c.Assert(old.Releasing(), jc.IsFalse)
+	_, err = old.StorageInstance()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+
+	// Completing the move again is a no-op.
+	err = s.storageBackend.CompleteStorageMove(storageTag, targetTag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageMoveSuite) TestCompleteStorageMoveReleasesVolume(c *gc.C) {
+	_, storageTag, volumeTag := s.setupProvisionedStorage(c, "modelscoped-block")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ := s.storageInstance(c, storageTag).Move()
+
+	err = s.storageBackend.CompleteStorageMove(storageTag, move.Volume)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old := s.volume(c, volumeTag)
+	c.Assert(old.Life(), gc.Equals, state.Dying)
+	c.Assert(old.Releasing(), jc.IsTrue)
+}
+
+func (s *StorageMoveSuite) TestCompleteStorageMoveFilesystem(c *gc.C) {
+	_, storageTag, filesystemTag := s.setupProvisionedFilesystem(c, "tmpfs-pool")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ := s.storageInstance(c, storageTag).Move()
+
+	err = s.storageBackend.CompleteStorageMove(storageTag, move.Filesystem)
+	c.Assert(err, jc.ErrorIsNil)
+
+	si := s.storageInstance(c, storageTag)
+	_, ok := si.Move()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(si.Pool(), gc.Equals, "loop-pool")
+	c.Assert(s.storageInstanceFilesystem(c, storageTag).FilesystemTag(), gc.Equals, move.Filesystem)
+	// The volume backing the new filesystem is assigned to the storage too.
+	backingVolume := s.filesystemVolume(c, move.Filesystem)
+	c.Assert(s.storageInstanceVolume(c, storageTag).VolumeTag(), gc.Equals, backingVolume.VolumeTag())
+
+	old := s.filesystem(c, filesystemTag)
+	c.Assert(old.Life(), gc.Equals, state.Dying)
+	_, err = old.Storage()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+
+	// Completing the move again is a no-op.
+	err = s.storageBackend.CompleteStorageMove(storageTag, move.Filesystem)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageMoveSuite) TestCompleteStorageMoveWrongVolume(c *gc.C) {
+	_, storageTag, _ := s.setupProvisionedStorage(c, "loop-pool")
+	err := s.storageBackend.CompleteStorageMove(storageTag, names.NewVolumeTag("1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cannot complete move of storage "data/0": move to volume 1 not found`)
+}
+
+func (s *StorageMoveSuite) TestCancelStorageMove(c *gc.C) {
+	_, storageTag, volumeTag := s.setupProvisionedStorage(c, "loop-pool")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ := s.storageInstance(c, storageTag).Move()
+
+	err = s.storageBackend.CancelStorageMove(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	si := s.storageInstance(c, storageTag)
+	_, ok := si.Move()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(si.Pool(), gc.Equals, "loop-pool")
+	c.Assert(s.volume(c, move.Volume).Life(), gc.Equals, state.Dying)
+	c.Assert(s.storageInstanceVolume(c, storageTag).VolumeTag(), gc.Equals, volumeTag)
+
+	err = s.storageBackend.CancelStorageMove(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageMoveSuite) TestCancelStorageMoveFilesystem(c *gc.C) {
+	_, storageTag, filesystemTag := s.setupProvisionedFilesystem(c, "tmpfs-pool")
+	err := s.storageBackend.MoveStorageInstance(storageTag, "loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ := s.storageInstance(c, storageTag).Move()
+
+	err = s.storageBackend.CancelStorageMove(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	si := s.storageInstance(c, storageTag)
+	_, ok := si.Move()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(si.Pool(), gc.Equals, "tmpfs-pool")
+	c.Assert(s.filesystem(c, move.Filesystem).Life(), gc.Equals, state.Dying)
+	c.Assert(s.storageInstanceFilesystem(c, storageTag).FilesystemTag(), gc.Equals, filesystemTag)
+}
+
+func (s *StorageMoveSuite) TestWatchStorageInstance(c *gc.C) {
+	_, storageTag, _ := s.setupProvisionedStorage(c, "loop-pool")
+	s.WaitForModelWatchersIdle(c, s.Model.UUID())
+
+	w := s.storageBackend.WatchStorageInstance(storageTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.storageBackend.MoveStorageInstance(storageTag, "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.storageBackend.CancelStorageMove(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *StorageMoveSuite) TestWatchStorageInstanceFilesystemMounted(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedFilesystem(c, "rootfs")
+	machine := unitMachine(c, s.State, u)
+	err := machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.MoveStorageInstance(storageTag, "tmpfs-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	move, _ := s.storageInstance(c, storageTag).Move()
+	err = s.storageBackend.SetFilesystemInfo(move.Filesystem, state.FilesystemInfo{Size: 1024, FilesystemId: "fs-new"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.WaitForModelWatchersIdle(c, s.Model.UUID())
+
+	w := s.storageBackend.WatchStorageInstance(storageTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Mounting the filesystem being moved to notifies watchers
+	// of the storage instance that the move target is ready.
+	err = s.storageBackend.SetFilesystemAttachmentInfo(
+		machine.MachineTag(), move.Filesystem,
+		state.FilesystemAttachmentInfo{MountPoint: "/var/lib/juju/storage/data/0-tmpfs-pool"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
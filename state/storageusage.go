@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+)
+
+const (
+	// storageUsageWarningKey is the status data key that marks a unit's
+	// workload status, or a filesystem or volume status message, as
+	// having been set because the storage is nearly full.
+	storageUsageWarningKey = "storage-usage-warning"
+
+	// The status data keys that record the status that was replaced by
+	// a storage usage warning, so it can be restored when the warning is
+	// cleared.
+	previousStatusKey  = "previous-status"
+	previousMessageKey = "previous-message"
+)
+
+// SetStorageUsageWarning sets the unit's workload status to warning with
+// the specified message, to warn that storage attached to the unit is
+// nearly full. The workload status that is replaced is restored when the
+// warning is cleared by calling SetStorageUsageWarning with an empty
+// message.
+//
+// If the charm sets the workload status while the warning is in effect,
+// the charm's status takes precedence and there is nothing to restore.
+func (u *Unit) SetStorageUsageWarning(message string) error {
+	current, err := getStatus(u.st.db(), u.globalKey(), "unit")
+	if err != nil {
+		return errors.Annotatef(err, "getting status of unit %q", u.Name())
+	}
+	_, warned := current.Data[storageUsageWarningKey]
+	now := u.st.clock().Now()
+	if message == "" {
+		if !warned {
+			return nil
+		}
+		previousStatus, _ := current.Data[previousStatusKey].(string)
+		previousMessage, _ := current.Data[previousMessageKey].(string)
+		return errors.Trace(u.SetStatus(status.StatusInfo{
+			Status:  status.Status(previousStatus),
+			Message: previousMessage,
+			Since:   &now,
+		}))
+	}
+	if warned && current.Message == message {
+		return nil
+	}
+	previousStatus, previousMessage := string(current.Status), current.Message
+	if warned {
+		previousStatus, _ = current.Data[previousStatusKey].(string)
+		previousMessage, _ = current.Data[previousMessageKey].(string)
+	}
+	// Warning is not a status that charms may set, so
+	// it is written directly rather than with SetStatus.
+	return errors.Trace(setStatus(u.st.db(), setStatusParams{
+		badge:     "unit",
+		globalKey: u.globalKey(),
+		status:    status.Warning,
+		message:   message,
+		rawData: map[string]interface{}{
+			storageUsageWarningKey: true,
+			previousStatusKey:      previousStatus,
+			previousMessageKey:     previousMessage,
+		},
+		updated: &now,
+	}))
+}
+
+// SetStorageUsageWarning sets the status message of the filesystem, or
+// if there is none the volume, assigned to the specified storage
+// instance, to warn that the storage is nearly full. The storage's own
+// status is left unchanged, as the owning units are warned by setting
+// their workload status with Unit.SetStorageUsageWarning. The message
+// that is replaced is restored when the warning is cleared by calling
+// SetStorageUsageWarning with an empty message.
+//
+// If the storage provisioner sets the status while the warning is in
+// effect, its status takes precedence and there is nothing to restore.
+func (sb *storageBackend) SetStorageUsageWarning(tag names.StorageTag, message string) error {
+	badge, globalKey, err := sb.storageStatusKey(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := getStatus(sb.mb.db(), globalKey, badge)
+	if err != nil {
+		return errors.Annotatef(err, "getting %s status of storage %q", badge, tag.Id())
+	}
+	_, warned := current.Data[storageUsageWarningKey]
+	now := sb.mb.clock().Now()
+	if message == "" {
+		if !warned {
+			return nil
+		}
+		previousMessage, _ := current.Data[previousMessageKey].(string)
+		return errors.Trace(setStatus(sb.mb.db(), setStatusParams{
+			badge:     badge,
+			globalKey: globalKey,
+			status:    current.Status,
+			message:   previousMessage,
+			updated:   &now,
+		}))
+	}
+	if warned && current.Message == message {
+		return nil
+	}
+	previousMessage := current.Message
+	if warned {
+		previousMessage, _ = current.Data[previousMessageKey].(string)
+	}
+	return errors.Trace(setStatus(sb.mb.db(), setStatusParams{
+		badge:     badge,
+		globalKey: globalKey,
+		status:    current.Status,
+		message:   message,
+		rawData: map[string]interface{}{
+			storageUsageWarningKey: true,
+			previousMessageKey:     previousMessage,
+		},
+		updated: &now,
+	}))
+}
+
+// storageStatusKey returns the status badge and global key of the
+// filesystem, or if there is none the volume, assigned to the specified
+// storage instance.
+func (sb *storageBackend) storageStatusKey(tag names.StorageTag) (badge, globalKey string, _ error) {
+	f, err := sb.storageInstanceFilesystem(tag)
+	if err == nil {
+		return "filesystem", filesystemGlobalKey(f.FilesystemTag().Id()), nil
+	} else if !errors.IsNotFound(err) {
+		return "", "", errors.Trace(err)
+	}
+	v, err := sb.storageInstanceVolume(tag)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return "volume", volumeGlobalKey(v.VolumeTag().Id()), nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StorageUsageWarningSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageUsageWarningSuite{})
+
+func (s *StorageUsageWarningSuite) setStatus(c *gc.C, setter status.StatusSetter, st status.Status, message string) {
+	now := coretesting.NonZeroTime()
+	err := setter.SetStatus(status.StatusInfo{
+		Status:  st,
+		Message: message,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageUsageWarningSuite) assertStatus(c *gc.C, getter status.StatusGetter, expect status.Status, message string) {
+	info, err := getter.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, expect)
+	c.Assert(info.Message, gc.Equals, message)
+}
+
+func (s *StorageUsageWarningSuite) TestSetStorageUsageWarningFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	s.setStatus(c, filesystem, status.Attached, "mounted")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "95% full")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, filesystem, status.Attached, "95% full")
+
+	// Updating the warning does not lose the original message.
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "97% full")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, filesystem, status.Attached, "97% full")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, filesystem, status.Attached, "mounted")
+}
+
+func (s *StorageUsageWarningSuite) TestSetStorageUsageWarningVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	s.setStatus(c, volume, status.Attached, "")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "95% full")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, volume, status.Attached, "95% full")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, volume, status.Attached, "")
+}
+
+func (s *StorageUsageWarningSuite) TestSetStorageUsageWarningLeavesUnitStatus(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setStatus(c, u, status.Active, "ready")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "95% full")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, u, status.Active, "ready")
+}
+
+func (s *StorageUsageWarningSuite) TestClearStorageUsageWarningNotWarned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	s.setStatus(c, filesystem, status.Attached, "mounted")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, filesystem, status.Attached, "mounted")
+}
+
+func (s *StorageUsageWarningSuite) TestClearStorageUsageWarningProvisionerStatus(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	s.setStatus(c, filesystem, status.Attached, "mounted")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "95% full")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage provisioner's status takes precedence over the warning.
+	s.setStatus(c, filesystem, status.Detaching, "")
+
+	err = s.storageBackend.SetStorageUsageWarning(storageTag, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, filesystem, status.Detaching, "")
+}
+
+func (s *StorageUsageWarningSuite) TestSetUnitStorageUsageWarning(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	s.setStatus(c, u, status.Active, "ready")
+
+	err := u.SetStorageUsageWarning("storage data/0 is 95% full")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, u, status.Warning, "storage data/0 is 95% full")
+
+	// Updating the warning does not lose the original status.
+	err = u.SetStorageUsageWarning("storage data/0 is 97% full")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, u, status.Warning, "storage data/0 is 97% full")
+
+	err = u.SetStorageUsageWarning("")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, u, status.Active, "ready")
+}
+
+func (s *StorageUsageWarningSuite) TestClearUnitStorageUsageWarningNotWarned(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	s.setStatus(c, u, status.Active, "ready")
+
+	err := u.SetStorageUsageWarning("")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, u, status.Active, "ready")
+}
+
+func (s *StorageUsageWarningSuite) TestClearUnitStorageUsageWarningCharmStatus(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	s.setStatus(c, u, status.Active, "ready")
+
+	err := u.SetStorageUsageWarning("storage data/0 is 95% full")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The charm's own status takes precedence over the warning.
+	s.setStatus(c, u, status.Maintenance, "cleaning up")
+
+	err = u.SetStorageUsageWarning("")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, u, status.Maintenance, "cleaning up")
+}
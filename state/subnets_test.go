@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type SubnetSuite struct {
@@ -390,3 +391,21 @@ func (s *SubnetSuite) TestUpdateNonEmpty(c *gc.C) {
 	c.Assert(subnet.VLANTag(), gc.Equals, expectedSubnetInfo.VLANTag)
 	c.Assert(subnet.AvailabilityZones(), gc.DeepEquals, expectedSubnetInfo.AvailabilityZones)
 }
+
+func (s *SubnetSuite) TestWatchSubnetChanges(c *gc.C) {
+	w := s.State.WatchSubnetChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	subnet, err := s.State.AddSubnet(network.SubnetInfo{CIDR: "8.8.8.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Moving the subnet to another space is reported.
+	_, err = s.State.AddSpace("testme", network.Id("2"), nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.Update(network.SubnetInfo{CIDR: subnet.CIDR(), SpaceName: "testme"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -92,7 +92,8 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
-	Tools                  *tools.Tools `bson:",omitempty"`
+	Paused                 *unitPauseDoc `bson:"paused,omitempty"`
+	Tools                  *tools.Tools  `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
@@ -1412,12 +1413,31 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 // opening the requested range conflicts with another already opened range on
 // the same subnet and and the unit's assigned machine.
 func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.openPortsOnSubnet(subnetID, "", protocol, fromPort, toPort)
+}
+
+func (u *Unit) openPortsOnSubnet(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
+	if endpoint != "" {
+		app, err := u.Application()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		endpoints, err := app.Endpoints()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !hasEndpoint(endpoints, endpoint) {
+			return errors.NotValidf("endpoint %q for application %q", endpoint, app.Name())
+		}
+	}
+
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
 		return errors.Annotatef(err, "unit %q has no assigned machine", u)
@@ -1490,6 +1510,14 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 	return u.OpenPortsOnSubnet("", protocol, fromPort, toPort)
 }
 
+// OpenPortsForEndpoint opens the given port range and protocol for the
+// unit, for the specified endpoint of the unit's application. If the
+// unit has already opened the range, it is opened for the endpoint
+// instead. An empty endpoint opens the range for all endpoints.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return u.openPortsOnSubnet("", endpoint, protocol, fromPort, toPort)
+}
+
 // ClosePorts closes the given port range and protocol for the unit.
 //
 // TODO(dimitern): This should be removed once we use ClosePortsOnSubnet across
@@ -1571,6 +1599,34 @@ func (u *Unit) OpenedPorts() ([]corenetwork.PortRange, error) {
 	return u.OpenedPortsOnSubnet("")
 }
 
+// OpenedEndpointPorts returns the open port ranges of the unit, keyed by
+// the name of the endpoint that they are opened for. Port ranges opened
+// for all endpoints are keyed by the empty string.
+func (u *Unit) OpenedEndpointPorts() (map[string][]corenetwork.PortRange, error) {
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getPorts(u.st, machineID, "")
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed getting ports for unit %q", u)
+	}
+	result := make(map[string][]corenetwork.PortRange)
+	for _, port := range machinePorts.PortsForUnit(u.Name()) {
+		result[port.Endpoint] = append(result[port.Endpoint], corenetwork.PortRange{
+			Protocol: port.Protocol,
+			FromPort: port.FromPort,
+			ToPort:   port.ToPort,
+		})
+	}
+	for _, ranges := range result {
+		corenetwork.SortPortRanges(ranges)
+	}
+	return result, nil
+}
+
 // CharmURL returns the charm URL this unit is currently using.
 func (u *Unit) CharmURL() (*charm.URL, bool) {
 	if u.doc.CharmURL == nil {
@@ -2491,9 +2547,10 @@ func storageParamsForStorageInstance(
 			}
 		} else if errors.IsNotFound(err) {
 			filesystemParams := FilesystemParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:    storage.StorageTag(),
+				Pool:       storage.doc.Constraints.Pool,
+				Size:       storage.doc.Constraints.Size,
+				SnapshotId: storage.doc.Constraints.SnapshotId,
 			}
 			filesystems = append(filesystems, HostFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
@@ -2536,9 +2593,10 @@ func storageParamsForStorageInstance(
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		} else if errors.IsNotFound(err) {
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:    storage.StorageTag(),
+				Pool:       storage.doc.Constraints.Pool,
+				Size:       storage.doc.Constraints.Size,
+				SnapshotId: storage.doc.Constraints.SnapshotId,
 			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -2903,6 +2961,21 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	payloadWithDefaults, err := u.PrepareActionPayload(name, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+}
+
+// PrepareActionPayload validates the payload for the named action
+// against the unit's charm, and returns it with any defaults inserted.
+func (u *Unit) PrepareActionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2924,16 +2997,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	m, err := u.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return m.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec.InsertDefaults(payload)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
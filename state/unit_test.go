@@ -1558,6 +1558,28 @@ func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	})
 }
 
+func (s *UnitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPorts("tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.unit.OpenedEndpointPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[string][]corenetwork.PortRange{
+		"url": {{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+		"":    {{FromPort: 8080, ToPort: 8080, Protocol: "tcp"}},
+	})
+
+	err = s.unit.OpenPortsForEndpoint("admin", "tcp", 443, 443)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 443-443/tcp \("wordpress/0", endpoint "admin"\) for unit "wordpress/0" on subnet "": endpoint "admin" for application "wordpress" not valid`)
+}
+
 func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1575,7 +1597,7 @@ func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 
 	// Now remove the unit and check again.
@@ -1619,10 +1641,10 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 
 	// Now remove the first unit and check again.
@@ -1639,7 +1661,7 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), gc.HasLen, 0)
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 }
 
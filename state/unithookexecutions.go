@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxUnitHookExecutions is the number of most recent hook executions
+// kept for each unit.
+const maxUnitHookExecutions = 20
+
+// HookExecution records the timing and outcome of a hook run by a unit.
+type HookExecution struct {
+	// Hook is the name of the hook that was run.
+	Hook string `bson:"hook"`
+
+	// Outcome describes how the execution ended, eg "succeeded" or
+	// "failed".
+	Outcome string `bson:"outcome"`
+
+	// Queued is when the unit agent queued the hook.
+	Queued time.Time `bson:"queued"`
+
+	// Started is when the hook started executing.
+	Started time.Time `bson:"started"`
+
+	// Finished is when the hook finished executing.
+	Finished time.Time `bson:"finished"`
+}
+
+// unitHookExecutionsDoc records the most recent hook executions
+// reported by a unit agent.
+type unitHookExecutionsDoc struct {
+	// DocID is the unit's global key.
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	TxnRevno  int64  `bson:"txn-revno"`
+
+	// Executions holds the executions, oldest first.
+	Executions []HookExecution `bson:"executions"`
+}
+
+// HookExecutions returns the most recent hook executions reported for
+// the unit, oldest first.
+func (u *Unit) HookExecutions() ([]HookExecution, error) {
+	coll, closer := u.st.db().GetCollection(unitHookExecutionsC)
+	defer closer()
+
+	var doc unitHookExecutionsDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook executions for unit %q", u.Name())
+	}
+	return doc.Executions, nil
+}
+
+// RecordHookExecutions appends the given hook executions to those
+// recorded for the unit, discarding all but the most recent ones.
+// Executions reported once the unit is dead are ignored.
+func (u *Unit) RecordHookExecutions(executions []HookExecution) error {
+	if len(executions) == 0 {
+		return nil
+	}
+	docID := u.st.docID(u.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			notDead, err := isNotDead(u.st, unitsC, u.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		coll, closer := u.st.db().GetCollection(unitHookExecutionsC)
+		defer closer()
+		var doc unitHookExecutionsDoc
+		err := coll.FindId(docID).One(&doc)
+		if err != nil && err != mgo.ErrNotFound {
+			return nil, errors.Trace(err)
+		}
+		found := err == nil
+
+		all := append(doc.Executions, executions...)
+		if len(all) > maxUnitHookExecutions {
+			all = all[len(all)-maxUnitHookExecutions:]
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		if !found {
+			ops = append(ops, txn.Op{
+				C:      unitHookExecutionsC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &unitHookExecutionsDoc{
+					DocID:      docID,
+					ModelUUID:  u.st.ModelUUID(),
+					Executions: all,
+				},
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      unitHookExecutionsC,
+				Id:     docID,
+				Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+				Update: bson.D{{"$set", bson.D{{"executions", all}}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record hook executions for unit %q", u.Name())
+	}
+	return nil
+}
+
+// removeUnitHookExecutionsOp returns an operation removing the hook
+// executions recorded for the unit with the given global key.
+func removeUnitHookExecutionsOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitHookExecutionsC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
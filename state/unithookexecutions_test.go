@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UnitHookExecutionsSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitHookExecutionsSuite{})
+
+func (s *UnitHookExecutionsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func hookExecution(hook string, started time.Time) state.HookExecution {
+	return state.HookExecution{
+		Hook:     hook,
+		Outcome:  "succeeded",
+		Queued:   started.Add(-time.Second),
+		Started:  started,
+		Finished: started.Add(2 * time.Second),
+	}
+}
+
+func (s *UnitHookExecutionsSuite) TestHookExecutionsEmpty(c *gc.C) {
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
+
+func (s *UnitHookExecutionsSuite) TestRecordHookExecutions(c *gc.C) {
+	now := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	first := hookExecution("install", now)
+	second := hookExecution("config-changed", now.Add(time.Minute))
+	second.Outcome = "failed"
+
+	err := s.unit.RecordHookExecutions([]state.HookExecution{first})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecutions([]state.HookExecution{second})
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 2)
+	for i, expected := range []state.HookExecution{first, second} {
+		c.Check(executions[i].Hook, gc.Equals, expected.Hook)
+		c.Check(executions[i].Outcome, gc.Equals, expected.Outcome)
+		c.Check(executions[i].Queued.Equal(expected.Queued), jc.IsTrue)
+		c.Check(executions[i].Started.Equal(expected.Started), jc.IsTrue)
+		c.Check(executions[i].Finished.Equal(expected.Finished), jc.IsTrue)
+	}
+}
+
+func (s *UnitHookExecutionsSuite) TestRecordHookExecutionsKeepsMostRecent(c *gc.C) {
+	now := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	var executions []state.HookExecution
+	for i := 0; i < 25; i++ {
+		executions = append(executions, hookExecution(fmt.Sprintf("hook-%d", i), now.Add(time.Duration(i)*time.Minute)))
+	}
+	err := s.unit.RecordHookExecutions(executions[:10])
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecutions(executions[10:])
+	c.Assert(err, jc.ErrorIsNil)
+
+	recorded, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, gc.HasLen, 20)
+	c.Assert(recorded[0].Hook, gc.Equals, "hook-5")
+	c.Assert(recorded[19].Hook, gc.Equals, "hook-24")
+}
+
+func (s *UnitHookExecutionsSuite) TestHookExecutionsRemovedWithUnit(c *gc.C) {
+	now := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	err := s.unit.RecordHookExecutions([]state.HookExecution{hookExecution("install", now)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetCollection(s.State, "unithookexecutions")
+	defer closer()
+	count, err := coll.Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
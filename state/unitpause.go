@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UnitPause describes why the hooks of a unit are paused.
+type UnitPause struct {
+	// By is the name of the user who paused the unit.
+	By string
+
+	// Reason is the reason given for pausing the unit.
+	Reason string
+
+	// Since is when the unit was paused.
+	Since time.Time
+}
+
+// unitPauseDoc is the persistent form of a UnitPause, held in the
+// unit's document.
+type unitPauseDoc struct {
+	By     string    `bson:"by"`
+	Reason string    `bson:"reason"`
+	Since  time.Time `bson:"since"`
+}
+
+// Paused returns why the unit's hooks are paused, and whether they are.
+func (u *Unit) Paused() (UnitPause, bool) {
+	if u.doc.Paused == nil {
+		return UnitPause{}, false
+	}
+	return UnitPause{
+		By:     u.doc.Paused.By,
+		Reason: u.doc.Paused.Reason,
+		Since:  u.doc.Paused.Since,
+	}, true
+}
+
+// Pause stops the unit agent from running hooks for the unit until it
+// is resumed. The agent keeps running, and the events that would
+// trigger hooks are handled once the unit is resumed. Pausing a paused
+// unit replaces who paused it and why.
+func (u *Unit) Pause(by, reason string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot pause unit %q", u)
+	doc := &unitPauseDoc{
+		By:     by,
+		Reason: reason,
+		Since:  u.st.clock().Now().UTC(),
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"paused", doc}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == nil {
+		u.doc.Paused = doc
+		return nil
+	} else if err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	if ok, err := isNotDead(u.st, unitsC, u.doc.DocID); err != nil {
+		return errors.Trace(err)
+	} else if !ok {
+		return ErrDead
+	}
+	return errors.Errorf("unexpected transaction failure")
+}
+
+// Resume lets the unit agent run hooks for a paused unit again.
+// Resuming a unit that is not paused does nothing.
+func (u *Unit) Resume() error {
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"paused", nil}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("unit %q", u)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot resume unit %q", u)
+	}
+	u.doc.Paused = nil
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type UnitPauseSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitPauseSuite{})
+
+func (s *UnitPauseSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitPauseSuite) TestNotPaused(c *gc.C) {
+	_, paused := s.unit.Paused()
+	c.Assert(paused, jc.IsFalse)
+}
+
+func (s *UnitPauseSuite) TestPause(c *gc.C) {
+	err := s.unit.Pause("admin", "kernel upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	pause, paused := s.unit.Paused()
+	c.Assert(paused, jc.IsTrue)
+	c.Assert(pause.By, gc.Equals, "admin")
+	c.Assert(pause.Reason, gc.Equals, "kernel upgrade")
+	c.Assert(pause.Since.IsZero(), jc.IsFalse)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	refreshed, paused := s.unit.Paused()
+	c.Assert(paused, jc.IsTrue)
+	c.Assert(refreshed.By, gc.Equals, "admin")
+	c.Assert(refreshed.Reason, gc.Equals, "kernel upgrade")
+	c.Assert(refreshed.Since.Equal(pause.Since), jc.IsTrue)
+}
+
+func (s *UnitPauseSuite) TestPauseReplacesReason(c *gc.C) {
+	err := s.unit.Pause("admin", "kernel upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Pause("bob", "disk full")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	pause, paused := s.unit.Paused()
+	c.Assert(paused, jc.IsTrue)
+	c.Assert(pause.By, gc.Equals, "bob")
+	c.Assert(pause.Reason, gc.Equals, "disk full")
+}
+
+func (s *UnitPauseSuite) TestPauseDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Pause("admin", "")
+	c.Assert(err, gc.ErrorMatches, `cannot pause unit ".*": not found or dead`)
+}
+
+func (s *UnitPauseSuite) TestResume(c *gc.C) {
+	err := s.unit.Pause("admin", "kernel upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	_, paused := s.unit.Paused()
+	c.Assert(paused, jc.IsFalse)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, paused = s.unit.Paused()
+	c.Assert(paused, jc.IsFalse)
+}
+
+func (s *UnitPauseSuite) TestResumeNotPaused(c *gc.C) {
+	err := s.unit.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	_, paused := s.unit.Paused()
+	c.Assert(paused, jc.IsFalse)
+}
+
+func (s *UnitPauseSuite) TestPauseTriggersWatcher(c *gc.C) {
+	w := s.unit.Watch()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.unit.Pause("admin", "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.unit.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	mgoutils "github.com/juju/juju/mongo/utils"
+)
+
+// unitStateDoc records the key/value state a charm keeps for one of its
+// units between hook executions.
+type unitStateDoc struct {
+	// DocID is the unit's global key.
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// State holds the unit's state, with keys escaped for mongo.
+	State map[string]string `bson:"state"`
+}
+
+// State returns the key/value state stored for the unit by its charm.
+// A unit without any stored state returns an empty map.
+func (u *Unit) State() (map[string]string, error) {
+	coll, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get state for unit %q", u.Name())
+	}
+	return unescapeStateKeys(doc.State), nil
+}
+
+// SetState replaces the key/value state stored for the unit by its
+// charm. Setting an empty state removes any stored state. The state
+// cannot be changed once the unit is dead.
+func (u *Unit) SetState(state map[string]string) error {
+	escaped := escapeStateKeys(state)
+	docID := u.st.docID(u.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			notDead, err := isNotDead(u.st, unitsC, u.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, errors.Errorf("unit %q is dead", u.Name())
+			}
+		}
+		coll, closer := u.st.db().GetCollection(unitStatesC)
+		defer closer()
+		count, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		switch {
+		case len(escaped) == 0 && count == 0:
+			return nil, jujutxn.ErrNoOperations
+		case len(escaped) == 0:
+			ops = append(ops, removeUnitStateOp(u.st, u.globalKey()))
+		case count == 0:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &unitStateDoc{
+					DocID:     docID,
+					ModelUUID: u.st.ModelUUID(),
+					State:     escaped,
+				},
+			})
+		default:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     docID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"state", escaped}}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set state for unit %q", u.Name())
+	}
+	return nil
+}
+
+// removeUnitStateOp returns an operation removing any state stored for
+// the unit with the given global key.
+func removeUnitStateOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+func escapeStateKeys(state map[string]string) map[string]string {
+	result := make(map[string]string, len(state))
+	for key, value := range state {
+		result[mgoutils.EscapeKey(key)] = value
+	}
+	return result
+}
+
+func unescapeStateKeys(state map[string]string) map[string]string {
+	result := make(map[string]string, len(state))
+	for key, value := range state {
+		result[mgoutils.UnescapeKey(key)] = value
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UnitStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestStateEmpty(c *gc.C) {
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestSetState(c *gc.C) {
+	expected := map[string]string{
+		"leader":      "no",
+		"seen.peers":  "2",
+		"$cost":       "3",
+		"multi\nline": "value",
+	}
+	err := s.unit.SetState(expected)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, expected)
+}
+
+func (s *UnitStateSuite) TestSetStateReplaces(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"one": "1", "two": "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(map[string]string{"two": "deux", "three": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, map[string]string{"two": "deux", "three": "3"})
+}
+
+func (s *UnitStateSuite) TestSetStateEmptyRemoves(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"one": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.HasLen, 0)
+
+	// Clearing state which isn't there is fine.
+	err = s.unit.SetState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitStateSuite) TestSetStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(map[string]string{"one": "1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit ".*": unit ".*" is dead`)
+}
+
+func (s *UnitStateSuite) TestStateRemovedWithUnit(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"one": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetCollection(s.State, "unitstates")
+	defer closer()
+	count, err := coll.Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
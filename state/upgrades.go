@@ -2589,3 +2589,56 @@ func EnsureRelationApplicationSettings(pool *StatePool) error {
 		return errors.Trace(st.db().RunTransaction(ops))
 	}))
 }
+
+// AddOfferConnectionCounts records the number of connections to each
+// application offer on the offer doc, so that offer connection limits
+// can be asserted when connections are added.
+func AddOfferConnectionCounts(pool *StatePool) error {
+	return errors.Trace(runForAllModelStates(pool, func(st *State) error {
+		connsCol, closer := st.db().GetCollection(offerConnectionsC)
+		defer closer()
+
+		counts := make(map[string]int)
+		connsIter := connsCol.Find(nil).Iter()
+		defer connsIter.Close()
+
+		var connDoc offerConnectionDoc
+		for connsIter.Next(&connDoc) {
+			counts[connDoc.OfferUUID]++
+		}
+		if err := connsIter.Close(); err != nil {
+			return errors.Trace(err)
+		}
+
+		offersCol, closer := st.db().GetCollection(applicationOffersC)
+		defer closer()
+
+		var offerDocs []applicationOfferDoc
+		if err := offersCol.Find(nil).All(&offerDocs); err != nil {
+			return errors.Trace(err)
+		}
+
+		var ops []txn.Op
+		for _, doc := range offerDocs {
+			count := counts[doc.OfferUUID]
+			if doc.ConnectionCount == count {
+				continue
+			}
+			update := bson.D{{"$set", bson.D{{"connection-count", count}}}}
+			if count == 0 {
+				update = bson.D{{"$unset", bson.D{{"connection-count", 1}}}}
+			}
+			ops = append(ops, txn.Op{
+				C:      applicationOffersC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Update: update,
+			})
+		}
+
+		if len(ops) == 0 {
+			return nil
+		}
+		return errors.Trace(st.db().RunTransaction(ops))
+	}))
+}
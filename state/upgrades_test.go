@@ -3594,6 +3594,65 @@ func (s *upgradesSuite) TestEnsureRelationApplicationSettings(c *gc.C) {
 	)
 }
 
+func (s *upgradesSuite) TestAddOfferConnectionCounts(c *gc.C) {
+	offersCol, closer := s.state.db().GetRawCollection(applicationOffersC)
+	defer closer()
+	connsCol, closer := s.state.db().GetRawCollection(offerConnectionsC)
+	defer closer()
+
+	model1 := s.makeModel(c, "model-1", coretesting.Attrs{})
+	defer func() { _ = model1.Close() }()
+	uuid1 := model1.ModelUUID()
+
+	err := offersCol.Insert(bson.M{
+		"_id":              ensureModelUUID(uuid1, "hosted-mysql"),
+		"model-uuid":       uuid1,
+		"offer-uuid":       "offer-uuid-1",
+		"offer-name":       "hosted-mysql",
+		"application-name": "mysql",
+		"max-connections":  2,
+	}, bson.M{
+		"_id":              ensureModelUUID(uuid1, "hosted-db2"),
+		"model-uuid":       uuid1,
+		"offer-uuid":       "offer-uuid-2",
+		"offer-name":       "hosted-db2",
+		"application-name": "db2",
+		"connection-count": 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = connsCol.Insert(bson.M{
+		"_id":        ensureModelUUID(uuid1, "0"),
+		"model-uuid": uuid1,
+		"offer-uuid": "offer-uuid-1",
+	}, bson.M{
+		"_id":        ensureModelUUID(uuid1, "1"),
+		"model-uuid": uuid1,
+		"offer-uuid": "offer-uuid-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := bsonMById{{
+		"_id":              ensureModelUUID(uuid1, "hosted-db2"),
+		"model-uuid":       uuid1,
+		"offer-uuid":       "offer-uuid-2",
+		"offer-name":       "hosted-db2",
+		"application-name": "db2",
+	}, {
+		"_id":              ensureModelUUID(uuid1, "hosted-mysql"),
+		"model-uuid":       uuid1,
+		"offer-uuid":       "offer-uuid-1",
+		"offer-name":       "hosted-mysql",
+		"application-name": "mysql",
+		"max-connections":  2,
+		"connection-count": 2,
+	}}
+	sort.Sort(expected)
+
+	s.assertUpgradedData(c, AddOfferConnectionCounts,
+		upgradedData(offersCol, expected),
+	)
+}
+
 func (s *upgradesSuite) TestChangeSubnetAZtoSlice(c *gc.C) {
 	col, closer := s.state.db().GetRawCollection(subnetsC)
 	defer closer()
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -52,6 +54,15 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns true if there is an
+	// outstanding resize request, otherwise false.
+	RequestedSize() (uint64, bool)
+
+	// Encrypted reports whether or not the volume is encrypted at
+	// rest by Juju, using a key held by the controller.
+	Encrypted() bool
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -127,6 +138,20 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// RequestedSize, if non-zero, is the size in MiB that the
+	// provisioned volume has been requested to grow to.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
+
+	// EncryptionKey, if non-empty, is the key used to encrypt the
+	// volume at rest. It is only handed out to the machine agent of
+	// a machine with a live attachment plan for the volume.
+	//
+	// The key is stored in plaintext, so the volume's contents are
+	// only protected from those without access to the controller
+	// database. It must never be included in model exports, dumps
+	// or status output.
+	EncryptionKey string `bson:"encryptionkey,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -176,6 +201,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if not empty, is the provider ID of the snapshot
+	// from which to create the volume.
+	SnapshotId string `bson:"snapshot-id,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -278,6 +307,16 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize != 0
+}
+
+// Encrypted is required to implement Volume.
+func (v *volume) Encrypted() bool {
+	return v.doc.EncryptionKey != ""
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -698,6 +737,46 @@ func isDetachableVolumePool(im *storageBackend, pool string) (bool, error) {
 	return false, nil
 }
 
+// newVolumeEncryptionKey returns a new key with which to encrypt a
+// volume created from the specified pool, or the empty string if the
+// pool does not request that volumes be encrypted.
+func newVolumeEncryptionKey(sb *storageBackend, pool string) (string, error) {
+	_, _, attrs, err := poolStorageProvider(sb, pool)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !storage.EncryptionRequested(attrs) {
+		return "", nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", errors.Annotate(err, "generating volume encryption key")
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// VolumeEncryptionKey returns the key with which the specified volume
+// is encrypted, or the empty string if the volume is not encrypted.
+// The key is only available while the volume has an Alive attachment
+// plan for the specified machine; otherwise an error satisfying
+// errors.IsNotFound is returned.
+func (sb *storageBackend) VolumeEncryptionKey(machine names.MachineTag, volume names.VolumeTag) (string, error) {
+	plan, err := sb.VolumeAttachmentPlan(machine, volume)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if plan.Life() != Alive {
+		return "", errors.NotFoundf(
+			"alive volume attachment plan %q on machine %q", volume.Id(), machine.Id(),
+		)
+	}
+	v, err := getVolumeByTag(sb.mb, volume)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return v.doc.EncryptionKey, nil
+}
+
 // DetachVolume marks the volume attachment identified by the specified machine
 // and volume tags as Dying, if it is Alive. DetachVolume will fail with a
 // IsContainsFilesystem error if the volume contains an attached filesystem; the
@@ -1047,6 +1126,10 @@ func (sb *storageBackend) addVolumeOps(params VolumeParams, hostId string) ([]tx
 		// Every new volume is created with one attachment.
 		doc.Params = &params
 		doc.AttachmentCount = 1
+		doc.EncryptionKey, err = newVolumeEncryptionKey(sb, params.Pool)
+		if err != nil {
+			return nil, names.VolumeTag{}, errors.Trace(err)
+		}
 	}
 	if !detachable {
 		doc.HostId = origHostId
@@ -1479,6 +1562,55 @@ func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []
 	}}
 }
 
+// SetVolumeSize records the size, in MiB, of a provisioned volume after
+// it has been resized. Any resize request that the new size satisfies
+// is cleared.
+func (sb *storageBackend) SetVolumeSize(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size for volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < info.Size {
+			return nil, errors.Errorf(
+				"cannot shrink volume from %dMiB to %dMiB",
+				info.Size, size,
+			)
+		}
+		if size == info.Size && v.doc.RequestedSize == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"info.size", size}}}}
+		if requested, ok := v.RequestedSize(); ok && size >= requested {
+			update = append(update, bson.DocElem{"$unset", bson.D{{"requestedsize", nil}}})
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: bson.D{
+				{"info.size", info.Size},
+				requestedSizeAssert(v.doc.RequestedSize),
+			},
+			Update: update,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// requestedSizeAssert returns an assertion that a volume or filesystem
+// document's requested size is unchanged.
+func requestedSizeAssert(requestedSize uint64) bson.DocElem {
+	if requestedSize == 0 {
+		return bson.DocElem{"requestedsize", bson.D{{"$exists", false}}}
+	}
+	return bson.DocElem{"requestedsize", requestedSize}
+}
+
 // AllVolumes returns all Volumes scoped to the model.
 func (sb *storageBackend) AllVolumes() ([]Volume, error) {
 	volumes, err := sb.volumes(nil)
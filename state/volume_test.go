@@ -241,6 +241,134 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) setupProvisionedVolume(c *gc.C) (names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, volumeTag
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstance(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedVolume(c)
+	_, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	err = s.storageBackend.SetVolumeSize(volumeTag, 2050)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.volume(c, volumeTag)
+	_, ok = volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2050))
+	c.Assert(info.VolumeId, gc.Equals, "vol-ume")
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstanceNotLarger(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c)
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": new size 1024MiB must be larger than current size 1024MiB`)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstanceNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestSetVolumeSizeShrink(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c)
+	err := s.storageBackend.SetVolumeSize(volumeTag, 512)
+	c.Assert(err, gc.ErrorMatches, `cannot set size for volume "0/0": cannot shrink volume from 1024MiB to 512MiB`)
+}
+
+func (s *VolumeStateSuite) TestVolumeEncryptionKey(c *gc.C) {
+	_, err := s.pm.Create("encrypted-block", "modelscoped-block", map[string]interface{}{
+		"encryption": "luks",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.HostVolumeParams{{
+			Volume: state.VolumeParams{Pool: "encrypted-block", Size: 1024},
+		}, {
+			Volume: state.VolumeParams{Pool: "persistent-block", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := s.volume(c, names.NewVolumeTag("0"))
+	c.Assert(encrypted.Encrypted(), jc.IsTrue)
+	unencrypted := s.volume(c, names.NewVolumeTag("1"))
+	c.Assert(unencrypted.Encrypted(), jc.IsFalse)
+
+	// The key is not available until the volume has been
+	// attached to the machine.
+	_, err = s.storageBackend.VolumeEncryptionKey(machine.MachineTag(), encrypted.VolumeTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	planInfo := state.VolumeAttachmentPlanInfo{DeviceType: storage.DeviceTypeLocal}
+	for _, tag := range []names.VolumeTag{encrypted.VolumeTag(), unencrypted.VolumeTag()} {
+		err = s.storageBackend.SetVolumeInfo(tag, state.VolumeInfo{VolumeId: "vol-" + tag.Id()})
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.storageBackend.SetVolumeAttachmentInfo(machine.MachineTag(), tag, state.VolumeAttachmentInfo{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.storageBackend.CreateVolumeAttachmentPlan(machine.MachineTag(), tag, planInfo)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	key, err := s.storageBackend.VolumeEncryptionKey(machine.MachineTag(), encrypted.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Matches, "[0-9a-f]{64}")
+	key, err = s.storageBackend.VolumeEncryptionKey(machine.MachineTag(), unencrypted.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Equals, "")
+
+	// Once the volume is being detached, the key is no
+	// longer handed out.
+	err = s.storageBackend.DetachVolume(machine.MachineTag(), encrypted.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.VolumeEncryptionKey(machine.MachineTag(), encrypted.VolumeTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The key is never included in a dump of the model.
+	dump, err := s.State.DumpAll()
+	c.Assert(err, jc.ErrorIsNil)
+	volumeDocs, ok := dump["volumes"].([]map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeDocs, gc.Not(gc.HasLen), 0)
+	for _, doc := range volumeDocs {
+		_, found := doc["encryptionkey"]
+		c.Check(found, jc.IsFalse)
+	}
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c)
+
+	w := s.storageBackend.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -240,14 +240,38 @@ func (sb *storageBackend) watchModelHostStorage(collection string) StringsWatche
 	mb := sb.mb
 	pattern := fmt.Sprintf("^%s$", mb.docID(machineOrUnitSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	filter := func(id interface{}) bool {
+	return newLifecycleWatcher(mb, collection, members, modelHostStorageFilter(mb), nil)
+}
+
+// modelHostStorageFilter returns a watcher filter that accepts only
+// model-scoped storage entities.
+func modelHostStorageFilter(mb modelBackend) func(interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return !strings.Contains(k, "/")
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, including requests to resize them.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: modelHostStorageFilter(sb.mb),
+	})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, including requests
+// to resize them.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: hostStorageFilter(sb.mb, m),
+	})
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
@@ -276,16 +300,21 @@ func (sb *storageBackend) watchHostStorage(host names.Tag, collection string) St
 	// The host parameter passed into this method is the application name, any of whose units we are interested in.
 	pattern := fmt.Sprintf("^%s(/%s)?/%s$", mb.docID(host.Id()), names.NumberSnippet, names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	prefix := fmt.Sprintf("%s(/%s)?/.*", host.Id(), names.NumberSnippet)
+	return newLifecycleWatcher(mb, collection, members, hostStorageFilter(mb, host), nil)
+}
+
+// hostStorageFilter returns a watcher filter that accepts only storage
+// entities scoped to the specified host.
+func hostStorageFilter(mb modelBackend, host names.Tag) func(interface{}) bool {
+	prefix := fmt.Sprintf("^%s(/%s)?/.*", host.Id(), names.NumberSnippet)
 	matchExp := regexp.MustCompile(prefix)
-	filter := func(id interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return matchExp.MatchString(k)
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
 }
 
 // WatchMachineAttachmentsPlans returns a StringsWatcher that notifies machine agents
@@ -666,8 +695,10 @@ func stateWatcherDeadError(err error) error {
 
 func (w *lifecycleWatcher) loop() error {
 	in := make(chan watcher.Change)
-	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
-	defer w.watcher.UnwatchCollection(w.collName, in)
+	for _, collName := range w.collNames {
+		w.watcher.WatchCollectionWithFilter(collName, in, w.filter)
+		defer w.watcher.UnwatchCollection(collName, in)
+	}
 	ids, err := w.initial()
 	if err != nil {
 		return err
@@ -1750,6 +1781,13 @@ func (sb *storageBackend) WatchStorageAttachment(s names.StorageTag, u names.Uni
 	return newEntityWatcher(sb.mb, storageAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchStorageInstance returns a watcher for observing changes to a
+// storage instance, such as a move to another storage pool starting
+// or completing.
+func (sb *storageBackend) WatchStorageInstance(s names.StorageTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, storageInstancesC, sb.mb.docID(s.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTag) NotifyWatcher {
@@ -1757,6 +1795,12 @@ func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTa
 	return newEntityWatcher(sb.mb, volumeAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchFilesystem returns a watcher for observing changes to a filesystem,
+// such as its size changing after it has been resized.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchFilesystemAttachment returns a watcher for observing changes
 // to a filesystem attachment.
 func (sb *storageBackend) WatchFilesystemAttachment(host names.Tag, f names.FilesystemTag) NotifyWatcher {
@@ -1772,6 +1816,12 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's application config, as opposed to its charm config.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -2532,7 +2582,7 @@ func (w *actionLogsWatcher) loop() error {
 				changes = messages[reportedCount:]
 			}
 		case out <- changes:
-			reportedCount = len(changes)
+			reportedCount += len(changes)
 			out = nil
 		}
 	}
@@ -3374,19 +3424,25 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 }
 
 // notifyCollWatcher implements NotifyWatcher, triggering when a
-// change is seen in a specific collection matching the provided
+// change is seen in any of a set of collections matching the provided
 // filter function.
 type notifyCollWatcher struct {
 	commonWatcher
-	collName string
-	filter   func(interface{}) bool
-	sink     chan struct{}
+	collNames []string
+	filter    func(interface{}) bool
+	sink      chan struct{}
 }
 
 func newNotifyCollWatcher(backend modelBackend, collName string, filter func(interface{}) bool) NotifyWatcher {
+	return newNotifyCollsWatcher(backend, filter, collName)
+}
+
+// newNotifyCollsWatcher returns a NotifyWatcher that triggers whenever
+// a change matching the filter is seen in any of the collections.
+func newNotifyCollsWatcher(backend modelBackend, filter func(interface{}) bool, collNames ...string) NotifyWatcher {
 	w := &notifyCollWatcher{
 		commonWatcher: newCommonWatcher(backend),
-		collName:      collName,
+		collNames:     collNames,
 		filter:        filter,
 		sink:          make(chan struct{}),
 	}
@@ -3405,8 +3461,10 @@ func (w *notifyCollWatcher) Changes() <-chan struct{} {
 func (w *notifyCollWatcher) loop() error {
 	in := make(chan watcher.Change)
 
-	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
-	defer w.watcher.UnwatchCollection(w.collName, in)
+	for _, collName := range w.collNames {
+		w.watcher.WatchCollectionWithFilter(collName, in, w.filter)
+		defer w.watcher.UnwatchCollection(collName, in)
+	}
 
 	// check if there are any pending changes before the first event
 	if _, ok := collect(watcher.Change{}, in, w.tomb.Dying()); !ok {
@@ -3507,6 +3565,31 @@ func (st *State) WatchSubnets(subnetFilter func(id interface{}) bool) StringsWat
 	return newLifecycleWatcher(st, subnetsC, nil, filter, nil)
 }
 
+// WatchSubnetChanges returns a NotifyWatcher that triggers whenever
+// any of the model's subnets is added, removed or changed, including
+// when a subnet is moved to another space.
+func (st *State) WatchSubnetChanges() NotifyWatcher {
+	return newNotifyCollWatcher(st, subnetsC, isLocalID(st))
+}
+
+// WatchHostFirewallChanges returns a NotifyWatcher that triggers
+// whenever anything that the host firewall rules of the model's
+// machines are derived from may have changed: the machines and their
+// addresses, the units and the ports they have opened, the
+// applications' expose settings, the model's subnets, and the relations
+// and their ingress networks.
+func (st *State) WatchHostFirewallChanges() NotifyWatcher {
+	return newNotifyCollsWatcher(st, isLocalID(st),
+		machinesC,
+		unitsC,
+		openedPortsC,
+		applicationsC,
+		subnetsC,
+		relationsC,
+		relationNetworksC,
+	)
+}
+
 // isLocalID returns a watcher filter func that rejects ids not specific
 // to the supplied modelBackend.
 func isLocalID(st modelBackend) func(interface{}) bool {
@@ -66,4 +66,14 @@ type BlockDevice struct {
 
 	// SerialId is the block devices serial id used for matching.
 	SerialId string `yaml:"serialid,omitempty"`
+
+	// UsedSize is the amount of space used on the filesystem mounted
+	// at MountPoint, in MiB. It is only set if the block device is
+	// mounted.
+	UsedSize uint64 `yaml:"usedsize,omitempty"`
+
+	// FreeSize is the amount of space available to unprivileged users
+	// on the filesystem mounted at MountPoint, in MiB. It is only set
+	// if the block device is mounted.
+	FreeSize uint64 `yaml:"freesize,omitempty"`
 }
@@ -28,11 +28,15 @@ type Config struct {
 	attrs    map[string]interface{}
 }
 
-var fields = schema.Fields{}
+var fields = schema.Fields{
+	ConfigEncryption: schema.Const(EncryptionLUKS),
+}
 
 var configChecker = schema.FieldMap(
 	fields,
-	schema.Defaults{},
+	schema.Defaults{
+		ConfigEncryption: schema.Omit,
+	},
 )
 
 // NewConfig creates a new Config for instantiating a storage source.
@@ -27,6 +27,10 @@ type Constraints struct {
 
 	// Count is the number of instances of the storage to create.
 	Count uint64
+
+	// SnapshotId, if not empty, is the provider ID of a volume snapshot
+	// from which to create the storage.
+	SnapshotId string
 }
 
 var (
@@ -35,11 +39,14 @@ var (
 	sizeRE  = regexp.MustCompile("^-?[0-9]+(?:\\.[0-9]+)?[MGTPEZY](?:i?B)?$")
 )
 
+// snapshotPrefix prefixes the snapshot field of storage constraints.
+const snapshotPrefix = "snapshot:"
+
 // ParseConstraints parses the specified string and creates a
 // Constraints structure.
 //
 // The acceptable format for storage constraints is a comma separated
-// sequence of: POOL, COUNT, and SIZE, where
+// sequence of: POOL, COUNT, SIZE and snapshot:SNAPSHOT, where
 //
 //    POOL identifies the storage pool. POOL can be a string
 //    starting with a letter, followed by zero or more digits
@@ -53,6 +60,9 @@ var (
 //    create. SIZE is a floating point number and multiplier from
 //    the set (M, G, T, P, E, Z, Y), which are all treated as
 //    powers of 1024.
+//
+//    SNAPSHOT is the provider ID of a volume snapshot from which
+//    to create the storage instances.
 func ParseConstraints(s string) (Constraints, error) {
 	var cons Constraints
 	fields := strings.Split(s, ",")
@@ -60,6 +70,14 @@ func ParseConstraints(s string) (Constraints, error) {
 		if field == "" {
 			continue
 		}
+		if strings.HasPrefix(field, snapshotPrefix) {
+			snapshotId := strings.TrimPrefix(field, snapshotPrefix)
+			if snapshotId == "" {
+				return cons, errors.New("snapshot ID must be specified")
+			}
+			cons.SnapshotId = snapshotId
+			continue
+		}
 		if IsValidPoolName(field) {
 			if cons.Pool != "" {
 				logger.Debugf("pool name is already set to %q, ignoring %q", cons.Pool, field)
@@ -84,7 +102,7 @@ func ParseConstraints(s string) (Constraints, error) {
 		}
 		logger.Debugf("ignoring unknown storage constraint %q", field)
 	}
-	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" {
+	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" && cons.SnapshotId == "" {
 		return Constraints{}, errors.New("storage constraints require at least one field to be specified")
 	}
 	if cons.Count == 0 {
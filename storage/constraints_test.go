@@ -63,6 +63,20 @@ func (s *ConstraintsSuite) TestParseConstraintsOptions(c *gc.C) {
 	})
 }
 
+func (s *ConstraintsSuite) TestParseConstraintsSnapshot(c *gc.C) {
+	s.testParse(c, "ebs,10G,snapshot:snap-0123", storage.Constraints{
+		Pool:       "ebs",
+		Count:      1,
+		Size:       10 * 1024,
+		SnapshotId: "snap-0123",
+	})
+	s.testParse(c, "snapshot:snap-0123", storage.Constraints{
+		Count:      1,
+		SnapshotId: "snap-0123",
+	})
+	s.testParseError(c, "ebs,snapshot:", `snapshot ID must be specified`)
+}
+
 func (s *ConstraintsSuite) TestParseConstraintsCountRange(c *gc.C) {
 	s.testParseError(c, "p,0,100M", `cannot parse count: count must be greater than zero, got "0"`)
 	s.testParseError(c, "p,00,100M", `cannot parse count: count must be greater than zero, got "00"`)
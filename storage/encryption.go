@@ -0,0 +1,38 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/juju/names.v3"
+)
+
+const (
+	// ConfigEncryption is the name of the storage pool attribute
+	// used to request that volumes created from the pool are
+	// encrypted at rest by Juju, independently of any encryption
+	// offered by the storage provider.
+	ConfigEncryption = "encryption"
+
+	// EncryptionLUKS is the value of ConfigEncryption that requests
+	// dm-crypt/LUKS encryption. The volume is formatted and opened
+	// by the machine agent when it is attached, using a key that is
+	// generated and held by the controller.
+	EncryptionLUKS = "luks"
+)
+
+// EncryptionRequested reports whether the given storage pool attributes
+// request that volumes be encrypted at rest by Juju.
+func EncryptionRequested(attrs map[string]interface{}) bool {
+	value, _ := attrs[ConfigEncryption].(string)
+	return value == EncryptionLUKS
+}
+
+// EncryptedDeviceName returns the device name, relative to /dev, of
+// the decrypted block device that is mapped for an encrypted volume.
+func EncryptedDeviceName(tag names.VolumeTag) string {
+	return path.Join("mapper", "juju-"+strings.Replace(tag.Id(), "/", "-", -1))
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/storage"
+)
+
+type EncryptionSuite struct{}
+
+var _ = gc.Suite(&EncryptionSuite{})
+
+func (s *EncryptionSuite) TestEncryptionRequested(c *gc.C) {
+	c.Assert(storage.EncryptionRequested(nil), jc.IsFalse)
+	c.Assert(storage.EncryptionRequested(map[string]interface{}{
+		"encryption": "luks",
+	}), jc.IsTrue)
+	c.Assert(storage.EncryptionRequested(map[string]interface{}{
+		"encryption": "",
+	}), jc.IsFalse)
+}
+
+func (s *EncryptionSuite) TestEncryptedDeviceName(c *gc.C) {
+	c.Assert(storage.EncryptedDeviceName(names.NewVolumeTag("0")), gc.Equals, "mapper/juju-0")
+	c.Assert(storage.EncryptedDeviceName(names.NewVolumeTag("1/2")), gc.Equals, "mapper/juju-1-2")
+}
+
+func (s *EncryptionSuite) TestConfigEncryption(c *gc.C) {
+	_, err := storage.NewConfig("secure", "loop", map[string]interface{}{
+		"encryption": "luks",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = storage.NewConfig("secure", "loop", map[string]interface{}{
+		"encryption": "rot13",
+	})
+	c.Assert(err, gc.ErrorMatches, `validating common storage config: encryption: expected "luks", .*`)
+}
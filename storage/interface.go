@@ -216,6 +216,49 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes, and listing them. A VolumeSource that
+// implements VolumeSnapshotter must also create volumes from the
+// snapshot identified by VolumeParams.SnapshotId, when it is set.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes a snapshot of each of the specified
+	// volumes. The snapshot may not be complete when the method returns;
+	// its progress is reported by the snapshot's status.
+	CreateVolumeSnapshots(
+		ctx context.ProviderCallContext,
+		params []VolumeSnapshotParams,
+	) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots returns the snapshots of the volumes with
+	// the specified provider IDs.
+	ListVolumeSnapshots(
+		ctx context.ProviderCallContext,
+		volumeIds []string,
+	) ([]VolumeSnapshot, error)
+}
+
+// VolumeResizer provides an interface for growing volumes while they
+// remain in use. Volumes are never shrunk.
+type VolumeResizer interface {
+	// ResizeVolumes grows each of the specified volumes to at least
+	// the requested size.
+	ResizeVolumes(
+		ctx context.ProviderCallContext,
+		params []VolumeResizeParams,
+	) ([]ResizeVolumesResult, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems
+// while they remain in use. Filesystems are never shrunk.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows each of the specified filesystems to
+	// at least the requested size.
+	ResizeFilesystems(
+		ctx context.ProviderCallContext,
+		params []FilesystemResizeParams,
+	) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -239,6 +282,11 @@ type VolumeParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId, if not empty, is the provider ID of the snapshot from
+	// which to create the volume. Only volume sources that implement
+	// VolumeSnapshotter are asked to create volumes from snapshots.
+	SnapshotId string
+
 	// Attachment identifies the machine that the volume should be attached
 	// to initially, or nil if the volume should not be attached to any
 	// machine. Some providers, such as MAAS, do not support dynamic
@@ -315,6 +363,11 @@ type FilesystemParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId, if not empty, is the provider ID of the volume snapshot
+	// from which the filesystem's backing volume is created. The volume
+	// already holds the filesystem, so it must not be formatted.
+	SnapshotId string
+
 	// Attachment identifies the machine that the filesystem should be attached
 	// to initially, or nil if the filesystem should not be attached to any
 	// machine.
@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package luks sets up dm-crypt/LUKS encryption on block devices
+// attached to a machine. Unlike the other attachment plans, it does
+// not make a device available by itself; it wraps a block device that
+// has already been attached, exposing the decrypted contents as a
+// device mapper target.
+package luks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/storage"
+)
+
+var logger = loggo.GetLogger("juju.storage.plans.luks")
+
+const (
+	luksFilesystemType = "crypto_LUKS"
+
+	// blkidNotFound is the exit code blkid returns if no
+	// signatures could be found on the device.
+	blkidNotFound = 2
+
+	bytesInMiB = 1024 * 1024
+)
+
+var devDir = "/dev"
+
+// runCommand runs the given command, writing stdin (which may
+// contain key material, and so is never logged) to its standard input.
+var runCommand = func(stdin string, cmd string, args ...string) (string, error) {
+	logger.Debugf("running: %s %s", cmd, strings.Join(args, " "))
+	c := exec.Command(cmd, args...)
+	if stdin != "" {
+		c.Stdin = strings.NewReader(stdin)
+	}
+	var output bytes.Buffer
+	c.Stdout = &output
+	c.Stderr = &output
+	err := c.Run()
+	if err != nil {
+		if out := strings.TrimSpace(output.String()); out != "" {
+			err = errors.Annotate(err, out)
+		}
+	}
+	return output.String(), err
+}
+
+// Open ensures that the block device at devicePath is formatted
+// with LUKS, using the given key, and opens it as the decrypted
+// device for the specified volume. Open returns a description of
+// the decrypted block device. Open is idempotent.
+//
+// If the device already contains something other than a LUKS
+// header, including a partition table, Open will refuse to format it.
+func Open(devicePath string, tag names.VolumeTag, key string) (storage.BlockDevice, error) {
+	deviceName := storage.EncryptedDeviceName(tag)
+	mappedPath := path.Join(devDir, deviceName)
+	if _, err := os.Stat(mappedPath); err == nil {
+		// The device is already open, but the underlying device
+		// may have been resized since; make sure that the mapping
+		// covers all of it.
+		logger.Debugf("%s is already open at %s", devicePath, mappedPath)
+		if _, err := runCommand(
+			key, "cryptsetup", "resize",
+			"--key-file=-", path.Base(deviceName),
+		); err != nil {
+			return storage.BlockDevice{}, errors.Annotatef(err, "resizing LUKS device %q", mappedPath)
+		}
+		return describeDevice(deviceName)
+	}
+
+	tags, err := probeDevice(devicePath)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	switch {
+	case tags["TYPE"] == luksFilesystemType:
+	case len(tags) == 0:
+		logger.Infof("formatting %s with LUKS", devicePath)
+		if _, err := runCommand(
+			key, "cryptsetup", "luksFormat",
+			"--batch-mode", "--key-file=-",
+			devicePath,
+		); err != nil {
+			return storage.BlockDevice{}, errors.Annotatef(err, "formatting %q with LUKS", devicePath)
+		}
+	default:
+		return storage.BlockDevice{}, errors.Errorf(
+			"refusing to encrypt %q: device contains %s", devicePath, describeContents(tags),
+		)
+	}
+
+	if _, err := runCommand(
+		key, "cryptsetup", "open",
+		"--type", "luks", "--key-file=-",
+		devicePath, path.Base(deviceName),
+	); err != nil {
+		return storage.BlockDevice{}, errors.Annotatef(err, "opening LUKS device %q", devicePath)
+	}
+	return describeDevice(deviceName)
+}
+
+// Close closes the decrypted device for the specified volume, if
+// it is open. Once closed, the contents of the underlying block
+// device cannot be read again without the key.
+func Close(tag names.VolumeTag) error {
+	deviceName := storage.EncryptedDeviceName(tag)
+	if _, err := os.Stat(path.Join(devDir, deviceName)); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := runCommand("", "cryptsetup", "close", path.Base(deviceName)); err != nil {
+		return errors.Annotatef(err, "closing LUKS device %q", deviceName)
+	}
+	return nil
+}
+
+// probeDevice returns the tags reported by a low-level blkid probe
+// of the specified device, such as TYPE for a filesystem or PTTYPE
+// for a partition table. The result is empty only if the device
+// contains no recognisable signatures at all.
+func probeDevice(devicePath string) (map[string]string, error) {
+	output, err := runCommand("", "blkid", "-p", "-o", "export", devicePath)
+	if err != nil {
+		if exitErr, ok := errors.Cause(err).(exitCoder); ok && exitErr.ExitCode() == blkidNotFound {
+			return nil, nil
+		}
+		return nil, errors.Annotatef(err, "probing %q", devicePath)
+	}
+	tags := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(fields) != 2 || fields[0] == "DEVNAME" {
+			continue
+		}
+		tags[fields[0]] = fields[1]
+	}
+	return tags, nil
+}
+
+// exitCoder is implemented by errors from commands
+// that exited with a non-zero status.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// describeContents returns a description of the device
+// contents identified by the given blkid tags.
+func describeContents(tags map[string]string) string {
+	if fsType := tags["TYPE"]; fsType != "" {
+		return fmt.Sprintf("%q data", fsType)
+	}
+	if ptType := tags["PTTYPE"]; ptType != "" {
+		return fmt.Sprintf("a %q partition table", ptType)
+	}
+	return "unrecognised data"
+}
+
+// describeDevice returns a storage.BlockDevice describing the
+// decrypted device with the given name.
+func describeDevice(deviceName string) (storage.BlockDevice, error) {
+	devicePath := path.Join(devDir, deviceName)
+	output, err := runCommand("", "blockdev", "--getsize64", devicePath)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Annotatef(err, "getting size of %q", devicePath)
+	}
+	size, err := strconv.ParseUint(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Annotatef(err, "parsing size of %q", devicePath)
+	}
+	tags, err := probeDevice(devicePath)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	return storage.BlockDevice{
+		DeviceName:     deviceName,
+		DeviceLinks:    []string{devicePath},
+		Size:           size / bytesInMiB,
+		FilesystemType: tags["TYPE"],
+	}, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package luks_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/luks"
+)
+
+type luksSuite struct {
+	testing.IsolationSuite
+
+	stub       testing.Stub
+	outputs    map[string]string
+	errors     map[string]error
+	mappedPath string
+}
+
+var _ = gc.Suite(&luksSuite{})
+
+func (s *luksSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	devDir := c.MkDir()
+	s.PatchValue(luks.DevDir, devDir)
+	s.mappedPath = filepath.Join(devDir, "mapper", "juju-0")
+	s.outputs = map[string]string{
+		"blockdev " + s.mappedPath: "1073741824\n",
+	}
+	s.errors = make(map[string]error)
+	s.PatchValue(luks.RunCommand, func(stdin string, cmd string, args ...string) (string, error) {
+		callArgs := make([]interface{}, len(args))
+		for i, arg := range args {
+			callArgs[i] = arg
+		}
+		s.stub.AddCall(cmd, callArgs...)
+		key := cmd + " " + args[len(args)-1]
+		return s.outputs[key], s.errors[key]
+	})
+}
+
+// setProbe sets the result of probing the device at the input
+// path with blkid, as a list of tags. If no tags are given, the
+// probe reports that the device contains no signatures.
+func (s *luksSuite) setProbe(devicePath string, tags ...string) {
+	key := "blkid " + devicePath
+	if len(tags) == 0 {
+		s.errors[key] = exitError(2)
+		return
+	}
+	s.outputs[key] = "DEVNAME=" + devicePath + "\n" + strings.Join(tags, "\n") + "\n"
+}
+
+func (s *luksSuite) TestOpenEmpty(c *gc.C) {
+	s.setProbe("/dev/sdb")
+	s.setProbe(s.mappedPath)
+
+	dev, err := luks.Open("/dev/sdb", names.NewVolumeTag("0"), "secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dev.DeviceName, gc.Equals, "mapper/juju-0")
+	c.Check(dev.Size, gc.Equals, uint64(1024))
+	c.Check(dev.FilesystemType, gc.Equals, "")
+
+	s.stub.CheckCallNames(c, "blkid", "cryptsetup", "cryptsetup", "blockdev", "blkid")
+	c.Check(s.stub.Calls()[1].Args, jc.DeepEquals, []interface{}{
+		"luksFormat", "--batch-mode", "--key-file=-", "/dev/sdb",
+	})
+	c.Check(s.stub.Calls()[2].Args, jc.DeepEquals, []interface{}{
+		"open", "--type", "luks", "--key-file=-", "/dev/sdb", "juju-0",
+	})
+}
+
+func (s *luksSuite) TestOpenLUKS(c *gc.C) {
+	s.setProbe("/dev/sdb", "TYPE=crypto_LUKS", "VERSION=2")
+	s.setProbe(s.mappedPath, "TYPE=ext4")
+
+	dev, err := luks.Open("/dev/sdb", names.NewVolumeTag("0"), "secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dev, jc.DeepEquals, storage.BlockDevice{
+		DeviceName:     "mapper/juju-0",
+		DeviceLinks:    []string{s.mappedPath},
+		Size:           1024,
+		FilesystemType: "ext4",
+	})
+
+	// The existing LUKS header is opened, not reformatted.
+	s.stub.CheckCallNames(c, "blkid", "cryptsetup", "blockdev", "blkid")
+	c.Check(s.stub.Calls()[1].Args[0], gc.Equals, "open")
+}
+
+func (s *luksSuite) TestOpenFilesystem(c *gc.C) {
+	s.setProbe("/dev/sdb", "UUID=1234", "TYPE=ext4")
+
+	_, err := luks.Open("/dev/sdb", names.NewVolumeTag("0"), "secret")
+	c.Assert(err, gc.ErrorMatches, `refusing to encrypt "/dev/sdb": device contains "ext4" data`)
+	s.stub.CheckCallNames(c, "blkid")
+}
+
+func (s *luksSuite) TestOpenPartitionTable(c *gc.C) {
+	s.setProbe("/dev/sdb", "PTUUID=1234", "PTTYPE=gpt")
+
+	_, err := luks.Open("/dev/sdb", names.NewVolumeTag("0"), "secret")
+	c.Assert(err, gc.ErrorMatches, `refusing to encrypt "/dev/sdb": device contains a "gpt" partition table`)
+	s.stub.CheckCallNames(c, "blkid")
+}
+
+func (s *luksSuite) TestOpenUnrecognisedSignature(c *gc.C) {
+	s.setProbe("/dev/sdb", "PART_ENTRY_SCHEME=dos")
+
+	_, err := luks.Open("/dev/sdb", names.NewVolumeTag("0"), "secret")
+	c.Assert(err, gc.ErrorMatches, `refusing to encrypt "/dev/sdb": device contains unrecognised data`)
+	s.stub.CheckCallNames(c, "blkid")
+}
+
+func (s *luksSuite) TestOpenProbeError(c *gc.C) {
+	// blkid exits with 8 if the probe results are ambivalent.
+	s.errors["blkid /dev/sdb"] = errors.Annotate(exitError(8), "ambivalent result")
+
+	_, err := luks.Open("/dev/sdb", names.NewVolumeTag("0"), "secret")
+	c.Assert(err, gc.ErrorMatches, `probing "/dev/sdb": ambivalent result: exit status 8`)
+	s.stub.CheckCallNames(c, "blkid")
+}
+
+type exitError int
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func (e exitError) ExitCode() int {
+	return int(e)
+}
@@ -25,6 +25,11 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]error, error)
+
+	CreateVolumeSnapshotsFunc func(context.ProviderCallContext, []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	ListVolumeSnapshotsFunc   func(context.ProviderCallContext, []string) ([]storage.VolumeSnapshot, error)
+
+	ResizeVolumesFunc func(context.ProviderCallContext, []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -98,3 +103,30 @@ func (s *VolumeSource) DetachVolumes(ctx context.ProviderCallContext, params []s
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", ctx, params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
+
+// ListVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListVolumeSnapshots(ctx context.ProviderCallContext, volIds []string) ([]storage.VolumeSnapshot, error) {
+	s.MethodCall(s, "ListVolumeSnapshots", ctx, volIds)
+	if s.ListVolumeSnapshotsFunc != nil {
+		return s.ListVolumeSnapshotsFunc(ctx, volIds)
+	}
+	return nil, nil
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", ctx, params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
@@ -92,17 +92,24 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		return nil, errors.Trace(err)
 	}
 	devicePath := devicePath(blockDevice)
-	if isDiskDevice(devicePath) {
-		if err := destroyPartitions(s.run, devicePath); err != nil {
-			return nil, errors.Trace(err)
+	if arg.SnapshotId != "" {
+		// The backing volume was restored from a snapshot of a volume
+		// that was partitioned and formatted in the same way, so the
+		// existing filesystem must be left intact.
+		logger.Debugf("not formatting %q, restored from snapshot %q", devicePath, arg.SnapshotId)
+	} else {
+		if isDiskDevice(devicePath) {
+			if err := destroyPartitions(s.run, devicePath); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := createPartition(s.run, devicePath); err != nil {
+				return nil, errors.Trace(err)
+			}
+			devicePath = partitionDevicePath(devicePath)
 		}
-		if err := createPartition(s.run, devicePath); err != nil {
+		if err := createFilesystem(s.run, devicePath); err != nil {
 			return nil, errors.Trace(err)
 		}
-		devicePath = partitionDevicePath(devicePath)
-	}
-	if err := createFilesystem(s.run, devicePath); err != nil {
-		return nil, errors.Trace(err)
 	}
 	return &storage.Filesystem{
 		arg.Tag,
@@ -178,6 +185,41 @@ func (s *managedFilesystemSource) DetachFilesystems(ctx context.ProviderCallCont
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+//
+// The filesystems are grown to fill their backing volumes' block
+// devices, which are expected to have been grown already.
+func (s *managedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (uint64, error) {
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return 0, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return blockDevice.Size, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -207,6 +249,32 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	if _, err := run("growpart", devicePath, "1"); err != nil {
+		// growpart fails with "NOCHANGE" if the partition
+		// already fills the disk.
+		if strings.Contains(err.Error(), "NOCHANGE") {
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the specified device path
+// to fill the device. The filesystem may be mounted.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
 func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
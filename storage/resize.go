@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import "gopkg.in/juju/names.v3"
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag of the volume to resize.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider ID of the volume to resize.
+	VolumeId string
+
+	// Size is the minimum size that the volume must be grown to, in MiB.
+	Size uint64
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the volume after it was resized, in MiB.
+	// This may be larger than requested, if the provider rounds
+	// volume sizes up.
+	Size  uint64
+	Error error
+}
+
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the tag of the filesystem to resize.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	Volume names.VolumeTag
+
+	// FilesystemId is the provider ID of the filesystem to resize.
+	FilesystemId string
+
+	// Size is the minimum size that the filesystem must be grown to,
+	// in MiB.
+	Size uint64
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Size should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	// Size is the size of the filesystem after it was resized, in MiB.
+	Size  uint64
+	Error error
+}
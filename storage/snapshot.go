@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import "time"
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider ID of the volume that the snapshot
+	// was taken of.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64
+
+	// Status is the provider-specific status of the snapshot,
+	// e.g. "pending" or "completed".
+	Status string
+
+	// Description is the description given to the snapshot when
+	// it was taken, if any.
+	Description string
+
+	// Created is when the snapshot was taken.
+	Created time.Time
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot of
+// a volume.
+type VolumeSnapshotParams struct {
+	// VolumeId is the provider ID of the volume to snapshot.
+	VolumeId string
+
+	// Description is a human-readable description to record with the
+	// snapshot, if the storage provider supports it.
+	Description string
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
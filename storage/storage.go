@@ -47,4 +47,18 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage attachment's volume or
+	// filesystem as seen by the host, in MiB, if known.
+	Size uint64
+
+	// MoveTarget, if non-empty, is the location of the storage that
+	// the storage attachment's contents are being moved to. It is
+	// only set once the target storage is ready for use.
+	MoveTarget string
+
+	// MoveCopied is the number of bytes of the storage contents
+	// recorded as copied to MoveTarget, so that an interrupted
+	// copy may be resumed.
+	MoveCopied uint64
 }
@@ -72,6 +72,7 @@ type StateBackend interface {
 	AddSubnetIdToSubnetDocs() error
 	ReplacePortsDocSubnetIDCIDR() error
 	EnsureRelationApplicationSettings() error
+	AddOfferConnectionCounts() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -289,3 +290,7 @@ func (s stateBackend) ReplacePortsDocSubnetIDCIDR() error {
 func (s stateBackend) EnsureRelationApplicationSettings() error {
 	return state.EnsureRelationApplicationSettings(s.pool)
 }
+
+func (s stateBackend) AddOfferConnectionCounts() error {
+	return state.AddOfferConnectionCounts(s.pool)
+}
@@ -55,5 +55,12 @@ func stateStepsFor27() []Step {
 				return context.State().EnsureRelationApplicationSettings()
 			},
 		},
+		&upgradeStep{
+			description: "add connection counts to application offers",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddOfferConnectionCounts()
+			},
+		},
 	}
 }
@@ -59,3 +59,8 @@ func (s *steps27Suite) TestEnsureRelationApplicationSettings(c *gc.C) {
 	step := findStateStep(c, v27, `ensure application settings exist for all relations`)
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps27Suite) TestAddOfferConnectionCounts(c *gc.C) {
+	step := findStateStep(c, v27, `add connection counts to application offers`)
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
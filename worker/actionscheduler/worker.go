@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// Facade represents the API methods used by the action scheduler worker.
+type Facade interface {
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+	RunDueActionSchedules() (time.Time, error)
+}
+
+// Config holds the resources and configuration
+// necessary to run an action scheduler worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot be expected
+// to run a functional action scheduler worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker runs the actions of the model's action schedules
+// as they come due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that runs scheduled actions.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watch, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Schedules that came due while the worker was not running are
+	// dealt with on the first change, according to their missed run
+	// policies. After that, the worker sleeps until the earliest
+	// schedule is due, or until the schedules change.
+	var timeout <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+		case <-timeout:
+		}
+
+		next, err := w.config.Facade.RunDueActionSchedules()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timeout = nil
+		if !next.IsZero() {
+			wait := next.Sub(w.config.Clock.Now())
+			if wait < 0 {
+				wait = 0
+			}
+			w.config.Logger.Debugf("next action schedule due in %v", wait)
+			timeout = w.config.Clock.After(wait)
+		}
+	}
+}
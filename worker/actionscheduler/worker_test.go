@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	changes chan struct{}
+	facade  *fakeFacade
+	ran     chan time.Time
+	next    time.Time
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 1, 30, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.ran = make(chan time.Time, 1)
+	s.facade = &fakeFacade{suite: s}
+}
+
+func (s *workerSuite) config() actionscheduler.Config {
+	return actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Facade = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	cfg = s.config()
+	cfg.Clock = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	cfg = s.config()
+	cfg.Logger = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Logger not valid")
+}
+
+func (s *workerSuite) TestWatchError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+
+	w, err := actionscheduler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *workerSuite) TestRunOnChange(c *gc.C) {
+	w, err := actionscheduler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- struct{}{}
+	s.waitRun(c)
+	s.changes <- struct{}{}
+	s.waitRun(c)
+	s.facade.CheckCallNames(c, "WatchActionSchedules", "RunDueActionSchedules", "RunDueActionSchedules")
+}
+
+func (s *workerSuite) TestRunWhenDue(c *gc.C) {
+	s.next = s.clock.Now().Add(30 * time.Minute)
+	w, err := actionscheduler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- struct{}{}
+	s.waitRun(c)
+	s.noRun(c)
+
+	c.Assert(s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitRun(c)
+}
+
+func (s *workerSuite) TestRunError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("boom"))
+
+	w, err := actionscheduler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *workerSuite) waitRun(c *gc.C) {
+	select {
+	case <-s.ran:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schedules to run")
+	}
+}
+
+func (s *workerSuite) noRun(c *gc.C) {
+	select {
+	case <-s.ran:
+		c.Fatalf("unexpected run of schedules")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeFacade struct {
+	testing.Stub
+	suite *workerSuite
+}
+
+func (f *fakeFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchActionSchedules")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.suite.changes), nil
+}
+
+func (f *fakeFacade) RunDueActionSchedules() (time.Time, error) {
+	f.MethodCall(f, "RunDueActionSchedules")
+	err := f.NextErr()
+	f.suite.ran <- f.suite.clock.Now()
+	return f.suite.next, err
+}
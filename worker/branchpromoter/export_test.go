@@ -0,0 +1,8 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+const RetryDelay = retryDelay
+
+var EvaluateBranch = evaluateBranch
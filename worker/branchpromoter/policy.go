@@ -0,0 +1,107 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/juju/api/branchpromoter"
+	"github.com/juju/juju/core/model"
+)
+
+const (
+	decisionCommit  = "commit"
+	decisionAbort   = "abort"
+	decisionUntrack = "untrack"
+
+	statusActive    = "active"
+	statusError     = "error"
+	actionCompleted = "completed"
+	actionFailed    = "failed"
+)
+
+// evaluateBranch applies the branch's promotion policy to the current state
+// of its tracking units. If a decision can be made it is returned.
+// Otherwise, if the branch will satisfy its healthy duration without any
+// further change to its units, the time remaining until then is returned.
+func evaluateBranch(branch branchpromoter.Branch, now time.Time) (*branchpromoter.Decision, time.Duration) {
+	if len(branch.Units) == 0 {
+		return nil, 0
+	}
+	policy := branch.Policy
+
+	var errored []string
+	for _, u := range branch.Units {
+		if u.WorkloadStatus == statusError || u.AgentStatus == statusError || u.ActionStatus == actionFailed {
+			errored = append(errored, u.Name)
+		}
+	}
+	if len(errored) > 0 {
+		sort.Strings(errored)
+		reason := fmt.Sprintf("units in error: %s", strings.Join(errored, ", "))
+		if policy.OnError == model.BranchErrorUntrack {
+			return &branchpromoter.Decision{
+				Branch:   branch.Name,
+				Decision: decisionUntrack,
+				Units:    errored,
+				Reason:   reason,
+			}, 0
+		}
+		return &branchpromoter.Decision{
+			Branch:   branch.Name,
+			Decision: decisionAbort,
+			Reason:   reason,
+		}, 0
+	}
+
+	if policy.ActionName != "" && allUnits(branch.Units, func(u branchpromoter.Unit) bool {
+		return u.ActionStatus == actionCompleted
+	}) {
+		return &branchpromoter.Decision{
+			Branch:   branch.Name,
+			Decision: decisionCommit,
+			Reason:   fmt.Sprintf("action %q completed on all units", policy.ActionName),
+		}, 0
+	}
+
+	if policy.HealthyFor <= 0 || !allUnits(branch.Units, func(u branchpromoter.Unit) bool {
+		return u.WorkloadStatus == statusActive
+	}) {
+		return nil, 0
+	}
+
+	// All units are active; the branch is healthy once the
+	// most recent of them has been so for the policy duration.
+	// Time before the branch was created or its policy was set
+	// does not count towards the healthy duration.
+	healthySince := branch.Created
+	if branch.PolicySet.After(healthySince) {
+		healthySince = branch.PolicySet
+	}
+	for _, u := range branch.Units {
+		if u.WorkloadSince.After(healthySince) {
+			healthySince = u.WorkloadSince
+		}
+	}
+	if wait := healthySince.Add(policy.HealthyFor).Sub(now); wait > 0 {
+		return nil, wait
+	}
+	return &branchpromoter.Decision{
+		Branch:   branch.Name,
+		Decision: decisionCommit,
+		Reason:   fmt.Sprintf("all units active for %v", policy.HealthyFor),
+	}, 0
+}
+
+func allUnits(units []branchpromoter.Unit, pred func(branchpromoter.Unit) bool) bool {
+	for _, u := range units {
+		if !pred(u) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apibranchpromoter "github.com/juju/juju/api/branchpromoter"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/branchpromoter"
+)
+
+type policySuite struct {
+	testing.IsolationSuite
+
+	now time.Time
+}
+
+var _ = gc.Suite(&policySuite{})
+
+func (s *policySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *policySuite) branch(policy model.PromotionPolicy, units ...apibranchpromoter.Unit) apibranchpromoter.Branch {
+	return apibranchpromoter.Branch{
+		Name:      "new-branch",
+		Policy:    policy,
+		Units:     units,
+		Created:   s.now.Add(-time.Hour),
+		PolicySet: s.now.Add(-time.Hour),
+	}
+}
+
+func (s *policySuite) activeUnit(name string, ago time.Duration) apibranchpromoter.Unit {
+	return apibranchpromoter.Unit{
+		Name:           name,
+		WorkloadStatus: "active",
+		WorkloadSince:  s.now.Add(-ago),
+		AgentStatus:    "idle",
+	}
+}
+
+func (s *policySuite) TestNoUnits(c *gc.C) {
+	decision, wait := branchpromoter.EvaluateBranch(s.branch(model.PromotionPolicy{
+		HealthyFor: time.Minute,
+		OnError:    model.BranchErrorAbort,
+	}), s.now)
+	c.Check(decision, gc.IsNil)
+	c.Check(wait, gc.Equals, time.Duration(0))
+}
+
+func (s *policySuite) TestHealthyCommit(c *gc.C) {
+	decision, wait := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{HealthyFor: 10 * time.Minute, OnError: model.BranchErrorAbort},
+		s.activeUnit("redis/0", 20*time.Minute),
+		s.activeUnit("redis/1", 10*time.Minute),
+	), s.now)
+	c.Check(wait, gc.Equals, time.Duration(0))
+	c.Check(decision, jc.DeepEquals, &apibranchpromoter.Decision{
+		Branch:   "new-branch",
+		Decision: "commit",
+		Reason:   "all units active for 10m0s",
+	})
+}
+
+func (s *policySuite) TestHealthyPending(c *gc.C) {
+	decision, wait := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{HealthyFor: 10 * time.Minute, OnError: model.BranchErrorAbort},
+		s.activeUnit("redis/0", 20*time.Minute),
+		s.activeUnit("redis/1", 4*time.Minute),
+	), s.now)
+	c.Check(decision, gc.IsNil)
+	c.Check(wait, gc.Equals, 6*time.Minute)
+}
+
+func (s *policySuite) TestHealthySinceBranchCreated(c *gc.C) {
+	branch := s.branch(
+		model.PromotionPolicy{HealthyFor: 10 * time.Minute, OnError: model.BranchErrorAbort},
+		s.activeUnit("redis/0", 20*time.Minute),
+		s.activeUnit("redis/1", 30*time.Minute),
+	)
+	branch.Created = s.now.Add(-3 * time.Minute)
+	decision, wait := branchpromoter.EvaluateBranch(branch, s.now)
+	c.Check(decision, gc.IsNil)
+	c.Check(wait, gc.Equals, 7*time.Minute)
+}
+
+func (s *policySuite) TestHealthySincePolicySet(c *gc.C) {
+	branch := s.branch(
+		model.PromotionPolicy{HealthyFor: 10 * time.Minute, OnError: model.BranchErrorAbort},
+		s.activeUnit("redis/0", 20*time.Minute),
+		s.activeUnit("redis/1", 30*time.Minute),
+	)
+	branch.PolicySet = s.now.Add(-time.Minute)
+	decision, wait := branchpromoter.EvaluateBranch(branch, s.now)
+	c.Check(decision, gc.IsNil)
+	c.Check(wait, gc.Equals, 9*time.Minute)
+}
+
+func (s *policySuite) TestNotAllActive(c *gc.C) {
+	unit := s.activeUnit("redis/1", time.Hour)
+	unit.WorkloadStatus = "maintenance"
+
+	decision, wait := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{HealthyFor: 10 * time.Minute, OnError: model.BranchErrorAbort},
+		s.activeUnit("redis/0", time.Hour),
+		unit,
+	), s.now)
+	c.Check(decision, gc.IsNil)
+	c.Check(wait, gc.Equals, time.Duration(0))
+}
+
+func (s *policySuite) TestActionCommit(c *gc.C) {
+	u0 := s.activeUnit("redis/0", time.Minute)
+	u0.ActionStatus = "completed"
+	u1 := s.activeUnit("redis/1", time.Minute)
+	u1.ActionStatus = "completed"
+
+	decision, _ := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{ActionName: "smoke-test", OnError: model.BranchErrorAbort},
+		u0, u1,
+	), s.now)
+	c.Check(decision, jc.DeepEquals, &apibranchpromoter.Decision{
+		Branch:   "new-branch",
+		Decision: "commit",
+		Reason:   `action "smoke-test" completed on all units`,
+	})
+}
+
+func (s *policySuite) TestActionIncomplete(c *gc.C) {
+	u0 := s.activeUnit("redis/0", time.Minute)
+	u0.ActionStatus = "completed"
+	u1 := s.activeUnit("redis/1", time.Minute)
+	u1.ActionStatus = "running"
+
+	decision, wait := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{ActionName: "smoke-test", OnError: model.BranchErrorAbort},
+		u0, u1,
+	), s.now)
+	c.Check(decision, gc.IsNil)
+	c.Check(wait, gc.Equals, time.Duration(0))
+}
+
+func (s *policySuite) TestErrorAbort(c *gc.C) {
+	unit := s.activeUnit("redis/1", time.Minute)
+	unit.WorkloadStatus = "error"
+
+	decision, _ := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{HealthyFor: time.Minute, OnError: model.BranchErrorAbort},
+		s.activeUnit("redis/0", time.Hour),
+		unit,
+	), s.now)
+	c.Check(decision, jc.DeepEquals, &apibranchpromoter.Decision{
+		Branch:   "new-branch",
+		Decision: "abort",
+		Reason:   "units in error: redis/1",
+	})
+}
+
+func (s *policySuite) TestErrorUntrack(c *gc.C) {
+	u1 := s.activeUnit("redis/1", time.Minute)
+	u1.AgentStatus = "error"
+	u2 := s.activeUnit("redis/2", time.Minute)
+	u2.ActionStatus = "failed"
+
+	decision, _ := branchpromoter.EvaluateBranch(s.branch(
+		model.PromotionPolicy{ActionName: "smoke-test", OnError: model.BranchErrorUntrack},
+		u2,
+		s.activeUnit("redis/0", time.Hour),
+		u1,
+	), s.now)
+	c.Check(decision, jc.DeepEquals, &apibranchpromoter.Decision{
+		Branch:   "new-branch",
+		Decision: "untrack",
+		Units:    []string{"redis/1", "redis/2"},
+		Reason:   "units in error: redis/1, redis/2",
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/branchpromoter"
+	"github.com/juju/juju/core/watcher"
+)
+
+// retryDelay is how long to wait before retrying
+// decisions that could not be applied.
+const retryDelay = 30 * time.Second
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade represents the API methods used by the branch promoter worker.
+type Facade interface {
+	WatchBranchPromotion() (watcher.NotifyWatcher, error)
+	BranchPromotionInfo() ([]branchpromoter.Branch, error)
+	PromoteBranches([]branchpromoter.Decision) error
+}
+
+// Config holds the resources and configuration
+// necessary to run a branch promoter worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot be expected
+// to run a functional branch promoter worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker evaluates the promotion policies of in-flight branches whenever
+// they or their tracking units change, committing or backing out the
+// branches accordingly.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that applies branch promotion policies.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	watch, err := w.config.Facade.WatchBranchPromotion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The timeout is used to re-evaluate policies once the
+	// earliest pending healthy duration has elapsed.
+	var timeout <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("branch promotion watcher closed")
+			}
+		case <-timeout:
+		}
+
+		wait, err := w.evaluate()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timeout = nil
+		if wait > 0 {
+			w.config.Logger.Debugf("re-evaluating branch promotion policies in %v", wait)
+			timeout = w.config.Clock.After(wait)
+		}
+	}
+}
+
+// evaluate applies the promotion policy of every in-flight branch that has
+// one, and returns the duration to wait before evaluating them again.
+// A zero duration indicates that no re-evaluation is pending.
+func (w *Worker) evaluate() (time.Duration, error) {
+	branches, err := w.config.Facade.BranchPromotionInfo()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	now := w.config.Clock.Now()
+	var (
+		decisions []branchpromoter.Decision
+		nextWait  time.Duration
+	)
+	for _, branch := range branches {
+		decision, wait := evaluateBranch(branch, now)
+		if decision != nil {
+			w.config.Logger.Infof("branch %q: %s (%s)", decision.Branch, decision.Decision, decision.Reason)
+			decisions = append(decisions, *decision)
+			continue
+		}
+		if wait > 0 && (nextWait == 0 || wait < nextWait) {
+			nextWait = wait
+		}
+	}
+
+	if len(decisions) > 0 {
+		// A failure to apply a decision is not fatal; the policies
+		// are evaluated again, and the decisions retried, shortly.
+		if err := w.config.Facade.PromoteBranches(decisions); err != nil {
+			w.config.Logger.Errorf("applying branch promotion decisions: %v", err)
+			if nextWait == 0 || retryDelay < nextWait {
+				nextWait = retryDelay
+			}
+		}
+	}
+	return nextWait, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,195 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpromoter_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apibranchpromoter "github.com/juju/juju/api/branchpromoter"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/branchpromoter"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock    *testclock.Clock
+	changes  chan struct{}
+	facade   *fakeFacade
+	decided  chan []apibranchpromoter.Decision
+	branches []apibranchpromoter.Branch
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.decided = make(chan []apibranchpromoter.Decision, 1)
+	s.facade = &fakeFacade{suite: s}
+}
+
+func (s *workerSuite) config() branchpromoter.Config {
+	return branchpromoter.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Facade = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	cfg = s.config()
+	cfg.Clock = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	cfg = s.config()
+	cfg.Logger = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Logger not valid")
+}
+
+func (s *workerSuite) TestWatchError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+
+	w, err := branchpromoter.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *workerSuite) TestCommitOnChange(c *gc.C) {
+	s.branches = []apibranchpromoter.Branch{{
+		Name:   "new-branch",
+		Policy: model.PromotionPolicy{HealthyFor: time.Minute, OnError: model.BranchErrorAbort},
+		Units: []apibranchpromoter.Unit{{
+			Name:           "redis/0",
+			WorkloadStatus: "active",
+			WorkloadSince:  s.clock.Now().Add(-time.Hour),
+		}},
+	}}
+
+	w, err := branchpromoter.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- struct{}{}
+	c.Check(s.nextDecisions(c), jc.DeepEquals, []apibranchpromoter.Decision{{
+		Branch:   "new-branch",
+		Decision: "commit",
+		Reason:   "all units active for 1m0s",
+	}})
+}
+
+func (s *workerSuite) TestCommitAfterHealthyDuration(c *gc.C) {
+	s.branches = []apibranchpromoter.Branch{{
+		Name:   "new-branch",
+		Policy: model.PromotionPolicy{HealthyFor: 10 * time.Minute, OnError: model.BranchErrorAbort},
+		Units: []apibranchpromoter.Unit{{
+			Name:           "redis/0",
+			WorkloadStatus: "active",
+			WorkloadSince:  s.clock.Now().Add(-4 * time.Minute),
+		}},
+	}}
+
+	w, err := branchpromoter.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- struct{}{}
+	s.noDecisions(c)
+
+	c.Assert(s.clock.WaitAdvance(6*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Check(s.nextDecisions(c), jc.DeepEquals, []apibranchpromoter.Decision{{
+		Branch:   "new-branch",
+		Decision: "commit",
+		Reason:   "all units active for 10m0s",
+	}})
+}
+
+func (s *workerSuite) TestPromoteBranchesError(c *gc.C) {
+	s.branches = []apibranchpromoter.Branch{{
+		Name:   "new-branch",
+		Policy: model.PromotionPolicy{HealthyFor: time.Minute, OnError: model.BranchErrorAbort},
+		Units: []apibranchpromoter.Unit{{
+			Name:           "redis/0",
+			WorkloadStatus: "active",
+			WorkloadSince:  s.clock.Now().Add(-time.Hour),
+		}},
+	}}
+	s.facade.SetErrors(nil, nil, errors.New("boom"))
+
+	w, err := branchpromoter.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.changes <- struct{}{}
+	_ = s.nextDecisions(c)
+	workertest.CheckAlive(c, w)
+
+	// The decision is retried after a delay.
+	c.Assert(s.clock.WaitAdvance(branchpromoter.RetryDelay, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Check(s.nextDecisions(c), jc.DeepEquals, []apibranchpromoter.Decision{{
+		Branch:   "new-branch",
+		Decision: "commit",
+		Reason:   "all units active for 1m0s",
+	}})
+	s.facade.CheckCallNames(c, "WatchBranchPromotion", "BranchPromotionInfo", "PromoteBranches", "BranchPromotionInfo", "PromoteBranches")
+}
+
+func (s *workerSuite) nextDecisions(c *gc.C) []apibranchpromoter.Decision {
+	select {
+	case decisions := <-s.decided:
+		return decisions
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for branch promotion decisions")
+	}
+	return nil
+}
+
+func (s *workerSuite) noDecisions(c *gc.C) {
+	select {
+	case decisions := <-s.decided:
+		c.Fatalf("unexpected decisions: %v", decisions)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeFacade struct {
+	testing.Stub
+	suite *workerSuite
+}
+
+func (f *fakeFacade) WatchBranchPromotion() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchBranchPromotion")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.suite.changes), nil
+}
+
+func (f *fakeFacade) BranchPromotionInfo() ([]apibranchpromoter.Branch, error) {
+	f.MethodCall(f, "BranchPromotionInfo")
+	return f.suite.branches, f.NextErr()
+}
+
+func (f *fakeFacade) PromoteBranches(decisions []apibranchpromoter.Decision) error {
+	f.MethodCall(f, "PromoteBranches", decisions)
+	f.suite.decided <- decisions
+	return f.NextErr()
+}
@@ -25,6 +25,13 @@ const (
 
 	// bytesInMiB is the number of bytes in a MiB.
 	bytesInMiB = 1024 * 1024
+
+	// usageChangePercent is the change in a filesystem's used capacity,
+	// as a percentage of its total capacity, that must be observed
+	// before the block devices are reported again. This prevents the
+	// normal churn of a busy filesystem from being reported every
+	// listing period.
+	usageChangePercent = 1
 )
 
 // BlockDeviceSetter is an interface that is supplied to
@@ -60,7 +67,7 @@ func doWork(listf ListBlockDevicesFunc, b BlockDeviceSetter, old *[]storage.Bloc
 	for _, blockDevice := range blockDevices {
 		sort.Strings(blockDevice.DeviceLinks)
 	}
-	if reflect.DeepEqual(blockDevices, *old) {
+	if !blockDevicesChanged(*old, blockDevices) {
 		logger.Tracef("no changes to block devices detected")
 		return nil
 	}
@@ -71,3 +78,40 @@ func doWork(listf ListBlockDevicesFunc, b BlockDeviceSetter, old *[]storage.Bloc
 	*old = blockDevices
 	return nil
 }
+
+// blockDevicesChanged reports whether the new block devices differ from
+// the old ones, ignoring changes in filesystem usage that are smaller
+// than usageChangePercent of the filesystem's capacity.
+func blockDevicesChanged(old, new []storage.BlockDevice) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for i := range new {
+		oldDev, newDev := old[i], new[i]
+		if usageChanged(oldDev, newDev) {
+			return true
+		}
+		oldDev.UsedSize, oldDev.FreeSize = 0, 0
+		newDev.UsedSize, newDev.FreeSize = 0, 0
+		if !reflect.DeepEqual(oldDev, newDev) {
+			return true
+		}
+	}
+	return false
+}
+
+// usageChanged reports whether the filesystem usage of the block device
+// has changed significantly.
+func usageChanged(old, new storage.BlockDevice) bool {
+	total := new.UsedSize + new.FreeSize
+	if total == 0 {
+		return old.UsedSize+old.FreeSize != 0
+	}
+	var delta uint64
+	if new.UsedSize > old.UsedSize {
+		delta = new.UsedSize - old.UsedSize
+	} else {
+		delta = old.UsedSize - new.UsedSize
+	}
+	return delta*100 >= total*usageChangePercent
+}
@@ -86,6 +86,48 @@ func (s *DiskManagerWorkerSuite) TestBlockDeviceChanges(c *gc.C) {
 	}})
 }
 
+func (s *DiskManagerWorkerSuite) TestBlockDeviceUsageChanges(c *gc.C) {
+	var oldDevices []storage.BlockDevice
+	var devicesSet [][]storage.BlockDevice
+	var setDevices BlockDeviceSetterFunc = func(devices []storage.BlockDevice) error {
+		devicesSet = append(devicesSet, append([]storage.BlockDevice{}, devices...))
+		return nil
+	}
+
+	device := storage.BlockDevice{
+		DeviceName: "sda",
+		MountPoint: "/srv",
+		UsedSize:   500,
+		FreeSize:   500,
+	}
+	var listDevices diskmanager.ListBlockDevicesFunc = func() ([]storage.BlockDevice, error) {
+		return []storage.BlockDevice{device}, nil
+	}
+
+	err := diskmanager.DoWork(listDevices, setDevices, &oldDevices)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devicesSet, gc.HasLen, 1)
+
+	// Changes in usage of less than 1% of the
+	// filesystem's capacity are not reported.
+	device.UsedSize, device.FreeSize = 509, 491
+	err = diskmanager.DoWork(listDevices, setDevices, &oldDevices)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devicesSet, gc.HasLen, 1)
+
+	device.UsedSize, device.FreeSize = 510, 490
+	err = diskmanager.DoWork(listDevices, setDevices, &oldDevices)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devicesSet, gc.HasLen, 2)
+	c.Assert(devicesSet[1][0].UsedSize, gc.Equals, uint64(510))
+
+	// Changes are measured from the last report.
+	device.UsedSize, device.FreeSize = 505, 495
+	err = diskmanager.DoWork(listDevices, setDevices, &oldDevices)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devicesSet, gc.HasLen, 2)
+}
+
 func (s *DiskManagerWorkerSuite) TestBlockDevicesSorted(c *gc.C) {
 	var devicesSet [][]storage.BlockDevice
 	var setDevices BlockDeviceSetterFunc = func(devices []storage.BlockDevice) error {
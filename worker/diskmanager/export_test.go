@@ -6,6 +6,7 @@ package diskmanager
 var (
 	ListBlockDevices = listBlockDevices
 	BlockDeviceInUse = &blockDeviceInUse
+	FilesystemUsage  = &filesystemUsage
 	DoWork           = doWork
 	NewWorkerFunc    = newWorker
 )
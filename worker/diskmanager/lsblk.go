@@ -130,6 +130,15 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 			dev.InUse = true
 		}
 
+		// Record how much of a mounted filesystem's capacity is in use,
+		// so that operators can be warned before it fills up.
+		if dev.MountPoint != "" {
+			dev.UsedSize, dev.FreeSize, err = filesystemUsage(dev.MountPoint)
+			if err != nil {
+				logger.Debugf("could not get usage of %q: %v", dev.MountPoint, err)
+			}
+		}
+
 		// Add additional information from sysfs.
 		if err := addHardwareInfo(&dev); err != nil {
 			logger.Errorf(
@@ -171,6 +180,20 @@ var blockDeviceInUse = func(dev storage.BlockDevice) (bool, error) {
 	return false, err
 }
 
+// filesystemUsage returns the used and free space, in MiB, of the
+// filesystem mounted at the specified mount point. The free space
+// is that which is available to unprivileged users.
+var filesystemUsage = func(mountPoint string) (used, free uint64, _ error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(mountPoint, &st); err != nil {
+		return 0, 0, errors.Annotatef(err, "statfs %q", mountPoint)
+	}
+	blockSize := uint64(st.Bsize)
+	used = (st.Blocks - st.Bfree) * blockSize / bytesInMiB
+	free = st.Bavail * blockSize / bytesInMiB
+	return used, free, nil
+}
+
 // addHardwareInfo adds additional information about the hardware, and how it is
 // attached to the machine, to the given BlockDevice.
 func addHardwareInfo(dev *storage.BlockDevice) error {
@@ -31,6 +31,9 @@ func (s *ListBlockDevicesSuite) SetUpTest(c *gc.C) {
 	s.PatchValue(diskmanager.BlockDeviceInUse, func(storage.BlockDevice) (bool, error) {
 		return false, nil
 	})
+	s.PatchValue(diskmanager.FilesystemUsage, func(string) (uint64, uint64, error) {
+		return 0, 0, errors.New("statfs failed")
+	})
 	testing.PatchExecutable(c, s, "udevadm", `#!/bin/bash --norc`)
 }
 
@@ -248,6 +251,34 @@ EOF`)
 	c.Assert(devices, gc.HasLen, 0)
 }
 
+func (s *ListBlockDevicesSuite) TestListBlockDevicesFilesystemUsage(c *gc.C) {
+	var mountPoints []string
+	s.PatchValue(diskmanager.FilesystemUsage, func(mountPoint string) (uint64, uint64, error) {
+		mountPoints = append(mountPoints, mountPoint)
+		return 100, 900, nil
+	})
+	testing.PatchExecutable(c, s, "lsblk", `#!/bin/bash --norc
+cat <<EOF
+KNAME="sda" SIZE="240057409536" LABEL="" UUID="" TYPE="disk"
+KNAME="sda1" SIZE="1048576000" LABEL="" UUID="" FSTYPE="ext4" MOUNTPOINT="/srv" TYPE="part"
+EOF`)
+
+	devices, err := diskmanager.ListBlockDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mountPoints, jc.DeepEquals, []string{"/srv"})
+	c.Assert(devices, jc.DeepEquals, []storage.BlockDevice{{
+		DeviceName: "sda",
+		Size:       228936,
+	}, {
+		DeviceName:     "sda1",
+		Size:           1000,
+		FilesystemType: "ext4",
+		MountPoint:     "/srv",
+		UsedSize:       100,
+		FreeSize:       900,
+	}})
+}
+
 func (s *ListBlockDevicesSuite) TestListBlockDevicesDeviceFiltering(c *gc.C) {
 	testing.PatchExecutable(c, s, "lsblk", `#!/bin/bash --norc
 cat <<EOF
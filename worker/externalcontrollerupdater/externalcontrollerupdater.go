@@ -210,9 +210,10 @@ func (w *controllerWatcher) loop() error {
 	for {
 		if client == nil {
 			apiInfo := &api.Info{
-				Addrs:  info.Addrs,
-				CACert: info.CACert,
-				Tag:    names.NewUserTag(api.AnonymousUsername),
+				Addrs:    info.Addrs,
+				CACert:   info.CACert,
+				ProxyURL: info.RelayProxy,
+				Tag:      names.NewUserTag(api.AnonymousUsername),
 			}
 			client, err = w.newExternalControllerWatcherClient(apiInfo)
 			if err != nil {
@@ -237,19 +238,21 @@ func (w *controllerWatcher) loop() error {
 			if err != nil {
 				return errors.Annotate(err, "getting external controller info")
 			}
-			if reflect.DeepEqual(newInfo.Addrs, info.Addrs) {
+			if reflect.DeepEqual(newInfo.Addrs, info.Addrs) && newInfo.RelayProxy == info.RelayProxy {
 				continue
 			}
-			// API addresses have changed. Save the details to the
-			// local controller and stop the existing notify watcher
-			// and set it to nil, so we'll restart it with the new
-			// addresses.
+			// API addresses or the relay proxy have changed. Save the
+			// details to the local controller and stop the existing
+			// notify watcher and set it to nil, so we'll restart it
+			// with the new addresses.
 			info.Addrs = newInfo.Addrs
+			info.RelayProxy = newInfo.RelayProxy
 			if err := w.setExternalControllerInfo(crossmodel.ControllerInfo{
 				ControllerTag: w.tag,
 				Alias:         info.Alias,
 				Addrs:         info.Addrs,
 				CACert:        info.CACert,
+				RelayProxy:    info.RelayProxy,
 			}); err != nil {
 				return errors.Annotate(err, "caching external controller info")
 			}
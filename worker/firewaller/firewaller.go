@@ -6,6 +6,7 @@ package firewaller
 import (
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/EvilSuperstars/go-cidrman"
@@ -36,8 +37,11 @@ import (
 
 // FirewallerAPI exposes functionality off the firewaller API facade to a worker.
 type FirewallerAPI interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchSubnetChanges() (watcher.NotifyWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -119,7 +123,9 @@ func (cfg Config) Validate() error {
 	return nil
 }
 
-type portRanges map[corenetwork.PortRange]bool
+// portRanges maps the port ranges opened by a unit to the application
+// endpoint each was opened for; "" means all endpoints.
+type portRanges map[corenetwork.PortRange]string
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -131,8 +137,10 @@ type Firewaller struct {
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
 
+	modelConfigWatcher   watcher.NotifyWatcher
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.NotifyWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -149,6 +157,13 @@ type Firewaller struct {
 	relationWorkerRunner       *worker.Runner
 	pollClock                  clock.Clock
 
+	// addressFamily is the model's preferred address family, which
+	// determines the CIDRs that "allow all" access to exposed applications.
+	// It is read by the application workers, so is guarded by
+	// addressFamilyMu.
+	addressFamilyMu sync.Mutex
+	addressFamily   corenetwork.AddressFamilyPreference
+
 	cloudCallContext context.ProviderCallContext
 }
 
@@ -212,6 +227,19 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 
 func (fw *Firewaller) setUp() error {
 	var err error
+	fw.modelConfigWatcher, err = fw.firewallerApi.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := fw.catacomb.Add(fw.modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	// Read the address family before any application is started,
+	// rather than waiting for the model config watcher's first event.
+	if _, err := fw.updateAddressFamily(); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.machinesWatcher, err = fw.firewallerApi.WatchModelMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -228,6 +256,17 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// Controllers without the subnet watcher cannot report changes
+	// to the CIDRs of the spaces applications are exposed to.
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnetChanges()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching subnets: %v", err)
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -246,10 +285,30 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetsChange watcher.NotifyChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
 			return fw.catacomb.ErrDying()
+		case _, ok := <-fw.modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			changed, err := fw.updateAddressFamily()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			// The CIDRs allowing access from everywhere depend on
+			// the address family, so have each application re-read
+			// its expose settings.
+			if changed {
+				for _, applicationd := range fw.applicationids {
+					applicationd.refreshExposeInfo()
+				}
+			}
 		case change, ok := <-fw.machinesWatcher.Changes():
 			if !ok {
 				return errors.New("machines watcher closed")
@@ -284,6 +343,16 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			// The CIDRs of the spaces applications are exposed to
+			// may have changed, so have each application re-read
+			// its expose settings.
+			for _, applicationd := range fw.applicationids {
+				applicationd.refreshExposeInfo()
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -306,6 +375,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedCIDRs = change.exposedCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -470,22 +540,25 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
+	exposedCIDRs := exposedEndpointCIDRs(exposedEndpoints, fw.preferredAddressFamily())
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:           fw,
+		application:  app,
+		exposed:      exposed,
+		exposedCIDRs: exposedCIDRs,
+		unitds:       make(map[names.UnitTag]*unitData),
+		refresh:      make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedCIDRs)
 		},
 	})
 	if err != nil {
@@ -661,19 +734,19 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortRanges(subnetTag)
 	if err != nil {
 		return err
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
-		unitd, ok := machined.unitds[unitTag]
+	for portRange, opened := range ports {
+		unitd, ok := machined.unitds[opened.UnitTag]
 		if !ok {
 			// It is common to receive port change notification before
 			// registering a unit. Skip handling the port change - it will
 			// be handled when the unit is registered.
-			logger.Debugf("failed to lookup %q, skipping port change", unitTag)
+			logger.Debugf("failed to lookup %q, skipping port change", opened.UnitTag)
 			return nil
 		}
 		ranges, ok := newPortRanges[unitd.tag]
@@ -681,7 +754,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[portRange] = opened.Endpoint
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -763,26 +836,31 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
+			var remoteCIDRs set.Strings
+			if !unitd.applicationd.exposed {
 				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+				remoteCIDRs = set.NewStrings()
+				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), remoteCIDRs); err != nil {
 					return nil, errors.Trace(err)
 				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
+				logger.Debugf("CIDRS for %v: %v", unitTag, remoteCIDRs.Values())
 			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+			for portRange, endpoint := range portRanges {
+				cidrs := remoteCIDRs
+				// If the unit is exposed, allow access from the sources
+				// of the endpoint the port range was opened for.
+				if unitd.applicationd.exposed {
+					cidrs = exposedPortCIDRs(unitd.applicationd.exposedCIDRs, endpoint)
+				}
+				if cidrs.Size() == 0 {
+					continue
 				}
+				sourceCidrs := cidrs.SortedValues()
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				want = append(want, rule)
 			}
 		}
 	}
@@ -837,7 +915,7 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		}
 		// No relevant firewall rule exists, so go public.
 		if newCidrs.Size() == 0 {
-			newCidrs.Add("0.0.0.0/0")
+			newCidrs = allowAllCIDRs(fw.preferredAddressFamily())
 		}
 	}
 	for _, cidr := range newCidrs.Values() {
@@ -846,6 +924,32 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 	return nil
 }
 
+// updateAddressFamily reads the model's preferred address family from
+// the model config, returning true if it has changed.
+func (fw *Firewaller) updateAddressFamily() (bool, error) {
+	modelConfig, err := fw.firewallerApi.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	family := modelConfig.PreferredAddressFamily()
+
+	fw.addressFamilyMu.Lock()
+	defer fw.addressFamilyMu.Unlock()
+	if family == fw.addressFamily {
+		return false, nil
+	}
+	logger.Debugf("preferred address family changed from %q to %q", fw.addressFamily, family)
+	fw.addressFamily = family
+	return true, nil
+}
+
+// preferredAddressFamily returns the model's preferred address family.
+func (fw *Firewaller) preferredAddressFamily() corenetwork.AddressFamilyPreference {
+	fw.addressFamilyMu.Lock()
+	defer fw.addressFamilyMu.Unlock()
+	return fw.addressFamily
+}
+
 // flushGlobalPorts opens and closes global ports in the environment.
 // It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
 // modify the environment.
@@ -1089,23 +1193,90 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and exposed CIDRs
+// for one specific application.
 type exposedChange struct {
 	applicationd *applicationData
 	exposed      bool
+	exposedCIDRs map[string]set.Strings
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposed      bool
+	exposedCIDRs map[string]set.Strings
+	unitds       map[names.UnitTag]*unitData
+
+	// refresh is signalled when the application's expose settings
+	// must be re-read, even though the application itself has not
+	// changed.
+	refresh chan struct{}
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// refreshExposeInfo asks the application's watch loop to re-read
+// the expose settings, without blocking if a refresh is pending.
+func (ad *applicationData) refreshExposeInfo() {
+	select {
+	case ad.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// exposedEndpointCIDRs returns the CIDRs that may access each endpoint
+// of an exposed application with the given expose settings, keyed by
+// endpoint name; the "" key applies to all endpoints. Access to all
+// endpoints is allowed from everywhere if there are no expose settings,
+// and access to an endpoint is allowed from everywhere if its settings
+// have no sources.
+func exposedEndpointCIDRs(
+	exposedEndpoints map[string]params.ExposedEndpoint, family corenetwork.AddressFamilyPreference,
+) map[string]set.Strings {
+	if len(exposedEndpoints) == 0 {
+		return map[string]set.Strings{"": allowAllCIDRs(family)}
+	}
+	result := make(map[string]set.Strings)
+	for endpoint, exposed := range exposedEndpoints {
+		if len(exposed.ExposeToSpaces) == 0 && len(exposed.ExposeToCIDRs) == 0 {
+			result[endpoint] = allowAllCIDRs(family)
+			continue
+		}
+		result[endpoint] = set.NewStrings(exposed.ExposeToCIDRs...)
+	}
+	return result
+}
+
+// exposedPortCIDRs returns the CIDRs that may access a port range opened
+// for the given endpoint, given the CIDRs for each exposed endpoint. A
+// port range opened for an endpoint may be accessed from the sources of
+// that endpoint and of all endpoints; a port range opened for all
+// endpoints may be accessed from the sources of any endpoint.
+func exposedPortCIDRs(exposedCIDRs map[string]set.Strings, endpoint string) set.Strings {
+	cidrs := set.NewStrings()
+	for exposedEndpoint, exposed := range exposedCIDRs {
+		if endpoint == "" || exposedEndpoint == "" || exposedEndpoint == endpoint {
+			cidrs = cidrs.Union(exposed)
+		}
+	}
+	return cidrs
+}
+
+// allowAllCIDRs returns the CIDRs allowing access from everywhere. IPv6
+// access is only allowed for models preferring or using both address
+// families.
+func allowAllCIDRs(family corenetwork.AddressFamilyPreference) set.Strings {
+	cidrs := set.NewStrings("0.0.0.0/0")
+	if family.UsesIPv6() {
+		cidrs.Add("::/0")
+	}
+	return cidrs
+}
+
+// watchLoop watches the application's exposed flag and expose
+// settings for changes, and re-reads them when asked to refresh.
+func (ad *applicationData) watchLoop(exposed bool, exposedCIDRs map[string]set.Strings) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1124,28 +1295,45 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 			if !ok {
 				return errors.New("application watcher closed")
 			}
-			change, err := ad.application.IsExposed()
-			if err != nil {
-				if errors.IsNotFound(err) {
-					logger.Debugf("application(%q).IsExposed() returned NotFound: %v", ad.application.Name(), err)
-					return nil
-				}
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				logger.Tracef("application(%q).IsExposed() == %v (unchanged)", ad.application.Name(), exposed)
-				continue
+		case <-ad.refresh:
+		}
+		change, exposedEndpoints, err := ad.application.ExposeInfo()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.Debugf("application(%q).ExposeInfo() returned NotFound: %v", ad.application.Name(), err)
+				return nil
 			}
-			logger.Tracef("application(%q).IsExposed() changed %v => %v", ad.application.Name(), exposed, change)
+			return errors.Trace(err)
+		}
+		changeCIDRs := exposedEndpointCIDRs(exposedEndpoints, ad.fw.preferredAddressFamily())
+		if change == exposed && sameExposedCIDRs(changeCIDRs, exposedCIDRs) {
+			logger.Tracef("application(%q).ExposeInfo() == %v, %v (unchanged)", ad.application.Name(), exposed, exposedCIDRs)
+			continue
+		}
+		logger.Tracef("application(%q).ExposeInfo() changed %v, %v => %v, %v",
+			ad.application.Name(), exposed, exposedCIDRs, change, changeCIDRs)
 
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
-			}
+		exposed = change
+		exposedCIDRs = changeCIDRs
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, change, changeCIDRs}:
+		}
+	}
+}
+
+func sameExposedCIDRs(a, b map[string]set.Strings) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for endpoint, cidrsA := range a {
+		cidrsB, ok := b[endpoint]
+		if !ok || cidrsA.Size() != cidrsB.Size() || !cidrsA.Difference(cidrsB).IsEmpty() {
+			return false
 		}
 	}
+	return true
 }
 
 // Kill is part of the worker.Worker interface.
@@ -0,0 +1,9 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller defines a worker that maintains a host-level
+// firewall on the machine it runs on, mirroring the ports opened by the
+// machine's units and the exposure rules of their applications. The
+// firewall is only maintained when the model's host-firewall setting is
+// enabled, providing enforcement on providers without native firewalls.
+package hostfirewaller
@@ -0,0 +1,9 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+var (
+	RunCommand    = &runCommand
+	NewWorkerFunc = newWorker
+)
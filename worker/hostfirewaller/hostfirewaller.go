@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/iptables"
+)
+
+var logger = loggo.GetLogger("juju.worker.hostfirewaller")
+
+// Facade is an interface that is supplied to NewWorker for watching
+// and getting the host firewall rules for the local machine.
+type Facade interface {
+	WatchHostFirewallRules() (watcher.NotifyWatcher, error)
+	HostFirewallRules() (apihostfirewaller.Rules, error)
+}
+
+// runCommand runs the given command via bash, returning an error
+// annotated with the command's output if it fails.
+var runCommand = func(command string) error {
+	output, err := exec.Command("bash", "-c", command).CombinedOutput()
+	if err != nil {
+		if out := strings.TrimSpace(string(output)); out != "" {
+			err = errors.Annotate(err, out)
+		}
+	}
+	return err
+}
+
+// NewWorker returns a worker that applies the host firewall rules for
+// the machine to the host firewall whenever they may have changed.
+var NewWorker = func(facade Facade) (worker.Worker, error) {
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &hostFirewaller{facade: facade},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// hostFirewaller implements watcher.NotifyHandler, applying the host
+// firewall rules if they have changed since they were last applied.
+// The first update always applies the rules, so that a firewall left
+// behind by a previous agent run is replaced or, if the host firewall
+// has since been disabled, removed.
+type hostFirewaller struct {
+	facade  Facade
+	applied string
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *hostFirewaller) SetUp() (watcher.NotifyWatcher, error) {
+	return h.facade.WatchHostFirewallRules()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *hostFirewaller) Handle(_ <-chan struct{}) error {
+	rules, err := h.facade.HostFirewallRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	command := renderCommand(rules)
+	if command == h.applied {
+		logger.Tracef("no changes to host firewall rules detected")
+		return nil
+	}
+	if rules.Enabled {
+		logger.Infof("updating host firewall: internal %v, ingress %v", rules.InternalCIDRs, rules.IngressRules)
+	} else {
+		logger.Debugf("removing host firewall")
+	}
+	if err := runCommand(command); err != nil {
+		return errors.Annotate(err, "updating host firewall")
+	}
+	h.applied = command
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *hostFirewaller) TearDown() error {
+	return nil
+}
+
+func renderCommand(rules apihostfirewaller.Rules) string {
+	if !rules.Enabled {
+		return iptables.RemoveHostFirewallCommand{}.Render()
+	}
+	ingressRules := append([]network.IngressRule(nil), rules.IngressRules...)
+	network.SortIngressRules(ingressRules)
+	return iptables.HostFirewallCommand{
+		InternalCIDRs: rules.InternalCIDRs,
+		Rules:         ingressRules,
+	}.Render()
+}
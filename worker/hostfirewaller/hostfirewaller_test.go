@@ -0,0 +1,157 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/iptables"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+var _ = gc.Suite(&HostFirewallerWorkerSuite{})
+
+type HostFirewallerWorkerSuite struct {
+	coretesting.BaseSuite
+	commands chan string
+	facade   *fakeFacade
+}
+
+func (s *HostFirewallerWorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.commands = make(chan string, 10)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}, 1),
+	}
+	s.PatchValue(hostfirewaller.RunCommand, func(command string) error {
+		s.commands <- command
+		return nil
+	})
+}
+
+func (s *HostFirewallerWorkerSuite) TestRulesChanges(c *gc.C) {
+	rules := apihostfirewaller.Rules{
+		Enabled:       true,
+		InternalCIDRs: []string{"10.0.0.1/32"},
+		IngressRules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 80),
+		},
+	}
+	s.facade.setRules(rules)
+
+	w, err := hostfirewaller.NewWorker(s.facade)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.changes <- struct{}{}
+	c.Assert(s.nextCommand(c), gc.Equals, iptables.HostFirewallCommand{
+		InternalCIDRs: rules.InternalCIDRs,
+		Rules:         rules.IngressRules,
+	}.Render())
+
+	// The rules have not changed, so nothing is run.
+	s.facade.changes <- struct{}{}
+	s.assertNoCommand(c)
+
+	// Opening a port rebuilds the chains.
+	rules.IngressRules = append(rules.IngressRules, network.MustNewIngressRule("tcp", 5432, 5432, "10.0.0.0/8"))
+	s.facade.setRules(rules)
+	s.facade.changes <- struct{}{}
+	c.Assert(s.nextCommand(c), gc.Equals, iptables.HostFirewallCommand{
+		InternalCIDRs: rules.InternalCIDRs,
+		Rules:         rules.IngressRules,
+	}.Render())
+
+	// Disabling the host firewall removes the chains.
+	s.facade.setRules(apihostfirewaller.Rules{})
+	s.facade.changes <- struct{}{}
+	c.Assert(s.nextCommand(c), gc.Equals, iptables.RemoveHostFirewallCommand{}.Render())
+}
+
+func (s *HostFirewallerWorkerSuite) TestRemovesOnFirstUpdate(c *gc.C) {
+	w, err := hostfirewaller.NewWorker(s.facade)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.changes <- struct{}{}
+	c.Assert(s.nextCommand(c), gc.Equals, iptables.RemoveHostFirewallCommand{}.Render())
+}
+
+func (s *HostFirewallerWorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("boom")
+
+	w, err := hostfirewaller.NewWorker(s.facade)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HostFirewallerWorkerSuite) TestCommandError(c *gc.C) {
+	s.PatchValue(hostfirewaller.RunCommand, func(command string) error {
+		return errors.New("iptables: command not found")
+	})
+	s.facade.setRules(apihostfirewaller.Rules{Enabled: true})
+
+	w, err := hostfirewaller.NewWorker(s.facade)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "updating host firewall: iptables: command not found")
+}
+
+func (s *HostFirewallerWorkerSuite) nextCommand(c *gc.C) string {
+	select {
+	case command := <-s.commands:
+		return command
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for host firewall update")
+	}
+	return ""
+}
+
+func (s *HostFirewallerWorkerSuite) assertNoCommand(c *gc.C) {
+	select {
+	case command := <-s.commands:
+		c.Fatalf("unexpected host firewall update: %s", command)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeFacade struct {
+	mu       sync.Mutex
+	rules    apihostfirewaller.Rules
+	changes  chan struct{}
+	watchErr error
+}
+
+func (f *fakeFacade) setRules(rules apihostfirewaller.Rules) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = rules
+}
+
+func (f *fakeFacade) WatchHostFirewallRules() (watcher.NotifyWatcher, error) {
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) HostFirewallRules() (apihostfirewaller.Rules, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rules, nil
+}
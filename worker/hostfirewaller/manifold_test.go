@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	basetesting "github.com/juju/juju/api/base/testing"
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+type manifoldSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) TestMachineHostFirewaller(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("host firewall is only supported on linux")
+	}
+	called := false
+
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			// We don't test the api call. We test that NewWorker is
+			// passed the expected arguments.
+			return nil
+		})
+
+	s.PatchValue(&hostfirewaller.NewWorker, func(f hostfirewaller.Facade) (worker.Worker, error) {
+		called = true
+
+		api, ok := f.(*apihostfirewaller.State)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(api, gc.NotNil)
+
+		return nil, nil
+	})
+
+	a := &dummyAgent{tag: names.NewMachineTag("1")}
+
+	_, err := hostfirewaller.NewWorkerFunc(a, apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *manifoldSuite) TestNonMachineAgent(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("host firewall is only supported on linux")
+	}
+	a := &dummyAgent{tag: names.NewUnitTag("mysql/0")}
+
+	_, err := hostfirewaller.NewWorkerFunc(a, nil)
+	c.Assert(err, gc.ErrorMatches, "expected MachineTag, got .*")
+}
+
+type dummyAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (a dummyAgent) CurrentConfig() agent.Config {
+	return dummyCfg{tag: a.tag}
+}
+
+type dummyCfg struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (c dummyCfg) Tag() names.Tag {
+	return c.tag
+}
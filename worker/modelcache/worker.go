@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
@@ -451,18 +452,23 @@ func (c *cacheWorker) translateBranch(d multiwatcher.Delta) interface{} {
 		}
 	}
 
-	return cache.BranchChange{
-		ModelUUID:     value.ModelUUID,
-		Name:          value.Name,
-		Id:            value.Id,
-		AssignedUnits: value.AssignedUnits,
-		Config:        coreItemChanges(value.Config),
-		Created:       value.Created,
-		CreatedBy:     value.CreatedBy,
-		Completed:     value.Completed,
-		CompletedBy:   value.CompletedBy,
-		GenerationId:  value.GenerationId,
-	}
+	change := cache.BranchChange{
+		ModelUUID:       value.ModelUUID,
+		Name:            value.Name,
+		Id:              value.Id,
+		AssignedUnits:   value.AssignedUnits,
+		Config:          coreItemChanges(value.Config),
+		Created:         value.Created,
+		CreatedBy:       value.CreatedBy,
+		Completed:       value.Completed,
+		CompletedBy:     value.CompletedBy,
+		GenerationId:    value.GenerationId,
+		PromotionPolicy: corePromotionPolicy(value.PromotionPolicy),
+	}
+	if value.PromotionPolicy != nil {
+		change.PromotionPolicySet = value.PromotionPolicy.Set
+	}
+	return change
 }
 
 // Kill is part of the worker.Worker interface.
@@ -532,6 +538,17 @@ func coreLXDProfile(delta *multiwatcher.Profile) lxdprofile.Profile {
 	}
 }
 
+func corePromotionPolicy(policy *multiwatcher.PromotionPolicy) *model.PromotionPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &model.PromotionPolicy{
+		HealthyFor: policy.HealthyFor,
+		ActionName: policy.ActionName,
+		OnError:    model.BranchErrorPolicy(policy.OnError),
+	}
+}
+
 func coreItemChanges(delta map[string][]multiwatcher.ItemChange) map[string]settings.ItemChanges {
 	if delta == nil {
 		return nil
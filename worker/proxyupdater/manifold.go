@@ -32,6 +32,11 @@ type ManifoldConfig struct {
 	ExternalUpdate  func(proxy.Settings) error
 	InProcessUpdate func(proxy.Settings) error
 	RunFunc         func(string, string, ...string) (string, error)
+
+	// AptSourcesFile is the apt sources list rewritten when the apt
+	// mirror changes. It is left empty for agents that must not
+	// change the machine's package sources.
+	AptSourcesFile string
 }
 
 // Manifold returns a dependency manifold that runs a proxy updater worker,
@@ -72,6 +77,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				InProcessUpdate: config.InProcessUpdate,
 				Logger:          config.Logger,
 				RunFunc:         config.RunFunc,
+				AptSourcesFile:  config.AptSourcesFile,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -7,7 +7,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
+	stdos "os"
 	stdexec "os/exec"
+	"regexp"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/os"
@@ -32,6 +36,11 @@ type Config struct {
 	InProcessUpdate func(proxy.Settings) error
 	RunFunc         func(string, string, ...string) (string, error)
 	Logger          Logger
+
+	// AptSourcesFile, if set, is the apt sources list whose primary
+	// archive entries are pointed at the apt mirror of the machine's
+	// spaces.
+	AptSourcesFile string
 }
 
 // Validate ensures that all the required fields have values.
@@ -61,8 +70,9 @@ type API interface {
 // changes are apt proxy configuration and the juju proxies stored in the juju
 // proxy file.
 type proxyWorker struct {
-	aptProxy proxy.Settings
-	proxy    proxy.Settings
+	aptProxy  proxy.Settings
+	aptMirror string
+	proxy     proxy.Settings
 
 	snapProxy           proxy.Settings
 	snapStoreProxy      string
@@ -302,6 +312,97 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxy.Settings) error {
 	return nil
 }
 
+// aptSourceLine matches the entries of an apt sources list, capturing
+// the URI of the archive.
+var aptSourceLine = regexp.MustCompile(`^(\s*deb(?:-src)?\s+(?:\[[^\]]*\]\s+)?)(\S+)(.*)$`)
+
+// aptSourcesBackupSuffix is appended to the name of the apt sources list
+// to name the copy of the list made before the space apt mirror was
+// first applied, from which the list is restored once no space apt
+// mirror applies.
+const aptSourcesBackupSuffix = ".juju-backup"
+
+// isPrimaryArchive reports whether the given apt source URI refers to
+// the primary Ubuntu archive, or one of its country mirrors. Other
+// archives, such as the security and ports archives and PPAs, are not
+// served by an apt mirror of the primary archive.
+func isPrimaryArchive(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host != "archive.ubuntu.com" && !strings.HasSuffix(host, ".archive.ubuntu.com") {
+		return false
+	}
+	return strings.TrimSuffix(u.Path, "/") == "/ubuntu"
+}
+
+// handleAptMirrorValue points the primary archive entries of the apt
+// sources list at the apt mirror of the machine's spaces, which is only
+// set if it differs from the model's apt mirror. The model's apt mirror
+// is applied by cloud-init when the machine is provisioned, so the list
+// is otherwise left as it is, or restored from the backup made before
+// a space apt mirror was applied.
+func (w *proxyWorker) handleAptMirrorValue(mirror string) {
+	if w.config.AptSourcesFile == "" {
+		w.config.Logger.Tracef("apt mirror not updated by this agent")
+		return
+	}
+	if mirror == w.aptMirror && !w.first {
+		return
+	}
+	backupFile := w.config.AptSourcesFile + aptSourcesBackupSuffix
+	if mirror == "" {
+		err := stdos.Rename(backupFile, w.config.AptSourcesFile)
+		if err == nil {
+			w.config.Logger.Debugf("restored apt sources file from %q", backupFile)
+		} else if !stdos.IsNotExist(err) {
+			w.config.Logger.Errorf("error restoring apt sources file: %v", err)
+			return
+		}
+		w.aptMirror = mirror
+		return
+	}
+
+	// The entries are always rewritten from the original list, so that
+	// only entries for the primary archive are pointed at the mirror.
+	content, err := ioutil.ReadFile(backupFile)
+	if stdos.IsNotExist(err) {
+		content, err = ioutil.ReadFile(w.config.AptSourcesFile)
+		if stdos.IsNotExist(err) {
+			w.config.Logger.Debugf("no apt sources file %q, apt mirror not updated", w.config.AptSourcesFile)
+			return
+		} else if err != nil {
+			w.config.Logger.Errorf("error reading apt sources file: %v", err)
+			return
+		}
+		if err := ioutil.WriteFile(backupFile, content, 0644); err != nil {
+			w.config.Logger.Errorf("error backing up apt sources file: %v", err)
+			return
+		}
+	} else if err != nil {
+		w.config.Logger.Errorf("error reading apt sources backup file: %v", err)
+		return
+	}
+	w.config.Logger.Debugf("new space apt mirror %q", mirror)
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		match := aptSourceLine.FindStringSubmatch(line)
+		if match == nil || !isPrimaryArchive(match[2]) {
+			continue
+		}
+		lines[i] = match[1] + mirror + match[3]
+	}
+	err = ioutil.WriteFile(w.config.AptSourcesFile, []byte(strings.Join(lines, "\n")), 0644)
+	if err != nil {
+		// It isn't really fatal, but we should record it.
+		w.config.Logger.Errorf("error writing apt sources file: %v", err)
+		return
+	}
+	w.aptMirror = mirror
+}
+
 func (w *proxyWorker) onChange() error {
 	config, err := w.config.API.ProxyConfig()
 	if err != nil {
@@ -310,6 +411,7 @@ func (w *proxyWorker) onChange() error {
 
 	w.handleProxyValues(config.LegacyProxy, config.JujuProxy)
 	w.handleSnapProxyValues(config.SnapProxy, config.SnapStoreProxyId, config.SnapStoreProxyAssertions, config.SnapStoreProxyURL)
+	w.handleAptMirrorValue(config.SpaceAptMirror)
 	return w.handleAptProxyValues(config.APTProxy)
 }
 
@@ -608,3 +608,92 @@ DATA...
 		"proxy.store=WhatDoesTheBigRedButtonDo",
 	})
 }
+
+const aptSources = `
+deb http://archive.ubuntu.com/ubuntu bionic main restricted
+deb-src [arch=amd64] http://gb.archive.ubuntu.com/ubuntu/ bionic main
+deb http://security.ubuntu.com/ubuntu bionic-security main
+deb http://ports.ubuntu.com/ubuntu-ports bionic main
+# deb http://archive.ubuntu.com/ubuntu bionic universe
+deb http://ppa.launchpad.net/juju/stable/ubuntu bionic main
+`
+
+func (s *ProxyUpdaterSuite) TestSpaceAptMirror(c *gc.C) {
+	sourcesFile := filepath.Join(c.MkDir(), "sources.list")
+	err := ioutil.WriteFile(sourcesFile, []byte(aptSources), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config.AptSourcesFile = sourcesFile
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{
+		AptMirror:      "http://dmz-mirror/ubuntu",
+		SpaceAptMirror: "http://dmz-mirror/ubuntu",
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, updater)
+
+	// Only the primary archive entries are pointed at the mirror.
+	s.waitForFile(c, sourcesFile, `
+deb http://dmz-mirror/ubuntu bionic main restricted
+deb-src [arch=amd64] http://dmz-mirror/ubuntu bionic main
+deb http://security.ubuntu.com/ubuntu bionic-security main
+deb http://ports.ubuntu.com/ubuntu-ports bionic main
+# deb http://archive.ubuntu.com/ubuntu bionic universe
+deb http://ppa.launchpad.net/juju/stable/ubuntu bionic main
+`)
+	s.waitForFile(c, sourcesFile+".juju-backup", aptSources)
+}
+
+func (s *ProxyUpdaterSuite) TestModelAptMirror(c *gc.C) {
+	sourcesFile := filepath.Join(c.MkDir(), "sources.list")
+	err := ioutil.WriteFile(sourcesFile, []byte(aptSources), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config.AptSourcesFile = sourcesFile
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{
+		AptMirror: "http://mirror/ubuntu",
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	// Wait for the settings to be applied before stopping the worker.
+	select {
+	case <-s.inProcSettings:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for proxy settings")
+	}
+	workertest.CleanKill(c, updater)
+
+	// The model's apt mirror is applied when the machine is
+	// provisioned, so the sources are left alone.
+	content, err := ioutil.ReadFile(sourcesFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, aptSources)
+	_, err = os.Stat(sourcesFile + ".juju-backup")
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ProxyUpdaterSuite) TestSpaceAptMirrorRemoved(c *gc.C) {
+	sourcesFile := filepath.Join(c.MkDir(), "sources.list")
+	err := ioutil.WriteFile(sourcesFile, []byte(aptSources), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config.AptSourcesFile = sourcesFile
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{
+		SpaceAptMirror: "http://dmz-mirror/ubuntu",
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForFile(c, sourcesFile+".juju-backup", aptSources)
+	workertest.CleanKill(c, updater)
+
+	// Once no space apt mirror applies, the original sources are
+	// restored.
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{}
+	updater, err = proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, updater)
+
+	s.waitForFile(c, sourcesFile, aptSources)
+	_, err = os.Stat(sourcesFile + ".juju-backup")
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
@@ -8,11 +8,14 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending filesystems, and
+// for volumes backing provisioned filesystems, so that filesystems can
+// be grown when their volumes are resized.
 func machineBlockDevicesChanged(ctx *context) error {
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
 	// We must query volumes for both incomplete filesystems
@@ -57,6 +60,22 @@ func machineBlockDevicesChanged(ctx *context) error {
 			volumeTags = append(volumeTags, filesystem.Volume)
 		}
 	}
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		var found bool
+		for _, tag := range volumeTags {
+			if filesystem.Volume == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			volumeTags = append(volumeTags, filesystem.Volume)
+		}
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
@@ -101,9 +120,22 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 	if err != nil {
 		return errors.Annotate(err, "refreshing volume block devices")
 	}
+	if err := fetchVolumeEncryptionKeys(ctx, ids, volumeTags, results); err != nil {
+		return errors.Trace(err)
+	}
 	for i, result := range results {
 		if result.Error == nil {
-			ctx.volumeBlockDevices[volumeTags[i]] = result.Result
+			if _, ok := ctx.volumeEncryptionKeys[volumeTags[i]]; !ok {
+				// We do not yet know whether or not the volume is
+				// encrypted, so we must not use the block device
+				// until the volume's attachment plan is processed.
+				continue
+			}
+			blockDevice, err := volumeBlockDevice(ctx, volumeTags[i], result.Result)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			ctx.volumeBlockDevices[volumeTags[i]] = blockDevice
 			for _, params := range ctx.incompleteFilesystemParams {
 				if params.Volume == volumeTags[i] {
 					updatePendingFilesystem(ctx, params)
@@ -118,6 +150,7 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 					updatePendingFilesystemAttachment(ctx, id, params)
 				}
 			}
+			maybeResizeFilesystems(ctx, volumeTags[i], blockDevice)
 		} else if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFound(result.Error) {
 			// Either the volume (attachment) isn't provisioned,
 			// or the corresponding block device is not yet known.
@@ -133,3 +166,25 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 	}
 	return nil
 }
+
+// maybeResizeFilesystems schedules the resizing of any provisioned
+// filesystems backed by the specified volume, if the volume's block
+// device has grown larger than the filesystem.
+func maybeResizeFilesystems(ctx *context, volumeTag names.VolumeTag, blockDevice storage.BlockDevice) {
+	for tag, filesystem := range ctx.filesystems {
+		if filesystem.Volume != volumeTag || blockDevice.Size <= filesystem.Size {
+			continue
+		}
+		if _, ok := ctx.pendingFilesystemResizes[tag]; ok {
+			continue
+		}
+		op := &resizeFilesystemOp{args: storage.FilesystemResizeParams{
+			Tag:          tag,
+			Volume:       volumeTag,
+			FilesystemId: filesystem.FilesystemId,
+			Size:         blockDevice.Size,
+		}}
+		ctx.pendingFilesystemResizes[tag] = op
+		scheduleOperations(ctx, op)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/luks"
+)
+
+// openEncryptedVolume and closeEncryptedVolume set up and tear down
+// the decrypted device of an encrypted volume. They are variables so
+// that they may be replaced in tests.
+var (
+	openEncryptedVolume  = luks.Open
+	closeEncryptedVolume = luks.Close
+)
+
+// fetchVolumeEncryptionKeys records the encryption keys of the volumes
+// of the specified attachments, for those volumes whose block devices
+// were successfully obtained and whose keys are not already known.
+//
+// A volume's key is not available until its attachment plan has been
+// created; such volumes are left unknown, and are refreshed again when
+// the attachment plan is processed.
+func fetchVolumeEncryptionKeys(
+	ctx *context,
+	ids []params.MachineStorageId,
+	volumeTags []names.VolumeTag,
+	blockDeviceResults []params.BlockDeviceResult,
+) error {
+	var unknownIds []params.MachineStorageId
+	var unknownTags []names.VolumeTag
+	for i, result := range blockDeviceResults {
+		if result.Error != nil {
+			continue
+		}
+		if _, ok := ctx.volumeEncryptionKeys[volumeTags[i]]; ok {
+			continue
+		}
+		unknownIds = append(unknownIds, ids[i])
+		unknownTags = append(unknownTags, volumeTags[i])
+	}
+	if len(unknownIds) == 0 {
+		return nil
+	}
+	results, err := ctx.config.Volumes.VolumeEncryptionKeys(unknownIds)
+	if errors.IsNotSupported(err) {
+		// The controller predates volume encryption,
+		// so none of the volumes can be encrypted.
+		for _, tag := range unknownTags {
+			ctx.volumeEncryptionKeys[tag] = ""
+		}
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting volume encryption keys")
+	}
+	for i, result := range results {
+		if result.Error == nil {
+			ctx.volumeEncryptionKeys[unknownTags[i]] = result.Result
+		} else if !params.IsCodeNotFound(result.Error) {
+			return errors.Annotatef(
+				result.Error, "getting encryption key for %s",
+				names.ReadableString(unknownTags[i]),
+			)
+		}
+	}
+	return nil
+}
+
+// volumeBlockDevice returns the block device that should be used for
+// the specified volume, given the block device that the volume is
+// attached as. For encrypted volumes, this is the decrypted device,
+// which is opened if necessary.
+func volumeBlockDevice(ctx *context, tag names.VolumeTag, attached storage.BlockDevice) (storage.BlockDevice, error) {
+	key := ctx.volumeEncryptionKeys[tag]
+	if key == "" {
+		return attached, nil
+	}
+	devicePath, err := storage.BlockDevicePath(attached)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	blockDevice, err := openEncryptedVolume(devicePath, tag, key)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Annotatef(
+			err, "opening encrypted %s", names.ReadableString(tag),
+		)
+	}
+	return blockDevice, nil
+}
+
+// forgetEncryptedVolume closes the decrypted device of the specified
+// volume, if it is open, and forgets the volume's encryption key.
+func forgetEncryptedVolume(ctx *context, tag names.VolumeTag) error {
+	// The key may not be known if the worker restarted since the
+	// volume was opened, so always attempt to close the device.
+	if err := closeEncryptedVolume(tag); err != nil {
+		return errors.Annotatef(err, "closing encrypted %s", names.ReadableString(tag))
+	}
+	if ctx.volumeEncryptionKeys[tag] != "" {
+		delete(ctx.volumeBlockDevices, tag)
+	}
+	delete(ctx.volumeEncryptionKeys, tag)
+	return nil
+}
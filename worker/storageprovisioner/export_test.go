@@ -7,6 +7,10 @@ import "gopkg.in/juju/worker.v1"
 
 var (
 	NewManagedFilesystemSource = &newManagedFilesystemSource
+	OpenEncryptedVolume        = &openEncryptedVolume
+	CloseEncryptedVolume       = &closeEncryptedVolume
+	ValidateVolumeParams       = validateVolumeParams
+	ValidateFilesystemParams   = validateFilesystemParams
 )
 
 func StorageWorker(parent worker.Worker, appName string) (worker.Worker, bool) {
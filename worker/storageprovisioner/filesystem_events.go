@@ -146,6 +146,8 @@ func updatePendingFilesystem(ctx *context, params storage.FilesystemParams) {
 func removePendingFilesystem(ctx *context, tag names.FilesystemTag) {
 	delete(ctx.incompleteFilesystemParams, tag)
 	ctx.schedule.Remove(tag)
+	delete(ctx.pendingFilesystemResizes, tag)
+	ctx.schedule.Remove(resizeKey{tag})
 }
 
 // updatePendingFilesystemAttachment adds the given filesystem attachment params to
@@ -488,6 +490,7 @@ func filesystemParamsFromParams(in params.FilesystemParams) (storage.FilesystemP
 		Provider:     providerType,
 		Attributes:   in.Attributes,
 		ResourceTags: in.Tags,
+		SnapshotId:   in.SnapshotId,
 	}, nil
 }
 
@@ -415,6 +415,14 @@ func validateFilesystemParams(
 	results := make([]error, len(filesystemParams))
 	for i, params := range filesystemParams {
 		err := filesystemSource.ValidateFilesystemParams(params)
+		if err == nil && params.SnapshotId != "" && params.Volume == (names.VolumeTag{}) {
+			// Only volume-backed filesystems can be created from
+			// a snapshot; the snapshot is restored to the volume.
+			err = errors.NotSupportedf(
+				"creating filesystem from snapshot with storage provider %q",
+				params.Provider,
+			)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -532,6 +540,84 @@ func filesystemAttachmentsFromStorage(in []storage.FilesystemAttachment) []param
 	return out
 }
 
+// resizeFilesystems grows volume-backed filesystems to fill their
+// backing volumes' block devices.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		logger.Debugf("managed filesystem source does not support resizing")
+		for tag := range ops {
+			delete(ctx.pendingFilesystemResizes, tag)
+		}
+		return nil
+	}
+	args := make([]storage.FilesystemResizeParams, 0, len(ops))
+	for _, op := range ops {
+		args = append(args, op.args)
+	}
+	logger.Debugf("resizing filesystems: %v", args)
+	results, err := resizer.ResizeFilesystems(ctx.config.CloudCallContext, args)
+	if err != nil {
+		return errors.Annotate(err, "resizing managed filesystems")
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	var sizes []params.StorageSize
+	for i, result := range results {
+		tag := args[i].Tag
+		op := ops[tag]
+		if result.Error != nil {
+			// Reschedule the resize, and record the failure in
+			// the filesystem's status until it succeeds.
+			reschedule = append(reschedule, op)
+			op.failed = true
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Error.String(),
+				Info:   errors.Annotate(result.Error, "resizing filesystem").Error(),
+			})
+			logger.Debugf(
+				"failed to resize %s: %v",
+				names.ReadableString(tag), result.Error,
+			)
+			continue
+		}
+		if op.failed {
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Attached.String(),
+			})
+		}
+		sizes = append(sizes, params.StorageSize{
+			Tag:  tag.String(),
+			Size: result.Size,
+		})
+		if filesystem, ok := ctx.filesystems[tag]; ok {
+			filesystem.Size = result.Size
+			ctx.filesystems[tag] = filesystem
+		}
+		delete(ctx.pendingFilesystemResizes, tag)
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(sizes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemSizes(sizes)
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystem sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing size of filesystem %s to state: %v",
+				sizes[i].Tag, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
 type createFilesystemOp struct {
 	exponentialBackoff
 	args storage.FilesystemParams
@@ -573,3 +659,16 @@ func (op *detachFilesystemOp) key() interface{} {
 		AttachmentTag: op.args.Filesystem.String(),
 	}
 }
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args storage.FilesystemResizeParams
+
+	// failed records whether a previous attempt to resize
+	// the filesystem failed, and so set the filesystem's status.
+	failed bool
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
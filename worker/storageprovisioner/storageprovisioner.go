@@ -71,6 +71,10 @@ type VolumeAccessor interface {
 	// the specified volume attachment IDs.
 	VolumeBlockDevices([]params.MachineStorageId) ([]params.BlockDeviceResult, error)
 
+	// VolumeEncryptionKeys returns the keys with which the volumes of
+	// the specified attachments are encrypted at rest.
+	VolumeEncryptionKeys([]params.MachineStorageId) ([]params.StringResult, error)
+
 	// VolumeAttachments returns details of volume attachments with
 	// the specified tags.
 	VolumeAttachments([]params.MachineStorageId) ([]params.VolumeAttachmentResult, error)
@@ -89,6 +93,18 @@ type VolumeAccessor interface {
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, including requests to
+	// resize them.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// SetVolumeSizes records the sizes of resized volumes.
+	SetVolumeSizes([]params.StorageSize) ([]params.ErrorResult, error)
+
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
@@ -137,6 +153,9 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// SetFilesystemSizes records the sizes of resized filesystems.
+	SetFilesystemSizes([]params.StorageSize) ([]params.ErrorResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -217,6 +236,7 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
@@ -255,6 +275,7 @@ func (w *storageProvisioner) loop() error {
 		volumes:                              make(map[names.VolumeTag]storage.Volume),
 		volumeAttachments:                    make(map[params.MachineStorageId]storage.VolumeAttachment),
 		volumeBlockDevices:                   make(map[names.VolumeTag]storage.BlockDevice),
+		volumeEncryptionKeys:                 make(map[names.VolumeTag]string),
 		filesystems:                          make(map[names.FilesystemTag]storage.Filesystem),
 		filesystemAttachments:                make(map[params.MachineStorageId]storage.FilesystemAttachment),
 		machines:                             make(map[names.MachineTag]*machineWatcher),
@@ -265,6 +286,8 @@ func (w *storageProvisioner) loop() error {
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
 		pendingVolumeBlockDevices:            names.NewSet(),
+		pendingVolumeResizes:                 make(map[names.VolumeTag]*resizeVolumeOp),
+		pendingFilesystemResizes:             make(map[names.FilesystemTag]*resizeFilesystemOp),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
 		ctx.volumeBlockDevices, ctx.filesystems,
@@ -285,6 +308,20 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		volumesChanges = volumesWatcher.Changes()
+
+		// Resizing volumes requires a newer controller; if the
+		// controller does not support it, carry on without.
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if errors.IsNotSupported(err) {
+			logger.Debugf("not watching volume resizes: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
 	}
 
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems(w.config.Scope)
@@ -331,6 +368,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -390,6 +434,8 @@ func processSchedule(ctx *context) error {
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -410,6 +456,10 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
 		}
 	}
 	if len(removeVolumeOps) > 0 {
@@ -452,6 +502,16 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
 	return nil
 }
 
@@ -471,6 +531,14 @@ type context struct {
 	// is only used by the machine-scoped storage provisioner.
 	volumeBlockDevices map[names.VolumeTag]storage.BlockDevice
 
+	// volumeEncryptionKeys contains the keys with which volumes
+	// attached to the scope-machine are encrypted, or the empty
+	// string for volumes that are known not to be encrypted. Keys
+	// are only held in memory, and are forgotten when the volume's
+	// attachment plan is removed. This is only used by the
+	// machine-scoped storage provisioner.
+	volumeEncryptionKeys map[names.VolumeTag]string
+
 	// filesystems contains information about provisioned filesystems.
 	filesystems map[names.FilesystemTag]storage.Filesystem
 
@@ -526,6 +594,15 @@ type context struct {
 	// block devices we wish to enquire.
 	pendingVolumeBlockDevices names.Set
 
+	// pendingVolumeResizes contains the scheduled operations for
+	// volumes that have been requested to be resized.
+	pendingVolumeResizes map[names.VolumeTag]*resizeVolumeOp
+
+	// pendingFilesystemResizes contains the scheduled operations for
+	// filesystems whose backing volumes have grown, and which must be
+	// grown to match.
+	pendingFilesystemResizes map[names.FilesystemTag]*resizeFilesystemOp
+
 	// managedFilesystemSource is a storage.FilesystemSource that
 	// manages filesystems backed by volumes attached to the host
 	// machine.
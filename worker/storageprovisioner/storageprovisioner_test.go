@@ -55,6 +55,9 @@ func (s *storageProvisionerSuite) SetUpTest(c *gc.C) {
 			return s.managedFilesystemSource
 		},
 	)
+	s.PatchValue(storageprovisioner.CloseEncryptedVolume, func(names.VolumeTag) error {
+		return nil
+	})
 }
 
 func (s *storageProvisionerSuite) TestStartStop(c *gc.C) {
@@ -701,6 +704,41 @@ func (s *storageProvisionerSuite) TestValidateFilesystemParams(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestValidateVolumeParamsSnapshotNotSupported(c *gc.C) {
+	volumeParams := []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		Provider: "dummy",
+	}, {
+		Tag:        names.NewVolumeTag("2"),
+		Provider:   "dummy",
+		SnapshotId: "snap-0123",
+	}}
+	valid, errs := storageprovisioner.ValidateVolumeParams(&dummyVolumeSource{}, volumeParams)
+	c.Assert(valid, jc.DeepEquals, volumeParams[:1])
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.Satisfies, errors.IsNotSupported)
+	c.Assert(errs[1], gc.ErrorMatches, `creating volume from snapshot with storage provider "dummy" not supported`)
+}
+
+func (s *storageProvisionerSuite) TestValidateFilesystemParamsSnapshot(c *gc.C) {
+	filesystemParams := []storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("1"),
+		Volume:     names.NewVolumeTag("1"),
+		Provider:   "dummy",
+		SnapshotId: "snap-0123",
+	}, {
+		Tag:        names.NewFilesystemTag("2"),
+		Provider:   "dummy",
+		SnapshotId: "snap-0123",
+	}}
+	valid, errs := storageprovisioner.ValidateFilesystemParams(&dummyFilesystemSource{}, filesystemParams)
+	c.Assert(valid, jc.DeepEquals, filesystemParams[:1])
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `creating filesystem from snapshot with storage provider "dummy" not supported`)
+}
+
 func (s *storageProvisionerSuite) TestFilesystemAdded(c *gc.C) {
 	expectedFilesystems := []params.Filesystem{{
 		FilesystemTag: "filesystem-1",
@@ -1066,6 +1104,62 @@ func (s *storageProvisionerSuite) TestCreateVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestCreateEncryptedVolumeBackedFilesystem(c *gc.C) {
+	var opened []interface{}
+	s.PatchValue(storageprovisioner.OpenEncryptedVolume, func(
+		devicePath string, tag names.VolumeTag, key string,
+	) (storage.BlockDevice, error) {
+		opened = append(opened, devicePath, tag, key)
+		return storage.BlockDevice{
+			DeviceName: "mapper/juju-0-0",
+			Size:       107,
+		}, nil
+	})
+
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return nil, nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	args.volumes.encryptionKeys["volume-0-0"] = "sekrit"
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       123,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+
+	// The filesystem must be created on the decrypted
+	// device, rather than on the attached block device.
+	filesystemInfo := waitChannel(
+		c, filesystemInfoSet,
+		"waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(filesystemInfo, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "mapper/juju-0-0",
+			Size:         107,
+		},
+	}})
+	c.Assert(opened, jc.DeepEquals, []interface{}{
+		"/dev/xvdf1", names.NewVolumeTag("0/0"), "sekrit",
+	})
+}
+
 func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	infoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -1119,6 +1213,147 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolume(c *gc.C) {
+	sizesSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.resizeRequests["volume-1"] = 2048
+	volumeAccessor.setVolumeSizes = func(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+		sizesSet <- sizes
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	var resizeArgs []storage.VolumeResizeParams
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeArgs = append(resizeArgs, args...)
+		return []storage.ResizeVolumesResult{{Size: 2050}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	sizes := waitChannel(c, sizesSet, "waiting for volume size to be set")
+	c.Assert(sizes, jc.DeepEquals, []params.StorageSize{{
+		Tag:  "volume-1",
+		Size: 2050,
+	}})
+	c.Assert(resizeArgs, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeNoRequest(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	var resized bool
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resized = true
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+
+	// There is no outstanding resize request for the volume,
+	// so the change should be ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	workertest.CleanKill(c, worker)
+	c.Assert(resized, jc.IsFalse)
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeRetry(c *gc.C) {
+	sizesSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.resizeRequests["volume-1"] = 2048
+	volumeAccessor.setVolumeSizes = func(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+		defer close(sizesSet)
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	// mockFunc's After will progress the current time by the specified
+	// duration and signal the channel immediately.
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []storage.ResizeVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.ResizeVolumesResult{{Size: args[0].Size}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, sizesSet, "waiting for volume size to be set")
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+	c.Assert(resizeVolumeTimes[0], gc.Equals, time.Time{})
+	c.Assert(resizeVolumeTimes[1].Sub(resizeVolumeTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(resizeVolumeTimes[2].Sub(resizeVolumeTimes[1]), gc.Equals, time.Minute)
+
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "attached", Info: ""},
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeBackedFilesystem(c *gc.C) {
+	sizesSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemSizes = func(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+		sizesSet <- sizes
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         123,
+		},
+	}
+
+	// The backing volume's block device has grown since the
+	// filesystem was created, so the filesystem should be
+	// grown to fill it.
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       246,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+
+	sizes := waitChannel(c, sizesSet, "waiting for filesystem size to be set")
+	c.Assert(sizes, jc.DeepEquals, []params.StorageSize{{
+		Tag:  "filesystem-0-0",
+		Size: 246,
+	}})
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
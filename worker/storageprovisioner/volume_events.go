@@ -55,6 +55,51 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, possibly because they have been
+// requested to be resized.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		tag := tags[i]
+		if err := result.Error; err != nil {
+			// NotFound means there is no outstanding resize
+			// request; Unauthorized means the volume has been
+			// removed.
+			if params.IsCodeNotFound(err) || params.IsCodeUnauthorized(err) || params.IsCodeNotProvisioned(err) {
+				continue
+			}
+			return errors.Annotatef(err, "getting resize params for %s", names.ReadableString(tag))
+		}
+		if op, ok := ctx.pendingVolumeResizes[tag]; ok {
+			// A resize is already scheduled; make sure it
+			// uses the most recently requested size.
+			op.args.Size = result.Result.Size
+			continue
+		}
+		op := &resizeVolumeOp{
+			args: storage.VolumeResizeParams{
+				Tag:      tag,
+				VolumeId: result.Result.VolumeId,
+				Size:     result.Result.Size,
+			},
+			provider: storage.ProviderType(result.Result.Provider),
+		}
+		ctx.pendingVolumeResizes[tag] = op
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 func sortVolumeAttachmentPlans(ctx *context, ids []params.MachineStorageId) (
 	alive, dying, dead []params.VolumeAttachmentPlanResult, err error) {
 	plans, err := ctx.config.Volumes.VolumeAttachmentPlans(ids)
@@ -143,6 +188,13 @@ func processDyingVolumePlans(ctx *context, volumePlans []params.VolumeAttachment
 			}
 			continue
 		}
+		volumeTag, err := names.ParseVolumeTag(val.Result.VolumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := forgetEncryptedVolume(ctx, volumeTag); err != nil {
+			return errors.Trace(err)
+		}
 		if err := volPlan.DetachVolume(val.Result.PlanInfo.DeviceAttributes); err != nil {
 			return errors.Trace(err)
 		}
@@ -264,6 +316,8 @@ func updatePendingVolume(ctx *context, params storage.VolumeParams) {
 func removePendingVolume(ctx *context, tag names.VolumeTag) {
 	delete(ctx.incompleteVolumeParams, tag)
 	ctx.schedule.Remove(tag)
+	delete(ctx.pendingVolumeResizes, tag)
+	ctx.schedule.Remove(resizeKey{tag})
 }
 
 // updatePendingVolumeAttachment adds the given volume attachment params to
@@ -644,6 +698,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		providerType,
 		in.Attributes,
 		in.Tags,
+		in.SnapshotId,
 		attachment,
 	}, nil
 }
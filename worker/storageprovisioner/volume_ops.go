@@ -414,6 +414,111 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes to their requested sizes.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      op.args.Tag,
+			Provider: op.provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	var sizes []params.StorageSize
+	for tag, op := range ops {
+		if volumeSources[string(op.provider)] == nil {
+			// The volume is managed by the machine provisioner,
+			// which does not support resizing volumes.
+			delete(ctx.pendingVolumeResizes, tag)
+		}
+	}
+	for sourceName, volumeParams := range paramsBySource {
+		resizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			for _, p := range volumeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info: errors.NotSupportedf(
+						"resizing volumes with storage provider %q", p.Provider,
+					).Error(),
+				})
+				delete(ctx.pendingVolumeResizes, p.Tag)
+			}
+			continue
+		}
+		args := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			args[i] = ops[p.Tag].args
+		}
+		logger.Debugf("resizing volumes: %v", args)
+		results, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, args)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := args[i].Tag
+			op := ops[tag]
+			if result.Error != nil {
+				// Reschedule the resize, and record the failure in
+				// the volume's status until it succeeds.
+				reschedule = append(reschedule, op)
+				op.failed = true
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "resizing volume").Error(),
+				})
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag), result.Error,
+				)
+				continue
+			}
+			if op.failed {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Attached.String(),
+				})
+			}
+			sizes = append(sizes, params.StorageSize{
+				Tag:  tag.String(),
+				Size: result.Size,
+			})
+			if volume, ok := ctx.volumes[tag]; ok {
+				volume.Size = result.Size
+				ctx.volumes[tag] = volume
+			}
+			delete(ctx.pendingVolumeResizes, tag)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(sizes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSizes(sizes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing size of volume %s to state: %v",
+				sizes[i].Tag, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -462,6 +567,14 @@ func validateVolumeParams(
 	results := make([]error, len(volumeParams))
 	for i, params := range volumeParams {
 		err := volumeSource.ValidateVolumeParams(params)
+		if err == nil && params.SnapshotId != "" {
+			if _, ok := volumeSource.(storage.VolumeSnapshotter); !ok {
+				err = errors.NotSupportedf(
+					"creating volume from snapshot with storage provider %q",
+					params.Provider,
+				)
+			}
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -575,3 +688,23 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+// resizeKey is the schedule key for resize operations, distinguishing
+// them from other operations on the same volume or filesystem.
+type resizeKey struct {
+	tag names.Tag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args     storage.VolumeResizeParams
+	provider storage.ProviderType
+
+	// failed records whether a previous attempt to resize
+	// the volume failed, and so set the volume's status.
+	failed bool
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
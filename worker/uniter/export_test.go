@@ -0,0 +1,20 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+var NewHookRecorder = newHookRecorder
+
+// NewHookRetryPolicy returns a func that decides whether failed hooks
+// are retried, as the uniter's hook retry policy does.
+func NewHookRetryPolicy(maxRetries int, hooks []string, hookName func(hook.Info) (string, error)) func(hook.Info, int) bool {
+	return hookRetryPolicy{
+		maxRetries: maxRetries,
+		hooks:      hooks,
+		hookName:   hookName,
+	}.shouldRetry
+}
@@ -16,8 +16,26 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// StorageResized is run on a unit after a storage instance
+	// attached to it has been grown.
+	StorageResized hooks.Kind = "storage-resized"
+
+	// StorageMoving is run on a unit when a storage instance
+	// attached to it is being moved to another storage pool,
+	// to copy the storage contents to the new storage. If the
+	// charm does not implement the hook, the contents are
+	// copied and verified by the unit agent, which requires
+	// that block storage is not in use.
+	StorageMoving hooks.Kind = "storage-moving"
 )
 
+// IsStorage returns whether the hook kind is a storage hook,
+// including those not yet known to the charm/hooks package.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized || kind == StorageMoving
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -37,6 +55,11 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// StorageMoveTarget is the location of the storage that the
+	// storage instance's contents are to be copied to. It is only
+	// set when Kind is StorageMoving.
+	StorageMoveTarget string `yaml:"storage-move-target,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -52,11 +75,19 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
 		return nil
+	case StorageMoving:
+		if !names.IsValidStorage(hi.StorageId) {
+			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
+		}
+		if hi.StorageMoveTarget == "" {
+			return fmt.Errorf("%q hook requires a move target", hi.Kind)
+		}
+		return nil
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
@@ -50,6 +50,11 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageMoving, StorageMoveTarget: "/dev/sdb"}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageMoving, StorageId: "data/0"}, `"storage-moving" hook requires a move target`},
+	{hook.Info{Kind: hook.StorageMoving, StorageId: "data/0", StorageMoveTarget: "/dev/sdb"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -63,3 +68,11 @@ func (s *InfoSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *InfoSuite) TestIsStorage(c *gc.C) {
+	c.Assert(hook.IsStorage(hooks.StorageAttached), jc.IsTrue)
+	c.Assert(hook.IsStorage(hooks.StorageDetaching), jc.IsTrue)
+	c.Assert(hook.IsStorage(hook.StorageResized), jc.IsTrue)
+	c.Assert(hook.IsStorage(hook.StorageMoving), jc.IsTrue)
+	c.Assert(hook.IsStorage(hooks.Install), jc.IsFalse)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+const (
+	hookMetricsNamespace = "juju"
+	hookMetricsSubsystem = "uniter"
+)
+
+// HookReporter reports hook executions to the controller.
+type HookReporter interface {
+	RecordHookExecutions([]params.HookExecution) error
+}
+
+// hookRecorder is an operation.HookRecorder that exports the timing
+// and outcome of hook executions as Prometheus metrics, and reports
+// them to the controller so that they can be shown by show-unit.
+type hookRecorder struct {
+	reporter HookReporter
+	disabled bool
+
+	duration     *prometheus.HistogramVec
+	queueLatency *prometheus.HistogramVec
+}
+
+// newHookRecorder returns a hookRecorder for the named unit that
+// reports executions using the given reporter.
+func newHookRecorder(unitName string, reporter HookReporter) *hookRecorder {
+	labels := prometheus.Labels{"unit": unitName}
+	return &hookRecorder{
+		reporter: reporter,
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   hookMetricsNamespace,
+			Subsystem:   hookMetricsSubsystem,
+			Name:        "hook_duration_seconds",
+			Help:        "Time taken to run hooks, by hook and outcome.",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.1, 2, 14),
+		}, []string{"hook", "outcome"}),
+		queueLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   hookMetricsNamespace,
+			Subsystem:   hookMetricsSubsystem,
+			Name:        "hook_queue_latency_seconds",
+			Help:        "Time hooks spent queued before they started running.",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.01, 4, 10),
+		}, []string{"hook"}),
+	}
+}
+
+// RecordHookExecution is part of the operation.HookRecorder interface.
+func (r *hookRecorder) RecordHookExecution(e operation.HookExecution) {
+	r.duration.WithLabelValues(e.Hook, string(e.Outcome)).Observe(e.Duration().Seconds())
+	r.queueLatency.WithLabelValues(e.Hook).Observe(e.QueueLatency().Seconds())
+
+	if r.disabled {
+		return
+	}
+	err := r.reporter.RecordHookExecutions([]params.HookExecution{{
+		Hook:     e.Hook,
+		Outcome:  string(e.Outcome),
+		Queued:   e.Queued,
+		Started:  e.Started,
+		Finished: e.Finished,
+	}})
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("controller does not record hook executions")
+		r.disabled = true
+	} else if err != nil {
+		// The execution is still exported as a metric, so
+		// failing to report it is not worth stopping for.
+		logger.Warningf("cannot record %q hook execution: %v", e.Hook, err)
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (r *hookRecorder) Describe(ch chan<- *prometheus.Desc) {
+	r.duration.Describe(ch)
+	r.queueLatency.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (r *hookRecorder) Collect(ch chan<- prometheus.Metric) {
+	r.duration.Collect(ch)
+	r.queueLatency.Collect(ch)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type hookMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&hookMetricsSuite{})
+
+type fakeHookReporter struct {
+	testing.Stub
+}
+
+func (r *fakeHookReporter) RecordHookExecutions(executions []params.HookExecution) error {
+	r.MethodCall(r, "RecordHookExecutions", executions)
+	return r.NextErr()
+}
+
+func (s *hookMetricsSuite) TestRecordHookExecution(c *gc.C) {
+	reporter := &fakeHookReporter{}
+	recorder := uniter.NewHookRecorder("mysql/0", reporter)
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(recorder)
+	c.Assert(err, jc.ErrorIsNil)
+
+	queued := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	execution := operation.HookExecution{
+		Hook:     "config-changed",
+		Outcome:  operation.HookFailed,
+		Queued:   queued,
+		Started:  queued.Add(2 * time.Second),
+		Finished: queued.Add(7 * time.Second),
+	}
+	recorder.RecordHookExecution(execution)
+
+	reporter.CheckCall(c, 0, "RecordHookExecutions", []params.HookExecution{{
+		Hook:     "config-changed",
+		Outcome:  "failed",
+		Queued:   execution.Queued,
+		Started:  execution.Started,
+		Finished: execution.Finished,
+	}})
+
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(families, gc.HasLen, 2)
+
+	c.Assert(families[0].GetName(), gc.Equals, "juju_uniter_hook_duration_seconds")
+	c.Assert(families[0].Metric, gc.HasLen, 1)
+	labels := make(map[string]string)
+	for _, label := range families[0].Metric[0].Label {
+		labels[label.GetName()] = label.GetValue()
+	}
+	c.Assert(labels, jc.DeepEquals, map[string]string{
+		"hook":    "config-changed",
+		"outcome": "failed",
+		"unit":    "mysql/0",
+	})
+	c.Assert(families[0].Metric[0].Histogram.GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(families[0].Metric[0].Histogram.GetSampleSum(), gc.Equals, float64(5))
+
+	c.Assert(families[1].GetName(), gc.Equals, "juju_uniter_hook_queue_latency_seconds")
+	c.Assert(families[1].Metric, gc.HasLen, 1)
+	c.Assert(families[1].Metric[0].Histogram.GetSampleSum(), gc.Equals, float64(2))
+}
+
+func (s *hookMetricsSuite) TestRecordHookExecutionNotImplemented(c *gc.C) {
+	reporter := &fakeHookReporter{}
+	reporter.SetErrors(errors.NotImplementedf("RecordHookExecutions"))
+	recorder := uniter.NewHookRecorder("mysql/0", reporter)
+
+	execution := operation.HookExecution{Hook: "install", Outcome: operation.HookSucceeded}
+	recorder.RecordHookExecution(execution)
+	recorder.RecordHookExecution(execution)
+
+	// Once the controller is known not to record executions,
+	// the recorder stops asking it to.
+	reporter.CheckCallNames(c, "RecordHookExecutions")
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+// hookRetryPolicy decides which failed hooks are retried automatically,
+// and how many times, according to the application's hook retry policy.
+type hookRetryPolicy struct {
+	// maxRetries, if not zero, is the number of times a failed hook
+	// is retried.
+	maxRetries int
+
+	// hooks, if not empty, holds the patterns matching the names of
+	// the hooks that are retried.
+	hooks []string
+
+	// hookName returns the name of the hook described by a hook.Info.
+	hookName func(hook.Info) (string, error)
+}
+
+// shouldRetry returns whether the failed hook, having already been
+// retried the given number of times, should be retried again.
+func (p hookRetryPolicy) shouldRetry(info hook.Info, retries int) bool {
+	if p.maxRetries > 0 && retries >= p.maxRetries {
+		logger.Infof("not retrying %q hook: retried %d times", info.Kind, retries)
+		return false
+	}
+	if len(p.hooks) == 0 {
+		return true
+	}
+	name, err := p.hookName(info)
+	if err != nil {
+		logger.Warningf("cannot determine name of %q hook: %v", info.Kind, err)
+		return false
+	}
+	for _, pattern := range p.hooks {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	logger.Infof("not retrying %q hook: not in the application's hook retry policy", name)
+	return false
+}
+
+// hookName returns the name of the charm hook run for the given
+// hook.Info, as the charm knows it.
+func (u *Uniter) hookName(info hook.Info) (string, error) {
+	hookName := string(info.Kind)
+	if info.Kind.IsRelation() {
+		relationName, err := u.relations.Name(info.RelationId)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		hookName = fmt.Sprintf("%s-%s", relationName, info.Kind)
+	}
+	if hook.IsStorage(info.Kind) {
+		storageName, err := names.StorageName(info.StorageId)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		hookName = fmt.Sprintf("%s-%s", storageName, info.Kind)
+	}
+	return hookName, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+type hookRetryPolicySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&hookRetryPolicySuite{})
+
+func relationHookName(info hook.Info) (string, error) {
+	if info.Kind.IsRelation() {
+		return "db-" + string(info.Kind), nil
+	}
+	return string(info.Kind), nil
+}
+
+func (s *hookRetryPolicySuite) TestMaxRetries(c *gc.C) {
+	shouldRetry := uniter.NewHookRetryPolicy(2, nil, relationHookName)
+	info := hook.Info{Kind: hooks.Install}
+	c.Assert(shouldRetry(info, 0), jc.IsTrue)
+	c.Assert(shouldRetry(info, 1), jc.IsTrue)
+	c.Assert(shouldRetry(info, 2), jc.IsFalse)
+}
+
+func (s *hookRetryPolicySuite) TestUnlimitedRetries(c *gc.C) {
+	shouldRetry := uniter.NewHookRetryPolicy(0, nil, relationHookName)
+	c.Assert(shouldRetry(hook.Info{Kind: hooks.Install}, 1000), jc.IsTrue)
+}
+
+func (s *hookRetryPolicySuite) TestHooks(c *gc.C) {
+	shouldRetry := uniter.NewHookRetryPolicy(0, []string{"install", "*-relation-changed"}, relationHookName)
+	c.Assert(shouldRetry(hook.Info{Kind: hooks.Install}, 0), jc.IsTrue)
+	c.Assert(shouldRetry(hook.Info{Kind: hooks.RelationChanged, RelationId: 1}, 0), jc.IsTrue)
+	c.Assert(shouldRetry(hook.Info{Kind: hooks.RelationJoined, RelationId: 1}, 0), jc.IsFalse)
+	c.Assert(shouldRetry(hook.Info{Kind: hooks.UpgradeCharm}, 0), jc.IsFalse)
+}
@@ -35,7 +35,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		if err != nil {
 			return "", err
 		}
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		if err := opc.u.storage.ValidateHook(hi); err != nil {
 			return "", err
 		}
@@ -61,7 +61,7 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
 	case hi.Kind.IsRelation():
 		return opc.u.relations.CommitHook(hi)
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		return opc.u.storage.CommitHook(hi)
 	}
 	return nil
@@ -128,3 +128,8 @@ func (opc *operationCallbacks) SetExecutingStatus(message string) error {
 func (opc *operationCallbacks) SetUpgradeSeriesStatus(upgradeSeriesStatus model.UpgradeSeriesStatus, reason string) error {
 	return setUpgradeSeriesStatus(opc.u, upgradeSeriesStatus, reason)
 }
+
+// CopyStorage is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) CopyStorage(hi hook.Info) error {
+	return opc.u.storage.CopyStorage(hi)
+}
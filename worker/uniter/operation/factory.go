@@ -4,6 +4,7 @@
 package operation
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
@@ -20,6 +21,11 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// Clock and HookRecorder are optional; when both are set, the
+	// timing and outcome of every hook execution is recorded.
+	Clock        clock.Clock
+	HookRecorder HookRecorder
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -89,11 +95,16 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 	if err := hookInfo.Validate(); err != nil {
 		return nil, err
 	}
-	return &runHook{
+	rh := &runHook{
 		info:          hookInfo,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
-	}, nil
+	}
+	if f.config.Clock != nil && f.config.HookRecorder != nil {
+		rh.clock = f.config.Clock
+		rh.recorder = f.config.HookRecorder
+	}
+	return rh, nil
 }
 
 // NewSkipHook is part of the Factory interface.
@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"time"
+)
+
+// HookOutcome describes how a hook execution ended.
+type HookOutcome string
+
+const (
+	// HookSucceeded is the outcome of a hook that ran to completion.
+	HookSucceeded HookOutcome = "succeeded"
+
+	// HookFailed is the outcome of a hook that returned an error.
+	HookFailed HookOutcome = "failed"
+
+	// HookMissing is the outcome of a hook that the charm does not
+	// implement.
+	HookMissing HookOutcome = "missing"
+
+	// HookRebooted is the outcome of a hook that requested a reboot.
+	HookRebooted HookOutcome = "rebooted"
+)
+
+// HookExecution records the timing and outcome of a single hook run.
+type HookExecution struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// Outcome describes how the hook execution ended.
+	Outcome HookOutcome
+
+	// Queued is when the remote state change that triggered the
+	// hook was first observed.
+	Queued time.Time
+
+	// Started is when the hook started executing.
+	Started time.Time
+
+	// Finished is when the hook finished executing.
+	Finished time.Time
+}
+
+// QueueLatency returns how long the hook waited between being
+// queued and starting to execute.
+func (e HookExecution) QueueLatency() time.Duration {
+	return e.Started.Sub(e.Queued)
+}
+
+// Duration returns how long the hook took to execute.
+func (e HookExecution) Duration() time.Duration {
+	return e.Finished.Sub(e.Started)
+}
+
+// HookRecorder is notified of every completed hook execution.
+type HookRecorder interface {
+	RecordHookExecution(HookExecution)
+}
+
+// HookQueuer is implemented by hook operations that can be told when
+// the remote state change that triggered them was first observed, so
+// the recorded queue latency covers the time spent waiting behind
+// other operations.
+type HookQueuer interface {
+	SetQueued(time.Time)
+}
@@ -178,6 +178,11 @@ type Callbacks interface {
 	// upgrade series hook code completes and, for display purposes, to
 	// supply a reason as to why it is making the change.
 	SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus, reason string) error
+
+	// CopyStorage copies the contents of storage being moved to another
+	// storage pool. It's only used by RunHook operations, when the charm
+	// does not implement the storage-moving hook itself.
+	CopyStorage(info hook.Info) error
 }
 
 // StorageUpdater is an interface used for updating local knowledge of storage
@@ -5,7 +5,9 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"gopkg.in/juju/charm.v6/hooks"
@@ -30,6 +32,12 @@ type runHook struct {
 
 	hookFound bool
 
+	// clock and recorder, if set, are used to record the timing
+	// and outcome of the hook execution.
+	clock    clock.Clock
+	recorder HookRecorder
+	queued   time.Time
+
 	RequiresMachineLock
 }
 
@@ -43,7 +51,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -116,19 +124,35 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	rh.hookFound = true
 	step := Done
 
+	started := rh.now()
 	err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
 	switch {
+	case charmrunner.IsMissingHookError(cause) && rh.info.Kind == hook.StorageMoving:
+		// The charm does not know how to move its storage,
+		// so copy the storage contents on its behalf.
+		rh.hookFound = false
+		if err = rh.callbacks.CopyStorage(rh.info); err != nil {
+			rh.record(HookFailed, started)
+			logger.Errorf("hook %q failed: %v", rh.name, err)
+			rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+			return nil, ErrHookFailed
+		}
+		rh.record(HookMissing, started)
 	case charmrunner.IsMissingHookError(cause):
 		rh.hookFound = false
+		rh.record(HookMissing, started)
 		err = nil
 	case cause == context.ErrRequeueAndReboot:
 		step = Queued
 		fallthrough
 	case cause == context.ErrReboot:
+		rh.record(HookRebooted, started)
 		err = ErrNeedsReboot
 	case err == nil:
+		rh.record(HookSucceeded, started)
 	default:
+		rh.record(HookFailed, started)
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
@@ -154,6 +178,40 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// now returns the current time, or the zero time if hook
+// executions are not being recorded.
+func (rh *runHook) now() time.Time {
+	if rh.clock == nil {
+		return time.Time{}
+	}
+	return rh.clock.Now()
+}
+
+// SetQueued is part of the HookQueuer interface.
+func (rh *runHook) SetQueued(queued time.Time) {
+	rh.queued = queued
+}
+
+// record reports the execution of the hook to the recorder, if any.
+// If the time the hook was queued is unknown, no queue latency is
+// reported.
+func (rh *runHook) record(outcome HookOutcome, started time.Time) {
+	if rh.recorder == nil {
+		return
+	}
+	queued := rh.queued
+	if queued.IsZero() {
+		queued = started
+	}
+	rh.recorder.RecordHookExecution(HookExecution{
+		Hook:     rh.name,
+		Outcome:  outcome,
+		Queued:   queued,
+		Started:  started,
+		Finished: rh.clock.Now(),
+	})
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
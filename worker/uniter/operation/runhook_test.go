@@ -4,6 +4,9 @@
 package operation_test
 
 import (
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -246,6 +249,54 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) getCopyStorageTest(c *gc.C, copyErr error) (operation.Operation, *CopyStorageCallbacks, hook.Info) {
+	runnerFactory := NewRunHookRunnerFactory(charmrunner.NewMissingHookError("blah-blah"))
+	callbacks := &CopyStorageCallbacks{
+		ExecuteHookCallbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+			MockNotifyHookCompleted: &MockNotify{},
+			MockNotifyHookFailed:    &MockNotify{},
+		},
+		MockCopyStorage: &MockCopyStorage{err: copyErr},
+	}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	hi := hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         "data/0",
+		StorageMoveTarget: "/dev/sdc",
+	}
+	op, err := factory.NewRunHook(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	return op, callbacks, hi
+}
+
+func (s *RunHookSuite) TestExecuteMissingStorageMovingHookCopiesStorage(c *gc.C) {
+	op, callbacks, hi := s.getCopyStorageTest(c, nil)
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Done,
+		Hook: &hi,
+	})
+	c.Assert(*callbacks.MockCopyStorage.gotHook, jc.DeepEquals, hi)
+	c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+}
+
+func (s *RunHookSuite) TestExecuteMissingStorageMovingHookCopyError(c *gc.C) {
+	op, callbacks, _ := s.getCopyStorageTest(c, errors.New("no space left on device"))
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
 func (s *RunHookSuite) TestExecuteRequeueRebootError(c *gc.C) {
 	runErr := context.ErrRequeueAndReboot
 	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
@@ -299,6 +350,54 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+type hookRecorder struct {
+	executions []operation.HookExecution
+}
+
+func (r *hookRecorder) RecordHookExecution(e operation.HookExecution) {
+	r.executions = append(r.executions, e)
+}
+
+func (s *RunHookSuite) TestExecuteRecordsHookExecution(c *gc.C) {
+	for i, test := range []struct {
+		runErr  error
+		outcome operation.HookOutcome
+	}{
+		{nil, operation.HookSucceeded},
+		{errors.New("graaargh"), operation.HookFailed},
+		{charmrunner.NewMissingHookError("blah-blah"), operation.HookMissing},
+		{context.ErrReboot, operation.HookRebooted},
+	} {
+		c.Logf("test %d: %v", i, test.outcome)
+		clock := testclock.NewClock(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
+		recorder := &hookRecorder{}
+		factory := operation.NewFactory(operation.FactoryParams{
+			RunnerFactory: NewRunHookRunnerFactory(test.runErr),
+			Callbacks: &ExecuteHookCallbacks{
+				PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+				MockNotifyHookCompleted: &MockNotify{},
+				MockNotifyHookFailed:    &MockNotify{},
+			},
+			Clock:        clock,
+			HookRecorder: recorder,
+		})
+		op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		c.Assert(err, jc.ErrorIsNil)
+		op.(operation.HookQueuer).SetQueued(clock.Now().Add(-2 * time.Second))
+		clock.Advance(3 * time.Second)
+		_, err = op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+		op.Execute(operation.State{})
+
+		c.Assert(recorder.executions, gc.HasLen, 1)
+		execution := recorder.executions[0]
+		c.Check(execution.Hook, gc.Equals, "some-hook-name")
+		c.Check(execution.Outcome, gc.Equals, test.outcome)
+		c.Check(execution.QueueLatency(), gc.Equals, 5*time.Second)
+		c.Check(execution.Duration(), gc.Equals, time.Duration(0))
+	}
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -212,6 +212,25 @@ func (cb *CommitHookCallbacks) CommitHook(hookInfo hook.Info) error {
 	return cb.MockCommitHook.Call(hookInfo)
 }
 
+type MockCopyStorage struct {
+	gotHook *hook.Info
+	err     error
+}
+
+func (mock *MockCopyStorage) Call(hookInfo hook.Info) error {
+	mock.gotHook = &hookInfo
+	return mock.err
+}
+
+type CopyStorageCallbacks struct {
+	*ExecuteHookCallbacks
+	*MockCopyStorage
+}
+
+func (cb *CopyStorageCallbacks) CopyStorage(hookInfo hook.Info) error {
+	return cb.MockCopyStorage.Call(hookInfo)
+}
+
 type MockNewActionRunner struct {
 	gotActionId *string
 	runner      *MockRunner
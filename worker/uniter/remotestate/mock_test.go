@@ -199,6 +199,7 @@ type mockUnit struct {
 	life                             params.Life
 	providerID                       string
 	resolved                         params.ResolvedMode
+	paused                           bool
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
@@ -226,6 +227,10 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) Paused() bool {
+	return u.paused
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
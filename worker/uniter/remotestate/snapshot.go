@@ -44,6 +44,10 @@ type Snapshot struct {
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
+	// Paused reports whether the unit's hooks are paused,
+	// in which case nothing but a resume should be done.
+	Paused bool
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -99,4 +103,12 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+
+	// Size is the size of the storage in MiB, as
+	// seen by the host, if known.
+	Size uint64
+
+	// MoveTarget, if non-empty, is the location of the storage
+	// that the storage's contents are to be moved to.
+	MoveTarget string
 }
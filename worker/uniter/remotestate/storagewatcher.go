@@ -78,10 +78,12 @@ func getStorageSnapshot(
 		return StorageSnapshot{}, errors.Annotate(err, "refreshing storage details")
 	}
 	snapshot := StorageSnapshot{
-		Life:     attachment.Life,
-		Kind:     attachment.Kind,
-		Attached: true,
-		Location: attachment.Location,
+		Life:       attachment.Life,
+		Kind:       attachment.Kind,
+		Attached:   true,
+		Location:   attachment.Location,
+		Size:       attachment.Size,
+		MoveTarget: attachment.MoveTarget,
 	}
 	return snapshot, nil
 }
@@ -248,6 +248,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.paused = true
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().Paused, jc.IsTrue)
+
 	s.st.unit.addressesWatcher.changes <- []string{"addresseshash2"}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().AddressesHash, gc.Equals, "addresseshash2")
@@ -399,6 +404,7 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 		Life:       params.Dying,
 		Kind:       params.StorageKindFilesystem,
 		Location:   "somewhere",
+		Size:       2048,
 	}
 	delete(s.st.storageAttachment, storageAttachmentId1)
 	storageTag0Watcher.changes <- struct{}{}
@@ -411,6 +417,7 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 			Attached: true,
 			Kind:     params.StorageKindFilesystem,
 			Location: "somewhere",
+			Size:     2048,
 		},
 	})
 }
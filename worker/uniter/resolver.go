@@ -20,7 +20,7 @@ import (
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(hook.Info, int) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -30,11 +30,20 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+
+	// ShouldRetryHook, if not nil, decides whether a failed hook that
+	// has already been retried the given number of times is retried
+	// again. If it is nil, failed hooks are retried indefinitely.
+	ShouldRetryHook func(hook.Info, int) bool
 }
 
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+
+	// hookRetries counts the times the failed hook has been retried
+	// automatically. It is reset when the hook is resolved.
+	hookRetries int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -64,6 +73,15 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrTerminate
 	}
 
+	// A paused unit runs nothing, not even an interrupted operation,
+	// until it is resumed; the remote state changes that arrive in the
+	// meantime are resolved then. A dying unit is not held back by the
+	// pause, so that it can still be removed.
+	if remoteState.Paused && remoteState.Life != params.Dying {
+		logger.Debugf("unit is paused, deferring operations")
+		return nil, resolver.ErrNoOperation
+	}
+
 	// Operations for series-upgrade need to be resolved early,
 	// in particular because no other operations should be run when the unit
 	// has completed preparation and is waiting for upgrade completion.
@@ -98,6 +116,9 @@ func (s *uniterResolver) NextOp(
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
 	}
+	if localState.Kind != operation.RunHook || localState.Step != operation.Pending {
+		s.hookRetries = 0
+	}
 
 	op, err = s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
@@ -187,7 +208,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(*localState.Hook, s.hookRetries); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -206,9 +227,10 @@ func (s *uniterResolver) nextOpHookError(
 			// timer. If the hook succeeds, we'll enter nextOp
 			// and stop the timer.
 			s.retryHookTimerStarted = false
+			s.hookRetries++
 			return opFactory.NewRunHook(*localState.Hook)
 		}
-		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
+		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks && s.shouldRetryHook(*localState.Hook) {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
 			// cleared so that we'll still start it again.
@@ -219,6 +241,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedRetryHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.hookRetries = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -226,6 +249,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedNoHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.hookRetries = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -237,6 +261,15 @@ func (s *uniterResolver) nextOpHookError(
 	}
 }
 
+// shouldRetryHook returns whether the failed hook should be retried
+// automatically.
+func (s *uniterResolver) shouldRetryHook(info hook.Info) bool {
+	if s.config.ShouldRetryHook == nil {
+		return true
+	}
+	return s.config.ShouldRetryHook(info, s.hookRetries)
+}
+
 func charmModified(local resolver.LocalState, remote remotestate.Snapshot) bool {
 	// CAAS models may not yet have read the charm url from state.
 	if remote.CharmURL == nil {
@@ -4,6 +4,9 @@
 package resolver
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6/hooks"
 
@@ -30,6 +33,11 @@ type LoopConfig struct {
 	Abort         <-chan struct{}
 	OnIdle        func() error
 	CharmDirGuard fortress.Guard
+
+	// Clock, if set, is used to record when remote state changes
+	// were first observed, so hook operations can report how long
+	// they were queued.
+	Clock clock.Clock
 }
 
 // Loop repeatedly waits for remote state changes, feeding the local and
@@ -60,7 +68,19 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 		return errors.Trace(err)
 	}
 
+	now := func() time.Time {
+		if cfg.Clock == nil {
+			return time.Time{}
+		}
+		return cfg.Clock.Now()
+	}
+	// observed records when the remote state changes that have not
+	// yet been resolved were first observed. The initial snapshot
+	// counts as a change.
+	observed := now()
+
 	for {
+		rf.Observed = observed
 		rf.RemoteState = cfg.Watcher.Snapshot()
 		rf.LocalState.State = cfg.Executor.State()
 
@@ -89,6 +109,8 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 			// If a resolver is waiting for events to
 			// complete, the agent is not idle.
 		case ErrNoOperation:
+			// Everything observed so far has been resolved.
+			observed = time.Time{}
 			if cfg.OnIdle != nil {
 				if err := cfg.OnIdle(); err != nil {
 					return errors.Trace(err)
@@ -102,6 +124,9 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 		case <-cfg.Abort:
 			return ErrLoopAborted
 		case <-cfg.Watcher.RemoteStateChanged():
+			if observed.IsZero() {
+				observed = now()
+			}
 		}
 	}
 }
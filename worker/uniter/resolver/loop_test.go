@@ -7,12 +7,15 @@ import (
 	"errors"
 	"time"
 
+	"github.com/juju/clock/testclock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/resolver"
@@ -28,6 +31,7 @@ type LoopSuite struct {
 	charmURL  *charm.URL
 	abort     chan struct{}
 	onIdle    func() error
+	clock     *testclock.Clock
 }
 
 var _ = gc.Suite(&LoopSuite{})
@@ -44,6 +48,7 @@ func (s *LoopSuite) SetUpTest(c *gc.C) {
 	s.executor = &mockOpExecutor{}
 	s.charmURL = charm.MustParseURL("cs:trusty/mysql")
 	s.abort = make(chan struct{})
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
 }
 
 func (s *LoopSuite) loop() (resolver.LocalState, error) {
@@ -58,6 +63,7 @@ func (s *LoopSuite) loop() (resolver.LocalState, error) {
 		Abort:         s.abort,
 		OnIdle:        s.onIdle,
 		CharmDirGuard: &mockCharmDirGuard{},
+		Clock:         s.clock,
 	}, &localState)
 	return localState, err
 }
@@ -124,6 +130,54 @@ func (s *LoopSuite) TestErrWaitingNoOnIdle(c *gc.C) {
 	c.Assert(onIdleCalled, jc.IsFalse)
 }
 
+func (s *LoopSuite) TestHookQueuedWhenRemoteStateObserved(c *gc.C) {
+	start := s.clock.Now()
+	queued := make(chan interface{}, 2)
+	s.opFactory.op = mockOp{setQueued: func(t time.Time) {
+		queued <- t
+	}}
+	var runHook bool
+	s.resolver = resolver.ResolverFunc(func(
+		_ resolver.LocalState,
+		_ remotestate.Snapshot,
+		f operation.Factory,
+	) (operation.Operation, error) {
+		if !runHook {
+			return nil, resolver.ErrNoOperation
+		}
+		runHook = false
+		// Time passes between observing the change and
+		// creating the hook operation.
+		s.clock.Advance(time.Second)
+		return f.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	})
+	runHook = true
+	onIdleCh := make(chan interface{}, 1)
+	s.onIdle = func() error {
+		onIdleCh <- nil
+		return nil
+	}
+
+	done := make(chan interface{}, 1)
+	go func() {
+		_, err := s.loop()
+		done <- err
+	}()
+
+	waitChannel(c, onIdleCh, "waiting for onIdle")
+	c.Assert(waitChannel(c, queued, "waiting for queued time"), gc.Equals, start)
+
+	s.clock.Advance(time.Minute)
+	runHook = true
+	s.watcher.changes <- struct{}{}
+	waitChannel(c, onIdleCh, "waiting for onIdle")
+	c.Assert(waitChannel(c, queued, "waiting for queued time"), gc.Equals, start.Add(time.Second+time.Minute))
+
+	close(s.abort)
+	err := waitChannel(c, done, "waiting for loop to exit")
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+}
+
 func (s *LoopSuite) TestInitialFinalLocalState(c *gc.C) {
 	var local resolver.LocalState
 	s.resolver = resolver.ResolverFunc(func(
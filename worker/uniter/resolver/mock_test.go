@@ -4,6 +4,8 @@
 package resolver_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	"gopkg.in/juju/charm.v6"
 
@@ -86,8 +88,15 @@ func (e *mockOpExecutor) Run(op operation.Operation) error {
 
 type mockOp struct {
 	operation.Operation
-	commit  func(operation.State) (*operation.State, error)
-	prepare func(operation.State) (*operation.State, error)
+	commit    func(operation.State) (*operation.State, error)
+	prepare   func(operation.State) (*operation.State, error)
+	setQueued func(time.Time)
+}
+
+func (op mockOp) SetQueued(queued time.Time) {
+	if op.setQueued != nil {
+		op.setQueued(queued)
+	}
 }
 
 func (op mockOp) Prepare(st operation.State) (*operation.State, error) {
@@ -4,6 +4,8 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6"
@@ -31,6 +33,10 @@ type resolverOpFactory struct {
 
 	LocalState  *LocalState
 	RemoteState remotestate.Snapshot
+
+	// Observed, if non-zero, is when the remote state changes that
+	// have not yet been resolved were first observed.
+	Observed time.Time
 }
 
 func (s *resolverOpFactory) NewRunHook(info hook.Info) (operation.Operation, error) {
@@ -38,6 +44,9 @@ func (s *resolverOpFactory) NewRunHook(info hook.Info) (operation.Operation, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if queuer, ok := op.(operation.HookQueuer); ok && !s.Observed.IsZero() {
+		queuer.SetQueued(s.Observed)
+	}
 	return s.wrapHookOp(op, info), nil
 }
 
@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+)
+
+const (
+	// actionStdout and actionStderr name the output streams of a
+	// running action.
+	actionStdout = "stdout"
+	actionStderr = "stderr"
+
+	// actionOutputFlushInterval is how often lines written by a
+	// running action are sent to the controller.
+	actionOutputFlushInterval = time.Second
+
+	// maxStreamedActionOutput is the number of bytes of an action's
+	// output which are streamed while it runs. The complete output is
+	// still recorded in the action's results once it finishes.
+	maxStreamedActionOutput = 1 << 20
+)
+
+type actionOutputLine struct {
+	stream string
+	line   string
+}
+
+// actionOutputStreamer batches up the lines written by a running action
+// and periodically records them against the action, so that they can be
+// followed before the action finishes.
+type actionOutputStreamer struct {
+	logOutput func(stream string, lines []string) error
+	clock     clock.Clock
+
+	mu       sync.Mutex
+	pending  []actionOutputLine
+	size     int
+	disabled bool
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newActionOutputStreamer(logOutput func(string, []string) error, clock clock.Clock) *actionOutputStreamer {
+	s := &actionOutputStreamer{
+		logOutput: logOutput,
+		clock:     clock,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+// receivers returns the given receivers along with one which adds the
+// lines it is given to the named stream, if the streamer is running.
+func (s *actionOutputStreamer) receivers(stream string, receivers ...charmrunner.MessageReceiver) []charmrunner.MessageReceiver {
+	if s == nil {
+		return receivers
+	}
+	return append(receivers, &actionOutputReceiver{streamer: s, stream: stream})
+}
+
+func (s *actionOutputStreamer) add(stream, line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.disabled {
+		return
+	}
+	s.size += len(line)
+	if s.size > maxStreamedActionOutput {
+		logger.Warningf("action output exceeds %d bytes, no longer streaming it", maxStreamedActionOutput)
+		s.disabled = true
+		return
+	}
+	s.pending = append(s.pending, actionOutputLine{stream: stream, line: line})
+}
+
+func (s *actionOutputStreamer) loop() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.stop:
+			s.flush()
+			return
+		case <-s.clock.After(actionOutputFlushInterval):
+			s.flush()
+		}
+	}
+}
+
+// flush sends the pending lines, one call for each run of lines written
+// to the same stream so that their order is kept.
+func (s *actionOutputStreamer) flush() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	for len(pending) > 0 {
+		stream := pending[0].stream
+		var lines []string
+		for len(pending) > 0 && pending[0].stream == stream {
+			lines = append(lines, pending[0].line)
+			pending = pending[1:]
+		}
+		if err := s.logOutput(stream, lines); err != nil {
+			// Streaming is a convenience; the output is recorded in
+			// full when the action finishes.
+			logger.Debugf("cannot stream action output: %v", err)
+			s.mu.Lock()
+			s.disabled = true
+			s.pending = nil
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// Stop sends any pending lines and stops the streamer.
+func (s *actionOutputStreamer) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// actionOutputReceiver implements charmrunner.MessageReceiver, adding
+// each line of output to a stream.
+type actionOutputReceiver struct {
+	streamer *actionOutputStreamer
+	stream   string
+}
+
+func (r *actionOutputReceiver) Messagef(isPrefix bool, message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	r.streamer.add(r.stream, message)
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/exec"
+
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// snapshotContext returns a snapshot of the parts of the hook context
+// that a hook can read through hook tools.
+func snapshotContext(ctx Context) (debug.ContextSnapshot, error) {
+	var snapshot debug.ContextSnapshot
+	config, err := ctx.ConfigSettings()
+	if err != nil {
+		return snapshot, errors.Annotate(err, "reading config")
+	}
+	snapshot.Config = config
+	if snapshot.Leader, err = ctx.IsLeader(); err != nil {
+		return snapshot, errors.Annotate(err, "reading leadership")
+	}
+	if snapshot.LeaderSettings, err = ctx.LeaderSettings(); err != nil {
+		return snapshot, errors.Annotate(err, "reading leader settings")
+	}
+	// Addresses may legitimately be unavailable, eg for CAAS units.
+	snapshot.PrivateAddress, _ = ctx.PrivateAddress()
+	snapshot.PublicAddress, _ = ctx.PublicAddress()
+
+	if relation, err := ctx.HookRelation(); err == nil {
+		snapshot.RelationId = relation.Id()
+		snapshot.RemoteUnit, _ = ctx.RemoteUnitName()
+	}
+	relationIds, err := ctx.RelationIds()
+	if err != nil {
+		return snapshot, errors.Annotate(err, "reading relations")
+	}
+	for _, id := range relationIds {
+		relation, err := snapshotRelation(ctx, id)
+		if err != nil {
+			return snapshot, errors.Annotatef(err, "reading relation %d", id)
+		}
+		snapshot.Relations = append(snapshot.Relations, relation)
+	}
+
+	storageTags, err := ctx.StorageTags()
+	if err != nil {
+		return snapshot, errors.Annotate(err, "reading storage")
+	}
+	for _, tag := range storageTags {
+		attachment, err := ctx.Storage(tag)
+		if err != nil {
+			return snapshot, errors.Annotatef(err, "reading storage %q", tag.Id())
+		}
+		snapshot.Storage = append(snapshot.Storage, debug.StorageSnapshot{
+			Id:       tag.Id(),
+			Kind:     attachment.Kind().String(),
+			Location: attachment.Location(),
+		})
+	}
+	return snapshot, nil
+}
+
+func snapshotRelation(ctx Context, id int) (debug.RelationSnapshot, error) {
+	relation, err := ctx.Relation(id)
+	if err != nil {
+		return debug.RelationSnapshot{}, errors.Trace(err)
+	}
+	snapshot := debug.RelationSnapshot{
+		Id:       relation.Id(),
+		Endpoint: relation.Name(),
+		Units:    make(map[string]map[string]string),
+	}
+	local, err := relation.Settings()
+	if err != nil {
+		return snapshot, errors.Trace(err)
+	}
+	snapshot.Local = local.Map()
+	for _, unit := range relation.UnitNames() {
+		settings, err := relation.ReadSettings(unit)
+		if err != nil {
+			return snapshot, errors.Trace(err)
+		}
+		snapshot.Units[unit] = settings
+	}
+	return snapshot, nil
+}
+
+// recordHookTool returns a jujuc.HookToolObserver that records hook
+// tool invocations in the given capture.
+func recordHookTool(capture *debug.Capture) jujuc.HookToolObserver {
+	return func(req jujuc.Request, resp *utilexec.ExecResponse) {
+		capture.RecordHookTool(debug.HookToolInvocation{
+			Command: req.CommandName,
+			Args:    req.Args,
+			Stdin:   string(req.Stdin),
+			Code:    resp.Code,
+			Stdout:  string(resp.Stdout),
+			Stderr:  string(resp.Stderr),
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+)
+
+// GetCharmState returns a copy of the unit's charm state, including any
+// changes made earlier in the hook.
+func (ctx *HookContext) GetCharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for key, value := range ctx.charmState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// GetCharmStateValue returns the value of the given key in the unit's
+// charm state.
+func (ctx *HookContext) GetCharmStateValue(key string) (string, error) {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := ctx.charmState[key]
+	if !ok {
+		return "", errors.NotFoundf("%q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue sets the given key in the unit's charm state. The
+// state is written to the controller when the context is flushed.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return errors.Trace(err)
+	}
+	if current, ok := ctx.charmState[key]; ok && current == value {
+		return nil
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue removes the given key from the unit's charm
+// state. The state is written to the controller when the context is
+// flushed.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.charmState[key]; !ok {
+		return nil
+	}
+	delete(ctx.charmState, key)
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// ensureCharmStateLoaded reads the unit's charm state from the
+// controller the first time it is needed in the hook.
+func (ctx *HookContext) ensureCharmStateLoaded() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	state, err := ctx.unit.State()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if state == nil {
+		state = make(map[string]string)
+	}
+	ctx.charmState = state
+	return nil
+}
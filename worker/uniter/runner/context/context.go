@@ -184,6 +184,16 @@ type HookContext struct {
 	// of, keyed on relation id.
 	relations map[int]*ContextRelation
 
+	// remoteSettingsDiff holds the changes to the remote unit's relation
+	// settings since the local unit last completed a hook for it. It is
+	// nil unless the context is running a relation-joined or
+	// relation-changed hook.
+	remoteSettingsDiff *jujuc.SettingsDiff
+
+	// commitRemoteSettings, if not nil, records the remote unit's
+	// relation settings as seen, once the hook has succeeded.
+	commitRemoteSettings func() error
+
 	// apiAddrs contains the API server addresses.
 	apiAddrs []string
 
@@ -223,6 +233,10 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// storageMoveTarget is the location that the storage instance
+	// associated with the running storage-moving hook is being moved to.
+	storageMoveTarget string
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -253,6 +267,13 @@ type HookContext struct {
 
 	// podSpecYaml is the pending pod spec to be committed.
 	podSpecYaml *string
+
+	// charmState is the unit's charm state, read on first use.
+	charmState map[string]string
+
+	// charmStateDirty records whether charmState has changed
+	// during the hook and needs to be written.
+	charmStateDirty bool
 }
 
 // Component implements hooks.Context.
@@ -485,8 +506,12 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenPortsForEndpoint("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -588,6 +613,15 @@ func (ctx *HookContext) LogActionMessage(message string) error {
 	return ctx.unit.LogActionMessage(ctx.actionData.Tag, message)
 }
 
+// LogActionOutput records lines written by the Action to the given
+// output stream while it runs.
+func (ctx *HookContext) LogActionOutput(stream string, lines []string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.unit.LogActionOutput(ctx.actionData.Tag, stream, lines)
+}
+
 // SetActionMessage sets a message for the Action, usually an error message.
 func (ctx *HookContext) SetActionMessage(message string) error {
 	if ctx.actionData == nil {
@@ -629,6 +663,15 @@ func (ctx *HookContext) RemoteUnitName() (string, error) {
 	return ctx.remoteUnitName, nil
 }
 
+// RemoteSettingsDiff returns the changes to the remote unit's relation
+// settings since the local unit last completed a hook for it.
+func (ctx *HookContext) RemoteSettingsDiff() (*jujuc.SettingsDiff, error) {
+	if ctx.remoteSettingsDiff == nil {
+		return nil, errors.NotFoundf("remote settings diff")
+	}
+	return ctx.remoteSettingsDiff, nil
+}
+
 func (ctx *HookContext) Relation(id int) (jujuc.ContextRelation, error) {
 	r, found := ctx.relations[id]
 	if !found {
@@ -713,9 +756,17 @@ func (context *HookContext) HookVars(paths Paths, remote bool) ([]string, error)
 			"JUJU_RELATION_ID="+r.FakeId(),
 			"JUJU_REMOTE_UNIT="+context.remoteUnitName,
 		)
+		if context.remoteSettingsDiff != nil {
+			vars = append(vars,
+				"JUJU_RELATION_CHANGED_KEYS="+strings.Join(context.remoteSettingsDiff.Keys(), " "),
+			)
+		}
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.storageMoveTarget != "" {
+		vars = append(vars, "JUJU_STORAGE_MOVE_TARGET="+context.storageMoveTarget)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -783,6 +834,20 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		defer ctx.handleReboot(&err)
 	}
 
+	// Changes are only written if every relation whose settings the
+	// hook changed still matches its schema.
+	if writeChanges {
+		for _, rctx := range ctx.relations {
+			if e := rctx.ValidateSettings(); e != nil {
+				logger.Errorf("%v", e)
+				if ctxErr == nil {
+					ctxErr = e
+				}
+				writeChanges = false
+			}
+		}
+	}
+
 	for id, rctx := range ctx.relations {
 		if writeChanges {
 			if e := rctx.WriteSettings(); e != nil {
@@ -798,12 +863,23 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if ctx.charmStateDirty && writeChanges {
+		if e := ctx.unit.SetState(ctx.charmState); e != nil {
+			e = errors.Annotatef(e, "could not write charm state from %q", process)
+			logger.Errorf("%v", e)
+			if ctxErr == nil {
+				ctxErr = e
+			}
+		}
+	}
+
 	for rangeKey, rangeInfo := range ctx.pendingPorts {
 		if writeChanges {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenPortsForEndpoint(
+					rangeInfo.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
@@ -854,6 +930,12 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		return ctxErr
 	}
 
+	if ctxErr == nil && ctx.commitRemoteSettings != nil {
+		if e := ctx.commitRemoteSettings(); e != nil {
+			logger.Warningf("cannot record remote relation settings: %v", e)
+		}
+	}
+
 	return ctxErr
 }
 
@@ -6,6 +6,7 @@ package context
 import (
 	"fmt"
 	"math/rand"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
@@ -182,12 +183,26 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		availabilityzone:   f.zone,
 		principal:          f.principal,
 	}
+	f.applyRelationSchemas(ctx.relations)
 	if err := f.updateContext(ctx); err != nil {
 		return nil, err
 	}
 	return ctx, nil
 }
 
+// applyRelationSchemas gives each relation the schema the charm declares
+// for its endpoint.
+func (f *contextFactory) applyRelationSchemas(relations map[int]*ContextRelation) {
+	schemas, err := ReadRelationSchemas(f.paths.GetCharmDir())
+	if err != nil {
+		logger.Errorf("cannot read relation schemas: %v", err)
+	}
+	for _, relation := range relations {
+		relation.schemaErr = err
+		relation.schema = schemas[relation.Name()]
+	}
+}
+
 // ActionContext is part of the ContextFactory interface.
 func (f *contextFactory) ActionContext(actionData *ActionData) (*HookContext, error) {
 	if actionData == nil {
@@ -223,8 +238,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 			relation.cache.InvalidateMember(hookInfo.RemoteUnit)
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
+		f.prepareRemoteSettings(ctx, relation, hookInfo)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -234,11 +250,50 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 			return nil, errors.Trace(err)
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
+		ctx.storageMoveTarget = hookInfo.StorageMoveTarget
 	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
 
+// prepareRemoteSettings sets up the context's view of how the remote
+// unit's relation settings have changed, and arranges for the changes
+// to be recorded as seen once the hook succeeds.
+func (f *contextFactory) prepareRemoteSettings(ctx *HookContext, relation *ContextRelation, hookInfo hook.Info) {
+	seen := newSeenSettings(filepath.Join(f.paths.GetBaseDir(), "relation-settings"))
+	relationId, remoteUnit := hookInfo.RelationId, hookInfo.RemoteUnit
+	switch hookInfo.Kind {
+	case hooks.RelationJoined, hooks.RelationChanged:
+		if remoteUnit == "" {
+			return
+		}
+		// The settings are read directly rather than through the
+		// relation's cache, leaving the cache to be filled only if
+		// the hook reads them.
+		current, err := relation.ru.ReadSettings(remoteUnit)
+		if err != nil {
+			logger.Warningf("cannot read settings of %q in relation %d: %v", remoteUnit, relationId, err)
+			return
+		}
+		previous, err := seen.Read(relationId, remoteUnit)
+		if err != nil {
+			logger.Warningf("cannot read recorded settings of %q in relation %d: %v", remoteUnit, relationId, err)
+		}
+		ctx.remoteSettingsDiff = jujuc.NewSettingsDiff(previous, current)
+		ctx.commitRemoteSettings = func() error {
+			return seen.Write(relationId, remoteUnit, current)
+		}
+	case hooks.RelationDeparted:
+		ctx.commitRemoteSettings = func() error {
+			return seen.Remove(relationId, remoteUnit)
+		}
+	case hooks.RelationBroken:
+		ctx.commitRemoteSettings = func() error {
+			return seen.RemoveRelation(relationId)
+		}
+	}
+}
+
 // CommandContext is part of the ContextFactory interface.
 func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext, error) {
 	ctx, err := f.coreContext()
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/testing/factory"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -250,6 +251,21 @@ func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	})
 	s.AssertNotActionContext(c, ctx)
 	s.AssertNotRelationContext(c, ctx)
+	vars, err := ctx.HookVars(s.paths, false)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, v := range vars {
+		c.Assert(v, gc.Not(jc.HasPrefix), "JUJU_STORAGE_MOVE_TARGET=")
+	}
+
+	ctx, err = contextFactory.HookContext(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         "data/0",
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	vars, err = ctx.HookVars(s.paths, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vars, jc.Contains, "JUJU_STORAGE_MOVE_TARGET=/dev/sdc")
 }
 
 var podSpec = `
@@ -585,6 +601,62 @@ func (s *ContextFactorySuite) TestNewHookContextRelationBrokenRetainsCaches(c *g
 	c.Assert(member, jc.IsTrue)
 }
 
+func (s *ContextFactorySuite) TestNewHookContextRelationRemoteSettingsDiff(c *gc.C) {
+	app, err := s.State.Application("db1")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.Relation(1)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"host": "10.0.0.1", "port": "3306"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.membership[1] = []string{unit.Name()}
+
+	ctx, err := s.factory.HookContext(hook.Info{
+		Kind:       hooks.RelationJoined,
+		RelationId: 1,
+		RemoteUnit: unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	diff, err := ctx.RemoteSettingsDiff()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diff.Keys(), jc.DeepEquals, []string{"host", "port"})
+	vars, err := ctx.HookVars(s.paths, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vars, jc.Contains, "JUJU_RELATION_CHANGED_KEYS=host port")
+	err = ctx.Flush("test", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("port", "3307")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err = s.factory.HookContext(hook.Info{
+		Kind:       hooks.RelationChanged,
+		RelationId: 1,
+		RemoteUnit: unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	diff, err = ctx.RemoteSettingsDiff()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diff, jc.DeepEquals, &jujuc.SettingsDiff{
+		Changed: map[string]jujuc.SettingChange{
+			"port": {Old: "3306", New: "3307"},
+		},
+	})
+}
+
+func (s *ContextFactorySuite) TestNewHookContextNoRemoteSettingsDiff(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ctx.RemoteSettingsDiff()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type StubLeadershipContext struct {
 	context.LeadershipContext
 	*testing.Stub
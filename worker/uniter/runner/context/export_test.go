@@ -192,3 +192,9 @@ func CachedSettings(cf0 ContextFactory, relId int, unitName string) (params.Sett
 func (ctx *HookContext) SLALevel() string {
 	return ctx.slaLevel
 }
+
+// SetSchema sets the schema against which the relation's settings are
+// validated.
+func (ctx *ContextRelation) SetSchema(schema *EndpointSchema) {
+	ctx.schema = schema
+}
@@ -92,6 +92,51 @@ func (s *FlushContextSuite) TestRunHookRelationFlushingSuccess(c *gc.C) {
 	})
 }
 
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingError(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"one": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("two", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a failure.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the changes have not been written to state.
+	charmState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"one": "1"})
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingSuccess(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"one": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("two", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("one")
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := ctx.GetCharmStateValue("two")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "2")
+	_, err = ctx.GetCharmStateValue("one")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the changes have been written to state.
+	charmState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"two": "2"})
+}
+
 func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	// Initially, no port ranges are open on the unit or its machine.
 	unitRanges, err := s.unit.OpenedPorts()
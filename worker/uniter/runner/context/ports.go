@@ -19,6 +19,9 @@ import (
 type PortRangeInfo struct {
 	ShouldOpen  bool
 	RelationTag names.RelationTag
+	// Endpoint is the application endpoint the port range is opened
+	// for; empty means all endpoints.
+	Endpoint string
 }
 
 // PortRange contains a port range and a relation id. Used as key to
@@ -42,6 +45,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 }
 
 func tryOpenPorts(
+	endpoint string,
 	protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
@@ -65,10 +69,12 @@ func tryOpenPorts(
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
 	if isKnown {
-		if !rangeInfo.ShouldOpen {
+		if !rangeInfo.ShouldOpen || endpoint != "" {
 			// If the same range is already pending to be closed, just
-			// mark is pending to be opened.
+			// mark is pending to be opened. Opening it again for an
+			// endpoint replaces the endpoint it is pending for.
 			rangeInfo.ShouldOpen = true
+			rangeInfo.Endpoint = endpoint
 			pendingPorts[rangeKey] = rangeInfo
 		}
 		return nil
@@ -88,8 +94,11 @@ func tryOpenPorts(
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
 				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				// ignored, unless it is opening it for an endpoint.
+				if endpoint == "" {
+					return nil
+				}
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -109,6 +118,7 @@ func tryOpenPorts(
 
 	rangeInfo = pendingPorts[rangeKey]
 	rangeInfo.ShouldOpen = true
+	rangeInfo.Endpoint = endpoint
 	pendingPorts[rangeKey] = rangeInfo
 	return nil
 }
@@ -116,6 +116,12 @@ func makeMachinePorts(
 
 func makePendingPorts(
 	proto string, fromPort, toPort int, shouldOpen bool,
+) map[context.PortRange]context.PortRangeInfo {
+	return makePendingPortsForEndpoint("", proto, fromPort, toPort, shouldOpen)
+}
+
+func makePendingPortsForEndpoint(
+	endpoint, proto string, fromPort, toPort int, shouldOpen bool,
 ) map[context.PortRange]context.PortRangeInfo {
 	result := make(map[context.PortRange]context.PortRangeInfo)
 	portRange := network.PortRange{
@@ -129,12 +135,14 @@ func makePendingPorts(
 	}
 	result[key] = context.PortRangeInfo{
 		ShouldOpen: shouldOpen,
+		Endpoint:   endpoint,
 	}
 	return result
 }
 
 type portsTest struct {
 	about         string
+	endpoint      string
 	proto         string
 	ports         []int
 	machinePorts  map[network.PortRange]params.RelationUnit
@@ -192,6 +200,16 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:         "open a range conflicting with the same unit (ignored)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
 		expectPending: map[context.PortRange]context.PortRangeInfo{},
+	}, {
+		about:         "open an existing range for an endpoint",
+		endpoint:      "db",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makePendingPortsForEndpoint("db", "tcp", 10, 20, true),
+	}, {
+		about:         "open a range pending to be opened already for an endpoint",
+		endpoint:      "db",
+		pendingPorts:  makePendingPorts("tcp", 10, 20, true),
+		expectPending: makePendingPortsForEndpoint("db", "tcp", 10, 20, true),
 	}, {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
@@ -202,6 +220,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
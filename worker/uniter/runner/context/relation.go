@@ -6,6 +6,8 @@ package context
 import (
 	"fmt"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/relation"
@@ -26,6 +28,18 @@ type ContextRelation struct {
 	// settings allows read and write access to the relation unit settings.
 	settings *uniter.Settings
 
+	// original holds the relation unit settings as they were when
+	// first read, so that changes to them can be detected.
+	original params.Settings
+
+	// schema, if not nil, describes the settings the charm may write
+	// to the relation.
+	schema *EndpointSchema
+
+	// schemaErr, if not nil, is the error encountered reading the
+	// charm's relation schemas.
+	schemaErr error
+
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
 }
@@ -68,6 +82,7 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 			return nil, err
 		}
 		ctx.settings = node
+		ctx.original = node.Map()
 	}
 	return ctx.settings, nil
 }
@@ -80,6 +95,43 @@ func (ctx *ContextRelation) WriteSettings() (err error) {
 	return
 }
 
+// settingsChanged returns whether the unit's relation settings have
+// been changed.
+func (ctx *ContextRelation) settingsChanged() bool {
+	if ctx.settings == nil {
+		return false
+	}
+	current := ctx.settings.Map()
+	if len(current) != len(ctx.original) {
+		return true
+	}
+	for k, v := range current {
+		if original, ok := ctx.original[k]; !ok || original != v {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSettings returns an error if the unit's relation settings
+// have been changed and do not match the schema the charm declares
+// for the relation's endpoint.
+func (ctx *ContextRelation) ValidateSettings() error {
+	if !ctx.settingsChanged() {
+		return nil
+	}
+	if ctx.schemaErr != nil {
+		return errors.Annotate(ctx.schemaErr, "invalid relation schema")
+	}
+	if ctx.schema == nil {
+		return nil
+	}
+	if err := ctx.schema.Validate(ctx.settings.Map()); err != nil {
+		return errors.Errorf("relation %q settings do not match schema: %v", ctx.FakeId(), err)
+	}
+	return nil
+}
+
 // Suspended returns true if the relation is suspended.
 func (ctx *ContextRelation) Suspended() bool {
 	return ctx.ru.Relation().Suspended()
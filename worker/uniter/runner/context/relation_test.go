@@ -166,6 +166,27 @@ func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
 }
 
+func (s *ContextRelationSuite) TestValidateSettings(c *gc.C) {
+	ctx := context.NewContextRelation(s.apiRelUnit, nil)
+	ctx.SetSchema(&context.EndpointSchema{
+		Settings: map[string]context.SettingSchema{
+			"port": {Type: "int"},
+		},
+	})
+
+	// Unchanged settings are not validated.
+	node, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.ValidateSettings(), jc.ErrorIsNil)
+
+	node.Set("port", "http")
+	err = ctx.ValidateSettings()
+	c.Assert(err, gc.ErrorMatches, `relation "ring:[0-9]+" settings do not match schema: "port" expected int, got "http"`)
+
+	node.Set("port", "80")
+	c.Assert(ctx.ValidateSettings(), jc.ErrorIsNil)
+}
+
 func convertSettings(settings params.Settings) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range settings {
@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// RelationSchemaFile is the name of the file in which a charm declares
+// schemas for the settings it writes to its relations.
+const RelationSchemaFile = "relation-schemas.yaml"
+
+// jujuRelationSettings holds the settings that Juju writes for every
+// unit in a relation, which strict schemas need not describe.
+var jujuRelationSettings = set.NewStrings("private-address", "ingress-address", "egress-subnets")
+
+// RelationSchemas holds the settings schemas declared by a charm, keyed
+// by endpoint name.
+type RelationSchemas map[string]*EndpointSchema
+
+// EndpointSchema describes the settings a charm writes to relations on
+// an endpoint.
+type EndpointSchema struct {
+	// Strict, if true, rejects settings not described in Settings.
+	Strict bool `yaml:"strict,omitempty"`
+
+	// Settings describes the settings, keyed by name.
+	Settings map[string]SettingSchema `yaml:"settings"`
+}
+
+// SettingSchema describes a single relation setting.
+type SettingSchema struct {
+	// Type is the type of the setting's value: one of "string" (the
+	// default), "int", "float", "bool" or "json".
+	Type string `yaml:"type,omitempty"`
+
+	// Required, if true, rejects settings without a value for the
+	// setting.
+	Required bool `yaml:"required,omitempty"`
+
+	// Pattern, if set, is a regular expression that the whole of the
+	// setting's value must match.
+	Pattern string `yaml:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// ReadRelationSchemas reads the relation schemas declared by the charm
+// in charmDir. A charm that declares no schemas has none.
+func ReadRelationSchemas(charmDir string) (RelationSchemas, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, RelationSchemaFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var schemas RelationSchemas
+	if err := goyaml.Unmarshal(data, &schemas); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", RelationSchemaFile)
+	}
+	for endpoint, schema := range schemas {
+		if schema == nil {
+			return nil, errors.NotValidf("empty schema for endpoint %q", endpoint)
+		}
+		for name, setting := range schema.Settings {
+			if err := setting.init(); err != nil {
+				return nil, errors.Annotatef(err, "endpoint %q setting %q", endpoint, name)
+			}
+			schema.Settings[name] = setting
+		}
+	}
+	return schemas, nil
+}
+
+func (s *SettingSchema) init() error {
+	switch s.Type {
+	case "":
+		s.Type = "string"
+	case "string", "int", "float", "bool", "json":
+	default:
+		return errors.NotValidf("type %q", s.Type)
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile("^(?:" + s.Pattern + ")$")
+		if err != nil {
+			return errors.Annotate(err, "invalid pattern")
+		}
+		s.pattern = pattern
+	}
+	return nil
+}
+
+// Validate returns an error describing every way in which the given
+// settings do not match the schema.
+func (s *EndpointSchema) Validate(settings map[string]string) error {
+	var problems []string
+	for name, setting := range s.Settings {
+		value, ok := settings[name]
+		if !ok {
+			if setting.Required {
+				problems = append(problems, fmt.Sprintf("%q is required", name))
+			}
+			continue
+		}
+		if err := setting.validate(value); err != nil {
+			problems = append(problems, fmt.Sprintf("%q %v", name, err))
+		}
+	}
+	if s.Strict {
+		for name := range settings {
+			if _, ok := s.Settings[name]; !ok && !jujuRelationSettings.Contains(name) {
+				problems = append(problems, fmt.Sprintf("%q is not in the schema", name))
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
+
+func (s SettingSchema) validate(value string) error {
+	var err error
+	switch s.Type {
+	case "int":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float":
+		_, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "json":
+		var v interface{}
+		err = json.Unmarshal([]byte(value), &v)
+	}
+	if err != nil {
+		return errors.Errorf("expected %s, got %q", s.Type, value)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return errors.Errorf("%q does not match %q", value, s.Pattern)
+	}
+	return nil
+}
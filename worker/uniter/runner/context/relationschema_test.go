@@ -0,0 +1,118 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type RelationSchemaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RelationSchemaSuite{})
+
+const relationSchemasYAML = `
+db:
+  strict: true
+  settings:
+    host:
+      required: true
+    port:
+      type: int
+      required: true
+    tls:
+      type: bool
+    options:
+      type: json
+    user:
+      pattern: "[a-z]+"
+`
+
+func (s *RelationSchemaSuite) readSchemas(c *gc.C, content string) (context.RelationSchemas, error) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, context.RelationSchemaFile), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return context.ReadRelationSchemas(dir)
+}
+
+func (s *RelationSchemaSuite) TestReadRelationSchemasMissing(c *gc.C) {
+	schemas, err := context.ReadRelationSchemas(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.IsNil)
+}
+
+func (s *RelationSchemaSuite) TestReadRelationSchemasInvalid(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "db: [",
+		err:     "parsing relation-schemas.yaml: .*",
+	}, {
+		content: "db:\n",
+		err:     `empty schema for endpoint "db" not valid`,
+	}, {
+		content: "db:\n  settings:\n    port:\n      type: number\n",
+		err:     `endpoint "db" setting "port": type "number" not valid`,
+	}, {
+		content: "db:\n  settings:\n    user:\n      pattern: \"[\"\n",
+		err:     `endpoint "db" setting "user": invalid pattern: .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.content)
+		_, err := s.readSchemas(c, test.content)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RelationSchemaSuite) TestValidate(c *gc.C) {
+	schemas, err := s.readSchemas(c, relationSchemasYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	schema := schemas["db"]
+	c.Assert(schema, gc.NotNil)
+
+	for i, test := range []struct {
+		settings map[string]string
+		err      string
+	}{{
+		settings: map[string]string{
+			"host":            "10.0.0.1",
+			"port":            "3306",
+			"tls":             "true",
+			"options":         `{"charset": "utf8"}`,
+			"user":            "admin",
+			"private-address": "10.0.0.1",
+		},
+	}, {
+		settings: map[string]string{"host": "10.0.0.1"},
+		err:      `"port" is required`,
+	}, {
+		settings: map[string]string{"host": "10.0.0.1", "port": "db"},
+		err:      `"port" expected int, got "db"`,
+	}, {
+		settings: map[string]string{"host": "10.0.0.1", "port": "1", "tls": "maybe", "options": "{"},
+		err:      `"options" expected json, got "{"; "tls" expected bool, got "maybe"`,
+	}, {
+		settings: map[string]string{"host": "10.0.0.1", "port": "1", "user": "Admin"},
+		err:      `"user" "Admin" does not match "\[a-z\]\+"`,
+	}, {
+		settings: map[string]string{"host": "10.0.0.1", "port": "1", "password": "secret"},
+		err:      `"password" is not in the schema`,
+	}} {
+		c.Logf("test %d", i)
+		err := schema.Validate(test.settings)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// seenSettings records, for each relation and remote unit, the remote
+// unit's settings as they were when the local unit last completed a
+// hook for that remote unit. This is what relation-diff compares the
+// remote unit's current settings against.
+type seenSettings struct {
+	dir string
+}
+
+// newSeenSettings returns a seenSettings that keeps its records under
+// the given directory.
+func newSeenSettings(dir string) *seenSettings {
+	return &seenSettings{dir: dir}
+}
+
+func (s *seenSettings) relationDir(relationId int) string {
+	return filepath.Join(s.dir, strconv.Itoa(relationId))
+}
+
+func (s *seenSettings) unitPath(relationId int, unitName string) string {
+	return filepath.Join(s.relationDir(relationId), strings.Replace(unitName, "/", "-", -1)+".yaml")
+}
+
+// Read returns the recorded settings of the given remote unit. A unit
+// with no record has no settings.
+func (s *seenSettings) Read(relationId int, unitName string) (map[string]string, error) {
+	var settings map[string]string
+	err := utils.ReadYaml(s.unitPath(relationId, unitName), &settings)
+	if os.IsNotExist(errors.Cause(err)) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings, nil
+}
+
+// Write records the settings of the given remote unit.
+func (s *seenSettings) Write(relationId int, unitName string, settings map[string]string) error {
+	if err := os.MkdirAll(s.relationDir(relationId), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.WriteYaml(s.unitPath(relationId, unitName), settings))
+}
+
+// Remove removes the record of the given remote unit.
+func (s *seenSettings) Remove(relationId int, unitName string) error {
+	err := os.Remove(s.unitPath(relationId, unitName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// RemoveRelation removes the records of every remote unit in the
+// given relation.
+func (s *seenSettings) RemoveRelation(relationId int) error {
+	return errors.Trace(os.RemoveAll(s.relationDir(relationId)))
+}
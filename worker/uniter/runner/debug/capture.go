@@ -0,0 +1,291 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// captureTimeFormat is the format of the start time that suffixes
+// the name of each capture directory.
+const captureTimeFormat = "20060102T150405.000"
+
+// maxCaptures is the number of captures kept for a unit. When a capture
+// is written, the oldest captures beyond this are removed.
+var maxCaptures = 50
+
+// ClientCaptureFile returns the path of the file in which
+// "juju debug-hooks --capture" records the hooks to capture.
+func (c *HooksContext) ClientCaptureFile() string {
+	return c.ClientFileLock() + "-capture"
+}
+
+// CaptureDir returns the directory in which hook captures
+// for the unit are written.
+func (c *HooksContext) CaptureDir() string {
+	basename := fmt.Sprintf("juju-%s-debug-captures", names.NewUnitTag(c.Unit))
+	return filepath.Join(c.FlockDir, basename)
+}
+
+// CaptureSession represents a "juju debug-hooks --capture" request.
+// Unlike a ServerSession, it does not interrupt hooks: matching hooks
+// run as usual, and a snapshot of their context is written alongside.
+type CaptureSession struct {
+	*HooksContext
+	hooks set.Strings
+}
+
+// FindCaptureSession returns the capture session requested for the
+// unit, or an error satisfying os.IsNotExist if there is none.
+func (c *HooksContext) FindCaptureSession() (*CaptureSession, error) {
+	data, err := ioutil.ReadFile(c.ClientCaptureFile())
+	if err != nil {
+		return nil, err
+	}
+	var args hookArgs
+	if err := goyaml.Unmarshal(data, &args); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CaptureSession{
+		HooksContext: c,
+		hooks:        set.NewStrings(args.Hooks...),
+	}, nil
+}
+
+// MatchHook returns true if the specified hook name matches
+// the hooks to capture.
+func (s *CaptureSession) MatchHook(hookName string) bool {
+	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
+}
+
+// NewCapture returns a Capture in which to record the execution of
+// the named hook, started at the given time.
+func (s *CaptureSession) NewCapture(hookName string, started time.Time) *Capture {
+	return &Capture{
+		dir:     s.CaptureDir(),
+		Unit:    s.Unit,
+		Hook:    hookName,
+		Started: started.UTC(),
+	}
+}
+
+// Capture records the context in which a hook ran, and the hook tools
+// it invoked, so that the hook's execution can be reproduced offline.
+type Capture struct {
+	dir string
+
+	// mu guards HookTools, which are recorded as the
+	// hook tool server handles requests.
+	mu sync.Mutex
+
+	Unit     string          `yaml:"unit"`
+	Hook     string          `yaml:"hook"`
+	Started  time.Time       `yaml:"started"`
+	Finished time.Time       `yaml:"finished"`
+	Error    string          `yaml:"error,omitempty"`
+	Context  ContextSnapshot `yaml:"context"`
+
+	// Env and HookTools are written to files of their own.
+	Env       []string             `yaml:"-"`
+	HookTools []HookToolInvocation `yaml:"-"`
+}
+
+// ContextSnapshot holds the hook context as it was when a hook started.
+type ContextSnapshot struct {
+	Config         map[string]interface{} `yaml:"config,omitempty"`
+	Leader         bool                   `yaml:"leader"`
+	LeaderSettings map[string]string      `yaml:"leader-settings,omitempty"`
+	PrivateAddress string                 `yaml:"private-address,omitempty"`
+	PublicAddress  string                 `yaml:"public-address,omitempty"`
+	Relations      []RelationSnapshot     `yaml:"relations,omitempty"`
+	Storage        []StorageSnapshot      `yaml:"storage,omitempty"`
+
+	// RelationId and RemoteUnit identify the relation and remote
+	// unit of a relation hook.
+	RelationId int    `yaml:"relation-id,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty"`
+}
+
+// RelationSnapshot holds the settings of a relation the unit is in.
+type RelationSnapshot struct {
+	Id       int                          `yaml:"id"`
+	Endpoint string                       `yaml:"endpoint"`
+	Local    map[string]string            `yaml:"local,omitempty"`
+	Units    map[string]map[string]string `yaml:"units,omitempty"`
+}
+
+// StorageSnapshot holds details of storage attached to the unit.
+type StorageSnapshot struct {
+	Id       string `yaml:"id"`
+	Kind     string `yaml:"kind"`
+	Location string `yaml:"location"`
+}
+
+// HookToolInvocation records a hook tool run by a hook, and its result.
+type HookToolInvocation struct {
+	Command string   `json:"command" yaml:"command"`
+	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+	Stdin   string   `json:"stdin,omitempty" yaml:"stdin,omitempty"`
+	Code    int      `json:"code" yaml:"code"`
+	Stdout  string   `json:"stdout,omitempty" yaml:"stdout,omitempty"`
+	Stderr  string   `json:"stderr,omitempty" yaml:"stderr,omitempty"`
+}
+
+// RecordHookTool records the invocation of a hook tool.
+func (c *Capture) RecordHookTool(invocation HookToolInvocation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HookTools = append(c.HookTools, invocation)
+}
+
+// Write writes the capture, finished at the given time with the given
+// hook error, to a new directory in the unit's capture directory, and
+// returns the path of that directory.
+func (c *Capture) Write(finished time.Time, hookErr error) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Finished = finished.UTC()
+	if hookErr != nil {
+		c.Error = hookErr.Error()
+	}
+
+	// The captured context may include secrets,
+	// so it is only readable by root.
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	name := fmt.Sprintf("%s-%s", c.Hook, c.Started.Format(captureTimeFormat))
+	dir := filepath.Join(c.dir, name)
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	capture, err := goyaml.Marshal(c)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	hookTools, err := goyaml.Marshal(c.HookTools)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	files := []struct {
+		filename string
+		contents []byte
+	}{
+		{"capture.yaml", capture},
+		{"hook-tools.yaml", hookTools},
+		{"env", []byte(strings.Join(c.Env, "\n") + "\n")},
+	}
+	for _, file := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, file.filename), file.contents, 0600); err != nil {
+			return "", errors.Annotatef(err, "writing %q", file.filename)
+		}
+	}
+	if err := pruneCaptures(c.dir, maxCaptures); err != nil {
+		return "", errors.Annotate(err, "pruning old captures")
+	}
+	return dir, nil
+}
+
+// pruneCaptures removes all but the most recently started keep
+// captures from the capture directory.
+func pruneCaptures(captureDir string, keep int) error {
+	entries, err := ioutil.ReadDir(captureDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	type capture struct {
+		name    string
+		started time.Time
+	}
+	var captures []capture
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		started, err := time.Parse(captureTimeFormat, name[strings.LastIndex(name, "-")+1:])
+		if err != nil {
+			// Not a capture.
+			continue
+		}
+		captures = append(captures, capture{name: name, started: started})
+	}
+	if len(captures) <= keep {
+		return nil
+	}
+	sort.Slice(captures, func(i, j int) bool {
+		return captures[i].started.Before(captures[j].started)
+	})
+	for _, capture := range captures[:len(captures)-keep] {
+		if err := os.RemoveAll(filepath.Join(captureDir, capture.name)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ClientCaptureScript returns a bash script suitable for executing
+// on the unit system to capture matching hooks or actions as they run.
+func ClientCaptureScript(c *HooksContext, match []string) string {
+	for _, m := range match {
+		if m == "*" {
+			match = nil
+			break
+		}
+	}
+	s := strings.Replace(debugHooksCaptureScript, "{capture_file}", c.ClientCaptureFile(), -1)
+	s = strings.Replace(s, "{capture_dir}", c.CaptureDir(), -1)
+	s = strings.Replace(s, "{unit_name}", c.Unit, -1)
+	return strings.Replace(s, "{hook_args}", encodeBase64Args(match), 1)
+}
+
+// ClientStopCaptureScript returns a bash script suitable for executing
+// on the unit system to stop capturing hooks. Existing captures are kept.
+func ClientStopCaptureScript(c *HooksContext) string {
+	s := strings.Replace(debugHooksStopCaptureScript, "{capture_file}", c.ClientCaptureFile(), -1)
+	s = strings.Replace(s, "{capture_dir}", c.CaptureDir(), -1)
+	return strings.Replace(s, "{unit_name}", c.Unit, -1)
+}
+
+// ClientFetchCapturesScript returns a bash script suitable for executing
+// on the unit system to write a gzipped tar archive of all the unit's
+// hook captures to stdout.
+func ClientFetchCapturesScript(c *HooksContext) string {
+	s := strings.Replace(debugHooksFetchCapturesScript, "{capture_dir}", c.CaptureDir(), -1)
+	return strings.Replace(s, "{unit_name}", c.Unit, -1)
+}
+
+const debugHooksCaptureScript = `#!/bin/bash
+set -e
+mkdir -p -m 0700 {capture_dir}
+echo "{hook_args}" | base64 -d > {capture_file}
+echo "Capturing hooks for {unit_name} in {capture_dir}" >&2
+`
+
+const debugHooksStopCaptureScript = `#!/bin/bash
+rm -f {capture_file}
+echo "Stopped capturing hooks for {unit_name}; captures remain in {capture_dir}" >&2
+`
+
+const debugHooksFetchCapturesScript = `#!/bin/bash
+set -e
+if [ ! -d {capture_dir} ]; then
+    echo "no hook captures for {unit_name}" >&2
+    exit 1
+fi
+tar -C {capture_dir} -czf - .
+`
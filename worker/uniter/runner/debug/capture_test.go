@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+type DebugHooksCaptureSuite struct {
+	ctx *debug.HooksContext
+}
+
+var _ = gc.Suite(&DebugHooksCaptureSuite{})
+
+func (s *DebugHooksCaptureSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	s.ctx = debug.NewHooksContext("foo/8")
+	s.ctx.FlockDir = c.MkDir()
+}
+
+func runScript(c *gc.C, script string) string {
+	out, err := exec.Command("/bin/bash", "-c", script).CombinedOutput()
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", out))
+	return string(out)
+}
+
+func (s *DebugHooksCaptureSuite) TestCaptureDir(c *gc.C) {
+	c.Assert(s.ctx.ClientCaptureFile(), jc.SamePath, filepath.Join(s.ctx.FlockDir, "juju-unit-foo-8-debug-hooks-capture"))
+	c.Assert(s.ctx.CaptureDir(), jc.SamePath, filepath.Join(s.ctx.FlockDir, "juju-unit-foo-8-debug-captures"))
+}
+
+func (s *DebugHooksCaptureSuite) TestFindCaptureSession(c *gc.C) {
+	_, err := s.ctx.FindCaptureSession()
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+
+	out := runScript(c, debug.ClientCaptureScript(s.ctx, []string{"start", "config-changed"}))
+	c.Assert(out, gc.Equals, "Capturing hooks for foo/8 in "+s.ctx.CaptureDir()+"\n")
+	session, err := s.ctx.FindCaptureSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("start"), jc.IsTrue)
+	c.Assert(session.MatchHook("config-changed"), jc.IsTrue)
+	c.Assert(session.MatchHook("stop"), jc.IsFalse)
+
+	runScript(c, debug.ClientCaptureScript(s.ctx, []string{"*"}))
+	session, err = s.ctx.FindCaptureSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("stop"), jc.IsTrue)
+
+	runScript(c, debug.ClientStopCaptureScript(s.ctx))
+	_, err = s.ctx.FindCaptureSession()
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *DebugHooksCaptureSuite) TestCaptureWrite(c *gc.C) {
+	runScript(c, debug.ClientCaptureScript(s.ctx, nil))
+	session, err := s.ctx.FindCaptureSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	capture := session.NewCapture("config-changed", started)
+	capture.Env = []string{"JUJU_UNIT_NAME=foo/8", "JUJU_HOOK_NAME=config-changed"}
+	capture.Context = debug.ContextSnapshot{
+		Config: map[string]interface{}{"port": 8080},
+		Leader: true,
+		Relations: []debug.RelationSnapshot{{
+			Id:       1,
+			Endpoint: "db",
+			Units:    map[string]map[string]string{"mysql/0": {"host": "10.0.0.1"}},
+		}},
+	}
+	capture.RecordHookTool(debug.HookToolInvocation{
+		Command: "config-get",
+		Args:    []string{"port"},
+		Stdout:  "8080\n",
+	})
+	dir, err := capture.Write(started.Add(time.Second), errors.New("exit status 1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dir, gc.Equals, filepath.Join(s.ctx.CaptureDir(), "config-changed-20200401T120000.000"))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "capture.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	var written map[string]interface{}
+	err = goyaml.Unmarshal(data, &written)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(written["unit"], gc.Equals, "foo/8")
+	c.Assert(written["hook"], gc.Equals, "config-changed")
+	c.Assert(written["error"], gc.Equals, "exit status 1")
+	context := written["context"].(map[interface{}]interface{})
+	c.Assert(context["leader"], jc.IsTrue)
+	c.Assert(context["config"], jc.DeepEquals, map[interface{}]interface{}{"port": 8080})
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "hook-tools.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	var hookTools []debug.HookToolInvocation
+	err = goyaml.Unmarshal(data, &hookTools)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hookTools, jc.DeepEquals, []debug.HookToolInvocation{{
+		Command: "config-get",
+		Args:    []string{"port"},
+		Stdout:  "8080\n",
+	}})
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "env"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "JUJU_UNIT_NAME=foo/8\nJUJU_HOOK_NAME=config-changed\n")
+
+	archive, err := exec.Command("/bin/bash", "-c", debug.ClientFetchCapturesScript(s.ctx)).Output()
+	c.Assert(err, jc.ErrorIsNil)
+	list := exec.Command("tar", "-tzf", "-")
+	list.Stdin = bytes.NewReader(archive)
+	out, err := list.CombinedOutput()
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", out))
+	c.Assert(string(out), jc.Contains, "config-changed-20200401T120000.000/capture.yaml")
+}
+
+func (s *DebugHooksCaptureSuite) TestCaptureWritePrunesOldCaptures(c *gc.C) {
+	defer testing.PatchValue(debug.MaxCaptures, 2).Restore()
+	runScript(c, debug.ClientCaptureScript(s.ctx, nil))
+	session, err := s.ctx.FindCaptureSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	for i, hookName := range []string{"start", "config-changed", "update-status"} {
+		capture := session.NewCapture(hookName, started.Add(time.Duration(i)*time.Minute))
+		_, err := capture.Write(started.Add(time.Duration(i)*time.Minute+time.Second), nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	entries, err := ioutil.ReadDir(s.ctx.CaptureDir())
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	c.Assert(names, jc.SameContents, []string{
+		"config-changed-20200401T120100.000",
+		"update-status-20200401T120200.000",
+	})
+}
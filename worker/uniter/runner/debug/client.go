@@ -30,12 +30,16 @@ func ClientScript(c *HooksContext, match []string) string {
 	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
 	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
 
-	yamlArgs := encodeArgs(match)
-	base64Args := base64.StdEncoding.EncodeToString(yamlArgs)
-	s = strings.Replace(s, "{hook_args}", base64Args, 1)
+	s = strings.Replace(s, "{hook_args}", encodeBase64Args(match), 1)
 	return s
 }
 
+// encodeBase64Args returns the hook args marshalled to YAML, then
+// encoded in base64 to avoid shell escapes.
+func encodeBase64Args(args []string) string {
+	return base64.StdEncoding.EncodeToString(encodeArgs(args))
+}
+
 func encodeArgs(args []string) []byte {
 	// Marshal to YAML, then encode in base64 to avoid shell escapes.
 	yamlArgs, err := goyaml.Marshal(hookArgs{Hooks: args})
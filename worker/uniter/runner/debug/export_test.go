@@ -14,3 +14,6 @@ func (c *HooksContext) FindSessionWithWriter(writer io.Writer) (*ServerSession,
 	}
 	return session, err
 }
+
+// MaxCaptures is the number of captures kept for a unit.
+var MaxCaptures = &maxCaptures
@@ -4,6 +4,7 @@
 package runner
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
@@ -40,6 +41,7 @@ func NewFactory(
 	paths context.Paths,
 	contextFactory context.ContextFactory,
 	remoteExecutor ExecFunc,
+	clock clock.Clock,
 ) (
 	Factory, error,
 ) {
@@ -48,6 +50,7 @@ func NewFactory(
 		paths:          paths,
 		contextFactory: contextFactory,
 		remoteExecutor: remoteExecutor,
+		clock:          clock,
 	}
 
 	return f, nil
@@ -62,6 +65,7 @@ type factory struct {
 	// Fields that shouldn't change in a factory's lifetime.
 	paths          context.Paths
 	remoteExecutor ExecFunc
+	clock          clock.Clock
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -70,7 +74,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths, f.remoteExecutor)
+	runner := NewRunnerWithClock(ctx, f.paths, f.remoteExecutor, f.clock)
 	return runner, nil
 }
 
@@ -84,7 +88,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths, f.remoteExecutor)
+	runner := NewRunnerWithClock(ctx, f.paths, f.remoteExecutor, f.clock)
 	return runner, nil
 }
 
@@ -130,7 +134,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	if err != nil {
 		return nil, charmrunner.NewBadActionError(name, err.Error())
 	}
-	runner := NewRunner(ctx, f.paths, f.remoteExecutor)
+	runner := NewRunnerWithClock(ctx, f.paths, f.remoteExecutor, f.clock)
 	return runner, nil
 }
 
@@ -179,6 +179,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		s.paths,
 		contextFactory,
 		nil,
+		testclock.NewClock(time.Time{}),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
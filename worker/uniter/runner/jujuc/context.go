@@ -41,6 +41,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextUnitCharmState
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -63,6 +64,12 @@ type relationHookContext interface {
 	// is associated with if it was found, and an error if it was not found or is not
 	// available.
 	RemoteUnitName() (string, error)
+
+	// RemoteSettingsDiff returns how the remote unit's settings have
+	// changed since the local unit last completed a hook for it, or a
+	// NotFound error if the hook is not a relation-joined or
+	// relation-changed hook.
+	RemoteSettingsDiff() (*SettingsDiff, error)
 }
 
 // ActionHookContext is the context for an action hook.
@@ -152,6 +159,12 @@ type ContextNetworking interface {
 	// executing unit's application is exposed.
 	OpenPorts(protocol string, fromPort, toPort int) error
 
+	// OpenPortsForEndpoint marks the supplied port range for opening
+	// for the given endpoint of the executing unit's application, when
+	// the application is exposed. Exposing the endpoint controls which
+	// sources may access the port range.
+	OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
 	// ClosePorts ensures the supplied port range is closed even when
 	// the executing unit's application is exposed (unless it is opened
 	// separately by a co- located unit).
@@ -306,6 +319,26 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextUnitCharmState expresses the parts of a hook context related to
+// the key/value state a charm keeps for its unit between hooks.
+type ContextUnitCharmState interface {
+
+	// GetCharmState returns a copy of the unit's charm state.
+	GetCharmState() (map[string]string, error)
+
+	// GetCharmStateValue returns the value of the given key in the
+	// unit's charm state, or a NotFound error if it is not set.
+	GetCharmStateValue(string) (string, error)
+
+	// SetCharmStateValue sets the given key to the given value in the
+	// unit's charm state. The change is written when the hook completes.
+	SetCharmStateValue(string, string) error
+
+	// DeleteCharmStateValue removes the given key from the unit's charm
+	// state. The change is written when the hook completes.
+	DeleteCharmStateValue(string) error
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+)
+
+// CharmState holds values for the hook context.
+type CharmState struct {
+	State map[string]string
+}
+
+// ContextUnitCharmState is a test double for jujuc.ContextUnitCharmState.
+type ContextUnitCharmState struct {
+	contextBase
+	info *CharmState
+}
+
+// GetCharmState implements jujuc.ContextUnitCharmState.
+func (c *ContextUnitCharmState) GetCharmState() (map[string]string, error) {
+	c.stub.AddCall("GetCharmState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(c.info.State))
+	for k, v := range c.info.State {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// GetCharmStateValue implements jujuc.ContextUnitCharmState.
+func (c *ContextUnitCharmState) GetCharmStateValue(key string) (string, error) {
+	c.stub.AddCall("GetCharmStateValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := c.info.State[key]
+	if !ok {
+		return "", errors.NotFoundf("%q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue implements jujuc.ContextUnitCharmState.
+func (c *ContextUnitCharmState) SetCharmStateValue(key, value string) error {
+	c.stub.AddCall("SetCharmStateValue", key, value)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.State == nil {
+		c.info.State = make(map[string]string)
+	}
+	c.info.State[key] = value
+	return nil
+}
+
+// DeleteCharmStateValue implements jujuc.ContextUnitCharmState.
+func (c *ContextUnitCharmState) DeleteCharmStateValue(key string) error {
+	c.stub.AddCall("DeleteCharmStateValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	delete(c.info.State, key)
+	return nil
+}
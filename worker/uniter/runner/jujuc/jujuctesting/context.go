@@ -23,6 +23,7 @@ type ContextInfo struct {
 	RelationHook
 	ActionHook
 	Version
+	CharmState
 }
 
 // Context returns a Context that wraps the info.
@@ -63,6 +64,7 @@ type Context struct {
 	ContextRelationHook
 	ContextActionHook
 	ContextVersion
+	ContextUnitCharmState
 }
 
 // NewContext builds a jujuc.Context test double.
@@ -92,5 +94,7 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextActionHook.info = &info.ActionHook
 	ctx.ContextVersion.stub = stub
 	ctx.ContextVersion.info = &info.Version
+	ctx.ContextUnitCharmState.stub = stub
+	ctx.ContextUnitCharmState.info = &info.CharmState
 	return &ctx
 }
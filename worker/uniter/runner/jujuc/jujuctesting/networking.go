@@ -17,6 +17,7 @@ type NetworkInterface struct {
 	PublicAddress      string
 	PrivateAddress     string
 	Ports              []network.PortRange
+	PortEndpoints      map[network.PortRange]string
 	NetworkInfoResults map[string]params.NetworkInfoResult
 }
 
@@ -84,6 +85,21 @@ func (c *ContextNetworking) OpenPorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenPortsForEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenPortsForEndpoint(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenPortsForEndpoint", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddPorts(protocol, from, to)
+	if c.info.PortEndpoints == nil {
+		c.info.PortEndpoints = make(map[network.PortRange]string)
+	}
+	c.info.PortEndpoints[network.PortRange{Protocol: protocol, FromPort: from, ToPort: to}] = endpoint
+	return nil
+}
+
 // ClosePorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	c.stub.AddCall("ClosePorts", protocol, from, to)
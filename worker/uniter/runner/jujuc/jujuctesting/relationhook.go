@@ -11,14 +11,16 @@ import (
 
 // RelationHook holds the values for the hook context.
 type RelationHook struct {
-	HookRelation   jujuc.ContextRelation
-	RemoteUnitName string
+	HookRelation       jujuc.ContextRelation
+	RemoteUnitName     string
+	RemoteSettingsDiff *jujuc.SettingsDiff
 }
 
 // Reset clears the RelationHook's data.
 func (rh *RelationHook) Reset() {
 	rh.HookRelation = nil
 	rh.RemoteUnitName = ""
+	rh.RemoteSettingsDiff = nil
 }
 
 // ContextRelationHook is a test double for jujuc.RelationHookContext.
@@ -49,3 +51,15 @@ func (c *ContextRelationHook) RemoteUnitName() (string, error) {
 
 	return c.info.RemoteUnitName, err
 }
+
+// RemoteSettingsDiff implements jujuc.RelationHookContext.
+func (c *ContextRelationHook) RemoteSettingsDiff() (*jujuc.SettingsDiff, error) {
+	c.stub.AddCall("RemoteSettingsDiff")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.info.RemoteSettingsDiff == nil {
+		return nil, errors.NotFoundf("remote settings diff")
+	}
+	return c.info.RemoteSettingsDiff, nil
+}
@@ -169,16 +169,39 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+The --endpoint option opens the port range for an endpoint of the
+application, so that it may only be accessed from the sources that
+the endpoint, or the whole application, is exposed to. Opening a port
+range again for another endpoint replaces the endpoint that it was
+opened for.`[1:],
+}
+
+// openPortCommand implements the open-port command.
+type openPortCommand struct {
+	portCommand
+	Endpoint string
+}
+
+func (c *openPortCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.portCommand.SetFlags(f)
+	f.StringVar(&c.Endpoint, "endpoint", "", "the application endpoint to open the port range for")
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
-	return &portCommand{
+	c := &openPortCommand{}
+	c.portCommand = portCommand{
 		info: openPortInfo,
-		action: func(c *portCommand) error {
+		action: func(*portCommand) error {
+			if c.Endpoint != "" {
+				return ctx.OpenPortsForEndpoint(c.Endpoint, c.Protocol, c.FromPort, c.ToPort)
+			}
 			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
 		},
-	}, nil
+	}
+	return c, nil
 }
 
 var closePortInfo = &cmd.Info{
@@ -91,6 +91,20 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenForEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--endpoint", "db", "5432/tcp"})
+	c.Check(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	hctx.info.CheckPorts(c, makeRanges("5432/tcp"))
+	c.Assert(hctx.info.PortEndpoints, jc.DeepEquals, map[network.PortRange]string{
+		{Protocol: "tcp", FromPort: 5432, ToPort: 5432}: "db",
+	})
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -136,6 +150,12 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+The --endpoint option opens the port range for an endpoint of the
+application, so that it may only be accessed from the sources that
+the endpoint, or the whole application, is exposed to. Opening a port
+range again for another endpoint replaces the endpoint that it was
+opened for.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
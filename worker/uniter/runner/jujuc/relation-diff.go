@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// SettingsDiff describes how a remote unit's relation settings have
+// changed since the local unit last completed a hook for that unit.
+type SettingsDiff struct {
+	// Added holds the settings that were not set before.
+	Added map[string]string `json:"added,omitempty" yaml:"added,omitempty"`
+
+	// Changed holds the settings whose values have changed.
+	Changed map[string]SettingChange `json:"changed,omitempty" yaml:"changed,omitempty"`
+
+	// Removed holds the previous values of settings that are no
+	// longer set.
+	Removed map[string]string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// SettingChange holds the previous and current values of a setting.
+type SettingChange struct {
+	Old string `json:"old" yaml:"old"`
+	New string `json:"new" yaml:"new"`
+}
+
+// NewSettingsDiff returns the difference between the old and new
+// settings.
+func NewSettingsDiff(old, new map[string]string) *SettingsDiff {
+	diff := &SettingsDiff{}
+	for k, v := range new {
+		oldValue, ok := old[k]
+		switch {
+		case !ok:
+			if diff.Added == nil {
+				diff.Added = make(map[string]string)
+			}
+			diff.Added[k] = v
+		case oldValue != v:
+			if diff.Changed == nil {
+				diff.Changed = make(map[string]SettingChange)
+			}
+			diff.Changed[k] = SettingChange{Old: oldValue, New: v}
+		}
+	}
+	for k, v := range old {
+		if _, ok := new[k]; !ok {
+			if diff.Removed == nil {
+				diff.Removed = make(map[string]string)
+			}
+			diff.Removed[k] = v
+		}
+	}
+	return diff
+}
+
+// Keys returns the sorted keys of all the added, changed and
+// removed settings.
+func (d *SettingsDiff) Keys() []string {
+	keys := make([]string, 0, len(d.Added)+len(d.Changed)+len(d.Removed))
+	for k := range d.Added {
+		keys = append(keys, k)
+	}
+	for k := range d.Changed {
+		keys = append(keys, k)
+	}
+	for k := range d.Removed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RelationDiffCommand implements the relation-diff command.
+type RelationDiffCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	keys bool
+	out  cmd.Output
+}
+
+// NewRelationDiffCommand returns a relation-diff command.
+func NewRelationDiffCommand(ctx Context) (cmd.Command, error) {
+	return &RelationDiffCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *RelationDiffCommand) Info() *cmd.Info {
+	doc := `
+relation-diff shows how the remote unit's settings have changed since
+this unit last completed a hook for the remote unit. It may only be
+used in relation-joined and relation-changed hooks. The first hook
+run for a remote unit sees all its settings as added.
+
+The changed keys are also available in the hook's environment, in
+JUJU_RELATION_CHANGED_KEYS.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "relation-diff",
+		Purpose: "show changes to the remote unit's relation settings",
+		Doc:     doc,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *RelationDiffCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+	f.BoolVar(&c.keys, "keys", false, "list only the keys of changed settings")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *RelationDiffCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *RelationDiffCommand) Run(ctx *cmd.Context) error {
+	diff, err := c.ctx.RemoteSettingsDiff()
+	if errors.IsNotFound(err) {
+		return errors.New("relation-diff may only be used in relation-joined and relation-changed hooks")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if c.keys {
+		return c.out.Write(ctx, diff.Keys())
+	}
+	return c.out.Write(ctx, diff)
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type RelationDiffSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&RelationDiffSuite{})
+
+func (s *RelationDiffSuite) TestNewSettingsDiff(c *gc.C) {
+	diff := jujuc.NewSettingsDiff(
+		map[string]string{"a": "1", "b": "2", "c": "3"},
+		map[string]string{"a": "1", "b": "4", "d": "5"},
+	)
+	c.Assert(diff, jc.DeepEquals, &jujuc.SettingsDiff{
+		Added:   map[string]string{"d": "5"},
+		Changed: map[string]jujuc.SettingChange{"b": {Old: "2", New: "4"}},
+		Removed: map[string]string{"c": "3"},
+	})
+	c.Assert(diff.Keys(), jc.DeepEquals, []string{"b", "c", "d"})
+}
+
+var relationDiffTests = []struct {
+	summary string
+	args    []string
+	out     string
+}{{
+	summary: "default format",
+	out: `
+added:
+  port: "3306"
+changed:
+  host:
+    old: 10.0.0.1
+    new: 10.0.0.2
+`[1:],
+}, {
+	summary: "keys only",
+	args:    []string{"--keys"},
+	out:     "- host\n- port\n",
+}, {
+	summary: "json keys",
+	args:    []string{"--keys", "--format", "json"},
+	out:     "[\"host\",\"port\"]\n",
+}}
+
+func (s *RelationDiffSuite) TestRelationDiff(c *gc.C) {
+	for i, t := range relationDiffTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx, info := s.newHookContext(1, "u/1")
+		info.RelationHook.RemoteSettingsDiff = jujuc.NewSettingsDiff(
+			map[string]string{"host": "10.0.0.1"},
+			map[string]string{"host": "10.0.0.2", "port": "3306"},
+		)
+		com, err := jujuc.NewCommand(hctx, cmdString("relation-diff"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *RelationDiffSuite) TestRelationDiffNotRelationHook(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-diff"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR relation-diff may only be used in relation-joined and relation-changed hooks\n")
+}
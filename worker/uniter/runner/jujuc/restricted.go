@@ -77,6 +77,11 @@ func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error
 	return ErrRestrictedContext
 }
 
+// OpenPortsForEndpoint implements hooks.Context.
+func (*RestrictedContext) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // ClosePorts implements hooks.Context.
 func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
@@ -143,6 +148,11 @@ func (*RestrictedContext) HookRelation() (ContextRelation, error) {
 // RemoteUnitName implements hooks.Context.
 func (*RestrictedContext) RemoteUnitName() (string, error) { return "", ErrRestrictedContext }
 
+// RemoteSettingsDiff implements hooks.Context.
+func (*RestrictedContext) RemoteSettingsDiff() (*SettingsDiff, error) {
+	return nil, ErrRestrictedContext
+}
+
 // ActionParams implements hooks.Context.
 func (*RestrictedContext) ActionParams() (map[string]interface{}, error) {
 	return nil, ErrRestrictedContext
@@ -176,3 +186,23 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// GetCharmState implements hooks.Context.
+func (*RestrictedContext) GetCharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GetCharmStateValue implements hooks.Context.
+func (*RestrictedContext) GetCharmStateValue(string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// SetCharmStateValue implements hooks.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error {
+	return ErrRestrictedContext
+}
+
+// DeleteCharmStateValue implements hooks.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error {
+	return ErrRestrictedContext
+}
@@ -50,6 +50,7 @@ var baseCommands = map[string]creator{
 	"juju-log" + cmdSuffix:                NewJujuLogCommand,
 	"open-port" + cmdSuffix:               NewOpenPortCommand,
 	"opened-ports" + cmdSuffix:            NewOpenedPortsCommand,
+	"relation-diff" + cmdSuffix:           NewRelationDiffCommand,
 	"relation-get" + cmdSuffix:            NewRelationGetCommand,
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
@@ -68,6 +69,9 @@ var baseCommands = map[string]creator{
 	"pod-spec-set" + cmdSuffix:            NewPodSpecSetCommand,
 	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"state-get" + cmdSuffix:               NewStateGetCommand,
+	"state-set" + cmdSuffix:               NewStateSetCommand,
+	"state-delete" + cmdSuffix:            NewStateDeleteCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -140,9 +144,10 @@ type CmdGetter func(contextId, cmdName string) (cmd.Command, error)
 
 // Jujuc implements the jujuc command in the form required by net/rpc.
 type Jujuc struct {
-	mu     sync.Mutex
-	getCmd CmdGetter
-	token  string
+	mu      sync.Mutex
+	getCmd  CmdGetter
+	token   string
+	observe HookToolObserver
 }
 
 // badReqErrorf returns an error indicating a bad Request.
@@ -195,6 +200,9 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	}
 	resp.Stdout = stdout.Bytes()
 	resp.Stderr = stderr.Bytes()
+	if j.observe != nil {
+		j.observe(req, resp)
+	}
 	return nil
 }
 
@@ -213,8 +221,18 @@ type Server struct {
 // remote command invocations against an appropriate Context. It will not
 // actually do so until Run is called.
 func NewServer(getCmd CmdGetter, socket sockets.Socket, token string) (*Server, error) {
+	return NewObservedServer(getCmd, socket, token, nil)
+}
+
+// HookToolObserver is called with each hook tool request that a Server
+// runs, and the response returned for it.
+type HookToolObserver func(req Request, resp *exec.ExecResponse)
+
+// NewObservedServer creates a Server like NewServer, which also passes
+// every hook tool request it runs to observe, if not nil.
+func NewObservedServer(getCmd CmdGetter, socket sockets.Socket, token string, observe HookToolObserver) (*Server, error) {
 	server := rpc.NewServer()
-	if err := server.Register(&Jujuc{getCmd: getCmd, token: token}); err != nil {
+	if err := server.Register(&Jujuc{getCmd: getCmd, token: token, observe: observe}); err != nil {
 		return nil, err
 	}
 	listener, err := sockets.Listen(socket)
@@ -146,6 +146,38 @@ func (s *ServerSuite) TestHappyPath(c *gc.C) {
 	c.Assert(string(content), gc.Equals, "something")
 }
 
+func (s *ServerSuite) TestObserver(c *gc.C) {
+	var observed []jujuc.Request
+	var codes []int
+	socket := s.osDependentSockPath(c)
+	srv, err := jujuc.NewObservedServer(factory, socket, "", func(req jujuc.Request, resp *exec.ExecResponse) {
+		observed = append(observed, req)
+		codes = append(codes, resp.Code)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error)
+	go func() { done <- srv.Run() }()
+
+	client, err := sockets.Dial(socket)
+	c.Assert(err, jc.ErrorIsNil)
+	var resp exec.ExecResponse
+	err = client.Call("Jujuc.Main", jujuc.Request{
+		ContextId:   "validCtx",
+		Dir:         c.MkDir(),
+		CommandName: "remote",
+		Args:        []string{"--value", "error"},
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	client.Close()
+	srv.Close()
+	c.Assert(<-done, jc.ErrorIsNil)
+
+	c.Assert(observed, gc.HasLen, 1)
+	c.Assert(observed[0].CommandName, gc.Equals, "remote")
+	c.Assert(observed[0].Args, jc.DeepEquals, []string{"--value", "error"})
+	c.Assert(codes, jc.DeepEquals, []int{1})
+}
+
 func (s *ServerSuite) TestNoStdin(c *gc.C) {
 	dir := c.MkDir()
 	_, err := s.Call(c, jujuc.Request{
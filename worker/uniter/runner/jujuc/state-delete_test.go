@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateDeleteSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateDeleteSuite{})
+
+func (s *stateDeleteSuite) createCommand(c *gc.C) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.State = map[string]string{"one": "1", "two": "2"}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *stateDeleteSuite) TestNoArguments(c *gc.C) {
+	_, com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no keys specified\n")
+}
+
+func (s *stateDeleteSuite) TestDelete(c *gc.C) {
+	hctx, com := s.createCommand(c)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one", "three"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.State, jc.DeepEquals, map[string]string{"two": "2"})
+	s.Stub.CheckCall(c, 0, "DeleteCharmStateValue", "one")
+	s.Stub.CheckCall(c, 1, "DeleteCharmStateValue", "three")
+}
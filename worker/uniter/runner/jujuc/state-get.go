@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// stateGetCommand implements the state-get command.
+type stateGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	key string
+}
+
+// NewStateGetCommand returns a state-get command.
+func NewStateGetCommand(ctx Context) (cmd.Command, error) {
+	return &stateGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateGetCommand) Info() *cmd.Info {
+	doc := `
+state-get prints the value of the unit's charm state specified by key.
+If no key is given, or if the key is "-", all keys and values will be
+printed. The state is kept by the controller and survives the unit's
+agent being restarted or the unit's model being migrated.
+
+See also:
+    state-delete
+    state-set
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "state-get",
+		Args:    "[<key>]",
+		Purpose: "print unit charm state",
+		Doc:     doc,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *stateGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateGetCommand) Init(args []string) error {
+	c.key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	if args[0] != "-" {
+		c.key = args[0]
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateGetCommand) Run(ctx *cmd.Context) error {
+	if c.key == "" {
+		state, err := c.ctx.GetCharmState()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, state)
+	}
+	value, err := c.ctx.GetCharmStateValue(c.key)
+	if errors.IsNotFound(err) {
+		return c.out.Write(ctx, nil)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, value)
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateGetSuite{})
+
+func (s *stateGetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.State = map[string]string{
+		"one":   "1",
+		"two":   "2",
+		"multi": "line\nvalue",
+	}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *stateGetSuite) TestInitExtraArgs(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	err := cmdtesting.InitCommand(com, []string{"one", "two"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *stateGetSuite) TestKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "1\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "GetCharmStateValue", "one")
+}
+
+func (s *stateGetSuite) TestMissingKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"three"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *stateGetSuite) TestAllYAML(c *gc.C) {
+	for _, args := range [][]string{
+		{"--format", "yaml"},
+		{"--format", "yaml", "-"},
+	} {
+		_, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, `
+multi: |-
+  line
+  value
+one: "1"
+two: "2"
+`[1:])
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+}
+
+func (s *stateGetSuite) TestAllJSON(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "json"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `{"multi":"line\nvalue","one":"1","two":"2"}`+"\n")
+}
+
+func (s *stateGetSuite) TestError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR boom\n")
+}
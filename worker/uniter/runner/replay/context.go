@@ -0,0 +1,706 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charm.v6/hooks"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Changes records what a replayed hook asked hook tools to change.
+// Settings and state are recorded as they stand once the hook has run.
+type Changes struct {
+	UnitStatus        *Status                   `json:"unit-status,omitempty" yaml:"unit-status,omitempty"`
+	ApplicationStatus *Status                   `json:"application-status,omitempty" yaml:"application-status,omitempty"`
+	WorkloadVersion   string                    `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
+	LeaderSettings    map[string]string         `json:"leader-settings,omitempty" yaml:"leader-settings,omitempty"`
+	RelationSettings  map[int]map[string]string `json:"relation-settings,omitempty" yaml:"relation-settings,omitempty"`
+	RelationStatus    map[int]string            `json:"relation-status,omitempty" yaml:"relation-status,omitempty"`
+	State             map[string]string         `json:"state,omitempty" yaml:"state,omitempty"`
+	OpenedPorts       []string                  `json:"opened-ports,omitempty" yaml:"opened-ports,omitempty"`
+	Metrics           []Metric                  `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+	Reboot            string                    `json:"reboot,omitempty" yaml:"reboot,omitempty"`
+}
+
+// Metric records a metric added by a replayed hook.
+type Metric struct {
+	Key    string            `json:"key" yaml:"key"`
+	Value  string            `json:"value" yaml:"value"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// Context is a jujuc.Context that serves hook tools from a ContextFile
+// rather than from a controller. Changes requested by hook tools are
+// applied to the context, so later hook tools see them, and recorded.
+type Context struct {
+	mu      sync.Mutex
+	file    ContextFile
+	changes Changes
+
+	relations map[int]*contextRelation
+	ports     []network.PortRange
+	portsSet  bool
+	stateSet  bool
+}
+
+var _ jujuc.Context = (*Context)(nil)
+
+// NewContext returns a Context serving the given context file, which
+// must be valid.
+func NewContext(file ContextFile) (*Context, error) {
+	if err := file.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Hook tools change the context, so
+	// don't share the caller's maps.
+	file.Context.State = copySettings(file.Context.State)
+	file.Context.LeaderSettings = copySettings(file.Context.LeaderSettings)
+	ctx := &Context{
+		file:      file,
+		relations: make(map[int]*contextRelation),
+	}
+	for _, snapshot := range file.Context.Relations {
+		ctx.relations[snapshot.Id] = newContextRelation(ctx, snapshot)
+	}
+	for _, ports := range file.Context.OpenedPorts {
+		portRange, err := network.ParsePortRange(ports)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctx.ports = append(ctx.ports, portRange)
+	}
+	network.SortPortRanges(ctx.ports)
+	return ctx, nil
+}
+
+// Changes returns the changes requested by hook tools so far.
+func (ctx *Context) Changes() Changes {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	changes := ctx.changes
+	if ctx.stateSet {
+		changes.State = copySettings(ctx.file.Context.State)
+	}
+	if ctx.portsSet {
+		changes.OpenedPorts = make([]string, len(ctx.ports))
+		for i, portRange := range ctx.ports {
+			changes.OpenedPorts[i] = portRange.String()
+		}
+	}
+	for id, r := range ctx.relations {
+		if !r.changed {
+			continue
+		}
+		if changes.RelationSettings == nil {
+			changes.RelationSettings = make(map[int]map[string]string)
+		}
+		changes.RelationSettings[id] = copySettings(r.local)
+	}
+	return changes
+}
+
+// UnitName is part of the jujuc.ContextUnit interface.
+func (ctx *Context) UnitName() string {
+	return ctx.file.Unit
+}
+
+// ConfigSettings is part of the jujuc.ContextUnit interface.
+func (ctx *Context) ConfigSettings() (charm.Settings, error) {
+	settings := make(charm.Settings)
+	for k, v := range ctx.file.Context.Config {
+		settings[k] = v
+	}
+	return settings, nil
+}
+
+// GoalState is part of the jujuc.ContextUnit interface.
+func (ctx *Context) GoalState() (*application.GoalState, error) {
+	return nil, errors.NotSupportedf("replaying goal-state")
+}
+
+// SetPodSpec is part of the jujuc.ContextUnit interface.
+func (ctx *Context) SetPodSpec(specYaml string) error {
+	return errors.NotSupportedf("replaying pod-spec-set")
+}
+
+// CloudSpec is part of the jujuc.ContextUnit interface.
+func (ctx *Context) CloudSpec() (*params.CloudSpec, error) {
+	return nil, errors.NotSupportedf("replaying credential-get")
+}
+
+// UnitStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) UnitStatus() (*jujuc.StatusInfo, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	info := statusInfo(ctx.file.Context.UnitStatus)
+	info.Tag = names.NewUnitTag(ctx.file.Unit).String()
+	return &info, nil
+}
+
+// SetUnitStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) SetUnitStatus(info jujuc.StatusInfo) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	status := newStatus(info)
+	ctx.file.Context.UnitStatus = status
+	ctx.changes.UnitStatus = status
+	return nil
+}
+
+// ApplicationStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) ApplicationStatus() (jujuc.ApplicationStatusInfo, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.file.Context.Leader {
+		return jujuc.ApplicationStatusInfo{}, errors.New("this unit is not the leader")
+	}
+	appName, _ := names.UnitApplication(ctx.file.Unit)
+	info := jujuc.ApplicationStatusInfo{
+		Application: statusInfo(ctx.file.Context.ApplicationStatus),
+		Units:       []jujuc.StatusInfo{statusInfo(ctx.file.Context.UnitStatus)},
+	}
+	info.Application.Tag = names.NewApplicationTag(appName).String()
+	info.Units[0].Tag = names.NewUnitTag(ctx.file.Unit).String()
+	return info, nil
+}
+
+// SetApplicationStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) SetApplicationStatus(info jujuc.StatusInfo) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.file.Context.Leader {
+		return errors.New("this unit is not the leader")
+	}
+	status := newStatus(info)
+	ctx.file.Context.ApplicationStatus = status
+	ctx.changes.ApplicationStatus = status
+	return nil
+}
+
+// AvailabilityZone is part of the jujuc.ContextInstance interface.
+func (ctx *Context) AvailabilityZone() (string, error) {
+	if ctx.file.Context.AvailabilityZone == "" {
+		return "", errors.NotFoundf("availability zone")
+	}
+	return ctx.file.Context.AvailabilityZone, nil
+}
+
+// RequestReboot is part of the jujuc.ContextInstance interface.
+func (ctx *Context) RequestReboot(priority jujuc.RebootPriority) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	switch priority {
+	case jujuc.RebootAfterHook:
+		ctx.changes.Reboot = "after-hook"
+	case jujuc.RebootNow:
+		ctx.changes.Reboot = "now"
+	}
+	return nil
+}
+
+// PublicAddress is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) PublicAddress() (string, error) {
+	if ctx.file.Context.PublicAddress == "" {
+		return "", errors.NotFoundf("public address")
+	}
+	return ctx.file.Context.PublicAddress, nil
+}
+
+// PrivateAddress is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) PrivateAddress() (string, error) {
+	if ctx.file.Context.PrivateAddress == "" {
+		return "", errors.NotFoundf("private address")
+	}
+	return ctx.file.Context.PrivateAddress, nil
+}
+
+// OpenPortsForEndpoint is part of the jujuc.ContextNetworking interface.
+// Replayed hooks do not track endpoints, so this is the same as OpenPorts.
+func (ctx *Context) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ctx.OpenPorts(protocol, fromPort, toPort)
+}
+
+// OpenPorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) OpenPorts(protocol string, fromPort, toPort int) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	newRange := network.PortRange{Protocol: protocol, FromPort: fromPort, ToPort: toPort}
+	if err := newRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, portRange := range ctx.ports {
+		if portRange == newRange {
+			return nil
+		}
+		if portRange.ConflictsWith(newRange) {
+			return errors.Errorf("cannot open %v (unit %q): conflicts with existing %v", newRange, ctx.file.Unit, portRange)
+		}
+	}
+	ctx.ports = append(ctx.ports, newRange)
+	network.SortPortRanges(ctx.ports)
+	ctx.portsSet = true
+	return nil
+}
+
+// ClosePorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) ClosePorts(protocol string, fromPort, toPort int) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	oldRange := network.PortRange{Protocol: protocol, FromPort: fromPort, ToPort: toPort}
+	if err := oldRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for i, portRange := range ctx.ports {
+		if portRange == oldRange {
+			ctx.ports = append(ctx.ports[:i], ctx.ports[i+1:]...)
+			ctx.portsSet = true
+			return nil
+		}
+	}
+	return nil
+}
+
+// OpenedPorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) OpenedPorts() []network.PortRange {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return append([]network.PortRange(nil), ctx.ports...)
+}
+
+// NetworkInfo is part of the jujuc.ContextNetworking interface. The
+// same results are returned whatever relation is asked about.
+func (ctx *Context) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
+	results := make(map[string]params.NetworkInfoResult)
+	for _, name := range bindingNames {
+		if result, ok := ctx.file.Context.Network[name]; ok {
+			results[name] = result
+		}
+	}
+	return results, nil
+}
+
+// IsLeader is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) IsLeader() (bool, error) {
+	return ctx.file.Context.Leader, nil
+}
+
+// LeaderSettings is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) LeaderSettings() (map[string]string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return copySettings(ctx.file.Context.LeaderSettings), nil
+}
+
+// WriteLeaderSettings is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) WriteLeaderSettings(settings map[string]string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.file.Context.Leader {
+		return errors.New("this unit is not the leader")
+	}
+	merged := copySettings(ctx.file.Context.LeaderSettings)
+	if merged == nil {
+		merged = make(map[string]string)
+	}
+	for k, v := range settings {
+		if v == "" {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+	ctx.file.Context.LeaderSettings = merged
+	ctx.changes.LeaderSettings = copySettings(merged)
+	return nil
+}
+
+// AddMetric is part of the jujuc.ContextMetrics interface.
+func (ctx *Context) AddMetric(key, value string, created time.Time) error {
+	return ctx.AddMetricLabels(key, value, created, nil)
+}
+
+// AddMetricLabels is part of the jujuc.ContextMetrics interface.
+func (ctx *Context) AddMetricLabels(key, value string, created time.Time, labels map[string]string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.changes.Metrics = append(ctx.changes.Metrics, Metric{
+		Key:    key,
+		Value:  value,
+		Labels: labels,
+	})
+	return nil
+}
+
+// StorageTags is part of the jujuc.ContextStorage interface.
+func (ctx *Context) StorageTags() ([]names.StorageTag, error) {
+	var tags []names.StorageTag
+	for _, snapshot := range ctx.file.Context.Storage {
+		tags = append(tags, names.NewStorageTag(snapshot.Id))
+	}
+	return tags, nil
+}
+
+// Storage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) Storage(tag names.StorageTag) (jujuc.ContextStorageAttachment, error) {
+	for _, snapshot := range ctx.file.Context.Storage {
+		if snapshot.Id != tag.Id() {
+			continue
+		}
+		kind, err := parseStorageKind(snapshot.Kind)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &storageAttachment{
+			tag:      tag,
+			kind:     kind,
+			location: snapshot.Location,
+		}, nil
+	}
+	return nil, errors.NotFoundf("storage %q", tag.Id())
+}
+
+// HookStorage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) HookStorage() (jujuc.ContextStorageAttachment, error) {
+	if !isStorageHook(ctx.file.Hook) {
+		return nil, errors.NotFoundf("hook storage")
+	}
+	return ctx.Storage(names.NewStorageTag(ctx.file.Context.StorageId))
+}
+
+// AddUnitStorage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) AddUnitStorage(map[string]params.StorageConstraints) error {
+	return errors.NotSupportedf("replaying storage-add")
+}
+
+// Component is part of the jujuc.ContextComponents interface.
+func (ctx *Context) Component(name string) (jujuc.ContextComponent, error) {
+	return nil, errors.NotFoundf("context component %q", name)
+}
+
+// Relation is part of the jujuc.ContextRelations interface.
+func (ctx *Context) Relation(id int) (jujuc.ContextRelation, error) {
+	r, ok := ctx.relations[id]
+	if !ok {
+		return nil, errors.NotFoundf("relation")
+	}
+	return r, nil
+}
+
+// RelationIds is part of the jujuc.ContextRelations interface.
+func (ctx *Context) RelationIds() ([]int, error) {
+	ids := make([]int, 0, len(ctx.relations))
+	for id := range ctx.relations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// HookRelation is part of the jujuc.RelationHookContext interface.
+func (ctx *Context) HookRelation() (jujuc.ContextRelation, error) {
+	if !isRelationHook(ctx.file.Hook) {
+		return nil, errors.NotFoundf("hook relation")
+	}
+	return ctx.Relation(ctx.file.Context.RelationId)
+}
+
+// RemoteUnitName is part of the jujuc.RelationHookContext interface.
+func (ctx *Context) RemoteUnitName() (string, error) {
+	if ctx.file.Context.RemoteUnit == "" {
+		return "", errors.NotFoundf("remote unit")
+	}
+	return ctx.file.Context.RemoteUnit, nil
+}
+
+// RemoteSettingsDiff is part of the jujuc.RelationHookContext interface.
+func (ctx *Context) RemoteSettingsDiff() (*jujuc.SettingsDiff, error) {
+	hook := ctx.file.Hook
+	if !strings.HasSuffix(hook, "-"+string(hooks.RelationJoined)) &&
+		!strings.HasSuffix(hook, "-"+string(hooks.RelationChanged)) {
+		return nil, errors.NotFoundf("remote settings diff")
+	}
+	remoteUnit := ctx.file.Context.RemoteUnit
+	if remoteUnit == "" {
+		return nil, errors.NotFoundf("remote settings diff")
+	}
+	r := ctx.relations[ctx.file.Context.RelationId]
+	return jujuc.NewSettingsDiff(ctx.file.Context.RemoteUnitPrevious, r.units[remoteUnit]), nil
+}
+
+// UnitWorkloadVersion is part of the jujuc.ContextVersion interface.
+func (ctx *Context) UnitWorkloadVersion() (string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.file.Context.WorkloadVersion, nil
+}
+
+// SetUnitWorkloadVersion is part of the jujuc.ContextVersion interface.
+func (ctx *Context) SetUnitWorkloadVersion(version string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.file.Context.WorkloadVersion = version
+	ctx.changes.WorkloadVersion = version
+	return nil
+}
+
+// GetCharmState is part of the jujuc.ContextUnitCharmState interface.
+func (ctx *Context) GetCharmState() (map[string]string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	state := copySettings(ctx.file.Context.State)
+	if state == nil {
+		state = make(map[string]string)
+	}
+	return state, nil
+}
+
+// GetCharmStateValue is part of the jujuc.ContextUnitCharmState interface.
+func (ctx *Context) GetCharmStateValue(key string) (string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	value, ok := ctx.file.Context.State[key]
+	if !ok {
+		return "", errors.NotFoundf("%q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue is part of the jujuc.ContextUnitCharmState interface.
+func (ctx *Context) SetCharmStateValue(key, value string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.file.Context.State == nil {
+		ctx.file.Context.State = make(map[string]string)
+	}
+	ctx.file.Context.State[key] = value
+	ctx.stateSet = true
+	return nil
+}
+
+// DeleteCharmStateValue is part of the jujuc.ContextUnitCharmState interface.
+func (ctx *Context) DeleteCharmStateValue(key string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	delete(ctx.file.Context.State, key)
+	ctx.stateSet = true
+	return nil
+}
+
+// ActionParams is part of the jujuc.ActionHookContext interface.
+func (ctx *Context) ActionParams() (map[string]interface{}, error) {
+	return nil, errors.New("not running an action")
+}
+
+// UpdateActionResults is part of the jujuc.ActionHookContext interface.
+func (ctx *Context) UpdateActionResults(keys []string, value string) error {
+	return errors.New("not running an action")
+}
+
+// SetActionMessage is part of the jujuc.ActionHookContext interface.
+func (ctx *Context) SetActionMessage(string) error {
+	return errors.New("not running an action")
+}
+
+// SetActionFailed is part of the jujuc.ActionHookContext interface.
+func (ctx *Context) SetActionFailed() error {
+	return errors.New("not running an action")
+}
+
+// LogActionMessage is part of the jujuc.ActionHookContext interface.
+func (ctx *Context) LogActionMessage(string) error {
+	return errors.New("not running an action")
+}
+
+// contextRelation is a jujuc.ContextRelation backed by a relation
+// snapshot.
+type contextRelation struct {
+	ctx      *Context
+	id       int
+	endpoint string
+	units    map[string]map[string]string
+	local    map[string]string
+	changed  bool
+}
+
+func newContextRelation(ctx *Context, snapshot debug.RelationSnapshot) *contextRelation {
+	local := copySettings(snapshot.Local)
+	if local == nil {
+		local = make(map[string]string)
+	}
+	return &contextRelation{
+		ctx:      ctx,
+		id:       snapshot.Id,
+		endpoint: snapshot.Endpoint,
+		units:    snapshot.Units,
+		local:    local,
+	}
+}
+
+// Id is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) Id() int {
+	return r.id
+}
+
+// Name is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) Name() string {
+	return r.endpoint
+}
+
+// FakeId is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) FakeId() string {
+	return fmt.Sprintf("%s:%d", r.endpoint, r.id)
+}
+
+// Settings is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) Settings() (jujuc.Settings, error) {
+	return relationSettings{r}, nil
+}
+
+// UnitNames is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) UnitNames() []string {
+	unitNames := make([]string, 0, len(r.units))
+	for unitName := range r.units {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	return unitNames
+}
+
+// ReadSettings is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) ReadSettings(unit string) (params.Settings, error) {
+	r.ctx.mu.Lock()
+	defer r.ctx.mu.Unlock()
+	if unit == r.ctx.file.Unit {
+		return params.Settings(copySettings(r.local)), nil
+	}
+	settings, ok := r.units[unit]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q in relation %d", unit, r.id)
+	}
+	return params.Settings(copySettings(settings)), nil
+}
+
+// Suspended is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) Suspended() bool {
+	return false
+}
+
+// SetStatus is part of the jujuc.ContextRelation interface.
+func (r *contextRelation) SetStatus(status relation.Status) error {
+	r.ctx.mu.Lock()
+	defer r.ctx.mu.Unlock()
+	if r.ctx.changes.RelationStatus == nil {
+		r.ctx.changes.RelationStatus = make(map[int]string)
+	}
+	r.ctx.changes.RelationStatus[r.id] = string(status)
+	return nil
+}
+
+// relationSettings is a jujuc.Settings giving access to the local
+// unit's settings in a relation.
+type relationSettings struct {
+	r *contextRelation
+}
+
+// Map is part of the jujuc.Settings interface.
+func (s relationSettings) Map() params.Settings {
+	s.r.ctx.mu.Lock()
+	defer s.r.ctx.mu.Unlock()
+	return params.Settings(copySettings(s.r.local))
+}
+
+// Set is part of the jujuc.Settings interface.
+func (s relationSettings) Set(key, value string) {
+	s.r.ctx.mu.Lock()
+	defer s.r.ctx.mu.Unlock()
+	s.r.local[key] = value
+	s.r.changed = true
+}
+
+// Delete is part of the jujuc.Settings interface.
+func (s relationSettings) Delete(key string) {
+	s.r.ctx.mu.Lock()
+	defer s.r.ctx.mu.Unlock()
+	delete(s.r.local, key)
+	s.r.changed = true
+}
+
+// storageAttachment is a jujuc.ContextStorageAttachment backed by a
+// storage snapshot.
+type storageAttachment struct {
+	tag      names.StorageTag
+	kind     storage.StorageKind
+	location string
+}
+
+// Tag is part of the jujuc.ContextStorageAttachment interface.
+func (s *storageAttachment) Tag() names.StorageTag {
+	return s.tag
+}
+
+// Kind is part of the jujuc.ContextStorageAttachment interface.
+func (s *storageAttachment) Kind() storage.StorageKind {
+	return s.kind
+}
+
+// Location is part of the jujuc.ContextStorageAttachment interface.
+func (s *storageAttachment) Location() string {
+	return s.location
+}
+
+func parseStorageKind(kind string) (storage.StorageKind, error) {
+	switch kind {
+	case storage.StorageKindBlock.String():
+		return storage.StorageKindBlock, nil
+	case storage.StorageKindFilesystem.String():
+		return storage.StorageKindFilesystem, nil
+	}
+	return storage.StorageKindUnknown, errors.NotValidf("storage kind %q", kind)
+}
+
+func statusInfo(status *Status) jujuc.StatusInfo {
+	if status == nil {
+		return jujuc.StatusInfo{Status: "unknown"}
+	}
+	return jujuc.StatusInfo{
+		Status: status.Status,
+		Info:   status.Info,
+		Data:   status.Data,
+	}
+}
+
+func newStatus(info jujuc.StatusInfo) *Status {
+	return &Status{
+		Status: info.Status,
+		Info:   info.Info,
+		Data:   info.Data,
+	}
+}
+
+func copySettings(settings map[string]string) map[string]string {
+	if settings == nil {
+		return nil
+	}
+	result := make(map[string]string, len(settings))
+	for k, v := range settings {
+		result[k] = v
+	}
+	return result
+}
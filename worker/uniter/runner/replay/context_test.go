@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ContextSuite struct {
+	file replay.ContextFile
+}
+
+var _ = gc.Suite(&ContextSuite{})
+
+func (s *ContextSuite) SetUpTest(c *gc.C) {
+	s.file = replay.ContextFile{}
+	err := goyaml.Unmarshal([]byte(contextYAML), &s.file)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ContextSuite) newContext(c *gc.C) *replay.Context {
+	ctx, err := replay.NewContext(s.file)
+	c.Assert(err, jc.ErrorIsNil)
+	return ctx
+}
+
+// run runs the named hook tool in ctx, and returns its output.
+func run(c *gc.C, ctx jujuc.Context, name string, args ...string) (string, error) {
+	command, err := jujuc.NewCommand(ctx, name)
+	c.Assert(err, jc.ErrorIsNil)
+	cmdCtx, err := cmdtesting.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(cmdCtx), nil
+}
+
+func (s *ContextSuite) TestReadTools(c *gc.C) {
+	ctx := s.newContext(c)
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{
+		{[]string{"config-get", "port"}, "3306\n"},
+		{[]string{"is-leader"}, "True\n"},
+		{[]string{"leader-get", "password"}, "secret\n"},
+		{[]string{"unit-get", "private-address"}, "10.0.0.1\n"},
+		{[]string{"relation-ids", "db"}, "db:3\n"},
+		{[]string{"relation-list"}, "wordpress/0\n"},
+		{[]string{"relation-get", "private-address"}, "10.0.0.2\n"},
+		{[]string{"relation-get", "user", "mysql/0"}, "admin\n"},
+		{[]string{"network-get", "db", "--ingress-address"}, "10.0.0.1\n"},
+		{[]string{"storage-get", "-s", "data/0", "location"}, "/srv/data\n"},
+		{[]string{"state-get", "initialised"}, "true\n"},
+		{[]string{"opened-ports"}, "3306/tcp\n"},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		out, err := run(c, ctx, test.args[0], test.args[1:]...)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(out, gc.Equals, test.expect)
+	}
+}
+
+func (s *ContextSuite) TestChanges(c *gc.C) {
+	ctx := s.newContext(c)
+	for _, args := range [][]string{
+		{"relation-set", "database=mysql", "user="},
+		{"leader-set", "password=changed"},
+		{"state-set", "initialised=false", "schema=2"},
+		{"open-port", "80"},
+		{"close-port", "3306"},
+		{"status-set", "active", "ready"},
+		{"application-version-set", "8.0"},
+	} {
+		_, err := run(c, ctx, args[0], args[1:]...)
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("%v", args))
+	}
+
+	// Later hook tools see the changes.
+	out, err := run(c, ctx, "relation-get", "-", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "database: mysql\n")
+
+	c.Assert(ctx.Changes(), jc.DeepEquals, replay.Changes{
+		UnitStatus:       &replay.Status{Status: "active", Info: "ready"},
+		WorkloadVersion:  "8.0",
+		LeaderSettings:   map[string]string{"password": "changed"},
+		RelationSettings: map[int]map[string]string{3: {"database": "mysql"}},
+		State:            map[string]string{"initialised": "false", "schema": "2"},
+		OpenedPorts:      []string{"80/tcp"},
+	})
+	// The context file is left alone.
+	c.Assert(s.file.Context.State, jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *ContextSuite) TestNoChanges(c *gc.C) {
+	ctx := s.newContext(c)
+	_, err := run(c, ctx, "config-get")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.Changes(), jc.DeepEquals, replay.Changes{})
+}
+
+func (s *ContextSuite) TestLeaderSetNotLeader(c *gc.C) {
+	s.file.Context.Leader = false
+	ctx := s.newContext(c)
+	_, err := run(c, ctx, "leader-set", "password=changed")
+	c.Assert(err, gc.ErrorMatches, ".*this unit is not the leader")
+	c.Assert(ctx.Changes(), jc.DeepEquals, replay.Changes{})
+}
+
+func (s *ContextSuite) TestHookRelation(c *gc.C) {
+	ctx := s.newContext(c)
+	relation, err := ctx.HookRelation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.FakeId(), gc.Equals, "db:3")
+
+	s.file.Hook = "config-changed"
+	ctx = s.newContext(c)
+	_, err = ctx.HookRelation()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ContextSuite) TestRelationDiff(c *gc.C) {
+	s.file.Context.RemoteUnitPrevious = map[string]string{
+		"private-address": "10.0.0.9",
+		"port":            "80",
+	}
+	ctx := s.newContext(c)
+	out, err := run(c, ctx, "relation-diff", "--keys")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "- port\n- private-address\n")
+
+	out, err = run(c, ctx, "relation-diff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+changed:
+  private-address:
+    old: 10.0.0.9
+    new: 10.0.0.2
+removed:
+  port: "80"
+`[1:])
+}
+
+func (s *ContextSuite) TestHookStorage(c *gc.C) {
+	s.file.Hook = "data-storage-attached"
+	s.file.Context.StorageId = "data/0"
+	ctx := s.newContext(c)
+	out, err := run(c, ctx, "storage-get", "kind")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "filesystem\n")
+}
+
+func (s *ContextSuite) TestNotSupported(c *gc.C) {
+	ctx := s.newContext(c)
+	_, err := run(c, ctx, "goal-state")
+	c.Assert(err, gc.ErrorMatches, "replaying goal-state not supported")
+	_, err = run(c, ctx, "credential-get")
+	c.Assert(err, gc.ErrorMatches, ".*replaying credential-get not supported")
+}
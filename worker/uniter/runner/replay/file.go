@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6/hooks"
+	"gopkg.in/juju/names.v3"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+// CaptureFilename is the name of the file in a "juju debug-hooks
+// --capture" directory that holds the captured hook context.
+const CaptureFilename = "capture.yaml"
+
+// ContextFile describes the context in which to replay a hook. Its
+// layout is that of the capture.yaml file written by "juju debug-hooks
+// --capture", so that captured hooks can be replayed as they are, and
+// hand-written files can add the replay-only fields to the context.
+type ContextFile struct {
+	Unit    string      `yaml:"unit"`
+	Hook    string      `yaml:"hook"`
+	Context ContextData `yaml:"context"`
+}
+
+// ContextData holds everything that hook tools can read in a replayed
+// hook. Hook tools that need anything else fail with a NotSupported
+// error.
+type ContextData struct {
+	debug.ContextSnapshot `yaml:",inline"`
+
+	// RemoteUnitPrevious holds the remote unit's settings as the unit
+	// last saw them, from which relation-diff reports changes. If it
+	// is not set, all the remote unit's settings are reported as added.
+	RemoteUnitPrevious map[string]string `yaml:"remote-unit-previous,omitempty"`
+
+	// StorageId identifies the storage attachment of a storage hook.
+	StorageId string `yaml:"storage-id,omitempty"`
+
+	// AvailabilityZone is the unit's availability zone.
+	AvailabilityZone string `yaml:"availability-zone,omitempty"`
+
+	// Network holds the results returned by network-get, keyed by
+	// binding name, in the format network-get writes them.
+	Network map[string]params.NetworkInfoResult `yaml:"network,omitempty"`
+
+	// State holds the unit's charm state.
+	State map[string]string `yaml:"state,omitempty"`
+
+	// OpenedPorts holds the port ranges opened by the unit,
+	// eg "80/tcp" or "1000-2000/udp".
+	OpenedPorts []string `yaml:"opened-ports,omitempty"`
+
+	// UnitStatus and ApplicationStatus are returned by status-get.
+	UnitStatus        *Status `yaml:"unit-status,omitempty"`
+	ApplicationStatus *Status `yaml:"application-status,omitempty"`
+
+	// WorkloadVersion is the unit's workload version.
+	WorkloadVersion string `yaml:"workload-version,omitempty"`
+}
+
+// Status describes a unit or application status.
+type Status struct {
+	Status string                 `json:"status" yaml:"status"`
+	Info   string                 `json:"message,omitempty" yaml:"message,omitempty"`
+	Data   map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// ReadContextFile reads the context file at the given path. If path
+// is a directory, it is taken to be a hook capture directory and the
+// context is read from the capture.yaml file within it.
+func ReadContextFile(path string) (*ContextFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, CaptureFilename)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var file ContextFile
+	if err := goyaml.Unmarshal(data, &file); err != nil {
+		return nil, errors.Annotatef(err, "parsing %q", path)
+	}
+	if err := file.Validate(); err != nil {
+		return nil, errors.Annotatef(err, "invalid context file %q", path)
+	}
+	return &file, nil
+}
+
+// Validate returns an error if the context file is not consistent.
+func (f *ContextFile) Validate() error {
+	if !names.IsValidUnit(f.Unit) {
+		return errors.NotValidf("unit name %q", f.Unit)
+	}
+	if f.Hook == "" {
+		return errors.NotValidf("empty hook name")
+	}
+	relationIds := make(map[int]bool)
+	for _, relation := range f.Context.Relations {
+		if relationIds[relation.Id] {
+			return errors.NotValidf("duplicate relation id %d", relation.Id)
+		}
+		relationIds[relation.Id] = true
+		if relation.Endpoint == "" {
+			return errors.NotValidf("relation %d with no endpoint", relation.Id)
+		}
+	}
+	storageIds := make(map[string]bool)
+	for _, storage := range f.Context.Storage {
+		if !names.IsValidStorage(storage.Id) {
+			return errors.NotValidf("storage id %q", storage.Id)
+		}
+		if _, err := parseStorageKind(storage.Kind); err != nil {
+			return errors.Annotatef(err, "storage %q", storage.Id)
+		}
+		storageIds[storage.Id] = true
+	}
+	for _, ports := range f.Context.OpenedPorts {
+		if _, err := network.ParsePortRange(ports); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if isRelationHook(f.Hook) && !relationIds[f.Context.RelationId] {
+		return errors.NotValidf("relation hook %q with unknown relation id %d", f.Hook, f.Context.RelationId)
+	}
+	if isStorageHook(f.Hook) && !storageIds[f.Context.StorageId] {
+		return errors.NotValidf("storage hook %q with unknown storage id %q", f.Hook, f.Context.StorageId)
+	}
+	return nil
+}
+
+// isRelationHook returns whether the named hook is run for a relation.
+func isRelationHook(hookName string) bool {
+	for _, kind := range []hooks.Kind{
+		hooks.RelationJoined,
+		hooks.RelationChanged,
+		hooks.RelationDeparted,
+		hooks.RelationBroken,
+	} {
+		if strings.HasSuffix(hookName, "-"+string(kind)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStorageHook returns whether the named hook is run for storage.
+func isStorageHook(hookName string) bool {
+	return strings.HasSuffix(hookName, "-"+string(hooks.StorageAttached)) ||
+		strings.HasSuffix(hookName, "-"+string(hooks.StorageDetaching)) ||
+		strings.HasSuffix(hookName, "-"+string(hook.StorageResized)) ||
+		strings.HasSuffix(hookName, "-"+string(hook.StorageMoving))
+}
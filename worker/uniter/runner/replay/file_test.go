@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ContextFileSuite struct{}
+
+var _ = gc.Suite(&ContextFileSuite{})
+
+func (s *ContextFileSuite) TestReadContextFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "context.yaml")
+	err := ioutil.WriteFile(path, []byte(contextYAML), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	file, err := replay.ReadContextFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(file.Unit, gc.Equals, "mysql/0")
+	c.Assert(file.Hook, gc.Equals, "db-relation-changed")
+	c.Assert(file.Context.Leader, jc.IsTrue)
+	c.Assert(file.Context.Config, jc.DeepEquals, map[string]interface{}{"port": 3306})
+	c.Assert(file.Context.Relations, gc.HasLen, 1)
+	c.Assert(file.Context.Relations[0].Units["wordpress/0"], jc.DeepEquals, map[string]string{
+		"private-address": "10.0.0.2",
+	})
+	c.Assert(file.Context.RelationId, gc.Equals, 3)
+	c.Assert(file.Context.Network["db"].IngressAddresses, jc.DeepEquals, []string{"10.0.0.1"})
+	c.Assert(file.Context.State, jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *ContextFileSuite) TestReadCaptureDir(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	hooksCtx := debug.NewHooksContext("mysql/0")
+	hooksCtx.FlockDir = c.MkDir()
+	script := debug.ClientCaptureScript(hooksCtx, nil)
+	out, err := exec.Command("/bin/bash", "-c", script).CombinedOutput()
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", out))
+	session, err := hooksCtx.FindCaptureSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	started := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	capture := session.NewCapture("db-relation-joined", started)
+	capture.Context = debug.ContextSnapshot{
+		Config: map[string]interface{}{"port": 3306},
+		Relations: []debug.RelationSnapshot{{
+			Id:       0,
+			Endpoint: "db",
+			Units:    map[string]map[string]string{"wordpress/0": {"user": "wp"}},
+		}},
+		RemoteUnit: "wordpress/0",
+	}
+	dir, err := capture.Write(started.Add(time.Second), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	file, err := replay.ReadContextFile(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(file.Unit, gc.Equals, "mysql/0")
+	c.Assert(file.Hook, gc.Equals, "db-relation-joined")
+	c.Assert(file.Context.ContextSnapshot, jc.DeepEquals, capture.Context)
+}
+
+func (s *ContextFileSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		file   replay.ContextFile
+		expect string
+	}{{
+		about:  "bad unit name",
+		file:   replay.ContextFile{Unit: "mysql", Hook: "install"},
+		expect: `unit name "mysql" not valid`,
+	}, {
+		about:  "no hook",
+		file:   replay.ContextFile{Unit: "mysql/0"},
+		expect: `empty hook name not valid`,
+	}, {
+		about: "relation hook for unknown relation",
+		file: replay.ContextFile{
+			Unit: "mysql/0",
+			Hook: "db-relation-changed",
+			Context: replay.ContextData{ContextSnapshot: debug.ContextSnapshot{
+				RelationId: 2,
+				Relations:  []debug.RelationSnapshot{{Id: 1, Endpoint: "db"}},
+			}},
+		},
+		expect: `relation hook "db-relation-changed" with unknown relation id 2 not valid`,
+	}, {
+		about: "storage hook for unknown storage",
+		file: replay.ContextFile{
+			Unit:    "mysql/0",
+			Hook:    "data-storage-attached",
+			Context: replay.ContextData{StorageId: "data/1"},
+		},
+		expect: `storage hook "data-storage-attached" with unknown storage id "data/1" not valid`,
+	}, {
+		about: "bad storage kind",
+		file: replay.ContextFile{
+			Unit: "mysql/0",
+			Hook: "install",
+			Context: replay.ContextData{ContextSnapshot: debug.ContextSnapshot{
+				Storage: []debug.StorageSnapshot{{Id: "data/0", Kind: "tape"}},
+			}},
+		},
+		expect: `storage "data/0": storage kind "tape" not valid`,
+	}, {
+		about: "bad opened ports",
+		file: replay.ContextFile{
+			Unit:    "mysql/0",
+			Hook:    "install",
+			Context: replay.ContextData{OpenedPorts: []string{"80/sctp"}},
+		},
+		expect: `.*invalid protocol "sctp".*`,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(test.file.Validate(), gc.ErrorMatches, test.expect)
+	}
+}
+
+const contextYAML = `
+unit: mysql/0
+hook: db-relation-changed
+context:
+  config:
+    port: 3306
+  leader: true
+  leader-settings:
+    password: secret
+  private-address: 10.0.0.1
+  relations:
+  - id: 3
+    endpoint: db
+    local:
+      user: admin
+    units:
+      wordpress/0:
+        private-address: 10.0.0.2
+  storage:
+  - id: data/0
+    kind: filesystem
+    location: /srv/data
+  relation-id: 3
+  remote-unit: wordpress/0
+  availability-zone: zone-a
+  network:
+    db:
+      bind-addresses:
+      - interfacename: eth0
+        addresses:
+        - address: 10.0.0.1
+          cidr: 10.0.0.0/24
+      ingress-addresses:
+      - 10.0.0.1
+  state:
+    initialised: "true"
+  opened-ports:
+  - 3306/tcp
+`
@@ -0,0 +1,256 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package replay runs charm hooks outside of a unit agent, serving the
+// hook tools they invoke from a context file instead of a controller.
+// This gives charm authors a way to run hooks deterministically, eg in
+// unit tests, with the hook tools behaving as they do on a unit.
+package replay
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	utilexec "github.com/juju/utils/exec"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.runner.replay")
+
+// contextId is the context id given to replayed hooks.
+const contextId = "replay"
+
+// Config holds the information needed to replay a hook.
+type Config struct {
+	// CharmDir is the directory holding the expanded charm.
+	CharmDir string
+
+	// Context is the context in which the hook runs.
+	Context *Context
+
+	// HookToolPath is the executable run for every hook tool. It must
+	// act as a hook tool client when invoked under the tool's name, as
+	// jujud does.
+	HookToolPath string
+
+	// Env holds extra environment variables for the hook, in the form
+	// "key=value". They override the hook's usual variables.
+	Env []string
+
+	// Stdout and Stderr receive the hook's output.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Observe, if not nil, is called with each hook tool invocation.
+	Observe jujuc.HookToolObserver
+}
+
+// Validate returns an error if the config is not valid.
+func (config Config) Validate() error {
+	if config.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if config.Context == nil {
+		return errors.NotValidf("nil Context")
+	}
+	if config.HookToolPath == "" {
+		return errors.NotValidf("empty HookToolPath")
+	}
+	if config.Stdout == nil {
+		return errors.NotValidf("nil Stdout")
+	}
+	if config.Stderr == nil {
+		return errors.NotValidf("nil Stderr")
+	}
+	return nil
+}
+
+// Result describes the outcome of a replayed hook.
+type Result struct {
+	Unit    string  `json:"unit" yaml:"unit"`
+	Hook    string  `json:"hook" yaml:"hook"`
+	Code    int     `json:"code" yaml:"code"`
+	Changes Changes `json:"changes" yaml:"changes"`
+
+	// HookTools holds the hook tools invoked by the hook, in the
+	// format of a capture's hook-tools.yaml file.
+	HookTools []debug.HookToolInvocation `json:"hook-tools,omitempty" yaml:"hook-tools,omitempty"`
+}
+
+// Run runs the context's hook in the charm directory and returns its
+// result. A hook that runs but exits with a non-zero code is not an
+// error; the code is recorded in the result. A charm that does not
+// implement the hook yields an error satisfying
+// charmrunner.IsMissingHookError.
+func Run(config Config) (*Result, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx := config.Context
+	hookName := ctx.file.Hook
+	hookPath := filepath.Join(config.CharmDir, "hooks", hookName)
+	if _, err := os.Stat(hookPath); os.IsNotExist(err) {
+		return nil, charmrunner.NewMissingHookError(hookName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	dir, err := ioutil.TempDir("", "juju-hook-replay")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	toolsDir := filepath.Join(dir, "tools")
+	if err := symlinkHookTools(toolsDir, config.HookToolPath); err != nil {
+		return nil, errors.Annotate(err, "installing hook tools")
+	}
+
+	result := &Result{
+		Unit: ctx.file.Unit,
+		Hook: hookName,
+	}
+	token, err := utils.RandomPassword()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	socket := sockets.Socket{
+		Network: "unix",
+		Address: filepath.Join(dir, "jujuc.socket"),
+	}
+	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
+		if ctxId != contextId {
+			return nil, errors.Errorf("expected context id %q, got %q", contextId, ctxId)
+		}
+		return jujuc.NewCommand(ctx, cmdName)
+	}
+	var mu sync.Mutex
+	observe := func(req jujuc.Request, resp *utilexec.ExecResponse) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.HookTools = append(result.HookTools, debug.HookToolInvocation{
+			Command: req.CommandName,
+			Args:    req.Args,
+			Stdin:   string(req.Stdin),
+			Code:    resp.Code,
+			Stdout:  string(resp.Stdout),
+			Stderr:  string(resp.Stderr),
+		})
+		if config.Observe != nil {
+			config.Observe(req, resp)
+		}
+	}
+	srv, err := jujuc.NewObservedServer(getCmd, socket, token, observe)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	go srv.Run()
+
+	ps := exec.Command(hookPath)
+	ps.Dir = config.CharmDir
+	ps.Env = mergeEnv(os.Environ(), hookVars(config, toolsDir, socket, token), config.Env)
+	ps.Stdout = config.Stdout
+	ps.Stderr = config.Stderr
+	logger.Debugf("replaying %q hook for %q", hookName, ctx.file.Unit)
+	err = ps.Run()
+	// The server must stop before the changes are read, so
+	// that no hook tool left running can still make any.
+	srv.Close()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		result.Code = 1
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			result.Code = status.ExitStatus()
+		}
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "running %q hook", hookName)
+	}
+	result.Changes = ctx.Changes()
+	return result, nil
+}
+
+// hookVars returns the environment variables the unit agent would
+// set for the hook.
+func hookVars(config Config, toolsDir string, socket sockets.Socket, token string) []string {
+	ctx := config.Context
+	file := ctx.file
+	vars := []string{
+		"PATH=" + toolsDir + string(os.PathListSeparator) + os.Getenv("PATH"),
+		"CHARM_DIR=" + config.CharmDir,
+		"JUJU_CHARM_DIR=" + config.CharmDir,
+		"JUJU_CONTEXT_ID=" + contextId,
+		"JUJU_AGENT_SOCKET_ADDRESS=" + socket.Address,
+		"JUJU_AGENT_SOCKET_NETWORK=" + socket.Network,
+		"JUJU_AGENT_TOKEN=" + token,
+		"JUJU_UNIT_NAME=" + file.Unit,
+		"JUJU_HOOK_NAME=" + file.Hook,
+		"JUJU_AVAILABILITY_ZONE=" + file.Context.AvailabilityZone,
+	}
+	if r, err := ctx.HookRelation(); err == nil {
+		vars = append(vars,
+			"JUJU_RELATION="+r.Name(),
+			"JUJU_RELATION_ID="+r.FakeId(),
+			"JUJU_REMOTE_UNIT="+file.Context.RemoteUnit,
+		)
+		if remote := file.Context.RemoteUnit; remote != "" {
+			vars = append(vars, "JUJU_REMOTE_APP="+strings.SplitN(remote, "/", 2)[0])
+		}
+	}
+	return vars
+}
+
+// mergeEnv returns the environment variables in base, with those in
+// each of the overrides replacing any of the same name.
+func mergeEnv(base []string, overrides ...[]string) []string {
+	var names []string
+	values := make(map[string]string)
+	add := func(vars []string) {
+		for _, v := range vars {
+			parts := strings.SplitN(v, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			if _, ok := values[parts[0]]; !ok {
+				names = append(names, parts[0])
+			}
+			values[parts[0]] = parts[1]
+		}
+	}
+	add(base)
+	for _, vars := range overrides {
+		add(vars)
+	}
+	env := make([]string, len(names))
+	for i, name := range names {
+		env[i] = fmt.Sprintf("%s=%s", name, values[name])
+	}
+	return env
+}
+
+// symlinkHookTools creates dir, holding a symlink to target for every
+// hook tool.
+func symlinkHookTools(dir, target string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range jujuc.CommandNames() {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type RunSuite struct {
+	charmDir string
+	ctx      *replay.Context
+	stdout   bytes.Buffer
+	stderr   bytes.Buffer
+}
+
+var _ = gc.Suite(&RunSuite{})
+
+func (s *RunSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook replay uses unix sockets and shell hooks")
+	}
+	s.charmDir = c.MkDir()
+	err := os.Mkdir(filepath.Join(s.charmDir, "hooks"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var file replay.ContextFile
+	err = goyaml.Unmarshal([]byte(contextYAML), &file)
+	c.Assert(err, jc.ErrorIsNil)
+	s.ctx, err = replay.NewContext(file)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stdout.Reset()
+	s.stderr.Reset()
+}
+
+func (s *RunSuite) writeHook(c *gc.C, name, script string) {
+	path := filepath.Join(s.charmDir, "hooks", name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/bash\n"+script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RunSuite) config() replay.Config {
+	return replay.Config{
+		CharmDir:     s.charmDir,
+		Context:      s.ctx,
+		HookToolPath: "/bin/true",
+		Env:          []string{"EXTRA=extra"},
+		Stdout:       &s.stdout,
+		Stderr:       &s.stderr,
+	}
+}
+
+func (s *RunSuite) TestRun(c *gc.C) {
+	s.writeHook(c, "db-relation-changed", `
+echo $JUJU_UNIT_NAME $JUJU_HOOK_NAME $JUJU_RELATION $JUJU_RELATION_ID $JUJU_REMOTE_UNIT $JUJU_REMOTE_APP
+echo $(pwd -P) $JUJU_CHARM_DIR $EXTRA
+readlink $(command -v config-get)
+echo failing >&2
+exit 3
+`)
+	result, err := replay.Run(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Unit, gc.Equals, "mysql/0")
+	c.Assert(result.Hook, gc.Equals, "db-relation-changed")
+	c.Assert(result.Code, gc.Equals, 3)
+	c.Assert(result.Changes, jc.DeepEquals, replay.Changes{})
+	c.Assert(result.HookTools, gc.HasLen, 0)
+
+	charmDir, err := filepath.EvalSymlinks(s.charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.stdout.String(), gc.Equals, ""+
+		"mysql/0 db-relation-changed db db:3 wordpress/0 wordpress\n"+
+		charmDir+" "+s.charmDir+" extra\n"+
+		"/bin/true\n",
+	)
+	c.Assert(s.stderr.String(), gc.Equals, "failing\n")
+}
+
+func (s *RunSuite) TestRunMissingHook(c *gc.C) {
+	_, err := replay.Run(s.config())
+	c.Assert(err, jc.Satisfies, charmrunner.IsMissingHookError)
+}
+
+func (s *RunSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.HookToolPath = ""
+	_, err := replay.Run(config)
+	c.Assert(err, gc.ErrorMatches, "empty HookToolPath not valid")
+}
@@ -64,6 +64,7 @@ type Context interface {
 	Id() string
 	HookVars(paths context.Paths, remote bool) ([]string, error)
 	ActionData() (*context.ActionData, error)
+	LogActionOutput(stream string, lines []string) error
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
@@ -75,7 +76,13 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths, remoteExecutor ExecFunc) Runner {
-	return &runner{context, paths, remoteExecutor}
+	return NewRunnerWithClock(context, paths, remoteExecutor, clock.WallClock)
+}
+
+// NewRunnerWithClock returns a Runner backed by the supplied context
+// and paths, which uses the supplied clock to time hook executions.
+func NewRunnerWithClock(context Context, paths context.Paths, remoteExecutor ExecFunc, clock clock.Clock) Runner {
+	return &runner{context, paths, remoteExecutor, clock}
 }
 
 // ExecParams holds all the necessary parameters for ExecFunc.
@@ -121,6 +128,7 @@ type runner struct {
 	paths   context.Paths
 	// remoteExecutor executes commands on a remote workload pod for CAAS.
 	remoteExecutor ExecFunc
+	clock          clock.Clock
 }
 
 func (runner *runner) Context() Context {
@@ -160,7 +168,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 			return nil, errors.Trace(err)
 		}
 	}
-	srv, err := runner.startJujucServer(token, rMode)
+	srv, err := runner.startJujucServer(token, rMode, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +313,18 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 			return errors.Trace(err)
 		}
 	}
-	srv, err := runner.startJujucServer(token, rMode)
+
+	// When the hook is being captured by "juju debug-hooks --capture",
+	// its context and the hook tools it runs are recorded as it runs.
+	debugctx := debug.NewHooksContext(runner.context.UnitName())
+	var capture *debug.Capture
+	var observeHookTool jujuc.HookToolObserver
+	if session, _ := debugctx.FindCaptureSession(); session != nil && session.MatchHook(hookName) {
+		capture = session.NewCapture(hookName, runner.clock.Now())
+		observeHookTool = recordHookTool(capture)
+	}
+
+	srv, err := runner.startJujucServer(token, rMode, observeHookTool)
 	if err != nil {
 		return err
 	}
@@ -329,7 +348,23 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 		err = runner.context.Flush(hookName, err)
 	}()
 
-	debugctx := debug.NewHooksContext(runner.context.UnitName())
+	if capture != nil {
+		capture.Env = env
+		snapshot, snapshotErr := snapshotContext(runner.context)
+		if snapshotErr != nil {
+			logger.Warningf("cannot snapshot context of %s hook: %v", hookName, snapshotErr)
+		}
+		capture.Context = snapshot
+		defer func() {
+			dir, captureErr := capture.Write(runner.clock.Now(), err)
+			if captureErr != nil {
+				logger.Warningf("cannot capture %s hook: %v", hookName, captureErr)
+				return
+			}
+			logger.Infof("captured %s hook in %s", hookName, dir)
+		}()
+	}
+
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
 		return session.RunHook(hookName, runner.paths.GetCharmDir(), env)
@@ -386,6 +421,16 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 	hook := filepath.Join(charmDir, filepath.Join(charmLocation, hookName))
 
 	var cancel chan struct{}
+	_, err := runner.context.ActionData()
+	runningAction := err == nil
+
+	// When running an action, its output is streamed as it runs.
+	var streamer *actionOutputStreamer
+	if runningAction {
+		streamer = newActionOutputStreamer(runner.context.LogActionOutput, runner.clock)
+		defer streamer.Stop()
+	}
+
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make stdout logging pipe: %v", err)
@@ -394,8 +439,10 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
 	hookOutLogger := charmrunner.NewHookLogger(outReader,
-		&loggerAdaptor{runner.getLogger(hookName)},
-		actionOut,
+		streamer.receivers(actionStdout,
+			&loggerAdaptor{runner.getLogger(hookName)},
+			actionOut,
+		)...,
 	)
 	defer hookOutLogger.Stop()
 	go hookOutLogger.Run()
@@ -404,8 +451,6 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 	// separately to pass back.
 	var actionErr = actionOut
 	var hookErrLogger *charmrunner.HookLogger
-	_, err = runner.context.ActionData()
-	runningAction := err == nil
 	if runningAction {
 		errReader, errWriter, err := os.Pipe()
 		if err != nil {
@@ -415,8 +460,10 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
 		hookErrLogger = charmrunner.NewHookLogger(errReader,
-			&loggerAdaptor{runner.getLogger(hookName)},
-			actionErr,
+			streamer.receivers(actionStderr,
+				&loggerAdaptor{runner.getLogger(hookName)},
+				actionErr,
+			)...,
 		)
 		defer hookErrLogger.Stop()
 		go hookErrLogger.Run()
@@ -439,6 +486,11 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 		},
 	)
 
+	// Send the last of the streamed output before the results.
+	hookOutLogger.Stop()
+	hookErrLogger.Stop()
+	streamer.Stop()
+
 	// If we are running an action, record stdout and stderr.
 	if runningAction && resp != nil {
 		if err := runner.updateActionResults(resp); err != nil {
@@ -458,6 +510,17 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+
+	_, err = runner.context.ActionData()
+	runningAction := err == nil
+
+	// When running an action, its output is streamed as it runs.
+	var streamer *actionOutputStreamer
+	if runningAction {
+		streamer = newActionOutputStreamer(runner.context.LogActionOutput, runner.clock)
+		defer streamer.Stop()
+	}
+
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -468,8 +531,10 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	ps.Stderr = outWriter
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
 	hookOutLogger := charmrunner.NewHookLogger(outReader,
-		&loggerAdaptor{runner.getLogger(hookName)},
-		actionOut,
+		streamer.receivers(actionStdout,
+			&loggerAdaptor{runner.getLogger(hookName)},
+			actionOut,
+		)...,
 	)
 	go hookOutLogger.Run()
 	defer hookOutLogger.Stop()
@@ -478,8 +543,6 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	// separately to pass back.
 	var actionErr io.Reader
 	var hookErrLogger *charmrunner.HookLogger
-	_, err = runner.context.ActionData()
-	runningAction := err == nil
 	if runningAction {
 		errReader, errWriter, err := os.Pipe()
 		if err != nil {
@@ -491,8 +554,10 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 		errBuf := &bufferAdaptor{ReadWriter: errWriter}
 		actionErr = errBuf
 		hookErrLogger = charmrunner.NewHookLogger(errReader,
-			&loggerAdaptor{runner.getLogger(hookName)},
-			errBuf,
+			streamer.receivers(actionStderr,
+				&loggerAdaptor{runner.getLogger(hookName)},
+				errBuf,
+			)...,
 		)
 		defer hookErrLogger.Stop()
 		go hookErrLogger.Run()
@@ -507,9 +572,11 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 		exitErr = ps.Wait()
 	}
 	// Ensure hook loggers are stopped before reading stdout/stderr
-	// so all the output is captured.
+	// so all the output is captured, and the last of the streamed
+	// output is sent before the results.
 	hookOutLogger.Stop()
 	hookErrLogger.Stop()
+	streamer.Stop()
 
 	// If we are running an action, record stdout and stderr.
 	if runningAction {
@@ -543,7 +610,7 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	return errors.Trace(exitErr)
 }
 
-func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Server, error) {
+func (runner *runner) startJujucServer(token string, rMode runMode, observe jujuc.HookToolObserver) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
@@ -554,7 +621,7 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 
 	socket := runner.paths.GetJujucServerSocket(rMode == runOnRemote)
 	logger.Debugf("starting jujuc server %s %v", token, socket)
-	srv, err := jujuc.NewServer(getCmd, socket, token)
+	srv, err := jujuc.NewObservedServer(getCmd, socket, token, observe)
 	if err != nil {
 		return nil, errors.Annotate(err, "starting jujuc server")
 	}
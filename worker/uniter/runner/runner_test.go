@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -159,6 +160,19 @@ type MockContext struct {
 	flushFailure    error
 	flushResult     error
 	modelType       model.ModelType
+
+	mu       sync.Mutex
+	streamed map[string][]string
+}
+
+func (ctx *MockContext) LogActionOutput(stream string, lines []string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.streamed == nil {
+		ctx.streamed = make(map[string][]string)
+	}
+	ctx.streamed[stream] = append(ctx.streamed[stream], lines...)
+	return nil
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -285,6 +299,10 @@ func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	c.Assert(ctx.actionResults, jc.DeepEquals, map[string]interface{}{
 		"Code": "0", "Stderr": "world\n", "Stdout": "hello\n",
 	})
+	c.Assert(ctx.streamed, jc.DeepEquals, map[string][]string{
+		"stdout": {"hello"},
+		"stderr": {"world"},
+	})
 }
 
 func (s *RunMockContextSuite) TestRunActionFlushCharmActionsCAASSuccess(c *gc.C) {
@@ -118,6 +118,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.paths,
 		s.contextFactory,
 		nil,
+		testclock.NewClock(time.Time{}),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
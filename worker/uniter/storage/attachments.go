@@ -42,11 +42,27 @@ type StorageAccessor interface {
 	// with the specified unit and storage tags. This method is only
 	// expected to succeed if the storage attachment is Dying.
 	RemoveStorageAttachment(names.StorageTag, names.UnitTag) error
+
+	// CompleteStorageMove completes the move of the storage
+	// attachment with the specified unit and storage tags to
+	// another storage pool, once its contents have been copied
+	// to the given target location.
+	CompleteStorageMove(names.StorageTag, names.UnitTag, string) error
+
+	// SetStorageMoveProgress records the number of bytes of the
+	// storage attachment with the specified unit and storage tags
+	// that have been copied to the given target location.
+	SetStorageMoveProgress(names.StorageTag, names.UnitTag, string, uint64) error
 }
 
 type storageAttachment struct {
 	*stateFile
 	jujuc.ContextStorageAttachment
+
+	// remoteSize is the size of the storage most recently reported
+	// by the controller, which is recorded in the state file when
+	// the next storage hook is committed.
+	remoteSize uint64
 }
 
 // Attachments generates storage hooks in response to changes to
@@ -137,6 +153,7 @@ func (a *Attachments) init() error {
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
 			},
+			attachment.Size,
 		}
 	}
 	for storageTag := range attachmentsByTag {
@@ -216,6 +233,17 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 	switch hi.Kind {
 	case hooks.StorageAttached:
 		a.pending.Remove(storageTag)
+		if err := a.recordSize(storageTag); err != nil {
+			return errors.Trace(err)
+		}
+	case hook.StorageResized:
+		if err := a.recordSize(storageTag); err != nil {
+			return errors.Trace(err)
+		}
+	case hook.StorageMoving:
+		if err := a.completeMove(storageTag, hi.StorageMoveTarget); err != nil {
+			return errors.Trace(err)
+		}
 	case hooks.StorageDetaching:
 		if err := a.removeStorageAttachment(storageTag); err != nil {
 			return errors.Trace(err)
@@ -224,6 +252,82 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 	return nil
 }
 
+// recordSize records the size of the storage most recently reported
+// by the controller in the storage attachment's state file, so that
+// the storage-resized hook is not run again for the same size.
+func (a *Attachments) recordSize(tag names.StorageTag) error {
+	attachment, ok := a.storageAttachments[tag]
+	if !ok || attachment.remoteSize <= attachment.size {
+		return nil
+	}
+	return attachment.SetSize(attachment.remoteSize)
+}
+
+// completeMove informs the controller that the contents of the
+// specified storage have been copied to the given target location,
+// so that the storage can be swapped over to the target.
+func (a *Attachments) completeMove(tag names.StorageTag, target string) error {
+	err := a.st.CompleteStorageMove(tag, a.unitTag, target)
+	if params.IsCodeNotFound(err) {
+		// The move was cancelled, or restarted with a different
+		// target, while the contents were being copied.
+		logger.Infof("move of storage %q to %q no longer in progress", tag.Id(), target)
+	} else if err != nil {
+		return errors.Annotate(err, "completing storage move")
+	} else if attachment, ok := a.storageAttachments[tag]; ok {
+		// Hooks must see the new location of the storage from
+		// now on, even before the controller reports it.
+		a.storageAttachments[tag] = storageAttachment{
+			attachment.stateFile, &contextStorage{
+				tag:      tag,
+				kind:     attachment.Kind(),
+				location: target,
+			},
+			attachment.remoteSize,
+		}
+	}
+	return nil
+}
+
+// CopyStorage copies the contents of the storage being moved by the
+// given storage-moving hook to the hook's move target. It is used
+// when the charm does not implement the hook itself.
+//
+// The progress of the copy is recorded by the controller, so that a
+// copy interrupted by the unit agent restarting resumes from where it
+// left off.
+func (a *Attachments) CopyStorage(hi hook.Info) error {
+	if hi.Kind != hook.StorageMoving {
+		return errors.Errorf("not a storage-moving hook: %#v", hi)
+	}
+	tag := names.NewStorageTag(hi.StorageId)
+	attachment, ok := a.storageAttachments[tag]
+	if !ok {
+		return errors.Errorf("unknown storage %q", hi.StorageId)
+	}
+	remote, err := a.st.StorageAttachment(tag, a.unitTag)
+	if err != nil {
+		return errors.Annotatef(err, "querying storage attachment %q", tag.Id())
+	}
+	if remote.MoveTarget != hi.StorageMoveTarget {
+		// The move was cancelled, or restarted with a different
+		// target, since the hook was queued.
+		logger.Infof("move of storage %q to %q no longer in progress", tag.Id(), hi.StorageMoveTarget)
+		return nil
+	}
+	logger.Infof(
+		"copying storage %q from %q to %q, starting at byte %d",
+		hi.StorageId, attachment.Location(), hi.StorageMoveTarget, remote.MoveCopied,
+	)
+	setProgress := func(copied uint64) error {
+		return a.st.SetStorageMoveProgress(tag, a.unitTag, hi.StorageMoveTarget, copied)
+	}
+	return copyStorage(
+		attachment.Kind(), attachment.Location(), hi.StorageMoveTarget,
+		remote.MoveCopied, setProgress,
+	)
+}
+
 func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 	if err := a.st.RemoveStorageAttachment(tag, a.unitTag); err != nil {
 		return errors.Annotate(err, "removing storage attachment")
@@ -234,7 +338,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
@@ -4,6 +4,7 @@
 package storage_test
 
 import (
+	"io"
 	"io/ioutil"
 	"path/filepath"
 
@@ -334,6 +335,422 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(removed, jc.IsTrue)
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageResized(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind:      operation.Continue,
+		Installed: true,
+	}}
+	remoteState := func(size uint64) remotestate.Snapshot {
+		return remotestate.Snapshot{
+			Life: params.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     params.Alive,
+					Location: "/dev/sdb",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}
+	}
+	op, err := r.NextOp(localState, remoteState(1024), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The size reported when the storage was attached is recorded,
+	// so storage-resized is not run until the storage grows.
+	stateFile := filepath.Join(stateDir, "data-0")
+	data, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+	_, err = r.NextOp(localState, remoteState(1024), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err = r.NextOp(localState, remoteState(2048), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+	err = att.ValidateHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = att.CommitHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err = ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 2048\n")
+
+	_, err = r.NextOp(localState, remoteState(2048), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *attachmentsSuite) TestAttachmentsStorageMoving(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	var completed []string
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+		completeMove: func(s names.StorageTag, u names.UnitTag, target string) error {
+			c.Assert(s, gc.Equals, storageTag)
+			c.Assert(u, gc.Equals, unitTag)
+			completed = append(completed, target)
+			if target == "/dev/sdd" {
+				return &params.Error{Code: params.CodeNotFound}
+			}
+			return nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind:      operation.Continue,
+		Installed: true,
+	}}
+	remoteState := func(moveTarget string) remotestate.Snapshot {
+		return remotestate.Snapshot{
+			Life: params.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:       params.StorageKindBlock,
+					Life:       params.Alive,
+					Location:   "/dev/sdb",
+					Attached:   true,
+					MoveTarget: moveTarget,
+				},
+			},
+		}
+	}
+	op, err := r.NextOp(localState, remoteState(""), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, err = r.NextOp(localState, remoteState("/dev/sdc"), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-moving")
+	hi := hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	}
+	err = att.ValidateHook(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	err = att.CommitHook(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, jc.DeepEquals, []string{"/dev/sdc"})
+
+	// Hooks see the new location of the storage immediately.
+	ctx, err := att.Storage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.Location(), gc.Equals, "/dev/sdc")
+
+	// The hook is not run again for the same move.
+	_, err = r.NextOp(localState, remoteState("/dev/sdc"), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// A move that is no longer in progress when the hook
+	// completes is not treated as an error.
+	hi.StorageMoveTarget = "/dev/sdd"
+	err = att.CommitHook(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, jc.DeepEquals, []string{"/dev/sdc", "/dev/sdd"})
+	ctx, err = att.Storage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.Location(), gc.Equals, "/dev/sdc")
+}
+
+func (s *attachmentsSuite) TestCopyStorage(c *gc.C) {
+	var opened []string
+	s.PatchValue(storage.OpenExclusive, func(path string) (io.Closer, error) {
+		opened = append(opened, path)
+		return ioutil.NopCloser(nil), nil
+	})
+	var commands [][]string
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		commands = append(commands, append([]string{cmd}, args...))
+		if cmd == "blockdev" {
+			return "2147487744\n", nil
+		}
+		return "", nil
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	var progress []uint64
+	att := s.attachedStorage(c, storageTag, params.StorageKindBlock, "/dev/sdb", "/dev/sdc", 0, &progress)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(opened, jc.DeepEquals, []string{"/dev/sdb"})
+	c.Assert(commands, jc.DeepEquals, [][]string{
+		{"blockdev", "--getsize64", "/dev/sdb"},
+		{"dd", "if=/dev/sdb", "of=/dev/sdc", "bs=4M", "iflag=skip_bytes,count_bytes", "oflag=seek_bytes",
+			"skip=0", "seek=0", "count=1073741824", "conv=notrunc,fsync"},
+		{"dd", "if=/dev/sdb", "of=/dev/sdc", "bs=4M", "iflag=skip_bytes,count_bytes", "oflag=seek_bytes",
+			"skip=1073741824", "seek=1073741824", "count=1073741824", "conv=notrunc,fsync"},
+		{"dd", "if=/dev/sdb", "of=/dev/sdc", "bs=4M", "iflag=skip_bytes,count_bytes", "oflag=seek_bytes",
+			"skip=2147483648", "seek=2147483648", "count=4096", "conv=notrunc,fsync"},
+		{"cmp", "-n", "2147487744", "/dev/sdb", "/dev/sdc"},
+	})
+	c.Assert(progress, jc.DeepEquals, []uint64{1073741824, 2147483648, 2147487744})
+}
+
+func (s *attachmentsSuite) TestCopyStorageResume(c *gc.C) {
+	s.PatchValue(storage.OpenExclusive, func(path string) (io.Closer, error) {
+		return ioutil.NopCloser(nil), nil
+	})
+	var commands [][]string
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		commands = append(commands, append([]string{cmd}, args...))
+		if cmd == "blockdev" {
+			return "2147483648\n", nil
+		}
+		return "", nil
+	})
+
+	// The first chunk was copied before the unit agent restarted.
+	storageTag := names.NewStorageTag("data/0")
+	var progress []uint64
+	att := s.attachedStorage(c, storageTag, params.StorageKindBlock, "/dev/sdb", "/dev/sdc", 1073741824, &progress)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(commands, jc.DeepEquals, [][]string{
+		{"blockdev", "--getsize64", "/dev/sdb"},
+		{"dd", "if=/dev/sdb", "of=/dev/sdc", "bs=4M", "iflag=skip_bytes,count_bytes", "oflag=seek_bytes",
+			"skip=1073741824", "seek=1073741824", "count=1073741824", "conv=notrunc,fsync"},
+		{"cmp", "-n", "2147483648", "/dev/sdb", "/dev/sdc"},
+	})
+	c.Assert(progress, jc.DeepEquals, []uint64{2147483648})
+}
+
+func (s *attachmentsSuite) TestCopyStorageMoveNotInProgress(c *gc.C) {
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		c.Fatalf("unexpected command %q", cmd)
+		return "", nil
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	att := s.attachedStorage(c, storageTag, params.StorageKindBlock, "/dev/sdb", "/dev/sdd", 0, nil)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *attachmentsSuite) TestCopyStorageFilesystem(c *gc.C) {
+	var commands [][]string
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		commands = append(commands, append([]string{cmd}, args...))
+		return "", nil
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	att := s.attachedStorage(c, storageTag, params.StorageKindFilesystem, "/srv/data", "/srv/data-new", 0, nil)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/srv/data-new",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(commands, jc.DeepEquals, [][]string{
+		{"rsync", "-aHAX", "--delete", "/srv/data/", "/srv/data-new"},
+		{"rsync", "-aHAX", "--delete", "--dry-run", "--checksum", "--itemize-changes", "/srv/data/", "/srv/data-new"},
+	})
+}
+
+func (s *attachmentsSuite) TestCopyStorageFilesystemVerifyError(c *gc.C) {
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		if args[len(args)-3] == "--itemize-changes" {
+			return ">fc.t...... db/table\n", nil
+		}
+		return "", nil
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	att := s.attachedStorage(c, storageTag, params.StorageKindFilesystem, "/srv/data", "/srv/data-new", 0, nil)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/srv/data-new",
+	})
+	c.Assert(err, gc.ErrorMatches, `(?s)verifying copy of "/srv/data/" to "/srv/data-new": contents differ:.*db/table`)
+}
+
+func (s *attachmentsSuite) TestCopyStorageInUse(c *gc.C) {
+	s.PatchValue(storage.OpenExclusive, func(path string) (io.Closer, error) {
+		return nil, errors.New("device or resource busy")
+	})
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		c.Fatalf("unexpected command %q", cmd)
+		return "", nil
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	att := s.attachedStorage(c, storageTag, params.StorageKindBlock, "/dev/sdb", "/dev/sdc", 0, nil)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot copy "/dev/sdb": the device must be unmounted and not in use: device or resource busy`)
+}
+
+func (s *attachmentsSuite) TestCopyStorageError(c *gc.C) {
+	s.PatchValue(storage.OpenExclusive, func(path string) (io.Closer, error) {
+		return ioutil.NopCloser(nil), nil
+	})
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		if cmd == "blockdev" {
+			return "1073741824\n", nil
+		}
+		return "", errors.New("no space left on device")
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	var progress []uint64
+	att := s.attachedStorage(c, storageTag, params.StorageKindBlock, "/dev/sdb", "/dev/sdc", 0, &progress)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, gc.ErrorMatches, `copying "/dev/sdb" to "/dev/sdc": no space left on device`)
+	c.Assert(progress, gc.HasLen, 0)
+}
+
+func (s *attachmentsSuite) TestCopyStorageVerifyError(c *gc.C) {
+	s.PatchValue(storage.OpenExclusive, func(path string) (io.Closer, error) {
+		return ioutil.NopCloser(nil), nil
+	})
+	s.PatchValue(storage.RunCommand, func(cmd string, args ...string) (string, error) {
+		switch cmd {
+		case "blockdev":
+			return "1073741824\n", nil
+		case "cmp":
+			return "", errors.New("/dev/sdb /dev/sdc differ: byte 1, line 1")
+		}
+		return "", nil
+	})
+
+	storageTag := names.NewStorageTag("data/0")
+	var progress []uint64
+	att := s.attachedStorage(c, storageTag, params.StorageKindBlock, "/dev/sdb", "/dev/sdc", 0, &progress)
+	err := att.CopyStorage(hook.Info{
+		Kind:              hook.StorageMoving,
+		StorageId:         storageTag.Id(),
+		StorageMoveTarget: "/dev/sdc",
+	})
+	c.Assert(err, gc.ErrorMatches, `verifying copy of "/dev/sdb" to "/dev/sdc": .* differ: .*`)
+
+	// The copy starts again from scratch next time.
+	c.Assert(progress, jc.DeepEquals, []uint64{1073741824, 0})
+}
+
+// attachedStorage returns storage.Attachments with the specified
+// storage attached at the given location, and being moved to the
+// given target with the given number of bytes already copied. The
+// copy progress recorded is appended to progress, if non-nil.
+func (s *attachmentsSuite) attachedStorage(
+	c *gc.C, storageTag names.StorageTag, kind params.StorageKind, location string,
+	moveTarget string, moveCopied uint64, progress *[]uint64,
+) *storage.Attachments {
+	unitTag := names.NewUnitTag("mysql/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			c.Assert(s, gc.Equals, storageTag)
+			c.Assert(u, gc.Equals, unitTag)
+			return params.StorageAttachment{
+				Kind:       kind,
+				Location:   location,
+				Life:       params.Alive,
+				MoveTarget: moveTarget,
+				MoveCopied: moveCopied,
+			}, nil
+		},
+		setMoveProgress: func(s names.StorageTag, u names.UnitTag, target string, copied uint64) error {
+			c.Assert(s, gc.Equals, storageTag)
+			c.Assert(u, gc.Equals, unitTag)
+			c.Assert(target, gc.Equals, moveTarget)
+			*progress = append(*progress, copied)
+			return nil
+		},
+	}
+	att, err := storage.NewAttachments(st, unitTag, c.MkDir(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+	_, err = r.NextOp(resolver.LocalState{State: operation.State{
+		Kind:      operation.Continue,
+		Installed: true,
+	}}, remotestate.Snapshot{
+		Life: params.Alive,
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: {
+				Kind:     kind,
+				Life:     params.Alive,
+				Location: location,
+				Attached: true,
+			},
+		},
+	}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return att
+}
+
 func (s *attachmentsSuite) TestAttachmentsSetDying(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
@@ -0,0 +1,149 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+// runCommand runs the given command, returning its output, or an
+// error annotated with the command's output if it fails.
+var runCommand = func(cmd string, args ...string) (string, error) {
+	logger.Debugf("running: %s %s", cmd, strings.Join(args, " "))
+	output, err := exec.Command(cmd, args...).CombinedOutput()
+	if err != nil {
+		if out := strings.TrimSpace(string(output)); out != "" {
+			err = errors.Annotate(err, out)
+		}
+	}
+	return string(output), err
+}
+
+// openExclusive opens the block device at the given path exclusively.
+// This fails if the device is mounted, or held by another exclusive
+// user such as device mapper, and prevents the device from being
+// mounted until the returned closer is closed.
+var openExclusive = func(path string) (io.Closer, error) {
+	return os.OpenFile(path, os.O_RDONLY|os.O_EXCL, 0)
+}
+
+// copyChunkSize is the number of bytes of a block device that are
+// copied between recording the progress of the copy.
+const copyChunkSize = 1 << 30
+
+// copyStorage copies the contents of storage of the given kind from
+// the source location to the target location, and verifies that the
+// target holds an identical copy. The copy starts from the given
+// number of bytes already copied, and setProgress is called to record
+// progress as the copy proceeds.
+func copyStorage(
+	kind storage.StorageKind,
+	source, target string,
+	copied uint64,
+	setProgress func(uint64) error,
+) error {
+	switch kind {
+	case storage.StorageKindBlock:
+		return copyBlockDevice(source, target, copied, setProgress)
+	case storage.StorageKindFilesystem:
+		return copyFilesystem(source, target)
+	}
+	return errors.NotSupportedf("copying %s storage", kind)
+}
+
+// copyBlockDevice copies the block device at the source path to the
+// target path with dd, a chunk at a time, recording the number of bytes
+// copied after each chunk.
+//
+// Only block devices that are not in use may be copied, to avoid
+// copying the device while it is being written to; the device is held
+// open exclusively for the duration of the copy.
+func copyBlockDevice(source, target string, copied uint64, setProgress func(uint64) error) error {
+	device, err := openExclusive(source)
+	if err != nil {
+		return errors.Annotatef(
+			err, "cannot copy %q: the device must be unmounted and not in use", source,
+		)
+	}
+	defer device.Close()
+
+	// The target may be larger than the source, so only copy
+	// and compare the extent of the source device.
+	out, err := runCommand("blockdev", "--getsize64", source)
+	if err != nil {
+		return errors.Annotatef(err, "getting size of %q", source)
+	}
+	size, err := strconv.ParseUint(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return errors.Annotatef(err, "getting size of %q", source)
+	}
+	for copied < size {
+		count := size - copied
+		if count > copyChunkSize {
+			count = copyChunkSize
+		}
+		if _, err := runCommand(
+			"dd", "if="+source, "of="+target, "bs=4M",
+			"iflag=skip_bytes,count_bytes", "oflag=seek_bytes",
+			fmt.Sprintf("skip=%d", copied),
+			fmt.Sprintf("seek=%d", copied),
+			fmt.Sprintf("count=%d", count),
+			"conv=notrunc,fsync",
+		); err != nil {
+			return errors.Annotatef(err, "copying %q to %q", source, target)
+		}
+		copied += count
+		if err := setProgress(copied); err != nil {
+			return errors.Annotate(err, "recording copy progress")
+		}
+	}
+
+	if _, err := runCommand(
+		"cmp", "-n", strconv.FormatUint(size, 10), source, target,
+	); err != nil {
+		// Start the copy again from scratch next time.
+		if err := setProgress(0); err != nil {
+			logger.Warningf("cannot reset copy progress: %v", err)
+		}
+		return errors.Annotatef(err, "verifying copy of %q to %q", source, target)
+	}
+	return nil
+}
+
+// copyFilesystem copies the contents of the filesystem mounted at the
+// source path to the filesystem mounted at the target path with rsync.
+// rsync only transfers the files that differ between the two, so an
+// interrupted copy picks up from where it left off when run again.
+func copyFilesystem(source, target string) error {
+	// The trailing slash copies the contents of the
+	// source, rather than the directory itself.
+	source = strings.TrimSuffix(source, "/") + "/"
+	if _, err := runCommand(
+		"rsync", "-aHAX", "--delete", source, target,
+	); err != nil {
+		return errors.Annotatef(err, "copying %q to %q", source, target)
+	}
+
+	// Compare the checksums of every file; rsync lists
+	// any file that would need to be transferred again.
+	out, err := runCommand(
+		"rsync", "-aHAX", "--delete", "--dry-run", "--checksum", "--itemize-changes", source, target,
+	)
+	if err != nil {
+		return errors.Annotatef(err, "verifying copy of %q to %q", source, target)
+	}
+	if out = strings.TrimSpace(out); out != "" {
+		return errors.Errorf("verifying copy of %q to %q: contents differ:\n%s", source, target, out)
+	}
+	return nil
+}
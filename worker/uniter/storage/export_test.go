@@ -9,6 +9,11 @@ import (
 	"github.com/juju/juju/worker/uniter/hook"
 )
 
+var (
+	RunCommand    = &runCommand
+	OpenExclusive = &openExclusive
+)
+
 type State interface {
 	hook.Committer
 	hook.Validator
@@ -18,8 +23,16 @@ func StateAttached(s State) bool {
 	return s.(*stateFile).attached
 }
 
+func StateSize(s State) uint64 {
+	return s.(*stateFile).size
+}
+
+func SetStateSize(s State, size uint64) error {
+	return s.(*stateFile).SetSize(size)
+}
+
 func ValidateHook(tag names.StorageTag, attached bool, hi hook.Info) error {
-	st := &state{tag, attached}
+	st := &state{storage: tag, attached: attached}
 	return st.ValidateHook(hi)
 }
 
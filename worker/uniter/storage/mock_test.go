@@ -18,6 +18,8 @@ type mockStorageAccessor struct {
 	unitStorageAttachments        func(names.UnitTag) ([]params.StorageAttachmentId, error)
 	destroyUnitStorageAttachments func(names.UnitTag) error
 	remove                        func(names.StorageTag, names.UnitTag) error
+	completeMove                  func(names.StorageTag, names.UnitTag, string) error
+	setMoveProgress               func(names.StorageTag, names.UnitTag, string, uint64) error
 }
 
 func (m *mockStorageAccessor) StorageAttachment(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
@@ -36,6 +38,14 @@ func (m *mockStorageAccessor) RemoveStorageAttachment(s names.StorageTag, u name
 	return m.remove(s, u)
 }
 
+func (m *mockStorageAccessor) CompleteStorageMove(s names.StorageTag, u names.UnitTag, target string) error {
+	return m.completeMove(s, u, target)
+}
+
+func (m *mockStorageAccessor) SetStorageMoveProgress(s names.StorageTag, u names.UnitTag, target string, copied uint64) error {
+	return m.setMoveProgress(s, u, target, copied)
+}
+
 type mockOperations struct {
 	operation.Factory
 }
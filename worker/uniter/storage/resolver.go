@@ -144,8 +144,18 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, moves and growth.
+			// The storage is swapped over to the move target as
+			// soon as the move is completed, so a move target that
+			// matches the storage location has already been moved to.
+			if snap.MoveTarget != "" && snap.MoveTarget != storageAttachment.Location() {
+				return opFactory.NewRunHook(hook.Info{
+					Kind:              hook.StorageMoving,
+					StorageId:         tag.Id(),
+					StorageMoveTarget: snap.MoveTarget,
+				})
+			}
+			return s.nextResizedHookOp(tag, storageAttachment, snap, opFactory)
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -184,7 +194,43 @@ func (s *storageResolver) nextHookOp(
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
 		},
+		snap.Size,
 	}
 
 	return opFactory.NewRunHook(hookInfo)
 }
+
+// nextResizedHookOp returns an operation to run the "storage-resized"
+// hook if the attached storage has grown since its size was last
+// reported to the charm.
+func (s *storageResolver) nextResizedHookOp(
+	tag names.StorageTag,
+	attachment storageAttachment,
+	snap remotestate.StorageSnapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if snap.Size <= attachment.size {
+		return nil, resolver.ErrNoOperation
+	}
+	if attachment.size == 0 {
+		// The size of the storage was not known when the
+		// storage was attached, so there is no change to
+		// report; just record the size.
+		if err := attachment.SetSize(snap.Size); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, resolver.ErrNoOperation
+	}
+	s.storage.storageAttachments[tag] = storageAttachment{
+		attachment.stateFile, &contextStorage{
+			tag:      tag,
+			kind:     storage.StorageKind(snap.Kind),
+			location: snap.Location,
+		},
+		snap.Size,
+	}
+	return opFactory.NewRunHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: tag.Id(),
+	})
+}
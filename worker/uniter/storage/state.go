@@ -25,6 +25,10 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage, in MiB, last
+	// reported to the charm. Zero means the size is unknown.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -41,7 +45,7 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if s.attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized, hook.StorageMoving:
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
@@ -85,6 +89,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -136,7 +141,7 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 		return d.Remove()
 	}
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{&attached, d.state.size}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
@@ -145,6 +150,23 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 	return nil
 }
 
+// SetSize atomically writes to disk the size of the storage, as last
+// reported to the charm. The storage must already be attached.
+func (d *stateFile) SetSize(size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "failed to write size for %q on state directory", d.storage.Id())
+	if !d.state.attached {
+		return errors.New("storage not attached")
+	}
+	attached := true
+	di := diskInfo{&attached, size}
+	if err := utils.WriteYaml(d.path, &di); err != nil {
+		return err
+	}
+	// If write was successful, update own state.
+	d.state.size = size
+	return nil
+}
+
 // Remove removes the directory if it exists and is empty.
 func (d *stateFile) Remove() error {
 	if err := os.Remove(d.path); err != nil && !os.IsNotExist(err) {
@@ -157,5 +179,6 @@ func (d *stateFile) Remove() error {
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
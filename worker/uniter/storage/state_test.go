@@ -197,4 +197,42 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidates(true, hooks.StorageDetaching)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidates(true, hook.StorageResized)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
+	assertValidates(true, hook.StorageMoving)
+	assertValidateFails(false, hook.StorageMoving, `inappropriate "storage-moving" hook for storage "data/0": storage not attached`)
+}
+
+func (s *stateSuite) TestSetSize(c *gc.C) {
+	dir := c.MkDir()
+	state, err := storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The size may only be recorded once the storage is attached.
+	err = storage.SetStateSize(state, 1024)
+	c.Assert(err, gc.ErrorMatches, `failed to write size for "data/0" on state directory: storage not attached`)
+
+	err = state.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: "data-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = storage.SetStateSize(state, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "data-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+
+	// The size is preserved across reads and hook commits.
+	state, err = storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+	err = state.CommitHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: "data-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
 	"github.com/juju/utils/exec"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
@@ -118,6 +119,11 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// prometheusRegisterer, if set, is used to export metrics about
+	// the hooks run by the uniter.
+	prometheusRegisterer prometheus.Registerer
+	hookRecorder         *hookRecorder
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -139,6 +145,7 @@ type UniterParams struct {
 	Clock                   clock.Clock
 	ApplicationChannel      watcher.NotifyChannel
 	SocketConfig            *SocketConfig
+	PrometheusRegisterer    prometheus.Registerer
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -197,6 +204,7 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		downloader:              uniterParams.Downloader,
 		applicationChannel:      uniterParams.ApplicationChannel,
 		runListener:             uniterParams.RunListener,
+		prometheusRegisterer:    uniterParams.PrometheusRegisterer,
 	}
 	startFunc := func() (worker.Worker, error) {
 		plan := catacomb.Plan{
@@ -233,6 +241,16 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 	}
 	logger.Infof("unit %q started", u.unit)
+	if u.prometheusRegisterer != nil {
+		if err := u.prometheusRegisterer.Register(u.hookRecorder); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				return errors.Annotate(err, "registering hook metrics")
+			}
+			logger.Debugf("hook metrics for %q already registered", u.unit)
+		} else {
+			defer u.prometheusRegisterer.Unregister(u.hookRecorder)
+		}
+	}
 
 	// Install is a special case, as it must run before there
 	// is any remote state, and before the remote state watcher
@@ -272,6 +290,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	)
 
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
+	retryPolicy := hookRetryPolicy{
+		maxRetries: u.hookRetryStrategy.MaxRetries,
+		hooks:      u.hookRetryStrategy.RetryHooks,
+		hookName:   u.hookName,
+	}
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
 	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
@@ -356,6 +379,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			ClearResolved:       clearResolved,
 			ReportHookError:     u.reportHookError,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
+			ShouldRetryHook:     retryPolicy.shouldRetry,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
 			Actions:             actions.NewResolver(),
@@ -392,6 +416,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				Abort:         u.catacomb.Dying(),
 				OnIdle:        onIdle,
 				CharmDirGuard: u.charmDirGuard,
+				Clock:         u.clock,
 			}, &localState)
 
 			err = u.translateResolverErr(err)
@@ -587,17 +612,20 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		remoteExecutor = u.newRemoteRunnerExecutor(u.unit, u.paths)
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory, remoteExecutor,
+		u.st, u.paths, contextFactory, remoteExecutor, u.clock,
 	)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	u.hookRecorder = newHookRecorder(unitTag.Id(), u.unit)
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		Clock:          u.clock,
+		HookRecorder:   u.hookRecorder,
 	})
 
 	charmURL, err := u.getApplicationCharmURL()
@@ -698,7 +726,7 @@ func (u *Uniter) acquireExecutionLock(action string) (func(), error) {
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+func (u *Uniter) reportHookError(hookInfo hook.Info, retries int) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
@@ -717,5 +745,13 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if retries > 0 {
+		statusData["retries"] = retries
+		if maxRetries := u.hookRetryStrategy.MaxRetries; maxRetries > 0 {
+			statusMessage += fmt.Sprintf(" (retries: %d/%d)", retries, maxRetries)
+		} else {
+			statusMessage += fmt.Sprintf(" (retries: %d)", retries)
+		}
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }